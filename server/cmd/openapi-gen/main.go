@@ -0,0 +1,46 @@
+// openapi-gen 从 HTTP 处理器的 swag 风格注释生成接口说明表
+//
+// 用法（在处理器包目录下，通常通过 go generate 调用）：
+//
+//	go run github.com/easyspace-ai/luckdb/server/cmd/openapi-gen -var routeDocs
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/openapi"
+)
+
+func main() {
+	var (
+		dir     = flag.String("dir", ".", "处理器包目录")
+		varName = flag.String("var", "routeDocs", "生成的变量名")
+		strict  = flag.Bool("strict", false, "存在无法解析的注释时失败")
+	)
+	flag.Parse()
+
+	generator, err := openapi.NewGenerator(*dir)
+	if err != nil {
+		log.Fatalf("openapi-gen: %v", err)
+	}
+	source, err := generator.Generate(*varName)
+	if err != nil {
+		log.Fatalf("openapi-gen: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		fmt.Fprintf(os.Stderr, "openapi-gen: %s\n", warning)
+	}
+	if *strict && len(generator.Warnings()) > 0 {
+		os.Exit(1)
+	}
+
+	output := filepath.Join(*dir, openapi.GeneratedFile)
+	if err := os.WriteFile(output, source, 0o644); err != nil {
+		log.Fatalf("openapi-gen: %v", err)
+	}
+}
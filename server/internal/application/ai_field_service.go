@@ -0,0 +1,682 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	aiDomain "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/cache"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
+	"github.com/easyspace-ai/luckdb/server/pkg/database"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// AI 字段批量生成参数
+const (
+	aiGenerateBatchSize = 100              // 每批处理的记录数
+	aiGenerateWorkers   = 4                // 每批并发生成数（提供者自身另有限流）
+	aiResultCacheSize   = 10000            // 生成结果缓存条数
+	aiResultCacheTTL    = 24 * time.Hour   // 生成结果缓存时间
+	aiBackgroundTimeout = 10 * time.Minute // 后台生成的超时时间
+	aiJobRetention      = time.Hour        // 已结束的后台任务保留多久供查询
+)
+
+// AIFieldService AI 字段生成服务 ✨
+//
+// 设计考量：
+//   - 提示词中的 {字段名} 替换为记录中该字段的值，渲染结果与提供者、模型、参数一起计算输入摘要
+//   - 相同输入摘要的生成结果缓存复用，重复内容不会重复调用模型
+//   - 按批读取记录，批内并发调用提供者；请求/token 限流由提供者实现
+//   - token 用量按 Base 累计，可按日期查询
+//   - 记录新建时生成空值；记录更新时只重新生成提示词引用了变更字段的 AI 字段
+type AIFieldService struct {
+	fieldRepo     repository.FieldRepository
+	recordRepo    recordRepo.RecordRepository
+	tableRepo     tableRepo.TableRepository
+	providers     aiDomain.ProviderRegistry
+	usageRepo     aiDomain.UsageRepository
+	cache         *cache.LRUCache
+	recordService *RecordService        // ✨ 生成结果写入后推送记录更新
+	permission    *PermissionServiceV2  // ✨ 表的编辑权限、Base 的访问权限
+	rowPermission *RowPermissionService // ✨ 只生成用户可编辑的行
+
+	jobsMu sync.Mutex
+	jobs   map[string]*aiJobEntry // 后台生成任务
+}
+
+// aiJobEntry 后台生成任务及其所属表（查询任务时校验权限）
+type aiJobEntry struct {
+	tableID string
+	status  *dto.AIGenerateJob
+}
+
+// NewAIFieldService 创建 AI 字段生成服务
+func NewAIFieldService(
+	fieldRepo repository.FieldRepository,
+	recordRepo recordRepo.RecordRepository,
+	tableRepo tableRepo.TableRepository,
+	providers aiDomain.ProviderRegistry,
+	usageRepo aiDomain.UsageRepository,
+) *AIFieldService {
+	return &AIFieldService{
+		fieldRepo:  fieldRepo,
+		recordRepo: recordRepo,
+		tableRepo:  tableRepo,
+		providers:  providers,
+		usageRepo:  usageRepo,
+		cache:      cache.NewLRUCache(aiResultCacheSize, nil),
+		jobs:       make(map[string]*aiJobEntry),
+	}
+}
+
+// SetRecordService 设置记录服务（用于延迟注入）
+func (s *AIFieldService) SetRecordService(recordService *RecordService) {
+	s.recordService = recordService
+}
+
+// SetPermissionService 设置权限服务（用于延迟注入）
+func (s *AIFieldService) SetPermissionService(permission *PermissionServiceV2) {
+	s.permission = permission
+}
+
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (s *AIFieldService) SetRowPermissionService(rowPermission *RowPermissionService) {
+	s.rowPermission = rowPermission
+}
+
+// aiGenerateJob 一次 AI 字段生成任务的上下文
+type aiGenerateJob struct {
+	fieldID    string
+	tableID    string
+	options    *fieldValueobject.AIOptions
+	baseID     string
+	provider   aiDomain.Provider
+	fieldNames map[string]string // 字段ID -> 字段名，用于渲染提示词
+	userID     string
+	usage      map[string]*aiDomain.Usage // 按模型累计的用量
+}
+
+// aiGenerateTask 批内需要调用模型的一个输入（相同输入的记录合并）
+type aiGenerateTask struct {
+	prompt  string
+	hash    string
+	records []*entity.Record
+	result  *aiDomain.CompletionResult
+	err     error
+}
+
+// GenerateField 重新生成 AI 字段的值（手动触发）
+// 需要表的记录编辑权限，只处理用户可编辑行范围内的记录；force 为 false 时只生成空值。
+// 不超过一批的指定记录同步生成；整张表或更多记录提交为后台任务，同一字段同时只有一个后台任务
+func (s *AIFieldService) GenerateField(ctx context.Context, fieldID string, req dto.AIGenerateRequest, userID string) (*dto.AIGenerateJob, error) {
+	job, err := s.prepareJob(ctx, fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+	access, err := s.authorizeGenerate(ctx, job.tableID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.RecordIDs) > 0 && len(req.RecordIDs) <= aiGenerateBatchSize {
+		result, err := s.generate(ctx, job, req, access)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		return &dto.AIGenerateJob{
+			JobID:      uuid.NewString(),
+			FieldID:    fieldID,
+			Status:     dto.AIGenerateJobCompleted,
+			Result:     result,
+			CreatedAt:  now,
+			FinishedAt: &now,
+		}, nil
+	}
+	return s.enqueueGenerate(ctx, job, req, access), nil
+}
+
+// GetGenerateJob 查询 AI 字段的后台生成任务
+func (s *AIFieldService) GetGenerateJob(ctx context.Context, fieldID, jobID, userID string) (*dto.AIGenerateJob, error) {
+	s.jobsMu.Lock()
+	entry, ok := s.jobs[jobID]
+	var snapshot dto.AIGenerateJob
+	if ok {
+		snapshot = *entry.status
+	}
+	s.jobsMu.Unlock()
+	if !ok || snapshot.FieldID != fieldID {
+		return nil, pkgerrors.ErrNotFound.WithDetails("生成任务不存在")
+	}
+	if _, err := s.authorizeGenerate(ctx, entry.tableID, userID); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// HandleRecordsChanged 记录创建/更新后异步生成受影响的 AI 字段
+// changedFieldIDs 为空表示新建记录，生成所有 AI 字段的空值；
+// 否则只重新生成提示词引用了变更字段的 AI 字段（输入未变时命中缓存，不会重复调用模型）。
+// 记录变更已经过权限校验，生成只处理这些记录，不再校验
+func (s *AIFieldService) HandleRecordsChanged(ctx context.Context, tableID string, recordIDs []string, changedFieldIDs []string) {
+	if len(recordIDs) == 0 {
+		return
+	}
+
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("获取字段列表失败，跳过 AI 字段生成",
+			logger.String("table_id", tableID), logger.ErrorField(err))
+		return
+	}
+	affected := affectedAIFields(fields, changedFieldIDs)
+	if len(affected) == 0 {
+		return
+	}
+
+	force := len(changedFieldIDs) > 0
+	userID := currentUserID(ctx)
+	done := observability.TrackCalculation("ai") // ✨ 后台生成队列深度与耗时
+	go func() {
+		defer done()
+		// ✨ 脱离请求的取消信号，但保留 trace 上下文，生成过程仍归属于触发它的请求链路
+		bgCtx, cancel := context.WithTimeout(observability.Detach(ctx), aiBackgroundTimeout)
+		defer cancel()
+		bgCtx, span := observability.StartSpan(bgCtx, "AIFieldService.HandleRecordsChanged",
+			attribute.String("table.id", tableID),
+			attribute.Int("record.count", len(recordIDs)),
+			attribute.Int("field.count", len(affected)),
+		)
+		defer span.End()
+		for _, fieldID := range affected {
+			job, err := s.prepareJob(bgCtx, fieldID, userID)
+			if err == nil {
+				_, err = s.generate(bgCtx, job, dto.AIGenerateRequest{RecordIDs: recordIDs, Force: force}, nil)
+			}
+			if err != nil {
+				logger.Warn("AI 字段自动生成失败",
+					logger.String("field_id", fieldID), logger.ErrorField(err))
+			}
+		}
+	}()
+}
+
+// GetBaseUsage 查询 Base 的 AI token 用量（需要 Base 的访问权限）
+func (s *AIFieldService) GetBaseUsage(ctx context.Context, baseID string, query dto.AIUsageQuery, userID string) (*aiDomain.UsageSummary, error) {
+	if s.permission != nil && !s.permission.CanAccessBase(ctx, userID, baseID) {
+		return nil, pkgerrors.ErrForbidden.WithDetails("无权访问该 Base")
+	}
+
+	from, to, err := query.Range(time.Now())
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message": "日期格式应为 YYYY-MM-DD",
+			"error":   err.Error(),
+		})
+	}
+	if from.After(to) {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails("开始日期不能晚于结束日期")
+	}
+
+	entries, err := s.usageRepo.List(ctx, baseID, from, to)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询 AI 用量失败: %v", err))
+	}
+
+	summary := &aiDomain.UsageSummary{
+		BaseID: baseID,
+		From:   aiDomain.UsageDate(from),
+		To:     aiDomain.UsageDate(to),
+		Items:  entries,
+	}
+	for _, entry := range entries {
+		summary.Requests += entry.Requests
+		summary.PromptTokens += entry.PromptTokens
+		summary.CompletionTokens += entry.CompletionTokens
+	}
+	summary.TotalTokens = summary.PromptTokens + summary.CompletionTokens
+	return summary, nil
+}
+
+// ==================== 内部方法 ====================
+
+// authorizeGenerate 校验表的记录编辑权限，返回用户可编辑的行范围（nil 表示不受行级规则限制）
+func (s *AIFieldService) authorizeGenerate(ctx context.Context, tableID, userID string) (*permission.RowAccess, error) {
+	if s.permission != nil && !s.permission.CanUpdateRecordsInTable(ctx, userID, tableID) {
+		return nil, pkgerrors.ErrForbidden.WithDetails("无权编辑该表的记录")
+	}
+	if s.rowPermission == nil {
+		return nil, nil
+	}
+	access, err := s.rowPermission.ResolveAccess(ctx, tableID, userID, permission.ActionRecordUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if !access.Allowed {
+		return nil, pkgerrors.ErrForbidden.WithDetails("无权编辑该表的记录")
+	}
+	return access, nil
+}
+
+// generate 生成指定记录或整张表的 AI 字段值
+// access 非空时整表生成只读取可编辑行范围内的记录，指定的记录逐条校验行级权限
+func (s *AIFieldService) generate(ctx context.Context, job *aiGenerateJob, req dto.AIGenerateRequest, access *permission.RowAccess) (*dto.AIGenerateResponse, error) {
+	tableID := job.tableID
+	result := &dto.AIGenerateResponse{FieldID: job.fieldID}
+	defer s.recordUsage(ctx, job)
+
+	if len(req.RecordIDs) > 0 {
+		for start := 0; start < len(req.RecordIDs); start += aiGenerateBatchSize {
+			end := start + aiGenerateBatchSize
+			if end > len(req.RecordIDs) {
+				end = len(req.RecordIDs)
+			}
+			ids := make([]valueobject.RecordID, 0, end-start)
+			for _, id := range req.RecordIDs[start:end] {
+				ids = append(ids, valueobject.NewRecordID(id))
+			}
+			records, err := s.recordRepo.FindByIDs(ctx, tableID, ids)
+			if err != nil {
+				return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+			}
+			if access != nil {
+				records = s.editableRecords(ctx, job, records, result)
+			}
+			s.generateBatch(ctx, job, records, req.Force, result)
+		}
+	} else {
+		filter := recordRepo.RecordFilter{
+			TableID:  &tableID,
+			OrderBy:  "__auto_number",
+			OrderDir: "asc",
+			Limit:    aiGenerateBatchSize,
+		}
+		if access != nil {
+			filter.AccessScopes = access.Scopes
+		}
+		for offset := 0; ; offset += aiGenerateBatchSize {
+			filter.Offset = offset
+			records, _, err := s.recordRepo.List(ctx, filter)
+			if err != nil {
+				return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询记录失败: %v", err))
+			}
+			s.generateBatch(ctx, job, records, req.Force, result)
+			if len(records) < aiGenerateBatchSize {
+				break
+			}
+		}
+	}
+
+	logger.Info("AI 字段生成完成",
+		logger.String("field_id", job.fieldID),
+		logger.Int("total", result.Total),
+		logger.Int("generated", result.Generated),
+		logger.Int("cached", result.Cached),
+		logger.Int("skipped", result.Skipped),
+		logger.Int("failed", result.Failed))
+
+	return result, nil
+}
+
+// editableRecords 过滤出用户可编辑的记录，其余记录计为失败
+func (s *AIFieldService) editableRecords(ctx context.Context, job *aiGenerateJob, records []*entity.Record, result *dto.AIGenerateResponse) []*entity.Record {
+	editable := make([]*entity.Record, 0, len(records))
+	for _, record := range records {
+		if err := s.rowPermission.CheckRecord(ctx, job.tableID, job.userID, permission.ActionRecordUpdate, RecordValues(record)); err != nil {
+			result.Total++
+			result.AddError(record.ID().String(), err)
+			continue
+		}
+		editable = append(editable, record)
+	}
+	return editable
+}
+
+// enqueueGenerate 提交后台生成任务；同一字段已有未结束的任务时直接返回该任务
+func (s *AIFieldService) enqueueGenerate(ctx context.Context, job *aiGenerateJob, req dto.AIGenerateRequest, access *permission.RowAccess) *dto.AIGenerateJob {
+	s.jobsMu.Lock()
+	now := time.Now()
+	for id, entry := range s.jobs {
+		if entry.status.Finished() && now.Sub(*entry.status.FinishedAt) > aiJobRetention {
+			delete(s.jobs, id)
+			continue
+		}
+		if entry.status.FieldID == job.fieldID && !entry.status.Finished() {
+			snapshot := *entry.status
+			s.jobsMu.Unlock()
+			return &snapshot
+		}
+	}
+	entry := &aiJobEntry{
+		tableID: job.tableID,
+		status: &dto.AIGenerateJob{
+			JobID:     uuid.NewString(),
+			FieldID:   job.fieldID,
+			Status:    dto.AIGenerateJobQueued,
+			CreatedAt: now,
+		},
+	}
+	s.jobs[entry.status.JobID] = entry
+	snapshot := *entry.status
+	s.jobsMu.Unlock()
+
+	done := observability.TrackCalculation("ai")
+	go func() {
+		defer done()
+		bgCtx, cancel := context.WithTimeout(observability.Detach(ctx), aiBackgroundTimeout)
+		defer cancel()
+		bgCtx, span := observability.StartSpan(bgCtx, "AIFieldService.GenerateField",
+			attribute.String("field.id", job.fieldID),
+			attribute.String("job.id", snapshot.JobID),
+		)
+
+		s.updateJob(entry, func(status *dto.AIGenerateJob) { status.Status = dto.AIGenerateJobRunning })
+		result, err := s.generate(bgCtx, job, req, access)
+		observability.EndSpan(span, err)
+		s.updateJob(entry, func(status *dto.AIGenerateJob) {
+			finished := time.Now()
+			status.FinishedAt = &finished
+			status.Result = result
+			status.Status = dto.AIGenerateJobCompleted
+			if err != nil {
+				status.Status = dto.AIGenerateJobFailed
+				status.Error = err.Error()
+			}
+		})
+		if err != nil {
+			logger.Warn("AI 字段后台生成失败",
+				logger.String("field_id", job.fieldID),
+				logger.String("job_id", snapshot.JobID),
+				logger.ErrorField(err))
+		}
+	}()
+	return &snapshot
+}
+
+func (s *AIFieldService) updateJob(entry *aiJobEntry, update func(status *dto.AIGenerateJob)) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	update(entry.status)
+}
+
+// prepareJob 加载 AI 字段、所属 Base 和提供者
+func (s *AIFieldService) prepareJob(ctx context.Context, fieldID, userID string) (*aiGenerateJob, error) {
+	field, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(fieldID))
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+	}
+	if field == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("字段不存在")
+	}
+	if field.Type().String() != fieldValueobject.TypeAI || field.Options() == nil || field.Options().AI == nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message":  "只有配置了提示词的 AI 字段支持生成",
+			"field_id": fieldID,
+		})
+	}
+	options := field.Options().AI
+
+	table, err := s.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找表失败: %v", err))
+	}
+	if table == nil {
+		return nil, pkgerrors.ErrTableNotFound.WithDetails(map[string]interface{}{
+			"table_id": field.TableID(),
+		})
+	}
+
+	if s.providers == nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails("未配置 AI 提供者")
+	}
+	provider, err := s.providers.Get(options.Provider)
+	if err != nil {
+		if errors.Is(err, aiDomain.ErrProviderNotFound) {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"message":  "AI 提供者未配置",
+				"provider": options.Provider,
+			})
+		}
+		return nil, pkgerrors.ErrInternalServer.WithDetails(fmt.Sprintf("获取 AI 提供者失败: %v", err))
+	}
+
+	fields, err := s.fieldRepo.FindByTableID(ctx, field.TableID())
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取字段列表失败: %v", err))
+	}
+	fieldNames := make(map[string]string, len(fields))
+	for _, f := range fields {
+		fieldNames[f.ID().String()] = f.Name().String()
+	}
+
+	if userID == "" {
+		userID = "system"
+	}
+
+	return &aiGenerateJob{
+		fieldID:    fieldID,
+		tableID:    field.TableID(),
+		options:    options,
+		baseID:     table.BaseID(),
+		provider:   provider,
+		fieldNames: fieldNames,
+		userID:     userID,
+		usage:      make(map[string]*aiDomain.Usage),
+	}, nil
+}
+
+// generateBatch 生成一批记录的 AI 字段值
+func (s *AIFieldService) generateBatch(ctx context.Context, job *aiGenerateJob, records []*entity.Record, force bool, result *dto.AIGenerateResponse) {
+	tasks := make([]*aiGenerateTask, 0, len(records))
+	taskByHash := make(map[string]*aiGenerateTask)
+
+	for _, record := range records {
+		result.Total++
+		data := record.Data().ToMap()
+		if !force && !isEmptyAIValue(data[job.fieldID]) {
+			result.Skipped++
+			continue
+		}
+
+		prompt := strings.TrimSpace(fieldValueobject.RenderAIPrompt(job.options.Prompt, job.promptValues(data)))
+		if prompt == "" {
+			result.Skipped++
+			continue
+		}
+
+		hash := fieldValueobject.AIInputHash(job.options, prompt)
+		if cached, ok := s.cache.Get(hash); ok {
+			if err := s.writeValue(ctx, job, record, cached.(string)); err != nil {
+				result.AddError(record.ID().String(), err)
+				continue
+			}
+			result.Cached++
+			continue
+		}
+
+		if task, ok := taskByHash[hash]; ok {
+			task.records = append(task.records, record)
+			continue
+		}
+		task := &aiGenerateTask{prompt: prompt, hash: hash, records: []*entity.Record{record}}
+		taskByHash[hash] = task
+		tasks = append(tasks, task)
+	}
+
+	// 批内并发调用模型
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, aiGenerateWorkers)
+	for _, task := range tasks {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(task *aiGenerateTask) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			callCtx, span := observability.StartSpan(ctx, "AIFieldService.Complete",
+				attribute.String("field.id", job.fieldID),
+				attribute.Int("record.count", len(task.records)),
+			)
+			task.result, task.err = job.provider.Complete(callCtx, aiDomain.NewCompletionRequest(job.options, task.prompt))
+			observability.EndSpan(span, task.err)
+		}(task)
+	}
+	wg.Wait()
+
+	// 串行写入，避免并发写同一张表
+	for _, task := range tasks {
+		if task.err != nil {
+			for _, record := range task.records {
+				result.AddError(record.ID().String(), task.err)
+			}
+			continue
+		}
+
+		job.addUsage(task.result)
+		result.PromptTokens += task.result.PromptTokens
+		result.CompletionTokens += task.result.CompletionTokens
+		s.cache.Set(task.hash, task.result.Text, aiResultCacheTTL)
+
+		for i, record := range task.records {
+			if err := s.writeValue(ctx, job, record, task.result.Text); err != nil {
+				result.AddError(record.ID().String(), err)
+				continue
+			}
+			if i == 0 {
+				result.Generated++
+			} else {
+				result.Cached++
+			}
+		}
+	}
+}
+
+// writeValue 写入生成结果并推送记录更新
+func (s *AIFieldService) writeValue(ctx context.Context, job *aiGenerateJob, record *entity.Record, text string) error {
+	if current, ok := record.Data().ToMap()[job.fieldID].(string); ok && current == text {
+		return nil
+	}
+
+	previous := RecordValues(record)
+	data, err := valueobject.NewRecordData(map[string]interface{}{job.fieldID: text})
+	if err != nil {
+		return err
+	}
+	if err := record.Update(data, job.userID); err != nil {
+		return err
+	}
+	if err := s.recordRepo.Save(ctx, record); err != nil {
+		return fmt.Errorf("保存记录失败: %w", err)
+	}
+
+	if s.recordService != nil {
+		event := &database.RecordEvent{
+			EventType:  "record.update",
+			TID:        record.TableID(),
+			RID:        record.ID().String(),
+			Fields:     record.Data().ToMap(),
+			UserID:     job.userID,
+			OldVersion: record.Version().Value() - 1,
+			NewVersion: record.Version().Value(),
+		}
+		s.recordService.publishRecordEvent(ctx, event, RecordValues(record), previous)
+	}
+	return nil
+}
+
+// recordUsage 保存本次任务累计的 token 用量
+func (s *AIFieldService) recordUsage(ctx context.Context, job *aiGenerateJob) {
+	if s.usageRepo == nil {
+		return
+	}
+	for _, usage := range job.usage {
+		if err := s.usageRepo.Record(ctx, usage); err != nil {
+			logger.Warn("记录 AI 用量失败",
+				logger.String("base_id", usage.BaseID),
+				logger.String("model", usage.Model),
+				logger.ErrorField(err))
+		}
+	}
+}
+
+// promptValues 以字段名为键的记录值，用于渲染提示词
+func (j *aiGenerateJob) promptValues(data map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(j.fieldNames))
+	for fieldID, name := range j.fieldNames {
+		if fieldID == j.fieldID {
+			continue
+		}
+		values[name] = data[fieldID]
+	}
+	return values
+}
+
+// addUsage 累计一次生成的用量
+func (j *aiGenerateJob) addUsage(result *aiDomain.CompletionResult) {
+	usage, ok := j.usage[result.Model]
+	if !ok {
+		j.usage[result.Model] = aiDomain.NewUsage(j.baseID, j.provider.Name(), result, time.Now())
+		return
+	}
+	usage.Requests++
+	usage.PromptTokens += result.PromptTokens
+	usage.CompletionTokens += result.CompletionTokens
+}
+
+// affectedAIFields 受记录变更影响的 AI 字段
+// changedFieldIDs 为空时返回所有 AI 字段
+func affectedAIFields(fields []*fieldEntity.Field, changedFieldIDs []string) []string {
+	changedNames := make(map[string]bool, len(changedFieldIDs))
+	changedIDs := make(map[string]bool, len(changedFieldIDs))
+	for _, id := range changedFieldIDs {
+		changedIDs[id] = true
+	}
+	for _, field := range fields {
+		if changedIDs[field.ID().String()] {
+			changedNames[field.Name().String()] = true
+		}
+	}
+
+	affected := make([]string, 0)
+	for _, field := range fields {
+		if field.Type().String() != fieldValueobject.TypeAI || field.Options() == nil || field.Options().AI == nil {
+			continue
+		}
+		if len(changedFieldIDs) == 0 {
+			affected = append(affected, field.ID().String())
+			continue
+		}
+		for _, name := range fieldValueobject.AIPromptFieldNames(field.Options().AI.Prompt) {
+			if changedNames[name] {
+				affected = append(affected, field.ID().String())
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// isEmptyAIValue AI 字段是否还没有值
+func isEmptyAIValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	text, ok := value.(string)
+	return ok && strings.TrimSpace(text) == ""
+}
@@ -0,0 +1,293 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/monitoring"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// defaultAlertHistoryLimit 告警历史默认分页大小
+const defaultAlertHistoryLimit = 50
+
+// AlertService 告警规则管理服务 ✨
+//
+// 规则、静默和告警历史存储在数据库中，告警系统每个评估周期重新读取，
+// 修改后无需重启即在下一个周期生效。权限由路由层限制为管理员
+type AlertService struct {
+	repo     monitoring.AlertRepository
+	alerting *monitoring.AlertingSystem
+}
+
+// NewAlertService 创建告警规则管理服务
+func NewAlertService(repo monitoring.AlertRepository, alerting *monitoring.AlertingSystem) *AlertService {
+	return &AlertService{
+		repo:     repo,
+		alerting: alerting,
+	}
+}
+
+// ListChannels 列出已配置的通知渠道
+func (s *AlertService) ListChannels(ctx context.Context) []string {
+	return s.alerting.NotifierNames()
+}
+
+// ListRules 列出告警规则
+func (s *AlertService) ListRules(ctx context.Context) ([]*dto.AlertRuleResponse, error) {
+	rules, err := s.repo.ListRules(ctx)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+
+	result := make([]*dto.AlertRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, dto.FromAlertRule(rule))
+	}
+	return result, nil
+}
+
+// GetRule 获取告警规则
+func (s *AlertService) GetRule(ctx context.Context, ruleID string) (*dto.AlertRuleResponse, error) {
+	rule, err := s.findRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.FromAlertRule(rule), nil
+}
+
+// CreateRule 创建告警规则
+func (s *AlertService) CreateRule(ctx context.Context, req dto.CreateAlertRuleRequest, userID string) (*dto.AlertRuleResponse, error) {
+	severity := monitoring.AlertingSeverityWarning
+	if req.Severity != "" {
+		severity = monitoring.AlertingSeverity(req.Severity)
+	}
+	rule := monitoring.NewAlertRule(req.Name, monitoring.AlertCondition{
+		MetricName: strings.TrimSpace(req.MetricName),
+		Labels:     req.MetricLabels,
+		Function:   monitoring.AlertFunction(req.Function),
+		Operator:   monitoring.ComparisonOperator(req.Operator),
+		Threshold:  req.Threshold,
+		Duration:   time.Duration(req.ForSeconds) * time.Second,
+	}, severity, userID)
+	rule.Description = strings.TrimSpace(req.Description)
+	rule.Channels = req.Channels
+	rule.RepeatInterval = time.Duration(req.RepeatIntervalSeconds) * time.Second
+	rule.Labels = req.Labels
+	rule.Annotations = req.Annotations
+
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveRule(ctx, rule); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	logger.Info("告警规则已创建",
+		logger.String("rule_id", rule.ID),
+		logger.String("metric", rule.Condition.MetricName))
+
+	return dto.FromAlertRule(rule), nil
+}
+
+// UpdateRule 更新告警规则
+func (s *AlertService) UpdateRule(ctx context.Context, ruleID string, req dto.UpdateAlertRuleRequest) (*dto.AlertRuleResponse, error) {
+	rule, err := s.findRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		rule.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.MetricName != nil {
+		rule.Condition.MetricName = strings.TrimSpace(*req.MetricName)
+	}
+	if req.MetricLabels != nil {
+		rule.Condition.Labels = req.MetricLabels
+	}
+	if req.Function != nil {
+		rule.Condition.Function = monitoring.AlertFunction(*req.Function)
+	}
+	if req.Operator != nil {
+		rule.Condition.Operator = monitoring.ComparisonOperator(*req.Operator)
+	}
+	if req.Threshold != nil {
+		rule.Condition.Threshold = *req.Threshold
+	}
+	if req.ForSeconds != nil {
+		rule.Condition.Duration = time.Duration(*req.ForSeconds) * time.Second
+	}
+	if req.Severity != nil {
+		rule.Severity = monitoring.AlertingSeverity(*req.Severity)
+	}
+	if req.Channels != nil {
+		rule.Channels = req.Channels
+	}
+	if req.RepeatIntervalSeconds != nil {
+		rule.RepeatInterval = time.Duration(*req.RepeatIntervalSeconds) * time.Second
+	}
+	if req.Labels != nil {
+		rule.Labels = req.Labels
+	}
+	if req.Annotations != nil {
+		rule.Annotations = req.Annotations
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveRule(ctx, rule); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	return dto.FromAlertRule(rule), nil
+}
+
+// DeleteRule 删除告警规则，历史保留；正在触发的告警在下一个评估周期结束
+func (s *AlertService) DeleteRule(ctx context.Context, ruleID string) error {
+	if _, err := s.findRule(ctx, ruleID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteRule(ctx, ruleID); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	logger.Info("告警规则已删除", logger.String("rule_id", ruleID))
+	return nil
+}
+
+// TestRule 通过规则的通知渠道发送一条测试告警
+func (s *AlertService) TestRule(ctx context.Context, ruleID string) (*dto.AlertTestResponse, error) {
+	rule, err := s.findRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.alerting.TestNotify(ctx, rule)
+	if err != nil {
+		return nil, pkgerrors.ErrBadRequest.WithDetails(err.Error())
+	}
+	return &dto.AlertTestResponse{Results: results}, nil
+}
+
+// ListAlerts 查询告警历史（按触发时间倒序）
+func (s *AlertService) ListAlerts(ctx context.Context, ruleID, status string, limit, offset int) (*dto.AlertListResponse, error) {
+	if limit <= 0 || limit > 200 {
+		limit = defaultAlertHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	alerts, total, err := s.repo.ListAlerts(ctx, monitoring.AlertHistoryQuery{
+		RuleID: ruleID,
+		Status: monitoring.AlertStatus(status),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+
+	result := &dto.AlertListResponse{Alerts: make([]*dto.AlertResponse, 0, len(alerts)), Total: total}
+	for _, alert := range alerts {
+		result.Alerts = append(result.Alerts, dto.FromAlert(alert))
+	}
+	return result, nil
+}
+
+// ListSilences 列出静默，默认只返回未结束的
+func (s *AlertService) ListSilences(ctx context.Context, includeExpired bool) ([]*dto.AlertSilenceResponse, error) {
+	now := time.Now()
+	silences, err := s.repo.ListSilences(ctx, includeExpired, now)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+
+	result := make([]*dto.AlertSilenceResponse, 0, len(silences))
+	for _, silence := range silences {
+		result = append(result, dto.FromAlertSilence(silence, now))
+	}
+	return result, nil
+}
+
+// CreateSilence 创建静默窗口
+func (s *AlertService) CreateSilence(ctx context.Context, req dto.CreateAlertSilenceRequest, userID string) (*dto.AlertSilenceResponse, error) {
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if !req.EndsAt.After(startsAt) || !req.EndsAt.After(now) {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails("结束时间必须晚于开始时间和当前时间")
+	}
+	if req.RuleID != "" {
+		if _, err := s.findRule(ctx, req.RuleID); err != nil {
+			return nil, err
+		}
+	}
+
+	silence := monitoring.NewAlertSilence(req.RuleID, req.Matchers, startsAt, req.EndsAt, req.Comment, userID)
+	if err := s.repo.SaveSilence(ctx, silence); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	logger.Info("告警静默已创建",
+		logger.String("silence_id", silence.ID),
+		logger.String("rule_id", silence.RuleID),
+		logger.String("ends_at", silence.EndsAt.Format(time.RFC3339)))
+
+	return dto.FromAlertSilence(silence, now), nil
+}
+
+// DeleteSilence 删除静默（提前结束）
+func (s *AlertService) DeleteSilence(ctx context.Context, silenceID string) error {
+	if err := s.repo.DeleteSilence(ctx, silenceID); err != nil {
+		if errors.Is(err, monitoring.ErrAlertSilenceNotFound) {
+			return pkgerrors.ErrNotFound.WithDetails("静默不存在")
+		}
+		return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	return nil
+}
+
+// findRule 查找规则
+func (s *AlertService) findRule(ctx context.Context, ruleID string) (*monitoring.AlertRule, error) {
+	rule, err := s.repo.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+	if rule == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("告警规则不存在")
+	}
+	return rule, nil
+}
+
+// validateRule 校验规则及其引用的通知渠道
+func (s *AlertService) validateRule(rule *monitoring.AlertRule) error {
+	if err := rule.Validate(); err != nil {
+		return pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	for _, channel := range rule.Channels {
+		if !s.alerting.HasNotifier(channel) {
+			return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"message":  "通知渠道未配置",
+				"channel":  channel,
+				"channels": s.alerting.NotifierNames(),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/database"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+const (
+	attachmentPreviewWorkers   = 2               // 并发生成数（PDF 渲染、视频截帧较耗 CPU）
+	attachmentPreviewQueueSize = 256             // 队列满时附件保持 pending，下次启动时恢复
+	attachmentPreviewTimeout   = 2 * time.Minute // 单个附件的生成超时
+	attachmentPreviewRecover   = 500             // 启动时恢复的 pending 附件上限
+)
+
+// AttachmentPreviewService 附件预览后台任务 ✨
+//
+// 设计考量：
+//   - 上传完成后附件标记为 pending 并入队，由固定数量的 worker 生成，不阻塞上传请求
+//   - 生成器只处理本地文件：worker 从存储下载到临时文件，产物（海报图、文本摘录）再上传回存储
+//   - 缺少外部工具时标记为 unsupported，生成出错时标记为 failed，都不影响附件本身
+//   - 生成完成后把缩略图URL、时长、页数写回引用该附件的单元格，并通过记录事件推送给前端
+type AttachmentPreviewService struct {
+	repo       attachment.Repository
+	linkRepo   attachment.LinkRepository
+	storage    attachment.Storage
+	generator  attachment.PreviewGenerator
+	config     *attachment.ThumbnailConfig
+	recordRepo recordRepo.RecordRepository
+
+	recordService *RecordService // ✨ 写回单元格后发布记录事件
+
+	queue  chan string
+	once   sync.Once
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewAttachmentPreviewService 创建附件预览服务
+func NewAttachmentPreviewService(
+	repo attachment.Repository,
+	linkRepo attachment.LinkRepository,
+	storage attachment.Storage,
+	generator attachment.PreviewGenerator,
+	config *attachment.ThumbnailConfig,
+	recordRepo recordRepo.RecordRepository,
+) *AttachmentPreviewService {
+	if config == nil {
+		config = attachment.DefaultThumbnailConfig()
+	}
+	return &AttachmentPreviewService{
+		repo:       repo,
+		linkRepo:   linkRepo,
+		storage:    storage,
+		generator:  generator,
+		config:     config,
+		recordRepo: recordRepo,
+		queue:      make(chan string, attachmentPreviewQueueSize),
+	}
+}
+
+// SetRecordService 设置记录服务（用于延迟注入）
+func (s *AttachmentPreviewService) SetRecordService(recordService *RecordService) {
+	s.recordService = recordService
+}
+
+// Start 启动 worker，并恢复上次退出时未完成的预览任务
+func (s *AttachmentPreviewService) Start(ctx context.Context) {
+	s.once.Do(func() {
+		workerCtx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		for i := 0; i < attachmentPreviewWorkers; i++ {
+			s.wg.Add(1)
+			go s.work(workerCtx)
+		}
+
+		pending, err := s.repo.ListPendingPreviews(ctx, attachmentPreviewRecover)
+		if err != nil {
+			logger.Warn("恢复附件预览任务失败", logger.ErrorField(err))
+			return
+		}
+		for _, item := range pending {
+			s.Enqueue(item.ID)
+		}
+		if len(pending) > 0 {
+			logger.Info("已恢复附件预览任务", logger.Int("count", len(pending)))
+		}
+	})
+}
+
+// Stop 停止 worker，正在生成的任务被取消后保持 pending
+func (s *AttachmentPreviewService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Prepare 按文件类型标记附件的预览状态：支持的类型为 pending，其余为 unsupported
+func (s *AttachmentPreviewService) Prepare(ctx context.Context, item *attachment.AttachmentItem) bool {
+	if s.config.Enabled && s.generator.Supports(item.MimeType) {
+		item.SetPreviewStatus(attachment.PreviewStatusPending)
+	} else {
+		item.SetPreviewStatus(attachment.PreviewStatusUnsupported)
+	}
+
+	if err := s.repo.UpdateAttachment(ctx, item); err != nil {
+		logger.Warn("更新附件预览状态失败",
+			logger.String("attachment_id", item.ID),
+			logger.ErrorField(err))
+		return false
+	}
+	return item.PreviewStatus == attachment.PreviewStatusPending
+}
+
+// Enqueue 把附件加入预览队列，队列已满时跳过（附件保持 pending）
+func (s *AttachmentPreviewService) Enqueue(attachmentID string) {
+	select {
+	case s.queue <- attachmentID:
+	default:
+		logger.Warn("附件预览队列已满，稍后恢复", logger.String("attachment_id", attachmentID))
+	}
+}
+
+// work 持续消费预览队列
+func (s *AttachmentPreviewService) work(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case attachmentID := <-s.queue:
+			jobCtx, cancel := context.WithTimeout(ctx, attachmentPreviewTimeout)
+			if err := s.Process(jobCtx, attachmentID); err != nil {
+				logger.Warn("生成附件预览失败",
+					logger.String("attachment_id", attachmentID),
+					logger.ErrorField(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// Process 生成单个附件的预览并写回附件和引用它的单元格
+func (s *AttachmentPreviewService) Process(ctx context.Context, attachmentID string) error {
+	item, err := s.repo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		// 附件可能在排队期间被删除
+		return nil
+	}
+	if item.PreviewStatus != attachment.PreviewStatusPending {
+		return nil
+	}
+
+	localPath, err := s.download(ctx, item)
+	if err != nil {
+		return s.finish(ctx, item, attachment.PreviewStatusFailed, err)
+	}
+	defer os.Remove(localPath)
+
+	preview, err := s.generator.Generate(ctx, localPath, item.MimeType, s.config)
+	if err == attachment.ErrPreviewUnavailable {
+		return s.finish(ctx, item, attachment.PreviewStatusUnsupported, nil)
+	}
+	if err != nil {
+		switch ctx.Err() {
+		case context.Canceled:
+			// 服务停止：保持 pending，由下次启动恢复
+			return err
+		case context.DeadlineExceeded:
+			// 超时的文件重试也会超时，标记失败（状态用不受超时影响的上下文写入）
+			return s.finish(context.WithoutCancel(ctx), item, attachment.PreviewStatusFailed, err)
+		}
+		return s.finish(ctx, item, attachment.PreviewStatusFailed, err)
+	}
+
+	smallPath, largePath, textPath, err := s.upload(ctx, item, preview)
+	if err != nil {
+		return s.finish(ctx, item, attachment.PreviewStatusFailed, err)
+	}
+	item.ApplyPreview(preview, smallPath, largePath, textPath)
+	if err := s.repo.UpdateAttachment(ctx, item); err != nil {
+		return fmt.Errorf("保存附件预览失败: %w", err)
+	}
+
+	s.refreshCells(ctx, item)
+	return nil
+}
+
+// ==================== 内部方法 ====================
+
+// finish 记录没有产物的最终状态，cause 为生成失败的原因
+func (s *AttachmentPreviewService) finish(ctx context.Context, item *attachment.AttachmentItem, status string, cause error) error {
+	item.SetPreviewStatus(status)
+	if err := s.repo.UpdateAttachment(ctx, item); err != nil {
+		return fmt.Errorf("更新附件预览状态失败: %w", err)
+	}
+	s.refreshCells(ctx, item)
+	return cause
+}
+
+// download 把附件下载到临时文件（保留扩展名，外部工具按扩展名识别格式）
+func (s *AttachmentPreviewService) download(ctx context.Context, item *attachment.AttachmentItem) (string, error) {
+	reader, err := s.storage.Download(ctx, item.Path)
+	if err != nil {
+		return "", fmt.Errorf("下载附件失败: %w", err)
+	}
+	defer reader.Close()
+
+	file, err := os.CreateTemp("", "luckdb-attachment-*"+filepath.Ext(item.Name))
+	if err != nil {
+		return "", fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", fmt.Errorf("下载附件失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("下载附件失败: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// upload 把预览产物上传到存储，返回各产物路径（没有的产物为空）
+func (s *AttachmentPreviewService) upload(ctx context.Context, item *attachment.AttachmentItem, preview *attachment.Preview) (string, string, string, error) {
+	smallPath, largePath, textPath := attachment.PreviewPaths(item.ID)
+
+	outputs := []struct {
+		path        *string
+		data        []byte
+		contentType string
+	}{
+		{&smallPath, preview.SmallImage, "image/jpeg"},
+		{&largePath, preview.LargeImage, "image/jpeg"},
+		{&textPath, []byte(preview.Text), "text/plain; charset=utf-8"},
+	}
+	for _, output := range outputs {
+		if len(output.data) == 0 {
+			*output.path = ""
+			continue
+		}
+		if err := s.storage.Upload(ctx, *output.path, bytes.NewReader(output.data), int64(len(output.data)), output.contentType); err != nil {
+			return "", "", "", fmt.Errorf("上传预览失败: %w", err)
+		}
+	}
+	return smallPath, largePath, textPath, nil
+}
+
+// refreshCells 把预览信息写回引用该附件的单元格
+func (s *AttachmentPreviewService) refreshCells(ctx context.Context, item *attachment.AttachmentItem) {
+	links, err := s.linkRepo.ListLinksByToken(ctx, item.Token)
+	if err != nil {
+		logger.Warn("查询附件引用失败",
+			logger.String("attachment_id", item.ID),
+			logger.ErrorField(err))
+		return
+	}
+
+	previewValue := item.PreviewCellValue(func(path string) string {
+		url, err := s.storage.GetURL(ctx, path, 24*time.Hour)
+		if err != nil {
+			return ""
+		}
+		return url
+	})
+	for _, link := range links {
+		if err := s.refreshCell(ctx, link, item, previewValue); err != nil {
+			logger.Warn("写回附件预览失败",
+				logger.String("attachment_id", item.ID),
+				logger.String("table_id", link.TableID),
+				logger.String("record_id", link.RecordID),
+				logger.ErrorField(err))
+		}
+	}
+}
+
+// refreshCell 合并单元格中该附件项的预览信息并保存
+func (s *AttachmentPreviewService) refreshCell(ctx context.Context, link *attachment.CellLink, item *attachment.AttachmentItem, previewValue map[string]interface{}) error {
+	record, err := s.recordRepo.FindByTableAndID(ctx, link.TableID, valueobject.NewRecordID(link.RecordID))
+	if err != nil {
+		return fmt.Errorf("查找记录失败: %w", err)
+	}
+	if record == nil {
+		return nil
+	}
+
+	cell := attachment.CellItems(record.Data().ToMap()[link.FieldID])
+	value := make([]interface{}, 0, len(cell))
+	changed := false
+	for _, existing := range cell {
+		if token, _ := existing["token"].(string); token == item.Token {
+			for key, v := range previewValue {
+				existing[key] = v
+			}
+			changed = true
+		}
+		value = append(value, existing)
+	}
+	if !changed {
+		return nil
+	}
+
+	previous := RecordValues(record)
+	data, err := valueobject.NewRecordData(map[string]interface{}{link.FieldID: value})
+	if err != nil {
+		return err
+	}
+	if err := record.Update(data, item.CreatedBy); err != nil {
+		return err
+	}
+	if err := s.recordRepo.Save(ctx, record); err != nil {
+		return fmt.Errorf("保存记录失败: %w", err)
+	}
+
+	if s.recordService != nil {
+		event := &database.RecordEvent{
+			EventType:  "record.update",
+			TID:        record.TableID(),
+			RID:        record.ID().String(),
+			Fields:     record.Data().ToMap(),
+			UserID:     item.CreatedBy,
+			OldVersion: record.Version().Value() - 1,
+			NewVersion: record.Version().Value(),
+		}
+		s.recordService.publishRecordEvent(ctx, event, RecordValues(record), previous)
+	}
+	return nil
+}
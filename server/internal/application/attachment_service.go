@@ -0,0 +1,808 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// dedupReportTop 去重报告中列出的重复内容条数
+const dedupReportTop = 10
+
+// appendCellMaxAttempts 追加附件到单元格时版本冲突的最大尝试次数
+const appendCellMaxAttempts = 5
+
+// AttachmentService 附件上传与单元格引用服务 ✨
+//
+// 设计考量：
+//   - 上传流程：签名（校验附件字段和配额）→ 凭令牌直传文件 → 通知完成，附件追加到令牌指定的单元格
+//   - 单元格写入统一走 RecordService，记录变更后按单元格内容同步附件引用（AttachmentLink），
+//     附件在最后一个引用它的单元格被清空后删除文件
+//   - 空间存储用量在附件创建/删除时累加，配置了配额时在签名和上传阶段拦截
+//   - 文件按内容（SHA-256）存储为 Blob，相同内容只保存一份；空间内已有的内容可秒传，
+//     没有附件引用的 Blob 由定时任务回收。用量与配额按附件的逻辑大小计算
+type AttachmentService struct {
+	attachments attachment.Service
+	repo        attachment.Repository
+	tokenRepo   attachment.UploadTokenRepository
+	linkRepo    attachment.LinkRepository
+	usageRepo   attachment.UsageRepository
+	fieldRepo   repository.FieldRepository
+	recordRepo  recordRepo.RecordRepository
+	tableRepo   tableRepo.TableRepository
+	baseRepo    baseRepo.BaseRepository
+	spaceQuota  int64 // 每个空间的存储配额（字节），0 表示不限制；配置了配额服务时以套餐为准
+
+	recordService *RecordService            // ✨ 上传完成后写入附件单元格
+	previews      *AttachmentPreviewService // ✨ 上传完成后在后台生成预览
+	quotas        *QuotaService             // ✨ 按空间套餐计算存储配额
+	permissions   *PermissionServiceV2      // ✨ 表/空间访问权限
+	rowPermission *RowPermissionService     // ✨ 行级权限
+}
+
+// NewAttachmentService 创建附件服务
+func NewAttachmentService(
+	attachments attachment.Service,
+	repo attachment.Repository,
+	tokenRepo attachment.UploadTokenRepository,
+	linkRepo attachment.LinkRepository,
+	usageRepo attachment.UsageRepository,
+	fieldRepo repository.FieldRepository,
+	recordRepo recordRepo.RecordRepository,
+	tableRepo tableRepo.TableRepository,
+	baseRepo baseRepo.BaseRepository,
+	spaceQuota int64,
+) *AttachmentService {
+	return &AttachmentService{
+		attachments: attachments,
+		repo:        repo,
+		tokenRepo:   tokenRepo,
+		linkRepo:    linkRepo,
+		usageRepo:   usageRepo,
+		fieldRepo:   fieldRepo,
+		recordRepo:  recordRepo,
+		tableRepo:   tableRepo,
+		baseRepo:    baseRepo,
+		spaceQuota:  spaceQuota,
+	}
+}
+
+// SetRecordService 设置记录服务（用于延迟注入）
+func (s *AttachmentService) SetRecordService(recordService *RecordService) {
+	s.recordService = recordService
+}
+
+// SetPreviewService 设置预览服务（用于延迟注入）
+func (s *AttachmentService) SetPreviewService(previews *AttachmentPreviewService) {
+	s.previews = previews
+}
+
+// SetQuotaService 设置配额服务（用于延迟注入）
+func (s *AttachmentService) SetQuotaService(quotas *QuotaService) {
+	s.quotas = quotas
+}
+
+// SetPermissionService 设置权限服务（用于延迟注入）
+func (s *AttachmentService) SetPermissionService(permissions *PermissionServiceV2) {
+	s.permissions = permissions
+}
+
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (s *AttachmentService) SetRowPermissionService(rowPermission *RowPermissionService) {
+	s.rowPermission = rowPermission
+}
+
+// GenerateSignature 为附件单元格生成上传签名，需要该记录的更新权限
+func (s *AttachmentService) GenerateSignature(ctx context.Context, userID string, req *attachment.SignatureRequest) (*attachment.SignatureResponse, error) {
+	if err := s.checkAttachmentField(ctx, req.TableID, req.FieldID); err != nil {
+		return nil, err
+	}
+	if err := s.checkRecordAccess(ctx, userID, req.TableID, req.RecordID, permission.ActionRecordUpdate); err != nil {
+		return nil, err
+	}
+
+	record, err := s.recordRepo.FindByTableAndID(ctx, req.TableID, valueobject.NewRecordID(req.RecordID))
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+	}
+	if record == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+
+	if err := s.checkQuota(ctx, req.TableID, 1); err != nil {
+		return nil, err
+	}
+	return s.attachments.GenerateSignature(ctx, userID, req)
+}
+
+// UploadFile 凭上传令牌写入文件，超出空间配额时拒绝
+func (s *AttachmentService) UploadFile(ctx context.Context, token string, reader io.Reader, filename string, size int64) error {
+	uploadToken, err := s.getUploadToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := s.checkQuota(ctx, uploadToken.TableID, size); err != nil {
+		return err
+	}
+	return s.attachments.UploadFile(ctx, token, reader, filename, size)
+}
+
+// NotifyUpload 确认上传完成：保存附件、计入空间用量并追加到令牌指定的单元格，再排队生成预览
+// 单元格写入失败时删除刚保存的附件
+func (s *AttachmentService) NotifyUpload(ctx context.Context, token, filename, userID string) (*attachment.NotifyResponse, error) {
+	uploadToken, err := s.getUploadToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if uploadToken.UserID != userID {
+		return nil, pkgerrors.ErrForbidden.WithDetails("上传令牌不属于当前用户")
+	}
+
+	response, err := s.attachments.NotifyUpload(ctx, token, filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attach(ctx, uploadToken, response.Attachment, userID); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// InstantUpload 秒传：空间内已有相同内容（SHA-256 与大小一致）时直接引用，无需再上传文件
+// 只在同一空间内命中，避免凭摘要取得其他空间的文件；未命中时返回 404，客户端改为正常上传
+func (s *AttachmentService) InstantUpload(ctx context.Context, token, userID string, req *attachment.InstantUploadRequest) (*attachment.NotifyResponse, error) {
+	uploadToken, err := s.getUploadToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if uploadToken.UserID != userID {
+		return nil, pkgerrors.ErrForbidden.WithDetails("上传令牌不属于当前用户")
+	}
+
+	spaceID, err := s.resolveSpaceID(ctx, uploadToken.TableID)
+	if err != nil {
+		return nil, err
+	}
+	tableIDs, err := s.spaceTableIDs(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.FindAttachmentByHash(ctx, req.Hash, tableIDs); err != nil {
+		if err == pkgerrors.ErrNotFound {
+			return nil, pkgerrors.ErrNotFound.WithDetails("空间内没有相同内容的文件，请直接上传")
+		}
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找附件失败: %v", err))
+	}
+	if err := s.checkQuota(ctx, uploadToken.TableID, req.Size); err != nil {
+		return nil, err
+	}
+
+	response, err := s.attachments.InstantUpload(ctx, token, req.Filename, req.Hash, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attach(ctx, uploadToken, response.Attachment, userID); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ReadFile 读取附件文件流，需要能读取引用该附件的记录
+func (s *AttachmentService) ReadFile(ctx context.Context, userID, path, token string) (*attachment.ReadResponse, error) {
+	item, err := s.attachmentForPath(ctx, path, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAttachmentAccess(ctx, userID, item, permission.ActionRecordRead); err != nil {
+		return nil, err
+	}
+	return s.attachments.ReadFile(ctx, path, token)
+}
+
+// GetAttachment 获取附件信息，需要能读取引用该附件的记录
+func (s *AttachmentService) GetAttachment(ctx context.Context, userID, id string) (*attachment.AttachmentItem, error) {
+	item, err := s.attachments.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAttachmentAccess(ctx, userID, item, permission.ActionRecordRead); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ListAttachments 列出附件
+// 指定记录时需要能读取该记录；否则只返回行级规则下可读取记录中的附件
+func (s *AttachmentService) ListAttachments(ctx context.Context, userID, tableID, fieldID, recordID string) ([]*attachment.AttachmentItem, error) {
+	if recordID != "" {
+		if err := s.checkRecordAccess(ctx, userID, tableID, recordID, permission.ActionRecordRead); err != nil {
+			return nil, err
+		}
+		return s.attachments.ListAttachments(ctx, tableID, fieldID, recordID)
+	}
+
+	access, err := s.resolveTableAccess(ctx, userID, tableID, permission.ActionRecordRead)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.attachments.ListAttachments(ctx, tableID, fieldID, "")
+	if err != nil || access.Unrestricted {
+		return items, err
+	}
+	return s.filterReadableItems(ctx, tableID, items, access)
+}
+
+// GetAttachmentStats 获取表格附件统计，受行级规则限制的用户不能查看全表统计
+func (s *AttachmentService) GetAttachmentStats(ctx context.Context, userID, tableID string) (*attachment.AttachmentStats, error) {
+	access, err := s.resolveTableAccess(ctx, userID, tableID, permission.ActionRecordRead)
+	if err != nil {
+		return nil, err
+	}
+	if !access.Unrestricted {
+		return nil, pkgerrors.ErrForbidden.WithDetails("只能读取部分记录时不能查看全表附件统计")
+	}
+	return s.attachments.GetAttachmentStats(ctx, tableID)
+}
+
+// CleanupExpiredTokens 清理过期的上传令牌和断点续传会话
+func (s *AttachmentService) CleanupExpiredTokens(ctx context.Context) error {
+	return s.attachments.CleanupExpiredTokens(ctx)
+}
+
+// CollectOrphanBlobs 回收没有附件引用的文件内容
+func (s *AttachmentService) CollectOrphanBlobs(ctx context.Context) (*attachment.GCResult, error) {
+	return s.attachments.CollectOrphanBlobs(ctx)
+}
+
+// RunTokenCleanup 按间隔清理过期的上传令牌、被放弃的断点续传分片和无引用的文件内容，ctx 取消时退出
+func (s *AttachmentService) RunTokenCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.CleanupExpiredTokens(ctx); err != nil {
+				logger.Warn("清理过期上传令牌失败", logger.ErrorField(err))
+			}
+			if _, err := s.CollectOrphanBlobs(ctx); err != nil {
+				logger.Warn("回收无引用的附件内容失败", logger.ErrorField(err))
+			}
+		}
+	}
+}
+
+// CreateUploadSession 创建断点续传会话，按声明的总大小检查空间配额
+func (s *AttachmentService) CreateUploadSession(ctx context.Context, token, filename string, size int64) (*attachment.UploadSession, error) {
+	uploadToken, err := s.getUploadToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx, uploadToken.TableID, size); err != nil {
+		return nil, err
+	}
+	return s.attachments.CreateUploadSession(ctx, token, filename, size)
+}
+
+// GetUploadSession 查询断点续传进度
+func (s *AttachmentService) GetUploadSession(ctx context.Context, token, id string) (*attachment.UploadSession, error) {
+	return s.attachments.GetUploadSession(ctx, token, id)
+}
+
+// UploadChunk 追加一个分片
+func (s *AttachmentService) UploadChunk(ctx context.Context, token, id string, offset int64, reader io.Reader, length int64, checksum *attachment.ChunkChecksum) (*attachment.UploadSession, error) {
+	return s.attachments.UploadChunk(ctx, token, id, offset, reader, length, checksum)
+}
+
+// FinalizeUploadSession 合并分片为最终文件，之后与普通上传一样调用 NotifyUpload
+func (s *AttachmentService) FinalizeUploadSession(ctx context.Context, token, id string) (*attachment.UploadSession, error) {
+	return s.attachments.FinalizeUploadSession(ctx, token, id)
+}
+
+// AbortUploadSession 终止断点续传会话
+func (s *AttachmentService) AbortUploadSession(ctx context.Context, token, id string) error {
+	return s.attachments.AbortUploadSession(ctx, token, id)
+}
+
+// DeleteFile 删除附件，需要附件所在记录的更新权限；仍被单元格引用的附件需先从单元格中移除
+func (s *AttachmentService) DeleteFile(ctx context.Context, userID, id string) error {
+	item, err := s.attachments.GetAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkAttachmentAccess(ctx, userID, item, permission.ActionRecordUpdate); err != nil {
+		return err
+	}
+
+	count, err := s.linkRepo.CountLinks(ctx, item.Token)
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("统计附件引用失败: %v", err))
+	}
+	if count > 0 {
+		return pkgerrors.ErrConflict.WithDetails(map[string]interface{}{
+			"message":    "附件仍被单元格引用，请先从单元格中移除",
+			"references": count,
+		})
+	}
+
+	if err := s.attachments.DeleteFile(ctx, id); err != nil {
+		return err
+	}
+	s.addUsage(ctx, item.TableID, -item.Size, -1)
+	return nil
+}
+
+// GetSpaceUsage 获取空间附件存储用量与配额
+func (s *AttachmentService) GetSpaceUsage(ctx context.Context, userID, spaceID string) (*attachment.SpaceUsage, error) {
+	if err := s.checkSpaceAccess(ctx, userID, spaceID); err != nil {
+		return nil, err
+	}
+	return s.spaceUsage(ctx, spaceID)
+}
+
+// spaceUsage 空间附件存储用量与配额
+func (s *AttachmentService) spaceUsage(ctx context.Context, spaceID string) (*attachment.SpaceUsage, error) {
+	usage, err := s.usageRepo.GetUsage(ctx, spaceID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询存储用量失败: %v", err))
+	}
+	if usage.Quota, err = s.storageQuota(ctx, spaceID); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// storageQuota 空间的存储配额（字节），0 表示不限制
+func (s *AttachmentService) storageQuota(ctx context.Context, spaceID string) (int64, error) {
+	if s.quotas == nil {
+		return s.spaceQuota, nil
+	}
+	return s.quotas.StorageQuota(ctx, spaceID)
+}
+
+// GetDedupReport 获取空间附件去重报告：逻辑大小、实际占用与节省最多的重复内容
+func (s *AttachmentService) GetDedupReport(ctx context.Context, userID, spaceID string) (*attachment.DedupReport, error) {
+	if err := s.checkSpaceAccess(ctx, userID, spaceID); err != nil {
+		return nil, err
+	}
+	tableIDs, err := s.spaceTableIDs(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.repo.GetDedupStats(ctx, tableIDs, dedupReportTop)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("统计附件去重失败: %v", err))
+	}
+	return attachment.NewDedupReport(spaceID, stats), nil
+}
+
+// SyncRecordAttachments 按记录当前的附件单元格同步附件引用
+// values 为记录变更后的值（以字段ID为键），记录删除时传 nil；
+// 新出现的附件建立引用，移除的附件删除引用，没有剩余引用的附件连同文件一起删除
+func (s *AttachmentService) SyncRecordAttachments(ctx context.Context, tableID, recordID string, values map[string]interface{}) {
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("同步附件引用失败：查询字段出错",
+			logger.String("table_id", tableID),
+			logger.String("record_id", recordID),
+			logger.ErrorField(err))
+		return
+	}
+	attachmentFields := make(map[string]bool)
+	for _, field := range fields {
+		if field.Type().String() == fieldValueobject.TypeAttachment {
+			attachmentFields[field.ID().String()] = true
+		}
+	}
+	if len(attachmentFields) == 0 {
+		return
+	}
+
+	existing, err := s.linkRepo.ListLinks(ctx, tableID, recordID)
+	if err != nil {
+		logger.Warn("同步附件引用失败：查询引用出错",
+			logger.String("table_id", tableID),
+			logger.String("record_id", recordID),
+			logger.ErrorField(err))
+		return
+	}
+	linked := make(map[string]map[string]bool)
+	for _, link := range existing {
+		if linked[link.FieldID] == nil {
+			linked[link.FieldID] = make(map[string]bool)
+		}
+		linked[link.FieldID][link.Token] = true
+	}
+
+	userID, _ := values["__last_modified_by"].(string)
+	var created []*attachment.CellLink
+	var released []string
+	for fieldID := range attachmentFields {
+		current := attachment.CellTokens(values[fieldID])
+
+		for token, name := range current {
+			if linked[fieldID][token] {
+				continue
+			}
+			item, err := s.repo.GetAttachmentByToken(ctx, token)
+			if err != nil {
+				// 单元格中可能是外部写入的附件项，没有对应的附件记录时不建立引用
+				continue
+			}
+			createdBy := userID
+			if createdBy == "" {
+				createdBy = item.CreatedBy
+			}
+			created = append(created, &attachment.CellLink{
+				AttachmentID: item.ID,
+				Token:        token,
+				Name:         name,
+				TableID:      tableID,
+				RecordID:     recordID,
+				FieldID:      fieldID,
+				CreatedBy:    createdBy,
+			})
+		}
+
+		var removed []string
+		for token := range linked[fieldID] {
+			if _, ok := current[token]; !ok {
+				removed = append(removed, token)
+			}
+		}
+		if len(removed) == 0 {
+			continue
+		}
+		if err := s.linkRepo.DeleteLinks(ctx, tableID, recordID, fieldID, removed); err != nil {
+			logger.Warn("删除附件引用失败",
+				logger.String("table_id", tableID),
+				logger.String("record_id", recordID),
+				logger.String("field_id", fieldID),
+				logger.ErrorField(err))
+			continue
+		}
+		released = append(released, removed...)
+	}
+
+	if err := s.linkRepo.CreateLinks(ctx, created); err != nil {
+		logger.Warn("创建附件引用失败",
+			logger.String("table_id", tableID),
+			logger.String("record_id", recordID),
+			logger.ErrorField(err))
+	}
+
+	for _, token := range released {
+		s.releaseAttachment(ctx, token)
+	}
+}
+
+// ==================== 内部方法 ====================
+
+// checkAttachmentField 校验字段属于该表且为附件字段
+func (s *AttachmentService) checkAttachmentField(ctx context.Context, tableID, fieldID string) error {
+	field, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(fieldID))
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+	}
+	if field == nil || field.TableID() != tableID {
+		return pkgerrors.ErrNotFound.WithDetails("字段不存在")
+	}
+	if field.Type().String() != fieldValueobject.TypeAttachment {
+		return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message":  "只有附件字段支持上传文件",
+			"field_id": fieldID,
+		})
+	}
+	return nil
+}
+
+// checkSpaceAccess 校验用户能访问空间
+func (s *AttachmentService) checkSpaceAccess(ctx context.Context, userID, spaceID string) error {
+	if s.permissions != nil && !s.permissions.CanAccessSpace(ctx, userID, spaceID) {
+		return pkgerrors.ErrSpaceNotAccessible.WithDetails(map[string]interface{}{"space_id": spaceID})
+	}
+	return nil
+}
+
+// resolveTableAccess 校验用户在表上的角色权限，并计算行级访问范围
+func (s *AttachmentService) resolveTableAccess(ctx context.Context, userID, tableID string, action permission.Action) (*permission.RowAccess, error) {
+	if s.permissions != nil {
+		allowed := false
+		switch action {
+		case permission.ActionRecordUpdate:
+			allowed = s.permissions.CanUpdateRecordsInTable(ctx, userID, tableID)
+		default:
+			allowed = s.permissions.CanAccessRecord(ctx, userID, tableID)
+		}
+		if !allowed {
+			return nil, pkgerrors.ErrForbidden.WithDetails(fmt.Sprintf("当前角色没有 %s 权限", action))
+		}
+	}
+	if s.rowPermission == nil {
+		return &permission.RowAccess{Allowed: true, Unrestricted: true}, nil
+	}
+	access, err := s.rowPermission.ResolveAccess(ctx, tableID, userID, action)
+	if err != nil {
+		return nil, err
+	}
+	if !access.Allowed {
+		return nil, pkgerrors.ErrForbidden.WithDetails(fmt.Sprintf("当前角色没有 %s 权限", action))
+	}
+	return access, nil
+}
+
+// checkRecordAccess 校验用户能否对记录执行操作（表级角色权限 + 行级规则）
+// 记录已删除时只有不受行级规则限制的用户可以访问
+func (s *AttachmentService) checkRecordAccess(ctx context.Context, userID, tableID, recordID string, action permission.Action) error {
+	access, err := s.resolveTableAccess(ctx, userID, tableID, action)
+	if err != nil || access.Unrestricted {
+		return err
+	}
+
+	record, err := s.recordRepo.FindByTableAndID(ctx, tableID, valueobject.NewRecordID(recordID))
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+	}
+	if record == nil {
+		return pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+	return s.rowPermission.CheckRecord(ctx, tableID, userID, action, RecordValues(record))
+}
+
+// checkAttachmentAccess 校验用户能否对附件执行操作：附件所在记录或引用它的任一单元格满足即可
+func (s *AttachmentService) checkAttachmentAccess(ctx context.Context, userID string, item *attachment.AttachmentItem, action permission.Action) error {
+	cells := []attachment.CellLink{{TableID: item.TableID, RecordID: item.RecordID}}
+	links, err := s.linkRepo.ListLinksByToken(ctx, item.Token)
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找附件引用失败: %v", err))
+	}
+	for _, link := range links {
+		cells = append(cells, *link)
+	}
+
+	lastErr := error(pkgerrors.ErrNotFound.WithDetails("附件不存在"))
+	checked := make(map[string]bool, len(cells))
+	for _, cell := range cells {
+		key := cell.TableID + "/" + cell.RecordID
+		if cell.TableID == "" || checked[key] {
+			continue
+		}
+		checked[key] = true
+		if lastErr = s.checkRecordAccess(ctx, userID, cell.TableID, cell.RecordID, action); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+// filterReadableItems 只保留行级规则下可读取记录中的附件
+func (s *AttachmentService) filterReadableItems(ctx context.Context, tableID string, items []*attachment.AttachmentItem, access *permission.RowAccess) ([]*attachment.AttachmentItem, error) {
+	ids := make([]valueobject.RecordID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, valueobject.NewRecordID(item.RecordID))
+	}
+	records, err := s.recordRepo.FindByIDs(ctx, tableID, ids)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+	}
+	readable := make(map[string]bool, len(records))
+	for _, record := range records {
+		readable[record.ID().String()] = access.Match(RecordValues(record))
+	}
+
+	result := make([]*attachment.AttachmentItem, 0, len(items))
+	for _, item := range items {
+		if readable[item.RecordID] {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// attachmentForPath 找到文件路径对应的附件：预览产物按所属附件，原文件优先按附件令牌
+func (s *AttachmentService) attachmentForPath(ctx context.Context, path, token string) (*attachment.AttachmentItem, error) {
+	var (
+		item *attachment.AttachmentItem
+		err  error
+	)
+	if attachmentID, _, ok := attachment.ParsePreviewPath(path); ok {
+		item, err = s.repo.GetAttachmentByID(ctx, attachmentID)
+	} else if token != "" {
+		if item, err = s.repo.GetAttachmentByToken(ctx, token); err != nil || item.Path != path {
+			item, err = s.repo.GetAttachmentByPath(ctx, path)
+		}
+	} else {
+		item, err = s.repo.GetAttachmentByPath(ctx, path)
+	}
+	if err != nil {
+		if err == pkgerrors.ErrNotFound {
+			return nil, pkgerrors.ErrNotFound.WithDetails("File not found")
+		}
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找附件失败: %v", err))
+	}
+	return item, nil
+}
+
+// getUploadToken 获取上传令牌，令牌无效时返回请求错误
+func (s *AttachmentService) getUploadToken(ctx context.Context, token string) (*attachment.UploadToken, error) {
+	uploadToken, err := s.tokenRepo.GetUploadToken(ctx, token)
+	if err != nil {
+		if err == pkgerrors.ErrNotFound {
+			return nil, pkgerrors.ErrBadRequest.WithDetails("Invalid upload token")
+		}
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找上传令牌失败: %v", err))
+	}
+	return uploadToken, nil
+}
+
+// attach 计入空间用量并把附件追加到令牌指定的单元格，再排队生成预览
+// 单元格写入失败时删除刚保存的附件
+func (s *AttachmentService) attach(ctx context.Context, uploadToken *attachment.UploadToken, item *attachment.AttachmentItem, userID string) error {
+	s.addUsage(ctx, item.TableID, item.Size, 1)
+
+	// 预览状态随附件项写入单元格，生成完成后再写回缩略图
+	queued := s.previews != nil && s.previews.Prepare(ctx, item)
+
+	if err := s.appendToCell(ctx, uploadToken, item, userID); err != nil {
+		s.removeAttachment(ctx, item)
+		return err
+	}
+	if queued {
+		s.previews.Enqueue(item.ID)
+	}
+	return nil
+}
+
+// appendToCell 把附件追加到令牌指定的单元格
+func (s *AttachmentService) appendToCell(ctx context.Context, uploadToken *attachment.UploadToken, item *attachment.AttachmentItem, userID string) error {
+	if s.recordService == nil {
+		return nil
+	}
+
+	// 带版本号更新，并发上传到同一单元格时冲突的一方重新读取后追加，避免互相覆盖
+	var err error
+	for attempt := 0; attempt < appendCellMaxAttempts; attempt++ {
+		if err = s.tryAppendToCell(ctx, uploadToken, item, userID); !isConflictError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// tryAppendToCell 读取单元格当前内容并按读取时的版本追加附件
+func (s *AttachmentService) tryAppendToCell(ctx context.Context, uploadToken *attachment.UploadToken, item *attachment.AttachmentItem, userID string) error {
+	record, err := s.recordRepo.FindByTableAndID(ctx, uploadToken.TableID, valueobject.NewRecordID(uploadToken.RecordID))
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+	}
+	if record == nil {
+		return pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+
+	cell := attachment.CellItems(record.Data().ToMap()[uploadToken.FieldID])
+	value := make([]interface{}, 0, len(cell)+1)
+	for _, existing := range cell {
+		value = append(value, existing)
+	}
+	value = append(value, item.ToCellValue())
+
+	version := int(record.Version().Value())
+	_, err = s.recordService.UpdateRecord(ctx, uploadToken.TableID, uploadToken.RecordID, dto.UpdateRecordRequest{
+		Data:    map[string]interface{}{uploadToken.FieldID: value},
+		Version: &version,
+	}, userID)
+	return err
+}
+
+// releaseAttachment 附件没有剩余引用时删除附件和文件
+func (s *AttachmentService) releaseAttachment(ctx context.Context, token string) {
+	count, err := s.linkRepo.CountLinks(ctx, token)
+	if err != nil || count > 0 {
+		return
+	}
+	item, err := s.repo.GetAttachmentByToken(ctx, token)
+	if err != nil {
+		return
+	}
+	s.removeAttachment(ctx, item)
+}
+
+// removeAttachment 删除附件和文件，并从空间用量中扣除
+func (s *AttachmentService) removeAttachment(ctx context.Context, item *attachment.AttachmentItem) {
+	if err := s.attachments.DeleteFile(ctx, item.ID); err != nil {
+		logger.Warn("删除附件失败",
+			logger.String("attachment_id", item.ID),
+			logger.ErrorField(err))
+		return
+	}
+	s.addUsage(ctx, item.TableID, -item.Size, -1)
+}
+
+// checkQuota 校验表所在空间写入 size 字节后仍在配额内
+func (s *AttachmentService) checkQuota(ctx context.Context, tableID string, size int64) error {
+	if s.quotas == nil && s.spaceQuota <= 0 {
+		return nil
+	}
+	spaceID, err := s.resolveSpaceID(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	usage, err := s.spaceUsage(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	if !usage.Allows(size) {
+		return pkgerrors.ErrStorageQuotaExceeded.WithDetails(map[string]interface{}{
+			"space_id":   spaceID,
+			"used_bytes": usage.UsedBytes,
+			"quota":      usage.Quota,
+		})
+	}
+	return nil
+}
+
+// addUsage 累加表所在空间的存储用量，失败只记录日志
+func (s *AttachmentService) addUsage(ctx context.Context, tableID string, bytes, files int64) {
+	spaceID, err := s.resolveSpaceID(ctx, tableID)
+	if err == nil {
+		err = s.usageRepo.AddUsage(ctx, spaceID, bytes, files)
+	}
+	if err != nil {
+		logger.Warn("更新空间存储用量失败",
+			logger.String("table_id", tableID),
+			logger.Int64("bytes", bytes),
+			logger.ErrorField(err))
+	}
+}
+
+// spaceTableIDs 列出空间内全部表的ID
+func (s *AttachmentService) spaceTableIDs(ctx context.Context, spaceID string) ([]string, error) {
+	bases, err := s.baseRepo.FindBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找Base失败: %v", err))
+	}
+	var tableIDs []string
+	for _, base := range bases {
+		tables, err := s.tableRepo.GetByBaseID(ctx, base.ID)
+		if err != nil {
+			return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找表失败: %v", err))
+		}
+		for _, table := range tables {
+			tableIDs = append(tableIDs, table.ID().String())
+		}
+	}
+	return tableIDs, nil
+}
+
+// resolveSpaceID 通过表所属的 Base 找到空间
+func (s *AttachmentService) resolveSpaceID(ctx context.Context, tableID string) (string, error) {
+	table, err := s.tableRepo.GetByID(ctx, tableID)
+	if err != nil {
+		return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找表失败: %v", err))
+	}
+	if table == nil {
+		return "", pkgerrors.ErrTableNotFound.WithDetails(map[string]interface{}{"table_id": tableID})
+	}
+	base, err := s.baseRepo.FindByID(ctx, table.BaseID())
+	if err != nil {
+		return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找Base失败: %v", err))
+	}
+	if base == nil {
+		return "", pkgerrors.ErrBaseNotFound.WithDetails(map[string]interface{}{"base_id": table.BaseID()})
+	}
+	return base.SpaceID, nil
+}
@@ -18,9 +18,10 @@ import (
 // BaseService Base应用服务（对齐原版BaseService）
 // 集成完全动态表架构：每个Base独立Schema
 type BaseService struct {
-	repo       repository.BaseRepository
-	spaceRepo  spaceRepository.SpaceRepository // 用于检查父空间是否存在
-	dbProvider database.DBProvider             // ✅ 数据库提供者（Schema管理）
+	repo         repository.BaseRepository
+	spaceRepo    spaceRepository.SpaceRepository // 用于检查父空间是否存在
+	dbProvider   database.DBProvider             // ✅ 数据库提供者（Schema管理）
+	fieldService *FieldService                   // ✨ 清理其他 Base 中关联到该 Base 的 Link 字段
 }
 
 // NewBaseService 创建Base服务
@@ -32,6 +33,11 @@ func NewBaseService(repo repository.BaseRepository, spaceRepo spaceRepository.Sp
 	}
 }
 
+// SetFieldService 设置字段服务（用于删除 Base 时清理跨 Base 关联）
+func (s *BaseService) SetFieldService(fieldService *FieldService) {
+	s.fieldService = fieldService
+}
+
 // CreateBase 创建Base（严格遵守：返回AppError）
 // ✅ 完全动态表架构：创建Base时创建独立Schema
 // 严格按照旧系统实现：teable-develop/apps/nestjs-backend/src/features/base/base.service.ts
@@ -212,6 +218,13 @@ func (s *BaseService) DeleteBase(ctx context.Context, baseID string) error {
 	logger.Info("正在删除Base及其Schema",
 		logger.String("base_id", baseID))
 
+	// 2.1 ✨ 删除其他 Base 中关联到该 Base 的 Link 字段（含对称字段和关联存储）
+	if s.fieldService != nil {
+		if err := s.fieldService.DeleteLinksToBase(ctx, baseID); err != nil {
+			return err
+		}
+	}
+
 	// 3. ✅ 删除Schema（CASCADE会自动删除其中所有的物理表）
 	// 参考旧系统：DROP SCHEMA IF EXISTS base_id CASCADE
 	if s.dbProvider.SupportsSchema() {
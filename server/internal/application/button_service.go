@@ -0,0 +1,476 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+	"github.com/easyspace-ai/luckdb/server/pkg/database"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// 按钮动作参数
+const (
+	buttonWebhookTimeout   = 10 * time.Second
+	buttonWebhookBodyLimit = 4096 // 失败时记录的响应内容长度上限
+	buttonWebhookSignature = "X-LuckDB-Signature"
+	buttonWorkflowTrigger  = "button"
+	buttonClickMaxAttempts = 5 // 点击状态版本冲突时的最大尝试次数
+)
+
+// ButtonService 按钮字段点击服务 ✨
+//
+// 设计考量：
+//   - 动作在服务端执行：open_url 渲染链接返回给客户端，trigger_automation 以记录为输入运行工作流，
+//     call_webhook 同步调用配置的地址；run_script 只能在客户端执行，服务端拒绝
+//   - 配置了确认提示时，未确认的点击只返回提示内容，不执行动作
+//   - 配置了允许角色时按角色判断，否则与编辑记录的权限一致（含行级规则）
+//   - 点击次数、最近点击人/时间、最近结果保存在单元格中，并通过 WebSocket 推送执行结果
+//   - 执行动作前先以带版本号的更新计入点击（状态为 running），动作完成后再回写结果；
+//     回写失败不影响返回的动作结果
+type ButtonService struct {
+	fieldRepo     repository.FieldRepository
+	recordRepo    recordRepo.RecordRepository
+	workflows     *WorkflowService
+	httpClient    *http.Client
+	recordService *RecordService        // ✨ 点击状态写入后推送记录更新
+	rowPermission *RowPermissionService // ✨ 点击权限与推送范围
+	wsService     websocket.Service     // ✨ 推送点击结果
+}
+
+// NewButtonService 创建按钮字段点击服务
+func NewButtonService(
+	fieldRepo repository.FieldRepository,
+	recordRepo recordRepo.RecordRepository,
+	workflows *WorkflowService,
+) *ButtonService {
+	return &ButtonService{
+		fieldRepo:  fieldRepo,
+		recordRepo: recordRepo,
+		workflows:  workflows,
+		httpClient: utils.NewOutboundHTTPClient(buttonWebhookTimeout),
+	}
+}
+
+// SetRecordService 设置记录服务（用于延迟注入）
+func (s *ButtonService) SetRecordService(recordService *RecordService) {
+	s.recordService = recordService
+}
+
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (s *ButtonService) SetRowPermissionService(rowPermission *RowPermissionService) {
+	s.rowPermission = rowPermission
+}
+
+// SetWebSocketService 设置 WebSocket 服务（用于延迟注入）
+func (s *ButtonService) SetWebSocketService(wsService websocket.Service) {
+	s.wsService = wsService
+}
+
+// Click 点击记录上的按钮并执行配置的动作
+func (s *ButtonService) Click(ctx context.Context, tableID, recordID, fieldID string, req dto.ButtonClickRequest, userID string) (*dto.ButtonClickResponse, error) {
+	field, options, err := s.loadButtonField(ctx, tableID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.recordRepo.FindByTableAndID(ctx, tableID, valueobject.NewRecordID(recordID))
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+	}
+	if record == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+
+	values := RecordValues(record)
+	if err := s.checkClickPermission(ctx, tableID, userID, options, values); err != nil {
+		return nil, err
+	}
+
+	if options.Action == fieldValueobject.ButtonActionRunScript {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message":  "run_script 动作只能在客户端执行",
+			"field_id": fieldID,
+		})
+	}
+
+	result := &dto.ButtonClickResponse{
+		FieldID:  fieldID,
+		RecordID: recordID,
+		Action:   options.Action,
+	}
+	if options.Confirm != nil && !req.Confirmed {
+		result.Status = dto.ButtonClickStatusConfirmationRequired
+		result.Confirm = options.Confirm
+		return result, nil
+	}
+
+	valuesByName, err := s.valuesByName(ctx, tableID, record)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先以带版本号的更新计入本次点击，并发点击不会丢失计数，也不会越过点击次数上限
+	clickedAt := time.Now()
+	record, reserved, err := s.reserveClick(ctx, record, fieldID, options, userID, clickedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch options.Action {
+	case fieldValueobject.ButtonActionOpenURL:
+		err = s.openURL(options, valuesByName, result)
+	case fieldValueobject.ButtonActionTriggerAutomation:
+		err = s.triggerWorkflow(ctx, options, field, record, valuesByName, userID, result)
+	case fieldValueobject.ButtonActionCallWebhook:
+		err = s.callWebhook(ctx, options, field, record, valuesByName, userID, clickedAt, result)
+	default:
+		err = fmt.Errorf("unsupported button action %q", options.Action)
+	}
+	if err != nil {
+		result.Status = dto.ButtonClickStatusFailed
+		result.Error = err.Error()
+		logger.Warn("按钮动作执行失败",
+			logger.String("field_id", fieldID),
+			logger.String("record_id", recordID),
+			logger.String("action", options.Action),
+			logger.ErrorField(err))
+	} else {
+		result.Status = dto.ButtonClickStatusSuccess
+	}
+
+	// 动作已经执行，回写结果失败时只在 ClickError 中说明，不影响动作的结果
+	// 客户端可能在 Webhook 调用期间断开，回写不随请求取消
+	record, state, err := s.finishClick(context.WithoutCancel(ctx), record, fieldID, reserved, result.Status, userID)
+	if err != nil {
+		result.ClickError = err.Error()
+		logger.Warn("保存按钮点击结果失败",
+			logger.String("field_id", fieldID),
+			logger.String("record_id", recordID),
+			logger.ErrorField(err))
+	}
+	result.Click = &state
+
+	s.broadcastResult(ctx, tableID, RecordValues(record), result, userID)
+	return result, nil
+}
+
+// ==================== 内部方法 ====================
+
+// loadButtonField 加载表中的按钮字段
+func (s *ButtonService) loadButtonField(ctx context.Context, tableID, fieldID string) (*fieldEntity.Field, *fieldValueobject.ButtonOptions, error) {
+	field, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(fieldID))
+	if err != nil {
+		return nil, nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+	}
+	if field == nil || field.TableID() != tableID {
+		return nil, nil, pkgerrors.ErrNotFound.WithDetails("字段不存在")
+	}
+	if field.Type().String() != fieldValueobject.TypeButton || field.Options() == nil || field.Options().Button == nil {
+		return nil, nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message":  "只有配置了动作的按钮字段支持点击",
+			"field_id": fieldID,
+		})
+	}
+	return field, field.Options().Button, nil
+}
+
+// checkClickPermission 校验用户能否点击按钮
+func (s *ButtonService) checkClickPermission(ctx context.Context, tableID, userID string, options *fieldValueobject.ButtonOptions, values map[string]interface{}) error {
+	if s.rowPermission == nil {
+		return nil
+	}
+
+	// 读取不到的记录返回 404
+	if err := s.rowPermission.CheckRecord(ctx, tableID, userID, permission.ActionRecordRead, values); err != nil {
+		return err
+	}
+
+	if len(options.AllowedRoles) == 0 {
+		return s.rowPermission.CheckRecord(ctx, tableID, userID, permission.ActionRecordUpdate, values)
+	}
+
+	role, err := s.rowPermission.ResolveRole(ctx, tableID, userID)
+	if err != nil {
+		return err
+	}
+	if !options.RoleAllowed(string(role)) {
+		return pkgerrors.ErrForbidden.WithDetails(map[string]interface{}{
+			"message":       "当前角色不能点击该按钮",
+			"role":          role,
+			"allowed_roles": options.AllowedRoles,
+		})
+	}
+	return nil
+}
+
+// valuesByName 以字段名为键的记录值，用于渲染模板和动作输入
+func (s *ButtonService) valuesByName(ctx context.Context, tableID string, record *entity.Record) (map[string]interface{}, error) {
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取字段列表失败: %v", err))
+	}
+
+	data := record.Data().ToMap()
+	values := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		if f.Type().String() == fieldValueobject.TypeButton {
+			continue
+		}
+		if value, ok := data[f.ID().String()]; ok {
+			values[f.Name().String()] = value
+		}
+	}
+	return values, nil
+}
+
+// openURL 渲染链接，由客户端打开
+func (s *ButtonService) openURL(options *fieldValueobject.ButtonOptions, values map[string]interface{}, result *dto.ButtonClickResponse) error {
+	rendered, err := fieldValueobject.RenderButtonURL(options.URLTemplate(), values)
+	if err != nil {
+		return err
+	}
+	result.URL = rendered
+	return nil
+}
+
+// triggerWorkflow 以记录为输入运行工作流
+func (s *ButtonService) triggerWorkflow(ctx context.Context, options *fieldValueobject.ButtonOptions, field *fieldEntity.Field, record *entity.Record, values map[string]interface{}, userID string, result *dto.ButtonClickResponse) error {
+	if s.workflows == nil {
+		return fmt.Errorf("workflow service is not configured")
+	}
+
+	input := map[string]interface{}{
+		"tableId":  field.TableID(),
+		"recordId": record.ID().String(),
+		"fieldId":  field.ID().String(),
+		"fields":   values,
+	}
+	run, err := s.workflows.RunWithTrigger(ctx, options.TargetWorkflowID(), userID, buttonWorkflowTrigger, input)
+	if err != nil {
+		return fmt.Errorf("failed to run workflow %s: %w", options.TargetWorkflowID(), err)
+	}
+	result.RunID = run.ID
+	return nil
+}
+
+// callWebhook 同步调用配置的 Webhook，2xx 视为成功
+func (s *ButtonService) callWebhook(ctx context.Context, options *fieldValueobject.ButtonOptions, field *fieldEntity.Field, record *entity.Record, values map[string]interface{}, userID string, clickedAt time.Time, result *dto.ButtonClickResponse) error {
+	webhook := options.Webhook
+	if webhook == nil || webhook.URL == "" {
+		return fmt.Errorf("webhook url is not configured")
+	}
+	// 只允许请求公网地址，连接时还会按解析出的 IP 再校验一次
+	if err := utils.ValidateOutboundURL(webhook.URL); err != nil {
+		return fmt.Errorf("webhook url is not allowed: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"event":       "button.click",
+		"tableId":     field.TableID(),
+		"recordId":    record.ID().String(),
+		"fieldId":     field.ID().String(),
+		"fields":      values,
+		"clickedBy":   userID,
+		"clickedTime": clickedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(webhook.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range webhook.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	if webhook.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(webhook.Secret))
+		mac.Write(body)
+		httpReq.Header.Set(buttonWebhookSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, buttonWebhookBodyLimit))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// reserveClick 计入一次点击并把状态置为 running，返回保存后的记录和点击状态
+// 保存带版本号检查，版本冲突时重新读取记录后重试；已达点击次数上限时拒绝，动作不会执行
+func (s *ButtonService) reserveClick(ctx context.Context, record *entity.Record, fieldID string, options *fieldValueobject.ButtonOptions, userID string, clickedAt time.Time) (*entity.Record, fieldValueobject.ButtonClickState, error) {
+	for attempt := 1; ; attempt++ {
+		state := fieldValueobject.ParseButtonClickState(record.Data().ToMap()[fieldID])
+		if options.ClickLimitReached(state) {
+			return nil, state, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"message":    "按钮点击次数已达上限",
+				"field_id":   fieldID,
+				"max_clicks": options.MaxClicks,
+			})
+		}
+
+		reserved := state.Click(userID, dto.ButtonClickStatusRunning, clickedAt)
+		err := s.saveClickState(ctx, record, fieldID, reserved, userID)
+		if err == nil {
+			return record, reserved, nil
+		}
+		if !isConflictError(err) || attempt >= buttonClickMaxAttempts {
+			return nil, reserved, err
+		}
+		if record, err = s.reloadRecord(ctx, record); err != nil {
+			return nil, reserved, err
+		}
+	}
+}
+
+// finishClick 把预占的点击状态更新为动作结果
+// 期间已有新的点击时保留新点击的状态，返回单元格中的当前状态
+func (s *ButtonService) finishClick(ctx context.Context, record *entity.Record, fieldID string, reserved fieldValueobject.ButtonClickState, status, userID string) (*entity.Record, fieldValueobject.ButtonClickState, error) {
+	finished := reserved
+	finished.LastStatus = status
+
+	for attempt := 1; ; attempt++ {
+		current := fieldValueobject.ParseButtonClickState(record.Data().ToMap()[fieldID])
+		if !current.SameClick(reserved) {
+			return record, current, nil
+		}
+
+		err := s.saveClickState(ctx, record, fieldID, finished, userID)
+		if err == nil {
+			return record, finished, nil
+		}
+		if !isConflictError(err) || attempt >= buttonClickMaxAttempts {
+			return record, finished, err
+		}
+		reloaded, err := s.reloadRecord(ctx, record)
+		if err != nil {
+			return record, finished, err
+		}
+		record = reloaded
+	}
+}
+
+// reloadRecord 重新读取记录（版本冲突后使用）
+func (s *ButtonService) reloadRecord(ctx context.Context, record *entity.Record) (*entity.Record, error) {
+	reloaded, err := s.recordRepo.FindByTableAndID(ctx, record.TableID(), record.ID())
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找记录失败: %v", err))
+	}
+	if reloaded == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+	return reloaded, nil
+}
+
+// saveClickState 带版本号检查保存点击状态并推送记录更新，版本冲突时原样返回 ErrConflict
+func (s *ButtonService) saveClickState(ctx context.Context, record *entity.Record, fieldID string, state fieldValueobject.ButtonClickState, userID string) error {
+	data, err := valueobject.NewRecordData(map[string]interface{}{fieldID: state.ToMap()})
+	if err != nil {
+		return pkgerrors.ErrInternalServer.WithDetails(err.Error())
+	}
+	previous := RecordValues(record)
+	if err := record.Update(data, userID); err != nil {
+		return pkgerrors.ErrInternalServer.WithDetails(err.Error())
+	}
+	if err := s.recordRepo.Save(ctx, record); err != nil {
+		if isConflictError(err) {
+			return err
+		}
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("保存点击状态失败: %v", err))
+	}
+
+	if s.recordService != nil {
+		event := &database.RecordEvent{
+			EventType:  "record.update",
+			TID:        record.TableID(),
+			RID:        record.ID().String(),
+			Fields:     record.Data().ToMap(),
+			UserID:     userID,
+			OldVersion: record.Version().Value() - 1,
+			NewVersion: record.Version().Value(),
+		}
+		s.recordService.publishRecordEvent(ctx, event, RecordValues(record), previous)
+	}
+	return nil
+}
+
+// broadcastResult 推送点击结果给能读取该记录的订阅者
+func (s *ButtonService) broadcastResult(ctx context.Context, tableID string, values map[string]interface{}, result *dto.ButtonClickResponse, userID string) {
+	if s.wsService == nil {
+		return
+	}
+
+	message := &websocket.Message{
+		Type: websocket.MessageTypeOp,
+		Data: &websocket.Operation{
+			Type:      websocket.OperationTypeButtonClick,
+			TableID:   tableID,
+			Timestamp: time.Now(),
+			UserID:    userID,
+			Data: &websocket.ButtonClickOp{
+				FieldID:   result.FieldID,
+				RecordID:  result.RecordID,
+				Action:    result.Action,
+				Status:    result.Status,
+				Result:    result.Result(),
+				Error:     result.Error,
+				ClickedBy: userID,
+			},
+		},
+	}
+
+	channel := fmt.Sprintf("table:%s", tableID)
+	var err error
+	if allow := s.recipientFilter(ctx, tableID, values); allow != nil {
+		err = s.wsService.BroadcastToChannelWhere(channel, message, allow)
+	} else {
+		err = s.wsService.BroadcastToChannel(channel, message)
+	}
+	if err != nil {
+		logger.Error("Failed to broadcast button click",
+			logger.String("table_id", tableID),
+			logger.String("field_id", result.FieldID),
+			logger.ErrorField(err))
+	}
+}
+
+// recipientFilter 行级规则下的推送范围，无规则时返回 nil
+func (s *ButtonService) recipientFilter(ctx context.Context, tableID string, values map[string]interface{}) func(userID string) bool {
+	if s.rowPermission == nil {
+		return nil
+	}
+	return s.rowPermission.RecipientFilter(ctx, tableID, values)
+}
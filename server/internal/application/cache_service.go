@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/cache"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
 
@@ -91,6 +92,7 @@ func (s *CacheService) Get(ctx context.Context, key string, dest interface{}) er
 				*destPtr = value
 			}
 			s.logCacheHit("local", key)
+			observability.RecordCacheLookup("local", true)
 			return nil
 		}
 	}
@@ -103,11 +105,13 @@ func (s *CacheService) Get(ctx context.Context, key string, dest interface{}) er
 				s.localCache.Set(fullKey, dest, s.config.LocalCacheTTL)
 			}
 			s.logCacheHit("redis", key)
+			observability.RecordCacheLookup("redis", true)
 			return nil
 		}
 	}
 
 	s.logCacheMiss(key)
+	observability.RecordCacheLookup("none", false)
 	return cache.ErrCacheNotFound
 }
 
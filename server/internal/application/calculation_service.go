@@ -4,15 +4,19 @@ import (
 	"context"
 	"regexp"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/easyspace-ai/luckdb/server/internal/domain/calculation/dependency"
 	formulaPkg "github.com/easyspace-ai/luckdb/server/internal/domain/calculation/formula"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/calculation/lookup"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/calculation/rollup"
 	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
 	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
 	"github.com/easyspace-ai/luckdb/server/pkg/errors"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
@@ -86,6 +90,13 @@ func NewCalculationService(
 //   - 使用依赖图确保计算顺序正确
 //   - 详细的日志记录，便于调试
 func (s *CalculationService) CalculateRecordFields(ctx context.Context, record *entity.Record) error {
+	defer observability.TrackCalculation("field")()
+	ctx, span := observability.StartSpan(ctx, "CalculationService.CalculateRecordFields",
+		attribute.String("table.id", record.TableID()),
+		attribute.String("record.id", record.ID().String()),
+	)
+	defer span.End()
+
 	// 1. 获取Table的所有字段
 	fields, err := s.fieldRepo.FindByTableID(ctx, record.TableID())
 	if err != nil {
@@ -311,6 +322,14 @@ func (s *CalculationService) CalculateAffectedFields(ctx context.Context, record
 		return nil
 	}
 
+	defer observability.TrackCalculation("field")()
+	ctx, span := observability.StartSpan(ctx, "CalculationService.CalculateAffectedFields",
+		attribute.String("table.id", record.TableID()),
+		attribute.String("record.id", record.ID().String()),
+		attribute.StringSlice("field.changed", changedFieldIDs),
+	)
+	defer span.End()
+
 	// 1. 获取所有字段
 	fields, err := s.fieldRepo.FindByTableID(ctx, record.TableID())
 	if err != nil {
@@ -641,8 +660,13 @@ func (s *CalculationService) calculateRollup(
 	}
 
 	// 3. 查询关联记录的目标字段值
+	// ✨ 关联记录位于 Link 字段的关联表（可能在其他 Base）
 	linkedRecordIDs := s.extractRecordIDs(linkValue)
-	values, err := s.fetchFieldValues(ctx, record.TableID(), linkedRecordIDs, rollupFieldID)
+	linkedTableID, err := s.linkedTableID(ctx, record.TableID(), linkFieldID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.fetchFieldValues(ctx, linkedTableID, linkedRecordIDs, rollupFieldID)
 	if err != nil {
 		return nil, err
 	}
@@ -686,7 +710,11 @@ func (s *CalculationService) calculateLookup(
 
 	// 3. 查询关联记录
 	linkedRecordIDs := s.extractRecordIDs(linkValue)
-	linkedRecordsMap, err := s.fetchRecordsMap(ctx, record.TableID(), linkedRecordIDs)
+	linkedTableID, err := s.linkedTableID(ctx, record.TableID(), linkFieldID)
+	if err != nil {
+		return nil, err
+	}
+	linkedRecordsMap, err := s.fetchRecordsMap(ctx, linkedTableID, linkedRecordIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -948,27 +976,26 @@ func (s *CalculationService) extractFormulaDependencies(field *fieldEntity.Field
 }
 
 // extractRecordIDs 从Link字段值中提取Record IDs
+// 支持 ID 字符串数组和 [{"id": "rec_xxx", "title": "..."}] 形式的关联缓存
 func (s *CalculationService) extractRecordIDs(linkValue interface{}) []string {
-	if linkValue == nil {
+	ids := fieldValueobject.ExtractLinkRecordIDs(linkValue)
+	if ids == nil {
 		return []string{}
 	}
+	return ids
+}
 
-	switch v := linkValue.(type) {
-	case string:
-		return []string{v}
-	case []string:
-		return v
-	case []interface{}:
-		result := make([]string, 0, len(v))
-		for _, item := range v {
-			if id, ok := item.(string); ok {
-				result = append(result, id)
-			}
-		}
-		return result
-	default:
-		return []string{}
+// linkedTableID 获取Link字段的关联表ID，未配置关联表时回退到当前表
+func (s *CalculationService) linkedTableID(ctx context.Context, tableID, linkFieldID string) (string, error) {
+	linkField, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(linkFieldID))
+	if err != nil {
+		return "", errors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if linkField == nil || linkField.Options() == nil || linkField.Options().Link == nil ||
+		linkField.Options().Link.LinkedTableID == "" {
+		return tableID, nil
 	}
+	return linkField.Options().Link.LinkedTableID, nil
 }
 
 // fetchFieldValues 批量查询字段值
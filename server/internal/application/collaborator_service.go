@@ -12,7 +12,8 @@ import (
 
 // CollaboratorService 协作者服务
 type CollaboratorService struct {
-	repo repository.CollaboratorRepository
+	repo          repository.CollaboratorRepository
+	subscriptions SubscriptionRevalidator // ✨ 角色变更/移除后重新校验实时订阅
 }
 
 // NewCollaboratorService 创建协作者服务
@@ -22,6 +23,11 @@ func NewCollaboratorService(repo repository.CollaboratorRepository) *Collaborato
 	}
 }
 
+// SetSubscriptionRevalidator 设置实时订阅重新校验（用于延迟注入）
+func (s *CollaboratorService) SetSubscriptionRevalidator(revalidator SubscriptionRevalidator) {
+	s.subscriptions = revalidator
+}
+
 // AddCollaborator 添加协作者
 func (s *CollaboratorService) AddCollaborator(
 	ctx context.Context,
@@ -101,18 +107,42 @@ func (s *CollaboratorService) UpdateCollaborator(
 	if err := s.repo.Update(ctx, collaborator); err != nil {
 		return nil, errors.ErrDatabaseOperation.WithDetails(err.Error())
 	}
+	s.revalidateSubscriptions(collaborator)
 
 	return s.toDTO(collaborator), nil
 }
 
 // RemoveCollaborator 移除协作者
 func (s *CollaboratorService) RemoveCollaborator(ctx context.Context, collaboratorID string) error {
+	collaborator, err := s.repo.GetByID(ctx, collaboratorID)
+	if err != nil {
+		return errors.ErrNotFound.WithDetails("协作者不存在")
+	}
+
 	if err := s.repo.Delete(ctx, collaboratorID); err != nil {
 		return errors.ErrDatabaseOperation.WithDetails(err.Error())
 	}
+	s.revalidateSubscriptions(collaborator)
 	return nil
 }
 
+// revalidateSubscriptions 后台重新校验受影响用户的实时订阅
+// 部门协作者无法直接定位到用户，重新校验所有连接
+func (s *CollaboratorService) revalidateSubscriptions(collaborator *entity.Collaborator) {
+	if s.subscriptions == nil {
+		return
+	}
+
+	go func() {
+		ctx := context.Background()
+		if collaborator.PrincipalType() == entity.PrincipalTypeUser {
+			s.subscriptions.RevalidateUser(ctx, collaborator.PrincipalID())
+			return
+		}
+		s.subscriptions.RevalidateAll(ctx)
+	}()
+}
+
 // toDTO 转换实体到DTO
 func (s *CollaboratorService) toDTO(collaborator *entity.Collaborator) *dto.CollaboratorResponse {
 	return &dto.CollaboratorResponse{
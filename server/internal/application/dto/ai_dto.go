@@ -0,0 +1,86 @@
+package dto
+
+import "time"
+
+// AIGenerateRequest AI 字段重新生成请求
+type AIGenerateRequest struct {
+	RecordIDs []string `json:"recordIds,omitempty"` // 为空时处理整张表
+	Force     bool     `json:"force"`               // true 时覆盖已有值；否则只生成空值
+}
+
+// AIGenerateError 单条记录生成失败
+type AIGenerateError struct {
+	RecordID string `json:"recordId"`
+	Error    string `json:"error"`
+}
+
+// AIGenerateResponse AI 字段重新生成结果
+type AIGenerateResponse struct {
+	FieldID          string             `json:"fieldId"`
+	Total            int                `json:"total"`     // 处理的记录数
+	Generated        int                `json:"generated"` // 调用模型生成的记录数
+	Cached           int                `json:"cached"`    // 命中缓存的记录数
+	Skipped          int                `json:"skipped"`   // 已有值或提示词为空而跳过的记录数
+	Failed           int                `json:"failed"`
+	Errors           []*AIGenerateError `json:"errors,omitempty"`
+	PromptTokens     int                `json:"promptTokens"`
+	CompletionTokens int                `json:"completionTokens"`
+}
+
+// AI 字段生成任务状态
+const (
+	AIGenerateJobQueued    = "queued"
+	AIGenerateJobRunning   = "running"
+	AIGenerateJobCompleted = "completed"
+	AIGenerateJobFailed    = "failed"
+)
+
+// AIGenerateJob AI 字段生成任务
+// 少量指定记录同步生成，返回时已完成；整张表或超过一批的记录在后台生成，按任务 ID 查询结果
+type AIGenerateJob struct {
+	JobID      string              `json:"jobId"`
+	FieldID    string              `json:"fieldId"`
+	Status     string              `json:"status"`
+	Result     *AIGenerateResponse `json:"result,omitempty"` // 结束后的生成结果
+	Error      string              `json:"error,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	FinishedAt *time.Time          `json:"finishedAt,omitempty"`
+}
+
+// Finished 任务是否已结束
+func (j *AIGenerateJob) Finished() bool {
+	return j.Status == AIGenerateJobCompleted || j.Status == AIGenerateJobFailed
+}
+
+// AddError 记录单条记录生成失败
+func (r *AIGenerateResponse) AddError(recordID string, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, &AIGenerateError{RecordID: recordID, Error: err.Error()})
+}
+
+// AIUsageQuery AI 用量查询参数（日期格式 YYYY-MM-DD，默认最近 30 天）
+type AIUsageQuery struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+// Range 解析查询的日期范围
+func (q AIUsageQuery) Range(now time.Time) (time.Time, time.Time, error) {
+	to := now
+	if q.To != "" {
+		parsed, err := time.Parse("2006-01-02", q.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -29)
+	if q.From != "" {
+		parsed, err := time.Parse("2006-01-02", q.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+	return from, to, nil
+}
@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/monitoring"
+)
+
+// CreateAlertRuleRequest 创建告警规则请求
+type CreateAlertRuleRequest struct {
+	Name                  string                 `json:"name" binding:"required"`
+	Description           string                 `json:"description"`
+	MetricName            string                 `json:"metricName" binding:"required"` // 如 luckdb_http_requests_in_flight；直方图可用 _count / _sum 后缀
+	MetricLabels          map[string]string      `json:"metricLabels"`                  // 只统计标签匹配的序列（求和）
+	Function              string                 `json:"function"`                      // value（默认）, rate
+	Operator              string                 `json:"operator" binding:"required"`   // gt, gte, lt, lte, eq, neq
+	Threshold             float64                `json:"threshold"`
+	ForSeconds            int64                  `json:"forSeconds"` // 条件持续满足多少秒后触发
+	Severity              string                 `json:"severity"`   // info, warning（默认）, critical
+	Channels              []string               `json:"channels"`   // 通知渠道，为空时发送到所有渠道
+	RepeatIntervalSeconds int64                  `json:"repeatIntervalSeconds"`
+	Labels                map[string]string      `json:"labels"` // 规则标签，用于静默匹配和通知内容
+	Annotations           map[string]interface{} `json:"annotations"`
+}
+
+// UpdateAlertRuleRequest 更新告警规则请求
+type UpdateAlertRuleRequest struct {
+	Name                  *string                `json:"name"`
+	Description           *string                `json:"description"`
+	MetricName            *string                `json:"metricName"`
+	MetricLabels          map[string]string      `json:"metricLabels"`
+	Function              *string                `json:"function"`
+	Operator              *string                `json:"operator"`
+	Threshold             *float64               `json:"threshold"`
+	ForSeconds            *int64                 `json:"forSeconds"`
+	Severity              *string                `json:"severity"`
+	Channels              []string               `json:"channels"`
+	RepeatIntervalSeconds *int64                 `json:"repeatIntervalSeconds"`
+	Labels                map[string]string      `json:"labels"`
+	Annotations           map[string]interface{} `json:"annotations"`
+	Enabled               *bool                  `json:"enabled"`
+}
+
+// AlertRuleResponse 告警规则响应
+type AlertRuleResponse struct {
+	ID                    string                 `json:"id"`
+	Name                  string                 `json:"name"`
+	Description           string                 `json:"description,omitempty"`
+	MetricName            string                 `json:"metricName"`
+	MetricLabels          map[string]string      `json:"metricLabels,omitempty"`
+	Function              string                 `json:"function"`
+	Operator              string                 `json:"operator"`
+	Threshold             float64                `json:"threshold"`
+	ForSeconds            int64                  `json:"forSeconds"`
+	Severity              string                 `json:"severity"`
+	Channels              []string               `json:"channels"`
+	RepeatIntervalSeconds int64                  `json:"repeatIntervalSeconds"`
+	Labels                map[string]string      `json:"labels,omitempty"`
+	Annotations           map[string]interface{} `json:"annotations,omitempty"`
+	Enabled               bool                   `json:"enabled"`
+	CreatedBy             string                 `json:"createdBy"`
+	CreatedAt             time.Time              `json:"createdAt"`
+	UpdatedAt             time.Time              `json:"updatedAt"`
+}
+
+// AlertResponse 告警历史响应
+type AlertResponse struct {
+	ID             string            `json:"id"`
+	RuleID         string            `json:"ruleId"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Severity       string            `json:"severity"`
+	Status         string            `json:"status"`
+	Instance       string            `json:"instance,omitempty"`
+	Value          float64           `json:"value"`
+	Threshold      float64           `json:"threshold"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	StartedAt      time.Time         `json:"startedAt"`
+	FiredAt        time.Time         `json:"firedAt"`
+	ResolvedAt     *time.Time        `json:"resolvedAt,omitempty"`
+	LastNotifiedAt *time.Time        `json:"lastNotifiedAt,omitempty"`
+	NotifyCount    int               `json:"notifyCount"`
+	Silenced       bool              `json:"silenced"`
+	LastError      string            `json:"lastError,omitempty"`
+}
+
+// AlertListResponse 告警历史列表响应
+type AlertListResponse struct {
+	Alerts []*AlertResponse `json:"alerts"`
+	Total  int64            `json:"total"`
+}
+
+// CreateAlertSilenceRequest 创建静默请求
+type CreateAlertSilenceRequest struct {
+	RuleID   string            `json:"ruleId"`   // 指定规则
+	Matchers map[string]string `json:"matchers"` // 或按规则标签匹配；都为空时静默所有告警
+	StartsAt *time.Time        `json:"startsAt"` // 默认立即开始
+	EndsAt   time.Time         `json:"endsAt" binding:"required"`
+	Comment  string            `json:"comment"`
+}
+
+// AlertSilenceResponse 静默响应
+type AlertSilenceResponse struct {
+	ID        string            `json:"id"`
+	RuleID    string            `json:"ruleId,omitempty"`
+	Matchers  map[string]string `json:"matchers,omitempty"`
+	StartsAt  time.Time         `json:"startsAt"`
+	EndsAt    time.Time         `json:"endsAt"`
+	Comment   string            `json:"comment,omitempty"`
+	Active    bool              `json:"active"`
+	CreatedBy string            `json:"createdBy"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// AlertTestResponse 测试通知结果
+type AlertTestResponse struct {
+	Results []monitoring.NotifyResult `json:"results"`
+}
+
+// FromAlertRule 从告警规则转换
+func FromAlertRule(rule *monitoring.AlertRule) *AlertRuleResponse {
+	channels := rule.Channels
+	if channels == nil {
+		channels = []string{}
+	}
+	function := string(rule.Condition.Function)
+	if function == "" {
+		function = string(monitoring.FunctionValue)
+	}
+	return &AlertRuleResponse{
+		ID:                    rule.ID,
+		Name:                  rule.Name,
+		Description:           rule.Description,
+		MetricName:            rule.Condition.MetricName,
+		MetricLabels:          rule.Condition.Labels,
+		Function:              function,
+		Operator:              string(rule.Condition.Operator),
+		Threshold:             rule.Condition.Threshold,
+		ForSeconds:            int64(rule.Condition.Duration / time.Second),
+		Severity:              string(rule.Severity),
+		Channels:              channels,
+		RepeatIntervalSeconds: int64(rule.RepeatInterval / time.Second),
+		Labels:                rule.Labels,
+		Annotations:           rule.Annotations,
+		Enabled:               rule.Enabled,
+		CreatedBy:             rule.CreatedBy,
+		CreatedAt:             rule.CreatedAt,
+		UpdatedAt:             rule.UpdatedAt,
+	}
+}
+
+// FromAlert 从告警转换
+func FromAlert(alert *monitoring.Alert) *AlertResponse {
+	return &AlertResponse{
+		ID:             alert.ID,
+		RuleID:         alert.RuleID,
+		Name:           alert.Name,
+		Description:    alert.Description,
+		Severity:       string(alert.Severity),
+		Status:         string(alert.Status),
+		Instance:       alert.Instance,
+		Value:          alert.Value,
+		Threshold:      alert.Threshold,
+		Labels:         alert.Labels,
+		StartedAt:      alert.StartedAt,
+		FiredAt:        alert.FiredAt,
+		ResolvedAt:     alert.ResolvedAt,
+		LastNotifiedAt: alert.LastNotifiedAt,
+		NotifyCount:    alert.NotifyCount,
+		Silenced:       alert.Silenced,
+		LastError:      alert.LastError,
+	}
+}
+
+// FromAlertSilence 从静默转换
+func FromAlertSilence(silence *monitoring.AlertSilence, now time.Time) *AlertSilenceResponse {
+	return &AlertSilenceResponse{
+		ID:        silence.ID,
+		RuleID:    silence.RuleID,
+		Matchers:  silence.Matchers,
+		StartsAt:  silence.StartsAt,
+		EndsAt:    silence.EndsAt,
+		Comment:   silence.Comment,
+		Active:    silence.Active(now),
+		CreatedBy: silence.CreatedBy,
+		CreatedAt: silence.CreatedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+
+// 按钮点击结果状态
+const (
+	ButtonClickStatusSuccess              = "success"
+	ButtonClickStatusFailed               = "failed"
+	ButtonClickStatusConfirmationRequired = "confirmation_required"
+	ButtonClickStatusRunning              = "running" // 点击已计数、动作尚未完成（只出现在单元格状态中）
+)
+
+// ButtonClickRequest 按钮点击请求
+type ButtonClickRequest struct {
+	Confirmed bool `json:"confirmed"` // 字段配置了确认提示时需为 true 才会执行
+}
+
+// ButtonClickResponse 按钮点击结果
+type ButtonClickResponse struct {
+	FieldID    string                                 `json:"fieldId"`
+	RecordID   string                                 `json:"recordId"`
+	Action     string                                 `json:"action"`
+	Status     string                                 `json:"status"`               // success / failed / confirmation_required
+	Confirm    *fieldValueobject.ButtonConfirmOptions `json:"confirm,omitempty"`    // 需要确认时返回确认提示
+	URL        string                                 `json:"url,omitempty"`        // open_url：渲染后的链接，由客户端打开
+	RunID      string                                 `json:"runId,omitempty"`      // trigger_automation：工作流运行ID
+	StatusCode int                                    `json:"statusCode,omitempty"` // call_webhook：Webhook 响应状态码
+	Error      string                                 `json:"error,omitempty"`
+	Click      *fieldValueobject.ButtonClickState     `json:"click,omitempty"`      // 点击后的单元格状态
+	ClickError string                                 `json:"clickError,omitempty"` // 动作已执行但回写结果失败时的原因，不影响 Status
+}
+
+// Result 推送给协作者的动作结果
+func (r *ButtonClickResponse) Result() map[string]interface{} {
+	result := make(map[string]interface{})
+	if r.URL != "" {
+		result["url"] = r.URL
+	}
+	if r.RunID != "" {
+		result["runId"] = r.RunID
+	}
+	if r.StatusCode != 0 {
+		result["statusCode"] = r.StatusCode
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
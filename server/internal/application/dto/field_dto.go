@@ -35,11 +35,36 @@ type FieldResponse struct {
 	Required    bool                   `json:"required"`
 	Unique      bool                   `json:"unique"`
 	IsPrimary   bool                   `json:"isPrimary"`
+	HasError    bool                   `json:"hasError,omitempty"` // 依赖的字段已删除等导致字段不可用
 	Description string                 `json:"description"`
 	CreatedAt   time.Time              `json:"createdAt"`
 	UpdatedAt   time.Time              `json:"updatedAt"`
 }
 
+// LinkCandidateQuery 关联记录选择器查询参数
+type LinkCandidateQuery struct {
+	Search string `form:"search"` // 按关联表标题字段模糊搜索
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+}
+
+// LinkCandidateResponse 可关联的记录
+type LinkCandidateResponse struct {
+	ID     string                 `json:"id"`
+	Title  interface{}            `json:"title"`  // 标题字段的值
+	Fields map[string]interface{} `json:"fields"` // 只包含 Link 字段配置的可见字段
+}
+
+// LinkCandidateListResponse 关联记录选择器响应
+type LinkCandidateListResponse struct {
+	TableID      string                   `json:"tableId"`      // 关联表ID
+	TitleFieldID string                   `json:"titleFieldId"` // 标题字段ID
+	Records      []*LinkCandidateResponse `json:"records"`
+	Total        int64                    `json:"total"`
+	Offset       int                      `json:"offset"`
+	Limit        int                      `json:"limit"`
+}
+
 // FieldListResponse 字段列表响应
 type FieldListResponse struct {
 	Fields []*FieldResponse `json:"fields"`
@@ -65,6 +90,7 @@ func FromFieldEntity(field *fieldEntity.Field) *FieldResponse {
 		Required:    field.IsRequired(),
 		Unique:      field.IsUnique(),
 		IsPrimary:   field.IsPrimary(),
+		HasError:    field.HasError(),
 		Description: desc,
 		CreatedAt:   field.CreatedAt(),
 		UpdatedAt:   field.UpdatedAt(),
@@ -99,6 +125,87 @@ func fieldOptionsToMap(options *fieldVO.FieldOptions) map[string]interface{} {
 		}
 	}
 
+	if options.Link != nil {
+		link := map[string]interface{}{
+			"linked_table_id": options.Link.LinkedTableID,
+			"relationship":    options.Link.Relationship,
+			"allow_multiple":  options.Link.AllowMultiple,
+			"is_symmetric":    options.Link.IsSymmetric,
+		}
+		if options.Link.SymmetricFieldID != "" {
+			link["symmetric_field_id"] = options.Link.SymmetricFieldID
+		}
+		if options.Link.BaseID != "" {
+			link["base_id"] = options.Link.BaseID
+		}
+		if options.Link.LookupFieldID != "" {
+			link["lookup_field_id"] = options.Link.LookupFieldID
+		}
+		if options.Link.FilterByViewID != nil {
+			link["filter_by_view_id"] = *options.Link.FilterByViewID
+		}
+		if len(options.Link.VisibleFieldIDs) > 0 {
+			link["visible_field_ids"] = options.Link.VisibleFieldIDs
+		}
+		if options.Link.Filter != nil {
+			link["filter"] = options.Link.Filter
+		}
+		result["link"] = link
+	}
+
+	if options.AI != nil {
+		ai := map[string]interface{}{
+			"provider": options.AI.Provider,
+			"model":    options.AI.Model,
+			"prompt":   options.AI.Prompt,
+		}
+		if options.AI.SystemPrompt != "" {
+			ai["system_prompt"] = options.AI.SystemPrompt
+		}
+		if options.AI.Temperature != nil {
+			ai["temperature"] = *options.AI.Temperature
+		}
+		if options.AI.MaxTokens > 0 {
+			ai["max_tokens"] = options.AI.MaxTokens
+		}
+		result["ai"] = ai
+	}
+
+	if options.Button != nil {
+		button := map[string]interface{}{
+			"label":  options.Button.Label,
+			"action": options.Button.Action,
+		}
+		if len(options.Button.Config) > 0 {
+			button["config"] = options.Button.Config
+		}
+		if options.Button.URL != "" {
+			button["url"] = options.Button.URL
+		}
+		if options.Button.WorkflowID != "" {
+			button["workflow_id"] = options.Button.WorkflowID
+		}
+		if options.Button.Webhook != nil {
+			// 不返回签名密钥
+			button["webhook"] = map[string]interface{}{
+				"url":        options.Button.Webhook.URL,
+				"method":     options.Button.Webhook.Method,
+				"headers":    options.Button.Webhook.Headers,
+				"has_secret": options.Button.Webhook.Secret != "",
+			}
+		}
+		if options.Button.Confirm != nil {
+			button["confirm"] = options.Button.Confirm
+		}
+		if len(options.Button.AllowedRoles) > 0 {
+			button["allowed_roles"] = options.Button.AllowedRoles
+		}
+		if options.Button.MaxClicks > 0 {
+			button["max_clicks"] = options.Button.MaxClicks
+		}
+		result["button"] = button
+	}
+
 	return result
 }
 
@@ -0,0 +1,10 @@
+package dto
+
+// GraphQLRequest GraphQL 请求（POST JSON 或 GET 查询参数）
+type GraphQLRequest struct {
+	Query         string                 `json:"query" form:"query" binding:"required"`
+	OperationName string                 `json:"operationName,omitempty" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables,omitempty" form:"-"`
+	// ReadOnly 只允许查询操作（GET 请求）
+	ReadOnly bool `json:"-" form:"-"`
+}
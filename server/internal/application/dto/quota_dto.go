@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// SpaceQuotaResponse 空间的套餐、配额与用量
+type SpaceQuotaResponse struct {
+	SpaceID         string     `json:"spaceId"`
+	Plan            string     `json:"plan"`                // 套餐，为空表示未限制
+	LicenseID       string     `json:"licenseId,omitempty"` // 空间所有者生效的许可证
+	Records         QuotaUsage `json:"records"`             // 空间内所有表的记录总数
+	Storage         QuotaUsage `json:"storage"`             // 附件存储（字节）
+	APICalls        QuotaUsage `json:"apiCalls"`            // 当月 API 调用次数
+	APICallsResetAt time.Time  `json:"apiCallsResetAt"`     // 下个自然月开始（UTC）
+}
+
+// QuotaUsage 用量与上限，limit 为 0 表示不限制
+type QuotaUsage struct {
+	Used  int64 `json:"used"`
+	Limit int64 `json:"limit"`
+}
@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/reminder"
+)
+
+// CreateReminderRuleRequest 创建记录提醒规则请求
+type CreateReminderRuleRequest struct {
+	Name             string                 `json:"name" binding:"required"`
+	DateFieldID      string                 `json:"dateFieldId" binding:"required"`
+	Offset           int                    `json:"offset"`
+	Unit             string                 `json:"unit"`      // minute, hour, day；默认 day
+	Direction        string                 `json:"direction"` // before, after；默认 before
+	Timezone         string                 `json:"timezone"`  // 只有日期的值按该时区零点计算，默认 UTC
+	Filter           map[string]interface{} `json:"filter"`    // 可选，只提醒满足条件的记录
+	RecipientFieldID string                 `json:"recipientFieldId"`
+	RecipientUserIDs []string               `json:"recipientUserIds"`
+	Message          string                 `json:"message"`
+}
+
+// UpdateReminderRuleRequest 更新记录提醒规则请求
+type UpdateReminderRuleRequest struct {
+	Name             *string                `json:"name"`
+	DateFieldID      *string                `json:"dateFieldId"`
+	Offset           *int                   `json:"offset"`
+	Unit             *string                `json:"unit"`
+	Direction        *string                `json:"direction"`
+	Timezone         *string                `json:"timezone"`
+	Filter           map[string]interface{} `json:"filter"` // 传空对象清除过滤条件
+	RecipientFieldID *string                `json:"recipientFieldId"`
+	RecipientUserIDs []string               `json:"recipientUserIds"`
+	Message          *string                `json:"message"`
+	Enabled          *bool                  `json:"enabled"`
+}
+
+// ReminderRuleResponse 记录提醒规则响应
+type ReminderRuleResponse struct {
+	ID               string                 `json:"id"`
+	TableID          string                 `json:"tableId"`
+	Name             string                 `json:"name"`
+	DateFieldID      string                 `json:"dateFieldId"`
+	Offset           int                    `json:"offset"`
+	Unit             string                 `json:"unit"`
+	Direction        string                 `json:"direction"`
+	Timezone         string                 `json:"timezone"`
+	Filter           map[string]interface{} `json:"filter,omitempty"`
+	RecipientFieldID string                 `json:"recipientFieldId,omitempty"`
+	RecipientUserIDs []string               `json:"recipientUserIds"`
+	Message          string                 `json:"message,omitempty"`
+	Enabled          bool                   `json:"enabled"`
+	CreatedBy        string                 `json:"createdBy"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+// RecordReminderResponse 记录的提醒计划响应
+type RecordReminderResponse struct {
+	ID       string     `json:"id"`
+	RuleID   string     `json:"ruleId"`
+	RecordID string     `json:"recordId"`
+	FireAt   time.Time  `json:"fireAt"`
+	Status   string     `json:"status"`
+	SentAt   *time.Time `json:"sentAt,omitempty"`
+}
+
+// FromReminderRule 从领域实体转换
+func FromReminderRule(rule *reminder.Rule) *ReminderRuleResponse {
+	recipients := rule.RecipientUserIDs
+	if recipients == nil {
+		recipients = []string{}
+	}
+	resp := &ReminderRuleResponse{
+		ID:               rule.ID,
+		TableID:          rule.TableID,
+		Name:             rule.Name,
+		DateFieldID:      rule.DateFieldID,
+		Offset:           rule.Offset,
+		Unit:             string(rule.Unit),
+		Direction:        string(rule.Direction),
+		Timezone:         rule.Timezone,
+		RecipientFieldID: rule.RecipientFieldID,
+		RecipientUserIDs: recipients,
+		Message:          rule.Message,
+		Enabled:          rule.Enabled,
+		CreatedBy:        rule.CreatedBy,
+		CreatedAt:        rule.CreatedAt,
+		UpdatedAt:        rule.UpdatedAt,
+	}
+	if !rule.Filter.IsEmpty() {
+		resp.Filter = rule.Filter.ToMap()
+	}
+	return resp
+}
+
+// FromRecordReminder 从领域实体转换
+func FromRecordReminder(item *reminder.Reminder) *RecordReminderResponse {
+	return &RecordReminderResponse{
+		ID:       item.ID,
+		RuleID:   item.RuleID,
+		RecordID: item.RecordID,
+		FireAt:   item.FireAt,
+		Status:   string(item.Status),
+		SentAt:   item.SentAt,
+	}
+}
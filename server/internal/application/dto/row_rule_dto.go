@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+)
+
+// CreateRowRuleRequest 创建行级规则请求
+type CreateRowRuleRequest struct {
+	Name    string                 `json:"name" binding:"required"`
+	Roles   []string               `json:"roles"`   // 适用角色，为空表示所有角色
+	Actions []string               `json:"actions"` // record|read, record|update, record|delete, record|create；为空默认 record|read
+	Filter  map[string]interface{} `json:"filter" binding:"required"`
+}
+
+// UpdateRowRuleRequest 更新行级规则请求
+type UpdateRowRuleRequest struct {
+	Name    *string                `json:"name"`
+	Roles   []string               `json:"roles"`
+	Actions []string               `json:"actions"`
+	Filter  map[string]interface{} `json:"filter"`
+	Enabled *bool                  `json:"enabled"`
+}
+
+// RowRuleResponse 行级规则响应
+type RowRuleResponse struct {
+	ID        string                 `json:"id"`
+	TableID   string                 `json:"tableId"`
+	Name      string                 `json:"name"`
+	Roles     []string               `json:"roles"`
+	Actions   []string               `json:"actions"`
+	Filter    map[string]interface{} `json:"filter"`
+	Enabled   bool                   `json:"enabled"`
+	CreatedBy string                 `json:"createdBy"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+// FromRowRule 从领域实体转换
+func FromRowRule(rule *permission.RowRule) *RowRuleResponse {
+	roles := make([]string, len(rule.Roles))
+	for i, role := range rule.Roles {
+		roles[i] = string(role)
+	}
+	actions := make([]string, len(rule.Actions))
+	for i, action := range rule.Actions {
+		actions[i] = string(action)
+	}
+
+	return &RowRuleResponse{
+		ID:        rule.ID,
+		TableID:   rule.TableID,
+		Name:      rule.Name,
+		Roles:     roles,
+		Actions:   actions,
+		Filter:    rule.Filter.ToMap(),
+		Enabled:   rule.Enabled,
+		CreatedBy: rule.CreatedBy,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+}
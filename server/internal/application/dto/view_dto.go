@@ -1,6 +1,7 @@
 package dto
 
 import (
+	viewDomain "github.com/easyspace-ai/luckdb/server/internal/domain/view"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/view/entity"
 	"time"
 )
@@ -10,7 +11,7 @@ type CreateViewRequest struct {
 	TableID     string                   `json:"tableId"` // ✅ 统一使用 camelCase，从URL路径获取，不需要required验证
 	Name        string                   `json:"name" binding:"required"`
 	Description string                   `json:"description"`
-	Type        string                   `json:"type" binding:"required"` // grid, kanban, gallery, form, calendar
+	Type        string                   `json:"type" binding:"required"` // grid, kanban, gallery, form, calendar, timeline
 	Filter      map[string]interface{}   `json:"filter"`
 	Sort        []map[string]interface{} `json:"sort"`
 	Group       []map[string]interface{} `json:"group"`
@@ -176,3 +177,287 @@ func FromViewEntities(views []*entity.View) []*ViewResponse {
 
 	return responses
 }
+
+// TimelineDataResponse 时间线视图数据响应
+type TimelineDataResponse struct {
+	WindowStart  time.Time                     `json:"windowStart"`
+	WindowEnd    time.Time                     `json:"windowEnd"`
+	Items        []*TimelineItemResponse       `json:"items"`
+	Groups       []*TimelineGroupResponse      `json:"groups"`
+	Dependencies []*TimelineDependencyResponse `json:"dependencies"`
+	Truncated    bool                          `json:"truncated"`
+}
+
+// TimelineItemResponse 时间线条目
+type TimelineItemResponse struct {
+	ID           string                 `json:"id"`
+	Title        string                 `json:"title"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	RawStart     time.Time              `json:"rawStart"`
+	RawEnd       time.Time              `json:"rawEnd"`
+	StartClamped bool                   `json:"startClamped"`
+	EndClamped   bool                   `json:"endClamped"`
+	Milestone    bool                   `json:"milestone"`
+	GroupID      string                 `json:"groupId,omitempty"`
+	Color        string                 `json:"color,omitempty"`
+	Fields       map[string]interface{} `json:"fields"`
+}
+
+// TimelineGroupResponse 时间线分组
+type TimelineGroupResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TimelineDependencyResponse 时间线依赖
+type TimelineDependencyResponse struct {
+	FromID string `json:"fromId"`
+	ToID   string `json:"toId"`
+}
+
+// FromTimelineViewData 从领域时间线数据转换为DTO
+func FromTimelineViewData(data *viewDomain.TimelineViewData) *TimelineDataResponse {
+	response := &TimelineDataResponse{
+		WindowStart:  data.WindowStart,
+		WindowEnd:    data.WindowEnd,
+		Items:        make([]*TimelineItemResponse, 0, len(data.Items)),
+		Groups:       make([]*TimelineGroupResponse, 0, len(data.Groups)),
+		Dependencies: make([]*TimelineDependencyResponse, 0, len(data.Dependencies)),
+		Truncated:    data.Truncated,
+	}
+
+	for _, item := range data.Items {
+		response.Items = append(response.Items, &TimelineItemResponse{
+			ID:           item.ID,
+			Title:        item.Title,
+			Start:        item.Start,
+			End:          item.End,
+			RawStart:     item.RawStart,
+			RawEnd:       item.RawEnd,
+			StartClamped: item.StartClamped,
+			EndClamped:   item.EndClamped,
+			Milestone:    item.Milestone,
+			GroupID:      item.GroupID,
+			Color:        item.Color,
+			Fields:       item.Data,
+		})
+	}
+	for _, group := range data.Groups {
+		response.Groups = append(response.Groups, &TimelineGroupResponse{
+			ID:    group.ID,
+			Name:  group.Name,
+			Count: group.Count,
+		})
+	}
+	for _, dep := range data.Dependencies {
+		response.Dependencies = append(response.Dependencies, &TimelineDependencyResponse{
+			FromID: dep.FromID,
+			ToID:   dep.ToID,
+		})
+	}
+
+	return response
+}
+
+// KanbanDataQuery 看板数据查询参数
+type KanbanDataQuery struct {
+	StackID string `form:"stackId"` // 只加载指定列（为空加载全部列）
+	Offset  int    `form:"offset"`  // 每列的偏移量
+	Limit   int    `form:"limit"`   // 每列的卡片数
+}
+
+// KanbanDataResponse 看板视图数据响应
+type KanbanDataResponse struct {
+	GroupFieldID string                 `json:"groupFieldId"`
+	Stacks       []*KanbanStackResponse `json:"stacks"`
+}
+
+// KanbanStackResponse 看板列
+type KanbanStackResponse struct {
+	ID      string                `json:"id"`
+	Name    string                `json:"name"`
+	Color   string                `json:"color,omitempty"`
+	Count   int                   `json:"count"`
+	HasMore bool                  `json:"hasMore"`
+	Cards   []*KanbanCardResponse `json:"cards"`
+}
+
+// KanbanCardResponse 看板卡片
+type KanbanCardResponse struct {
+	ID     string                 `json:"id"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// MoveKanbanCardRequest 移动看板卡片请求
+type MoveKanbanCardRequest struct {
+	RecordID  string `json:"recordId" binding:"required"`
+	ToStackID string `json:"toStackId" binding:"required"` // 目标列（未分类列为 __empty）
+	AnchorID  string `json:"anchorId"`                     // 参照卡片，为空时不调整顺序
+	Position  string `json:"position"`                     // 相对参照卡片的位置：before, after
+}
+
+// FromKanbanViewData 从领域看板数据转换为DTO
+func FromKanbanViewData(data *viewDomain.KanbanViewData) *KanbanDataResponse {
+	response := &KanbanDataResponse{
+		GroupFieldID: data.Config.GroupFieldID,
+		Stacks:       make([]*KanbanStackResponse, 0, len(data.Groups)),
+	}
+
+	for _, group := range data.Groups {
+		stack := &KanbanStackResponse{
+			ID:      group.ID,
+			Name:    group.Name,
+			Color:   group.Color,
+			Count:   group.Count,
+			HasMore: group.HasMore,
+			Cards:   make([]*KanbanCardResponse, 0, len(group.Cards)),
+		}
+		for _, card := range group.Cards {
+			id, _ := card["id"].(string)
+			fields, _ := card["fields"].(map[string]interface{})
+			stack.Cards = append(stack.Cards, &KanbanCardResponse{ID: id, Fields: fields})
+		}
+		response.Stacks = append(response.Stacks, stack)
+	}
+
+	return response
+}
+
+// CalendarDataResponse 日历视图数据响应
+type CalendarDataResponse struct {
+	WindowStart time.Time                `json:"windowStart"`
+	WindowEnd   time.Time                `json:"windowEnd"`
+	Timezone    string                   `json:"timezone"`
+	Events      []*CalendarEventResponse `json:"events"`
+	Truncated   bool                     `json:"truncated"`
+}
+
+// CalendarEventResponse 日历事件
+type CalendarEventResponse struct {
+	ID       string                 `json:"id"`
+	Title    string                 `json:"title"`
+	Start    string                 `json:"start"` // 全天事件为日期（YYYY-MM-DD），否则为带时区偏移的 RFC3339 时间
+	End      string                 `json:"end"`   // 全天事件为包含的最后一天
+	AllDay   bool                   `json:"allDay"`
+	MultiDay bool                   `json:"multiDay"`
+	Color    string                 `json:"color,omitempty"`
+	Fields   map[string]interface{} `json:"fields"`
+}
+
+// FromCalendarViewData 从领域日历数据转换为DTO
+func FromCalendarViewData(data *viewDomain.CalendarViewData) *CalendarDataResponse {
+	response := &CalendarDataResponse{
+		WindowStart: data.WindowStart,
+		WindowEnd:   data.WindowEnd,
+		Timezone:    data.Timezone,
+		Events:      make([]*CalendarEventResponse, 0, len(data.Events)),
+		Truncated:   data.Truncated,
+	}
+
+	for _, event := range data.Events {
+		response.Events = append(response.Events, &CalendarEventResponse{
+			ID:       event.ID,
+			Title:    event.Title,
+			Start:    event.StartTime,
+			End:      event.EndTime,
+			AllDay:   event.AllDay,
+			MultiDay: event.MultiDay,
+			Color:    event.Color,
+			Fields:   event.Data,
+		})
+	}
+
+	return response
+}
+
+// GridGroupsQuery 分组树查询参数
+type GridGroupsQuery struct {
+	Statistics string `form:"statistics"` // 列统计，格式为 fieldId:func，多个以逗号分隔
+}
+
+// GridGroupsResponse 分组网格数据响应
+type GridGroupsResponse struct {
+	Group      []map[string]interface{} `json:"group"`      // 视图分组配置
+	Total      int64                    `json:"total"`      // 总记录数
+	Statistics map[string]interface{}   `json:"statistics"` // 全表列统计，以 fieldId:func 为键
+	Groups     []*GridGroupResponse     `json:"groups"`
+	Truncated  bool                     `json:"truncated"`
+}
+
+// GridGroupResponse 分组树节点
+type GridGroupResponse struct {
+	ID         string                 `json:"id"` // 用于查询分组内记录
+	FieldID    string                 `json:"fieldId"`
+	Depth      int                    `json:"depth"`
+	Value      interface{}            `json:"value"`
+	Name       string                 `json:"name"`
+	Count      int64                  `json:"count"`
+	Statistics map[string]interface{} `json:"statistics"`
+	Children   []*GridGroupResponse   `json:"children"`
+}
+
+// GridGroupRecordsQuery 分组内记录查询参数
+type GridGroupRecordsQuery struct {
+	GroupID string `form:"groupId" binding:"required"` // 叶子或中间分组的ID
+	Offset  int    `form:"offset"`
+	Limit   int    `form:"limit"`
+}
+
+// GridGroupRecordsResponse 分组内记录
+type GridGroupRecordsResponse struct {
+	GroupID string                `json:"groupId"`
+	Records []*ViewRecordResponse `json:"records"`
+	Total   int64                 `json:"total"`
+	Offset  int                   `json:"offset"`
+	Limit   int                   `json:"limit"`
+}
+
+// ViewRecordResponse 视图中的记录
+type ViewRecordResponse struct {
+	ID     string                 `json:"id"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// FromGridGroupedData 从领域分组数据转换为DTO
+func FromGridGroupedData(group []map[string]interface{}, data *viewDomain.GridGroupedData) *GridGroupsResponse {
+	return &GridGroupsResponse{
+		Group:      group,
+		Total:      data.Total,
+		Statistics: data.Statistics,
+		Groups:     fromGridGroups(data.Groups),
+		Truncated:  data.Truncated,
+	}
+}
+
+func fromGridGroups(groups []*viewDomain.GridGroup) []*GridGroupResponse {
+	responses := make([]*GridGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		responses = append(responses, &GridGroupResponse{
+			ID:         group.ID,
+			FieldID:    group.FieldID,
+			Depth:      group.Depth,
+			Value:      group.Value,
+			Name:       group.Name,
+			Count:      group.Count,
+			Statistics: group.Statistics,
+			Children:   fromGridGroups(group.Children),
+		})
+	}
+	return responses
+}
+
+// ViewStatisticsResponse 视图列统计响应
+type ViewStatisticsResponse struct {
+	ViewID     string                     `json:"viewId"`
+	Total      int64                      `json:"total"` // 满足视图过滤条件的记录数
+	Statistics []*ColumnStatisticResponse `json:"statistics"`
+}
+
+// ColumnStatisticResponse 单列统计结果
+type ColumnStatisticResponse struct {
+	FieldID string      `json:"fieldId"`
+	Func    string      `json:"func"`
+	Value   interface{} `json:"value"` // 没有数据时为 null
+}
@@ -6,7 +6,10 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/easyspace-ai/luckdb/server/internal/domain/events"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
 
@@ -295,7 +298,19 @@ func (eb *EventBus) publishBatchAsync(ctx context.Context, eventList []events.Do
 }
 
 // executeHandler 执行事件处理器
-func (eb *EventBus) executeHandler(ctx context.Context, handler events.EventHandler, event events.DomainEvent) error {
+func (eb *EventBus) executeHandler(ctx context.Context, handler events.EventHandler, event events.DomainEvent) (err error) {
+	// ✨ 可观测性：处理延迟、处理结果与处理器 span
+	observability.ObserveEventLag(event.EventType(), event.OccurredAt())
+	ctx, span := observability.StartSpan(ctx, "EventBus.Handle",
+		attribute.String("event.type", event.EventType()),
+		attribute.String("event.id", event.EventID()),
+		attribute.String("event.handler", fmt.Sprintf("%T", handler)),
+	)
+	defer func() {
+		observability.RecordEventHandled(event.EventType(), err)
+		observability.EndSpan(span, err)
+	}()
+
 	// 重试机制
 	for attempt := 0; attempt <= eb.config.MaxRetries; attempt++ {
 		err := handler.Handle(ctx, event)
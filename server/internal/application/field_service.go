@@ -7,11 +7,15 @@ import (
 	"strings"
 
 	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/calculation/dependency"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/factory"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/handler"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	relationshipVO "github.com/easyspace-ai/luckdb/server/internal/domain/relationship/valueobject"
 	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database"
 	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
@@ -27,6 +31,9 @@ type FieldService struct {
 	broadcaster  FieldBroadcaster                      // ✨ WebSocket广播器
 	tableRepo    tableRepo.TableRepository             // ✅ 表格仓储（获取Base ID）
 	dbProvider   database.DBProvider                   // ✅ 数据库提供者（列管理）
+	linkRepo     recordRepo.LinkRepository             // ✨ Link 关联存储（外键列/中间表）
+	baseRepo     baseRepo.BaseRepository               // ✨ 跨 Base 关联校验（同一 Space）
+	permission   *PermissionServiceV2                  // ✨ 跨 Base 关联的权限检查
 }
 
 // FieldBroadcaster 字段变更广播器接口
@@ -59,6 +66,21 @@ func (s *FieldService) SetBroadcaster(broadcaster FieldBroadcaster) {
 	s.broadcaster = broadcaster
 }
 
+// SetLinkRepository 设置 Link 关联存储仓储（用于延迟注入）
+func (s *FieldService) SetLinkRepository(linkRepo recordRepo.LinkRepository) {
+	s.linkRepo = linkRepo
+}
+
+// SetBaseRepository 设置 Base 仓储（跨 Base 关联校验，用于延迟注入）
+func (s *FieldService) SetBaseRepository(baseRepo baseRepo.BaseRepository) {
+	s.baseRepo = baseRepo
+}
+
+// SetPermissionService 设置权限服务（跨 Base 关联权限检查，用于延迟注入）
+func (s *FieldService) SetPermissionService(permission *PermissionServiceV2) {
+	s.permission = permission
+}
+
 // CreateField 创建字段（参考原版实现逻辑）
 func (s *FieldService) CreateField(ctx context.Context, req dto.CreateFieldRequest, userID string) (*dto.FieldResponse, error) {
 	// 1. 验证字段名称
@@ -176,6 +198,21 @@ func (s *FieldService) CreateField(ctx context.Context, req dto.CreateFieldReque
 	// 5. ✨ 应用通用字段配置（defaultValue, showAs, formatting 等）
 	// 参考 Teable 的优秀设计，补充我们之前缺失的配置
 	s.applyCommonFieldOptions(field, req.Options)
+	if err := validateLinkOptions(field); err != nil {
+		return nil, err
+	}
+	if err := validateAIOptions(field); err != nil {
+		return nil, err
+	}
+	if err := validateButtonOptions(ctx, field); err != nil {
+		return nil, err
+	}
+	if err := s.prepareLinkField(ctx, field, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkLinkedFieldAccess(ctx, field, userID); err != nil {
+		return nil, err
+	}
 
 	// 6. 循环依赖检测（仅对虚拟字段）
 	if isVirtualFieldType(req.Type) {
@@ -279,6 +316,20 @@ func (s *FieldService) CreateField(ctx context.Context, req dto.CreateFieldReque
 			logger.String("field_id", field.ID().String()),
 			logger.String("db_field_name", dbFieldName),
 			logger.String("db_type", dbType))
+
+		// 8.6 ✨ Link 字段：创建外键列或中间表，存储信息写入字段选项
+		if s.linkRepo != nil && hasLinkTarget(field) {
+			if _, err := s.linkRepo.EnsureStorage(ctx, field); err != nil {
+				if rollbackErr := s.dbProvider.DropColumn(ctx, baseID, tableID, dbFieldName); rollbackErr != nil {
+					logger.Error("回滚删除物理表列失败",
+						logger.String("field_id", field.ID().String()),
+						logger.String("db_field_name", dbFieldName),
+						logger.ErrorField(rollbackErr))
+				}
+				return nil, pkgerrors.ErrDatabaseOperation.WithDetails(
+					fmt.Sprintf("创建Link关联存储失败: %v", err))
+			}
+		}
 	}
 
 	// 9. 保存字段元数据
@@ -342,6 +393,18 @@ func (s *FieldService) CreateField(ctx context.Context, req dto.CreateFieldReque
 		)
 	}
 
+	// 11. ✨ 双向关联：在关联表（可能位于其他 Base）中创建对称字段
+	if hasLinkTarget(field) && field.Options().Link.IsSymmetric && field.Options().Link.SymmetricFieldID == "" {
+		if err := s.createSymmetricField(ctx, field, userID); err != nil {
+			if rollbackErr := s.DeleteField(ctx, field.ID().String()); rollbackErr != nil {
+				logger.Error("回滚删除Link字段失败",
+					logger.String("field_id", field.ID().String()),
+					logger.ErrorField(rollbackErr))
+			}
+			return nil, err
+		}
+	}
+
 	return dto.FromFieldEntity(field), nil
 }
 
@@ -548,6 +611,9 @@ func (s *FieldService) UpdateField(ctx context.Context, fieldID string, req dto.
 		// ✨ 应用通用字段配置（defaultValue, showAs, formatting 等）
 		// 参考 Teable 的优秀设计，补充我们之前缺失的配置
 		s.applyCommonFieldOptions(field, req.Options)
+		if err := validateButtonOptions(ctx, field); err != nil {
+			return nil, err
+		}
 	}
 
 	// 4. 更新约束
@@ -656,6 +722,14 @@ func (s *FieldService) DeleteField(ctx context.Context, fieldID string) error {
 		logger.Info("✅ 物理表列删除成功",
 			logger.String("field_id", fieldID),
 			logger.String("db_field_name", dbFieldName))
+
+		// 2.3 ✨ 删除 Link 字段的外键列或中间表
+		if s.linkRepo != nil && field.Type().String() == valueobject.TypeLink {
+			if err := s.linkRepo.DropStorage(ctx, field); err != nil {
+				return pkgerrors.ErrDatabaseOperation.WithDetails(
+					fmt.Sprintf("删除Link关联存储失败: %v", err))
+			}
+		}
 	}
 
 	// 3. 删除字段元数据
@@ -685,6 +759,428 @@ func (s *FieldService) DeleteField(ctx context.Context, fieldID string) error {
 		)
 	}
 
+	// 6. ✨ Link 字段：依赖它的 Lookup/Rollup 字段标记为错误，并删除对称字段
+	if field.Type().String() == valueobject.TypeLink {
+		s.markLinkDependentsAsError(ctx, tableID, fieldID)
+
+		if hasLinkTarget(field) && field.Options().Link.SymmetricFieldID != "" {
+			symmetricID := field.Options().Link.SymmetricFieldID
+			symmetric, err := s.fieldRepo.FindByID(ctx, valueobject.NewFieldID(symmetricID))
+			if err != nil {
+				return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找对称字段失败: %v", err))
+			}
+			// 对称字段已删除时 FindByID 返回 nil，避免互相递归
+			if symmetric != nil {
+				if err := s.DeleteField(ctx, symmetricID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// markLinkDependentsAsError 将通过已删除 Link 字段引用数据的 Lookup/Rollup 字段标记为错误
+func (s *FieldService) markLinkDependentsAsError(ctx context.Context, tableID, linkFieldID string) {
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("获取字段列表失败，跳过依赖字段标记",
+			logger.String("table_id", tableID),
+			logger.ErrorField(err))
+		return
+	}
+
+	for _, dependent := range fields {
+		options := dependent.Options()
+		if options == nil {
+			continue
+		}
+		dependsOnLink := (options.Lookup != nil && options.Lookup.LinkFieldID == linkFieldID) ||
+			(options.Rollup != nil && options.Rollup.LinkFieldID == linkFieldID)
+		if !dependsOnLink {
+			continue
+		}
+
+		dependent.MarkAsError()
+		if err := s.fieldRepo.Save(ctx, dependent); err != nil {
+			logger.Warn("标记依赖字段错误失败",
+				logger.String("field_id", dependent.ID().String()),
+				logger.ErrorField(err))
+			continue
+		}
+		if s.broadcaster != nil {
+			s.broadcaster.BroadcastFieldUpdate(tableID, dependent)
+		}
+	}
+}
+
+// MigrateLinkStorage 将尚未创建关联存储的 Link 字段迁移到外键列/中间表 ✨
+// 先把旧版 TEXT[] 关联列转换为 JSONB，再为每个字段创建存储、把记录列中的 JSON 关联值写入其中
+// 并保存字段选项，返回迁移的字段数。已迁移的字段会被跳过，可在每次启动时执行
+func (s *FieldService) MigrateLinkStorage(ctx context.Context) (int, error) {
+	if s.linkRepo == nil {
+		return 0, nil
+	}
+
+	fieldType, err := valueobject.NewFieldType(valueobject.TypeLink)
+	if err != nil {
+		return 0, err
+	}
+	linkFields, _, err := s.fieldRepo.List(ctx, repository.FieldFilter{FieldType: &fieldType})
+	if err != nil {
+		return 0, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询Link字段失败: %v", err))
+	}
+
+	migratedFields := 0
+	for _, field := range linkFields {
+		// 关联缓存以 JSON 写入记录列，TEXT[] 列需先转换
+		converted, err := s.linkRepo.ConvertLegacyColumn(ctx, field)
+		if err != nil {
+			return migratedFields, pkgerrors.ErrDatabaseOperation.WithDetails(
+				fmt.Sprintf("转换Link字段 %s 的列类型失败: %v", field.ID().String(), err))
+		}
+		if converted {
+			logger.Info("✅ Link 字段列已从 TEXT[] 转换为 JSONB",
+				logger.String("field_id", field.ID().String()),
+				logger.String("table_id", field.TableID()))
+		}
+		// 字段元数据中的列类型同步为 JSONB，记录读写按 JSON 处理
+		if field.SyncDBFieldType() {
+			if err := s.fieldRepo.Save(ctx, field); err != nil {
+				return migratedFields, pkgerrors.ErrDatabaseOperation.WithDetails(
+					fmt.Sprintf("保存Link字段 %s 失败: %v", field.ID().String(), err))
+			}
+		}
+
+		if !hasLinkTarget(field) || field.Options().Link.HasStorage() {
+			continue
+		}
+
+		if _, err := s.linkRepo.EnsureStorage(ctx, field); err != nil {
+			return migratedFields, pkgerrors.ErrDatabaseOperation.WithDetails(
+				fmt.Sprintf("创建Link字段 %s 关联存储失败: %v", field.ID().String(), err))
+		}
+		records, err := s.linkRepo.MigrateJSONLinks(ctx, field)
+		if err != nil {
+			return migratedFields, pkgerrors.ErrDatabaseOperation.WithDetails(
+				fmt.Sprintf("迁移Link字段 %s 失败: %v", field.ID().String(), err))
+		}
+		// 存储信息写入字段选项后才视为迁移完成，中途失败会在下次执行时重新迁移
+		if err := s.fieldRepo.Save(ctx, field); err != nil {
+			return migratedFields, pkgerrors.ErrDatabaseOperation.WithDetails(
+				fmt.Sprintf("保存Link字段 %s 失败: %v", field.ID().String(), err))
+		}
+
+		migratedFields++
+		logger.Info("✅ Link 字段已迁移到关联存储",
+			logger.String("field_id", field.ID().String()),
+			logger.String("table_id", field.TableID()),
+			logger.Int("records", records))
+	}
+
+	return migratedFields, nil
+}
+
+// hasLinkTarget 是否为已指定关联表的 Link 字段
+func hasLinkTarget(field *entity.Field) bool {
+	if field.Type().String() != valueobject.TypeLink {
+		return false
+	}
+	options := field.Options()
+	return options != nil && options.Link != nil && options.Link.LinkedTableID != ""
+}
+
+// validateLinkOptions 校验 Link 字段的关系类型和过滤条件
+func validateLinkOptions(field *entity.Field) error {
+	if !hasLinkTarget(field) {
+		return nil
+	}
+	relationship := field.Options().Link.Relationship
+	if relationship != "" {
+		if _, err := relationshipVO.NewRelationType(relationship); err != nil {
+			return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"field":        "relationship",
+				"relationship": relationship,
+				"error":        err.Error(),
+			})
+		}
+	}
+	_, err := linkConditionFilter(field.Options().Link.Filter)
+	return err
+}
+
+// validateAIOptions 校验 AI 字段配置：必须提供提示词
+func validateAIOptions(field *entity.Field) error {
+	if field.Type().String() != valueobject.TypeAI {
+		return nil
+	}
+	options := field.Options()
+	if options == nil || options.AI == nil || strings.TrimSpace(options.AI.Prompt) == "" {
+		return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"field":   "prompt",
+			"message": "AI 字段必须配置提示词",
+		})
+	}
+	return nil
+}
+
+// validateButtonOptions 校验按钮字段配置：动作类型及其所需的 url/workflowId/webhook
+func validateButtonOptions(ctx context.Context, field *entity.Field) error {
+	if field.Type().String() != valueobject.TypeButton {
+		return nil
+	}
+	if err := handler.NewButtonFieldHandler().ValidateOptions(ctx, field); err != nil {
+		return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"field":   "options",
+			"message": err.Error(),
+		})
+	}
+	return nil
+}
+
+// prepareLinkField 解析 Link 字段的关联表所在 Base ✨
+// 同 Base 关联清空 BaseID；跨 Base 关联要求两个 Base 属于同一 Space，且用户可以访问关联表
+func (s *FieldService) prepareLinkField(ctx context.Context, field *entity.Field, userID string) error {
+	if !hasLinkTarget(field) || s.tableRepo == nil {
+		return nil
+	}
+	link := field.Options().Link
+
+	hostTable, err := s.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取Table信息失败: %v", err))
+	}
+	if hostTable == nil {
+		return pkgerrors.ErrNotFound.WithDetails("Table不存在")
+	}
+	linkedTable, err := s.tableRepo.GetByID(ctx, link.LinkedTableID)
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取关联表信息失败: %v", err))
+	}
+	if linkedTable == nil {
+		return pkgerrors.ErrNotFound.WithDetails(map[string]interface{}{
+			"message":       "关联表不存在",
+			"linkedTableId": link.LinkedTableID,
+		})
+	}
+
+	foreignBaseID := linkedTable.BaseID()
+	if link.BaseID != "" && link.BaseID != foreignBaseID {
+		return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message":       "关联表不属于指定的 Base",
+			"baseId":        link.BaseID,
+			"linkedTableId": link.LinkedTableID,
+		})
+	}
+	if foreignBaseID == hostTable.BaseID() {
+		link.BaseID = ""
+		return nil
+	}
+
+	// 跨 Base 关联：仅允许同一 Space 内的 Base
+	if s.baseRepo != nil {
+		hostBase, err := s.baseRepo.FindByID(ctx, hostTable.BaseID())
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取Base信息失败: %v", err))
+		}
+		foreignBase, err := s.baseRepo.FindByID(ctx, foreignBaseID)
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取关联Base信息失败: %v", err))
+		}
+		if hostBase.SpaceID != foreignBase.SpaceID {
+			return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"message":       "只能关联同一 Space 内的表",
+				"linkedTableId": link.LinkedTableID,
+			})
+		}
+	}
+	if s.permission != nil && !s.permission.CanAccessTable(ctx, userID, link.LinkedTableID) {
+		return pkgerrors.ErrForbidden.WithDetails(map[string]interface{}{
+			"message":       "没有关联表所在 Base 的访问权限",
+			"linkedTableId": link.LinkedTableID,
+		})
+	}
+
+	link.BaseID = foreignBaseID
+	return nil
+}
+
+// checkLinkedFieldAccess 检查 Lookup/Rollup 字段对关联表的访问权限 ✨
+// 通过跨 Base 的 Link 字段引用其他 Base 的数据时，用户需要能访问该表
+func (s *FieldService) checkLinkedFieldAccess(ctx context.Context, field *entity.Field, userID string) error {
+	if s.permission == nil || field.Options() == nil {
+		return nil
+	}
+
+	var linkFieldID string
+	switch {
+	case field.Options().Lookup != nil:
+		linkFieldID = field.Options().Lookup.LinkFieldID
+	case field.Options().Rollup != nil:
+		linkFieldID = field.Options().Rollup.LinkFieldID
+	}
+	if linkFieldID == "" {
+		return nil
+	}
+
+	linkField, err := s.fieldRepo.FindByID(ctx, valueobject.NewFieldID(linkFieldID))
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找Link字段失败: %v", err))
+	}
+	if linkField == nil || !hasLinkTarget(linkField) || linkField.Options().Link.BaseID == "" {
+		return nil
+	}
+
+	if !s.permission.CanAccessTable(ctx, userID, linkField.Options().Link.LinkedTableID) {
+		return pkgerrors.ErrForbidden.WithDetails(map[string]interface{}{
+			"message":       "没有关联表所在 Base 的访问权限",
+			"linkedTableId": linkField.Options().Link.LinkedTableID,
+		})
+	}
+	return nil
+}
+
+// createSymmetricField 在关联表中创建对称 Link 字段并共享关联存储 ✨
+// 跨 Base 关联时对称字段位于关联表所在的 Base，其 BaseID 指向当前表所在的 Base
+func (s *FieldService) createSymmetricField(ctx context.Context, field *entity.Field, userID string) error {
+	link := field.Options().Link
+
+	hostTable, err := s.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil || hostTable == nil {
+		return pkgerrors.ErrNotFound.WithDetails("Table不存在")
+	}
+	linkedTable, err := s.tableRepo.GetByID(ctx, link.LinkedTableID)
+	if err != nil || linkedTable == nil {
+		return pkgerrors.ErrNotFound.WithDetails("关联表不存在")
+	}
+
+	// 1. 以当前表名命名，重名时追加序号
+	name := hostTable.Name().String()
+	for i := 2; ; i++ {
+		fieldName, err := valueobject.NewFieldName(name)
+		if err != nil {
+			return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("对称字段名称无效: %v", err))
+		}
+		exists, err := s.fieldRepo.ExistsByName(ctx, linkedTable.ID().String(), fieldName, nil)
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("检查字段名称失败: %v", err))
+		}
+		if !exists {
+			break
+		}
+		name = fmt.Sprintf("%s %d", hostTable.Name().String(), i)
+	}
+
+	symmetric, err := s.fieldFactory.CreateFieldWithType(linkedTable.ID().String(), name, valueobject.TypeLink, userID)
+	if err != nil {
+		return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("创建对称字段失败: %v", err))
+	}
+
+	options := valueobject.NewFieldOptions().WithLink(hostTable.ID().String(), valueobject.InverseRelationship(link.Relationship), true)
+	options.Link.SymmetricFieldID = field.ID().String()
+	options.Link.AllowMultiple = options.Link.UsesJunctionTable()
+	if linkedTable.BaseID() != hostTable.BaseID() {
+		options.Link.BaseID = hostTable.BaseID()
+	}
+	if link.HasStorage() {
+		options.Link.ShareStorage(link)
+	}
+	if err := symmetric.UpdateOptions(options); err != nil {
+		return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("设置对称字段选项失败: %v", err))
+	}
+
+	maxOrder, err := s.fieldRepo.GetMaxOrder(ctx, linkedTable.ID().String())
+	if err != nil {
+		maxOrder = -1
+	}
+	symmetric.SetOrder(maxOrder + 1)
+
+	// 2. 在关联表所在的 Base 中创建物理列
+	if s.dbProvider != nil {
+		columnDef := database.ColumnDefinition{
+			Name: symmetric.DBFieldName().String(),
+			Type: symmetric.DBFieldType(),
+		}
+		if err := s.dbProvider.AddColumn(ctx, linkedTable.BaseID(), linkedTable.ID().String(), columnDef); err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("创建对称字段物理列失败: %v", err))
+		}
+	}
+
+	// 3. 保存双方的对称关系
+	if err := s.fieldRepo.Save(ctx, symmetric); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("保存对称字段失败: %v", err))
+	}
+	link.SymmetricFieldID = symmetric.ID().String()
+	link.IsSymmetric = true
+	if err := s.fieldRepo.Save(ctx, field); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("保存Link字段失败: %v", err))
+	}
+
+	logger.Info("✅ 对称Link字段创建成功",
+		logger.String("field_id", field.ID().String()),
+		logger.String("symmetric_field_id", symmetric.ID().String()),
+		logger.String("linked_table_id", linkedTable.ID().String()))
+
+	if s.broadcaster != nil {
+		s.broadcaster.BroadcastFieldCreate(linkedTable.ID().String(), symmetric)
+	}
+	return nil
+}
+
+// DeleteLinksToBase 删除其他 Base 中关联到指定 Base 的 Link 字段 ✨
+// 删除 Base 前调用，避免遗留指向已删除表的关联字段和关联存储
+func (s *FieldService) DeleteLinksToBase(ctx context.Context, baseID string) error {
+	if s.tableRepo == nil {
+		return nil
+	}
+
+	tables, err := s.tableRepo.GetByBaseID(ctx, baseID)
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取Base下的表格失败: %v", err))
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	baseTables := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		baseTables[table.ID().String()] = true
+	}
+
+	fieldType, err := valueobject.NewFieldType(valueobject.TypeLink)
+	if err != nil {
+		return err
+	}
+
+	// 按关联表查询 Link 字段，只处理宿主表不在该 Base 中的字段
+	var linkFields []*entity.Field
+	for _, table := range tables {
+		linkedTableID := table.ID().String()
+		fields, _, err := s.fieldRepo.List(ctx, repository.FieldFilter{FieldType: &fieldType, LinkedTableID: &linkedTableID})
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询Link字段失败: %v", err))
+		}
+		for _, field := range fields {
+			if hasLinkTarget(field) && !baseTables[field.TableID()] {
+				linkFields = append(linkFields, field)
+			}
+		}
+	}
+
+	for _, field := range linkFields {
+		// 对称字段已随另一侧一起删除时跳过
+		current, err := s.fieldRepo.FindByID(ctx, field.ID())
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+		}
+		if current == nil {
+			continue
+		}
+		if err := s.DeleteField(ctx, field.ID().String()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1074,6 +1570,19 @@ func (s *FieldService) applyCommonFieldOptions(field *entity.Field, reqOptions m
 		if options.Link == nil {
 			options.Link = &valueobject.LinkOptions{}
 		}
+		// 关联目标和关系类型（决定外键列/中间表存储）
+		if linkedTableID, ok := reqOptions["linkedTableId"].(string); ok {
+			options.Link.LinkedTableID = linkedTableID
+		} else if foreignTableID, ok := reqOptions["foreignTableId"].(string); ok {
+			options.Link.LinkedTableID = foreignTableID
+		}
+		if relationship, ok := reqOptions["relationship"].(string); ok {
+			options.Link.Relationship = relationship
+			options.Link.AllowMultiple = relationship == relationshipVO.OneToMany || relationship == relationshipVO.ManyToMany
+		}
+		if isSymmetric, ok := reqOptions["isSymmetric"].(bool); ok {
+			options.Link.IsSymmetric = isSymmetric
+		}
 		// 高级过滤功能（参考 Teable）
 		if baseID, ok := reqOptions["baseId"].(string); ok {
 			options.Link.BaseID = baseID
@@ -1111,6 +1620,90 @@ func (s *FieldService) applyCommonFieldOptions(field *entity.Field, reqOptions m
 			}
 			options.Link.Filter = filter
 		}
+
+	case "ai":
+		if options.AI == nil {
+			options.AI = &valueobject.AIOptions{}
+		}
+		// 提示词中的 {字段名} 在生成时替换为记录的字段值
+		if provider, ok := reqOptions["provider"].(string); ok {
+			options.AI.Provider = provider
+		}
+		if model, ok := reqOptions["model"].(string); ok {
+			options.AI.Model = model
+		}
+		if prompt, ok := reqOptions["prompt"].(string); ok {
+			options.AI.Prompt = prompt
+		}
+		if systemPrompt, ok := reqOptions["systemPrompt"].(string); ok {
+			options.AI.SystemPrompt = systemPrompt
+		}
+		if temperature, ok := reqOptions["temperature"].(float64); ok {
+			options.AI.Temperature = &temperature
+		}
+		if maxTokens, ok := reqOptions["maxTokens"].(float64); ok {
+			options.AI.MaxTokens = int(maxTokens)
+		}
+
+	case "button":
+		if options.Button == nil {
+			options.Button = &valueobject.ButtonOptions{}
+		}
+		if label, ok := reqOptions["label"].(string); ok {
+			options.Button.Label = label
+		}
+		if action, ok := reqOptions["action"].(string); ok {
+			options.Button.Action = action
+		}
+		if config, ok := reqOptions["config"].(map[string]interface{}); ok {
+			options.Button.Config = config
+		}
+		// URL 模板中的 {字段名} 在点击时替换为记录的字段值
+		if urlTemplate, ok := reqOptions["url"].(string); ok {
+			options.Button.URL = urlTemplate
+		}
+		if workflowID, ok := reqOptions["workflowId"].(string); ok {
+			options.Button.WorkflowID = workflowID
+		}
+		if webhookData, ok := reqOptions["webhook"].(map[string]interface{}); ok {
+			webhook := &valueobject.ButtonWebhookOptions{
+				URL:    getStringFromMap(webhookData, "url"),
+				Method: getStringFromMap(webhookData, "method"),
+				Secret: getStringFromMap(webhookData, "secret"),
+			}
+			if headers, ok := webhookData["headers"].(map[string]interface{}); ok {
+				webhook.Headers = make(map[string]string, len(headers))
+				for key, value := range headers {
+					if str, ok := value.(string); ok {
+						webhook.Headers[key] = str
+					}
+				}
+			}
+			options.Button.Webhook = webhook
+		}
+		if confirm, ok := reqOptions["confirm"]; ok {
+			if confirmData, ok := confirm.(map[string]interface{}); ok {
+				options.Button.Confirm = &valueobject.ButtonConfirmOptions{
+					Title:       getStringFromMap(confirmData, "title"),
+					Description: getStringFromMap(confirmData, "description"),
+					ConfirmText: getStringFromMap(confirmData, "confirmText"),
+				}
+			} else if confirm == nil {
+				options.Button.Confirm = nil
+			}
+		}
+		if rolesData, ok := reqOptions["allowedRoles"].([]interface{}); ok {
+			roles := make([]string, 0, len(rolesData))
+			for _, item := range rolesData {
+				if role, ok := item.(string); ok && role != "" {
+					roles = append(roles, role)
+				}
+			}
+			options.Button.AllowedRoles = roles
+		}
+		if maxClicks, ok := reqOptions["maxClicks"].(float64); ok {
+			options.Button.MaxClicks = int(maxClicks)
+		}
 	}
 
 	// 更新字段的 options
@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+// ForeignFieldGuard 跨 Base 关联字段的读取权限 ✨
+//
+// 跨 Base 的 Link 字段以及基于它的 Lookup/Rollup 字段展示的是其他 Base 的数据。
+// 创建字段时只校验了创建者能否访问关联表，其他协作者读取记录时由这里校验：
+// 不能访问关联表的用户看不到这些字段的值（与记录中没有该字段一致）。
+// 没有用户上下文（系统内部调用、分享访客）时不做限制
+type ForeignFieldGuard struct {
+	fieldRepo  repository.FieldRepository
+	permission *PermissionServiceV2
+}
+
+// NewForeignFieldGuard 创建跨 Base 字段读取权限校验
+func NewForeignFieldGuard(fieldRepo repository.FieldRepository, permission *PermissionServiceV2) *ForeignFieldGuard {
+	return &ForeignFieldGuard{fieldRepo: fieldRepo, permission: permission}
+}
+
+// HiddenFields 用户不能读取的字段 ID，没有需要隐藏的字段时返回 nil
+func (g *ForeignFieldGuard) HiddenFields(ctx context.Context, tableID, userID string) (map[string]bool, error) {
+	if g == nil || userID == "" {
+		return nil, nil
+	}
+	foreign, err := g.foreignFields(ctx, tableID)
+	if err != nil || len(foreign) == 0 {
+		return nil, err
+	}
+
+	var hidden map[string]bool
+	for linkedTableID, fieldIDs := range foreign {
+		if g.permission.CanAccessTable(ctx, userID, linkedTableID) {
+			continue
+		}
+		if hidden == nil {
+			hidden = make(map[string]bool)
+		}
+		for _, fieldID := range fieldIDs {
+			hidden[fieldID] = true
+		}
+	}
+	return hidden, nil
+}
+
+// MaskResponses 移除当前用户不能读取的字段值
+func (g *ForeignFieldGuard) MaskResponses(ctx context.Context, tableID string, records ...*dto.RecordResponse) error {
+	hidden, err := g.HiddenFields(ctx, tableID, currentUserID(ctx))
+	if err != nil || len(hidden) == 0 {
+		return err
+	}
+	for _, record := range records {
+		if record != nil {
+			record.Data = withoutFields(record.Data, hidden)
+		}
+	}
+	return nil
+}
+
+// MaskRecords 返回移除了当前用户不能读取的字段值的记录，原记录不变
+func (g *ForeignFieldGuard) MaskRecords(ctx context.Context, tableID string, records []*entity.Record) ([]*entity.Record, error) {
+	hidden, err := g.HiddenFields(ctx, tableID, currentUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return maskRecords(records, hidden)
+}
+
+// maskRecords 返回移除了指定字段值的记录
+func maskRecords(records []*entity.Record, hidden map[string]bool) ([]*entity.Record, error) {
+	if len(hidden) == 0 {
+		return records, nil
+	}
+	masked := make([]*entity.Record, len(records))
+	for i, record := range records {
+		data, err := valueobject.NewRecordData(withoutFields(record.Data().ToMap(), hidden))
+		if err != nil {
+			return nil, pkgerrors.ErrInternalServer.WithDetails(fmt.Sprintf("处理记录数据失败: %v", err))
+		}
+		masked[i] = entity.ReconstructRecord(record.ID(), record.TableID(), data, record.Version(),
+			record.CreatedBy(), record.UpdatedBy(), record.CreatedAt(), record.UpdatedAt(), record.DeletedAt())
+	}
+	return masked, nil
+}
+
+// RecipientFilter 实时推送的接收者划分
+// 返回表中的跨 Base 字段以及判定用户能否读取全部跨 Base 字段的函数；
+// 不能读取的用户收到移除了这些字段的消息。表中没有跨 Base 字段时返回 nil
+func (g *ForeignFieldGuard) RecipientFilter(ctx context.Context, tableID string) (map[string]bool, func(userID string) bool, error) {
+	if g == nil {
+		return nil, nil, nil
+	}
+	foreign, err := g.foreignFields(ctx, tableID)
+	if err != nil || len(foreign) == 0 {
+		return nil, nil, err
+	}
+
+	fields := make(map[string]bool)
+	for _, fieldIDs := range foreign {
+		for _, fieldID := range fieldIDs {
+			fields[fieldID] = true
+		}
+	}
+
+	// 每次广播按用户缓存判定结果
+	var mu sync.Mutex
+	cache := make(map[string]bool)
+	canReadAll := func(userID string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if allowed, ok := cache[userID]; ok {
+			return allowed
+		}
+		allowed := true
+		for linkedTableID := range foreign {
+			if !g.permission.CanAccessTable(ctx, userID, linkedTableID) {
+				allowed = false
+				break
+			}
+		}
+		cache[userID] = allowed
+		return allowed
+	}
+	return fields, canReadAll, nil
+}
+
+// foreignFields 表中展示其他 Base 数据的字段，按关联表分组
+func (g *ForeignFieldGuard) foreignFields(ctx context.Context, tableID string) (map[string][]string, error) {
+	fields, err := g.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+	}
+
+	// 跨 Base 的 Link 字段（同 Base 关联的 BaseID 为空）
+	linkedTables := make(map[string]string)
+	for _, field := range fields {
+		if hasLinkTarget(field) && field.Options().Link.BaseID != "" {
+			linkedTables[field.ID().String()] = field.Options().Link.LinkedTableID
+		}
+	}
+	if len(linkedTables) == 0 {
+		return nil, nil
+	}
+
+	foreign := make(map[string][]string)
+	for _, field := range fields {
+		fieldID := field.ID().String()
+		if linkedTableID, ok := linkedTables[fieldID]; ok {
+			foreign[linkedTableID] = append(foreign[linkedTableID], fieldID)
+			continue
+		}
+		if options := field.Options(); options != nil {
+			var linkFieldID string
+			switch {
+			case options.Lookup != nil:
+				linkFieldID = options.Lookup.LinkFieldID
+			case options.Rollup != nil:
+				linkFieldID = options.Rollup.LinkFieldID
+			}
+			if linkedTableID, ok := linkedTables[linkFieldID]; ok {
+				foreign[linkedTableID] = append(foreign[linkedTableID], fieldID)
+			}
+		}
+	}
+	return foreign, nil
+}
+
+// withoutFields 移除指定字段后的值（副本）
+func withoutFields(values map[string]interface{}, hidden map[string]bool) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if !hidden[key] {
+			result[key] = value
+		}
+	}
+	return result
+}
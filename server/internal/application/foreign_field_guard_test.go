@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	collaboratorEntity "github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/entity"
+	collaboratorRepo "github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/repository"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/table/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/authctx"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// guardTestCollaborators 用户在 Base 上的角色
+type guardTestCollaborators struct {
+	collaboratorRepo.CollaboratorRepository
+	roles map[string]collaboratorEntity.RoleName // baseID/userID -> 角色
+}
+
+func (r *guardTestCollaborators) FindByResourceAndPrincipal(ctx context.Context, resourceID, principalID string) (*collaboratorEntity.Collaborator, error) {
+	role, ok := r.roles[resourceID+"/"+principalID]
+	if !ok {
+		return nil, fmt.Errorf("collaborator not found")
+	}
+	return collaboratorEntity.NewCollaborator(resourceID, collaboratorEntity.ResourceTypeBase, principalID,
+		collaboratorEntity.PrincipalTypeUser, role, principalID)
+}
+
+type guardTestFields struct {
+	quotaTestFields
+}
+
+func (r *guardTestFields) FindByTableID(ctx context.Context, tableID string) ([]*fieldEntity.Field, error) {
+	var result []*fieldEntity.Field
+	for _, field := range r.fields {
+		if field.TableID() == tableID {
+			result = append(result, field)
+		}
+	}
+	return result, nil
+}
+
+// newGuardTestEnv tbl_test 通过跨 Base 的 Link 字段关联 bse_other 中的项目表，
+// usr_both 能访问两个 Base，usr_host 只能访问 tbl_test 所在的 Base
+func newGuardTestEnv(t *testing.T) *ForeignFieldGuard {
+	t.Helper()
+	logger.Logger = zap.NewNop()
+	tableName, err := tableValueobject.NewTableName("Projects")
+	require.NoError(t, err)
+	projects, err := tableEntity.NewTable("bse_other", tableName, "usr_both")
+	require.NoError(t, err)
+
+	crossLink := fieldValueobject.NewFieldOptions()
+	crossLink.Link = &fieldValueobject.LinkOptions{LinkedTableID: projects.ID().String(), Relationship: "many_to_one", BaseID: "bse_other"}
+	localLink := fieldValueobject.NewFieldOptions()
+	localLink.Link = &fieldValueobject.LinkOptions{LinkedTableID: "tbl_local", Relationship: "many_to_one"}
+	lookup := fieldValueobject.NewFieldOptions()
+	lookup.Lookup = &fieldValueobject.LookupOptions{LinkFieldID: "fld_project", LookupFieldID: "fld_name"}
+	rollup := fieldValueobject.NewFieldOptions()
+	rollup.Rollup = &fieldValueobject.RollupOptions{LinkFieldID: "fld_local", RollupFieldID: "fld_points", AggregationFunction: "sum"}
+
+	fields := &guardTestFields{quotaTestFields{fields: []*fieldEntity.Field{
+		newSpecField(t, "fld_title", "Title", fieldValueobject.TypeSingleLineText, 1, nil),
+		newSpecField(t, "fld_project", "Project", fieldValueobject.TypeLink, 2, crossLink),
+		newSpecField(t, "fld_project_name", "Project name", fieldValueobject.TypeLookup, 3, lookup),
+		newSpecField(t, "fld_local", "Local", fieldValueobject.TypeLink, 4, localLink),
+		newSpecField(t, "fld_points", "Points", fieldValueobject.TypeRollup, 5, rollup),
+	}}}
+	permissions := NewPermissionServiceV2(
+		&guardTestCollaborators{roles: map[string]collaboratorEntity.RoleName{
+			"bse_other/usr_both": collaboratorEntity.RoleViewer,
+		}},
+		nil, nil,
+		&quotaTestTables{tables: []*tableEntity.Table{projects}},
+	)
+	return NewForeignFieldGuard(fields, permissions)
+}
+
+func TestForeignFieldGuard_HiddenFields(t *testing.T) {
+	guard := newGuardTestEnv(t)
+	ctx := context.Background()
+
+	// 不能访问关联表时隐藏跨 Base 的 Link 字段及基于它的 Lookup
+	hidden, err := guard.HiddenFields(ctx, "tbl_test", "usr_host")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"fld_project": true, "fld_project_name": true}, hidden)
+
+	hidden, err = guard.HiddenFields(ctx, "tbl_test", "usr_both")
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+
+	// 系统内部调用不限制
+	hidden, err = guard.HiddenFields(ctx, "tbl_test", "")
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+}
+
+func TestForeignFieldGuard_MaskResponses(t *testing.T) {
+	guard := newGuardTestEnv(t)
+	record := func() *dto.RecordResponse {
+		return &dto.RecordResponse{ID: "rec_1", TableID: "tbl_test", Data: map[string]interface{}{
+			"fld_title":        "任务",
+			"fld_project":      map[string]interface{}{"id": "rec_p", "title": "机密项目"},
+			"fld_project_name": []interface{}{"机密项目"},
+			"fld_points":       3,
+		}}
+	}
+
+	masked := record()
+	require.NoError(t, guard.MaskResponses(authctx.WithUser(context.Background(), "usr_host"), "tbl_test", masked))
+	assert.Equal(t, map[string]interface{}{"fld_title": "任务", "fld_points": 3}, masked.Data)
+
+	visible := record()
+	require.NoError(t, guard.MaskResponses(authctx.WithUser(context.Background(), "usr_both"), "tbl_test", visible))
+	assert.Len(t, visible.Data, 4)
+
+	// 未配置时不做限制
+	var disabled *ForeignFieldGuard
+	unmasked := record()
+	require.NoError(t, disabled.MaskResponses(authctx.WithUser(context.Background(), "usr_host"), "tbl_test", unmasked))
+	assert.Len(t, unmasked.Data, 4)
+}
+
+func TestForeignFieldGuard_RecipientFilter(t *testing.T) {
+	guard := newGuardTestEnv(t)
+
+	fields, canReadAll, err := guard.RecipientFilter(context.Background(), "tbl_test")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"fld_project": true, "fld_project_name": true}, fields)
+	assert.True(t, canReadAll("usr_both"))
+	assert.False(t, canReadAll("usr_host"))
+
+	// 没有跨 Base 字段的表不划分接收者
+	fields, canReadAll, err = guard.RecipientFilter(context.Background(), "tbl_other")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+	assert.Nil(t, canReadAll)
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// graphQLCost 查询的复杂度和字段嵌套深度
+type graphQLCost struct {
+	complexity int
+	depth      int
+}
+
+// graphQLCostAnalyzer 在执行前计算已通过校验的操作的成本
+//
+// 复杂度默认每个字段为 1 加子选择的复杂度，分页查询按 first 放大子选择的复杂度。
+// 被 @skip/@include 排除的选择和内省字段不计入
+type graphQLCostAnalyzer struct {
+	schema    *graphQLSchema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+// analyzeGraphQLCost 计算操作的复杂度和深度
+func analyzeGraphQLCost(schema *graphQLSchema, doc *ast.Document, op *ast.OperationDefinition, variables map[string]interface{}) graphQLCost {
+	a := &graphQLCostAnalyzer{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+		visiting:  make(map[string]bool),
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	root := schema.schema.QueryType()
+	if op.Operation == ast.OperationTypeMutation {
+		root = schema.schema.MutationType()
+	}
+	if root == nil {
+		return graphQLCost{}
+	}
+	complexity, depth := a.selectionSet(root, op.SelectionSet, 0)
+	return graphQLCost{complexity: complexity, depth: depth}
+}
+
+func (a *graphQLCostAnalyzer) selectionSet(parent *graphql.Object, set *ast.SelectionSet, depth int) (int, int) {
+	if set == nil {
+		return 0, depth
+	}
+	complexity, maxDepth := 0, depth
+	for _, selection := range set.Selections {
+		var childComplexity, childDepth int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if !a.included(s.Directives) {
+				continue
+			}
+			childComplexity, childDepth = a.field(parent, s, depth+1)
+		case *ast.FragmentSpread:
+			if !a.included(s.Directives) || s.Name == nil {
+				continue
+			}
+			fragment := a.fragments[s.Name.Value]
+			if fragment == nil || a.visiting[s.Name.Value] {
+				continue
+			}
+			a.visiting[s.Name.Value] = true
+			childComplexity, childDepth = a.selectionSet(parent, fragment.SelectionSet, depth)
+			delete(a.visiting, s.Name.Value)
+		case *ast.InlineFragment:
+			if !a.included(s.Directives) {
+				continue
+			}
+			childComplexity, childDepth = a.selectionSet(parent, s.SelectionSet, depth)
+		}
+		complexity += childComplexity
+		if childDepth > maxDepth {
+			maxDepth = childDepth
+		}
+	}
+	return complexity, maxDepth
+}
+
+func (a *graphQLCostAnalyzer) field(parent *graphql.Object, field *ast.Field, depth int) (int, int) {
+	if field.Name == nil || strings.HasPrefix(field.Name.Value, "__") {
+		return 0, depth - 1
+	}
+	definition := parent.Fields()[field.Name.Value]
+	if definition == nil {
+		return 0, depth
+	}
+
+	childComplexity, childDepth := 0, depth
+	if object, ok := graphql.GetNamed(definition.Type).(*graphql.Object); ok {
+		childComplexity, childDepth = a.selectionSet(object, field.SelectionSet, depth)
+	}
+	if complexity := a.schema.complexity[parent.Name()+"."+field.Name.Value]; complexity != nil {
+		args := make(map[string]interface{}, len(field.Arguments))
+		for _, argument := range field.Arguments {
+			if argument.Name != nil {
+				args[argument.Name.Value] = graphQLASTValue(argument.Value, a.variables)
+			}
+		}
+		return complexity(childComplexity, args), childDepth
+	}
+	return 1 + childComplexity, childDepth
+}
+
+// included 按 @skip/@include 判断选择是否生效
+func (a *graphQLCostAnalyzer) included(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive.Name == nil || (directive.Name.Value != "skip" && directive.Name.Value != "include") {
+			continue
+		}
+		condition := false
+		for _, argument := range directive.Arguments {
+			if argument.Name != nil && argument.Name.Value == "if" {
+				condition, _ = graphQLASTValue(argument.Value, a.variables).(bool)
+			}
+		}
+		if (directive.Name.Value == "skip" && condition) || (directive.Name.Value == "include" && !condition) {
+			return false
+		}
+	}
+	return true
+}
+
+// graphQLASTValue 字面量转换为 JSON 等价的值（整数为 int64，浮点数为 float64），变量取 variables 中的值
+func graphQLASTValue(value ast.Value, variables map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case *ast.Variable:
+		if v.Name == nil {
+			return nil
+		}
+		return variables[v.Name.Value]
+	case *ast.StringValue:
+		return v.Value
+	case *ast.EnumValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+			return i
+		}
+		f, _ := strconv.ParseFloat(v.Value, 64)
+		return f
+	case *ast.FloatValue:
+		f, _ := strconv.ParseFloat(v.Value, 64)
+		return f
+	case *ast.ListValue:
+		items := make([]interface{}, 0, len(v.Values))
+		for _, item := range v.Values {
+			items = append(items, graphQLASTValue(item, variables))
+		}
+		return items
+	case *ast.ObjectValue:
+		fields := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			if field.Name != nil {
+				fields[field.Name.Value] = graphQLASTValue(field.Value, variables)
+			}
+		}
+		return fields
+	}
+	return nil
+}
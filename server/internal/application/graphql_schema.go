@@ -0,0 +1,1023 @@
+package application
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	viewValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+// GraphQL 分页
+const (
+	graphQLDefaultPageSize = 20
+	graphQLMaxPageSize     = 100
+)
+
+// 记录对象上的系统字段，字段名与表字段冲突时表字段加后缀
+var graphQLSystemFields = []string{"id", "createdTime", "lastModifiedTime", "createdBy", "lastModifiedBy", "version"}
+
+// graphQLSystemColumns 可用于过滤和排序的系统列（<T>Field 枚举值 → 物理列）
+var graphQLSystemColumns = []struct {
+	name   string
+	column string
+}{
+	{"ID", "__id"},
+	{"CREATED_TIME", "__created_time"},
+	{"LAST_MODIFIED_TIME", "__last_modified_time"},
+	{"CREATED_BY", "__created_by"},
+	{"LAST_MODIFIED_BY", "__last_modified_by"},
+}
+
+// graphQLFilterOperators 过滤操作符，枚举值名为操作符的大写下划线形式
+var graphQLFilterOperators = []viewValueobject.FilterItemOperator{
+	viewValueobject.FilterItemOpIs, viewValueobject.FilterItemOpIsNot,
+	viewValueobject.FilterItemOpContains, viewValueobject.FilterItemOpNotContains,
+	viewValueobject.FilterItemOpIsEmpty, viewValueobject.FilterItemOpIsNotEmpty,
+	viewValueobject.FilterItemOpGreater, viewValueobject.FilterItemOpGreaterEqual,
+	viewValueobject.FilterItemOpLess, viewValueobject.FilterItemOpLessEqual,
+	viewValueobject.FilterItemOpIsBefore, viewValueobject.FilterItemOpIsAfter, viewValueobject.FilterItemOpIsWithin,
+	viewValueobject.FilterItemOpHasAnyOf, viewValueobject.FilterItemOpHasAllOf, viewValueobject.FilterItemOpHasNoneOf,
+	viewValueobject.FilterItemOpIsExactly, viewValueobject.FilterItemOpIsNotExactly,
+}
+
+// 每张表生成的类型名后缀
+var graphQLTableTypeSuffixes = []string{"", "Connection", "Edge", "Filter", "Condition", "Sort", "Field", "Input", "Patch"}
+
+// graphQLJSON 任意 JSON 值，原样输入输出
+var graphQLJSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "任意 JSON 值",
+	Serialize:   func(value interface{}) interface{} { return value },
+	ParseValue:  func(value interface{}) interface{} { return value },
+	ParseLiteral: func(value ast.Value) interface{} {
+		return graphQLASTValue(value, nil)
+	},
+})
+
+// graphQLDateTime RFC 3339 时间，解析器收到字符串
+var graphQLDateTime = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "DateTime",
+	Description: "RFC 3339 时间",
+	Serialize:   serializeGraphQLDateTime,
+	ParseValue:  parseGraphQLDateTime,
+	ParseLiteral: func(value ast.Value) interface{} {
+		if s, ok := value.(*ast.StringValue); ok {
+			return parseGraphQLDateTime(s.Value)
+		}
+		return nil
+	},
+})
+
+// serializeGraphQLDateTime 时间输出为 RFC 3339 字符串，零值输出 null
+func serializeGraphQLDateTime(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return nil
+		}
+		return v.Format(time.RFC3339Nano)
+	case string:
+		return v
+	}
+	return nil
+}
+
+// parseGraphQLDateTime 接受 RFC 3339 时间或日期字符串，原样交给解析器；无效输入返回 nil 由校验报错
+func parseGraphQLDateTime(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return s
+		}
+	}
+	return nil
+}
+
+// graphQLComplexityFunc 字段复杂度，childComplexity 为子选择的复杂度之和
+type graphQLComplexityFunc func(childComplexity int, args map[string]interface{}) int
+
+// graphQLSchema 生成的 Schema 及按参数计算复杂度的根字段
+type graphQLSchema struct {
+	schema     graphql.Schema
+	complexity map[string]graphQLComplexityFunc // "类型.字段" → 复杂度
+}
+
+// gqlValueKind 字段值在 GraphQL 中的表示
+type gqlValueKind int
+
+const (
+	gqlString gqlValueKind = iota
+	gqlFloat
+	gqlInt
+	gqlBoolean
+	gqlDateTime
+	gqlSelect
+	gqlUser
+	gqlLink
+	gqlJSON
+)
+
+// gqlTable 表格在 Schema 中的映射
+type gqlTable struct {
+	id          string
+	name        string
+	description string
+	typeName    string
+	fields      []*gqlField
+	byName      map[string]*gqlField // GraphQL 字段名 → 字段
+	object      *graphql.Object
+}
+
+// gqlField 字段在 Schema 中的映射
+type gqlField struct {
+	id       string
+	name     string // GraphQL 字段名
+	column   string // 物理列名（排序）
+	kind     gqlValueKind
+	multiple bool
+	readOnly bool
+	required bool
+	choices  *graphql.Enum // 选择字段的选项
+	linked   *gqlTable     // 关联到本 Base 内的表
+	source   *fieldEntity.Field
+}
+
+// graphQLSchemaBuilder 按 Base 的表和字段生成 Schema
+//
+// 每张表生成记录类型 <T>、分页类型 <T>Connection/<T>Edge、过滤与排序输入
+// <T>Filter/<T>Condition/<T>Sort、字段枚举 <T>Field 以及写入输入 <T>Input/<T>Patch。
+// 名称取自表名和字段名的 ASCII 部分，无法生成合法名称时使用 ID
+type graphQLSchemaBuilder struct {
+	service *GraphQLService
+	baseID  string
+	tables  []*gqlTable
+	byID    map[string]*gqlTable
+	types   map[string]bool
+
+	complexity map[string]graphQLComplexityFunc
+
+	pageInfo  *graphql.Object
+	tableInfo *graphql.Object
+	operator  *graphql.Enum
+	conjunct  *graphql.Enum
+	direction *graphql.Enum
+}
+
+func newGraphQLSchemaBuilder(service *GraphQLService, baseID string) *graphQLSchemaBuilder {
+	b := &graphQLSchemaBuilder{
+		service: service,
+		baseID:  baseID,
+		byID:    make(map[string]*gqlTable),
+		types:   make(map[string]bool),
+
+		complexity: make(map[string]graphQLComplexityFunc),
+	}
+	for _, name := range []string{"Query", "Mutation", "PageInfo", "TableInfo", "FilterOperator", "FilterConjunction",
+		"SortDirection", "String", "Int", "Float", "Boolean", "ID", "JSON", "DateTime"} {
+		b.types[name] = true
+	}
+	return b
+}
+
+// build 生成 Schema；tables 与 fields 按表 ID 对应
+func (b *graphQLSchemaBuilder) build(tables []*tableEntity.Table, fields map[string][]*fieldEntity.Field) (*graphQLSchema, error) {
+	b.sharedTypes()
+
+	// 1. 先确定所有表的类型名和字段映射，关联字段需要引用其他表的类型
+	for _, table := range tables {
+		t := &gqlTable{
+			id:       table.ID().String(),
+			name:     table.Name().String(),
+			typeName: b.tableTypeName(table),
+			byName:   make(map[string]*gqlField),
+		}
+		if table.Description() != nil {
+			t.description = *table.Description()
+		}
+		b.tables = append(b.tables, t)
+		b.byID[t.id] = t
+	}
+	for _, t := range b.tables {
+		b.mapFields(t, fields[t.id])
+	}
+
+	// 2. 记录类型（互相引用，字段在生成 Schema 时填充）
+	for _, t := range b.tables {
+		t := t
+		t.object = graphql.NewObject(graphql.ObjectConfig{
+			Name:        t.typeName,
+			Description: graphQLTableDescription(t),
+			Fields:      graphql.FieldsThunk(func() graphql.Fields { return b.objectFields(t) }),
+		})
+	}
+
+	// 3. 根类型
+	query := graphql.Fields{
+		"tables": {
+			Description: "当前用户可以读取的表格及其类型名",
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(b.tableInfo))),
+			Resolve:     b.safeResolve(b.resolveTables),
+		},
+	}
+	mutation := graphql.Fields{}
+	rootNames := map[string]bool{"tables": true}
+	for _, t := range b.tables {
+		b.addRootFields(t, query, mutation, rootNames)
+	}
+
+	config := graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: query})}
+	if len(mutation) > 0 {
+		config.Mutation = graphql.NewObject(graphql.ObjectConfig{Name: "Mutation", Fields: mutation})
+	}
+	schema, err := graphql.NewSchema(config)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLSchema{schema: schema, complexity: b.complexity}, nil
+}
+
+// sharedTypes 所有表共用的类型
+func (b *graphQLSchemaBuilder) sharedTypes() {
+	b.pageInfo = graphql.NewObject(graphql.ObjectConfig{Name: "PageInfo", Description: "分页信息", Fields: graphql.Fields{
+		"hasNextPage":     {Type: graphql.NewNonNull(graphql.Boolean)},
+		"hasPreviousPage": {Type: graphql.NewNonNull(graphql.Boolean)},
+		"startCursor":     {Type: graphql.String},
+		"endCursor":       {Type: graphql.String},
+	}})
+	b.tableInfo = graphql.NewObject(graphql.ObjectConfig{Name: "TableInfo", Description: "表格与 GraphQL 类型的对应关系", Fields: graphql.Fields{
+		"id":          {Type: graphql.NewNonNull(graphql.ID)},
+		"name":        {Type: graphql.NewNonNull(graphql.String)},
+		"typeName":    {Type: graphql.NewNonNull(graphql.String)},
+		"description": {Type: graphql.String},
+	}})
+
+	operators := graphql.EnumValueConfigMap{}
+	for _, op := range graphQLFilterOperators {
+		operators[screamingName(string(op))] = &graphql.EnumValueConfig{Value: string(op)}
+	}
+	b.operator = graphql.NewEnum(graphql.EnumConfig{Name: "FilterOperator", Description: "过滤操作符", Values: operators})
+	b.conjunct = graphql.NewEnum(graphql.EnumConfig{Name: "FilterConjunction", Description: "过滤条件之间的关系", Values: graphql.EnumValueConfigMap{
+		"AND": {Value: string(viewValueobject.FilterOperatorAnd)},
+		"OR":  {Value: string(viewValueobject.FilterOperatorOr)},
+	}})
+	b.direction = graphql.NewEnum(graphql.EnumConfig{Name: "SortDirection", Description: "排序方向", Values: graphql.EnumValueConfigMap{
+		"ASC":  {Value: string(viewValueobject.SortOrderAsc)},
+		"DESC": {Value: string(viewValueobject.SortOrderDesc)},
+	}})
+}
+
+// tableTypeName 表格的类型名，保证与其派生类型名都不冲突
+func (b *graphQLSchemaBuilder) tableTypeName(table *tableEntity.Table) string {
+	name := pascalName(table.Name().String())
+	if name == "" {
+		name = pascalName(table.ID().String())
+	}
+	candidate := name
+	for i := 2; ; i++ {
+		free := true
+		for _, suffix := range graphQLTableTypeSuffixes {
+			if b.types[candidate+suffix] {
+				free = false
+				break
+			}
+		}
+		if free {
+			break
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	for _, suffix := range graphQLTableTypeSuffixes {
+		b.types[candidate+suffix] = true
+	}
+	return candidate
+}
+
+// uniqueTypeName 登记一个不冲突的类型名
+func (b *graphQLSchemaBuilder) uniqueTypeName(name string) string {
+	candidate := name
+	for i := 2; b.types[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	b.types[candidate] = true
+	return candidate
+}
+
+// mapFields 确定字段名和值类型
+func (b *graphQLSchemaBuilder) mapFields(t *gqlTable, fields []*fieldEntity.Field) {
+	sorted := make([]*fieldEntity.Field, 0, len(fields))
+	for _, field := range fields {
+		if !field.IsDeleted() {
+			sorted = append(sorted, field)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order() < sorted[j].Order() })
+
+	used := make(map[string]bool)
+	for _, name := range graphQLSystemFields {
+		used[name] = true
+	}
+	for _, field := range sorted {
+		name := camelName(field.Name().String())
+		if name == "" {
+			name = camelName(field.ID().String())
+		}
+		candidate := name
+		for i := 2; used[candidate]; i++ {
+			candidate = fmt.Sprintf("%s%d", name, i)
+		}
+		used[candidate] = true
+
+		f := &gqlField{
+			id:       field.ID().String(),
+			name:     candidate,
+			column:   field.DBFieldName().String(),
+			readOnly: isReadOnlyField(field),
+			required: field.IsRequired(),
+			source:   field,
+		}
+		b.mapValueKind(t, f)
+		t.fields = append(t.fields, f)
+		t.byName[f.name] = f
+	}
+}
+
+// mapValueKind 字段类型到 GraphQL 值类型
+func (b *graphQLSchemaBuilder) mapValueKind(t *gqlTable, f *gqlField) {
+	options := f.source.Options()
+	if options == nil {
+		options = fieldValueobject.NewFieldOptions()
+	}
+
+	switch f.source.Type().String() {
+	case fieldValueobject.TypeText, fieldValueobject.TypeSingleLineText, fieldValueobject.TypeLongText,
+		fieldValueobject.TypePhone, fieldValueobject.TypeEmail, fieldValueobject.TypeURL,
+		fieldValueobject.TypeCreatedBy, fieldValueobject.TypeLastModifiedBy:
+		f.kind = gqlString
+	case fieldValueobject.TypeNumber, fieldValueobject.TypePercent, fieldValueobject.TypeCurrency,
+		fieldValueobject.TypeDuration, fieldValueobject.TypeRollup:
+		f.kind = gqlFloat
+	case fieldValueobject.TypeRating, fieldValueobject.TypeAutoNumber, fieldValueobject.TypeCount:
+		f.kind = gqlInt
+	case fieldValueobject.TypeBoolean, fieldValueobject.TypeCheckbox:
+		f.kind = gqlBoolean
+	case fieldValueobject.TypeDate, fieldValueobject.TypeDateTime,
+		fieldValueobject.TypeCreatedTime, fieldValueobject.TypeLastModifiedTime:
+		f.kind = gqlDateTime
+	case fieldValueobject.TypeSelect, fieldValueobject.TypeSingleSelect, fieldValueobject.TypeMultipleSelect:
+		f.kind = gqlString
+		f.multiple = f.source.Type().String() == fieldValueobject.TypeMultipleSelect
+		if options.Select != nil && len(options.Select.Choices) > 0 {
+			f.kind = gqlSelect
+			f.choices = b.choiceEnum(t, f, options.Select.Choices)
+		}
+	case fieldValueobject.TypeUser:
+		f.kind = gqlUser
+		f.multiple = options.User != nil && options.User.IsMultiple
+	case fieldValueobject.TypeLink:
+		f.kind = gqlJSON
+		if link := options.Link; link != nil && (link.BaseID == "" || link.BaseID == b.baseID) {
+			if linked := b.byID[link.LinkedTableID]; linked != nil {
+				f.kind = gqlLink
+				f.linked = linked
+				f.multiple = link.UsesJunctionTable()
+			}
+		}
+	default:
+		// 附件、查找、公式、AI 等结构不固定
+		f.kind = gqlJSON
+	}
+}
+
+// choiceEnum 选择字段的选项枚举，枚举值名取自选项名，值为选项名
+func (b *graphQLSchemaBuilder) choiceEnum(t *gqlTable, f *gqlField, choices []fieldValueobject.SelectChoice) *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	used := make(map[string]bool)
+	for i, choice := range choices {
+		name := screamingName(camelName(choice.Name))
+		if name == "" {
+			name = screamingName(camelName(choice.ID))
+		}
+		if name == "" || name == "TRUE" || name == "FALSE" || name == "NULL" {
+			name = fmt.Sprintf("OPTION_%d", i+1)
+		}
+		candidate := name
+		for n := 2; used[candidate]; n++ {
+			candidate = fmt.Sprintf("%s_%d", name, n)
+		}
+		used[candidate] = true
+
+		value := &graphql.EnumValueConfig{Value: choice.Name}
+		if candidate != choice.Name {
+			value.Description = choice.Name
+		}
+		values[candidate] = value
+	}
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name:        b.uniqueTypeName(t.typeName + strings.ToUpper(f.name[:1]) + f.name[1:]),
+		Description: fmt.Sprintf("「%s」的选项", f.source.Name().String()),
+		Values:      values,
+	})
+}
+
+// objectFields 记录类型的字段
+func (b *graphQLSchemaBuilder) objectFields(t *gqlTable) graphql.Fields {
+	fields := graphql.Fields{
+		"id":               {Type: graphql.NewNonNull(graphql.ID), Resolve: recordAttr(func(r *dto.RecordResponse) interface{} { return r.ID })},
+		"createdTime":      {Type: graphQLDateTime, Resolve: recordAttr(func(r *dto.RecordResponse) interface{} { return r.CreatedAt })},
+		"lastModifiedTime": {Type: graphQLDateTime, Resolve: recordAttr(func(r *dto.RecordResponse) interface{} { return r.UpdatedAt })},
+		"createdBy":        {Type: graphql.String, Resolve: recordAttr(func(r *dto.RecordResponse) interface{} { return emptyToNil(r.CreatedBy) })},
+		"lastModifiedBy":   {Type: graphql.String, Resolve: recordAttr(func(r *dto.RecordResponse) interface{} { return emptyToNil(r.UpdatedBy) })},
+		"version":          {Type: graphql.NewNonNull(graphql.Int), Resolve: recordAttr(func(r *dto.RecordResponse) interface{} { return r.Version })},
+	}
+	for _, f := range t.fields {
+		fields[f.name] = &graphql.Field{
+			Description: graphQLFieldDescription(f),
+			Type:        b.outputType(f),
+			Resolve:     b.safeResolve(b.resolveValue(f)),
+		}
+	}
+	return fields
+}
+
+// outputType 字段值的输出类型
+func (b *graphQLSchemaBuilder) outputType(f *gqlField) graphql.Output {
+	var item graphql.Output
+	switch f.kind {
+	case gqlString, gqlUser:
+		item = graphql.String
+	case gqlFloat:
+		item = graphql.Float
+	case gqlInt:
+		item = graphql.Int
+	case gqlBoolean:
+		item = graphql.Boolean
+	case gqlDateTime:
+		item = graphQLDateTime
+	case gqlSelect:
+		item = f.choices
+	case gqlLink:
+		item = f.linked.object
+	default:
+		return graphQLJSON
+	}
+	if f.multiple {
+		return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))
+	}
+	return item
+}
+
+// inputType 字段值的输入类型；关联字段接受记录 ID
+func (b *graphQLSchemaBuilder) inputType(f *gqlField) graphql.Input {
+	var item graphql.Input
+	switch f.kind {
+	case gqlString, gqlUser:
+		item = graphql.String
+	case gqlFloat:
+		item = graphql.Float
+	case gqlInt:
+		item = graphql.Int
+	case gqlBoolean:
+		item = graphql.Boolean
+	case gqlDateTime:
+		item = graphQLDateTime
+	case gqlSelect:
+		item = f.choices
+	case gqlLink:
+		item = graphql.ID
+	default:
+		return graphQLJSON
+	}
+	if f.multiple {
+		return graphql.NewList(graphql.NewNonNull(item))
+	}
+	return item
+}
+
+// addRootFields 表格的查询与变更入口
+func (b *graphQLSchemaBuilder) addRootFields(t *gqlTable, query, mutation graphql.Fields, used map[string]bool) {
+	rootName := func(name string) string {
+		candidate := name
+		for i := 2; used[candidate]; i++ {
+			candidate = fmt.Sprintf("%s%d", name, i)
+		}
+		used[candidate] = true
+		return candidate
+	}
+	camel := strings.ToLower(t.typeName[:1]) + t.typeName[1:]
+
+	// 字段枚举（过滤与排序）
+	fieldValues := graphql.EnumValueConfigMap{}
+	for _, system := range graphQLSystemColumns {
+		fieldValues[system.name] = &graphql.EnumValueConfig{Value: system.column}
+	}
+	for _, f := range t.fields {
+		name := screamingName(f.name)
+		candidate := name
+		for i := 2; fieldValues[candidate] != nil; i++ {
+			candidate = fmt.Sprintf("%s_%d", name, i)
+		}
+		fieldValues[candidate] = &graphql.EnumValueConfig{Description: f.source.Name().String(), Value: f.id}
+	}
+	fieldEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        t.typeName + "Field",
+		Description: fmt.Sprintf("「%s」可用于过滤和排序的字段", t.name),
+		Values:      fieldValues,
+	})
+
+	condition := graphql.NewInputObject(graphql.InputObjectConfig{Name: t.typeName + "Condition", Description: "过滤条件", Fields: graphql.InputObjectConfigFieldMap{
+		"field":    {Type: graphql.NewNonNull(fieldEnum)},
+		"operator": {Type: graphql.NewNonNull(b.operator)},
+		"value":    {Type: graphQLJSON, Description: "比较值，isEmpty/isNotEmpty 不需要"},
+	}})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{Name: t.typeName + "Filter", Description: "过滤条件组", Fields: graphql.InputObjectConfigFieldMap{
+		"conjunction": {Type: b.conjunct, DefaultValue: string(viewValueobject.FilterOperatorAnd)},
+		"conditions":  {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(condition)))},
+	}})
+	sortInput := graphql.NewInputObject(graphql.InputObjectConfig{Name: t.typeName + "Sort", Description: "排序", Fields: graphql.InputObjectConfigFieldMap{
+		"field":     {Type: graphql.NewNonNull(fieldEnum)},
+		"direction": {Type: b.direction, DefaultValue: string(viewValueobject.SortOrderAsc)},
+	}})
+
+	edge := graphql.NewObject(graphql.ObjectConfig{Name: t.typeName + "Edge", Fields: graphql.Fields{
+		"cursor": {Type: graphql.NewNonNull(graphql.String)},
+		"node":   {Type: graphql.NewNonNull(t.object)},
+	}})
+	connection := graphql.NewObject(graphql.ObjectConfig{Name: t.typeName + "Connection", Fields: graphql.Fields{
+		"edges": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge))), Resolve: resolveConnectionEdges},
+		"nodes": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t.object))), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*recordConnection).records, nil
+		}},
+		"pageInfo": {Type: graphql.NewNonNull(b.pageInfo), Resolve: resolveConnectionPageInfo},
+		"totalCount": {Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*recordConnection).total, nil
+		}},
+	}})
+
+	recordsName := rootName(camel + "Records")
+	query[recordsName] = &graphql.Field{
+		Description: fmt.Sprintf("分页查询「%s」的记录", t.name),
+		Type:        graphql.NewNonNull(connection),
+		Args: graphql.FieldConfigArgument{
+			"first":  {Type: graphql.Int, DefaultValue: graphQLDefaultPageSize, Description: fmt.Sprintf("每页数量（1-%d）", graphQLMaxPageSize)},
+			"after":  {Type: graphql.String, Description: "上一页的 endCursor"},
+			"filter": {Type: filter},
+			"sort":   {Type: graphql.NewList(graphql.NewNonNull(sortInput)), Description: "排序，目前按第一项排序"},
+		},
+		Resolve: b.safeResolve(b.service.resolveRecords(t)),
+	}
+	b.complexity["Query."+recordsName] = func(childComplexity int, args map[string]interface{}) int {
+		first, ok := graphQLNumber(args["first"])
+		if !ok {
+			first = graphQLDefaultPageSize
+		}
+		if first < 1 {
+			first = 1
+		}
+		return 1 + childComplexity*int(first)
+	}
+	query[rootName(camel)] = &graphql.Field{
+		Description: fmt.Sprintf("按 ID 获取「%s」的记录", t.name),
+		Type:        t.object,
+		Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+		Resolve:     b.safeResolve(b.service.resolveRecord(t)),
+	}
+
+	// 变更
+	inputFields := graphql.InputObjectConfigFieldMap{}
+	patchFields := graphql.InputObjectConfigFieldMap{}
+	for _, f := range t.fields {
+		if f.readOnly {
+			continue
+		}
+		inputType := b.inputType(f)
+		patchFields[f.name] = &graphql.InputObjectFieldConfig{Description: f.source.Name().String(), Type: inputType}
+		if f.required {
+			inputType = graphql.NewNonNull(inputType)
+		}
+		inputFields[f.name] = &graphql.InputObjectFieldConfig{Description: f.source.Name().String(), Type: inputType}
+	}
+	if len(inputFields) == 0 {
+		return
+	}
+	input := graphql.NewInputObject(graphql.InputObjectConfig{Name: t.typeName + "Input", Description: fmt.Sprintf("创建「%s」记录", t.name), Fields: inputFields})
+	patch := graphql.NewInputObject(graphql.InputObjectConfig{Name: t.typeName + "Patch", Description: fmt.Sprintf("更新「%s」记录，只写入提供的字段", t.name), Fields: patchFields})
+
+	mutation[rootName("create"+t.typeName)] = &graphql.Field{
+		Type:    graphql.NewNonNull(t.object),
+		Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(input)}},
+		Resolve: b.safeResolve(b.service.resolveCreate(t)),
+	}
+	mutation[rootName("update"+t.typeName)] = &graphql.Field{
+		Type: graphql.NewNonNull(t.object),
+		Args: graphql.FieldConfigArgument{
+			"id":      {Type: graphql.NewNonNull(graphql.ID)},
+			"input":   {Type: graphql.NewNonNull(patch)},
+			"version": {Type: graphql.Int, Description: "记录版本号，用于乐观锁"},
+		},
+		Resolve: b.safeResolve(b.service.resolveUpdate(t)),
+	}
+	mutation[rootName("delete"+t.typeName)] = &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.ID),
+		Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+		Resolve: b.safeResolve(b.service.resolveDelete(t)),
+	}
+}
+
+// resolveTables 当前用户可以读取的表格
+func (b *graphQLSchemaBuilder) resolveTables(p graphql.ResolveParams) (interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(b.tables))
+	for _, t := range b.tables {
+		if err := b.service.requireAction(p.Context, t.id, permission.ActionRecordRead); err != nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"id":          t.id,
+			"name":        t.name,
+			"typeName":    t.typeName,
+			"description": emptyToNil(t.description),
+		})
+	}
+	return result, nil
+}
+
+// resolveValue 读取并规整记录中的字段值
+func (b *graphQLSchemaBuilder) resolveValue(f *gqlField) graphql.FieldResolveFn {
+	if f.kind == gqlLink {
+		return b.service.resolveLink(f)
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		record := p.Source.(*dto.RecordResponse)
+		return graphQLCellValue(f, record.Data[f.id]), nil
+	}
+}
+
+// recordAttr 记录属性的解析器
+func recordAttr(get func(r *dto.RecordResponse) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*dto.RecordResponse)), nil
+	}
+}
+
+// recordConnection 分页查询结果
+type recordConnection struct {
+	records []*dto.RecordResponse
+	total   int64
+	offset  int
+}
+
+func resolveConnectionEdges(p graphql.ResolveParams) (interface{}, error) {
+	conn := p.Source.(*recordConnection)
+	edges := make([]map[string]interface{}, len(conn.records))
+	for i, record := range conn.records {
+		edges[i] = map[string]interface{}{"cursor": encodeRecordCursor(conn.offset + i + 1), "node": record}
+	}
+	return edges, nil
+}
+
+func resolveConnectionPageInfo(p graphql.ResolveParams) (interface{}, error) {
+	conn := p.Source.(*recordConnection)
+	info := map[string]interface{}{
+		"hasNextPage":     int64(conn.offset+len(conn.records)) < conn.total,
+		"hasPreviousPage": conn.offset > 0,
+		"startCursor":     nil,
+		"endCursor":       nil,
+	}
+	if len(conn.records) > 0 {
+		info["startCursor"] = encodeRecordCursor(conn.offset + 1)
+		info["endCursor"] = encodeRecordCursor(conn.offset + len(conn.records))
+	}
+	return info, nil
+}
+
+// 游标为不透明字符串，内容是记录在结果中的位置（从 1 开始）
+const recordCursorPrefix = "cursor:"
+
+func encodeRecordCursor(position int) string {
+	return base64.StdEncoding.EncodeToString([]byte(recordCursorPrefix + strconv.Itoa(position)))
+}
+
+// decodeRecordCursor 游标转换为下一页的偏移量
+func decodeRecordCursor(cursor string) (int, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(raw), recordCursorPrefix) {
+		if position, convErr := strconv.Atoi(strings.TrimPrefix(string(raw), recordCursorPrefix)); convErr == nil && position >= 0 {
+			return position, nil
+		}
+	}
+	return 0, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("无效的游标: %s", cursor))
+}
+
+// graphQLRecordQuery 分页、过滤和排序参数转换为记录查询
+func graphQLRecordQuery(t *gqlTable, args map[string]interface{}) (RecordQuery, error) {
+	query := RecordQuery{OrderBy: "__auto_number", OrderDir: string(viewValueobject.SortOrderAsc)}
+
+	first, _ := args["first"].(int)
+	if first < 1 || first > graphQLMaxPageSize {
+		return query, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("first 必须在 1 到 %d 之间", graphQLMaxPageSize))
+	}
+	query.Limit = first
+	if after, ok := args["after"].(string); ok && after != "" {
+		offset, err := decodeRecordCursor(after)
+		if err != nil {
+			return query, err
+		}
+		query.Offset = offset
+	}
+
+	if raw, ok := args["filter"].(map[string]interface{}); ok {
+		filter := &viewValueobject.Filter{Operator: viewValueobject.FilterOperatorAnd}
+		if conjunction, ok := raw["conjunction"].(string); ok {
+			filter.Operator = viewValueobject.FilterOperator(conjunction)
+		}
+		conditions, _ := raw["conditions"].([]interface{})
+		for _, item := range conditions {
+			condition := item.(map[string]interface{})
+			filter.Filters = append(filter.Filters, viewValueobject.FilterItem{
+				FieldID:  condition["field"].(string),
+				Operator: viewValueobject.FilterItemOperator(condition["operator"].(string)),
+				Value:    condition["value"],
+			})
+		}
+		if len(filter.Filters) > 0 {
+			if err := filter.Validate(); err != nil {
+				return query, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("过滤条件无效: %v", err))
+			}
+			query.Filters = []*viewValueobject.Filter{filter}
+		}
+	}
+
+	if raw, ok := args["sort"].([]interface{}); ok && len(raw) > 0 {
+		sortSpec := &viewValueobject.Sort{}
+		for _, item := range raw {
+			sortItem := item.(map[string]interface{})
+			order := viewValueobject.SortOrderAsc
+			if direction, ok := sortItem["direction"].(string); ok {
+				order = viewValueobject.SortOrder(direction)
+			}
+			sortSpec.SortItems = append(sortSpec.SortItems, viewValueobject.SortItem{
+				FieldID: sortItem["field"].(string),
+				Order:   order,
+			})
+		}
+		if err := sortSpec.Validate(); err != nil {
+			return query, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("排序无效: %v", err))
+		}
+		// 仓储只支持单列排序，与视图一致按第一项排序
+		first := sortSpec.SortItems[0]
+		column := first.FieldID
+		if !strings.HasPrefix(column, "__") {
+			column = ""
+			for _, f := range t.fields {
+				if f.id == first.FieldID {
+					column = f.column
+				}
+			}
+		}
+		if column != "" {
+			query.OrderBy = column
+			query.OrderDir = string(first.Order)
+		}
+	}
+	return query, nil
+}
+
+// graphQLRecordData 输入对象转换为以字段 ID 为键的记录数据
+func graphQLRecordData(t *gqlTable, input map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{}, len(input))
+	for name, value := range input {
+		f := t.byName[name]
+		if f == nil {
+			continue
+		}
+		if f.kind == gqlLink && value != nil {
+			// 关联字段写入 {id} 或 [{id}]
+			if f.multiple {
+				ids, _ := value.([]interface{})
+				links := make([]interface{}, 0, len(ids))
+				for _, id := range ids {
+					links = append(links, map[string]interface{}{"id": id})
+				}
+				value = links
+			} else {
+				value = map[string]interface{}{"id": value}
+			}
+		}
+		data[f.id] = value
+	}
+	return data
+}
+
+// graphQLCellValue 规整单元格值，使其符合字段的 GraphQL 类型；无法表示的值视为空
+func graphQLCellValue(f *gqlField, value interface{}) interface{} {
+	if f.kind == gqlJSON {
+		return value
+	}
+	if f.multiple {
+		var items []interface{}
+		switch v := value.(type) {
+		case nil:
+		case []interface{}:
+			items = v
+		case []string:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		default:
+			items = []interface{}{v}
+		}
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			if normalized := graphQLScalarValue(f, item); normalized != nil {
+				result = append(result, normalized)
+			}
+		}
+		return result
+	}
+	return graphQLScalarValue(f, value)
+}
+
+func graphQLScalarValue(f *gqlField, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch f.kind {
+	case gqlString:
+		return graphQLString(value)
+	case gqlUser:
+		if m, ok := value.(map[string]interface{}); ok {
+			value = m["id"]
+		}
+		return graphQLString(value)
+	case gqlFloat:
+		if number, ok := graphQLNumber(value); ok {
+			return number
+		}
+	case gqlInt:
+		if number, ok := graphQLNumber(value); ok && number <= math.MaxInt32 && number >= math.MinInt32 {
+			return int(math.Round(number))
+		}
+	case gqlBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+	case gqlDateTime:
+		switch v := value.(type) {
+		case time.Time, *time.Time:
+			return v
+		case string:
+			if v != "" {
+				return v
+			}
+		}
+	case gqlSelect:
+		if m, ok := value.(map[string]interface{}); ok {
+			value = m["name"]
+		}
+		if name, ok := value.(string); ok {
+			for _, choice := range f.choices.Values() {
+				if choice.Value == name {
+					return name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func graphQLString(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case bool, int, int32, int64, float32, float64, json.Number:
+		return fmt.Sprint(v)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return string(encoded)
+}
+
+func graphQLNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func graphQLTableDescription(t *gqlTable) string {
+	description := fmt.Sprintf("表格「%s」（%s）", t.name, t.id)
+	if t.description != "" {
+		description += "\n\n" + t.description
+	}
+	return description
+}
+
+func graphQLFieldDescription(f *gqlField) string {
+	description := fmt.Sprintf("%s（%s，%s）", f.source.Name().String(), f.source.Type().String(), f.id)
+	if f.source.Description() != nil && *f.source.Description() != "" {
+		description += "：" + *f.source.Description()
+	}
+	return description
+}
+
+// graphQLWords 名称中的 ASCII 单词
+func graphQLWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+}
+
+// pascalName 大驼峰名称；不能以数字开头，无法生成时返回空
+func pascalName(name string) string {
+	var sb strings.Builder
+	for _, word := range graphQLWords(name) {
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	result := sb.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		return ""
+	}
+	return result
+}
+
+// camelName 小驼峰名称；全大写的首个单词整体转小写（URL → url）
+func camelName(name string) string {
+	pascal := pascalName(name)
+	if pascal == "" {
+		return ""
+	}
+	words := graphQLWords(name)
+	if first := words[0]; first == strings.ToUpper(first) {
+		return strings.ToLower(first) + pascal[len(first):]
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+// screamingName 大写下划线名称（hasAnyOf → HAS_ANY_OF）
+func screamingName(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
+
+func emptyToNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// graphQLBuiltinScalars 规范内置的标量，SDL 中不输出
+var graphQLBuiltinScalars = map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+
+// printGraphQLSchema 输出 Schema 的 SDL，不含内置标量和内省类型；类型、字段和参数按名称排序
+func printGraphQLSchema(schema *graphql.Schema) string {
+	names := make([]string, 0, len(schema.TypeMap()))
+	for name := range schema.TypeMap() {
+		if !strings.HasPrefix(name, "__") && !graphQLBuiltinScalars[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	blocks := make([]string, 0, len(names))
+	for _, name := range names {
+		if block := printGraphQLType(schema.Type(name)); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func printGraphQLType(t graphql.Type) string {
+	var sb strings.Builder
+	sb.WriteString(printGraphQLDescription(t.Description(), ""))
+	switch typ := t.(type) {
+	case *graphql.Scalar:
+		sb.WriteString("scalar " + typ.Name())
+	case *graphql.Enum:
+		values := append([]*graphql.EnumValueDefinition(nil), typ.Values()...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+		sb.WriteString("enum " + typ.Name() + " {\n")
+		for _, value := range values {
+			sb.WriteString(printGraphQLDescription(value.Description, "  "))
+			sb.WriteString("  " + value.Name + printGraphQLDeprecated(value.DeprecationReason) + "\n")
+		}
+		sb.WriteString("}")
+	case *graphql.Object:
+		fields := typ.Fields()
+		sb.WriteString("type " + typ.Name() + " {\n")
+		for _, name := range sortedKeys(fields) {
+			field := fields[name]
+			sb.WriteString(printGraphQLDescription(field.Description, "  "))
+			sb.WriteString("  " + name + printGraphQLArgs(field.Args) + ": " + field.Type.String() + printGraphQLDeprecated(field.DeprecationReason) + "\n")
+		}
+		sb.WriteString("}")
+	case *graphql.InputObject:
+		fields := typ.Fields()
+		sb.WriteString("input " + typ.Name() + " {\n")
+		for _, name := range sortedKeys(fields) {
+			field := fields[name]
+			sb.WriteString(printGraphQLDescription(field.Description(), "  "))
+			sb.WriteString("  " + printGraphQLInputValue(name, field.Type, field.DefaultValue) + "\n")
+		}
+		sb.WriteString("}")
+	default:
+		return ""
+	}
+	return sb.String()
+}
+
+func printGraphQLArgs(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sorted := append([]*graphql.Argument(nil), args...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+	parts := make([]string, len(sorted))
+	for i, arg := range sorted {
+		parts[i] = printGraphQLInputValue(arg.Name(), arg.Type, arg.DefaultValue)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func printGraphQLInputValue(name string, t graphql.Input, defaultValue interface{}) string {
+	s := name + ": " + t.String()
+	if defaultValue != nil {
+		s += " = " + printGraphQLValue(defaultValue, t)
+	}
+	return s
+}
+
+func printGraphQLDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	if !strings.Contains(description, "\n") {
+		quoted, _ := json.Marshal(description)
+		return indent + string(quoted) + "\n"
+	}
+	return indent + `"""` + "\n" + indent + strings.ReplaceAll(strings.ReplaceAll(description, `"""`, `\"""`), "\n", "\n"+indent) + "\n" + indent + `"""` + "\n"
+}
+
+func printGraphQLDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	quoted, _ := json.Marshal(reason)
+	return " @deprecated(reason: " + string(quoted) + ")"
+}
+
+// printGraphQLValue 以 GraphQL 字面量输出默认值（枚举输出名称）
+func printGraphQLValue(value interface{}, t graphql.Type) string {
+	if enum, ok := graphql.GetNullable(t).(*graphql.Enum); ok {
+		if name, ok := enum.Serialize(value).(string); ok {
+			return name
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		quoted, _ := json.Marshal(v)
+		return string(quoted)
+	case bool, int, int64, float64:
+		return fmt.Sprint(v)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,502 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	viewDomain "github.com/easyspace-ai/luckdb/server/internal/domain/view"
+	viewValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/authctx"
+	"github.com/easyspace-ai/luckdb/server/pkg/dataloader"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// GraphQL 查询成本限制
+const (
+	graphQLMaxComplexity = 5000
+	graphQLMaxDepth      = 12
+)
+
+// GraphQLService 按 Base 的表和字段生成 GraphQL Schema 并执行查询 ✨
+//
+// 解析、校验和执行使用 graphql-go，这里只负责生成 Schema、解析器和查询成本限制。
+// Schema 按表结构指纹缓存，表或字段变更后下次请求时重建。
+// 读写都经过 RecordService，计算字段、行级权限和实时推送与 REST 接口一致；
+// 关联字段通过按请求创建的 dataloader 合并为每张关联表一次查询
+type GraphQLService struct {
+	tableRepo     tableRepo.TableRepository
+	fieldRepo     repository.FieldRepository
+	recordService *RecordService
+	permissions   *PermissionServiceV2
+	rowPermission *RowPermissionService
+
+	mu      sync.Mutex
+	schemas map[string]*cachedGraphQLSchema // baseID → Schema
+}
+
+type cachedGraphQLSchema struct {
+	fingerprint string
+	schema      *graphQLSchema
+}
+
+// NewGraphQLService 创建 GraphQL 服务
+func NewGraphQLService(
+	tableRepo tableRepo.TableRepository,
+	fieldRepo repository.FieldRepository,
+	recordService *RecordService,
+	permissions *PermissionServiceV2,
+	rowPermission *RowPermissionService,
+) *GraphQLService {
+	return &GraphQLService{
+		tableRepo:     tableRepo,
+		fieldRepo:     fieldRepo,
+		recordService: recordService,
+		permissions:   permissions,
+		rowPermission: rowPermission,
+		schemas:       make(map[string]*cachedGraphQLSchema),
+	}
+}
+
+// Execute 在 Base 的 Schema 上执行 GraphQL 请求
+// 返回的 error 表示请求无法执行（无权访问、Base 不存在）；查询本身的错误在结果的 errors 中
+func (s *GraphQLService) Execute(ctx context.Context, baseID, userID string, req dto.GraphQLRequest) (*graphql.Result, error) {
+	schema, err := s.loadSchema(ctx, baseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = authctx.WithUser(ctx, userID)
+	ctx = context.WithValue(ctx, graphQLRequestKey{}, newGraphQLRequestState(s, userID))
+	return schema.execute(ctx, req), nil
+}
+
+// execute 解析、校验请求并在成本限制内执行
+func (c *graphQLSchema) execute(ctx context.Context, req dto.GraphQLRequest) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&c.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	op := graphQLOperation(doc, req.OperationName)
+	if op == nil {
+		// 操作名无效或缺少操作名，由执行阶段返回对应错误
+		return graphql.Execute(graphql.ExecuteParams{Schema: c.schema, AST: doc, OperationName: req.OperationName, Context: ctx})
+	}
+	if req.ReadOnly && op.Operation != ast.OperationTypeQuery {
+		return graphQLErrorResult(fmt.Sprintf("Can only perform a %s operation from a POST request.", op.Operation), nil)
+	}
+	cost := analyzeGraphQLCost(c, doc, op, req.Variables)
+	if cost.depth > graphQLMaxDepth {
+		return graphQLErrorResult(
+			fmt.Sprintf("Query depth %d exceeds the maximum allowed depth of %d.", cost.depth, graphQLMaxDepth),
+			map[string]interface{}{"code": "QUERY_TOO_DEEP", "depth": cost.depth, "maxDepth": graphQLMaxDepth})
+	}
+	if cost.complexity > graphQLMaxComplexity {
+		return graphQLErrorResult(
+			fmt.Sprintf("Query complexity %d exceeds the maximum allowed complexity of %d.", cost.complexity, graphQLMaxComplexity),
+			map[string]interface{}{"code": "QUERY_TOO_COMPLEX", "complexity": cost.complexity, "maxComplexity": graphQLMaxComplexity})
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        c.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	result.Extensions = map[string]interface{}{"complexity": cost.complexity}
+	for i, resultErr := range result.Errors {
+		result.Errors[i] = formatGraphQLError(resultErr)
+	}
+	return result
+}
+
+// graphQLOperation 按名称选择要执行的操作，找不到时由执行阶段报告错误
+func graphQLOperation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		op, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = op
+		} else if op.Name != nil && op.Name.Value == name {
+			return op
+		}
+	}
+	return found
+}
+
+// graphQLErrorResult 请求未执行时的错误结果
+func graphQLErrorResult(message string, extensions map[string]interface{}) *graphql.Result {
+	err := gqlerrors.NewFormattedError(message)
+	err.Extensions = extensions
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{err}}
+}
+
+// loadSchema 获取 Base 的 Schema（按表结构指纹缓存）
+func (s *GraphQLService) loadSchema(ctx context.Context, baseID, userID string) (*graphQLSchema, error) {
+	if s.permissions != nil && !s.permissions.CanAccessBase(ctx, userID, baseID) {
+		return nil, pkgerrors.ErrForbidden.WithDetails("无权访问该 Base")
+	}
+
+	tables, err := s.tableRepo.GetByBaseID(ctx, baseID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+	active := make([]*tableEntity.Table, 0, len(tables))
+	for _, table := range tables {
+		if !table.IsDeleted() {
+			active = append(active, table)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		if !active[i].CreatedAt().Equal(active[j].CreatedAt()) {
+			return active[i].CreatedAt().Before(active[j].CreatedAt())
+		}
+		return active[i].ID().String() < active[j].ID().String()
+	})
+
+	fields := make(map[string][]*fieldEntity.Field, len(active))
+	for _, table := range active {
+		tableFields, err := s.fieldRepo.FindByTableID(ctx, table.ID().String())
+		if err != nil {
+			return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+		}
+		fields[table.ID().String()] = tableFields
+	}
+
+	fingerprint := graphQLSchemaFingerprint(active, fields)
+	s.mu.Lock()
+	cached := s.schemas[baseID]
+	s.mu.Unlock()
+	if cached != nil && cached.fingerprint == fingerprint {
+		return cached.schema, nil
+	}
+
+	schema, err := newGraphQLSchemaBuilder(s, baseID).build(active, fields)
+	if err != nil {
+		return nil, pkgerrors.ErrInternalServer.WithDetails(fmt.Sprintf("生成 GraphQL Schema 失败: %v", err))
+	}
+	s.mu.Lock()
+	s.schemas[baseID] = &cachedGraphQLSchema{fingerprint: fingerprint, schema: schema}
+	s.mu.Unlock()
+	return schema, nil
+}
+
+// SDL 获取 Base 的 Schema 定义语言文本
+func (s *GraphQLService) SDL(ctx context.Context, baseID, userID string) (string, error) {
+	schema, err := s.loadSchema(ctx, baseID, userID)
+	if err != nil {
+		return "", err
+	}
+	return printGraphQLSchema(&schema.schema), nil
+}
+
+// graphQLSchemaFingerprint 影响 Schema 的表和字段属性
+func graphQLSchemaFingerprint(tables []*tableEntity.Table, fields map[string][]*fieldEntity.Field) string {
+	hash := sha256.New()
+	for _, table := range tables {
+		description := ""
+		if table.Description() != nil {
+			description = *table.Description()
+		}
+		fmt.Fprintf(hash, "table|%s|%s|%s\n", table.ID().String(), table.Name().String(), description)
+		for _, field := range fields[table.ID().String()] {
+			options, _ := json.Marshal(field.Options())
+			fieldDescription := ""
+			if field.Description() != nil {
+				fieldDescription = *field.Description()
+			}
+			fmt.Fprintf(hash, "field|%s|%s|%s|%s|%v|%v|%v|%g|%s|%s\n",
+				field.ID().String(), field.Name().String(), field.Type().String(), field.DBFieldName().String(),
+				field.IsRequired(), field.IsComputed(), field.IsDeleted(), field.Order(), fieldDescription, options)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// graphQLRequestKey 请求状态在 context 中的键
+type graphQLRequestKey struct{}
+
+// graphQLRequestState 单次请求内的角色缓存和关联记录加载器
+type graphQLRequestState struct {
+	service *GraphQLService
+	userID  string
+
+	mu      sync.Mutex
+	roles   map[string]permission.Role
+	loaders map[string]*dataloader.Loader[string, *dto.RecordResponse]
+}
+
+func newGraphQLRequestState(service *GraphQLService, userID string) *graphQLRequestState {
+	return &graphQLRequestState{
+		service: service,
+		userID:  userID,
+		roles:   make(map[string]permission.Role),
+		loaders: make(map[string]*dataloader.Loader[string, *dto.RecordResponse]),
+	}
+}
+
+func graphQLRequestFrom(ctx context.Context) *graphQLRequestState {
+	state, _ := ctx.Value(graphQLRequestKey{}).(*graphQLRequestState)
+	return state
+}
+
+// loader 关联表的记录加载器，通过 RecordService 查询以应用行级权限和计算字段
+func (r *graphQLRequestState) loader(tableID string) *dataloader.Loader[string, *dto.RecordResponse] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if loader, ok := r.loaders[tableID]; ok {
+		return loader
+	}
+	loader := dataloader.New(func(ctx context.Context, ids []string) (map[string]*dto.RecordResponse, error) {
+		records, _, err := r.service.recordService.QueryRecords(ctx, tableID, RecordQuery{
+			Filters: []*viewValueobject.Filter{viewDomain.LinkRecordIDFilter(ids)},
+			Limit:   len(ids),
+		})
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]*dto.RecordResponse, len(records))
+		for _, record := range records {
+			result[record.ID] = record
+		}
+		return result, nil
+	})
+	r.loaders[tableID] = loader
+	return loader
+}
+
+// requireAction 校验当前用户在表格上的角色权限，角色按请求缓存
+func (s *GraphQLService) requireAction(ctx context.Context, tableID string, action permission.Action) error {
+	state := graphQLRequestFrom(ctx)
+	if s.rowPermission == nil || state == nil || state.userID == "" {
+		return nil
+	}
+
+	state.mu.Lock()
+	role, ok := state.roles[tableID]
+	state.mu.Unlock()
+	if !ok {
+		resolved, err := s.rowPermission.ResolveRole(ctx, tableID, state.userID)
+		if err != nil {
+			return err
+		}
+		role = resolved
+		state.mu.Lock()
+		state.roles[tableID] = role
+		state.mu.Unlock()
+	}
+	if !permission.HasRolePermission(role, action) {
+		return pkgerrors.ErrForbidden.WithDetails(fmt.Sprintf("当前角色没有 %s 权限", action))
+	}
+	return nil
+}
+
+// resolveRecords <t>Records：分页查询
+func (s *GraphQLService) resolveRecords(t *gqlTable) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err := s.requireAction(p.Context, t.id, permission.ActionRecordRead); err != nil {
+			return nil, err
+		}
+		query, err := graphQLRecordQuery(t, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		records, total, err := s.recordService.QueryRecords(p.Context, t.id, query)
+		if err != nil {
+			return nil, err
+		}
+		return &recordConnection{records: records, total: total, offset: query.Offset}, nil
+	}
+}
+
+// resolveRecord <t>(id)：不存在或不可见的记录返回 null
+func (s *GraphQLService) resolveRecord(t *gqlTable) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err := s.requireAction(p.Context, t.id, permission.ActionRecordRead); err != nil {
+			return nil, err
+		}
+		record, err := s.recordService.GetRecord(p.Context, t.id, p.Args["id"].(string))
+		if err != nil {
+			if appErr, ok := pkgerrors.IsAppError(err); ok && appErr.Code == pkgerrors.ErrNotFound.Code {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return record, nil
+	}
+}
+
+// resolveLink 关联字段：登记到关联表的加载器，同一轮的关联记录合并查询
+func (s *GraphQLService) resolveLink(f *gqlField) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		record := p.Source.(*dto.RecordResponse)
+		ids := fieldValueobject.ExtractLinkRecordIDs(record.Data[f.id])
+		if len(ids) == 0 {
+			if f.multiple {
+				return []*dto.RecordResponse{}, nil
+			}
+			return nil, nil
+		}
+		if !f.multiple {
+			ids = ids[:1]
+		}
+
+		state := graphQLRequestFrom(p.Context)
+		if state == nil {
+			return nil, pkgerrors.ErrInternalServer.WithDetails("缺少 GraphQL 请求上下文")
+		}
+		load := state.loader(f.linked.id).LoadMany(p.Context, ids)
+		return func() (result interface{}, err error) {
+			defer recoverGraphQLPanic(&err)
+			loaded, err := load()
+			if err != nil {
+				return nil, err
+			}
+			// 不可见或已删除的关联记录不返回
+			visible := make([]*dto.RecordResponse, 0, len(loaded))
+			for _, linked := range loaded {
+				if linked != nil {
+					visible = append(visible, linked)
+				}
+			}
+			if f.multiple {
+				return visible, nil
+			}
+			if len(visible) == 0 {
+				return nil, nil
+			}
+			return visible[0], nil
+		}, nil
+	}
+}
+
+// resolveCreate create<T>
+func (s *GraphQLService) resolveCreate(t *gqlTable) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err := s.requireAction(p.Context, t.id, permission.ActionRecordCreate); err != nil {
+			return nil, err
+		}
+		input, _ := p.Args["input"].(map[string]interface{})
+		return s.recordService.CreateRecord(p.Context, dto.CreateRecordRequest{
+			TableID: t.id,
+			Data:    graphQLRecordData(t, input),
+		}, currentUserID(p.Context))
+	}
+}
+
+// resolveUpdate update<T>
+func (s *GraphQLService) resolveUpdate(t *gqlTable) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err := s.requireAction(p.Context, t.id, permission.ActionRecordUpdate); err != nil {
+			return nil, err
+		}
+		input, _ := p.Args["input"].(map[string]interface{})
+		req := dto.UpdateRecordRequest{Data: graphQLRecordData(t, input)}
+		if version, ok := p.Args["version"].(int); ok {
+			req.Version = &version
+		}
+		return s.recordService.UpdateRecord(p.Context, t.id, p.Args["id"].(string), req, currentUserID(p.Context))
+	}
+}
+
+// resolveDelete delete<T>，返回被删除的记录 ID
+func (s *GraphQLService) resolveDelete(t *gqlTable) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err := s.requireAction(p.Context, t.id, permission.ActionRecordDelete); err != nil {
+			return nil, err
+		}
+		id := p.Args["id"].(string)
+		if err := s.recordService.DeleteRecord(p.Context, t.id, id); err != nil {
+			return nil, err
+		}
+		return id, nil
+	}
+}
+
+// safeResolve 解析器 panic 时记录日志，返回不含 panic 信息的错误
+func (b *graphQLSchemaBuilder) safeResolve(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		defer recoverGraphQLPanic(&err)
+		return resolve(p)
+	}
+}
+
+// recoverGraphQLPanic 在解析器中 defer 调用
+func recoverGraphQLPanic(err *error) {
+	if recovered := recover(); recovered != nil {
+		logger.Error("GraphQL 解析器 panic",
+			logger.Any("panic", recovered),
+			logger.String("stack", string(debug.Stack())))
+		*err = pkgerrors.ErrInternalServer
+	}
+}
+
+// formatGraphQLError 应用错误的错误码放入 extensions，解析器的其他错误不向客户端暴露细节；
+// 语法、校验等 GraphQL 自身的错误原样返回
+func formatGraphQLError(err gqlerrors.FormattedError) gqlerrors.FormattedError {
+	original := graphQLOriginalError(err)
+	if original == nil {
+		return err
+	}
+	if appErr, ok := pkgerrors.IsAppError(original); ok {
+		err.Message = appErr.Message
+		if details, ok := appErr.Details.(string); ok && details != "" {
+			err.Message += ": " + details
+		}
+		err.Extensions = map[string]interface{}{"code": appErr.Code}
+		return err
+	}
+
+	logger.Error("GraphQL 解析器错误", logger.ErrorField(original))
+	err.Message = pkgerrors.ErrInternalServer.Message
+	err.Extensions = map[string]interface{}{"code": pkgerrors.ErrInternalServer.Code}
+	return err
+}
+
+// graphQLOriginalError 解析器返回的原始错误，GraphQL 自身的错误返回 nil
+func graphQLOriginalError(err error) error {
+	for err != nil {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/table/valueobject"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+func newGraphQLTable(t *testing.T, name string) *tableEntity.Table {
+	t.Helper()
+	tableName, err := tableValueobject.NewTableName(name)
+	require.NoError(t, err)
+	table, err := tableEntity.NewTable("bse_test", tableName, "usr_test")
+	require.NoError(t, err)
+	return table
+}
+
+// buildGraphQLTestSchema 任务表关联项目表，项目表反向关联多个任务
+func buildGraphQLTestSchema(t *testing.T) (*graphqlTestTables, *graphQLSchema) {
+	t.Helper()
+	tasks := newGraphQLTable(t, "Tasks")
+	projects := newGraphQLTable(t, "项目")
+
+	status := fieldValueobject.NewFieldOptions()
+	status.Select = &fieldValueobject.SelectOptions{
+		Choices: []fieldValueobject.SelectChoice{{ID: "c1", Name: "In progress"}, {ID: "c2", Name: "完成"}},
+	}
+	projectLink := fieldValueobject.NewFieldOptions()
+	projectLink.Link = &fieldValueobject.LinkOptions{LinkedTableID: projects.ID().String(), Relationship: "many_to_one"}
+	tasksLink := fieldValueobject.NewFieldOptions()
+	tasksLink.Link = &fieldValueobject.LinkOptions{LinkedTableID: tasks.ID().String(), Relationship: "one_to_many"}
+
+	title := newSpecField(t, "fld_title", "Task title", fieldValueobject.TypeSingleLineText, 1, nil)
+	require.NoError(t, title.SetRequired(true))
+	fields := map[string][]*fieldEntity.Field{
+		tasks.ID().String(): {
+			title,
+			newSpecField(t, "fld_status", "Status", fieldValueobject.TypeSingleSelect, 2, status),
+			newSpecField(t, "fld_points", "Points", fieldValueobject.TypeNumber, 3, nil),
+			newSpecField(t, "fld_project", "Project", fieldValueobject.TypeLink, 4, projectLink),
+			newSpecField(t, "fld_total", "合计", fieldValueobject.TypeFormula, 5, nil),
+		},
+		projects.ID().String(): {
+			newSpecField(t, "fld_name", "名称", fieldValueobject.TypeSingleLineText, 1, nil),
+			newSpecField(t, "fld_tasks", "Tasks", fieldValueobject.TypeLink, 2, tasksLink),
+		},
+	}
+
+	b := newGraphQLSchemaBuilder(&GraphQLService{}, "bse_test")
+	schema, err := b.build([]*tableEntity.Table{tasks, projects}, fields)
+	require.NoError(t, err)
+	return &graphqlTestTables{tasks: b.byID[tasks.ID().String()], projects: b.byID[projects.ID().String()]}, schema
+}
+
+type graphqlTestTables struct {
+	tasks    *gqlTable
+	projects *gqlTable
+}
+
+func TestGraphQLSchema_Build(t *testing.T) {
+	tables, compiled := buildGraphQLTestSchema(t)
+	schema := &compiled.schema
+	sdl := printGraphQLSchema(schema)
+
+	// 无法生成 ASCII 名称的表和字段使用 ID
+	assert.Equal(t, "Tasks", tables.tasks.typeName)
+	assert.Equal(t, pascalName(tables.projects.id), tables.projects.typeName)
+	assert.Equal(t, "taskTitle", tables.tasks.fields[0].name)
+	assert.Equal(t, "fldTotal", tables.tasks.fields[4].name)
+
+	// 选择字段为枚举，关联字段为嵌套对象，多对多关联为列表
+	assert.Contains(t, sdl, "enum TasksStatus {")
+	assert.Contains(t, sdl, "IN_PROGRESS")
+	assert.Contains(t, sdl, "C2")
+	assert.Contains(t, sdl, "project: "+tables.projects.typeName+"\n")
+	assert.Contains(t, sdl, "tasks: [Tasks!]!")
+	assert.Contains(t, sdl, "fldTotal: JSON")
+
+	// 必填字段在创建输入中为非空，更新输入中全部可选
+	assert.Contains(t, sdl, "taskTitle: String!")
+	input := schema.Type("TasksInput").(*graphql.InputObject)
+	patch := schema.Type("TasksPatch").(*graphql.InputObject)
+	assert.NotContains(t, inputFieldNames(input), "fldTotal", "计算字段不可写")
+	assert.Equal(t, inputFieldNames(input), inputFieldNames(patch))
+
+	// 根字段
+	query := schema.QueryType()
+	for _, name := range []string{"tables", "tasksRecords", "tasks"} {
+		assert.Contains(t, query.Fields(), name)
+	}
+	for _, name := range []string{"createTasks", "updateTasks", "deleteTasks"} {
+		assert.Contains(t, schema.MutationType().Fields(), name)
+	}
+}
+
+func TestGraphQLRecordQuery(t *testing.T) {
+	tables, _ := buildGraphQLTestSchema(t)
+
+	query, err := graphQLRecordQuery(tables.tasks, map[string]interface{}{
+		"first": 10,
+		"after": encodeRecordCursor(20),
+		"filter": map[string]interface{}{
+			"conjunction": "or",
+			"conditions": []interface{}{
+				map[string]interface{}{"field": "fld_points", "operator": "isGreater", "value": 3},
+			},
+		},
+		"sort": []interface{}{map[string]interface{}{"field": "fld_points", "direction": "desc"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, query.Limit)
+	assert.Equal(t, 20, query.Offset)
+	require.Len(t, query.Filters, 1)
+	assert.Equal(t, "or", string(query.Filters[0].Operator))
+	assert.Equal(t, tables.tasks.fields[2].column, query.OrderBy)
+	assert.Equal(t, "desc", query.OrderDir)
+
+	// 默认按自增序号排序
+	query, err = graphQLRecordQuery(tables.tasks, map[string]interface{}{"first": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "__auto_number", query.OrderBy)
+	assert.Empty(t, query.Filters)
+
+	_, err = graphQLRecordQuery(tables.tasks, map[string]interface{}{"first": graphQLMaxPageSize + 1})
+	assert.Error(t, err)
+	_, err = graphQLRecordQuery(tables.tasks, map[string]interface{}{"first": 1, "after": "bad"})
+	assert.Error(t, err)
+}
+
+func TestGraphQLRecordData(t *testing.T) {
+	tables, _ := buildGraphQLTestSchema(t)
+
+	data := graphQLRecordData(tables.tasks, map[string]interface{}{
+		"taskTitle": "写文档",
+		"project":   "rec_1",
+		"unknown":   1,
+	})
+	assert.Equal(t, map[string]interface{}{
+		"fld_title":   "写文档",
+		"fld_project": map[string]interface{}{"id": "rec_1"},
+	}, data)
+
+	data = graphQLRecordData(tables.projects, map[string]interface{}{"tasks": []interface{}{"rec_1", "rec_2"}})
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "rec_1"}, map[string]interface{}{"id": "rec_2"}}, data["fld_tasks"])
+}
+
+func TestGraphQLCellValue(t *testing.T) {
+	tables, _ := buildGraphQLTestSchema(t)
+	points := tables.tasks.byName["points"]
+	status := tables.tasks.byName["status"]
+
+	assert.Equal(t, 3.5, graphQLCellValue(points, "3.5"))
+	assert.Nil(t, graphQLCellValue(points, "abc"))
+	assert.Equal(t, "完成", graphQLCellValue(status, map[string]interface{}{"id": "c2", "name": "完成"}))
+	assert.Nil(t, graphQLCellValue(status, "已删除的选项"), "不在枚举中的选项视为空")
+}
+
+func TestGraphQLNames(t *testing.T) {
+	assert.Equal(t, "OrderItems", pascalName("order items"))
+	assert.Equal(t, "", pascalName("2024 计划"))
+	assert.Equal(t, "urlLink", camelName("URL link"))
+	assert.Equal(t, "HAS_ANY_OF", screamingName("hasAnyOf"))
+	assert.Equal(t, "IS_GREATER_EQUAL", screamingName("isGreaterEqual"))
+}
+
+func TestGraphQLSchema_Execute(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	tables, schema := buildGraphQLTestSchema(t)
+	ctx := context.Background()
+
+	result := schema.execute(ctx, dto.GraphQLRequest{Query: "{ tables { typeName } }"})
+	require.Empty(t, result.Errors)
+	assert.Equal(t, map[string]interface{}{"tables": []interface{}{
+		map[string]interface{}{"typeName": "Tasks"},
+		map[string]interface{}{"typeName": tables.projects.typeName},
+	}}, result.Data)
+	assert.Equal(t, 2, result.Extensions["complexity"])
+
+	// 校验错误原样返回
+	result = schema.execute(ctx, dto.GraphQLRequest{Query: "{ unknown }"})
+	require.Len(t, result.Errors, 1)
+	assert.Nil(t, result.Data)
+
+	// GET 请求只能执行查询
+	result = schema.execute(ctx, dto.GraphQLRequest{Query: `mutation { deleteTasks(id: "rec_1") }`, ReadOnly: true})
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "Can only perform a mutation operation")
+}
+
+func TestGraphQLSchema_CostLimits(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	_, schema := buildGraphQLTestSchema(t)
+	ctx := context.Background()
+
+	// 分页字段按 first 放大子选择的复杂度
+	var aliases strings.Builder
+	for i := 0; i < 30; i++ {
+		fmt.Fprintf(&aliases, "p%d: project { id } ", i)
+	}
+	result := schema.execute(ctx, dto.GraphQLRequest{
+		Query:     "query($n: Int) { tasksRecords(first: $n) { edges { node { " + aliases.String() + "} } } }",
+		Variables: map[string]interface{}{"n": graphQLMaxPageSize},
+	})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "QUERY_TOO_COMPLEX", result.Errors[0].Extensions["code"])
+
+	// 片段展开后的嵌套同样计入深度
+	nested := "id"
+	for i := 0; i < graphQLMaxDepth/2; i++ {
+		nested = "project { tasks { " + nested + " } }"
+	}
+	result = schema.execute(ctx, dto.GraphQLRequest{
+		Query: "fragment P on Tasks { " + nested + " } { tasks(id: \"rec_1\") { ...P } }",
+	})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "QUERY_TOO_DEEP", result.Errors[0].Extensions["code"])
+
+	// 被 @skip 排除的选择不计入
+	result = schema.execute(ctx, dto.GraphQLRequest{
+		Query:     "query($s: Boolean!) { tasksRecords(first: 100) { edges { node { id ... @skip(if: $s) { " + aliases.String() + "} } } } }",
+		Variables: map[string]interface{}{"s": true},
+	})
+	for _, err := range result.Errors {
+		assert.NotEqual(t, "QUERY_TOO_COMPLEX", err.Extensions["code"])
+	}
+}
+func TestFormatGraphQLError(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	formatted := formatGraphQLError(gqlerrors.FormatError(pkgerrors.ErrForbidden.WithDetails("无权访问")))
+	assert.Equal(t, pkgerrors.ErrForbidden.Message+": 无权访问", formatted.Message)
+	assert.Equal(t, pkgerrors.ErrForbidden.Code, formatted.Extensions["code"])
+
+	// 非应用错误不暴露原始信息
+	formatted = formatGraphQLError(gqlerrors.FormatError(assert.AnError))
+	assert.Equal(t, pkgerrors.ErrInternalServer.Message, formatted.Message)
+	assert.Equal(t, pkgerrors.ErrInternalServer.Code, formatted.Extensions["code"])
+
+	// GraphQL 自身的错误原样返回
+	formatted = formatGraphQLError(gqlerrors.FormatError(gqlerrors.NewError("Syntax Error", nil, "", nil, nil, nil)))
+	assert.Equal(t, "Syntax Error", formatted.Message)
+	assert.Nil(t, formatted.Extensions)
+}
+
+func inputFieldNames(input *graphql.InputObject) []string {
+	names := make([]string, 0, len(input.Fields()))
+	for name := range input.Fields() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
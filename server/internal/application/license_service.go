@@ -118,6 +118,28 @@ func (s *LicenseService) Deactivate(ctx context.Context, licenseKey string) erro
 		Update("status", "inactive").Error
 }
 
+// GetActiveLicenseForUser 获取用户当前生效的许可证，没有时返回 nil ✨
+// 用户有多个生效的许可证时取最近开始的一个
+func (s *LicenseService) GetActiveLicenseForUser(ctx context.Context, userID string) (*models.License, error) {
+	var license models.License
+	err := s.db.WithContext(ctx).
+		Table("license").
+		Select("license.*").
+		Joins("JOIN license_customer ON license_customer.license_id = license.id").
+		Where("license_customer.user_id = ? AND license_customer.status = ? AND license_customer.deleted_time IS NULL", userID, "active").
+		Where("license_customer.end_date IS NULL OR license_customer.end_date > ?", time.Now()).
+		Where("license.status = ? AND license.deleted_time IS NULL", "active").
+		Order("license_customer.start_date DESC").
+		First(&license).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &license, nil
+}
+
 // GetUsage 获取许可证使用情况
 func (s *LicenseService) GetUsage(ctx context.Context, licenseID string) (map[string]interface{}, error) {
 	var license models.License
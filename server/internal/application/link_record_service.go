@@ -0,0 +1,284 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	viewDomain "github.com/easyspace-ai/luckdb/server/internal/domain/view"
+	viewRepo "github.com/easyspace-ai/luckdb/server/internal/domain/view/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// LinkRecordService Link 字段关联记录服务 ✨
+//
+// 设计考量：
+//   - 约束来自 Link 字段选项：filterByViewId 对应视图的过滤条件 + filter 过滤条件
+//   - 选择器只返回满足约束的候选记录，只暴露 visibleFieldIds 中的字段
+//   - 写入关联时按同样的约束在服务端校验，约束均下推为 SQL 条件，与选择器结果一致
+type LinkRecordService struct {
+	fieldRepo     repository.FieldRepository
+	recordRepo    recordRepo.RecordRepository
+	viewRepo      viewRepo.ViewRepository
+	permission    *PermissionServiceV2  // ✨ 关联表访问权限（可能在其他 Base）
+	rowPermission *RowPermissionService // ✨ 关联表行级权限
+}
+
+// NewLinkRecordService 创建 Link 字段关联记录服务
+func NewLinkRecordService(
+	fieldRepo repository.FieldRepository,
+	recordRepo recordRepo.RecordRepository,
+	viewRepo viewRepo.ViewRepository,
+) *LinkRecordService {
+	return &LinkRecordService{
+		fieldRepo:  fieldRepo,
+		recordRepo: recordRepo,
+		viewRepo:   viewRepo,
+	}
+}
+
+// SetPermissionService 设置权限服务（用于延迟注入）
+func (s *LinkRecordService) SetPermissionService(permission *PermissionServiceV2) {
+	s.permission = permission
+}
+
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (s *LinkRecordService) SetRowPermissionService(rowPermission *RowPermissionService) {
+	s.rowPermission = rowPermission
+}
+
+// ListCandidates 查询 Link 字段可关联的记录
+// 按关联表标题字段搜索，只返回满足字段约束且当前用户可见的记录
+func (s *LinkRecordService) ListCandidates(ctx context.Context, fieldID string, query dto.LinkCandidateQuery) (*dto.LinkCandidateListResponse, error) {
+	// 1. 获取 Link 字段
+	field, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(fieldID))
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+	}
+	if field == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("字段不存在")
+	}
+	if !hasLinkTarget(field) {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"message":  "只有 Link 字段支持选择关联记录",
+			"field_id": fieldID,
+		})
+	}
+	link := field.Options().Link
+	linkedTableID := link.LinkedTableID
+
+	// 2. 关联表访问权限
+	userID := currentUserID(ctx)
+	if s.permission != nil && userID != "" && !s.permission.CanAccessTable(ctx, userID, linkedTableID) {
+		return nil, pkgerrors.ErrForbidden.WithDetails(map[string]interface{}{
+			"message":       "没有关联表的访问权限",
+			"linkedTableId": linkedTableID,
+		})
+	}
+
+	// 3. 字段约束 + 标题字段搜索
+	filters, err := s.constraintFilters(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	foreignFields, err := s.fieldRepo.FindByTableID(ctx, linkedTableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取关联表字段失败: %v", err))
+	}
+	titleFieldID := linkTitleFieldID(link, foreignFields)
+	if search := viewDomain.LinkSearchFilter(titleFieldID, query.Search); search != nil {
+		filters = append(filters, search)
+	}
+
+	offset, limit := viewDomain.NormalizeLinkCandidatePage(query.Offset, query.Limit)
+	result := &dto.LinkCandidateListResponse{
+		TableID:      linkedTableID,
+		TitleFieldID: titleFieldID,
+		Records:      []*dto.LinkCandidateResponse{},
+		Offset:       offset,
+		Limit:        limit,
+	}
+
+	filter := recordRepo.RecordFilter{
+		TableID:  &linkedTableID,
+		Filters:  filters,
+		OrderBy:  "__auto_number",
+		OrderDir: "asc",
+		Offset:   offset,
+		Limit:    limit,
+	}
+
+	// 4. ✨ 行级权限：只返回关联表中当前用户可见的记录
+	if s.rowPermission != nil && userID != "" {
+		access, err := s.rowPermission.ResolveAccess(ctx, linkedTableID, userID, permission.ActionRecordRead)
+		if err != nil {
+			return nil, err
+		}
+		if !access.Allowed {
+			return result, nil
+		}
+		filter.AccessScopes = access.Scopes
+	}
+
+	records, total, err := s.recordRepo.List(ctx, filter)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询候选记录失败: %v", err))
+	}
+
+	result.Total = total
+	for _, record := range records {
+		values := record.Data().ToMap()
+		result.Records = append(result.Records, &dto.LinkCandidateResponse{
+			ID:     record.ID().String(),
+			Title:  values[titleFieldID],
+			Fields: viewDomain.LinkCandidateFields(values, link.VisibleFieldIDs, titleFieldID),
+		})
+	}
+
+	return result, nil
+}
+
+// ValidateLinks 校验记录数据中的 Link 值是否满足字段约束
+// data 以字段ID为键，只校验其中出现的 Link 字段；关联的记录不存在或不满足约束时返回错误
+func (s *LinkRecordService) ValidateLinks(ctx context.Context, tableID string, data map[string]interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取字段列表失败: %v", err))
+	}
+
+	for _, field := range fields {
+		value, ok := data[field.ID().String()]
+		if !ok || !hasLinkTarget(field) {
+			continue
+		}
+		recordIDs := fieldValueobject.ExtractLinkRecordIDs(value)
+		if len(recordIDs) == 0 {
+			continue
+		}
+
+		link := field.Options().Link
+		filters, err := s.constraintFilters(ctx, link)
+		if err != nil {
+			return err
+		}
+		if len(filters) == 0 {
+			continue
+		}
+
+		linkedTableID := link.LinkedTableID
+		records, _, err := s.recordRepo.List(ctx, recordRepo.RecordFilter{
+			TableID: &linkedTableID,
+			Filters: append(filters, viewDomain.LinkRecordIDFilter(recordIDs)),
+			Limit:   len(recordIDs),
+		})
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("校验关联记录失败: %v", err))
+		}
+
+		matched := make(map[string]bool, len(records))
+		for _, record := range records {
+			matched[record.ID().String()] = true
+		}
+		rejected := make([]string, 0)
+		for _, id := range recordIDs {
+			if !matched[id] {
+				rejected = append(rejected, id)
+			}
+		}
+		if len(rejected) > 0 {
+			return pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"message":    "关联的记录不满足字段的过滤条件",
+				"field_id":   field.ID().String(),
+				"field_name": field.Name().String(),
+				"record_ids": rejected,
+			})
+		}
+	}
+
+	return nil
+}
+
+// constraintFilters Link 字段的约束条件：视图过滤 + 字段过滤
+func (s *LinkRecordService) constraintFilters(ctx context.Context, link *fieldValueobject.LinkOptions) ([]*valueobject.Filter, error) {
+	filters := make([]*valueobject.Filter, 0, 3)
+
+	if link.FilterByViewID != nil && *link.FilterByViewID != "" && s.viewRepo != nil {
+		view, err := s.viewRepo.FindByID(ctx, *link.FilterByViewID)
+		if err != nil {
+			return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找视图失败: %v", err))
+		}
+		switch {
+		case view == nil:
+			// 视图已删除时约束随之失效
+			logger.Warn("Link 字段限定的视图不存在，忽略视图过滤",
+				logger.String("view_id", *link.FilterByViewID))
+		case view.TableID() != link.LinkedTableID:
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+				"message":        "限定的视图不属于关联表",
+				"filterByViewId": *link.FilterByViewID,
+			})
+		case !view.Filter().IsEmpty():
+			filters = append(filters, view.Filter())
+		}
+	}
+
+	linkFilter, err := linkConditionFilter(link.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if linkFilter != nil {
+		filters = append(filters, linkFilter)
+	}
+
+	return filters, nil
+}
+
+// linkConditionFilter 将 Link 字段的过滤条件转换为视图过滤器
+func linkConditionFilter(options *fieldValueobject.FilterOptions) (*valueobject.Filter, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	items := make([]valueobject.FilterItem, 0, len(options.Conditions))
+	for _, condition := range options.Conditions {
+		items = append(items, valueobject.FilterItem{
+			FieldID:  condition.FieldID,
+			Operator: valueobject.FilterItemOperator(condition.Operator),
+			Value:    condition.Value,
+		})
+	}
+
+	filter, err := viewDomain.LinkFilter(options.Conjunction, items)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(map[string]interface{}{
+			"field": "filter",
+			"error": err.Error(),
+		})
+	}
+	return filter, nil
+}
+
+// linkTitleFieldID 关联记录的标题字段：Link 字段指定的显示字段 > 主字段 > 第一个字段
+func linkTitleFieldID(link *fieldValueobject.LinkOptions, foreignFields []*fieldEntity.Field) string {
+	if link.LookupFieldID != "" {
+		return link.LookupFieldID
+	}
+	if primary := primaryFieldID(foreignFields); primary != "" {
+		return primary
+	}
+	if len(foreignFields) > 0 {
+		return foreignFields[0].ID().String()
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+	"github.com/easyspace-ai/luckdb/server/pkg/authctx"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+// LiveQueryService WebSocket 视图实时查询执行器 ✨
+//
+// 每次执行（订阅和记录变更后的刷新）都按订阅方当前的权限校验：
+//   - 访问控制与视图频道订阅相同，分享链接的访客只能查询被分享的视图
+//   - 登录用户按行级权限限定结果范围
+//
+// 查询条件为视图过滤条件叠加客户端过滤条件，排序为客户端排序或视图排序的全部排序项，最后按 __auto_number
+type LiveQueryService struct {
+	viewService *ViewService
+	authorizer  websocket.SubscriptionAuthorizer
+}
+
+// NewLiveQueryService 创建实时查询执行器
+func NewLiveQueryService(viewService *ViewService, authorizer websocket.SubscriptionAuthorizer) *LiveQueryService {
+	return &LiveQueryService{
+		viewService: viewService,
+		authorizer:  authorizer,
+	}
+}
+
+// ExecuteLiveQuery 执行视图查询，返回分页窗口内的记录
+func (s *LiveQueryService) ExecuteLiveQuery(ctx context.Context, subscriber *websocket.Subscriber, spec *websocket.LiveQuerySpec) (*websocket.LiveQueryResult, error) {
+	// 1. 访问控制
+	scope := &websocket.ChannelScope{Channel: "view." + spec.ViewID, Type: websocket.ScopeView, ViewID: spec.ViewID}
+	if err := s.authorizer.AuthorizeSubscription(ctx, subscriber, scope); err != nil {
+		return nil, err
+	}
+
+	view, err := s.viewService.viewRepo.FindByID(ctx, spec.ViewID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找视图失败: %v", err))
+	}
+	if view == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("视图不存在")
+	}
+	tableID := view.TableID()
+
+	// 2. 客户端过滤与排序
+	filters := []*valueobject.Filter{view.Filter()}
+	if spec.Filter != nil {
+		filter, err := valueobject.NewFilter(spec.Filter)
+		if err != nil {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("过滤器无效: %v", err))
+		}
+		filters = append(filters, filter)
+	}
+	sort := view.Sort()
+	if len(spec.Sort) > 0 {
+		if sort, err = valueobject.NewSort(spec.Sort); err != nil {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("排序无效: %v", err))
+		}
+	}
+
+	// 3. ✨ 行级权限（分享访客没有用户上下文，按分享视图读取）
+	if subscriber.UserID != "" {
+		ctx = authctx.WithUser(ctx, subscriber.UserID)
+	}
+	result := &websocket.LiveQueryResult{TableID: tableID, Records: []websocket.LiveRecord{}}
+	scopes, allowed, err := s.viewService.readScopes(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return result, nil
+	}
+
+	// 4. 查询分页窗口
+	filter := recordRepo.RecordFilter{
+		TableID:      &tableID,
+		Filters:      filters,
+		AccessScopes: scopes,
+		OrderBy:      "__auto_number",
+		OrderDir:     "asc",
+		Limit:        spec.Limit,
+		Offset:       spec.Offset,
+	}
+	if sort != nil && len(sort.SortItems) > 0 {
+		fields, err := s.viewService.tableFields(ctx, tableID)
+		if err != nil {
+			return nil, err
+		}
+		// 依次应用全部排序项，排序值都相同时由仓储按 __auto_number 兜底
+		var orders []recordRepo.RecordOrder
+		for _, item := range sort.SortItems {
+			if column := dbColumnOf(fields, item.FieldID); column != "" {
+				orders = append(orders, recordRepo.RecordOrder{Column: column, Dir: string(item.Order)})
+			}
+		}
+		if len(orders) > 0 {
+			filter.OrderBy = orders[0].Column
+			filter.OrderDir = orders[0].Dir
+			filter.ThenOrderBy = orders[1:]
+		}
+	}
+
+	if result.DependsOn, err = s.dependsOn(ctx, tableID, filters, scopes, sort); err != nil {
+		return nil, err
+	}
+
+	records, total, err := s.viewService.recordRepo.List(ctx, filter)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询视图记录失败: %v", err))
+	}
+	if records, err = s.viewService.foreignFields.MaskRecords(ctx, tableID, records); err != nil {
+		return nil, err
+	}
+
+	result.Total = total
+	for _, record := range records {
+		result.Records = append(result.Records, websocket.LiveRecord{
+			ID:      record.ID().String(),
+			Version: record.Version().Value(),
+			Fields:  record.Data().ToMap(),
+		})
+	}
+	return result, nil
+}
+
+// dependsOn 过滤、排序和行级权限范围引用的字段
+// 引用最后修改时间/修改人字段时返回 nil：这些值随任意字段的更新变化，总是刷新
+func (s *LiveQueryService) dependsOn(ctx context.Context, tableID string, filters []*valueobject.Filter, scopes [][]*valueobject.Filter, sort *valueobject.Sort) ([]string, error) {
+	seen := make(map[string]bool)
+	dependsOn := make([]string, 0)
+	add := func(fieldIDs ...string) {
+		for _, fieldID := range fieldIDs {
+			if !seen[fieldID] {
+				seen[fieldID] = true
+				dependsOn = append(dependsOn, fieldID)
+			}
+		}
+	}
+	for _, filter := range filters {
+		add(filter.GetFieldIDs()...)
+	}
+	for _, scope := range scopes {
+		for _, filter := range scope {
+			add(filter.GetFieldIDs()...)
+		}
+	}
+	if sort != nil {
+		for _, item := range sort.SortItems {
+			add(item.FieldID)
+		}
+	}
+	if len(dependsOn) == 0 {
+		return dependsOn, nil
+	}
+
+	fields, err := s.viewService.tableFields(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if !seen[field.ID().String()] {
+			continue
+		}
+		switch field.Type().String() {
+		case fieldValueobject.TypeLastModifiedTime, fieldValueobject.TypeLastModifiedBy:
+			return nil, nil
+		}
+	}
+	return dependsOn, nil
+}
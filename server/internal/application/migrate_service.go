@@ -392,6 +392,17 @@ func (s *MigrateService) runAutoMigrate(db *gorm.DB) error {
 		&models.Permission{},
 		&models.Attachment{},
 		&models.Collaborator{},
+		&models.TableRowRule{},       // ✨ 行级访问规则
+		&models.ViewRecordOrder{},    // ✨ 视图内记录手动顺序
+		&models.AIUsage{},            // ✨ AI 字段 token 用量
+		&models.SpaceStorageUsage{},  // ✨ 空间附件存储用量
+		&models.UploadSession{},      // ✨ 附件断点续传会话
+		&models.AttachmentBlob{},     // ✨ 附件内容去重存储
+		&models.RecordReminderRule{}, // ✨ 记录提醒规则
+		&models.RecordReminder{},     // ✨ 记录提醒计划
+		&models.AlertRule{},          // ✨ 告警规则
+		&models.AlertHistory{},       // ✨ 告警历史
+		&models.AlertSilence{},       // ✨ 告警静默窗口
 		// &models.Invitation{},        // TODO: Invitation模型待实现
 		// &models.InvitationRecord{},  // TODO: InvitationRecord模型待实现
 		&models.RecordChange{},
@@ -425,7 +436,7 @@ func (s *MigrateService) runAutoMigrate(db *gorm.DB) error {
 		&models.PinResource{},
 		&models.Setting{},
 		&models.Waitlist{},
-		&models.Attachments{},
+		// &models.Attachments{}, // 与 models.Attachment 共用 attachments 表，其 NOT NULL 的 hash 列会阻止附件写入
 		&models.AttachmentsTable{},
 
 		// 组织架构
@@ -448,6 +459,7 @@ func (s *MigrateService) runAutoMigrate(db *gorm.DB) error {
 		&models.NotificationTemplate{},
 		&models.NotificationSubscription{},
 		&models.NotificationDelivery{},
+		&models.NotificationPreference{}, // ✨ 用户通知偏好
 
 		// 审计日志
 		&models.AuditLog{},
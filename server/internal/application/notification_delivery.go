@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+	userRepo "github.com/easyspace-ai/luckdb/server/internal/domain/user/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/user/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+)
+
+// InAppNotificationDispatcher 应用内通知：通过 WebSocket 推送到用户的所有连接 ✨
+// 通知本身已保存在通知列表中，离线用户上线后从列表读取
+type InAppNotificationDispatcher struct {
+	wsService websocket.Service
+}
+
+// NewInAppNotificationDispatcher 创建应用内通知投递器
+func NewInAppNotificationDispatcher(wsService websocket.Service) *InAppNotificationDispatcher {
+	return &InAppNotificationDispatcher{wsService: wsService}
+}
+
+// Channel 负责的渠道
+func (d *InAppNotificationDispatcher) Channel() string {
+	return notification.ChannelInApp
+}
+
+// Dispatch 推送通知到用户频道
+func (d *InAppNotificationDispatcher) Dispatch(ctx context.Context, envelope *notification.Envelope) error {
+	if d.wsService == nil || envelope.Notification == nil {
+		return nil
+	}
+	message := websocket.NewMessage(websocket.MessageTypeNotification, envelope.Notification)
+	return d.wsService.BroadcastToUser(envelope.Recipient.UserID, message)
+}
+
+// NotificationRecipientResolver 从用户仓储查询通知接收人的姓名和邮箱 ✨
+type NotificationRecipientResolver struct {
+	userRepo userRepo.UserRepository
+}
+
+// NewNotificationRecipientResolver 创建接收人解析器
+func NewNotificationRecipientResolver(userRepo userRepo.UserRepository) *NotificationRecipientResolver {
+	return &NotificationRecipientResolver{userRepo: userRepo}
+}
+
+// ResolveRecipient 查询接收人，已停用或删除的用户返回错误
+func (r *NotificationRecipientResolver) ResolveRecipient(ctx context.Context, userID string) (*notification.Recipient, error) {
+	user, err := r.userRepo.FindByID(ctx, valueobject.NewUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsDeleted() {
+		return nil, fmt.Errorf("user %s not found", userID)
+	}
+	if !user.IsActive() {
+		return nil, fmt.Errorf("user %s is not active", userID)
+	}
+	return &notification.Recipient{
+		UserID: userID,
+		Name:   user.Name(),
+		Email:  user.Email().String(),
+	}, nil
+}
+
+var (
+	_ notification.Dispatcher        = (*InAppNotificationDispatcher)(nil)
+	_ notification.RecipientResolver = (*NotificationRecipientResolver)(nil)
+)
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/easyspace-ai/luckdb/server/internal/application/permission"
+	baseEntity "github.com/easyspace-ai/luckdb/server/internal/domain/base/entity"
 	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/repository"
@@ -139,7 +140,7 @@ func (s *PermissionServiceV2) CanCreateTablesInBase(ctx context.Context, userID,
 func (s *PermissionServiceV2) CanAccessTable(ctx context.Context, userID, tableID string) bool {
 	// 1. 获取Table所属的Base
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -150,7 +151,7 @@ func (s *PermissionServiceV2) CanAccessTable(ctx context.Context, userID, tableI
 // CanManageTableSchema 检查用户是否可以管理Table结构（字段、视图）
 func (s *PermissionServiceV2) CanManageTableSchema(ctx context.Context, userID, tableID string) bool {
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -160,7 +161,7 @@ func (s *PermissionServiceV2) CanManageTableSchema(ctx context.Context, userID,
 // CanDeleteTable 检查用户是否可以删除Table
 func (s *PermissionServiceV2) CanDeleteTable(ctx context.Context, userID, tableID string) bool {
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -172,7 +173,7 @@ func (s *PermissionServiceV2) CanDeleteTable(ctx context.Context, userID, tableI
 // CanAccessRecord 检查用户是否可以访问Record
 func (s *PermissionServiceV2) CanAccessRecord(ctx context.Context, userID, tableID string) bool {
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -182,7 +183,7 @@ func (s *PermissionServiceV2) CanAccessRecord(ctx context.Context, userID, table
 // CanCreateRecordsInTable 检查用户是否可以在Table中创建Record
 func (s *PermissionServiceV2) CanCreateRecordsInTable(ctx context.Context, userID, tableID string) bool {
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -192,7 +193,7 @@ func (s *PermissionServiceV2) CanCreateRecordsInTable(ctx context.Context, userI
 // CanUpdateRecordsInTable 检查用户是否可以更新Record
 func (s *PermissionServiceV2) CanUpdateRecordsInTable(ctx context.Context, userID, tableID string) bool {
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -202,7 +203,7 @@ func (s *PermissionServiceV2) CanUpdateRecordsInTable(ctx context.Context, userI
 // CanDeleteRecordsInTable 检查用户是否可以删除Record
 func (s *PermissionServiceV2) CanDeleteRecordsInTable(ctx context.Context, userID, tableID string) bool {
 	table, err := s.tableRepo.GetByID(ctx, tableID)
-	if err != nil {
+	if err != nil || table == nil {
 		return false
 	}
 
@@ -219,6 +220,101 @@ func (s *PermissionServiceV2) FilterAccessibleRecords(ctx context.Context, userI
 	return []string{}
 }
 
+// ==================== Base 角色解析 ====================
+
+// collaboratorRoleLevels 协作者角色等级，用户与所属部门有多个协作关系时取最高角色
+var collaboratorRoleLevels = map[entity.RoleName]int{
+	entity.RoleViewer:    1,
+	entity.RoleCommenter: 1,
+	entity.RoleEditor:    2,
+	entity.RoleCreator:   3,
+	entity.RoleOwner:     4,
+}
+
+// BaseRole 解析用户（含所属部门）在 Base 上的有效角色，无角色时返回空字符串
+// 优先级：Base 协作者 > Space 协作者 > Base 创建者（视为所有者）
+func (s *PermissionServiceV2) BaseRole(ctx context.Context, base *baseEntity.Base, userID string, groupIDs []string) entity.RoleName {
+	principals := append([]string{userID}, groupIDs...)
+	for _, resourceID := range []string{base.ID, base.SpaceID} {
+		roles := make(map[string]entity.RoleName, len(principals))
+		for _, principalID := range principals {
+			collaborator, err := s.collaboratorRepo.FindByResourceAndPrincipal(ctx, resourceID, principalID)
+			if err == nil && collaborator != nil {
+				roles[principalID] = collaborator.Role()
+			}
+		}
+		if role := highestRole(roles, principals); role != "" {
+			return role
+		}
+	}
+	if base.IsCreatedBy(userID) {
+		return entity.RoleOwner
+	}
+	return ""
+}
+
+// BaseRoleResolver 批量解析多个用户在同一 Base 上的角色，规则与 BaseRole 一致
+// 用于实时推送等需要逐个判断接收者的场景，避免每个接收者都查询协作者
+type BaseRoleResolver struct {
+	base       *baseEntity.Base
+	baseRoles  map[string]entity.RoleName
+	spaceRoles map[string]entity.RoleName
+}
+
+// NewBaseRoleResolver 一次加载 Base 与所属空间的全部协作者
+func (s *PermissionServiceV2) NewBaseRoleResolver(ctx context.Context, base *baseEntity.Base) (*BaseRoleResolver, error) {
+	baseRoles, err := s.resourceRoles(ctx, base.ID, entity.ResourceTypeBase)
+	if err != nil {
+		return nil, err
+	}
+	spaceRoles, err := s.resourceRoles(ctx, base.SpaceID, entity.ResourceTypeSpace)
+	if err != nil {
+		return nil, err
+	}
+	return &BaseRoleResolver{base: base, baseRoles: baseRoles, spaceRoles: spaceRoles}, nil
+}
+
+// Role 用户（含所属部门）在 Base 上的有效角色，无角色时返回空字符串
+func (r *BaseRoleResolver) Role(userID string, groupIDs []string) entity.RoleName {
+	principals := append([]string{userID}, groupIDs...)
+	if role := highestRole(r.baseRoles, principals); role != "" {
+		return role
+	}
+	if role := highestRole(r.spaceRoles, principals); role != "" {
+		return role
+	}
+	if r.base.IsCreatedBy(userID) {
+		return entity.RoleOwner
+	}
+	return ""
+}
+
+// resourceRoles 资源上各主体的角色
+func (s *PermissionServiceV2) resourceRoles(ctx context.Context, resourceID string, resourceType entity.ResourceType) (map[string]entity.RoleName, error) {
+	collaborators, err := s.collaboratorRepo.ListByResource(ctx, resourceID, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string]entity.RoleName, len(collaborators))
+	for _, collaborator := range collaborators {
+		if collaboratorRoleLevels[collaborator.Role()] > collaboratorRoleLevels[roles[collaborator.PrincipalID()]] {
+			roles[collaborator.PrincipalID()] = collaborator.Role()
+		}
+	}
+	return roles, nil
+}
+
+// highestRole 主体中最高的角色
+func highestRole(roles map[string]entity.RoleName, principals []string) entity.RoleName {
+	var best entity.RoleName
+	for _, principalID := range principals {
+		if role := roles[principalID]; collaboratorRoleLevels[role] > collaboratorRoleLevels[best] {
+			best = role
+		}
+	}
+	return best
+}
+
 // ==================== 辅助方法 ====================
 
 // GetUserRole 获取用户在资源上的角色
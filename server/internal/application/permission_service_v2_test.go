@@ -0,0 +1,33 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	baseEntity "github.com/easyspace-ai/luckdb/server/internal/domain/base/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/entity"
+)
+
+func TestBaseRoleResolver(t *testing.T) {
+	base := &baseEntity.Base{ID: "bse_1", SpaceID: "spc_1", CreatedBy: "usr_creator"}
+	resolver := &BaseRoleResolver{
+		base: base,
+		baseRoles: map[string]entity.RoleName{
+			"usr_viewer": entity.RoleViewer,
+			"dep_sales":  entity.RoleEditor,
+		},
+		spaceRoles: map[string]entity.RoleName{
+			"usr_viewer":      entity.RoleOwner,
+			"usr_space_owner": entity.RoleOwner,
+		},
+	}
+
+	// Base 协作者优先于 Space 协作者
+	assert.Equal(t, entity.RoleViewer, resolver.Role("usr_viewer", nil))
+	// 用户与所属部门取最高角色
+	assert.Equal(t, entity.RoleEditor, resolver.Role("usr_viewer", []string{"dep_sales"}))
+	assert.Equal(t, entity.RoleOwner, resolver.Role("usr_space_owner", nil))
+	assert.Equal(t, entity.RoleOwner, resolver.Role("usr_creator", nil))
+	assert.Empty(t, resolver.Role("usr_stranger", []string{"dep_other"}))
+}
@@ -0,0 +1,448 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
+	fieldRepo "github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	recordValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	spaceRepo "github.com/easyspace-ai/luckdb/server/internal/domain/space/repository"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	viewRepo "github.com/easyspace-ai/luckdb/server/internal/domain/view/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/cache"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/ratelimit"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+const (
+	quotaCacheSize = 10000
+	quotaPlanTTL   = time.Minute      // 套餐缓存，许可证变更最多延迟一分钟生效
+	quotaRecordTTL = 30 * time.Second // 空间记录数缓存，期间新建的记录累加到缓存值上
+	quotaOwnerTTL  = 10 * time.Minute // Base/表/字段/视图/记录的归属缓存（不会变化，只为限制内存）
+)
+
+// activeLicenseFinder 查找用户生效的许可证（LicenseService）
+type activeLicenseFinder interface {
+	GetActiveLicenseForUser(ctx context.Context, userID string) (*models.License, error)
+}
+
+// recordTableFinder 按记录 ID 查找所在表（旧路由 /records/:recordId 兼容）
+type recordTableFinder interface {
+	FindTableIDByRecordID(ctx context.Context, recordID recordValueobject.RecordID) (string, error)
+}
+
+// QuotaResource 请求访问的资源 ID（取自路由参数）
+// 按 空间 > Base > 表 > 字段/视图/记录 的顺序找到所属空间
+type QuotaResource struct {
+	SpaceID  string
+	BaseID   string
+	TableID  string
+	FieldID  string
+	ViewID   string
+	RecordID string
+}
+
+// SpacePlan 空间的套餐与配额
+type SpacePlan struct {
+	Plan      string // 套餐名，为空表示未限制
+	LicenseID string // 空间所有者生效的许可证
+	Quota     config.PlanQuota
+}
+
+// QuotaService 空间配额 ✨
+//
+// 套餐取自空间所有者生效的许可证（LicenseService），没有许可证时使用默认套餐：
+//   - 记录数：创建记录前校验空间内所有表的记录总数
+//   - 附件存储：许可证的 max_storage 优先，其次是套餐配置，最后是 storage.space_quota
+//   - API 调用：每个访问空间内资源的请求计数一次，按自然月（UTC）重置；
+//     计数存储与限流共用（配置 Redis 时所有实例共享）
+type QuotaService struct {
+	cfg            config.QuotaConfig
+	defaultStorage int64
+	licenses       activeLicenseFinder
+	spaceRepo      spaceRepo.SpaceRepository
+	baseRepo       baseRepo.BaseRepository
+	tableRepo      tableRepo.TableRepository
+	fieldRepo      fieldRepo.FieldRepository
+	viewRepo       viewRepo.ViewRepository
+	recordRepo     recordRepo.RecordRepository
+	usageRepo      attachment.UsageRepository
+	counter        ratelimit.Counter
+	permissions    *PermissionServiceV2
+
+	cache   *cache.LRUCache
+	records sync.Mutex // 记录数缓存的读-改-写
+	now     func() time.Time
+}
+
+// NewQuotaService 创建配额服务
+// defaultStorage 为没有套餐存储配额时的附件存储配额（storage.space_quota）
+func NewQuotaService(
+	cfg config.QuotaConfig,
+	defaultStorage int64,
+	licenses *LicenseService,
+	spaceRepo spaceRepo.SpaceRepository,
+	baseRepo baseRepo.BaseRepository,
+	tableRepo tableRepo.TableRepository,
+	fieldRepo fieldRepo.FieldRepository,
+	viewRepo viewRepo.ViewRepository,
+	recordRepo recordRepo.RecordRepository,
+	usageRepo attachment.UsageRepository,
+	counter ratelimit.Counter,
+	permissions *PermissionServiceV2,
+) *QuotaService {
+	s := &QuotaService{
+		cfg:            cfg,
+		defaultStorage: defaultStorage,
+		spaceRepo:      spaceRepo,
+		baseRepo:       baseRepo,
+		tableRepo:      tableRepo,
+		fieldRepo:      fieldRepo,
+		viewRepo:       viewRepo,
+		recordRepo:     recordRepo,
+		usageRepo:      usageRepo,
+		counter:        counter,
+		permissions:    permissions,
+		cache:          cache.NewLRUCache(quotaCacheSize, nil),
+		now:            time.Now,
+	}
+	if licenses != nil {
+		s.licenses = licenses
+	}
+	return s
+}
+
+// Plan 空间的套餐与配额
+func (s *QuotaService) Plan(ctx context.Context, spaceID string) (*SpacePlan, error) {
+	if !s.cfg.Enabled {
+		return &SpacePlan{Quota: config.PlanQuota{MaxStorage: s.defaultStorage}}, nil
+	}
+
+	cacheKey := "plan:" + spaceID
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		return cached.(*SpacePlan), nil
+	}
+
+	space, err := s.spaceRepo.GetByID(ctx, spaceID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找空间失败: %v", err))
+	}
+	if space == nil {
+		return nil, pkgerrors.ErrSpaceNotFound.WithDetails(map[string]interface{}{"space_id": spaceID})
+	}
+
+	plan := &SpacePlan{Plan: s.cfg.DefaultPlan}
+	var licenseStorage int64
+	if s.licenses != nil {
+		license, err := s.licenses.GetActiveLicenseForUser(ctx, space.Space().OwnerID())
+		if err != nil {
+			return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找许可证失败: %v", err))
+		}
+		if license != nil {
+			plan.Plan = license.Plan
+			plan.LicenseID = license.ID
+			licenseStorage = license.MaxStorage
+		}
+	}
+
+	// 配置中的套餐名会被转为小写
+	plan.Quota = s.cfg.Plans[strings.ToLower(plan.Plan)]
+	if licenseStorage > 0 {
+		plan.Quota.MaxStorage = licenseStorage
+	}
+	if plan.Quota.MaxStorage <= 0 {
+		plan.Quota.MaxStorage = s.defaultStorage
+	}
+
+	s.cache.Set(cacheKey, plan, quotaPlanTTL)
+	return plan, nil
+}
+
+// StorageQuota 空间的附件存储配额（字节），0 表示不限制
+func (s *QuotaService) StorageQuota(ctx context.Context, spaceID string) (int64, error) {
+	plan, err := s.Plan(ctx, spaceID)
+	if err != nil {
+		return 0, err
+	}
+	return plan.Quota.MaxStorage, nil
+}
+
+// CheckRecordQuota 校验表所在空间再创建 adding 条记录后仍在配额内
+//
+// 记录数短暂缓存，通过校验的新记录累加到缓存值上；缓存值可能因删除记录或创建失败而偏大，
+// 因此按缓存值将超出配额时重新统计后再判定。
+// 判定与占用在同一临界区内完成，并发创建不会同时通过最后的剩余配额
+func (s *QuotaService) CheckRecordQuota(ctx context.Context, tableID string, adding int) error {
+	if !s.cfg.Enabled || adding <= 0 {
+		return nil
+	}
+	spaceID, err := s.ResolveSpaceID(ctx, "", "", tableID)
+	if err != nil || spaceID == "" {
+		// 表不存在由调用方处理
+		return err
+	}
+	plan, err := s.Plan(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	limit := plan.Quota.MaxRecords
+	if limit <= 0 {
+		return nil
+	}
+
+	s.records.Lock()
+	defer s.records.Unlock()
+
+	// 缓存内且未超出配额时直接累加，否则重新统计
+	cacheKey := "records:" + spaceID
+	var used int64
+	if cached, ok := s.cache.Get(cacheKey); ok && cached.(int64)+int64(adding) <= limit {
+		used = cached.(int64)
+	} else if used, err = s.countRecords(ctx, spaceID); err != nil {
+		return err
+	}
+	if used+int64(adding) > limit {
+		return pkgerrors.ErrQuotaExceeded.WithDetails(map[string]interface{}{
+			"quota":    "records",
+			"space_id": spaceID,
+			"plan":     plan.Plan,
+			"used":     used,
+			"limit":    limit,
+		})
+	}
+
+	s.cache.Set(cacheKey, used+int64(adding), quotaRecordTTL)
+	return nil
+}
+
+// ConsumeAPICall 记录空间的一次 API 调用，超出当月配额时返回错误
+func (s *QuotaService) ConsumeAPICall(ctx context.Context, spaceID string) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+	plan, err := s.Plan(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	resetAt := nextMonth(now)
+	// 保留到下月之后一天，避免各实例时钟差异导致提前清零
+	used, err := s.counter.Incr(ctx, apiCallCounterKey(spaceID, now), resetAt.Sub(now)+24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	limit := plan.Quota.MaxAPICallsPerMonth
+	if limit > 0 && used > limit {
+		return pkgerrors.ErrQuotaExceeded.WithDetails(map[string]interface{}{
+			"quota":    "api_calls",
+			"space_id": spaceID,
+			"plan":     plan.Plan,
+			"used":     used,
+			"limit":    limit,
+			"reset_at": resetAt,
+		})
+	}
+	return nil
+}
+
+// GetUsage 获取空间的套餐、配额与用量
+func (s *QuotaService) GetUsage(ctx context.Context, spaceID, userID string) (*dto.SpaceQuotaResponse, error) {
+	if s.permissions != nil && !s.permissions.CanAccessSpace(ctx, userID, spaceID) {
+		return nil, pkgerrors.ErrSpaceNotAccessible.WithDetails(map[string]interface{}{"space_id": spaceID})
+	}
+
+	plan, err := s.Plan(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.countRecords(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := s.usageRepo.GetUsage(ctx, spaceID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询存储用量失败: %v", err))
+	}
+
+	now := s.now().UTC()
+	var apiCalls int64
+	if s.cfg.Enabled {
+		if apiCalls, err = s.counter.Get(ctx, apiCallCounterKey(spaceID, now)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.SpaceQuotaResponse{
+		SpaceID:         spaceID,
+		Plan:            plan.Plan,
+		LicenseID:       plan.LicenseID,
+		Records:         dto.QuotaUsage{Used: records, Limit: plan.Quota.MaxRecords},
+		Storage:         dto.QuotaUsage{Used: storage.UsedBytes, Limit: plan.Quota.MaxStorage},
+		APICalls:        dto.QuotaUsage{Used: apiCalls, Limit: plan.Quota.MaxAPICallsPerMonth},
+		APICallsResetAt: nextMonth(now),
+	}, nil
+}
+
+// ResolveResourceSpaceID 按请求访问的资源找到所属空间，资源不存在时返回空字符串
+func (s *QuotaService) ResolveResourceSpaceID(ctx context.Context, resource QuotaResource) (string, error) {
+	if resource.SpaceID == "" && resource.BaseID == "" && resource.TableID == "" {
+		tableID, err := s.resolveResourceTableID(ctx, resource)
+		if err != nil {
+			return "", err
+		}
+		resource.TableID = tableID
+	}
+	return s.ResolveSpaceID(ctx, resource.SpaceID, resource.BaseID, resource.TableID)
+}
+
+// resolveResourceTableID 按字段、视图或记录 ID 找到所在表
+func (s *QuotaService) resolveResourceTableID(ctx context.Context, resource QuotaResource) (string, error) {
+	var cacheKey string
+	switch {
+	case resource.FieldID != "":
+		cacheKey = "field:" + resource.FieldID
+	case resource.ViewID != "":
+		cacheKey = "view:" + resource.ViewID
+	case resource.RecordID != "":
+		cacheKey = "record:" + resource.RecordID
+	default:
+		return "", nil
+	}
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		return cached.(string), nil
+	}
+
+	var tableID string
+	switch {
+	case resource.FieldID != "":
+		if s.fieldRepo == nil {
+			return "", nil
+		}
+		field, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(resource.FieldID))
+		if err != nil {
+			return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找字段失败: %v", err))
+		}
+		if field == nil {
+			return "", nil
+		}
+		tableID = field.TableID()
+	case resource.ViewID != "":
+		if s.viewRepo == nil {
+			return "", nil
+		}
+		view, err := s.viewRepo.FindByID(ctx, resource.ViewID)
+		if err != nil {
+			return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找视图失败: %v", err))
+		}
+		if view == nil {
+			return "", nil
+		}
+		tableID = view.TableID()
+	default:
+		finder, ok := s.recordRepo.(recordTableFinder)
+		if !ok {
+			return "", nil
+		}
+		// 记录不存在时由处理器返回 404
+		found, err := finder.FindTableIDByRecordID(ctx, recordValueobject.NewRecordID(resource.RecordID))
+		if err != nil || found == "" {
+			return "", nil
+		}
+		tableID = found
+	}
+
+	s.cache.Set(cacheKey, tableID, quotaOwnerTTL)
+	return tableID, nil
+}
+
+// ResolveSpaceID 按空间、Base 或表 ID 找到所属空间，资源不存在时返回空字符串
+func (s *QuotaService) ResolveSpaceID(ctx context.Context, spaceID, baseID, tableID string) (string, error) {
+	if spaceID != "" {
+		return spaceID, nil
+	}
+	if baseID == "" && tableID != "" {
+		cacheKey := "table:" + tableID
+		if cached, ok := s.cache.Get(cacheKey); ok {
+			baseID = cached.(string)
+		} else {
+			table, err := s.tableRepo.GetByID(ctx, tableID)
+			if err != nil {
+				return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找表失败: %v", err))
+			}
+			if table == nil {
+				return "", nil
+			}
+			baseID = table.BaseID()
+			s.cache.Set(cacheKey, baseID, quotaOwnerTTL)
+		}
+	}
+	if baseID == "" {
+		return "", nil
+	}
+
+	cacheKey := "base:" + baseID
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		return cached.(string), nil
+	}
+	base, err := s.baseRepo.FindByID(ctx, baseID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && base == nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找Base失败: %v", err))
+	}
+	s.cache.Set(cacheKey, base.SpaceID, quotaOwnerTTL)
+	return base.SpaceID, nil
+}
+
+// countRecords 统计空间内所有表的记录数，并刷新缓存
+func (s *QuotaService) countRecords(ctx context.Context, spaceID string) (int64, error) {
+	bases, err := s.baseRepo.FindBySpaceID(ctx, spaceID)
+	if err != nil {
+		return 0, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找Base失败: %v", err))
+	}
+	var total int64
+	for _, base := range bases {
+		tables, err := s.tableRepo.GetByBaseID(ctx, base.ID)
+		if err != nil {
+			return 0, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找表失败: %v", err))
+		}
+		for _, table := range tables {
+			if table.IsDeleted() {
+				continue
+			}
+			count, err := s.recordRepo.CountByTableID(ctx, table.ID().String())
+			if err != nil {
+				return 0, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("统计记录数失败: %v", err))
+			}
+			total += count
+		}
+	}
+	s.cache.Set("records:"+spaceID, total, quotaRecordTTL)
+	return total, nil
+}
+
+// apiCallCounterKey 空间当月 API 调用计数的键
+func apiCallCounterKey(spaceID string, now time.Time) string {
+	return fmt.Sprintf("quota:api:%s:%s", spaceID, now.Format("200601"))
+}
+
+// nextMonth 下个自然月的开始
+func nextMonth(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+}
@@ -0,0 +1,320 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	baseEntity "github.com/easyspace-ai/luckdb/server/internal/domain/base/entity"
+	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldRepo "github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	spaceAggregate "github.com/easyspace-ai/luckdb/server/internal/domain/space/aggregate"
+	spaceEntity "github.com/easyspace-ai/luckdb/server/internal/domain/space/entity"
+	spaceRepo "github.com/easyspace-ai/luckdb/server/internal/domain/space/repository"
+	spaceValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/space/valueobject"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	tableValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/table/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+func TestQuotaAPICallPeriod(t *testing.T) {
+	now := time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC)
+	assert.Equal(t, "quota:api:spc_1:202612", apiCallCounterKey("spc_1", now))
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), nextMonth(now))
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), nextMonth(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+// 配额测试用的内存仓储，只实现配额服务用到的方法
+type quotaTestSpaces struct {
+	spaceRepo.SpaceRepository
+	owners map[string]string // spaceID -> ownerID
+}
+
+func (r *quotaTestSpaces) GetByID(ctx context.Context, id string) (*spaceAggregate.SpaceAggregate, error) {
+	owner, ok := r.owners[id]
+	if !ok {
+		return nil, nil
+	}
+	name, _ := spaceValueobject.NewSpaceName("测试空间")
+	space, err := spaceEntity.NewSpace(name, owner)
+	if err != nil {
+		return nil, err
+	}
+	return spaceAggregate.NewSpaceAggregate(space), nil
+}
+
+type quotaTestBases struct {
+	baseRepo.BaseRepository
+	bases []*baseEntity.Base
+}
+
+func (r *quotaTestBases) FindByID(ctx context.Context, id string) (*baseEntity.Base, error) {
+	for _, base := range r.bases {
+		if base.ID == id {
+			return base, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *quotaTestBases) FindBySpaceID(ctx context.Context, spaceID string) ([]*baseEntity.Base, error) {
+	var result []*baseEntity.Base
+	for _, base := range r.bases {
+		if base.SpaceID == spaceID {
+			result = append(result, base)
+		}
+	}
+	return result, nil
+}
+
+type quotaTestTables struct {
+	tableRepo.TableRepository
+	tables []*tableEntity.Table
+}
+
+func (r *quotaTestTables) GetByID(ctx context.Context, id string) (*tableEntity.Table, error) {
+	for _, table := range r.tables {
+		if table.ID().String() == id {
+			return table, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *quotaTestTables) GetByBaseID(ctx context.Context, baseID string) ([]*tableEntity.Table, error) {
+	var result []*tableEntity.Table
+	for _, table := range r.tables {
+		if table.BaseID() == baseID {
+			result = append(result, table)
+		}
+	}
+	return result, nil
+}
+
+type quotaTestFields struct {
+	fieldRepo.FieldRepository
+	fields []*fieldEntity.Field
+}
+
+func (r *quotaTestFields) FindByID(ctx context.Context, id fieldValueobject.FieldID) (*fieldEntity.Field, error) {
+	for _, field := range r.fields {
+		if field.ID().String() == id.String() {
+			return field, nil
+		}
+	}
+	return nil, nil
+}
+
+type quotaTestRecords struct {
+	recordRepo.RecordRepository
+	mu     sync.Mutex
+	counts map[string]int64 // tableID -> 记录数
+	calls  int
+}
+
+func (r *quotaTestRecords) CountByTableID(ctx context.Context, tableID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	return r.counts[tableID], nil
+}
+
+type quotaTestLicenses map[string]*models.License // ownerID -> 许可证
+
+func (l quotaTestLicenses) GetActiveLicenseForUser(ctx context.Context, userID string) (*models.License, error) {
+	return l[userID], nil
+}
+
+// quotaTestCounter 进程内计数器
+type quotaTestCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *quotaTestCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[key]++
+	return c.counts[key], nil
+}
+
+func (c *quotaTestCounter) Get(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[key], nil
+}
+
+type quotaTestEnv struct {
+	service *QuotaService
+	records *quotaTestRecords
+	counter *quotaTestCounter
+	tableID string
+}
+
+// newQuotaTestEnv 两个空间：spc_pro 的所有者持有 pro 许可证，spc_free 使用默认套餐；
+// 每个空间一个 Base 和一张表
+func newQuotaTestEnv(t *testing.T) *quotaTestEnv {
+	t.Helper()
+	cfg := config.QuotaConfig{
+		Enabled:     true,
+		DefaultPlan: "free",
+		Plans: map[string]config.PlanQuota{
+			"free": {MaxRecords: 10, MaxStorage: 1 << 20, MaxAPICallsPerMonth: 3},
+			"pro":  {MaxRecords: 1000, MaxAPICallsPerMonth: 1000},
+		},
+	}
+	tableName, err := tableValueobject.NewTableName("任务")
+	require.NoError(t, err)
+	freeTable, err := tableEntity.NewTable("bse_free", tableName, "usr_free")
+	require.NoError(t, err)
+	proTable, err := tableEntity.NewTable("bse_pro", tableName, "usr_pro")
+	require.NoError(t, err)
+
+	records := &quotaTestRecords{counts: map[string]int64{freeTable.ID().String(): 8}}
+	counter := &quotaTestCounter{counts: map[string]int64{}}
+	service := NewQuotaService(cfg, 5<<20, nil,
+		&quotaTestSpaces{owners: map[string]string{"spc_free": "usr_free", "spc_pro": "usr_pro"}},
+		&quotaTestBases{bases: []*baseEntity.Base{
+			{ID: "bse_free", SpaceID: "spc_free"},
+			{ID: "bse_pro", SpaceID: "spc_pro"},
+		}},
+		&quotaTestTables{tables: []*tableEntity.Table{freeTable, proTable}},
+		&quotaTestFields{fields: []*fieldEntity.Field{newQuotaTestField(t, "fld_title", freeTable.ID().String())}},
+		nil,
+		records,
+		nil,
+		counter,
+		nil,
+	)
+	service.licenses = quotaTestLicenses{"usr_pro": {ID: "lic_1", Plan: "Pro", MaxStorage: 50 << 20}}
+	return &quotaTestEnv{service: service, records: records, counter: counter, tableID: freeTable.ID().String()}
+}
+
+func newQuotaTestField(t *testing.T, id, tableID string) *fieldEntity.Field {
+	t.Helper()
+	fieldName, err := fieldValueobject.NewFieldName("标题")
+	require.NoError(t, err)
+	typ, err := fieldValueobject.NewFieldType(fieldValueobject.TypeSingleLineText)
+	require.NoError(t, err)
+	dbName, err := fieldValueobject.NewDBFieldName(fieldName)
+	require.NoError(t, err)
+	now := time.Now()
+	return fieldEntity.ReconstructField(fieldValueobject.NewFieldID(id), tableID, fieldName, typ,
+		dbName, "", fieldValueobject.NewFieldOptions(), 1, 1, "usr_test", now, now)
+}
+
+func requireQuotaExceeded(t *testing.T, err error) map[string]interface{} {
+	t.Helper()
+	appErr, ok := pkgerrors.IsAppError(err)
+	require.True(t, ok, "expected quota error, got %v", err)
+	require.Equal(t, pkgerrors.ErrQuotaExceeded.Code, appErr.Code)
+	details, _ := appErr.Details.(map[string]interface{})
+	return details
+}
+
+func TestQuotaService_PlanFromLicense(t *testing.T) {
+	env := newQuotaTestEnv(t)
+	ctx := context.Background()
+
+	// 许可证的套餐名不区分大小写，存储配额以许可证为准
+	plan, err := env.service.Plan(ctx, "spc_pro")
+	require.NoError(t, err)
+	assert.Equal(t, "Pro", plan.Plan)
+	assert.Equal(t, "lic_1", plan.LicenseID)
+	assert.Equal(t, int64(1000), plan.Quota.MaxRecords)
+	assert.Equal(t, int64(50<<20), plan.Quota.MaxStorage)
+
+	// 没有许可证时使用默认套餐
+	plan, err = env.service.Plan(ctx, "spc_free")
+	require.NoError(t, err)
+	assert.Equal(t, "free", plan.Plan)
+	assert.Empty(t, plan.LicenseID)
+	assert.Equal(t, int64(1<<20), plan.Quota.MaxStorage)
+
+	_, err = env.service.Plan(ctx, "spc_missing")
+	assert.Error(t, err)
+}
+
+func TestQuotaService_CheckRecordQuota(t *testing.T) {
+	env := newQuotaTestEnv(t)
+	ctx := context.Background()
+
+	setCount := func(n int64) {
+		env.records.mu.Lock()
+		env.records.counts[env.tableID] = n
+		env.records.mu.Unlock()
+	}
+
+	// 已有 8 条，配额 10 条；通过校验的记录累加到缓存上，不再重新统计
+	require.NoError(t, env.service.CheckRecordQuota(ctx, env.tableID, 1))
+	require.NoError(t, env.service.CheckRecordQuota(ctx, env.tableID, 1))
+	assert.Equal(t, 1, env.records.calls)
+	setCount(10)
+
+	// 超出配额时重新统计后拒绝
+	details := requireQuotaExceeded(t, env.service.CheckRecordQuota(ctx, env.tableID, 1))
+	assert.Equal(t, "records", details["quota"])
+	assert.Equal(t, int64(10), details["used"])
+	assert.Equal(t, int64(10), details["limit"])
+	assert.Equal(t, 2, env.records.calls)
+
+	// 删除记录后重新统计即可继续创建
+	setCount(5)
+	require.NoError(t, env.service.CheckRecordQuota(ctx, env.tableID, 5))
+	setCount(10)
+	requireQuotaExceeded(t, env.service.CheckRecordQuota(ctx, env.tableID, 1))
+
+	// 表不存在时不校验，由调用方返回错误
+	require.NoError(t, env.service.CheckRecordQuota(ctx, "tbl_missing", 100))
+}
+
+func TestQuotaService_ConsumeAPICall(t *testing.T) {
+	env := newQuotaTestEnv(t)
+	ctx := context.Background()
+	env.service.now = func() time.Time { return time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC) }
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, env.service.ConsumeAPICall(ctx, "spc_free"))
+	}
+	details := requireQuotaExceeded(t, env.service.ConsumeAPICall(ctx, "spc_free"))
+	assert.Equal(t, "api_calls", details["quota"])
+	assert.Equal(t, int64(4), details["used"])
+	assert.Equal(t, int64(3), details["limit"])
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), details["reset_at"])
+
+	// 其他空间与下个月各自计数
+	require.NoError(t, env.service.ConsumeAPICall(ctx, "spc_pro"))
+	env.service.now = func() time.Time { return time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, env.service.ConsumeAPICall(ctx, "spc_free"))
+}
+
+func TestQuotaService_ResolveResourceSpaceID(t *testing.T) {
+	env := newQuotaTestEnv(t)
+	ctx := context.Background()
+
+	spaceID, err := env.service.ResolveResourceSpaceID(ctx, QuotaResource{FieldID: "fld_title"})
+	require.NoError(t, err)
+	assert.Equal(t, "spc_free", spaceID)
+
+	spaceID, err = env.service.ResolveResourceSpaceID(ctx, QuotaResource{TableID: env.tableID, FieldID: "fld_other"})
+	require.NoError(t, err)
+	assert.Equal(t, "spc_free", spaceID)
+
+	// 资源不存在或没有可解析的参数时不计数
+	spaceID, err = env.service.ResolveResourceSpaceID(ctx, QuotaResource{FieldID: "fld_missing"})
+	require.NoError(t, err)
+	assert.Empty(t, spaceID)
+	spaceID, err = env.service.ResolveResourceSpaceID(ctx, QuotaResource{})
+	require.NoError(t, err)
+	assert.Empty(t, spaceID)
+}
@@ -20,7 +20,7 @@ func NewRecordBroadcaster(wsService websocket.Service) *RecordBroadcasterImpl {
 }
 
 // BroadcastRecordCreate 广播记录创建操作
-func (b *RecordBroadcasterImpl) BroadcastRecordCreate(tableID, recordID string, fields map[string]interface{}) {
+func (b *RecordBroadcasterImpl) BroadcastRecordCreate(tableID, recordID string, fields map[string]interface{}, allow func(userID string) bool) {
 	operation := &websocket.Operation{
 		Type:    websocket.OperationTypeRecordCreate,
 		TableID: tableID,
@@ -30,13 +30,7 @@ func (b *RecordBroadcasterImpl) BroadcastRecordCreate(tableID, recordID string,
 		},
 	}
 
-	message := &websocket.Message{
-		Type: websocket.MessageTypeOp,
-		Data: operation,
-	}
-
-	channel := fmt.Sprintf("table:%s", tableID)
-	if err := b.wsService.BroadcastToChannel(channel, message); err != nil {
+	if err := b.broadcast(tableID, operation, allow); err != nil {
 		logger.Error("Failed to broadcast record create event",
 			logger.String("table_id", tableID),
 			logger.String("record_id", recordID),
@@ -45,7 +39,7 @@ func (b *RecordBroadcasterImpl) BroadcastRecordCreate(tableID, recordID string,
 }
 
 // BroadcastRecordUpdate 广播记录更新操作
-func (b *RecordBroadcasterImpl) BroadcastRecordUpdate(tableID, recordID string, fields map[string]interface{}) {
+func (b *RecordBroadcasterImpl) BroadcastRecordUpdate(tableID, recordID string, fields map[string]interface{}, allow func(userID string) bool) {
 	operation := &websocket.Operation{
 		Type:    websocket.OperationTypeRecordUpdate,
 		TableID: tableID,
@@ -55,13 +49,7 @@ func (b *RecordBroadcasterImpl) BroadcastRecordUpdate(tableID, recordID string,
 		},
 	}
 
-	message := &websocket.Message{
-		Type: websocket.MessageTypeOp,
-		Data: operation,
-	}
-
-	channel := fmt.Sprintf("table:%s", tableID)
-	if err := b.wsService.BroadcastToChannel(channel, message); err != nil {
+	if err := b.broadcast(tableID, operation, allow); err != nil {
 		logger.Error("Failed to broadcast record update event",
 			logger.String("table_id", tableID),
 			logger.String("record_id", recordID),
@@ -70,7 +58,7 @@ func (b *RecordBroadcasterImpl) BroadcastRecordUpdate(tableID, recordID string,
 }
 
 // BroadcastRecordDelete 广播记录删除操作
-func (b *RecordBroadcasterImpl) BroadcastRecordDelete(tableID, recordID string) {
+func (b *RecordBroadcasterImpl) BroadcastRecordDelete(tableID, recordID string, allow func(userID string) bool) {
 	operation := &websocket.Operation{
 		Type:    websocket.OperationTypeRecordDelete,
 		TableID: tableID,
@@ -79,16 +67,24 @@ func (b *RecordBroadcasterImpl) BroadcastRecordDelete(tableID, recordID string)
 		},
 	}
 
+	if err := b.broadcast(tableID, operation, allow); err != nil {
+		logger.Error("Failed to broadcast record delete event",
+			logger.String("table_id", tableID),
+			logger.String("record_id", recordID),
+			logger.ErrorField(err))
+	}
+}
+
+// broadcast 发送到表频道；allow 非空时只发送给通过行级权限校验的用户
+func (b *RecordBroadcasterImpl) broadcast(tableID string, operation *websocket.Operation, allow func(userID string) bool) error {
 	message := &websocket.Message{
 		Type: websocket.MessageTypeOp,
 		Data: operation,
 	}
 
 	channel := fmt.Sprintf("table:%s", tableID)
-	if err := b.wsService.BroadcastToChannel(channel, message); err != nil {
-		logger.Error("Failed to broadcast record delete event",
-			logger.String("table_id", tableID),
-			logger.String("record_id", recordID),
-			logger.ErrorField(err))
+	if allow != nil {
+		return b.wsService.BroadcastToChannelWhere(channel, message, allow)
 	}
+	return b.wsService.BroadcastToChannel(channel, message)
 }
@@ -3,14 +3,21 @@ package application
 import (
 	"context"
 	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
 	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
 	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	viewValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
 	infraRepository "github.com/easyspace-ai/luckdb/server/internal/infrastructure/repository"
+	"github.com/easyspace-ai/luckdb/server/pkg/authctx"
 	"github.com/easyspace-ai/luckdb/server/pkg/database"
 	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
@@ -33,6 +40,10 @@ import (
 //   - 记录变更实时推送到前端
 //   - 计算字段变更实时推送
 //   - 支持多客户端同步
+//
+// 行级权限：
+//   - 单条读取/更新/删除按行级规则校验，列表查询下推为SQL条件
+//   - 实时推送只发送给能看到该记录的用户
 type RecordService struct {
 	recordRepo         recordRepo.RecordRepository
 	fieldRepo          repository.FieldRepository
@@ -40,13 +51,23 @@ type RecordService struct {
 	calculationService *CalculationService       // ✨ 计算引擎
 	broadcaster        Broadcaster               // ✨ WebSocket广播器
 	typecastService    *TypecastService          // ✅ Phase 2: 类型转换和验证
+	rowPermission      *RowPermissionService     // ✨ 行级权限
+	statisticsNotifier RecordChangeNotifier      // ✨ 视图列统计推送
+	liveQueries        RecordUpdateNotifier      // ✨ WebSocket 实时查询刷新
+	linkRecords        *LinkRecordService        // ✨ Link 字段关联约束校验
+	aiFields           *AIFieldService           // ✨ AI 字段自动生成
+	attachments        *AttachmentService        // ✨ 附件单元格引用同步
+	reminders          *ReminderService          // ✨ 日期字段提醒重新规划
+	quotas             *QuotaService             // ✨ 空间记录数配额
+	foreignFields      *ForeignFieldGuard        // ✨ 跨 Base 关联字段的读取权限
 }
 
 // Broadcaster WebSocket广播器接口
 type Broadcaster interface {
-	BroadcastRecordUpdate(tableID, recordID string, fields map[string]interface{})
-	BroadcastRecordCreate(tableID, recordID string, fields map[string]interface{})
-	BroadcastRecordDelete(tableID, recordID string)
+	// allow 为 nil 时发送给频道内所有连接，否则只发送给 allow 返回 true 的用户
+	BroadcastRecordUpdate(tableID, recordID string, fields map[string]interface{}, allow func(userID string) bool)
+	BroadcastRecordCreate(tableID, recordID string, fields map[string]interface{}, allow func(userID string) bool)
+	BroadcastRecordDelete(tableID, recordID string, allow func(userID string) bool)
 }
 
 // NewRecordService 创建记录服务（集成计算引擎+实时推送+验证）✨
@@ -73,6 +94,65 @@ func (s *RecordService) SetBroadcaster(broadcaster Broadcaster) {
 	s.broadcaster = broadcaster
 }
 
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (s *RecordService) SetRowPermissionService(rowPermission *RowPermissionService) {
+	s.rowPermission = rowPermission
+}
+
+// SetStatisticsNotifier 设置视图列统计推送器（用于延迟注入）
+func (s *RecordService) SetStatisticsNotifier(notifier RecordChangeNotifier) {
+	s.statisticsNotifier = notifier
+}
+
+// SetLiveQueryNotifier 设置实时查询刷新通知（用于延迟注入）
+func (s *RecordService) SetLiveQueryNotifier(notifier RecordUpdateNotifier) {
+	s.liveQueries = notifier
+}
+
+// SetAIFieldService 设置 AI 字段生成服务（用于延迟注入）
+func (s *RecordService) SetAIFieldService(aiFields *AIFieldService) {
+	s.aiFields = aiFields
+}
+
+// SetLinkRecordService 设置 Link 字段关联服务（用于延迟注入）
+func (s *RecordService) SetLinkRecordService(linkRecords *LinkRecordService) {
+	s.linkRecords = linkRecords
+}
+
+// SetAttachmentService 设置附件服务（用于延迟注入）
+func (s *RecordService) SetAttachmentService(attachments *AttachmentService) {
+	s.attachments = attachments
+}
+
+// SetReminderService 设置记录提醒服务（用于延迟注入）
+func (s *RecordService) SetReminderService(reminders *ReminderService) {
+	s.reminders = reminders
+}
+
+// SetForeignFieldGuard 设置跨 Base 字段读取权限校验（用于延迟注入）
+func (s *RecordService) SetForeignFieldGuard(guard *ForeignFieldGuard) {
+	s.foreignFields = guard
+}
+
+// SetQuotaService 设置配额服务（用于延迟注入）
+func (s *RecordService) SetQuotaService(quotas *QuotaService) {
+	s.quotas = quotas
+}
+
+// checkRecordQuota 校验表所在空间再创建 n 条记录后仍在配额内
+func (s *RecordService) checkRecordQuota(ctx context.Context, tableID string, n int) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.CheckRecordQuota(ctx, tableID, n)
+}
+
+// isConflictError 是否为记录版本冲突（乐观锁）
+func isConflictError(err error) bool {
+	appErr, ok := pkgerrors.IsAppError(err)
+	return ok && appErr.Code == pkgerrors.ErrConflict.Code
+}
+
 // CreateRecord 创建记录（集成自动计算）✨ 事务版
 //
 // 执行流程：
@@ -88,6 +168,9 @@ func (s *RecordService) SetBroadcaster(broadcaster Broadcaster) {
 //   - 计算失败回滚整个事务
 //   - 事务成功后才发布 WebSocket 事件
 func (s *RecordService) CreateRecord(ctx context.Context, req dto.CreateRecordRequest, userID string) (*dto.RecordResponse, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.CreateRecord", attribute.String("table.id", req.TableID))
+	defer span.End()
+
 	// ✅ 在事务前检查表是否存在
 	table, err := s.tableRepo.GetByID(ctx, req.TableID)
 	if err != nil {
@@ -98,6 +181,9 @@ func (s *RecordService) CreateRecord(ctx context.Context, req dto.CreateRecordRe
 			"table_id": req.TableID,
 		})
 	}
+	if err := s.checkRecordQuota(ctx, req.TableID, 1); err != nil {
+		return nil, err
+	}
 
 	var record *entity.Record
 	var finalFields map[string]interface{}
@@ -122,6 +208,11 @@ func (s *RecordService) CreateRecord(ctx context.Context, req dto.CreateRecordRe
 			return err
 		}
 
+		// ✨ 关联的记录必须满足 Link 字段的视图和过滤约束
+		if err := s.checkLinkConstraints(txCtx, req.TableID, validatedData); err != nil {
+			return err
+		}
+
 		// 3. 创建记录数据值对象
 		recordData, err := valueobject.NewRecordData(validatedData)
 		if err != nil {
@@ -155,8 +246,14 @@ func (s *RecordService) CreateRecord(ctx context.Context, req dto.CreateRecordRe
 				logger.String("record_id", record.ID().String()))
 		}
 
-		// 7. ✅ 收集事件（不立即发送）
+		// 7. ✨ 行级权限：新记录必须落在可创建的范围内
+		if err := s.checkRowAccess(txCtx, req.TableID, userID, permission.ActionRecordCreate, RecordValues(record)); err != nil {
+			return err
+		}
+
+		// 8. ✅ 收集事件（不立即发送）
 		finalFields = record.Data().ToMap()
+		values := RecordValues(record)
 		event := &database.RecordEvent{
 			EventType: "record.create",
 			TID:       req.TableID,
@@ -166,9 +263,10 @@ func (s *RecordService) CreateRecord(ctx context.Context, req dto.CreateRecordRe
 		}
 		database.AddEventToTx(txCtx, event)
 
-		// 8. ✨ 添加事务提交后回调（发布 WebSocket 事件）
+		// 9. ✨ 添加事务提交后回调（发布 WebSocket 事件）
 		database.AddTxCallback(txCtx, func() {
-			s.publishRecordEvent(event)
+			s.publishRecordEvent(ctx, event, values, nil)
+			s.generateAIFields(ctx, req.TableID, []string{record.ID().String()}, nil)
 		})
 
 		return nil
@@ -181,11 +279,14 @@ func (s *RecordService) CreateRecord(ctx context.Context, req dto.CreateRecordRe
 	logger.Info("记录创建完成，事件将在事务提交后发布",
 		logger.String("record_id", record.ID().String()))
 
-	return dto.FromRecordEntity(record), nil
+	return s.toResponse(ctx, req.TableID, record)
 }
 
 // GetRecord 获取记录详情
 func (s *RecordService) GetRecord(ctx context.Context, tableID, recordID string) (*dto.RecordResponse, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.GetRecord", attribute.String("table.id", tableID), attribute.String("record.id", recordID))
+	defer span.End()
+
 	id := valueobject.NewRecordID(recordID)
 
 	record, err := s.recordRepo.FindByTableAndID(ctx, tableID, id)
@@ -196,7 +297,12 @@ func (s *RecordService) GetRecord(ctx context.Context, tableID, recordID string)
 		return nil, pkgerrors.ErrNotFound.WithDetails("记录不存在")
 	}
 
-	return dto.FromRecordEntity(record), nil
+	// ✨ 行级权限：不可见的记录按不存在处理
+	if err := s.checkRowAccess(ctx, tableID, currentUserID(ctx), permission.ActionRecordRead, RecordValues(record)); err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ctx, tableID, record)
 }
 
 // UpdateRecord 更新记录（集成智能重算）✨ 事务版
@@ -215,6 +321,9 @@ func (s *RecordService) GetRecord(ctx context.Context, tableID, recordID string)
 //   - 计算失败回滚整个事务
 //   - 事务成功后才发布 WebSocket 事件
 func (s *RecordService) UpdateRecord(ctx context.Context, tableID, recordID string, req dto.UpdateRecordRequest, userID string) (*dto.RecordResponse, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.UpdateRecord", attribute.String("table.id", tableID), attribute.String("record.id", recordID))
+	defer span.End()
+
 	// ✅ 在事务前检查表是否存在
 	table, err := s.tableRepo.GetByID(ctx, tableID)
 	if err != nil {
@@ -243,6 +352,12 @@ func (s *RecordService) UpdateRecord(ctx context.Context, tableID, recordID stri
 		}
 		record = records[0]
 
+		// ✨ 行级权限：只能更新可操作范围内的记录
+		previous := RecordValues(record)
+		if err := s.checkRowAccess(txCtx, tableID, userID, permission.ActionRecordUpdate, previous); err != nil {
+			return err
+		}
+
 		// ✅ 2. 乐观锁检查：如果提供了版本号，验证是否匹配
 		if req.Version != nil {
 			expectedVersion, err := valueobject.NewRecordVersion(int64(*req.Version))
@@ -263,6 +378,11 @@ func (s *RecordService) UpdateRecord(ctx context.Context, tableID, recordID stri
 		oldData := record.Data().ToMap()
 		changedFieldIDs := s.identifyChangedFields(oldData, req.Data)
 
+		// ✨ 关联的记录必须满足 Link 字段的视图和过滤约束
+		if err := s.checkLinkConstraints(txCtx, tableID, req.Data); err != nil {
+			return err
+		}
+
 		// 4. 创建新数据
 		newData, err := valueobject.NewRecordData(req.Data)
 		if err != nil {
@@ -288,9 +408,18 @@ func (s *RecordService) UpdateRecord(ctx context.Context, tableID, recordID stri
 				logger.Int("changed_fields", len(changedFieldIDs)))
 		}
 
+		// ✨ 行级权限：更新后的记录不能移出可操作范围
+		values := RecordValues(record)
+		if err := s.checkRowAccess(txCtx, tableID, userID, permission.ActionRecordUpdate, values); err != nil {
+			return pkgerrors.ErrForbidden.WithDetails("更新后的记录将超出可操作的行范围")
+		}
+
 		// 7. 保存（在事务中，包含计算后的字段）
 		// 注意：record.Update()已经递增了版本，但Save会用旧版本做乐观锁检查
 		if err := s.recordRepo.Save(txCtx, record); err != nil {
+			if isConflictError(err) {
+				return err // 并发更新导致的版本冲突，保留错误类型供调用方重试
+			}
 			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("保存记录失败: %v", err))
 		}
 
@@ -311,7 +440,10 @@ func (s *RecordService) UpdateRecord(ctx context.Context, tableID, recordID stri
 
 		// 9. ✨ 添加事务提交后回调（发布 WebSocket 事件）
 		database.AddTxCallback(txCtx, func() {
-			s.publishRecordEvent(event)
+			s.publishRecordEvent(ctx, event, values, previous)
+			if len(changedFieldIDs) > 0 {
+				s.generateAIFields(ctx, tableID, []string{recordID}, changedFieldIDs)
+			}
 		})
 
 		return nil
@@ -324,7 +456,7 @@ func (s *RecordService) UpdateRecord(ctx context.Context, tableID, recordID stri
 	logger.Info("记录更新完成，事件将在事务提交后发布",
 		logger.String("record_id", recordID))
 
-	return dto.FromRecordEntity(record), nil
+	return s.toResponse(ctx, tableID, record)
 }
 
 // validateRequiredFields 验证必填字段
@@ -405,6 +537,9 @@ func (s *RecordService) isValueEqual(a, b interface{}) bool {
 // DeleteRecord 删除记录 ✨ 事务版
 // ✅ 对齐 Teable：所有记录操作都需要 tableID
 func (s *RecordService) DeleteRecord(ctx context.Context, tableID, recordID string) error {
+	ctx, span := observability.StartSpan(ctx, "RecordService.DeleteRecord", attribute.String("table.id", tableID), attribute.String("record.id", recordID))
+	defer span.End()
+
 	// ✅ 在事务中执行所有操作
 	err := database.Transaction(ctx, s.recordRepo.(*infraRepository.RecordRepositoryDynamic).GetDB(), nil, func(txCtx context.Context) error {
 		id := valueobject.NewRecordID(recordID)
@@ -418,6 +553,12 @@ func (s *RecordService) DeleteRecord(ctx context.Context, tableID, recordID stri
 			return pkgerrors.ErrNotFound.WithDetails("记录不存在")
 		}
 
+		// ✨ 行级权限：只能删除可操作范围内的记录
+		values := RecordValues(record)
+		if err := s.checkRowAccess(txCtx, tableID, currentUserID(ctx), permission.ActionRecordDelete, values); err != nil {
+			return err
+		}
+
 		// 2. 删除记录（使用 tableID）
 		if err := s.recordRepo.DeleteByTableAndID(txCtx, tableID, id); err != nil {
 			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("删除记录失败: %v", err))
@@ -436,7 +577,7 @@ func (s *RecordService) DeleteRecord(ctx context.Context, tableID, recordID stri
 
 		// 4. ✨ 添加事务提交后回调（发布 WebSocket 事件）
 		database.AddTxCallback(txCtx, func() {
-			s.publishRecordEvent(event)
+			s.publishRecordEvent(ctx, event, values, nil)
 		})
 
 		return nil
@@ -454,17 +595,50 @@ func (s *RecordService) DeleteRecord(ctx context.Context, tableID, recordID stri
 
 // ListRecords 列出表格的所有记录
 func (s *RecordService) ListRecords(ctx context.Context, tableID string, limit, offset int) ([]*dto.RecordResponse, int64, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.ListRecords", attribute.String("table.id", tableID))
+	defer span.End()
+
+	return s.QueryRecords(ctx, tableID, RecordQuery{Limit: limit, Offset: offset})
+}
+
+// RecordQuery 记录查询条件 ✨
+type RecordQuery struct {
+	Filters  []*viewValueobject.Filter // 过滤条件（字段ID为键，多个条件之间 AND）
+	OrderBy  string                    // 排序的物理列名，为空时按创建时间倒序
+	OrderDir string                    // asc, desc
+	Limit    int                       // 为 0 时默认 100
+	Offset   int
+}
+
+// QueryRecords 按过滤、排序和分页查询记录 ✨
+// 与 ListRecords 一样应用行级权限并计算虚拟字段
+func (s *RecordService) QueryRecords(ctx context.Context, tableID string, query RecordQuery) ([]*dto.RecordResponse, int64, error) {
 	// 构建过滤器
 	filter := recordRepo.RecordFilter{
-		TableID: &tableID,
-		Limit:   limit,
-		Offset:  offset,
+		TableID:  &tableID,
+		Filters:  query.Filters,
+		OrderBy:  query.OrderBy,
+		OrderDir: query.OrderDir,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
 	}
 
 	if filter.Limit == 0 {
 		filter.Limit = 100 // 默认限制
 	}
 
+	// ✨ 行级权限：可见范围下推为查询条件，总数同样只统计可见记录
+	if userID := currentUserID(ctx); s.rowPermission != nil && userID != "" {
+		access, err := s.rowPermission.ResolveAccess(ctx, tableID, userID, permission.ActionRecordRead)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !access.Allowed {
+			return []*dto.RecordResponse{}, 0, nil
+		}
+		filter.AccessScopes = access.Scopes
+	}
+
 	// 查询记录列表
 	records, total, err := s.recordRepo.List(ctx, filter)
 	if err != nil {
@@ -493,11 +667,18 @@ func (s *RecordService) ListRecords(ctx context.Context, tableID string, limit,
 	}
 
 	// 转换为 DTO
-	return dto.FromRecordEntities(records), total, nil
+	responses := dto.FromRecordEntities(records)
+	if err := s.foreignFields.MaskResponses(ctx, tableID, responses...); err != nil {
+		return nil, 0, err
+	}
+	return responses, total, nil
 }
 
 // BatchCreateRecords 批量创建记录（严格遵守：返回AppError）
 func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string, req dto.BatchCreateRecordRequest, userID string) (*dto.BatchCreateRecordResponse, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.BatchCreateRecords", attribute.String("table.id", tableID), attribute.Int("record.count", len(req.Records)))
+	defer span.End()
+
 	// ✅ 允许空数组：直接返回成功响应
 	if len(req.Records) == 0 {
 		return &dto.BatchCreateRecordResponse{
@@ -508,8 +689,14 @@ func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string,
 		}, nil
 	}
 
+	// 超出记录数配额时整批拒绝
+	if err := s.checkRecordQuota(ctx, tableID, len(req.Records)); err != nil {
+		return nil, err
+	}
+
 	successRecords := make([]*dto.RecordResponse, 0, len(req.Records))
 	errorsList := make([]string, 0)
+	createdIDs := make([]string, 0, len(req.Records))
 
 	// 遍历每条记录进行创建
 	for i, item := range req.Records {
@@ -519,6 +706,10 @@ func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string,
 			errorsList = append(errorsList, fmt.Sprintf("记录%d数据验证失败: %v", i+1, err))
 			continue
 		}
+		if err := s.checkLinkConstraints(ctx, tableID, validatedData); err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("记录%d数据验证失败: %v", i+1, err))
+			continue
+		}
 
 		// 创建记录数据值对象（使用验证后的数据）
 		recordData, err := valueobject.NewRecordData(validatedData)
@@ -534,6 +725,12 @@ func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string,
 			continue
 		}
 
+		// ✨ 行级权限
+		if err := s.checkRowAccess(ctx, tableID, userID, permission.ActionRecordCreate, RecordValues(record)); err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("记录%d创建失败: %v", i+1, err))
+			continue
+		}
+
 		// 保存记录
 		if err := s.recordRepo.Save(ctx, record); err != nil {
 			errorsList = append(errorsList, fmt.Sprintf("记录%d保存失败: %v", i+1, err))
@@ -552,9 +749,15 @@ func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string,
 			}
 		}
 
+		values := RecordValues(record)
+		s.syncAttachments(ctx, tableID, record.ID().String(), values)
+		s.planReminders(ctx, tableID, record.ID().String(), values)
+
 		// 添加到成功列表
 		successRecords = append(successRecords, dto.FromRecordEntity(record))
+		createdIDs = append(createdIDs, record.ID().String())
 	}
+	s.generateAIFields(ctx, tableID, createdIDs, nil)
 
 	logger.Info("批量创建记录完成",
 		logger.String("table_id", tableID),
@@ -563,6 +766,9 @@ func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string,
 		logger.Int("failed", len(errorsList)),
 	)
 
+	if err := s.foreignFields.MaskResponses(ctx, tableID, successRecords...); err != nil {
+		return nil, err
+	}
 	return &dto.BatchCreateRecordResponse{
 		Records:      successRecords,
 		SuccessCount: len(successRecords),
@@ -573,8 +779,13 @@ func (s *RecordService) BatchCreateRecords(ctx context.Context, tableID string,
 
 // BatchUpdateRecords 批量更新记录（严格遵守：返回AppError）
 func (s *RecordService) BatchUpdateRecords(ctx context.Context, tableID string, req dto.BatchUpdateRecordRequest, userID string) (*dto.BatchUpdateRecordResponse, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.BatchUpdateRecords", attribute.String("table.id", tableID), attribute.Int("record.count", len(req.Records)))
+	defer span.End()
+
 	successRecords := make([]*dto.RecordResponse, 0, len(req.Records))
 	errorsList := make([]string, 0)
+	updatedIDs := make([]string, 0, len(req.Records))
+	changedFields := make(map[string]bool)
 
 	// 遍历每条记录进行更新
 	for i, item := range req.Records {
@@ -591,6 +802,17 @@ func (s *RecordService) BatchUpdateRecords(ctx context.Context, tableID string,
 		}
 		record := records[0]
 
+		// ✨ 行级权限：更新前后都必须在可操作范围内
+		if err := s.checkRowAccess(ctx, tableID, userID, permission.ActionRecordUpdate, RecordValues(record)); err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("记录%s更新失败: %v", item.ID, err))
+			continue
+		}
+
+		if err := s.checkLinkConstraints(ctx, tableID, item.Fields); err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("记录%s更新失败: %v", item.ID, err))
+			continue
+		}
+
 		// 创建新数据
 		newData, err := valueobject.NewRecordData(item.Fields)
 		if err != nil {
@@ -603,6 +825,10 @@ func (s *RecordService) BatchUpdateRecords(ctx context.Context, tableID string,
 			errorsList = append(errorsList, fmt.Sprintf("记录%s更新失败: %v", item.ID, err))
 			continue
 		}
+		if err := s.checkRowAccess(ctx, tableID, userID, permission.ActionRecordUpdate, RecordValues(record)); err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("记录%s更新后将超出可操作的行范围", item.ID))
+			continue
+		}
 
 		// 保存
 		if err := s.recordRepo.Save(ctx, record); err != nil {
@@ -610,8 +836,23 @@ func (s *RecordService) BatchUpdateRecords(ctx context.Context, tableID string,
 			continue
 		}
 
+		values := RecordValues(record)
+		s.syncAttachments(ctx, tableID, item.ID, values)
+		s.planReminders(ctx, tableID, item.ID, values)
+
 		// 添加到成功列表
 		successRecords = append(successRecords, dto.FromRecordEntity(record))
+		updatedIDs = append(updatedIDs, item.ID)
+		for fieldID := range item.Fields {
+			changedFields[fieldID] = true
+		}
+	}
+	if len(changedFields) > 0 {
+		changedFieldIDs := make([]string, 0, len(changedFields))
+		for fieldID := range changedFields {
+			changedFieldIDs = append(changedFieldIDs, fieldID)
+		}
+		s.generateAIFields(ctx, tableID, updatedIDs, changedFieldIDs)
 	}
 
 	logger.Info("批量更新记录完成",
@@ -620,6 +861,9 @@ func (s *RecordService) BatchUpdateRecords(ctx context.Context, tableID string,
 		logger.Int("failed", len(errorsList)),
 	)
 
+	if err := s.foreignFields.MaskResponses(ctx, tableID, successRecords...); err != nil {
+		return nil, err
+	}
 	return &dto.BatchUpdateRecordResponse{
 		Records:      successRecords,
 		SuccessCount: len(successRecords),
@@ -630,18 +874,37 @@ func (s *RecordService) BatchUpdateRecords(ctx context.Context, tableID string,
 
 // BatchDeleteRecords 批量删除记录（严格遵守：返回AppError）
 func (s *RecordService) BatchDeleteRecords(ctx context.Context, tableID string, req dto.BatchDeleteRecordRequest) (*dto.BatchDeleteRecordResponse, error) {
+	ctx, span := observability.StartSpan(ctx, "RecordService.BatchDeleteRecords", attribute.String("table.id", tableID), attribute.Int("record.count", len(req.RecordIDs)))
+	defer span.End()
+
 	errorsList := make([]string, 0)
 	successCount := 0
+	userID := currentUserID(ctx)
 
 	// 遍历每条记录进行删除（使用 tableID）
 	for _, recordID := range req.RecordIDs {
 		id := valueobject.NewRecordID(recordID)
 
+		// ✨ 行级权限：只能删除可操作范围内的记录
+		if s.rowPermission != nil && userID != "" {
+			record, err := s.recordRepo.FindByTableAndID(ctx, tableID, id)
+			if err != nil || record == nil {
+				errorsList = append(errorsList, fmt.Sprintf("记录%s不存在", recordID))
+				continue
+			}
+			if err := s.checkRowAccess(ctx, tableID, userID, permission.ActionRecordDelete, RecordValues(record)); err != nil {
+				errorsList = append(errorsList, fmt.Sprintf("记录%s删除失败: %v", recordID, err))
+				continue
+			}
+		}
+
 		// 删除记录（使用 tableID）
 		if err := s.recordRepo.DeleteByTableAndID(ctx, tableID, id); err != nil {
 			errorsList = append(errorsList, fmt.Sprintf("记录%s删除失败: %v", recordID, err))
 			continue
 		}
+		s.syncAttachments(ctx, tableID, recordID, nil)
+		s.planReminders(ctx, tableID, recordID, nil)
 
 		successCount++
 	}
@@ -659,28 +922,147 @@ func (s *RecordService) BatchDeleteRecords(ctx context.Context, tableID string,
 	}, nil
 }
 
+// toResponse 转换为响应，并移除当前用户不能读取的跨 Base 字段值
+func (s *RecordService) toResponse(ctx context.Context, tableID string, record *entity.Record) (*dto.RecordResponse, error) {
+	response := dto.FromRecordEntity(record)
+	if err := s.foreignFields.MaskResponses(ctx, tableID, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// checkRowAccess 校验行级权限
+// 没有用户上下文（系统内部调用）或未配置行级权限服务时不做限制
+func (s *RecordService) checkRowAccess(ctx context.Context, tableID, userID string, action permission.Action, values map[string]interface{}) error {
+	if s.rowPermission == nil || userID == "" {
+		return nil
+	}
+	return s.rowPermission.CheckRecord(ctx, tableID, userID, action, values)
+}
+
+// checkLinkConstraints 校验 Link 字段的关联约束
+// 未配置 Link 字段关联服务时不做限制
+func (s *RecordService) checkLinkConstraints(ctx context.Context, tableID string, data map[string]interface{}) error {
+	if s.linkRecords == nil {
+		return nil
+	}
+	return s.linkRecords.ValidateLinks(ctx, tableID, data)
+}
+
+// generateAIFields 记录变更后异步生成受影响的 AI 字段
+// changedFieldIDs 为空表示新建记录；未配置 AI 字段生成服务时跳过
+func (s *RecordService) generateAIFields(ctx context.Context, tableID string, recordIDs []string, changedFieldIDs []string) {
+	if s.aiFields == nil {
+		return
+	}
+	s.aiFields.HandleRecordsChanged(ctx, tableID, recordIDs, changedFieldIDs)
+}
+
+// syncAttachments 记录变更后同步附件单元格引用，记录删除时 values 传 nil
+// 未配置附件服务时跳过
+func (s *RecordService) syncAttachments(ctx context.Context, tableID, recordID string, values map[string]interface{}) {
+	if s.attachments == nil {
+		return
+	}
+	s.attachments.SyncRecordAttachments(ctx, tableID, recordID, values)
+}
+
+// planReminders 记录变更后重新规划日期提醒，记录删除时 values 传 nil
+// 未配置提醒服务时跳过
+func (s *RecordService) planReminders(ctx context.Context, tableID, recordID string, values map[string]interface{}) {
+	if s.reminders == nil {
+		return
+	}
+	s.reminders.PlanRecord(ctx, tableID, recordID, values)
+}
+
+// currentUserID 从上下文获取当前用户ID
+func currentUserID(ctx context.Context) string {
+	userID, _ := authctx.UserFrom(ctx)
+	return userID
+}
+
 // publishRecordEvent 发布记录事件到 WebSocket
-func (s *RecordService) publishRecordEvent(event *database.RecordEvent) {
+// values 为变更后的记录值，previous 为更新前的记录值，用于按行级权限筛选接收者：
+// 更新后移出某用户可见范围的记录，对该用户推送删除
+func (s *RecordService) publishRecordEvent(ctx context.Context, event *database.RecordEvent, values, previous map[string]interface{}) {
+	if s.statisticsNotifier != nil {
+		s.statisticsNotifier.NotifyRecordChanged(event.TID)
+	}
+	if s.liveQueries != nil {
+		// 更新只影响窗口包含该记录、或过滤/排序引用了变更字段的查询
+		if event.EventType == "record.update" && previous != nil {
+			s.liveQueries.NotifyRecordUpdated(event.TID, event.RID, changedValueKeys(previous, values))
+		} else {
+			s.liveQueries.NotifyRecordChanged(event.TID)
+		}
+	}
+	if event.EventType == "record.delete" {
+		s.syncAttachments(ctx, event.TID, event.RID, nil)
+		s.planReminders(ctx, event.TID, event.RID, nil)
+	} else {
+		s.syncAttachments(ctx, event.TID, event.RID, values)
+		s.planReminders(ctx, event.TID, event.RID, values)
+	}
 	if s.broadcaster == nil {
 		return
 	}
 
+	var allow, allowedBefore func(userID string) bool
+	if s.rowPermission != nil {
+		allow = s.rowPermission.RecipientFilter(ctx, event.TID, values)
+		if previous != nil {
+			allowedBefore = s.rowPermission.RecipientFilter(ctx, event.TID, previous)
+		}
+	}
+
+	// ✨ 不能读取跨 Base 字段的用户收到移除了这些字段的消息；
+	// 无法确定跨 Base 字段时不推送字段值，客户端重新读取记录
+	foreign, canReadForeign, err := s.foreignFields.RecipientFilter(ctx, event.TID)
+	broadcastFields := func(send func(fields map[string]interface{}, allow func(userID string) bool)) {
+		switch {
+		case err != nil:
+			logger.Warn("解析跨 Base 字段失败，推送时不包含字段值",
+				logger.String("table_id", event.TID),
+				logger.ErrorField(err))
+			send(map[string]interface{}{}, allow)
+		case len(foreign) == 0:
+			send(event.Fields, allow)
+		default:
+			send(event.Fields, func(userID string) bool {
+				return (allow == nil || allow(userID)) && canReadForeign(userID)
+			})
+			send(withoutFields(event.Fields, foreign), func(userID string) bool {
+				return (allow == nil || allow(userID)) && !canReadForeign(userID)
+			})
+		}
+	}
+
 	switch event.EventType {
 	case "record.create":
-		s.broadcaster.BroadcastRecordCreate(event.TID, event.RID, event.Fields)
+		broadcastFields(func(fields map[string]interface{}, allow func(userID string) bool) {
+			s.broadcaster.BroadcastRecordCreate(event.TID, event.RID, fields, allow)
+		})
 		logger.Info("WebSocket 事件已发布：创建",
 			logger.String("table_id", event.TID),
 			logger.String("record_id", event.RID))
 
 	case "record.update":
-		s.broadcaster.BroadcastRecordUpdate(event.TID, event.RID, event.Fields)
+		broadcastFields(func(fields map[string]interface{}, allow func(userID string) bool) {
+			s.broadcaster.BroadcastRecordUpdate(event.TID, event.RID, fields, allow)
+		})
+		if allow != nil && allowedBefore != nil {
+			s.broadcaster.BroadcastRecordDelete(event.TID, event.RID, func(userID string) bool {
+				return allowedBefore(userID) && !allow(userID)
+			})
+		}
 		logger.Info("WebSocket 事件已发布：更新",
 			logger.String("table_id", event.TID),
 			logger.String("record_id", event.RID),
 			logger.Int64("version", event.NewVersion))
 
 	case "record.delete":
-		s.broadcaster.BroadcastRecordDelete(event.TID, event.RID)
+		s.broadcaster.BroadcastRecordDelete(event.TID, event.RID, allow)
 		logger.Info("WebSocket 事件已发布：删除",
 			logger.String("table_id", event.TID),
 			logger.String("record_id", event.RID))
@@ -690,3 +1072,19 @@ func (s *RecordService) publishRecordEvent(event *database.RecordEvent) {
 			logger.String("event_type", event.EventType))
 	}
 }
+
+// changedValueKeys 更新前后值不同的字段（含系统字段）
+func changedValueKeys(previous, values map[string]interface{}) []string {
+	changed := make([]string, 0)
+	for key, value := range values {
+		if old, ok := previous[key]; !ok || !reflect.DeepEqual(old, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range previous {
+		if _, ok := values[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,551 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	recordValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/reminder"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+const (
+	// reminderBatchSize 每轮调度和每页回填处理的数量
+	reminderBatchSize = 200
+	// reminderSendingTimeout 发送中状态超过该时长视为实例崩溃，恢复为待发送
+	reminderSendingTimeout = 5 * time.Minute
+	// defaultReminderGrace 停机后补发提醒的默认宽限期
+	defaultReminderGrace = 24 * time.Hour
+)
+
+// ReminderService 记录提醒服务 ✨
+//
+// 设计考量：
+//   - 规则变更时回填整张表的提醒计划，记录变更/删除时只重新规划该记录
+//   - 提醒计划持久化在数据库中，调度器按触发时间扫描，重启后继续发送
+//   - 多实例部署时通过条件更新领取提醒，同一提醒只发送一次
+//   - 发送前重新读取记录并校验日期、过滤条件和接收人的行级读取权限
+type ReminderService struct {
+	ruleRepo      reminder.RuleRepository
+	reminderRepo  reminder.Repository
+	recordRepo    recordRepo.RecordRepository
+	fieldRepo     repository.FieldRepository
+	tableRepo     tableRepo.TableRepository
+	rowPermission *RowPermissionService
+	notifications notification.Service
+	grace         time.Duration
+}
+
+// NewReminderService 创建记录提醒服务
+func NewReminderService(
+	ruleRepo reminder.RuleRepository,
+	reminderRepo reminder.Repository,
+	recordRepo recordRepo.RecordRepository,
+	fieldRepo repository.FieldRepository,
+	tableRepo tableRepo.TableRepository,
+	rowPermission *RowPermissionService,
+	notifications notification.Service,
+	grace time.Duration,
+) *ReminderService {
+	if grace <= 0 {
+		grace = defaultReminderGrace
+	}
+	return &ReminderService{
+		ruleRepo:      ruleRepo,
+		reminderRepo:  reminderRepo,
+		recordRepo:    recordRepo,
+		fieldRepo:     fieldRepo,
+		tableRepo:     tableRepo,
+		rowPermission: rowPermission,
+		notifications: notifications,
+		grace:         grace,
+	}
+}
+
+// ==================== 规则管理 ====================
+
+// ListRules 列出表的提醒规则
+func (s *ReminderService) ListRules(ctx context.Context, tableID, userID string) ([]*dto.ReminderRuleResponse, error) {
+	if err := s.ensureRole(ctx, tableID, userID, permission.ActionTableRead); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	responses := make([]*dto.ReminderRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, dto.FromReminderRule(rule))
+	}
+	return responses, nil
+}
+
+// CreateRule 创建提醒规则，并为表中已有记录规划提醒
+func (s *ReminderService) CreateRule(ctx context.Context, tableID string, req dto.CreateReminderRuleRequest, userID string) (*dto.ReminderRuleResponse, error) {
+	if err := s.ensureRole(ctx, tableID, userID, permission.ActionAutomationUpdate); err != nil {
+		return nil, err
+	}
+
+	rule := reminder.NewRule(tableID, req.Name, req.DateFieldID, userID)
+	rule.Offset = req.Offset
+	if req.Unit != "" {
+		rule.Unit = reminder.OffsetUnit(req.Unit)
+	}
+	if req.Direction != "" {
+		rule.Direction = reminder.Direction(req.Direction)
+	}
+	if req.Timezone != "" {
+		rule.Timezone = req.Timezone
+	}
+	if len(req.Filter) > 0 {
+		filter, err := valueobject.NewFilter(req.Filter)
+		if err != nil {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+		}
+		rule.Filter = filter
+	}
+	rule.RecipientFieldID = req.RecipientFieldID
+	rule.RecipientUserIDs = req.RecipientUserIDs
+	rule.Message = strings.TrimSpace(req.Message)
+
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Save(ctx, rule); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if err := s.planTable(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	logger.Info("记录提醒规则已创建",
+		logger.String("table_id", tableID),
+		logger.String("rule_id", rule.ID))
+
+	return dto.FromReminderRule(rule), nil
+}
+
+// UpdateRule 更新提醒规则，并重新规划表中所有记录的提醒
+func (s *ReminderService) UpdateRule(ctx context.Context, tableID, ruleID string, req dto.UpdateReminderRuleRequest, userID string) (*dto.ReminderRuleResponse, error) {
+	if err := s.ensureRole(ctx, tableID, userID, permission.ActionAutomationUpdate); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.findRule(ctx, tableID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.DateFieldID != nil {
+		rule.DateFieldID = *req.DateFieldID
+	}
+	if req.Offset != nil {
+		rule.Offset = *req.Offset
+	}
+	if req.Unit != nil {
+		rule.Unit = reminder.OffsetUnit(*req.Unit)
+	}
+	if req.Direction != nil {
+		rule.Direction = reminder.Direction(*req.Direction)
+	}
+	if req.Timezone != nil {
+		rule.Timezone = *req.Timezone
+	}
+	if req.Filter != nil {
+		rule.Filter = nil
+		if len(req.Filter) > 0 {
+			if rule.Filter, err = valueobject.NewFilter(req.Filter); err != nil {
+				return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+			}
+		}
+	}
+	if req.RecipientFieldID != nil {
+		rule.RecipientFieldID = *req.RecipientFieldID
+	}
+	if req.RecipientUserIDs != nil {
+		rule.RecipientUserIDs = req.RecipientUserIDs
+	}
+	if req.Message != nil {
+		rule.Message = strings.TrimSpace(*req.Message)
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Save(ctx, rule); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if err := s.planTable(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return dto.FromReminderRule(rule), nil
+}
+
+// DeleteRule 删除提醒规则及其所有提醒计划
+func (s *ReminderService) DeleteRule(ctx context.Context, tableID, ruleID, userID string) error {
+	if err := s.ensureRole(ctx, tableID, userID, permission.ActionAutomationUpdate); err != nil {
+		return err
+	}
+
+	if _, err := s.findRule(ctx, tableID, ruleID); err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(ctx, ruleID); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if err := s.reminderRepo.DeleteByRule(ctx, ruleID); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	return nil
+}
+
+// ListRecordReminders 列出记录的提醒计划
+func (s *ReminderService) ListRecordReminders(ctx context.Context, tableID, recordID, userID string) ([]*dto.RecordReminderResponse, error) {
+	records, err := s.recordRepo.FindByIDs(ctx, tableID, []recordValueobject.RecordID{recordValueobject.NewRecordID(recordID)})
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if len(records) == 0 {
+		return nil, pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+	if !s.canRead(ctx, tableID, userID, RecordValues(records[0])) {
+		return nil, pkgerrors.ErrNotFound.WithDetails("记录不存在")
+	}
+
+	items, err := s.reminderRepo.FindByRecord(ctx, tableID, recordID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	responses := make([]*dto.RecordReminderResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, dto.FromRecordReminder(item))
+	}
+	return responses, nil
+}
+
+// ==================== 规划 ====================
+
+// PlanRecord 记录变更后重新规划提醒，记录删除时 values 传 nil
+// 作为记录变更钩子调用，失败只记录日志
+func (s *ReminderService) PlanRecord(ctx context.Context, tableID, recordID string, values map[string]interface{}) {
+	rules, err := s.ruleRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("规划记录提醒失败：查询规则出错",
+			logger.String("table_id", tableID),
+			logger.String("record_id", recordID),
+			logger.ErrorField(err))
+		return
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		if err := s.planOne(ctx, rule, recordID, values, now); err != nil {
+			logger.Warn("规划记录提醒失败",
+				logger.String("rule_id", rule.ID),
+				logger.String("record_id", recordID),
+				logger.ErrorField(err))
+		}
+	}
+}
+
+// planTable 为表中所有记录规划规则的提醒
+func (s *ReminderService) planTable(ctx context.Context, rule *reminder.Rule) error {
+	tableID := rule.TableID
+	now := time.Now()
+	for offset := 0; ; offset += reminderBatchSize {
+		records, _, err := s.recordRepo.List(ctx, recordRepo.RecordFilter{
+			TableID:  &tableID,
+			OrderBy:  "created_at",
+			OrderDir: "asc",
+			Limit:    reminderBatchSize,
+			Offset:   offset,
+		})
+		if err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+		}
+		for _, record := range records {
+			if err := s.planOne(ctx, rule, record.ID().String(), RecordValues(record), now); err != nil {
+				return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+			}
+		}
+		if len(records) < reminderBatchSize {
+			return nil
+		}
+	}
+}
+
+// planOne 规划单条记录在规则下的提醒
+func (s *ReminderService) planOne(ctx context.Context, rule *reminder.Rule, recordID string, values map[string]interface{}, now time.Time) error {
+	existing, err := s.reminderRepo.FindByRuleAndRecord(ctx, rule.ID, recordID)
+	if err != nil {
+		return err
+	}
+	planned := rule.Plan(existing, recordID, values, now)
+	if planned == nil {
+		return nil
+	}
+	return s.reminderRepo.Save(ctx, planned)
+}
+
+// ==================== 调度 ====================
+
+// Run 周期性发送到期提醒，直到 ctx 取消
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ProcessDue(ctx, time.Now()); err != nil {
+			logger.Warn("处理到期提醒失败", logger.ErrorField(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue 发送到期的提醒，返回本轮处理的数量
+func (s *ReminderService) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	if released, err := s.reminderRepo.ReleaseStale(ctx, now.Add(-reminderSendingTimeout)); err != nil {
+		return 0, err
+	} else if released > 0 {
+		logger.Warn("恢复未完成发送的提醒", logger.Int64("count", released))
+	}
+
+	due, err := s.reminderRepo.ListDue(ctx, now, reminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, item := range due {
+		claimed, err := s.reminderRepo.Claim(ctx, item.ID)
+		if err != nil {
+			return processed, err
+		}
+		if !claimed {
+			continue
+		}
+		item.Status = reminder.StatusSending
+		if err := s.fire(ctx, item, now); err != nil {
+			logger.Warn("发送记录提醒失败",
+				logger.String("reminder_id", item.ID),
+				logger.String("record_id", item.RecordID),
+				logger.ErrorField(err))
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// fire 发送一条已领取的提醒
+func (s *ReminderService) fire(ctx context.Context, item *reminder.Reminder, now time.Time) error {
+	rule, err := s.ruleRepo.FindByID(ctx, item.RuleID)
+	if err != nil {
+		return s.release(ctx, item, err)
+	}
+	if rule == nil {
+		item.Cancel()
+		return s.reminderRepo.Save(ctx, item)
+	}
+	if now.Sub(item.FireAt) > s.grace {
+		item.MarkMissed(fmt.Sprintf("scheduler was unavailable for more than %s", s.grace))
+		return s.reminderRepo.Save(ctx, item)
+	}
+
+	records, err := s.recordRepo.FindByIDs(ctx, item.TableID, []recordValueobject.RecordID{recordValueobject.NewRecordID(item.RecordID)})
+	if err != nil {
+		return s.release(ctx, item, err)
+	}
+	var values map[string]interface{}
+	if len(records) > 0 {
+		values = RecordValues(records[0])
+	}
+	// 钩子未覆盖的变更（如直接写库）在发送前兜底：日期变化则改期，不再满足条件则取消
+	if planned := rule.Plan(item, item.RecordID, values, now); planned != nil {
+		return s.reminderRepo.Save(ctx, planned)
+	}
+
+	title, content := s.renderReminder(ctx, rule, values)
+	var failures []string
+	for _, userID := range rule.Recipients(values) {
+		if !s.canRead(ctx, rule.TableID, userID, values) {
+			continue
+		}
+		_, err := s.notifications.CreateNotification(ctx, &notification.CreateNotificationRequest{
+			UserID:     userID,
+			Type:       notification.NotificationTypeReminder,
+			Title:      title,
+			Content:    content,
+			Priority:   notification.NotificationPriorityNormal,
+			SourceID:   item.RecordID,
+			SourceType: "record",
+			Data: map[string]interface{}{
+				"table_id":    rule.TableID,
+				"record_id":   item.RecordID,
+				"field_id":    rule.DateFieldID,
+				"rule_id":     rule.ID,
+				"reminder_id": item.ID,
+				"fire_at":     item.FireAt,
+			},
+		})
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", userID, err))
+		}
+	}
+
+	item.MarkSent(now)
+	item.LastError = strings.Join(failures, "; ")
+	return s.reminderRepo.Save(ctx, item)
+}
+
+// release 发送前出错时恢复为待发送，下一轮重试
+func (s *ReminderService) release(ctx context.Context, item *reminder.Reminder, cause error) error {
+	item.Status = reminder.StatusPending
+	item.LastError = cause.Error()
+	item.UpdatedAt = time.Now()
+	if err := s.reminderRepo.Save(ctx, item); err != nil {
+		return err
+	}
+	return cause
+}
+
+// renderReminder 生成提醒标题和内容，规则未配置内容时使用默认文案
+func (s *ReminderService) renderReminder(ctx context.Context, rule *reminder.Rule, values map[string]interface{}) (string, string) {
+	if rule.Message != "" {
+		return rule.Name, rule.Message
+	}
+
+	tableName, dateFieldName, recordName := "", rule.DateFieldID, ""
+	if table, err := s.tableRepo.GetByID(ctx, rule.TableID); err == nil && table != nil {
+		tableName = table.Name().String()
+	}
+	if fields, err := s.fieldRepo.FindByTableID(ctx, rule.TableID); err == nil {
+		for _, field := range fields {
+			if field.ID().String() == rule.DateFieldID {
+				dateFieldName = field.Name().String()
+			}
+			if field.IsPrimary() && values[field.ID().String()] != nil {
+				recordName = fmt.Sprint(values[field.ID().String()])
+			}
+		}
+	}
+	if recordName == "" {
+		recordName = fmt.Sprint(values["__id"])
+	}
+
+	due := ""
+	if t, ok := reminder.ParseDate(values[rule.DateFieldID], rule.Location()); ok {
+		due = t.In(rule.Location()).Format("2006-01-02 15:04")
+	}
+	return rule.Name, fmt.Sprintf("「%s」中的记录「%s」%s：%s", tableName, recordName, dateFieldName, due)
+}
+
+// ==================== 内部方法 ====================
+
+// validateRule 校验规则字段与接收人
+func (s *ReminderService) validateRule(ctx context.Context, rule *reminder.Rule) error {
+	if err := rule.Validate(); err != nil {
+		return pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	dateField, err := s.findField(ctx, rule.TableID, rule.DateFieldID)
+	if err != nil {
+		return err
+	}
+	if t := dateField.Type().String(); t != fieldValueobject.TypeDate && t != fieldValueobject.TypeDateTime {
+		return pkgerrors.ErrValidationFailed.WithDetails("提醒字段必须是日期字段")
+	}
+
+	if rule.RecipientFieldID != "" {
+		recipientField, err := s.findField(ctx, rule.TableID, rule.RecipientFieldID)
+		if err != nil {
+			return err
+		}
+		if recipientField.Type().String() != fieldValueobject.TypeUser {
+			return pkgerrors.ErrValidationFailed.WithDetails("接收人字段必须是用户字段")
+		}
+	}
+
+	for _, userID := range rule.RecipientUserIDs {
+		if err := s.ensureRole(ctx, rule.TableID, userID, permission.ActionRecordRead); err != nil {
+			return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("接收人 %s 不是该表的协作者", userID))
+		}
+	}
+	return nil
+}
+
+// findField 查找属于该表的字段
+func (s *ReminderService) findField(ctx context.Context, tableID, fieldID string) (*fieldEntity.Field, error) {
+	field, err := s.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(fieldID))
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if field == nil || field.TableID() != tableID {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("字段 %s 不存在", fieldID))
+	}
+	return field, nil
+}
+
+// canRead 接收人能否读取记录（角色 + 行级规则），未配置行级权限服务时不限制
+func (s *ReminderService) canRead(ctx context.Context, tableID, userID string, values map[string]interface{}) bool {
+	if s.rowPermission == nil {
+		return true
+	}
+	return s.rowPermission.CheckRecord(ctx, tableID, userID, permission.ActionRecordRead, values) == nil
+}
+
+// ensureRole 校验用户在表所属 Base 上的角色权限
+func (s *ReminderService) ensureRole(ctx context.Context, tableID, userID string, action permission.Action) error {
+	if s.rowPermission == nil {
+		return nil
+	}
+	role, err := s.rowPermission.ResolveRole(ctx, tableID, userID)
+	if err != nil {
+		return err
+	}
+	if !permission.HasRolePermission(role, action) {
+		return pkgerrors.ErrForbidden.WithDetails(fmt.Sprintf("当前角色没有 %s 权限", action))
+	}
+	return nil
+}
+
+// findRule 查找属于该表的规则
+func (s *ReminderService) findRule(ctx context.Context, tableID, ruleID string) (*reminder.Rule, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if rule == nil || rule.TableID != tableID {
+		return nil, pkgerrors.ErrNotFound.WithDetails(reminder.ErrRuleNotFound.Error())
+	}
+	return rule, nil
+}
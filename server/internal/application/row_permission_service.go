@@ -0,0 +1,426 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	baseEntity "github.com/easyspace-ai/luckdb/server/internal/domain/base/entity"
+	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
+	collaboratorEntity "github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// RowPermissionService 行级权限服务 ✨
+//
+// 设计考量：
+//   - 规则以 valueobject.Filter 表达，支持 @me / @myGroups 占位符
+//   - 先按协作者角色校验 RolePermissions，再用规则收窄行范围
+//   - 列表查询下推为 SQL 条件，单条校验和实时推送在内存中求值
+//   - 角色由 PermissionServiceV2 解析；实时推送时每次广播只加载一次表、Base 和协作者
+type RowPermissionService struct {
+	ruleRepo      permission.RowRuleRepository
+	permissions   *PermissionServiceV2
+	baseRepo      baseRepo.BaseRepository
+	tableRepo     tableRepo.TableRepository
+	groupResolver permission.GroupResolver
+}
+
+// NewRowPermissionService 创建行级权限服务
+func NewRowPermissionService(
+	ruleRepo permission.RowRuleRepository,
+	permissions *PermissionServiceV2,
+	baseRepo baseRepo.BaseRepository,
+	tableRepo tableRepo.TableRepository,
+	groupResolver permission.GroupResolver,
+) *RowPermissionService {
+	return &RowPermissionService{
+		ruleRepo:      ruleRepo,
+		permissions:   permissions,
+		baseRepo:      baseRepo,
+		tableRepo:     tableRepo,
+		groupResolver: groupResolver,
+	}
+}
+
+// ==================== 规则管理 ====================
+
+// ListRules 列出表的行级规则
+func (s *RowPermissionService) ListRules(ctx context.Context, tableID, userID string) ([]*dto.RowRuleResponse, error) {
+	if err := s.ensureCanManage(ctx, tableID, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	responses := make([]*dto.RowRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, dto.FromRowRule(rule))
+	}
+	return responses, nil
+}
+
+// CreateRule 创建行级规则
+func (s *RowPermissionService) CreateRule(ctx context.Context, tableID string, req dto.CreateRowRuleRequest, userID string) (*dto.RowRuleResponse, error) {
+	if err := s.ensureCanManage(ctx, tableID, userID); err != nil {
+		return nil, err
+	}
+
+	filter, err := valueobject.NewFilter(req.Filter)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	rule, err := permission.NewRowRule(tableID, req.Name, toRoles(req.Roles), toActions(req.Actions), filter, userID)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	if err := s.ruleRepo.Save(ctx, rule); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	logger.Info("行级规则已创建",
+		logger.String("table_id", tableID),
+		logger.String("rule_id", rule.ID))
+
+	return dto.FromRowRule(rule), nil
+}
+
+// UpdateRule 更新行级规则
+func (s *RowPermissionService) UpdateRule(ctx context.Context, tableID, ruleID string, req dto.UpdateRowRuleRequest, userID string) (*dto.RowRuleResponse, error) {
+	if err := s.ensureCanManage(ctx, tableID, userID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.findRule(ctx, tableID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, roles, actions, filter, enabled := rule.Name, rule.Roles, rule.Actions, rule.Filter, rule.Enabled
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.Roles != nil {
+		roles = toRoles(req.Roles)
+	}
+	if req.Actions != nil {
+		actions = toActions(req.Actions)
+	}
+	if req.Filter != nil {
+		if filter, err = valueobject.NewFilter(req.Filter); err != nil {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+		}
+	}
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	if err := rule.Update(name, roles, actions, filter, enabled); err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	if err := s.ruleRepo.Save(ctx, rule); err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	return dto.FromRowRule(rule), nil
+}
+
+// DeleteRule 删除行级规则
+func (s *RowPermissionService) DeleteRule(ctx context.Context, tableID, ruleID, userID string) error {
+	if err := s.ensureCanManage(ctx, tableID, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.findRule(ctx, tableID, ruleID); err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(ctx, ruleID); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	return nil
+}
+
+// ==================== 权限求值 ====================
+
+// ResolveAccess 计算用户对表中记录某操作的行级访问范围
+func (s *RowPermissionService) ResolveAccess(ctx context.Context, tableID, userID string, action permission.Action) (*permission.RowAccess, error) {
+	rules, err := s.ruleRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+
+	// 快速路径：表上没有启用的规则时不做行级限制
+	if !hasEnabledRule(rules) {
+		return &permission.RowAccess{Allowed: true, Unrestricted: true}, nil
+	}
+
+	subject, err := s.resolveSubject(ctx, tableID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return permission.EvaluateRowAccess(subject, action, rules), nil
+}
+
+// ResolveRole 解析用户在表所属 Base 上的角色（含部门协作者），无角色时返回空字符串
+func (s *RowPermissionService) ResolveRole(ctx context.Context, tableID, userID string) (permission.Role, error) {
+	subject, err := s.resolveSubject(ctx, tableID, userID)
+	if err != nil {
+		return "", err
+	}
+	return subject.Role, nil
+}
+
+// ReadScopes 获取用户可读取的行范围（用于列表、统计等查询下推）
+func (s *RowPermissionService) ReadScopes(ctx context.Context, tableID, userID string) ([][]*valueobject.Filter, error) {
+	access, err := s.ResolveAccess(ctx, tableID, userID, permission.ActionRecordRead)
+	if err != nil {
+		return nil, err
+	}
+	if !access.Allowed {
+		return nil, pkgerrors.ErrForbidden.WithDetails("无权读取该表的记录")
+	}
+	return access.Scopes, nil
+}
+
+// CheckRecord 校验用户能否对记录执行操作
+// 读取不到的记录返回 404，避免泄露记录是否存在
+func (s *RowPermissionService) CheckRecord(ctx context.Context, tableID, userID string, action permission.Action, values map[string]interface{}) error {
+	access, err := s.ResolveAccess(ctx, tableID, userID, action)
+	if err != nil {
+		return err
+	}
+	if !access.Allowed {
+		return pkgerrors.ErrForbidden.WithDetails(fmt.Sprintf("当前角色没有 %s 权限", action))
+	}
+	if access.Match(values) {
+		return nil
+	}
+
+	if action != permission.ActionRecordRead && action != permission.ActionRecordCreate {
+		if readAccess, err := s.ResolveAccess(ctx, tableID, userID, permission.ActionRecordRead); err == nil && readAccess.Match(values) {
+			return pkgerrors.ErrForbidden.WithDetails("该记录不在可操作的行范围内")
+		}
+	}
+	if action == permission.ActionRecordCreate {
+		return pkgerrors.ErrForbidden.WithDetails("新记录不在可创建的行范围内")
+	}
+	return pkgerrors.ErrNotFound.WithDetails("记录不存在")
+}
+
+// RecipientFilter 生成实时推送的接收者过滤函数
+// 返回 nil 表示表上没有行级规则，无需过滤
+func (s *RowPermissionService) RecipientFilter(ctx context.Context, tableID string, values map[string]interface{}) func(userID string) bool {
+	rules, err := s.ruleRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("加载行级规则失败，本次变更不做实时推送",
+			logger.String("table_id", tableID),
+			logger.ErrorField(err))
+	} else if !hasEnabledRule(rules) {
+		return nil
+	}
+
+	subjects := s.recipientSubjects(ctx, tableID, rules)
+	return func(userID string) bool {
+		subject, ok := subjects(userID)
+		if !ok {
+			return false
+		}
+		return permission.EvaluateRowAccess(subject, permission.ActionRecordRead, rules).Match(values)
+	}
+}
+
+// UnrestrictedReaderFilter 生成"可读取全表记录"的用户过滤函数（用于推送全表聚合结果）
+// 返回 nil 表示表上没有行级规则，所有订阅者都可接收
+func (s *RowPermissionService) UnrestrictedReaderFilter(ctx context.Context, tableID string) func(userID string) bool {
+	rules, err := s.ruleRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("加载行级规则失败，本次统计不推送结果",
+			logger.String("table_id", tableID),
+			logger.ErrorField(err))
+	} else if !hasEnabledRule(rules) {
+		return nil
+	}
+
+	subjects := s.recipientSubjects(ctx, tableID, rules)
+	return func(userID string) bool {
+		subject, ok := subjects(userID)
+		if !ok {
+			return false
+		}
+		access := permission.EvaluateRowAccess(subject, permission.ActionRecordRead, rules)
+		return access.Allowed && access.Unrestricted
+	}
+}
+
+// RecordValues 构建行级规则求值所需的记录值（字段值 + 系统列）
+func RecordValues(record *entity.Record) map[string]interface{} {
+	values := record.Data().ToMap()
+	result := make(map[string]interface{}, len(values)+6)
+	for k, v := range values {
+		result[k] = v
+	}
+	result["__id"] = record.ID().String()
+	result["__created_by"] = record.CreatedBy()
+	result["__last_modified_by"] = record.UpdatedBy()
+	result["__created_time"] = record.CreatedAt()
+	result["__last_modified_time"] = record.UpdatedAt()
+	return result
+}
+
+// ==================== 内部方法 ====================
+
+// resolveSubject 解析用户角色与所属部门
+func (s *RowPermissionService) resolveSubject(ctx context.Context, tableID, userID string) (permission.RowSubject, error) {
+	base, err := s.tableBase(ctx, tableID)
+	if err != nil {
+		return permission.RowSubject{UserID: userID}, err
+	}
+	groups := s.userGroups(ctx, userID)
+	return permission.RowSubject{
+		UserID:   userID,
+		GroupIDs: groups,
+		Role:     toPermissionRole(s.permissions.BaseRole(ctx, base, userID, groups)),
+	}, nil
+}
+
+// recipientSubjects 为一次推送准备接收者的主体解析
+// 表、Base 和协作者角色只加载一次，之后每个接收者只解析所属部门；加载失败或表上规则加载失败时不推送
+func (s *RowPermissionService) recipientSubjects(ctx context.Context, tableID string, rules []*permission.RowRule) func(userID string) (permission.RowSubject, bool) {
+	var roles *BaseRoleResolver
+	if rules != nil {
+		base, err := s.tableBase(ctx, tableID)
+		if err == nil {
+			roles, err = s.permissions.NewBaseRoleResolver(ctx, base)
+		}
+		if err != nil {
+			logger.Warn("加载协作者角色失败，本次变更不做实时推送",
+				logger.String("table_id", tableID),
+				logger.ErrorField(err))
+		}
+	}
+
+	return func(userID string) (permission.RowSubject, bool) {
+		if roles == nil {
+			return permission.RowSubject{}, false
+		}
+		groups := s.userGroups(ctx, userID)
+		return permission.RowSubject{
+			UserID:   userID,
+			GroupIDs: groups,
+			Role:     toPermissionRole(roles.Role(userID, groups)),
+		}, true
+	}
+}
+
+// tableBase 查找表所属的 Base
+func (s *RowPermissionService) tableBase(ctx context.Context, tableID string) (*baseEntity.Base, error) {
+	table, err := s.tableRepo.GetByID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找表失败: %v", err))
+	}
+	if table == nil {
+		return nil, pkgerrors.ErrTableNotFound.WithDetails(tableID)
+	}
+
+	base, err := s.baseRepo.FindByID(ctx, table.BaseID())
+	if err != nil || base == nil {
+		return nil, pkgerrors.ErrBaseNotFound.WithDetails(table.BaseID())
+	}
+	return base, nil
+}
+
+// userGroups 解析用户所属部门（用于 @myGroups 和部门协作者），失败时按不属于任何部门处理
+func (s *RowPermissionService) userGroups(ctx context.Context, userID string) []string {
+	if s.groupResolver == nil {
+		return nil
+	}
+	groups, err := s.groupResolver.ResolveUserGroups(ctx, userID)
+	if err != nil {
+		logger.Warn("解析用户部门失败", logger.String("user_id", userID), logger.ErrorField(err))
+	}
+	return groups
+}
+
+// ensureCanManage 只有可配置权限矩阵的角色可以管理行级规则
+func (s *RowPermissionService) ensureCanManage(ctx context.Context, tableID, userID string) error {
+	base, err := s.tableBase(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	role := toPermissionRole(s.permissions.BaseRole(ctx, base, userID, nil))
+	if !permission.HasRolePermission(role, permission.ActionBaseAuthorityMatrixConfig) {
+		return pkgerrors.ErrForbidden.WithDetails("只有所有者或创建者可以管理行级权限")
+	}
+	return nil
+}
+
+// findRule 查找属于该表的规则
+func (s *RowPermissionService) findRule(ctx context.Context, tableID, ruleID string) (*permission.RowRule, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(err.Error())
+	}
+	if rule == nil || rule.TableID != tableID {
+		return nil, pkgerrors.ErrNotFound.WithDetails(permission.ErrRowRuleNotFound.Error())
+	}
+	return rule, nil
+}
+
+// toPermissionRole 协作者角色映射到权限矩阵角色（评论者按查看者处理）
+func toPermissionRole(role collaboratorEntity.RoleName) permission.Role {
+	switch role {
+	case collaboratorEntity.RoleOwner:
+		return permission.RoleOwner
+	case collaboratorEntity.RoleCreator:
+		return permission.RoleCreator
+	case collaboratorEntity.RoleEditor:
+		return permission.RoleEditor
+	case collaboratorEntity.RoleViewer, collaboratorEntity.RoleCommenter:
+		return permission.RoleViewer
+	}
+	return ""
+}
+
+func hasEnabledRule(rules []*permission.RowRule) bool {
+	for _, rule := range rules {
+		if rule.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func toRoles(values []string) []permission.Role {
+	if values == nil {
+		return nil
+	}
+	roles := make([]permission.Role, len(values))
+	for i, v := range values {
+		roles[i] = permission.Role(v)
+	}
+	return roles
+}
+
+func toActions(values []string) []permission.Action {
+	if values == nil {
+		return nil
+	}
+	actions := make([]permission.Action, len(values))
+	for i, v := range values {
+		actions[i] = permission.Action(v)
+	}
+	return actions
+}
@@ -0,0 +1,430 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/openapi"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// 表格文档中的记录组件名
+const (
+	recordFieldsSchema      = "RecordFields"      // 响应中的 data：全部字段，计算字段只读
+	recordFieldsInputSchema = "RecordFieldsInput" // 创建记录的 data：可写字段，必填字段为 required
+	recordFieldsPatchSchema = "RecordFieldsPatch" // 更新记录的 data：可写字段，均为可选
+	recordSchema            = "Record"
+)
+
+// TableOpenAPIService 按表字段生成记录接口的 OpenAPI 文档 ✨
+//
+// 通用文档中记录的 data 是任意对象；这里按字段类型给出每个字段的值结构，
+// 属性名为字段 ID（请求中也接受字段名），计算字段只出现在响应中。
+// 只包含当前用户角色可以执行的记录操作
+type TableOpenAPIService struct {
+	tableRepo     tableRepo.TableRepository
+	fieldRepo     repository.FieldRepository
+	rowPermission *RowPermissionService
+}
+
+// NewTableOpenAPIService 创建表格文档服务
+func NewTableOpenAPIService(
+	tableRepo tableRepo.TableRepository,
+	fieldRepo repository.FieldRepository,
+	rowPermission *RowPermissionService,
+) *TableOpenAPIService {
+	return &TableOpenAPIService{
+		tableRepo:     tableRepo,
+		fieldRepo:     fieldRepo,
+		rowPermission: rowPermission,
+	}
+}
+
+// BuildSpec 生成表格的记录接口文档
+func (s *TableOpenAPIService) BuildSpec(ctx context.Context, tableID, userID, version string) (*openapi.Document, error) {
+	role := permission.RoleOwner
+	if s.rowPermission != nil {
+		resolved, err := s.rowPermission.ResolveRole(ctx, tableID, userID)
+		if err != nil {
+			return nil, err
+		}
+		role = resolved
+	}
+	if !permission.HasRolePermission(role, permission.ActionTableRead) {
+		return nil, pkgerrors.ErrForbidden.WithDetails(fmt.Sprintf("当前角色没有 %s 权限", permission.ActionTableRead))
+	}
+
+	table, err := s.tableRepo.GetByID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+	if table == nil {
+		return nil, pkgerrors.ErrTableNotFound.WithDetails(tableID)
+	}
+
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseQuery.WithDetails(err.Error())
+	}
+
+	return buildTableSpec(table, fields, role, version), nil
+}
+
+// buildTableSpec 根据表格、字段和角色生成文档
+func buildTableSpec(table *tableEntity.Table, fields []*fieldEntity.Field, role permission.Role, version string) *openapi.Document {
+	tableID := table.ID().String()
+	description := fmt.Sprintf("表格「%s」的记录接口。记录 data 的属性名为字段 ID，请求中也可以使用字段名；计算字段只读。", table.Name().String())
+	if table.Description() != nil && *table.Description() != "" {
+		description += "\n\n" + *table.Description()
+	}
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       table.Name().String(),
+		Version:     version,
+		Description: description,
+	})
+	addRecordSchemas(doc, tableID, fields)
+
+	can := func(action permission.Action) bool {
+		return permission.HasRolePermission(role, action)
+	}
+	recordsPath := "/api/v1/tables/" + tableID + "/records"
+	recordPath := recordsPath + "/{recordId}"
+	batchPath := recordsPath + "/batch"
+	recordID := &openapi.Parameter{Name: "recordId", In: "path", Required: true, Schema: &openapi.Schema{Type: openapi.TypeNames("string")}}
+
+	if can(permission.ActionRecordRead) {
+		doc.AddOperation(http.MethodGet, recordsPath, tableOperation(doc, "listRecords", "列出记录",
+			[]*openapi.Parameter{
+				{Name: "limit", In: "query", Description: "每页数量（默认 100）", Schema: &openapi.Schema{Type: openapi.TypeNames("integer"), Minimum: openapi.Float(1)}},
+				{Name: "offset", In: "query", Description: "偏移量", Schema: &openapi.Schema{Type: openapi.TypeNames("integer"), Minimum: openapi.Float(0)}},
+			}, nil,
+			&openapi.Schema{AllOf: []*openapi.Schema{
+				doc.Schemas().Schema(openapi.TypeOf[response.PaginatedData]()),
+				{Type: openapi.TypeNames("object"), Properties: map[string]*openapi.Schema{
+					"list": {Type: openapi.TypeNames("array"), Items: openapi.Ref(recordSchema)},
+				}},
+			}},
+			http.StatusForbidden, http.StatusNotFound))
+		doc.AddOperation(http.MethodGet, recordPath, tableOperation(doc, "getRecord", "获取记录",
+			[]*openapi.Parameter{recordID}, nil, openapi.Ref(recordSchema),
+			http.StatusForbidden, http.StatusNotFound))
+	}
+
+	if can(permission.ActionRecordCreate) {
+		doc.AddOperation(http.MethodPost, recordsPath, tableOperation(doc, "createRecord", "创建记录",
+			nil, &openapi.Schema{
+				Type:     openapi.TypeNames("object"),
+				Required: []string{"tableId", "data"},
+				Properties: map[string]*openapi.Schema{
+					"tableId": {Type: openapi.TypeNames("string"), Enum: []interface{}{tableID}},
+					"data":    openapi.Ref(recordFieldsInputSchema),
+				},
+			}, openapi.Ref(recordSchema),
+			http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound))
+		doc.AddOperation(http.MethodPost, batchPath, tableOperation(doc, "batchCreateRecords", "批量创建记录",
+			nil, &openapi.Schema{
+				Type:     openapi.TypeNames("object"),
+				Required: []string{"records"},
+				Properties: map[string]*openapi.Schema{
+					"records": {Type: openapi.TypeNames("array"), MaxItems: openapi.Int(1000), Items: &openapi.Schema{
+						Type:       openapi.TypeNames("object"),
+						Required:   []string{"fields"},
+						Properties: map[string]*openapi.Schema{"fields": openapi.Ref(recordFieldsInputSchema)},
+					}},
+				},
+			}, batchRecordsSchema(doc, openapi.TypeOf[dto.BatchCreateRecordResponse]()),
+			http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound))
+	}
+
+	if can(permission.ActionRecordUpdate) {
+		doc.AddOperation(http.MethodPatch, recordPath, tableOperation(doc, "updateRecord", "更新记录",
+			[]*openapi.Parameter{recordID}, &openapi.Schema{
+				Type:     openapi.TypeNames("object"),
+				Required: []string{"data"},
+				Properties: map[string]*openapi.Schema{
+					"data":    openapi.Ref(recordFieldsPatchSchema),
+					"version": {Type: openapi.TypeNames("integer"), Description: "记录版本号，用于乐观锁"},
+				},
+			}, openapi.Ref(recordSchema),
+			http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict))
+		doc.AddOperation(http.MethodPatch, batchPath, tableOperation(doc, "batchUpdateRecords", "批量更新记录",
+			nil, &openapi.Schema{
+				Type:     openapi.TypeNames("object"),
+				Required: []string{"records"},
+				Properties: map[string]*openapi.Schema{
+					"records": {Type: openapi.TypeNames("array"), MinItems: openapi.Int(1), MaxItems: openapi.Int(1000), Items: &openapi.Schema{
+						Type:     openapi.TypeNames("object"),
+						Required: []string{"id", "fields"},
+						Properties: map[string]*openapi.Schema{
+							"id":     {Type: openapi.TypeNames("string")},
+							"fields": openapi.Ref(recordFieldsPatchSchema),
+						},
+					}},
+				},
+			}, batchRecordsSchema(doc, openapi.TypeOf[dto.BatchUpdateRecordResponse]()),
+			http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound))
+	}
+
+	if can(permission.ActionRecordDelete) {
+		doc.AddOperation(http.MethodDelete, recordPath, tableOperation(doc, "deleteRecord", "删除记录",
+			[]*openapi.Parameter{recordID}, nil, nil,
+			http.StatusForbidden, http.StatusNotFound))
+		doc.AddOperation(http.MethodDelete, batchPath, tableOperation(doc, "batchDeleteRecords", "批量删除记录",
+			nil, doc.Schemas().Schema(openapi.TypeOf[dto.BatchDeleteRecordRequest]()),
+			doc.Schemas().Schema(openapi.TypeOf[dto.BatchDeleteRecordResponse]()),
+			http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound))
+	}
+
+	return doc
+}
+
+// tableOperation 生成记录操作，data 为 nil 时响应不约束 data
+func tableOperation(doc *openapi.Document, id, summary string, params []*openapi.Parameter, body, data *openapi.Schema, failures ...int) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: id,
+		Summary:     summary,
+		Tags:        []string{"Record"},
+		Parameters:  params,
+		Responses:   map[string]*openapi.Response{"200": doc.SuccessResponse("", data)},
+	}
+	if body != nil {
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]*openapi.MediaType{"application/json": {Schema: body}},
+		}
+	}
+	openapi.RequireAuth(op, false)
+	for _, status := range append(failures, http.StatusUnauthorized, http.StatusInternalServerError) {
+		op.Responses[fmt.Sprint(status)] = doc.ErrorResponse(status)
+	}
+	return op
+}
+
+// batchRecordsSchema 批量响应，records 使用本表的记录结构
+func batchRecordsSchema(doc *openapi.Document, responseType reflect.Type) *openapi.Schema {
+	return &openapi.Schema{AllOf: []*openapi.Schema{
+		doc.Schemas().Schema(responseType),
+		{Type: openapi.TypeNames("object"), Properties: map[string]*openapi.Schema{
+			"records": {Type: openapi.TypeNames("array"), Items: openapi.Ref(recordSchema)},
+		}},
+	}}
+}
+
+// addRecordSchemas 注册记录相关组件
+func addRecordSchemas(doc *openapi.Document, tableID string, fields []*fieldEntity.Field) {
+	sorted := make([]*fieldEntity.Field, len(fields))
+	copy(sorted, fields)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order() < sorted[j].Order() })
+
+	output := &openapi.Schema{Type: openapi.TypeNames("object"), Properties: make(map[string]*openapi.Schema)}
+	input := &openapi.Schema{Type: openapi.TypeNames("object"), Properties: make(map[string]*openapi.Schema)}
+	patch := &openapi.Schema{Type: openapi.TypeNames("object"), Properties: make(map[string]*openapi.Schema)}
+
+	for _, field := range sorted {
+		if field.IsDeleted() {
+			continue
+		}
+		id := field.ID().String()
+		readOnly := isReadOnlyField(field)
+
+		value := fieldValueSchema(doc, field, true)
+		value.ReadOnly = readOnly
+		output.Properties[id] = value
+		if readOnly {
+			continue
+		}
+
+		input.Properties[id] = fieldValueSchema(doc, field, false)
+		patch.Properties[id] = fieldValueSchema(doc, field, false)
+		if field.IsRequired() {
+			input.Required = append(input.Required, id)
+		}
+	}
+
+	components := doc.Components.Schemas
+	components[recordFieldsSchema] = output
+	components[recordFieldsInputSchema] = input
+	components[recordFieldsPatchSchema] = patch
+	components[recordSchema] = &openapi.Schema{AllOf: []*openapi.Schema{
+		doc.Schemas().Schema(openapi.TypeOf[dto.RecordResponse]()),
+		{Type: openapi.TypeNames("object"), Properties: map[string]*openapi.Schema{
+			"tableId": {Type: openapi.TypeNames("string"), Enum: []interface{}{tableID}},
+			"data":    openapi.Ref(recordFieldsSchema),
+		}},
+	}}
+}
+
+// isReadOnlyField 计算字段、系统字段和按钮字段不能写入
+func isReadOnlyField(field *fieldEntity.Field) bool {
+	if field.IsComputed() {
+		return true
+	}
+	switch field.Type().String() {
+	case fieldValueobject.TypeFormula, fieldValueobject.TypeRollup, fieldValueobject.TypeLookup,
+		fieldValueobject.TypeCount, fieldValueobject.TypeAutoNumber,
+		fieldValueobject.TypeCreatedTime, fieldValueobject.TypeCreatedBy,
+		fieldValueobject.TypeLastModifiedTime, fieldValueobject.TypeLastModifiedBy,
+		fieldValueobject.TypeAI, fieldValueobject.TypeButton:
+		return true
+	}
+	return false
+}
+
+// fieldValueSchema 字段值的 Schema；output 为 true 时描述响应中的值，否则描述请求中可接受的值
+func fieldValueSchema(doc *openapi.Document, field *fieldEntity.Field, output bool) *openapi.Schema {
+	options := field.Options()
+	if options == nil {
+		options = fieldValueobject.NewFieldOptions()
+	}
+	str := func() *openapi.Schema { return &openapi.Schema{Type: openapi.TypeNames("string")} }
+
+	var schema *openapi.Schema
+	switch field.Type().String() {
+	case fieldValueobject.TypeText, fieldValueobject.TypeSingleLineText, fieldValueobject.TypeLongText, fieldValueobject.TypePhone:
+		schema = str()
+	case fieldValueobject.TypeEmail:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Format: "email"}
+	case fieldValueobject.TypeURL:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Format: "uri"}
+
+	case fieldValueobject.TypeNumber, fieldValueobject.TypePercent, fieldValueobject.TypeCurrency:
+		schema = &openapi.Schema{Type: openapi.TypeNames("number")}
+		if number := options.Number; number != nil {
+			if min := firstIntPtr(number.MinValue, number.Min); min != nil {
+				schema.Minimum = openapi.Float(float64(*min))
+			}
+			if max := firstIntPtr(number.MaxValue, number.Max); max != nil {
+				schema.Maximum = openapi.Float(float64(*max))
+			}
+		}
+	case fieldValueobject.TypeRating:
+		schema = &openapi.Schema{Type: openapi.TypeNames("integer"), Minimum: openapi.Float(0)}
+		if options.Rating != nil && options.Rating.Max > 0 {
+			schema.Maximum = openapi.Float(float64(options.Rating.Max))
+		}
+	case fieldValueobject.TypeDuration:
+		schema = &openapi.Schema{Type: openapi.TypeNames("number"), Description: "时长（秒）"}
+
+	case fieldValueobject.TypeBoolean, fieldValueobject.TypeCheckbox:
+		schema = &openapi.Schema{Type: openapi.TypeNames("boolean")}
+	case fieldValueobject.TypeDate:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Description: "ISO 8601 日期"}
+	case fieldValueobject.TypeDateTime:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Format: "date-time"}
+
+	case fieldValueobject.TypeSelect, fieldValueobject.TypeSingleSelect:
+		schema = choiceSchema(options)
+	case fieldValueobject.TypeMultipleSelect:
+		schema = &openapi.Schema{Type: openapi.TypeNames("array"), Items: choiceSchema(options)}
+
+	case fieldValueobject.TypeUser:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Description: "用户 ID"}
+		if options.User != nil && options.User.IsMultiple {
+			schema = &openapi.Schema{Type: openapi.TypeNames("array"), Items: schema}
+		}
+	case fieldValueobject.TypeLink:
+		schema = linkSchema(options, output)
+	case fieldValueobject.TypeAttachment:
+		schema = &openapi.Schema{Type: openapi.TypeNames("array"), Items: doc.Schemas().Schema(openapi.TypeOf[attachment.AttachmentItem]())}
+
+	case fieldValueobject.TypeAutoNumber, fieldValueobject.TypeCount:
+		schema = &openapi.Schema{Type: openapi.TypeNames("integer")}
+	case fieldValueobject.TypeRollup:
+		schema = &openapi.Schema{Type: openapi.TypeNames("number")}
+	case fieldValueobject.TypeCreatedTime, fieldValueobject.TypeLastModifiedTime:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Format: "date-time"}
+	case fieldValueobject.TypeCreatedBy, fieldValueobject.TypeLastModifiedBy:
+		schema = &openapi.Schema{Type: openapi.TypeNames("string"), Description: "用户 ID"}
+	case fieldValueobject.TypeLookup:
+		schema = &openapi.Schema{Type: openapi.TypeNames("array")}
+	default:
+		// formula、ai、button 等结果类型不固定
+		schema = &openapi.Schema{}
+	}
+
+	schema.Title = field.Name().String()
+	description := field.Type().String()
+	if field.Description() != nil && *field.Description() != "" {
+		description += "：" + *field.Description()
+	}
+	if schema.Description != "" {
+		description += "（" + schema.Description + "）"
+	}
+	schema.Description = description
+
+	// 非必填字段可以清空
+	if !output && !field.IsRequired() && len(schema.Type) == 1 {
+		schema.Type = append(schema.Type, "null")
+		if schema.Enum != nil {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+	return schema
+}
+
+// choiceSchema 选择项名称
+func choiceSchema(options *fieldValueobject.FieldOptions) *openapi.Schema {
+	schema := &openapi.Schema{Type: openapi.TypeNames("string")}
+	if options.Select != nil && len(options.Select.Choices) > 0 {
+		for _, choice := range options.Select.Choices {
+			schema.Enum = append(schema.Enum, choice.Name)
+		}
+		if !options.Select.PreventAutoNewOptions {
+			// 允许写入新选项时不限制取值，只列出已有选项
+			schema.Examples, schema.Enum = schema.Enum, nil
+		}
+	}
+	return schema
+}
+
+// linkSchema 关联字段：响应为 {id, title}，请求接受记录 ID、{id} 或它们的数组
+func linkSchema(options *fieldValueobject.FieldOptions, output bool) *openapi.Schema {
+	multiple := options.Link == nil || options.Link.UsesJunctionTable()
+	description := "关联记录"
+	if options.Link != nil && options.Link.LinkedTableID != "" {
+		description += "（表 " + options.Link.LinkedTableID + "）"
+	}
+
+	ref := &openapi.Schema{
+		Type:       openapi.TypeNames("object"),
+		Required:   []string{"id"},
+		Properties: map[string]*openapi.Schema{"id": {Type: openapi.TypeNames("string")}},
+	}
+	if output {
+		ref.Properties["title"] = &openapi.Schema{Type: openapi.TypeNames("string")}
+		if multiple {
+			return &openapi.Schema{Type: openapi.TypeNames("array"), Items: ref, Description: description}
+		}
+		return &openapi.Schema{Type: openapi.TypeNames("object"), Required: ref.Required, Properties: ref.Properties, Description: description}
+	}
+
+	single := []*openapi.Schema{{Type: openapi.TypeNames("string"), Description: "记录 ID"}, ref}
+	if !multiple {
+		return &openapi.Schema{OneOf: single, Description: description}
+	}
+	return &openapi.Schema{OneOf: append(single, &openapi.Schema{
+		Type:  openapi.TypeNames("array"),
+		Items: &openapi.Schema{OneOf: single},
+	}), Description: description}
+}
+
+func firstIntPtr(values ...*int) *int {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/table/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/openapi"
+)
+
+// newSpecField 创建测试字段
+func newSpecField(t *testing.T, id, name, fieldType string, order float64, options *fieldValueobject.FieldOptions) *fieldEntity.Field {
+	t.Helper()
+	fieldName, err := fieldValueobject.NewFieldName(name)
+	require.NoError(t, err)
+	typ, err := fieldValueobject.NewFieldType(fieldType)
+	require.NoError(t, err)
+	if options == nil {
+		options = fieldValueobject.NewFieldOptions()
+	}
+	dbName, err := fieldValueobject.NewDBFieldName(fieldName)
+	require.NoError(t, err)
+	now := time.Now()
+	return fieldEntity.ReconstructField(fieldValueobject.NewFieldID(id), "tbl_test", fieldName, typ,
+		dbName, "", options, order, 1, "usr_test", now, now)
+}
+
+func TestBuildTableSpec(t *testing.T) {
+	tableName, err := tableValueobject.NewTableName("任务")
+	require.NoError(t, err)
+	table, err := tableEntity.NewTable("bse_test", tableName, "usr_test")
+	require.NoError(t, err)
+	tableID := table.ID().String()
+
+	status := fieldValueobject.NewFieldOptions()
+	status.Select = &fieldValueobject.SelectOptions{
+		Choices:               []fieldValueobject.SelectChoice{{ID: "c1", Name: "进行中"}, {ID: "c2", Name: "完成"}},
+		PreventAutoNewOptions: true,
+	}
+	title := newSpecField(t, "fld_title", "标题", fieldValueobject.TypeSingleLineText, 1, nil)
+	require.NoError(t, title.SetRequired(true))
+	fields := []*fieldEntity.Field{
+		newSpecField(t, "fld_total", "合计", fieldValueobject.TypeFormula, 3, nil),
+		newSpecField(t, "fld_status", "状态", fieldValueobject.TypeSingleSelect, 2, status),
+		title,
+	}
+
+	doc := buildTableSpec(table, fields, permission.RoleOwner, "1.0")
+	schemas := doc.Components.Schemas
+
+	// 响应包含全部字段，计算字段只读
+	output := schemas[recordFieldsSchema]
+	require.Len(t, output.Properties, 3)
+	assert.True(t, output.Properties["fld_total"].ReadOnly)
+	assert.Equal(t, "状态", output.Properties["fld_status"].Title)
+	assert.Equal(t, []interface{}{"进行中", "完成"}, output.Properties["fld_status"].Enum)
+
+	// 请求只包含可写字段，必填字段为 required，可选字段可以清空
+	input := schemas[recordFieldsInputSchema]
+	assert.NotContains(t, input.Properties, "fld_total")
+	assert.Equal(t, []string{"fld_title"}, input.Required)
+	assert.Equal(t, openapi.SchemaType{"string"}, input.Properties["fld_title"].Type)
+	assert.Equal(t, openapi.SchemaType{"string", "null"}, input.Properties["fld_status"].Type)
+	assert.Equal(t, []interface{}{"进行中", "完成", nil}, input.Properties["fld_status"].Enum)
+
+	// 所有者可以执行全部记录操作，路径使用具体的表 ID
+	recordsPath := "/api/v1/tables/" + tableID + "/records"
+	require.Contains(t, doc.Paths, recordsPath)
+	assert.Contains(t, *doc.Paths[recordsPath], "get")
+	assert.Contains(t, *doc.Paths[recordsPath], "post")
+	assert.Contains(t, *doc.Paths[recordsPath+"/{recordId}"], "delete")
+	assert.Equal(t, "#/components/responses/Error409", (*doc.Paths[recordsPath+"/{recordId}"])["patch"].Responses["409"].Ref)
+
+	// 查看者只有读取操作
+	viewer := buildTableSpec(table, fields, permission.RoleViewer, "1.0")
+	assert.Equal(t, []string{"get"}, methods(*viewer.Paths[recordsPath]))
+	assert.NotContains(t, viewer.Paths, recordsPath+"/batch")
+}
+
+func TestFieldValueSchema_Choices(t *testing.T) {
+	doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "1.0"})
+	options := fieldValueobject.NewFieldOptions()
+	options.Select = &fieldValueobject.SelectOptions{Choices: []fieldValueobject.SelectChoice{{ID: "c1", Name: "红"}}}
+	field := newSpecField(t, "fld_tags", "标签", fieldValueobject.TypeMultipleSelect, 1, options)
+
+	// 允许自动新增选项时只给出示例，不限制取值
+	schema := fieldValueSchema(doc, field, true)
+	assert.Equal(t, openapi.SchemaType{"array"}, schema.Type)
+	assert.Nil(t, schema.Items.Enum)
+	assert.Equal(t, []interface{}{"红"}, schema.Items.Examples)
+}
+
+// methods 返回路径上的操作方法
+func methods(item openapi.PathItem) []string {
+	var result []string
+	for method := range item {
+		result = append(result, method)
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/validation"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
 	"github.com/easyspace-ai/luckdb/server/pkg/errors"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
@@ -95,6 +96,14 @@ func (s *TypecastService) ValidateAndTypecastRecord(
 			continue
 		}
 
+		// 跳过按钮字段（点击状态只能由点击接口写入）
+		if field.Type().String() == fieldValueobject.TypeButton {
+			logger.Debug("跳过按钮字段",
+				logger.String("field_id", fieldID),
+				logger.String("field_name", field.Name().String()))
+			continue
+		}
+
 		// 验证值
 		validationResult := s.factory.ValidateField(ctx, value, field)
 
@@ -3,30 +3,84 @@ package application
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldRepo "github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	recordEntity "github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
 	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	viewDomain "github.com/easyspace-ai/luckdb/server/internal/domain/view"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/view/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/view/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	infraRepository "github.com/easyspace-ai/luckdb/server/internal/infrastructure/repository"
+	"github.com/easyspace-ai/luckdb/server/pkg/database"
 	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
 
 // ViewService 视图应用服务
 type ViewService struct {
-	viewRepo  repository.ViewRepository
-	tableRepo tableRepo.TableRepository // ✅ 添加表仓储，用于检查表存在性
+	viewRepo        repository.ViewRepository
+	tableRepo       tableRepo.TableRepository            // ✅ 添加表仓储，用于检查表存在性
+	fieldRepo       fieldRepo.FieldRepository            // ✨ 字段仓储，用于校验视图配置引用的字段
+	recordRepo      recordRepo.RecordRepository          // ✨ 记录仓储，用于时间线、看板、日历等视图的数据查询
+	recordOrderRepo repository.RecordOrderRepository     // ✨ 视图内记录手动顺序（看板拖拽）
+	aggregateRepo   recordRepo.RecordAggregateRepository // ✨ 记录聚合（分组统计、列统计）
+	recordService   *RecordService                       // ✨ 记录服务，用于移动看板卡片时更新分组字段
+	rowPermission   *RowPermissionService                // ✨ 行级权限
+	subscriptions   SubscriptionRevalidator              // ✨ 关闭/刷新分享后撤销分享链接的实时订阅
+	liveQueries     RecordChangeNotifier                 // ✨ 过滤/排序变更后刷新实时查询
+	foreignFields   *ForeignFieldGuard                   // ✨ 跨 Base 关联字段的读取权限
 }
 
 // NewViewService 创建视图服务
-func NewViewService(viewRepo repository.ViewRepository, tableRepo tableRepo.TableRepository) *ViewService {
+func NewViewService(
+	viewRepo repository.ViewRepository,
+	tableRepo tableRepo.TableRepository,
+	fieldRepo fieldRepo.FieldRepository,
+	recordRepo recordRepo.RecordRepository,
+	recordOrderRepo repository.RecordOrderRepository,
+	aggregateRepo recordRepo.RecordAggregateRepository,
+) *ViewService {
 	return &ViewService{
-		viewRepo:  viewRepo,
-		tableRepo: tableRepo,
+		viewRepo:        viewRepo,
+		tableRepo:       tableRepo,
+		fieldRepo:       fieldRepo,
+		recordRepo:      recordRepo,
+		recordOrderRepo: recordOrderRepo,
+		aggregateRepo:   aggregateRepo,
 	}
 }
 
+// SetRecordService 设置记录服务（用于延迟注入）
+func (s *ViewService) SetRecordService(recordService *RecordService) {
+	s.recordService = recordService
+}
+
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (s *ViewService) SetRowPermissionService(rowPermission *RowPermissionService) {
+	s.rowPermission = rowPermission
+}
+
+// SetSubscriptionRevalidator 设置实时订阅重新校验（用于延迟注入）
+func (s *ViewService) SetSubscriptionRevalidator(revalidator SubscriptionRevalidator) {
+	s.subscriptions = revalidator
+}
+
+// SetForeignFieldGuard 设置跨 Base 字段读取权限校验（用于延迟注入）
+func (s *ViewService) SetForeignFieldGuard(guard *ForeignFieldGuard) {
+	s.foreignFields = guard
+}
+
+// SetLiveQueryNotifier 设置实时查询刷新通知（用于延迟注入）
+func (s *ViewService) SetLiveQueryNotifier(notifier RecordChangeNotifier) {
+	s.liveQueries = notifier
+}
+
 // CreateView 创建视图
 func (s *ViewService) CreateView(
 	ctx context.Context,
@@ -110,6 +164,9 @@ func (s *ViewService) CreateView(
 		if err := view.UpdateOptions(req.Options); err != nil {
 			return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
 		}
+		if err := s.validateViewOptions(ctx, view); err != nil {
+			return nil, err
+		}
 	}
 
 	// 8.5. 设置视图排序order（基于当前表的视图数量）
@@ -244,6 +301,8 @@ func (s *ViewService) UpdateViewFilter(
 		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("更新视图失败: %v", err))
 	}
 
+	s.refreshLiveQueries(view.TableID())
+
 	logger.Info("视图过滤器更新成功",
 		logger.String("view_id", viewID),
 	)
@@ -285,6 +344,8 @@ func (s *ViewService) UpdateViewSort(
 		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("更新视图失败: %v", err))
 	}
 
+	s.refreshLiveQueries(view.TableID())
+
 	logger.Info("视图排序更新成功",
 		logger.String("view_id", viewID),
 	)
@@ -314,6 +375,15 @@ func (s *ViewService) UpdateViewGroup(
 		if err != nil {
 			return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("分组无效: %v", err))
 		}
+
+		// ✨ 校验分组字段存在且支持分组
+		fields, err := s.tableFields(ctx, view.TableID())
+		if err != nil {
+			return err
+		}
+		if err := viewDomain.ValidateGridGroup(group, nil, fieldTypeMap(fields)); err != nil {
+			return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("分组无效: %v", err))
+		}
 	}
 
 	// 3. 更新分组
@@ -354,6 +424,17 @@ func (s *ViewService) UpdateViewColumnMeta(
 		return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("列配置无效: %v", err))
 	}
 
+	// ✨ 校验列统计适用于字段类型
+	if statistics := columnMeta.Statistics(); len(statistics) > 0 {
+		fields, err := s.tableFields(ctx, view.TableID())
+		if err != nil {
+			return err
+		}
+		if err := viewDomain.ValidateColumnStatistics(statistics, fieldTypeMap(fields)); err != nil {
+			return pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("列配置无效: %v", err))
+		}
+	}
+
 	// 3. 更新列配置
 	if err := view.UpdateColumnMeta(columnMeta); err != nil {
 		return pkgerrors.ErrValidationFailed.WithDetails(err.Error())
@@ -390,6 +471,9 @@ func (s *ViewService) UpdateViewOptions(
 	if err := view.UpdateOptions(options); err != nil {
 		return pkgerrors.ErrValidationFailed.WithDetails(err.Error())
 	}
+	if err := s.validateViewOptions(ctx, view); err != nil {
+		return err
+	}
 
 	// 3. 保存更新
 	if err := s.viewRepo.Update(ctx, view); err != nil {
@@ -422,6 +506,9 @@ func (s *ViewService) PatchViewOptions(
 	if err := view.PatchOptions(options); err != nil {
 		return pkgerrors.ErrValidationFailed.WithDetails(err.Error())
 	}
+	if err := s.validateViewOptions(ctx, view); err != nil {
+		return err
+	}
 
 	// 3. 保存更新
 	if err := s.viewRepo.Update(ctx, view); err != nil {
@@ -510,12 +597,14 @@ func (s *ViewService) DisableShare(ctx context.Context, viewID string) error {
 	}
 
 	// 2. 禁用分享
+	oldShareID := view.ShareID()
 	view.DisableSharing()
 
 	// 3. 保存更新
 	if err := s.viewRepo.Update(ctx, view); err != nil {
 		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("更新视图失败: %v", err))
 	}
+	s.revokeShareSubscriptions(oldShareID)
 
 	logger.Info("视图分享已禁用",
 		logger.String("view_id", viewID),
@@ -536,6 +625,7 @@ func (s *ViewService) RefreshShareID(ctx context.Context, viewID string) (string
 	}
 
 	// 2. 刷新分享ID
+	oldShareID := view.ShareID()
 	shareID, err := view.RefreshShareID()
 	if err != nil {
 		return "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
@@ -545,6 +635,7 @@ func (s *ViewService) RefreshShareID(ctx context.Context, viewID string) (string
 	if err := s.viewRepo.Update(ctx, view); err != nil {
 		return "", pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("更新视图失败: %v", err))
 	}
+	s.revokeShareSubscriptions(oldShareID)
 
 	logger.Info("视图分享ID已刷新",
 		logger.String("view_id", viewID),
@@ -554,6 +645,21 @@ func (s *ViewService) RefreshShareID(ctx context.Context, viewID string) (string
 	return shareID, nil
 }
 
+// revokeShareSubscriptions 后台撤销旧分享链接建立的实时订阅
+func (s *ViewService) revokeShareSubscriptions(shareID *string) {
+	if s.subscriptions == nil || shareID == nil || *shareID == "" {
+		return
+	}
+	go s.subscriptions.RevalidateShare(context.Background(), *shareID)
+}
+
+// refreshLiveQueries 视图条件变更后刷新表上的实时查询
+func (s *ViewService) refreshLiveQueries(tableID string) {
+	if s.liveQueries != nil {
+		s.liveQueries.NotifyRecordChanged(tableID)
+	}
+}
+
 // UpdateShareMeta 更新分享元数据
 func (s *ViewService) UpdateShareMeta(
 	ctx context.Context,
@@ -719,3 +825,824 @@ func (s *ViewService) CountViews(ctx context.Context, tableID string) (int64, er
 
 	return count, nil
 }
+
+// GetTimelineData 获取时间线视图数据 ✨
+//
+// 执行流程：
+//  1. 解析并校验时间线配置（开始/结束日期、分组、依赖字段）
+//  2. 解析查询窗口（跨度超过上限时裁剪）
+//  3. 将"与窗口重叠"下推为查询条件，叠加视图过滤器与行级权限
+//  4. 构建条目、分组和依赖，条目起止时间裁剪到窗口内
+func (s *ViewService) GetTimelineData(ctx context.Context, viewID, start, end string) (*dto.TimelineDataResponse, error) {
+	// 1. 查找视图
+	view, err := s.findDataView(ctx, viewID, valueobject.ViewTypeTimeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 解析配置
+	config, startColumn, err := s.parseTimelineConfig(ctx, view.TableID(), view.Options())
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 解析查询窗口
+	window, err := viewDomain.NewTimelineWindow(start, end)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	// 4. ✨ 行级权限
+	tableID := view.TableID()
+	scopes, allowed, err := s.readScopes(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return dto.FromTimelineViewData(viewDomain.BuildTimelineData(config, window, nil)), nil
+	}
+
+	// 5. 构建查询：视图过滤器 + 窗口重叠条件
+	filter := recordRepo.RecordFilter{
+		TableID:      &tableID,
+		Filters:      append([]*valueobject.Filter{view.Filter()}, dateOverlapFilters(config.StartDateField, config.EndDateField, window.Start, window.End)...),
+		AccessScopes: scopes,
+		OrderBy:      startColumn,
+		OrderDir:     "asc",
+		Limit:        viewDomain.MaxTimelineItems + 1, // 多取一条用于判断是否截断
+	}
+
+	records, _, err := s.recordRepo.List(ctx, filter)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询时间线记录失败: %v", err))
+	}
+	if records, err = s.foreignFields.MaskRecords(ctx, tableID, records); err != nil {
+		return nil, err
+	}
+
+	// 6. 构建时间线数据
+	data := viewDomain.BuildTimelineData(config, window, toViewRecords(records))
+	if len(records) > viewDomain.MaxTimelineItems {
+		data.Truncated = true
+	}
+
+	return dto.FromTimelineViewData(data), nil
+}
+
+// GetKanbanData 获取看板视图数据 ✨
+//
+// 执行流程：
+//  1. 解析看板配置，分组字段必须是单选或用户字段
+//  2. 确定看板列：单选字段取选项，用户字段从现有记录中发现，另加未分类列
+//  3. 每列单独查询（视图过滤器 + 行级权限 + 列条件），返回整列数量和当前页卡片
+//  4. 卡片按视图手动顺序排列，未排序的卡片按创建顺序排在后面
+func (s *ViewService) GetKanbanData(ctx context.Context, viewID string, query dto.KanbanDataQuery) (*dto.KanbanDataResponse, error) {
+	// 1. 查找视图并解析配置
+	view, err := s.findDataView(ctx, viewID, valueobject.ViewTypeKanban)
+	if err != nil {
+		return nil, err
+	}
+	config, groupField, err := s.parseKanbanConfig(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &viewDomain.KanbanViewData{Groups: []viewDomain.KanbanGroup{}, Config: *config}
+
+	// 2. ✨ 行级权限
+	tableID := view.TableID()
+	scopes, allowed, err := s.readScopes(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return dto.FromKanbanViewData(data), nil
+	}
+	base := recordRepo.RecordFilter{
+		TableID:      &tableID,
+		Filters:      []*valueobject.Filter{view.Filter()},
+		AccessScopes: scopes,
+	}
+
+	// 3. 确定看板列
+	stacks, err := s.kanbanStacks(ctx, config, groupField, base)
+	if err != nil {
+		return nil, err
+	}
+	if query.StackID != "" {
+		stack, err := selectKanbanStack(stacks, groupField, query.StackID)
+		if err != nil {
+			return nil, err
+		}
+		stacks = []viewDomain.KanbanGroup{stack}
+	}
+
+	// 4. 逐列查询卡片
+	hidden, err := s.foreignFields.HiddenFields(ctx, tableID, currentUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	offset, limit := viewDomain.NormalizeKanbanPage(query.Offset, query.Limit)
+	for _, stack := range stacks {
+		filter := base
+		filter.Filters = append(append([]*valueobject.Filter{}, base.Filters...), viewDomain.KanbanStackFilter(config.GroupFieldID, stack))
+		filter.ManualOrderViewID = viewID
+		filter.OrderBy = "__auto_number"
+		filter.OrderDir = "asc"
+		filter.Offset = offset
+		filter.Limit = limit
+
+		records, total, err := s.recordRepo.List(ctx, filter)
+		if err != nil {
+			return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询看板卡片失败: %v", err))
+		}
+		if records, err = maskRecords(records, hidden); err != nil {
+			return nil, err
+		}
+
+		stack.Count = int(total)
+		stack.HasMore = offset+len(records) < int(total)
+		stack.Cards = make([]map[string]interface{}, 0, len(records))
+		for _, record := range toViewRecords(records) {
+			stack.Cards = append(stack.Cards, viewDomain.BuildKanbanCard(config, record))
+		}
+		data.Groups = append(data.Groups, stack)
+	}
+
+	return dto.FromKanbanViewData(data), nil
+}
+
+// MoveKanbanCard 移动看板卡片 ✨
+//
+// 在同一事务中完成：
+//  1. 通过记录服务更新分组字段（复用字段校验、行级权限和实时推送）
+//  2. 指定参照卡片时，把卡片的手动顺序设置到参照卡片之前或之后
+func (s *ViewService) MoveKanbanCard(ctx context.Context, viewID string, req dto.MoveKanbanCardRequest, userID string) (*dto.RecordResponse, error) {
+	if s.recordService == nil {
+		return nil, pkgerrors.ErrInternalServer.WithDetails("记录服务未初始化")
+	}
+
+	// 1. 查找视图并解析配置
+	view, err := s.findDataView(ctx, viewID, valueobject.ViewTypeKanban)
+	if err != nil {
+		return nil, err
+	}
+	config, groupField, err := s.parseKanbanConfig(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 验证请求
+	if req.AnchorID != "" {
+		if req.AnchorID == req.RecordID {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails("参照卡片不能是被移动的卡片本身")
+		}
+		if err := viewDomain.ValidateKanbanPosition(req.Position); err != nil {
+			return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+		}
+	}
+	stack, err := selectKanbanStack(kanbanChoiceStacks(groupField), groupField, req.ToStackID)
+	if err != nil {
+		return nil, err
+	}
+	value := viewDomain.KanbanStackValue(stack)
+	if value != nil && isMultipleUserField(groupField) {
+		value = []string{stack.ID}
+	}
+
+	// 3. 在事务中更新分组字段和手动顺序
+	tableID := view.TableID()
+	var response *dto.RecordResponse
+	err = database.Transaction(ctx, s.recordRepo.(*infraRepository.RecordRepositoryDynamic).GetDB(), nil, func(txCtx context.Context) error {
+		updated, err := s.recordService.UpdateRecord(txCtx, tableID, req.RecordID, dto.UpdateRecordRequest{
+			Data: map[string]interface{}{config.GroupFieldID: value},
+		}, userID)
+		if err != nil {
+			return err
+		}
+		response = updated
+
+		if req.AnchorID == "" {
+			return nil
+		}
+		order, err := s.kanbanCardOrder(txCtx, view, config, stack, req)
+		if err != nil {
+			return err
+		}
+		if err := s.recordOrderRepo.SetOrders(txCtx, viewID, map[string]float64{req.RecordID: order}); err != nil {
+			return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("更新卡片顺序失败: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("看板卡片移动成功",
+		logger.String("view_id", viewID),
+		logger.String("record_id", req.RecordID),
+		logger.String("to_stack", req.ToStackID))
+
+	return response, nil
+}
+
+// GetCalendarData 获取日历视图数据 ✨
+//
+// 执行流程：
+//  1. 按请求的时区解析查询窗口（日期为该时区的整天）
+//  2. 查询开始/结束时间与窗口相交的记录（数据库预过滤放宽范围以覆盖全天事件）
+//  3. 在内存中按时区精确判断重叠，生成全天、跨天和带时间的事件
+func (s *ViewService) GetCalendarData(ctx context.Context, viewID, start, end, timezone string) (*dto.CalendarDataResponse, error) {
+	// 1. 查找视图并解析配置
+	view, err := s.findDataView(ctx, viewID, valueobject.ViewTypeCalendar)
+	if err != nil {
+		return nil, err
+	}
+	config, dateFields, startColumn, err := s.parseCalendarConfig(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 解析查询窗口
+	window, err := viewDomain.NewCalendarWindow(start, end, timezone)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	// 3. ✨ 行级权限
+	tableID := view.TableID()
+	scopes, allowed, err := s.readScopes(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return dto.FromCalendarViewData(viewDomain.BuildCalendarData(config, window, nil, dateFields)), nil
+	}
+
+	// 4. 查询与窗口相交的记录
+	lower, upper := window.QueryBounds()
+	filter := recordRepo.RecordFilter{
+		TableID:      &tableID,
+		Filters:      append([]*valueobject.Filter{view.Filter()}, dateOverlapFilters(config.StartField(), config.EndField(), lower, upper)...),
+		AccessScopes: scopes,
+		OrderBy:      startColumn,
+		OrderDir:     "asc",
+		Limit:        viewDomain.MaxCalendarEvents + 1, // 多取一条用于判断是否截断
+	}
+
+	records, _, err := s.recordRepo.List(ctx, filter)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询日历记录失败: %v", err))
+	}
+	if records, err = s.foreignFields.MaskRecords(ctx, tableID, records); err != nil {
+		return nil, err
+	}
+
+	// 5. 构建日历事件
+	data := viewDomain.BuildCalendarData(config, window, toViewRecords(records), dateFields)
+	if len(records) > viewDomain.MaxCalendarEvents {
+		data.Truncated = true
+	}
+
+	return dto.FromCalendarViewData(data), nil
+}
+
+// GetGridGroups 获取分组网格的分组树
+// ✨ 按视图分组配置（最多3层）在数据库中统计每个分组的记录数和列统计，分组内记录通过 GetGridGroupRecords 分页加载
+func (s *ViewService) GetGridGroups(ctx context.Context, viewID string, query dto.GridGroupsQuery) (*dto.GridGroupsResponse, error) {
+	// 1. 查找视图
+	view, err := s.findDataView(ctx, viewID, valueobject.ViewTypeGrid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 解析并校验分组与列统计
+	statistics, err := valueobject.ParseColumnStatistics(query.Statistics)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	fields, err := s.tableFields(ctx, view.TableID())
+	if err != nil {
+		return nil, err
+	}
+	if query.Statistics == "" {
+		// 未指定时使用列配置中的统计
+		statistics = viewDomain.ApplicableColumnStatistics(view.ColumnMeta().Statistics(), fieldTypeMap(fields))
+	}
+	group := view.Group()
+	if err := viewDomain.ValidateGridGroup(group, statistics, fieldTypeMap(fields)); err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	var items []valueobject.GroupItem
+	if group != nil {
+		items = group.GroupItems
+	}
+
+	// 3. ✨ 行级权限
+	tableID := view.TableID()
+	scopes, allowed, err := s.readScopes(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return dto.FromGridGroupedData(group.ToSlice(), viewDomain.BuildGridGroupTree(items, nil, false)), nil
+	}
+
+	// 4. 分组统计
+	result, err := s.aggregateRepo.AggregateGroups(ctx, recordRepo.GroupAggregateQuery{
+		Filter: recordRepo.RecordFilter{
+			TableID:      &tableID,
+			Filters:      []*valueobject.Filter{view.Filter()},
+			AccessScopes: scopes,
+		},
+		GroupBy:    items,
+		Statistics: statistics,
+		MaxGroups:  viewDomain.MaxGridGroups,
+	})
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("分组统计失败: %v", err))
+	}
+
+	// 5. 组装分组树
+	summaries := make([]viewDomain.GridGroupSummary, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		summaries = append(summaries, viewDomain.GridGroupSummary{
+			Keys:       row.Keys,
+			Count:      row.Count,
+			Statistics: row.Statistics,
+		})
+	}
+
+	return dto.FromGridGroupedData(group.ToSlice(), viewDomain.BuildGridGroupTree(items, summaries, result.Truncated)), nil
+}
+
+// GetGridGroupRecords 分页获取分组内的记录
+// ✨ 按视图排序后再按自增编号排序，保证分组内分页稳定
+func (s *ViewService) GetGridGroupRecords(ctx context.Context, viewID string, query dto.GridGroupRecordsQuery) (*dto.GridGroupRecordsResponse, error) {
+	// 1. 查找视图
+	view, err := s.findDataView(ctx, viewID, valueobject.ViewTypeGrid)
+	if err != nil {
+		return nil, err
+	}
+	group := view.Group()
+	if group.IsEmpty() {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails("视图未配置分组")
+	}
+
+	// 2. 分组路径转为过滤条件
+	keys, err := viewDomain.DecodeGridGroupID(query.GroupID, group.GetLevelCount())
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	fields, err := s.tableFields(ctx, view.TableID())
+	if err != nil {
+		return nil, err
+	}
+	groupFilter, err := viewDomain.GridGroupFilter(group.GroupItems, fieldTypeMap(fields), keys)
+	if err != nil {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	offset, limit := viewDomain.NormalizeGridGroupPage(query.Offset, query.Limit)
+	response := &dto.GridGroupRecordsResponse{
+		GroupID: query.GroupID,
+		Records: []*dto.ViewRecordResponse{},
+		Offset:  offset,
+		Limit:   limit,
+	}
+
+	// 3. ✨ 行级权限
+	tableID := view.TableID()
+	scopes, allowed, err := s.readScopes(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return response, nil
+	}
+
+	// 4. 查询分组内记录
+	filter := recordRepo.RecordFilter{
+		TableID:      &tableID,
+		Filters:      []*valueobject.Filter{view.Filter(), groupFilter},
+		AccessScopes: scopes,
+		OrderBy:      "__auto_number",
+		OrderDir:     "asc",
+		Limit:        limit,
+		Offset:       offset,
+	}
+	if sort := view.Sort(); sort != nil && len(sort.SortItems) > 0 {
+		if column := dbColumnOf(fields, sort.SortItems[0].FieldID); column != "" {
+			filter.OrderBy = column
+			filter.OrderDir = string(sort.SortItems[0].Order)
+		}
+	}
+
+	records, total, err := s.recordRepo.List(ctx, filter)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询分组记录失败: %v", err))
+	}
+	if records, err = s.foreignFields.MaskRecords(ctx, tableID, records); err != nil {
+		return nil, err
+	}
+
+	response.Total = total
+	for _, record := range toViewRecords(records) {
+		response.Records = append(response.Records, &dto.ViewRecordResponse{ID: record.ID, Fields: record.Fields})
+	}
+	return response, nil
+}
+
+// GetViewStatistics 获取视图的列统计
+// ✨ 统计列配置（ColumnMeta.statistic）中可见列，遵循视图过滤条件和行级权限
+func (s *ViewService) GetViewStatistics(ctx context.Context, viewID string) (*dto.ViewStatisticsResponse, error) {
+	view, err := s.viewRepo.FindByID(ctx, viewID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找视图失败: %v", err))
+	}
+	if view == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("视图不存在")
+	}
+
+	scopes, allowed, err := s.readScopes(ctx, view.TableID())
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, pkgerrors.ErrForbidden.WithDetails("无权读取该表的记录")
+	}
+
+	return s.computeViewStatistics(ctx, view, scopes)
+}
+
+// computeViewStatistics 计算视图的列统计
+// scopes 为空表示不做行级限制（用于向可读取全表的用户推送）
+func (s *ViewService) computeViewStatistics(ctx context.Context, view *entity.View, scopes [][]*valueobject.Filter) (*dto.ViewStatisticsResponse, error) {
+	fields, err := s.tableFields(ctx, view.TableID())
+	if err != nil {
+		return nil, err
+	}
+	statistics := viewDomain.ApplicableColumnStatistics(view.ColumnMeta().Statistics(), fieldTypeMap(fields))
+
+	tableID := view.TableID()
+	result, err := s.aggregateRepo.AggregateGroups(ctx, recordRepo.GroupAggregateQuery{
+		Filter: recordRepo.RecordFilter{
+			TableID:      &tableID,
+			Filters:      []*valueobject.Filter{view.Filter()},
+			AccessScopes: scopes,
+		},
+		Statistics: statistics,
+	})
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("列统计失败: %v", err))
+	}
+
+	response := &dto.ViewStatisticsResponse{
+		ViewID:     view.ID(),
+		Statistics: make([]*dto.ColumnStatisticResponse, 0, len(statistics)),
+	}
+	var values map[string]interface{}
+	if len(result.Rows) > 0 {
+		response.Total = result.Rows[0].Count
+		values = result.Rows[0].Statistics
+	}
+	for _, statistic := range statistics {
+		response.Statistics = append(response.Statistics, &dto.ColumnStatisticResponse{
+			FieldID: statistic.FieldID,
+			Func:    string(statistic.Func),
+			Value:   values[statistic.Key()],
+		})
+	}
+	return response, nil
+}
+
+// findDataView 查找视图并检查视图类型
+func (s *ViewService) findDataView(ctx context.Context, viewID string, viewType valueobject.ViewType) (*entity.View, error) {
+	view, err := s.viewRepo.FindByID(ctx, viewID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查找视图失败: %v", err))
+	}
+	if view == nil {
+		return nil, pkgerrors.ErrNotFound.WithDetails("视图不存在")
+	}
+	if view.ViewType() != viewType {
+		return nil, pkgerrors.ErrValidationFailed.WithDetails(fmt.Sprintf("该视图不是%s视图", viewType))
+	}
+	return view, nil
+}
+
+// readScopes 当前用户在表上的行级读取范围
+// 第二个返回值为 false 表示当前用户无权读取该表任何记录；没有用户上下文时不限制
+func (s *ViewService) readScopes(ctx context.Context, tableID string) ([][]*valueobject.Filter, bool, error) {
+	userID := currentUserID(ctx)
+	if s.rowPermission == nil || userID == "" {
+		return nil, true, nil
+	}
+	access, err := s.rowPermission.ResolveAccess(ctx, tableID, userID, permission.ActionRecordRead)
+	if err != nil {
+		return nil, false, err
+	}
+	return access.Scopes, access.Allowed, nil
+}
+
+// validateViewOptions 校验视图类型相关的选项
+// 目前只有时间线视图有强类型配置，其余视图保持原样
+func (s *ViewService) validateViewOptions(ctx context.Context, view *entity.View) error {
+	if !view.ViewType().IsTimeline() || len(view.Options()) == 0 {
+		return nil
+	}
+	_, _, err := s.parseTimelineConfig(ctx, view.TableID(), view.Options())
+	return err
+}
+
+// parseTimelineConfig 解析并校验时间线配置
+// 未指定标题字段时使用表的主字段；同时返回开始日期字段的物理列名，用于排序
+func (s *ViewService) parseTimelineConfig(ctx context.Context, tableID string, options map[string]interface{}) (*viewDomain.TimelineViewConfig, string, error) {
+	parsed, err := viewDomain.GetGlobalConfigManager().ParseConfig(viewDomain.ViewTypeTimeline, options)
+	if err != nil {
+		return nil, "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	config := parsed.(*viewDomain.TimelineViewConfig)
+	if err := config.Validate(); err != nil {
+		return nil, "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	fields, err := s.tableFields(ctx, tableID)
+	if err != nil {
+		return nil, "", err
+	}
+	if config.TitleField == "" {
+		config.TitleField = primaryFieldID(fields)
+	}
+	if err := config.ValidateFieldTypes(fieldTypeMap(fields)); err != nil {
+		return nil, "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	return config, dbColumnOf(fields, config.StartDateField), nil
+}
+
+// parseKanbanConfig 解析并校验看板配置，返回分组字段
+func (s *ViewService) parseKanbanConfig(ctx context.Context, view *entity.View) (*viewDomain.KanbanViewConfig, *fieldEntity.Field, error) {
+	parsed, err := viewDomain.GetGlobalConfigManager().ParseConfig(viewDomain.ViewTypeKanban, view.Options())
+	if err != nil {
+		return nil, nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	config := parsed.(*viewDomain.KanbanViewConfig)
+	if err := config.Validate(); err != nil {
+		return nil, nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	fields, err := s.tableFields(ctx, view.TableID())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := config.ValidateFieldTypes(fieldTypeMap(fields)); err != nil {
+		return nil, nil, pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	for _, field := range fields {
+		if field.ID().String() == config.GroupFieldID {
+			return config, field, nil
+		}
+	}
+	return nil, nil, pkgerrors.ErrValidationFailed.WithDetails("看板分组字段不存在")
+}
+
+// parseCalendarConfig 解析并校验日历配置
+// 未指定标题字段时使用表的主字段；返回日期（不含时间）字段集合和开始时间字段的物理列名
+func (s *ViewService) parseCalendarConfig(ctx context.Context, view *entity.View) (*viewDomain.CalendarViewConfig, map[string]bool, string, error) {
+	parsed, err := viewDomain.GetGlobalConfigManager().ParseConfig(viewDomain.ViewTypeCalendar, view.Options())
+	if err != nil {
+		return nil, nil, "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+	config := parsed.(*viewDomain.CalendarViewConfig)
+	if err := config.Validate(); err != nil {
+		return nil, nil, "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	fields, err := s.tableFields(ctx, view.TableID())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if config.TitleFieldID == "" {
+		config.TitleFieldID = primaryFieldID(fields)
+	}
+	if err := config.ValidateFieldTypes(fieldTypeMap(fields)); err != nil {
+		return nil, nil, "", pkgerrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	dateFields := make(map[string]bool)
+	for _, field := range fields {
+		if field.Type().String() == "date" {
+			dateFields[field.ID().String()] = true
+		}
+	}
+
+	return config, dateFields, dbColumnOf(fields, config.StartField()), nil
+}
+
+// kanbanStacks 确定看板的列（未分类列在最前）
+// 单选字段按选项顺序；用户字段从现有记录中按出现顺序发现
+func (s *ViewService) kanbanStacks(ctx context.Context, config *viewDomain.KanbanViewConfig, groupField *fieldEntity.Field, base recordRepo.RecordFilter) ([]viewDomain.KanbanGroup, error) {
+	if groupField.Type().String() != "user" {
+		return kanbanChoiceStacks(groupField), nil
+	}
+
+	scan := base
+	scan.OrderBy = "__auto_number"
+	scan.OrderDir = "asc"
+	scan.Limit = viewDomain.MaxKanbanStackScan
+	records, _, err := s.recordRepo.List(ctx, scan)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询看板分组失败: %v", err))
+	}
+
+	values := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		value, _ := record.Data().Get(config.GroupFieldID)
+		values = append(values, value)
+	}
+	return append([]viewDomain.KanbanGroup{viewDomain.NewKanbanEmptyStack()}, viewDomain.KanbanStacksFromValues(values)...), nil
+}
+
+// kanbanCardOrder 计算卡片移动到参照卡片之前或之后的手动顺序值
+// 参照卡片尚未排序或相邻顺序值过近时，先按当前展示顺序重排目标列
+func (s *ViewService) kanbanCardOrder(ctx context.Context, view *entity.View, config *viewDomain.KanbanViewConfig, stack viewDomain.KanbanGroup, req dto.MoveKanbanCardRequest) (float64, error) {
+	before := req.Position == viewDomain.KanbanPositionBefore
+
+	for attempt := 0; ; attempt++ {
+		orders, err := s.recordOrderRepo.FindOrders(ctx, view.ID(), []string{req.AnchorID})
+		if err != nil {
+			return 0, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询卡片顺序失败: %v", err))
+		}
+
+		anchor, ok := orders[req.AnchorID]
+		if !ok {
+			if attempt > 0 {
+				return 0, pkgerrors.ErrValidationFailed.WithDetails("参照卡片不在目标列中")
+			}
+			if err := s.rebalanceKanbanStack(ctx, view, config, stack, req.RecordID); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		neighbor, err := s.recordOrderRepo.FindNeighbor(ctx, view.ID(), anchor, before, req.RecordID)
+		if err != nil {
+			return 0, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询卡片顺序失败: %v", err))
+		}
+		prev, next := &anchor, neighbor
+		if before {
+			prev, next = neighbor, &anchor
+		}
+
+		if attempt == 0 && viewDomain.KanbanOrderNeedsRebalance(prev, next) {
+			if err := s.rebalanceKanbanStack(ctx, view, config, stack, req.RecordID); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		return viewDomain.KanbanOrderBetween(prev, next), nil
+	}
+}
+
+// rebalanceKanbanStack 按当前展示顺序为目标列的卡片重新分配等间隔的顺序值
+// 顺序对所有用户共享，因此不叠加当前用户的行级权限范围
+func (s *ViewService) rebalanceKanbanStack(ctx context.Context, view *entity.View, config *viewDomain.KanbanViewConfig, stack viewDomain.KanbanGroup, movingRecordID string) error {
+	tableID := view.TableID()
+	records, _, err := s.recordRepo.List(ctx, recordRepo.RecordFilter{
+		TableID:           &tableID,
+		Filters:           []*valueobject.Filter{view.Filter(), viewDomain.KanbanStackFilter(config.GroupFieldID, stack)},
+		ManualOrderViewID: view.ID(),
+		OrderBy:           "__auto_number",
+		OrderDir:          "asc",
+		Limit:             viewDomain.MaxKanbanOrderBatch,
+	})
+	if err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("查询看板卡片失败: %v", err))
+	}
+
+	orders := make(map[string]float64, len(records))
+	for _, record := range records {
+		if record.ID().String() == movingRecordID {
+			continue
+		}
+		orders[record.ID().String()] = float64(len(orders) + 1)
+	}
+	if err := s.recordOrderRepo.SetOrders(ctx, view.ID(), orders); err != nil {
+		return pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("更新卡片顺序失败: %v", err))
+	}
+	return nil
+}
+
+// tableFields 获取表的字段列表
+func (s *ViewService) tableFields(ctx context.Context, tableID string) ([]*fieldEntity.Field, error) {
+	fields, err := s.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, pkgerrors.ErrDatabaseOperation.WithDetails(fmt.Sprintf("获取字段列表失败: %v", err))
+	}
+	return fields, nil
+}
+
+// kanbanChoiceStacks 单选字段的看板列（未分类列 + 各选项）
+func kanbanChoiceStacks(groupField *fieldEntity.Field) []viewDomain.KanbanGroup {
+	stacks := []viewDomain.KanbanGroup{viewDomain.NewKanbanEmptyStack()}
+	if options := groupField.Options(); options != nil && options.Select != nil {
+		for _, choice := range options.Select.Choices {
+			stacks = append(stacks, viewDomain.KanbanGroup{
+				ID:    choice.Name, // 单选字段存储选项名称
+				Name:  choice.Name,
+				Color: choice.Color,
+				Value: choice.Name,
+			})
+		}
+	}
+	return stacks
+}
+
+// selectKanbanStack 按ID选择看板列
+// 用户字段的列不预先确定，任意用户ID都是合法的列
+func selectKanbanStack(stacks []viewDomain.KanbanGroup, groupField *fieldEntity.Field, stackID string) (viewDomain.KanbanGroup, error) {
+	for _, stack := range stacks {
+		if stack.ID == stackID {
+			return stack, nil
+		}
+	}
+	if groupField.Type().String() == "user" {
+		return viewDomain.KanbanGroup{ID: stackID, Name: stackID, Value: stackID}, nil
+	}
+	return viewDomain.KanbanGroup{}, pkgerrors.ErrNotFound.WithDetails(fmt.Sprintf("看板列不存在: %s", stackID))
+}
+
+// isMultipleUserField 是否为多用户字段
+func isMultipleUserField(field *fieldEntity.Field) bool {
+	options := field.Options()
+	return field.Type().String() == "user" && options != nil && options.User != nil && options.User.IsMultiple
+}
+
+// dateOverlapFilters 日期区间与 [lower, upper] 相交的查询条件
+// 开始 <= upper 且 (开始 >= lower 或 结束 >= lower)
+func dateOverlapFilters(startField, endField string, lower, upper time.Time) []*valueobject.Filter {
+	tail := &valueobject.Filter{
+		Operator: valueobject.FilterOperatorOr,
+		Filters: []valueobject.FilterItem{
+			{FieldID: startField, Operator: valueobject.FilterItemOpGreaterEqual, Value: lower.Format(time.RFC3339)},
+		},
+	}
+	if endField != "" {
+		tail.Filters = append(tail.Filters, valueobject.FilterItem{
+			FieldID: endField, Operator: valueobject.FilterItemOpGreaterEqual, Value: lower.Format(time.RFC3339),
+		})
+	}
+
+	return []*valueobject.Filter{
+		{
+			Operator: valueobject.FilterOperatorAnd,
+			Filters: []valueobject.FilterItem{
+				{FieldID: startField, Operator: valueobject.FilterItemOpLessEqual, Value: upper.Format(time.RFC3339)},
+			},
+		},
+		tail,
+	}
+}
+
+// toViewRecords 记录实体转为视图数据构建所需的记录
+func toViewRecords(records []*recordEntity.Record) []viewDomain.ViewRecord {
+	viewRecords := make([]viewDomain.ViewRecord, 0, len(records))
+	for _, record := range records {
+		viewRecords = append(viewRecords, viewDomain.ViewRecord{
+			ID:     record.ID().String(),
+			Fields: record.Data().ToMap(),
+		})
+	}
+	return viewRecords
+}
+
+// fieldTypeMap 字段ID到字段类型的映射
+func fieldTypeMap(fields []*fieldEntity.Field) map[string]string {
+	fieldTypes := make(map[string]string, len(fields))
+	for _, field := range fields {
+		fieldTypes[field.ID().String()] = field.Type().String()
+	}
+	return fieldTypes
+}
+
+// primaryFieldID 主字段ID
+func primaryFieldID(fields []*fieldEntity.Field) string {
+	for _, field := range fields {
+		if field.IsPrimary() {
+			return field.ID().String()
+		}
+	}
+	return ""
+}
+
+// dbColumnOf 字段对应的物理列名（字段不存在时为空）
+func dbColumnOf(fields []*fieldEntity.Field, fieldID string) string {
+	for _, field := range fields {
+		if field.ID().String() == fieldID {
+			return field.DBFieldName().String()
+		}
+	}
+	return ""
+}
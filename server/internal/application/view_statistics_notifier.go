@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// 统计推送参数
+const (
+	DefaultViewStatisticsDelay   = 500 * time.Millisecond // 记录变更后合并推送的等待时间
+	viewStatisticsComputeTimeout = 30 * time.Second
+)
+
+// RecordChangeNotifier 记录变更通知（事务提交后调用）
+type RecordChangeNotifier interface {
+	NotifyRecordChanged(tableID string)
+}
+
+// RecordUpdateNotifier 带变更字段的记录更新通知，实时查询据此跳过不受影响的刷新
+type RecordUpdateNotifier interface {
+	RecordChangeNotifier
+	NotifyRecordUpdated(tableID, recordID string, fieldIDs []string)
+}
+
+// ViewStatisticsNotifier 视图列统计推送器 ✨
+//
+// 记录变更后按表合并（debounce），等待期结束后重新执行 SQL 聚合，
+// 并通过 WebSocket 推送配置了列统计的视图的最新结果：
+//   - 表上没有行级规则时推送给所有订阅者
+//   - 可读取全表的用户收到统计值
+//   - 受行级规则限制的用户只收到 stale 标记，由客户端按自己的范围重新拉取
+type ViewStatisticsNotifier struct {
+	viewService   *ViewService
+	wsService     websocket.Service
+	rowPermission *RowPermissionService
+	delay         time.Duration
+
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// NewViewStatisticsNotifier 创建视图列统计推送器
+func NewViewStatisticsNotifier(viewService *ViewService, wsService websocket.Service, rowPermission *RowPermissionService) *ViewStatisticsNotifier {
+	return &ViewStatisticsNotifier{
+		viewService:   viewService,
+		wsService:     wsService,
+		rowPermission: rowPermission,
+		delay:         DefaultViewStatisticsDelay,
+		pending:       make(map[string]bool),
+	}
+}
+
+// NotifyRecordChanged 记录变更通知，同一张表在等待期内的变更只触发一次重新统计
+func (n *ViewStatisticsNotifier) NotifyRecordChanged(tableID string) {
+	if n == nil || n.wsService == nil || tableID == "" {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.pending[tableID] {
+		return
+	}
+	n.pending[tableID] = true
+	time.AfterFunc(n.delay, func() { n.flush(tableID) })
+}
+
+// flush 重新统计表上所有配置了列统计的视图并推送
+func (n *ViewStatisticsNotifier) flush(tableID string) {
+	n.mu.Lock()
+	delete(n.pending, tableID)
+	n.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), viewStatisticsComputeTimeout)
+	defer cancel()
+
+	views, err := n.viewService.viewRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		logger.Warn("加载视图失败，跳过统计推送",
+			logger.String("table_id", tableID),
+			logger.ErrorField(err))
+		return
+	}
+
+	var allow func(userID string) bool
+	if n.rowPermission != nil {
+		if unrestricted := n.rowPermission.UnrestrictedReaderFilter(ctx, tableID); unrestricted != nil {
+			// 每个视图推送两次（统计值与 stale），缓存用户的判定结果
+			decisions := make(map[string]bool)
+			allow = func(userID string) bool {
+				if decision, ok := decisions[userID]; ok {
+					return decision
+				}
+				decisions[userID] = unrestricted(userID)
+				return decisions[userID]
+			}
+		}
+	}
+
+	channel := fmt.Sprintf("table:%s", tableID)
+	for _, view := range views {
+		if len(view.ColumnMeta().Statistics()) == 0 {
+			continue
+		}
+
+		statistics, err := n.viewService.computeViewStatistics(ctx, view, nil)
+		if err != nil {
+			logger.Warn("视图统计失败，跳过推送",
+				logger.String("view_id", view.ID()),
+				logger.ErrorField(err))
+			continue
+		}
+
+		message := viewStatisticsMessage(tableID, &websocket.ViewStatisticsOp{ViewID: view.ID(), Statistics: statistics})
+		if allow == nil {
+			err = n.wsService.BroadcastToChannel(channel, message)
+		} else {
+			err = n.wsService.BroadcastToChannelWhere(channel, message, allow)
+			if err == nil {
+				stale := viewStatisticsMessage(tableID, &websocket.ViewStatisticsOp{ViewID: view.ID(), Stale: true})
+				err = n.wsService.BroadcastToChannelWhere(channel, stale, func(userID string) bool { return !allow(userID) })
+			}
+		}
+		if err != nil {
+			logger.Error("Failed to broadcast view statistics",
+				logger.String("view_id", view.ID()),
+				logger.ErrorField(err))
+		}
+	}
+}
+
+// viewStatisticsMessage 构建视图统计推送消息
+func viewStatisticsMessage(tableID string, op *websocket.ViewStatisticsOp) *websocket.Message {
+	return &websocket.Message{
+		Type: websocket.MessageTypeOp,
+		Data: &websocket.Operation{
+			Type:    websocket.OperationTypeViewStatistics,
+			TableID: tableID,
+			Data:    op,
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	viewEntity "github.com/easyspace-ai/luckdb/server/internal/domain/view/entity"
+	viewRepo "github.com/easyspace-ai/luckdb/server/internal/domain/view/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// SubscriptionRevalidator 权限变更后重新校验实时订阅，强制退订已无权访问的频道
+type SubscriptionRevalidator interface {
+	RevalidateUser(ctx context.Context, userID string) int
+	RevalidateShare(ctx context.Context, shareID string) int
+	RevalidateAll(ctx context.Context) int
+}
+
+// WebSocketSubscriptionAuthorizer WebSocket 频道订阅授权 ✨
+//
+// 登录用户按 PermissionServiceV2 校验频道对应的资源：
+//   - 空间、Base 频道需要对应的读权限
+//   - 表、字段、协作频道需要表的读权限；视图频道需要视图所属表的读权限
+//   - 记录频道需要记录的读权限，表上有行级规则时记录还必须在可读取的行范围内
+//   - 用户频道只能订阅自己的
+//
+// 分享链接的匿名访客只能订阅被分享视图的频道，分享关闭后即失效
+type WebSocketSubscriptionAuthorizer struct {
+	permission    *PermissionServiceV2
+	viewRepo      viewRepo.ViewRepository
+	rowPermission *RowPermissionService // ✨ 记录频道的行级权限
+	recordRepo    recordRepo.RecordRepository
+}
+
+// NewWebSocketSubscriptionAuthorizer 创建订阅授权
+func NewWebSocketSubscriptionAuthorizer(permission *PermissionServiceV2, viewRepo viewRepo.ViewRepository) *WebSocketSubscriptionAuthorizer {
+	return &WebSocketSubscriptionAuthorizer{
+		permission: permission,
+		viewRepo:   viewRepo,
+	}
+}
+
+// SetRowPermissionService 设置行级权限服务（用于延迟注入）
+func (a *WebSocketSubscriptionAuthorizer) SetRowPermissionService(rowPermission *RowPermissionService, recordRepo recordRepo.RecordRepository) {
+	a.rowPermission = rowPermission
+	a.recordRepo = recordRepo
+}
+
+// AuthorizeShare 校验分享链接是否有效
+func (a *WebSocketSubscriptionAuthorizer) AuthorizeShare(ctx context.Context, shareID string) error {
+	_, err := a.sharedView(ctx, shareID)
+	return err
+}
+
+// AuthorizeSubscription 校验订阅方能否订阅频道
+func (a *WebSocketSubscriptionAuthorizer) AuthorizeSubscription(ctx context.Context, subscriber *websocket.Subscriber, scope *websocket.ChannelScope) error {
+	if subscriber.UserID == "" {
+		return a.authorizeShareScope(ctx, subscriber.ShareID, scope)
+	}
+
+	userID := subscriber.UserID
+	allowed := false
+	switch scope.Type {
+	case websocket.ScopeUser:
+		allowed = scope.UserID == userID
+	case websocket.ScopeSpace:
+		allowed = a.permission.CanAccessSpace(ctx, userID, scope.SpaceID)
+	case websocket.ScopeBase:
+		allowed = a.permission.CanAccessBase(ctx, userID, scope.BaseID)
+	case websocket.ScopeTable:
+		allowed = a.permission.CanAccessTable(ctx, userID, scope.TableID)
+	case websocket.ScopeView:
+		view, err := a.viewRepo.FindByID(ctx, scope.ViewID)
+		if err != nil {
+			return lookupFailed(scope.Channel, err)
+		}
+		allowed = view != nil && viewInScope(view, scope) && a.permission.CanAccessTable(ctx, userID, view.TableID())
+	case websocket.ScopeRecord:
+		if !a.permission.CanAccessRecord(ctx, userID, scope.TableID) {
+			break
+		}
+		var err error
+		if allowed, err = a.canReadRecord(ctx, userID, scope); err != nil {
+			return lookupFailed(scope.Channel, err)
+		}
+	}
+
+	if !allowed {
+		return &websocket.SubscriptionError{Message: "access denied to channel " + scope.Channel}
+	}
+	return nil
+}
+
+// canReadRecord 记录是否在用户可读取的行范围内，记录不存在时拒绝
+func (a *WebSocketSubscriptionAuthorizer) canReadRecord(ctx context.Context, userID string, scope *websocket.ChannelScope) (bool, error) {
+	if a.rowPermission == nil {
+		return true, nil
+	}
+	access, err := a.rowPermission.ResolveAccess(ctx, scope.TableID, userID, permission.ActionRecordRead)
+	if err != nil {
+		return false, err
+	}
+	if !access.Allowed || access.Unrestricted {
+		return access.Allowed, nil
+	}
+
+	record, err := a.recordRepo.FindByTableAndID(ctx, scope.TableID, valueobject.NewRecordID(scope.RecordID))
+	if err != nil {
+		return false, err
+	}
+	return record != nil && access.Match(RecordValues(record)), nil
+}
+
+// authorizeShareScope 分享链接只能订阅被分享视图的频道
+func (a *WebSocketSubscriptionAuthorizer) authorizeShareScope(ctx context.Context, shareID string, scope *websocket.ChannelScope) error {
+	view, err := a.sharedView(ctx, shareID)
+	if err != nil {
+		return err
+	}
+	if scope.Type != websocket.ScopeView || scope.ViewID != view.ID() || !viewInScope(view, scope) {
+		return &websocket.SubscriptionError{Message: "share link can only subscribe to the shared view"}
+	}
+	return nil
+}
+
+// sharedView 查找已启用分享的视图
+func (a *WebSocketSubscriptionAuthorizer) sharedView(ctx context.Context, shareID string) (*viewEntity.View, error) {
+	if shareID == "" {
+		return nil, &websocket.SubscriptionError{Message: "share link is required"}
+	}
+	view, err := a.viewRepo.FindByShareID(ctx, shareID)
+	if err != nil {
+		return nil, lookupFailed(shareID, err)
+	}
+	if view == nil || !view.EnableShare() || view.ShareID() == nil || *view.ShareID() != shareID {
+		return nil, &websocket.SubscriptionError{Message: "share link is invalid or disabled"}
+	}
+	return view, nil
+}
+
+// viewInScope 频道携带表 ID 时视图必须属于该表
+func viewInScope(view *viewEntity.View, scope *websocket.ChannelScope) bool {
+	return scope.TableID == "" || scope.TableID == view.TableID()
+}
+
+// lookupFailed 查询失败时只向客户端返回概要信息，详细错误写日志
+func lookupFailed(target string, err error) error {
+	logger.Error("Failed to authorize websocket subscription",
+		logger.String("target", target),
+		logger.ErrorField(err))
+	return &websocket.SubscriptionError{Message: "failed to verify access"}
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
@@ -76,15 +77,34 @@ func (s *WorkflowService) Delete(ctx context.Context, id string) error {
 
 // Run 运行工作流
 func (s *WorkflowService) Run(ctx context.Context, workflowID, userID string, input map[string]interface{}) (*models.WorkflowRun, error) {
+	return s.RunWithTrigger(ctx, workflowID, userID, "manual", input)
+}
+
+// RunWithTrigger 以指定触发方式运行工作流，input 作为运行输入保存
+func (s *WorkflowService) RunWithTrigger(ctx context.Context, workflowID, userID, triggerType string, input map[string]interface{}) (*models.WorkflowRun, error) {
+	if _, err := s.GetByID(ctx, workflowID); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	run := &models.WorkflowRun{
 		ID:          utils.GenerateIDWithPrefix("wfr"),
 		WorkflowID:  workflowID,
 		CreatedBy:   userID,
 		Status:      "running",
+		TriggerType: triggerType,
 		StartedTime: &now,
 	}
 
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		inputJSON := string(data)
+		run.Input = &inputJSON
+	}
+
 	if err := s.db.WithContext(ctx).Create(run).Error; err != nil {
 		return nil, err
 	}
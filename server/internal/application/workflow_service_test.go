@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
@@ -16,6 +17,19 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
 
+	// 模型中的 PostgreSQL 类型 "timestamp(3) without time zone" 在 SQLite 中无法建表，
+	// 建表语句（经 Raw 回调执行）中改为 SQLite 可识别的 timestamp
+	err = db.Callback().Raw().Before("gorm:raw").Register("test:sqlite_timestamp", func(tx *gorm.DB) {
+		sql := tx.Statement.SQL.String()
+		if strings.Contains(sql, " without time zone") {
+			tx.Statement.SQL.Reset()
+			tx.Statement.SQL.WriteString(strings.ReplaceAll(sql, "timestamp(3) without time zone", "timestamp"))
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
 	// Auto migrate
 	if err := db.AutoMigrate(&models.Workflow{}, &models.WorkflowRun{}); err != nil {
 		t.Fatalf("Failed to migrate: %v", err)
@@ -193,3 +207,35 @@ func TestWorkflowService_Run(t *testing.T) {
 		t.Errorf("Expected status 'running', got '%s'", run.Status)
 	}
 }
+
+func TestWorkflowService_RunWithTrigger(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewWorkflowService(db)
+
+	workflow := &models.Workflow{
+		Name:        "Test Workflow",
+		Type:        "automation",
+		TriggerType: "button",
+		CreatedBy:   "user123",
+	}
+	service.Create(context.Background(), workflow)
+
+	input := map[string]interface{}{"recordId": "rec123"}
+	run, err := service.RunWithTrigger(context.Background(), workflow.ID, "user123", "button", input)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if run.TriggerType != "button" {
+		t.Errorf("Expected trigger type 'button', got '%s'", run.TriggerType)
+	}
+
+	if run.Input == nil || *run.Input != `{"recordId":"rec123"}` {
+		t.Errorf("Expected input to be stored, got %v", run.Input)
+	}
+
+	// 工作流不存在时不创建运行记录
+	if _, err := service.RunWithTrigger(context.Background(), "wfl_missing", "user123", "button", nil); err == nil {
+		t.Error("Expected error for missing workflow")
+	}
+}
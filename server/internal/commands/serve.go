@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/easyspace-ai/luckdb/server/internal/config"
 	"github.com/easyspace-ai/luckdb/server/internal/container"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
 	httpHandlers "github.com/easyspace-ai/luckdb/server/internal/interfaces/http"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
@@ -90,6 +92,18 @@ func runServe(version string) error {
 		)
 	}
 
+	// ✨ 初始化链路追踪（未启用时为 noop）
+	shutdownTracing, err := observability.InitTracing(context.Background(), cfg.Observability.Tracing, version)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", logger.ErrorField(err))
+	}
+	if cfg.Observability.Tracing.Enabled {
+		logger.Info("Tracing enabled",
+			logger.String("endpoint", cfg.Observability.Tracing.Endpoint),
+			logger.Float64("sample_ratio", cfg.Observability.Tracing.SampleRatio),
+		)
+	}
+
 	// 创建依赖注入容器
 	cont := container.NewContainer(cfg)
 
@@ -141,6 +155,11 @@ func runServe(version string) error {
 		logger.Error("Server forced to shutdown", logger.ErrorField(err))
 	}
 
+	// ✨ 导出剩余的 span
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to shutdown tracing", logger.ErrorField(err))
+	}
+
 	// 关闭SQL日志记录器
 	if logger.SQLLogger != nil {
 		if err := logger.SQLLogger.Close(); err != nil {
@@ -161,13 +180,25 @@ func setupRouter(cfg *config.Config, cont *container.Container, version string)
 
 	router := gin.New()
 
+	// ✨ 只信任配置的反向代理转发的客户端 IP（限流按客户端 IP 计数）
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Error("可信代理配置无效，不信任任何代理", logger.ErrorField(err))
+		_ = router.SetTrustedProxies(nil)
+	}
+
 	// 基础中间件
 	router.Use(gin.Recovery())
 	router.Use(corsMiddleware())
+	router.Use(observability.GinMiddleware()) // ✨ 链路追踪与 HTTP 指标，需在日志中间件之前以便日志带上 trace_id
 	router.Use(loggerMiddleware())
 
 	// 健康检查
 	router.GET("/health", healthCheckHandler(cont, version))
+
+	// ✨ Prometheus 指标
+	if cfg.Observability.Metrics.Enabled {
+		router.GET(cfg.Observability.Metrics.Path, metricsHandler(cfg.Observability.Metrics.Token))
+	}
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"service": "LuckDB API",
@@ -178,7 +209,7 @@ func setupRouter(cfg *config.Config, cont *container.Container, version string)
 	})
 
 	// 设置API路由
-	httpHandlers.SetupRoutes(router, cont)
+	httpHandlers.SetupRoutes(router, cont, version)
 
 	return router
 }
@@ -210,13 +241,29 @@ func healthCheckHandler(cont *container.Container, version string) gin.HandlerFu
 	}
 }
 
+// metricsHandler Prometheus 指标处理器，配置了 token 时要求 Bearer 认证
+func metricsHandler(token string) gin.HandlerFunc {
+	handler := observability.MetricsHandler()
+	return func(c *gin.Context) {
+		if token != "" {
+			expected := "Bearer " + token
+			if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte(expected)) != 1 {
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
 // corsMiddleware CORS中间件
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Tus-Resumable, Upload-Length, Upload-Offset, Upload-Metadata, Upload-Checksum")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Location, Tus-Resumable, Tus-Version, Tus-Extension, Tus-Checksum-Algorithm, Upload-Offset, Upload-Length")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH, HEAD")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -238,7 +285,7 @@ func loggerMiddleware() gin.HandlerFunc {
 
 		duration := time.Since(start)
 
-		logger.Info("HTTP Request",
+		logger.Ctx(c.Request.Context()).Info("HTTP Request",
 			logger.String("method", c.Request.Method),
 			logger.String("path", path),
 			logger.String("query", query),
@@ -1,48 +1,50 @@
 package config
 
+import "os"
+
 // AIConfig AI provider configuration
 type AIConfig struct {
 	// Default provider to use
-	DefaultProvider string `yaml:"default_provider" env:"AI_DEFAULT_PROVIDER" default:"openai"`
+	DefaultProvider string `mapstructure:"default_provider" yaml:"default_provider" env:"AI_DEFAULT_PROVIDER" default:"openai"`
 
 	// Provider configurations
-	Providers map[string]AIProviderConfig `yaml:"providers"`
+	Providers map[string]AIProviderConfig `mapstructure:"providers" yaml:"providers"`
 }
 
 // AIProviderConfig individual AI provider configuration
 type AIProviderConfig struct {
 	// Provider type (openai, deepseek, anthropic, etc.)
-	Type string `yaml:"type"`
+	Type string `mapstructure:"type" yaml:"type"`
 
 	// API key for authentication
-	APIKey string `yaml:"api_key" env:"AI_API_KEY"`
+	APIKey string `mapstructure:"api_key" yaml:"api_key" env:"AI_API_KEY"`
 
 	// Base URL for API requests (optional, uses default if not specified)
-	BaseURL string `yaml:"base_url" env:"AI_BASE_URL"`
+	BaseURL string `mapstructure:"base_url" yaml:"base_url" env:"AI_BASE_URL"`
 
 	// Default model to use
-	DefaultModel string `yaml:"default_model"`
+	DefaultModel string `mapstructure:"default_model" yaml:"default_model"`
 
 	// Request timeout in seconds
-	Timeout int `yaml:"timeout" default:"30"`
+	Timeout int `mapstructure:"timeout" yaml:"timeout" default:"30"`
 
 	// Rate limiting
-	RateLimit AIRateLimitConfig `yaml:"rate_limit"`
+	RateLimit AIRateLimitConfig `mapstructure:"rate_limit" yaml:"rate_limit"`
 
 	// Provider-specific options
-	Options map[string]interface{} `yaml:"options"`
+	Options map[string]interface{} `mapstructure:"options" yaml:"options"`
 }
 
 // AIRateLimitConfig rate limiting configuration for AI providers
 type AIRateLimitConfig struct {
 	// Requests per minute
-	RequestsPerMinute int `yaml:"requests_per_minute" default:"60"`
+	RequestsPerMinute int `mapstructure:"requests_per_minute" yaml:"requests_per_minute" default:"60"`
 
 	// Tokens per minute
-	TokensPerMinute int `yaml:"tokens_per_minute" default:"90000"`
+	TokensPerMinute int `mapstructure:"tokens_per_minute" yaml:"tokens_per_minute" default:"90000"`
 
 	// Concurrent requests
-	ConcurrentRequests int `yaml:"concurrent_requests" default:"10"`
+	ConcurrentRequests int `mapstructure:"concurrent_requests" yaml:"concurrent_requests" default:"10"`
 }
 
 // DefaultAIConfig returns default AI configuration
@@ -85,3 +87,8 @@ func DefaultAIConfig() AIConfig {
 		},
 	}
 }
+
+// ResolvedAPIKey returns the API key with ${ENV} placeholders expanded
+func (c AIProviderConfig) ResolvedAPIKey() string {
+	return os.ExpandEnv(c.APIKey)
+}
@@ -21,6 +21,11 @@ type Config struct {
 	WebSocket WebSocketConfig `mapstructure:"websocket"`
 	AI        AIConfig        `mapstructure:"ai"`
 	MCP       MCPConfig       `mapstructure:"mcp"`
+
+	Notification  NotificationConfig  `mapstructure:"notification"`
+	Observability ObservabilityConfig `mapstructure:"observability"`
+	RateLimit     HTTPRateLimitConfig `mapstructure:"rate_limit"`
+	Quota         QuotaConfig         `mapstructure:"quota"`
 }
 
 // ServerConfig 服务器配置
@@ -36,6 +41,9 @@ type ServerConfig struct {
 	EnableCORS          bool          `mapstructure:"enable_cors"`
 	EnableSwagger       bool          `mapstructure:"enable_swagger"`
 	PermissionsDisabled bool          `mapstructure:"permissions_disabled"` // 禁用权限检查（仅用于开发）
+	// TrustedProxies 可信反向代理的 IP/CIDR，只有来自这些地址的 X-Forwarded-For 才用于确定客户端 IP
+	// 为空时不信任任何代理，客户端 IP 取连接的对端地址
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // DatabaseConfig 数据库配置
@@ -78,6 +86,11 @@ type StorageConfig struct {
 	S3         S3Config    `mapstructure:"s3"`
 	CDNDomain  string      `mapstructure:"cdn_domain"`
 	UploadPath string      `mapstructure:"upload_path"` // 兼容性字段
+
+	// 附件上传限制 ✨
+	MaxFileSize  int64    `mapstructure:"max_file_size"` // 单个文件最大字节数
+	AllowedTypes []string `mapstructure:"allowed_types"` // 允许的 MIME 类型，为空表示不限制
+	SpaceQuota   int64    `mapstructure:"space_quota"`   // 每个空间的附件存储配额（字节），0 表示不限制
 }
 
 type LocalConfig struct {
@@ -132,6 +145,101 @@ type WebSocketConfig struct {
 	EnablePresence    bool          `mapstructure:"enable_presence"`
 }
 
+// NotificationConfig 通知投递配置
+type NotificationConfig struct {
+	DeliveryInterval time.Duration `mapstructure:"delivery_interval"` // 处理顺延、重试和摘要投递的间隔
+	SMTP             SMTPConfig    `mapstructure:"smtp"`
+	WebhookTimeout   time.Duration `mapstructure:"webhook_timeout"`   // 出站 Webhook 请求超时
+	ReminderInterval time.Duration `mapstructure:"reminder_interval"` // 记录提醒调度间隔
+	ReminderGrace    time.Duration `mapstructure:"reminder_grace"`    // 停机后补发提醒的宽限期，超过则标记为错过
+}
+
+// SMTPConfig 邮件发送配置，Host 为空时不启用邮件渠道
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+	TLS      bool   `mapstructure:"tls"` // 直接使用 TLS 连接（465 端口），否则在服务器支持时使用 STARTTLS
+}
+
+// ObservabilityConfig 指标、链路追踪与告警配置
+type ObservabilityConfig struct {
+	Metrics  MetricsConfig  `mapstructure:"metrics"`
+	Tracing  TracingConfig  `mapstructure:"tracing"`
+	Alerting AlertingConfig `mapstructure:"alerting"`
+}
+
+// MetricsConfig Prometheus 指标配置
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`  // 抓取路径，默认 /metrics
+	Token   string `mapstructure:"token"` // 非空时抓取需携带 Authorization: Bearer <token>
+}
+
+// TracingConfig OpenTelemetry 链路追踪配置（OTLP/HTTP 导出）
+type TracingConfig struct {
+	Enabled     bool              `mapstructure:"enabled"`
+	Endpoint    string            `mapstructure:"endpoint"`     // OTLP/HTTP 地址，如 http://otel-collector:4318；为空时读取 OTEL_EXPORTER_OTLP_ENDPOINT
+	Headers     map[string]string `mapstructure:"headers"`      // 导出请求头，如认证令牌
+	ServiceName string            `mapstructure:"service_name"` // 默认 luckdb
+	SampleRatio float64           `mapstructure:"sample_ratio"` // 采样率 0-1，上游已采样的请求始终跟随上游
+}
+
+// AlertingConfig 告警配置
+// 告警规则存储在数据库中，这里只配置评估间隔和通知渠道；规则通过 channels 选择渠道，为空时发送到所有渠道
+type AlertingConfig struct {
+	Enabled  bool                 `mapstructure:"enabled"`
+	Interval time.Duration        `mapstructure:"interval"` // 规则评估间隔
+	EmailTo  []string             `mapstructure:"email_to"` // 告警邮件收件人（使用 notification.smtp 发送），为空时不启用 email 渠道
+	Webhooks []AlertWebhookConfig `mapstructure:"webhooks"`
+}
+
+// AlertWebhookConfig 告警 Webhook 渠道
+type AlertWebhookConfig struct {
+	Name     string            `mapstructure:"name"` // 渠道名，规则 channels 中引用
+	URL      string            `mapstructure:"url"`
+	Method   string            `mapstructure:"method"` // 默认 POST
+	Headers  map[string]string `mapstructure:"headers"`
+	Template string            `mapstructure:"template"` // Go text/template 格式的 JSON 消息体，为空时发送告警 JSON
+	Timeout  time.Duration     `mapstructure:"timeout"`
+}
+
+// HTTPRateLimitConfig 接口限流配置（令牌桶）
+// 配置 Redis 时计数由所有实例共享，Redis 不可用时退回进程内限流
+type HTTPRateLimitConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	IP      RateLimitRule `mapstructure:"ip"`    // 每个客户端 IP，作用于所有接口
+	Auth    RateLimitRule `mapstructure:"auth"`  // 认证接口（登录、注册、刷新令牌），按客户端 IP
+	User    RateLimitRule `mapstructure:"user"`  // 每个用户，该用户的所有令牌合计
+	Token   RateLimitRule `mapstructure:"token"` // 每个访问令牌（API Key），按 Authorization 中的令牌区分
+}
+
+// RateLimitRule 令牌桶规则：每 period 补充 requests 个令牌，最多积累 burst 个
+// requests 为 0 表示不限制，burst 为 0 时等于 requests
+type RateLimitRule struct {
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
+	Burst    int           `mapstructure:"burst"`
+}
+
+// QuotaConfig 空间配额配置
+// 空间所有者持有生效的许可证时按许可证的套餐（plan）取配额，否则使用 default_plan；
+// default_plan 为空或套餐未配置时不限制
+type QuotaConfig struct {
+	Enabled     bool                 `mapstructure:"enabled"`
+	DefaultPlan string               `mapstructure:"default_plan"`
+	Plans       map[string]PlanQuota `mapstructure:"plans"`
+}
+
+// PlanQuota 套餐配额，0 表示不限制
+type PlanQuota struct {
+	MaxRecords          int64 `mapstructure:"max_records"`             // 空间内所有表的记录总数
+	MaxStorage          int64 `mapstructure:"max_storage"`             // 附件存储（字节），许可证设置了 max_storage 时以许可证为准
+	MaxAPICallsPerMonth int64 `mapstructure:"max_api_calls_per_month"` // 每个自然月（UTC）访问空间内资源的请求数
+}
+
 // Load 加载配置
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -210,6 +318,8 @@ func setDefaults() {
 	viper.SetDefault("storage.type", "local")
 	viper.SetDefault("storage.local.upload_path", "./uploads")
 	viper.SetDefault("storage.local.url_prefix", "/uploads")
+	viper.SetDefault("storage.max_file_size", 100*1024*1024)
+	viper.SetDefault("storage.space_quota", 0)
 
 	// Logger defaults
 	viper.SetDefault("logger.level", "info")
@@ -241,6 +351,44 @@ func setDefaults() {
 	viper.SetDefault("websocket.max_connections", 1000)
 	viper.SetDefault("websocket.enable_presence", true)
 
+	// Notification defaults
+	viper.SetDefault("notification.delivery_interval", "1m")
+	viper.SetDefault("notification.smtp.port", 587)
+	viper.SetDefault("notification.webhook_timeout", "10s")
+	viper.SetDefault("notification.reminder_interval", "30s")
+	viper.SetDefault("notification.reminder_grace", "24h")
+
+	// Observability defaults
+	viper.SetDefault("observability.metrics.enabled", true)
+	viper.SetDefault("observability.metrics.path", "/metrics")
+	viper.SetDefault("observability.tracing.enabled", false)
+	viper.SetDefault("observability.tracing.service_name", "luckdb")
+	viper.SetDefault("observability.tracing.sample_ratio", 1.0)
+	viper.SetDefault("observability.alerting.enabled", true)
+	viper.SetDefault("observability.alerting.interval", "30s")
+
+	// Rate limit defaults
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.ip.requests", 1200)
+	viper.SetDefault("rate_limit.ip.period", "1m")
+	viper.SetDefault("rate_limit.auth.requests", 10)
+	viper.SetDefault("rate_limit.auth.period", "1m")
+	viper.SetDefault("rate_limit.auth.burst", 5)
+	viper.SetDefault("rate_limit.user.requests", 600)
+	viper.SetDefault("rate_limit.user.period", "1m")
+	viper.SetDefault("rate_limit.token.requests", 300)
+	viper.SetDefault("rate_limit.token.period", "1m")
+
+	// Quota defaults（套餐名与许可证的 plan 对应）
+	viper.SetDefault("quota.enabled", true)
+	viper.SetDefault("quota.default_plan", "")
+	viper.SetDefault("quota.plans.basic.max_records", 50000)
+	viper.SetDefault("quota.plans.basic.max_api_calls_per_month", 100000)
+	viper.SetDefault("quota.plans.pro.max_records", 1000000)
+	viper.SetDefault("quota.plans.pro.max_api_calls_per_month", 2000000)
+	viper.SetDefault("quota.plans.enterprise.max_records", 0)
+	viper.SetDefault("quota.plans.enterprise.max_api_calls_per_month", 0)
+
 	// MCP defaults
 	viper.SetDefault("mcp.enabled", true)
 	viper.SetDefault("mcp.server.host", "0.0.0.0")
@@ -3,20 +3,32 @@ package container
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/easyspace-ai/luckdb/server/internal/application"
 	"github.com/easyspace-ai/luckdb/server/internal/config"
+	infraAI "github.com/easyspace-ai/luckdb/server/internal/infrastructure/ai"
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/cache"
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/monitoring"
+	infraNotification "github.com/easyspace-ai/luckdb/server/internal/infrastructure/notification"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/pubsub"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/ratelimit"
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/storage"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 
 	// 领域层仓储接口
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
 	baseRepo "github.com/easyspace-ai/luckdb/server/internal/domain/base/repository"
 	collaboratorRepo "github.com/easyspace-ai/luckdb/server/internal/domain/collaborator/repository"
 	fieldRepo "github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
 	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
 	spaceRepo "github.com/easyspace-ai/luckdb/server/internal/domain/space/repository"
 	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
@@ -41,15 +53,19 @@ type Container struct {
 	cacheClient *cache.RedisClient
 
 	// 仓储层（基础设施层实现）
-	userRepository         userRepo.UserRepository
-	userConfigRepository   userRepo.UserConfigRepository
-	collaboratorRepository collaboratorRepo.CollaboratorRepository
-	baseRepository         baseRepo.BaseRepository
-	recordRepository       recordRepo.RecordRepository
-	fieldRepository        fieldRepo.FieldRepository
-	spaceRepository        spaceRepo.SpaceRepository
-	tableRepository        tableRepo.TableRepository
-	viewRepository         viewRepo.ViewRepository
+	userRepository            userRepo.UserRepository
+	userConfigRepository      userRepo.UserConfigRepository
+	collaboratorRepository    collaboratorRepo.CollaboratorRepository
+	baseRepository            baseRepo.BaseRepository
+	recordRepository          recordRepo.RecordRepository
+	fieldRepository           fieldRepo.FieldRepository
+	spaceRepository           spaceRepo.SpaceRepository
+	tableRepository           tableRepo.TableRepository
+	viewRepository            viewRepo.ViewRepository
+	rowRuleRepository         permission.RowRuleRepository         // 行级规则仓储 ✨
+	recordOrderRepository     viewRepo.RecordOrderRepository       // 视图内记录手动顺序仓储 ✨
+	recordAggregateRepository recordRepo.RecordAggregateRepository // 记录聚合仓储（分组统计）✨
+	linkRepository            recordRepo.LinkRepository            // Link 关联存储仓储（外键列/中间表）✨
 
 	// 应用服务层
 	errorService        *application.ErrorService // 统一错误处理服务 ✨
@@ -65,6 +81,22 @@ type Container struct {
 	fieldService        *application.FieldService
 	recordService       *application.RecordService
 	viewService         *application.ViewService
+	rowPermission       *application.RowPermissionService     // 行级权限服务 ✨
+	linkRecordService   *application.LinkRecordService        // Link 字段关联记录服务 ✨
+	aiFieldService      *application.AIFieldService           // AI 字段生成服务 ✨
+	buttonService       *application.ButtonService            // 按钮字段点击服务 ✨
+	attachmentService   *application.AttachmentService        // 附件上传与单元格引用服务 ✨
+	previewService      *application.AttachmentPreviewService // 附件预览后台任务 ✨
+	notificationService notification.Service                  // 通知服务 ✨
+	notificationWorker  *notification.Deliverer               // 通知渠道分发（顺延、重试、摘要）✨
+	reminderService     *application.ReminderService          // 日期字段记录提醒 ✨
+	alertingSystem      *monitoring.AlertingSystem            // 指标告警评估与通知 ✨
+	alertService        *application.AlertService             // 告警规则、静默与历史 ✨
+	tableOpenAPIService *application.TableOpenAPIService      // 按表字段生成的记录接口文档 ✨
+	graphQLService      *application.GraphQLService           // 按 Base 生成 Schema 的 GraphQL 接口 ✨
+	licenseService      *application.LicenseService           // 许可证（空间套餐来源）✨
+	quotaService        *application.QuotaService             // 空间记录数/存储/API 调用配额 ✨
+	rateLimitStore      ratelimit.Store                       // 限流与配额计数存储 ✨
 
 	// 基础设施服务 ✨
 	batchService       *application.BatchService       // 批量操作服务
@@ -87,6 +119,9 @@ type Container struct {
 	// WebSocket 服务 ✨
 	wsManager *websocket.Manager
 	wsService websocket.Service
+	wsGuard   *websocket.SubscriptionGuard // 订阅授权 ✨
+	wsLive    *websocket.LiveQueryManager  // 视图实时查询 ✨
+	wsPubSub  *pubsub.RedisPubSub          // 实时查询跨实例通知 ✨
 }
 
 // NewContainer 创建新的容器
@@ -122,6 +157,13 @@ func (c *Container) Initialize() error {
 	c.initServices()
 	logger.Info("✅ 应用服务层已初始化")
 
+	// 5. ✨ 将 JSON 存储的 Link 字段迁移到外键列/中间表（已迁移的字段会跳过）
+	if migrated, err := c.fieldService.MigrateLinkStorage(context.Background()); err != nil {
+		logger.Warn("迁移Link字段关联存储失败（下次启动时重试）", logger.ErrorField(err))
+	} else if migrated > 0 {
+		logger.Info("✅ Link 字段关联存储迁移完成", logger.Int("fields", migrated))
+	}
+
 	logger.Info("🎉 依赖注入容器初始化完成")
 	return nil
 }
@@ -135,6 +177,16 @@ func (c *Container) initDatabase() error {
 
 	c.db = db
 
+	// ✨ 可观测性：SQL span（挂在请求/后台任务 span 下）与连接池指标
+	if err := c.db.GetDB().Use(observability.GormTracing{}); err != nil {
+		return fmt.Errorf("注册SQL链路追踪插件失败: %w", err)
+	}
+	if sqlDB, err := c.db.GetDB().DB(); err == nil {
+		if err := observability.RegisterDBStats(sqlDB); err != nil {
+			logger.Warn("注册数据库连接池指标失败", logger.ErrorField(err))
+		}
+	}
+
 	// ✅ 初始化DBProvider（根据数据库类型自动选择）
 	factory := database.NewProviderFactory()
 	c.dbProvider = factory.MustCreateProvider(c.db.GetDB())
@@ -193,6 +245,28 @@ func (c *Container) initRepositories() {
 	// 视图仓储
 	c.viewRepository = repository.NewViewRepository(db)
 
+	// 行级规则仓储 ✨
+	c.rowRuleRepository = repository.NewRowRuleRepository(db)
+
+	// 视图内记录手动顺序仓储 ✨
+	c.recordOrderRepository = repository.NewRecordOrderRepository(db)
+
+	// 记录聚合仓储（分组统计）✨
+	c.recordAggregateRepository = repository.NewRecordAggregateRepository(
+		db,
+		c.dbProvider,
+		c.tableRepository,
+		c.fieldRepository,
+	)
+
+	// Link 关联存储仓储 ✨
+	c.linkRepository = repository.NewLinkRepository(
+		db,
+		c.dbProvider,
+		c.tableRepository,
+		c.fieldRepository,
+	)
+
 }
 
 // initServices 初始化所有应用服务（完美架构）
@@ -236,7 +310,14 @@ func (c *Container) initServices() {
 	c.baseService = application.NewBaseService(c.baseRepository, c.spaceRepository, c.dbProvider) // ✅ 注入DBProvider + SpaceRepository
 
 	// ✅ 先初始化 ViewService（独立服务，不依赖其他服务）
-	c.viewService = application.NewViewService(c.viewRepository, c.tableRepository)
+	c.viewService = application.NewViewService(
+		c.viewRepository,
+		c.tableRepository,
+		c.fieldRepository,
+		c.recordRepository,
+		c.recordOrderRepository,
+		c.recordAggregateRepository,
+	)
 
 	// ✅ 初始化 FieldService (暂时传nil，待实现broadcaster)
 	c.fieldService = application.NewFieldService(
@@ -246,6 +327,10 @@ func (c *Container) initServices() {
 		c.tableRepository, // ✅ 注入TableRepository
 		c.dbProvider,      // ✅ 注入DBProvider
 	)
+	c.fieldService.SetLinkRepository(c.linkRepository)         // ✨ Link 字段使用外键列/中间表存储关联
+	c.fieldService.SetBaseRepository(c.baseRepository)         // ✨ 跨 Base 关联校验同一 Space
+	c.fieldService.SetPermissionService(c.permissionServiceV2) // ✨ 跨 Base 关联校验关联表访问权限
+	c.baseService.SetFieldService(c.fieldService)              // ✨ 删除 Base 时清理跨 Base 关联
 
 	// ✅ 初始化 TableService（依赖 FieldService 和 ViewService）
 	c.tableService = application.NewTableService(
@@ -279,15 +364,288 @@ func (c *Container) initServices() {
 	// ✅ Phase 2: 类型转换服务
 	typecastService := application.NewTypecastService(c.fieldRepository)
 
-	// 记录服务（集成计算引擎+验证） (暂时传nil broadcaster，待实现)
+	// 记录服务（集成计算引擎+验证+实时推送）
+	// WebSocket 服务已在上面初始化，这里直接注入记录广播器
 	c.recordService = application.NewRecordService(
 		c.recordRepository,
 		c.fieldRepository,
 		c.tableRepository,    // ✅ 注入表仓储，用于检查表存在性
 		c.calculationService, // 注入计算服务 ✨
-		nil,                  // broadcaster (待实现)
-		typecastService,      // ✅ 注入验证服务
+		application.NewRecordBroadcaster(c.wsService), // ✨ 记录广播器
+		typecastService, // ✅ 注入验证服务
+	)
+	c.viewService.SetRecordService(c.recordService) // ✨ 看板卡片移动通过记录服务更新分组字段
+
+	// ✨ 行级权限服务（规则与角色权限组合，作用于记录读写、列表和实时推送）
+	c.rowPermission = application.NewRowPermissionService(
+		c.rowRuleRepository,
+		c.permissionServiceV2,
+		c.baseRepository,
+		c.tableRepository,
+		repository.NewOrganizationGroupResolver(c.db.GetDB()),
+	)
+	c.recordService.SetRowPermissionService(c.rowPermission)
+	c.viewService.SetRowPermissionService(c.rowPermission)
+
+	// ✨ 跨 Base 关联字段读取权限（记录读取、视图数据、实时查询和推送）
+	foreignFields := application.NewForeignFieldGuard(c.fieldRepository, c.permissionServiceV2)
+	c.recordService.SetForeignFieldGuard(foreignFields)
+	c.viewService.SetForeignFieldGuard(foreignFields)
+
+	// ✨ 视图列统计推送（记录变更后合并重新统计）
+	c.recordService.SetStatisticsNotifier(application.NewViewStatisticsNotifier(c.viewService, c.wsService, c.rowPermission))
+
+	// ✨ Link 字段关联记录（选择器候选记录 + 写入时的视图/过滤约束校验）
+	c.linkRecordService = application.NewLinkRecordService(c.fieldRepository, c.recordRepository, c.viewRepository)
+	c.linkRecordService.SetPermissionService(c.permissionServiceV2)
+	c.linkRecordService.SetRowPermissionService(c.rowPermission)
+	c.recordService.SetLinkRecordService(c.linkRecordService)
+
+	// ✨ 按表字段生成记录接口的 OpenAPI 文档
+	c.tableOpenAPIService = application.NewTableOpenAPIService(c.tableRepository, c.fieldRepository, c.rowPermission)
+
+	// ✨ GraphQL 接口（Schema 按 Base 的表和字段生成，读写经过记录服务）
+	c.graphQLService = application.NewGraphQLService(
+		c.tableRepository,
+		c.fieldRepository,
+		c.recordService,
+		c.permissionServiceV2,
+		c.rowPermission,
+	)
+
+	// ✨ AI 字段（兼容 OpenAI 接口的提供者 + 批量生成 + 按 Base 计量 token 用量）
+	c.aiFieldService = application.NewAIFieldService(
+		c.fieldRepository,
+		c.recordRepository,
+		c.tableRepository,
+		infraAI.NewRegistryFromConfig(c.cfg.AI),
+		repository.NewAIUsageRepository(c.db.GetDB()),
+	)
+	c.aiFieldService.SetRecordService(c.recordService)
+	c.aiFieldService.SetPermissionService(c.permissionServiceV2)
+	c.aiFieldService.SetRowPermissionService(c.rowPermission)
+	c.recordService.SetAIFieldService(c.aiFieldService)
+
+	// ✨ 按钮字段（服务端执行打开链接/触发工作流/调用 Webhook，点击状态写入单元格）
+	c.buttonService = application.NewButtonService(
+		c.fieldRepository,
+		c.recordRepository,
+		application.NewWorkflowService(c.db.GetDB()),
+	)
+	c.buttonService.SetRecordService(c.recordService)
+	c.buttonService.SetRowPermissionService(c.rowPermission)
+	c.buttonService.SetWebSocketService(c.wsService)
+
+	// ✨ 附件（签名直传 + 流式下载 + 单元格引用计数清理 + 空间配额）
+	c.initAttachmentService()
+
+	// ✨ 限流存储与空间配额（依赖附件服务）
+	c.initRateLimiting()
+
+	// ✨ 通知（应用内/邮件/Webhook 渠道 + 用户偏好 + 摘要）
+	c.initNotificationService()
+
+	// ✨ 日期字段记录提醒（依赖通知服务）
+	c.initReminderService()
+
+	// ✨ 指标告警（规则存储在数据库，SMTP/Webhook 渠道来自配置）
+	c.initAlerting()
+}
+
+// initNotificationService 初始化通知服务与渠道分发
+//
+// 应用内通知总是启用；配置 SMTP 主机时启用邮件渠道，Webhook 渠道使用用户偏好中的地址
+func (c *Container) initNotificationService() {
+	notificationRepo := repository.NewNotificationRepository(c.db.GetDB())
+	c.notificationService = notification.NewService(
+		notificationRepo,
+		notificationRepo,
+		notificationRepo,
+		logger.Logger,
+	)
+
+	c.notificationWorker = notification.NewDeliverer(
+		notificationRepo,
+		notificationRepo,
+		notificationRepo,
+		notificationRepo,
+		application.NewNotificationRecipientResolver(c.userRepository),
+		logger.Logger,
+	)
+	c.notificationWorker.RegisterDispatcher(application.NewInAppNotificationDispatcher(c.wsService))
+	c.notificationWorker.RegisterDispatcher(infraNotification.NewWebhookDispatcher(c.cfg.Notification.WebhookTimeout))
+	if c.cfg.Notification.SMTP.Host != "" {
+		c.notificationWorker.RegisterDispatcher(infraNotification.NewEmailDispatcher(c.cfg.Notification.SMTP))
+	} else {
+		logger.Info("未配置 SMTP，邮件通知渠道未启用")
+	}
+	c.notificationService.SetDeliverer(c.notificationWorker, notificationRepo)
+}
+
+// initReminderService 初始化记录提醒服务，记录变更时重新规划提醒
+func (c *Container) initReminderService() {
+	db := c.db.GetDB()
+	c.reminderService = application.NewReminderService(
+		repository.NewReminderRuleRepository(db),
+		repository.NewReminderRepository(db),
+		c.recordRepository,
+		c.fieldRepository,
+		c.tableRepository,
+		c.rowPermission,
+		c.notificationService,
+		c.cfg.Notification.ReminderGrace,
+	)
+	c.recordService.SetReminderService(c.reminderService)
+}
+
+// initAlerting 初始化告警系统与通知渠道
+//
+// 日志渠道总是启用；配置收件人和 SMTP 主机时启用 email 渠道，每个 Webhook 配置一个渠道
+func (c *Container) initAlerting() {
+	cfg := c.cfg.Observability.Alerting
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	alertRepo := repository.NewAlertRepository(c.db.GetDB())
+	c.alertingSystem = monitoring.NewAlertingSystem(interval)
+	c.alertingSystem.SetLogger(logger.Logger)
+	c.alertingSystem.SetStore(alertRepo)
+	if hostname, err := os.Hostname(); err == nil {
+		c.alertingSystem.SetInstance(hostname)
+	}
+
+	c.alertingSystem.AddNotifier(monitoring.NewLogNotifier())
+
+	smtp := c.cfg.Notification.SMTP
+	if len(cfg.EmailTo) > 0 && smtp.Host != "" {
+		c.alertingSystem.AddNotifier(monitoring.NewEmailNotifier(monitoring.EmailConfig{
+			SMTPHost:  smtp.Host,
+			SMTPPort:  smtp.Port,
+			Username:  smtp.Username,
+			Password:  smtp.Password,
+			From:      smtp.From,
+			To:        cfg.EmailTo,
+			EnableTLS: smtp.TLS,
+		}))
+	} else if len(cfg.EmailTo) > 0 {
+		logger.Warn("已配置告警邮件收件人但未配置 SMTP，email 告警渠道未启用")
+	}
+
+	for _, webhook := range cfg.Webhooks {
+		notifier, err := monitoring.NewWebhookNotifier(monitoring.WebhookConfig{
+			Name:     webhook.Name,
+			URL:      webhook.URL,
+			Method:   webhook.Method,
+			Headers:  webhook.Headers,
+			Timeout:  webhook.Timeout,
+			Template: webhook.Template,
+		})
+		if err != nil {
+			logger.Warn("告警 Webhook 渠道配置无效，已跳过",
+				logger.String("name", webhook.Name),
+				logger.ErrorField(err))
+			continue
+		}
+		c.alertingSystem.AddNotifier(notifier)
+	}
+
+	c.alertService = application.NewAlertService(alertRepo, c.alertingSystem)
+}
+
+// initAttachmentService 初始化附件服务（本地存储）
+func (c *Container) initAttachmentService() {
+	db := c.db.GetDB()
+
+	uploadPath := c.cfg.Storage.Local.UploadPath
+	if uploadPath == "" {
+		uploadPath = c.cfg.Storage.UploadPath // 兼容旧配置
+	}
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	tokenRepo := repository.NewUploadTokenRepository(db)
+	linkRepo := repository.NewAttachmentLinkRepository(db)
+	fileStorage := storage.NewLocalStorage(uploadPath, logger.Logger)
+	attachmentDomain := attachment.NewService(
+		attachmentRepo,
+		tokenRepo,
+		repository.NewUploadSessionRepository(db),
+		repository.NewBlobRepository(db),
+		fileStorage,
+		nil, // 缩略图由预览服务在后台生成
+		storage.NewFileValidator(logger.Logger),
+		&attachment.AttachmentStorageConfig{
+			Type:         c.cfg.Storage.Type,
+			LocalPath:    uploadPath,
+			CDNBaseURL:   c.cfg.Storage.CDNDomain,
+			MaxFileSize:  c.cfg.Storage.MaxFileSize,
+			AllowedTypes: c.cfg.Storage.AllowedTypes,
+		},
+		nil,
+		logger.Logger,
+	)
+
+	c.attachmentService = application.NewAttachmentService(
+		attachmentDomain,
+		attachmentRepo,
+		tokenRepo,
+		linkRepo,
+		repository.NewStorageUsageRepository(db),
+		c.fieldRepository,
+		c.recordRepository,
+		c.tableRepository,
+		c.baseRepository,
+		c.cfg.Storage.SpaceQuota,
+	)
+	c.attachmentService.SetRecordService(c.recordService)
+	c.attachmentService.SetPermissionService(c.permissionServiceV2)
+	c.attachmentService.SetRowPermissionService(c.rowPermission)
+	c.recordService.SetAttachmentService(c.attachmentService)
+
+	// ✨ 预览：PDF/视频海报图、文本摘录，在 StartServices 中启动 worker
+	c.previewService = application.NewAttachmentPreviewService(
+		attachmentRepo,
+		linkRepo,
+		fileStorage,
+		storage.NewPreviewGenerator(logger.Logger),
+		attachment.DefaultThumbnailConfig(),
+		c.recordRepository,
 	)
+	c.previewService.SetRecordService(c.recordService)
+	c.attachmentService.SetPreviewService(c.previewService)
+}
+
+// initRateLimiting 初始化限流存储与空间配额服务
+//
+// 配置 Redis 时限流与 API 调用计数在实例间共享，Redis 出错时临时退回进程内计数
+func (c *Container) initRateLimiting() {
+	if c.cacheClient != nil {
+		c.rateLimitStore = ratelimit.NewFallbackStore(
+			ratelimit.NewRedisStore(c.cacheClient.GetClient()),
+			ratelimit.NewMemoryStore(),
+		)
+	} else {
+		c.rateLimitStore = ratelimit.NewMemoryStore()
+	}
+
+	db := c.db.GetDB()
+	c.licenseService = application.NewLicenseService(db)
+	c.quotaService = application.NewQuotaService(
+		c.cfg.Quota,
+		c.cfg.Storage.SpaceQuota,
+		c.licenseService,
+		c.spaceRepository,
+		c.baseRepository,
+		c.tableRepository,
+		c.fieldRepository,
+		c.viewRepository,
+		c.recordRepository,
+		repository.NewStorageUsageRepository(db),
+		c.rateLimitStore,
+		c.permissionServiceV2,
+	)
+	c.recordService.SetQuotaService(c.quotaService)
+	c.attachmentService.SetQuotaService(c.quotaService)
 }
 
 // initWebSocketService 初始化 WebSocket 服务
@@ -300,9 +658,61 @@ func (c *Container) initWebSocketService() {
 	// 创建 WebSocket Service
 	c.wsService = websocket.NewService(c.wsManager, logger.Logger)
 
+	// ✨ 可靠投递：频道消息编号并保留回放缓冲，断线重连后按序号补发；
+	// 频道消息只投递给本实例的连接，序号和缓冲也按实例保存，重连到其他实例时重新同步
+	c.wsManager.SetReplayStore(websocket.NewMemoryReplayStore(
+		websocket.DefaultReplayCapacity, websocket.DefaultReplayTTL))
+
 	// 在后台启动 Manager
 	go c.wsManager.Run(context.Background())
 
+	// ✨ WebSocket 连接指标（抓取时读取）
+	wsStat := func(key string) func() float64 {
+		return func() float64 {
+			switch v := c.wsManager.GetStats()[key].(type) {
+			case int:
+				return float64(v)
+			case int64:
+				return float64(v)
+			}
+			return 0
+		}
+	}
+	for _, gauge := range []struct{ name, help, key string }{
+		{"connections", "当前 WebSocket 连接数", "total_connections"},
+		{"users", "当前在线的 WebSocket 用户数", "total_users"},
+		{"slow_consumers", "因消费过慢被断开的连接累计数", "slow_consumers"},
+	} {
+		if err := observability.RegisterGaugeFunc("websocket", gauge.name, gauge.help, wsStat(gauge.key)); err != nil {
+			logger.Warn("注册WebSocket指标失败", logger.String("metric", gauge.name), logger.ErrorField(err))
+		}
+	}
+
+	// ✨ 订阅授权：按表/视图/记录权限校验，分享链接只能订阅被分享视图；
+	// 协作者角色变更/移除、分享关闭后重新校验并强制退订，拒绝记录审计日志
+	subscriptionAuthorizer := application.NewWebSocketSubscriptionAuthorizer(c.permissionServiceV2, c.viewRepository)
+	subscriptionAuthorizer.SetRowPermissionService(c.rowPermission, c.recordRepository)
+	c.wsGuard = websocket.NewSubscriptionGuard(c.wsManager, subscriptionAuthorizer, logger.Logger)
+	c.wsGuard.SetAuditor(repository.NewSubscriptionAuditRepository(c.db.GetDB()))
+	c.collaboratorService.SetSubscriptionRevalidator(c.wsGuard)
+	c.viewService.SetSubscriptionRevalidator(c.wsGuard)
+
+	// ✨ 视图实时查询：返回快照，记录或视图条件变更后推送结果差异；
+	// 配置 Redis 时变更通知经 Pub/Sub 广播到所有实例
+	c.wsLive = websocket.NewLiveQueryManager(
+		c.wsManager,
+		application.NewLiveQueryService(c.viewService, subscriptionAuthorizer),
+		logger.Logger,
+	)
+	if c.cacheClient != nil {
+		c.wsPubSub = pubsub.NewRedisPubSub(c.cacheClient.GetClient(), logger.Logger)
+		if err := c.wsLive.SetPubSub(c.wsPubSub); err != nil {
+			logger.Warn("实时查询跨实例通知未启用", logger.ErrorField(err))
+		}
+	}
+	c.recordService.SetLiveQueryNotifier(c.wsLive)
+	c.viewService.SetLiveQueryNotifier(c.wsLive)
+
 	// ✅ 设置字段服务的广播器
 	if c.fieldService != nil {
 		fieldBroadcaster := application.NewFieldBroadcaster(c.wsService)
@@ -374,6 +784,11 @@ func (c *Container) Close() {
 		logger.Info("✅ 数据库连接已关闭")
 	}
 
+	// 关闭实时查询的 Pub/Sub 订阅（需在缓存连接之前）
+	if c.wsPubSub != nil {
+		c.wsPubSub.Close()
+	}
+
 	// 关闭缓存连接
 	if c.cacheClient != nil {
 		c.cacheClient.Close()
@@ -509,6 +924,66 @@ func (c *Container) RecordService() *application.RecordService {
 	return c.recordService
 }
 
+// RowPermissionService 获取行级权限服务 ✨
+func (c *Container) RowPermissionService() *application.RowPermissionService {
+	return c.rowPermission
+}
+
+// LinkRecordService 获取 Link 字段关联记录服务 ✨
+func (c *Container) LinkRecordService() *application.LinkRecordService {
+	return c.linkRecordService
+}
+
+// AIFieldService 获取 AI 字段生成服务 ✨
+func (c *Container) AIFieldService() *application.AIFieldService {
+	return c.aiFieldService
+}
+
+// ButtonService 获取按钮字段点击服务 ✨
+func (c *Container) ButtonService() *application.ButtonService {
+	return c.buttonService
+}
+
+// AttachmentService 获取附件服务 ✨
+func (c *Container) AttachmentService() *application.AttachmentService {
+	return c.attachmentService
+}
+
+// NotificationService 获取通知服务 ✨
+func (c *Container) NotificationService() notification.Service {
+	return c.notificationService
+}
+
+// ReminderService 获取记录提醒服务 ✨
+func (c *Container) ReminderService() *application.ReminderService {
+	return c.reminderService
+}
+
+// AlertService 获取告警规则服务 ✨
+func (c *Container) AlertService() *application.AlertService {
+	return c.alertService
+}
+
+// TableOpenAPIService 获取表格记录接口文档服务 ✨
+func (c *Container) TableOpenAPIService() *application.TableOpenAPIService {
+	return c.tableOpenAPIService
+}
+
+// GraphQLService 获取 GraphQL 服务 ✨
+func (c *Container) GraphQLService() *application.GraphQLService {
+	return c.graphQLService
+}
+
+// QuotaService 获取空间配额服务 ✨
+func (c *Container) QuotaService() *application.QuotaService {
+	return c.quotaService
+}
+
+// RateLimitStore 获取限流存储 ✨
+func (c *Container) RateLimitStore() ratelimit.Store {
+	return c.rateLimitStore
+}
+
 // ViewService 获取视图服务
 func (c *Container) ViewService() *application.ViewService {
 	return c.viewService
@@ -556,6 +1031,16 @@ func (c *Container) WebSocketManager() *websocket.Manager {
 	return c.wsManager
 }
 
+// WebSocketSubscriptionGuard 获取 WebSocket 订阅守卫 ✨
+func (c *Container) WebSocketSubscriptionGuard() *websocket.SubscriptionGuard {
+	return c.wsGuard
+}
+
+// WebSocketLiveQueries 获取 WebSocket 实时查询管理器 ✨
+func (c *Container) WebSocketLiveQueries() *websocket.LiveQueryManager {
+	return c.wsLive
+}
+
 // WebSocketService 获取 WebSocket 服务 ✨
 func (c *Container) WebSocketService() websocket.Service {
 	return c.wsService
@@ -593,6 +1078,35 @@ func (c *Container) StartServices(ctx context.Context) {
 	// - WebSocket 服务
 	// - 计算任务队列
 
+	// ✨ 附件预览 worker
+	if c.previewService != nil {
+		c.previewService.Start(ctx)
+	}
+
+	// ✨ 定时清理过期上传令牌和被放弃的断点续传分片
+	if c.attachmentService != nil {
+		go c.attachmentService.RunTokenCleanup(ctx, time.Hour)
+	}
+
+	// ✨ 通知：免打扰顺延、失败重试和摘要到期后投递
+	if c.notificationWorker != nil {
+		interval := c.cfg.Notification.DeliveryInterval
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		go c.notificationWorker.Run(ctx, interval)
+	}
+
+	// ✨ 记录提醒：按计划时间发送，停机期间到期的提醒在宽限期内补发
+	if c.reminderService != nil {
+		go c.reminderService.Run(ctx, c.cfg.Notification.ReminderInterval)
+	}
+
+	// ✨ 告警：按间隔读取规则并评估本进程的 Prometheus 指标
+	if c.alertingSystem != nil && c.cfg.Observability.Alerting.Enabled {
+		go c.alertingSystem.Start(ctx, monitoring.NewRegistryMetricsStorage(observability.Registry))
+	}
+
 	logger.Info("✅ 后台服务启动完成")
 }
 
@@ -601,6 +1115,9 @@ func (c *Container) StopServices() {
 	logger.Info("停止后台服务...")
 
 	// 停止后台任务（优雅关闭所有后台服务）
+	if c.previewService != nil {
+		c.previewService.Stop()
+	}
 
 	logger.Info("✅ 后台服务已停止")
 }
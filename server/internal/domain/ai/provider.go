@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"context"
+	"errors"
+
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+)
+
+// ErrProviderNotFound 未配置指定的 AI 提供者
+var ErrProviderNotFound = errors.New("ai provider not found")
+
+// CompletionRequest 一次文本生成请求
+type CompletionRequest struct {
+	Model        string   // 模型，为空时使用提供者的默认模型
+	SystemPrompt string   // 系统提示词
+	Prompt       string   // 用户提示词（已完成字段替换）
+	Temperature  *float64 // 采样温度
+	MaxTokens    int      // 最大生成 token 数，0 表示不限制
+}
+
+// NewCompletionRequest 由 AI 字段选项和渲染后的提示词创建生成请求
+func NewCompletionRequest(options *fieldValueobject.AIOptions, prompt string) CompletionRequest {
+	return CompletionRequest{
+		Model:        options.Model,
+		SystemPrompt: options.SystemPrompt,
+		Prompt:       prompt,
+		Temperature:  options.Temperature,
+		MaxTokens:    options.MaxTokens,
+	}
+}
+
+// CompletionResult 文本生成结果
+type CompletionResult struct {
+	Text             string // 生成的文本
+	Model            string // 实际使用的模型
+	PromptTokens     int    // 输入 token 数
+	CompletionTokens int    // 输出 token 数
+}
+
+// TotalTokens 本次生成消耗的 token 总数
+func (r *CompletionResult) TotalTokens() int {
+	return r.PromptTokens + r.CompletionTokens
+}
+
+// Provider AI 文本生成提供者
+// 实现需自行处理限流，可被多个协程并发调用
+type Provider interface {
+	// Name 提供者名称（配置中的键）
+	Name() string
+	// DefaultModel 未指定模型时使用的模型
+	DefaultModel() string
+	// Complete 生成文本
+	Complete(ctx context.Context, req CompletionRequest) (*CompletionResult, error)
+}
+
+// ProviderRegistry 按名称查找 AI 提供者
+type ProviderRegistry interface {
+	// Get 获取提供者，name 为空时返回默认提供者；未配置时返回 ErrProviderNotFound
+	Get(name string) (Provider, error)
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+// Usage 一次 AI 生成的 token 用量（按 Base 计量）
+type Usage struct {
+	BaseID           string
+	Provider         string
+	Model            string
+	Requests         int
+	PromptTokens     int
+	CompletionTokens int
+	Date             time.Time // 统计日期（UTC，截断到天）
+}
+
+// NewUsage 由生成结果创建用量记录
+func NewUsage(baseID, provider string, result *CompletionResult, at time.Time) *Usage {
+	return &Usage{
+		BaseID:           baseID,
+		Provider:         provider,
+		Model:            result.Model,
+		Requests:         1,
+		PromptTokens:     result.PromptTokens,
+		CompletionTokens: result.CompletionTokens,
+		Date:             UsageDate(at),
+	}
+}
+
+// UsageDate 用量统计日期：UTC 当天零点
+func UsageDate(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// UsageSummary Base 在一段时间内的 AI 用量汇总
+type UsageSummary struct {
+	BaseID           string        `json:"baseId"`
+	From             time.Time     `json:"from"`
+	To               time.Time     `json:"to"`
+	Requests         int64         `json:"requests"`
+	PromptTokens     int64         `json:"promptTokens"`
+	CompletionTokens int64         `json:"completionTokens"`
+	TotalTokens      int64         `json:"totalTokens"`
+	Items            []*UsageEntry `json:"items"`
+}
+
+// UsageEntry 按日期、提供者、模型分组的用量
+type UsageEntry struct {
+	Date             time.Time `json:"date"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model"`
+	Requests         int64     `json:"requests"`
+	PromptTokens     int64     `json:"promptTokens"`
+	CompletionTokens int64     `json:"completionTokens"`
+}
+
+// UsageRepository AI 用量仓储接口
+type UsageRepository interface {
+	// Record 累加一次用量（同一 Base、日期、提供者、模型合并为一行）
+	Record(ctx context.Context, usage *Usage) error
+	// List 查询 Base 在 [from, to] 日期范围内的用量明细
+	List(ctx context.Context, baseID string, from, to time.Time) ([]*UsageEntry, error)
+}
@@ -0,0 +1,129 @@
+package attachment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// Blob 按内容寻址存储的文件对象
+// 内容相同的附件共用一个 Blob；附件被最后一个单元格释放后删除（见 AttachmentLink），
+// 不再被任何附件引用的 Blob 在宽限期后由垃圾回收删除
+type Blob struct {
+	Hash         string    `json:"hash"` // SHA-256（十六进制小写）
+	Path         string    `json:"path"`
+	Size         int64     `json:"size"`
+	MimeType     string    `json:"mimetype"`
+	CreatedTime  time.Time `json:"created_time"`
+	LastUsedTime time.Time `json:"last_used_time"`
+}
+
+// NewBlob 创建 Blob
+// 路径带随机后缀：回收与重新上传同一内容并发时，新旧对象不会落在同一路径上互相删除
+func NewBlob(hash, mimeType string, size int64) *Blob {
+	return &Blob{
+		Hash:         hash,
+		Path:         fmt.Sprintf("blobs/%s/%s/%s_%s", hash[:2], hash[2:4], hash, utils.GenerateNanoID(6)),
+		Size:         size,
+		MimeType:     mimeType,
+		CreatedTime:  time.Now(),
+		LastUsedTime: time.Now(),
+	}
+}
+
+// BlobGracePeriod 最近使用过的 Blob 在宽限期内不会被回收，
+// 覆盖“已命中 Blob、附件尚未写入”的窗口
+const BlobGracePeriod = time.Hour
+
+// blobCollectBatch 每批回收的 Blob 数
+const blobCollectBatch = 100
+
+// IsValidHash 检查是否为 SHA-256 十六进制小写摘要
+func IsValidHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// hashObject 流式计算存储对象的 SHA-256
+func hashObject(ctx context.Context, storage Storage, path string) (string, error) {
+	reader, err := storage.Download(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// copyObject 复制存储对象，存储支持合并时直接由存储完成
+func copyObject(ctx context.Context, storage Storage, source, target string, size int64, contentType string) error {
+	if composer, ok := storage.(Composer); ok {
+		return composer.Compose(ctx, target, []string{source}, contentType)
+	}
+	reader, err := storage.Download(ctx, source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return storage.Upload(ctx, target, reader, size, contentType)
+}
+
+// GCResult 一次垃圾回收的结果
+type GCResult struct {
+	Blobs int   `json:"blobs"`
+	Bytes int64 `json:"bytes"`
+}
+
+// DuplicateBlob 被多个附件共用的 Blob
+type DuplicateBlob struct {
+	Hash       string `json:"hash"`
+	Name       string `json:"name"`
+	MimeType   string `json:"mimetype"`
+	Size       int64  `json:"size"`
+	Copies     int64  `json:"copies"`     // 共用该内容的附件数
+	References int64  `json:"references"` // 引用这些附件的单元格数
+	SavedBytes int64  `json:"saved_bytes"`
+}
+
+// DedupStats 一组表内附件的去重统计
+type DedupStats struct {
+	Files        int64            `json:"files"`
+	LogicalBytes int64            `json:"logical_bytes"` // 不去重时需要的存储
+	StoredBytes  int64            `json:"stored_bytes"`  // 实际占用的存储
+	UniqueBlobs  int64            `json:"unique_blobs"`
+	Duplicates   []*DuplicateBlob `json:"duplicates"`
+}
+
+// DedupReport 空间去重报告
+type DedupReport struct {
+	SpaceID string `json:"space_id"`
+	*DedupStats
+	SavedBytes   int64   `json:"saved_bytes"`
+	SavingsRatio float64 `json:"savings_ratio"` // 节省的存储占逻辑大小的比例
+}
+
+// NewDedupReport 由统计生成报告
+func NewDedupReport(spaceID string, stats *DedupStats) *DedupReport {
+	report := &DedupReport{SpaceID: spaceID, DedupStats: stats}
+	report.SavedBytes = stats.LogicalBytes - stats.StoredBytes
+	if stats.LogicalBytes > 0 {
+		report.SavingsRatio = float64(report.SavedBytes) / float64(stats.LogicalBytes)
+	}
+	return report
+}
@@ -0,0 +1,263 @@
+package attachment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+// memAttachmentRepo 内存附件仓储（删除即移除）
+type memAttachmentRepo struct {
+	items map[string]*AttachmentItem
+}
+
+func (r *memAttachmentRepo) CreateAttachment(ctx context.Context, item *AttachmentItem) error {
+	r.items[item.ID] = item
+	return nil
+}
+
+func (r *memAttachmentRepo) GetAttachmentByID(ctx context.Context, id string) (*AttachmentItem, error) {
+	if item, ok := r.items[id]; ok {
+		return item, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *memAttachmentRepo) GetAttachmentByToken(ctx context.Context, token string) (*AttachmentItem, error) {
+	return r.find(func(item *AttachmentItem) bool { return item.Token == token })
+}
+
+func (r *memAttachmentRepo) GetAttachmentByPath(ctx context.Context, path string) (*AttachmentItem, error) {
+	return r.find(func(item *AttachmentItem) bool { return item.Path == path })
+}
+
+func (r *memAttachmentRepo) UpdateAttachment(ctx context.Context, item *AttachmentItem) error {
+	r.items[item.ID] = item
+	return nil
+}
+
+func (r *memAttachmentRepo) DeleteAttachment(ctx context.Context, id string) error {
+	delete(r.items, id)
+	return nil
+}
+
+func (r *memAttachmentRepo) ListAttachments(ctx context.Context, tableID, fieldID, recordID string) ([]*AttachmentItem, error) {
+	return nil, nil
+}
+
+func (r *memAttachmentRepo) GetAttachmentStats(ctx context.Context, tableID string) (*AttachmentStats, error) {
+	return &AttachmentStats{}, nil
+}
+
+func (r *memAttachmentRepo) ListPendingPreviews(ctx context.Context, limit int) ([]*AttachmentItem, error) {
+	return nil, nil
+}
+
+func (r *memAttachmentRepo) FindAttachmentByHash(ctx context.Context, hash string, tableIDs []string) (*AttachmentItem, error) {
+	return r.find(func(item *AttachmentItem) bool { return item.Hash == hash })
+}
+
+func (r *memAttachmentRepo) GetDedupStats(ctx context.Context, tableIDs []string, top int) (*DedupStats, error) {
+	return &DedupStats{}, nil
+}
+
+func (r *memAttachmentRepo) find(match func(*AttachmentItem) bool) (*AttachmentItem, error) {
+	for _, item := range r.items {
+		if match(item) {
+			return item, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+// memBlobRepo 内存 Blob 仓储，按附件仓储判断引用
+type memBlobRepo struct {
+	blobs       map[string]*Blob
+	attachments *memAttachmentRepo
+}
+
+func (r *memBlobRepo) CreateBlob(ctx context.Context, blob *Blob) (*Blob, error) {
+	if existing, ok := r.blobs[blob.Hash]; ok {
+		existing.LastUsedTime = time.Now()
+		return existing, nil
+	}
+	r.blobs[blob.Hash] = blob
+	return blob, nil
+}
+
+func (r *memBlobRepo) TouchBlob(ctx context.Context, hash string) (*Blob, error) {
+	blob, ok := r.blobs[hash]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	blob.LastUsedTime = time.Now()
+	return blob, nil
+}
+
+func (r *memBlobRepo) ListOrphanBlobs(ctx context.Context, before time.Time, limit int) ([]*Blob, error) {
+	var orphans []*Blob
+	for hash, blob := range r.blobs {
+		if r.orphan(hash, before) && len(orphans) < limit {
+			orphans = append(orphans, blob)
+		}
+	}
+	return orphans, nil
+}
+
+func (r *memBlobRepo) DeleteOrphanBlob(ctx context.Context, hash string, before time.Time) (bool, error) {
+	if !r.orphan(hash, before) {
+		return false, nil
+	}
+	delete(r.blobs, hash)
+	return true, nil
+}
+
+func (r *memBlobRepo) orphan(hash string, before time.Time) bool {
+	blob, ok := r.blobs[hash]
+	if !ok || !blob.LastUsedTime.Before(before) {
+		return false
+	}
+	_, err := r.attachments.FindAttachmentByHash(context.Background(), hash, nil)
+	return err == errors.ErrNotFound
+}
+
+type dedupFixture struct {
+	service     Service
+	storage     *memStorage
+	tokens      *memTokenRepo
+	attachments *memAttachmentRepo
+	blobs       *memBlobRepo
+}
+
+func newDedupFixture() *dedupFixture {
+	f := &dedupFixture{
+		storage:     &memStorage{objects: map[string][]byte{}},
+		tokens:      &memTokenRepo{tokens: map[string]*UploadToken{}},
+		attachments: &memAttachmentRepo{items: map[string]*AttachmentItem{}},
+	}
+	f.blobs = &memBlobRepo{blobs: map[string]*Blob{}, attachments: f.attachments}
+	f.service = NewService(f.attachments, f.tokens, nil, f.blobs, f.storage, nil, stubValidator{}, nil, nil, zap.NewNop())
+	return f
+}
+
+// upload 签发令牌、上传内容并通知完成
+func (f *dedupFixture) upload(t *testing.T, token, filename, content string) *AttachmentItem {
+	f.tokens.tokens[token] = &UploadToken{
+		Token: token, TableID: "tbl", FieldID: "fld", RecordID: "rec",
+		ExpiresAt: time.Now().Add(time.Hour), CreatedTime: time.Now(),
+	}
+	ctx := context.Background()
+	require.NoError(t, f.service.UploadFile(ctx, token, strings.NewReader(content), filename, int64(len(content))))
+	response, err := f.service.NotifyUpload(ctx, token, filename)
+	require.NoError(t, err)
+	return response.Attachment
+}
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestIsValidHash(t *testing.T) {
+	assert.True(t, IsValidHash(sha256Hex("logo")))
+	assert.False(t, IsValidHash(strings.ToUpper(sha256Hex("logo"))))
+	assert.False(t, IsValidHash("abc"))
+	assert.False(t, IsValidHash(strings.Repeat("g", 64)))
+}
+
+func TestNewDedupReport(t *testing.T) {
+	report := NewDedupReport("spc", &DedupStats{Files: 4, LogicalBytes: 400, StoredBytes: 100, UniqueBlobs: 1})
+	assert.Equal(t, int64(300), report.SavedBytes)
+	assert.InDelta(t, 0.75, report.SavingsRatio, 0.0001)
+	assert.Zero(t, NewDedupReport("spc", &DedupStats{}).SavingsRatio)
+}
+
+func TestDedup_SameContentSharesBlob(t *testing.T) {
+	f := newDedupFixture()
+
+	first := f.upload(t, "tok_first_upload", "logo.png", "same bytes")
+	second := f.upload(t, "tok_second_upload", "brand.png", "same bytes")
+	other := f.upload(t, "tok_other_upload", "contract.pdf", "other bytes")
+
+	assert.Equal(t, sha256Hex("same bytes"), first.Hash)
+	assert.Equal(t, first.Path, second.Path)
+	assert.NotEqual(t, first.Path, other.Path)
+	assert.True(t, strings.HasPrefix(first.Path, "blobs/"+first.Hash[:2]+"/"))
+	assert.Contains(t, *second.PresignedURL, "token=tok_second_upload")
+
+	// 上传的暂存文件已移入 Blob，只剩两份内容
+	assert.Len(t, f.storage.objects, 2)
+
+	// 共用 Blob 时按令牌返回各自的文件名
+	ctx := context.Background()
+	read, err := f.service.ReadFile(ctx, second.Path, second.Token)
+	require.NoError(t, err)
+	read.Reader.Close()
+	assert.Equal(t, "brand.png", read.Name)
+}
+
+func TestDedup_InstantUpload(t *testing.T) {
+	f := newDedupFixture()
+	ctx := context.Background()
+	existing := f.upload(t, "tok_existing_file", "logo.png", "logo bytes")
+
+	f.tokens.tokens["tok_instant_file"] = &UploadToken{
+		Token: "tok_instant_file", TableID: "tbl", FieldID: "fld", RecordID: "rec2",
+		MaxSize: 1024, ExpiresAt: time.Now().Add(time.Hour), CreatedTime: time.Now(),
+	}
+
+	_, err := f.service.InstantUpload(ctx, "tok_instant_file", "logo.png", sha256Hex("unknown"), 10)
+	assert.Equal(t, "NOT_FOUND", appErrorCode(err))
+	_, err = f.service.InstantUpload(ctx, "tok_instant_file", "logo.png", existing.Hash, existing.Size+1)
+	assert.Equal(t, "NOT_FOUND", appErrorCode(err))
+	_, err = f.service.InstantUpload(ctx, "tok_instant_file", "logo.png", "not-a-hash", existing.Size)
+	assert.Equal(t, "BAD_REQUEST", appErrorCode(err))
+
+	response, err := f.service.InstantUpload(ctx, "tok_instant_file", "copy.png", existing.Hash, existing.Size)
+	require.NoError(t, err)
+	assert.Equal(t, existing.Path, response.Attachment.Path)
+	assert.Equal(t, "rec2", response.Attachment.RecordID)
+	assert.Len(t, f.storage.objects, 1)
+
+	// 令牌用过即作废
+	_, err = f.service.InstantUpload(ctx, "tok_instant_file", "copy.png", existing.Hash, existing.Size)
+	assert.Error(t, err)
+}
+
+func TestDedup_CollectOrphanBlobs(t *testing.T) {
+	f := newDedupFixture()
+	ctx := context.Background()
+	first := f.upload(t, "tok_first_upload", "a.txt", "shared")
+	second := f.upload(t, "tok_second_upload", "b.txt", "shared")
+
+	// 删除一个附件不影响仍被引用的 Blob
+	require.NoError(t, f.service.DeleteFile(ctx, first.ID))
+	f.blobs.blobs[second.Hash].LastUsedTime = time.Now().Add(-2 * BlobGracePeriod)
+	result, err := f.service.CollectOrphanBlobs(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Blobs)
+	assert.Contains(t, f.storage.objects, second.Path)
+
+	// 最后一个附件删除后，宽限期内仍保留
+	require.NoError(t, f.service.DeleteFile(ctx, second.ID))
+	f.blobs.blobs[second.Hash].LastUsedTime = time.Now()
+	result, err = f.service.CollectOrphanBlobs(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Blobs)
+
+	f.blobs.blobs[second.Hash].LastUsedTime = time.Now().Add(-2 * BlobGracePeriod)
+	result, err = f.service.CollectOrphanBlobs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &GCResult{Blobs: 1, Bytes: int64(len("shared"))}, result)
+	assert.Empty(t, f.storage.objects)
+	assert.Empty(t, f.blobs.blobs)
+}
@@ -1,6 +1,8 @@
 package attachment
 
 import (
+	"encoding/json"
+	"io"
 	"time"
 
 	"github.com/easyspace-ai/luckdb/server/pkg/utils"
@@ -14,11 +16,20 @@ type AttachmentItem struct {
 	Token          string    `json:"token"`
 	Size           int64     `json:"size"`
 	MimeType       string    `json:"mimetype"`
+	Hash           string    `json:"hash,omitempty"` // 内容 SHA-256，Path 指向对应的 Blob
 	PresignedURL   *string   `json:"presigned_url,omitempty"`
 	Width          *int      `json:"width,omitempty"`
 	Height         *int      `json:"height,omitempty"`
 	SmallThumbnail *string   `json:"sm_thumbnail_url,omitempty"`
 	LargeThumbnail *string   `json:"lg_thumbnail_url,omitempty"`
+	TextPreview    *string   `json:"text_preview_url,omitempty"`
+	Duration       *float64  `json:"duration,omitempty"`
+	PageCount      *int      `json:"page_count,omitempty"`
+	PreviewStatus  string    `json:"preview_status,omitempty"`
+	TableID        string    `json:"table_id,omitempty"`
+	FieldID        string    `json:"field_id,omitempty"`
+	RecordID       string    `json:"record_id,omitempty"`
+	CreatedBy      string    `json:"created_by,omitempty"`
 	CreatedTime    time.Time `json:"created_time"`
 	UpdatedTime    time.Time `json:"updated_time"`
 }
@@ -57,6 +68,74 @@ func (a *AttachmentItem) SetPresignedURL(url string) {
 	a.UpdatedTime = time.Now()
 }
 
+// ToCellValue 转换为附件单元格中的一项
+func (a *AttachmentItem) ToCellValue() map[string]interface{} {
+	value := map[string]interface{}{
+		"id":       a.ID,
+		"name":     a.Name,
+		"token":    a.Token,
+		"size":     a.Size,
+		"mimetype": a.MimeType,
+		"path":     a.Path,
+	}
+	if a.Width != nil && a.Height != nil {
+		value["width"] = *a.Width
+		value["height"] = *a.Height
+	}
+	if a.PresignedURL != nil {
+		value["presignedUrl"] = *a.PresignedURL
+	}
+	if a.PreviewStatus != "" {
+		value["previewStatus"] = a.PreviewStatus
+	}
+	// 缩略图等预览产物由后台生成后通过 PreviewCellValue 写入
+	return value
+}
+
+// CellItems 解析附件单元格值（数组或 JSON 字符串），无法解析的项会被忽略
+func CellItems(value interface{}) []map[string]interface{} {
+	var raw []interface{}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		raw = v
+	case []map[string]interface{}:
+		return v
+	case string:
+		if err := json.Unmarshal([]byte(v), &raw); err != nil {
+			return nil
+		}
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil || json.Unmarshal(encoded, &raw) != nil {
+			return nil
+		}
+	}
+
+	items := make([]map[string]interface{}, 0, len(raw))
+	for _, item := range raw {
+		if m, ok := item.(map[string]interface{}); ok {
+			items = append(items, m)
+		}
+	}
+	return items
+}
+
+// CellTokens 返回附件单元格引用的附件 token -> 文件名，缺少 token 的项会被忽略
+func CellTokens(value interface{}) map[string]string {
+	tokens := make(map[string]string)
+	for _, item := range CellItems(value) {
+		token, _ := item["token"].(string)
+		if token == "" {
+			continue
+		}
+		name, _ := item["name"].(string)
+		tokens[token] = name
+	}
+	return tokens
+}
+
 // IsImage 检查是否为图片
 func (a *AttachmentItem) IsImage() bool {
 	return len(a.MimeType) >= 5 && a.MimeType[:5] == "image"
@@ -189,6 +268,13 @@ type NotifyResponse struct {
 	Message    string          `json:"message,omitempty"`
 }
 
+// InstantUploadRequest 秒传请求：空间内已有相同内容时无需再上传文件
+type InstantUploadRequest struct {
+	Filename string `json:"filename" binding:"required"`
+	Hash     string `json:"hash" binding:"required"` // SHA-256（十六进制）
+	Size     int64  `json:"size" binding:"required"`
+}
+
 // ReadRequest 读取请求
 type ReadRequest struct {
 	Path                       string `form:"path" binding:"required"`
@@ -197,11 +283,14 @@ type ReadRequest struct {
 }
 
 // ReadResponse 读取响应
+// Reader 由调用方负责关闭；实现了 io.ReadSeeker 时可按 Range 请求分段读取
 type ReadResponse struct {
-	Data     []byte            `json:"data"`
+	Reader   io.ReadCloser     `json:"-"`
+	Name     string            `json:"name"`
 	Headers  map[string]string `json:"headers"`
 	MimeType string            `json:"mime_type"`
 	Size     int64             `json:"size"`
+	ModTime  time.Time         `json:"mod_time"`
 }
 
 // AttachmentStats 附件统计信息
@@ -216,6 +305,31 @@ type AttachmentStats struct {
 	LastUploaded  time.Time `json:"last_uploaded"`
 }
 
+// CellLink 附件与单元格的引用关系：一个附件被某条记录的附件字段引用
+// 附件在最后一个引用它的单元格被清空后删除
+type CellLink struct {
+	AttachmentID string `json:"attachment_id"`
+	Token        string `json:"token"`
+	Name         string `json:"name"`
+	TableID      string `json:"table_id"`
+	RecordID     string `json:"record_id"`
+	FieldID      string `json:"field_id"`
+	CreatedBy    string `json:"created_by"`
+}
+
+// SpaceUsage 空间的附件存储用量
+type SpaceUsage struct {
+	SpaceID   string `json:"space_id"`
+	UsedBytes int64  `json:"used_bytes"`
+	FileCount int64  `json:"file_count"`
+	Quota     int64  `json:"quota"` // 字节，0 表示不限制
+}
+
+// Allows 再写入 size 字节后是否仍在配额内
+func (u *SpaceUsage) Allows(size int64) bool {
+	return u.Quota <= 0 || u.UsedBytes+size <= u.Quota
+}
+
 // AttachmentStorageConfig 附件存储配置
 type AttachmentStorageConfig struct {
 	Type         string   `json:"type"` // local, s3, oss, etc.
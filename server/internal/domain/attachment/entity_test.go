@@ -0,0 +1,60 @@
+package attachment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellTokens(t *testing.T) {
+	assert.Empty(t, CellTokens(nil))
+	assert.Empty(t, CellTokens("not json"))
+
+	cell := []interface{}{
+		map[string]interface{}{"token": "tok_a", "name": "a.png"},
+		map[string]interface{}{"name": "missing-token.png"},
+		"unexpected",
+	}
+	assert.Equal(t, map[string]string{"tok_a": "a.png"}, CellTokens(cell))
+
+	// 数据库中读出的 JSON 字符串
+	assert.Equal(t, map[string]string{"tok_b": "b.pdf"}, CellTokens(`[{"token":"tok_b","name":"b.pdf"}]`))
+}
+
+func TestAttachmentItem_ToCellValue(t *testing.T) {
+	item := NewAttachmentItem("a.png", "attachments/a.png", "tok_a", "image/png", 10)
+	item.SetPresignedURL("/api/v1/attachments/read/attachments/a.png")
+
+	value := item.ToCellValue()
+	assert.Equal(t, "tok_a", value["token"])
+	assert.Equal(t, "/api/v1/attachments/read/attachments/a.png", value["presignedUrl"])
+	assert.NotContains(t, value, "width")
+
+	// 单元格项经过解析后仍能找回 token
+	assert.Equal(t, map[string]string{"tok_a": "a.png"}, CellTokens([]interface{}{value}))
+}
+
+func TestSpaceUsage_Allows(t *testing.T) {
+	assert.True(t, (&SpaceUsage{UsedBytes: 1 << 40}).Allows(1))
+
+	usage := &SpaceUsage{UsedBytes: 90, Quota: 100}
+	assert.True(t, usage.Allows(10))
+	assert.False(t, usage.Allows(11))
+}
+
+func TestNarrowAllowedTypes(t *testing.T) {
+	configured := []string{"image/png", "image/jpeg"}
+	assert.Equal(t, configured, narrowAllowedTypes(nil, configured))
+	assert.Equal(t, []string{"image/png"}, narrowAllowedTypes([]string{"image/png", "text/html"}, configured))
+	assert.Equal(t, configured, narrowAllowedTypes([]string{"text/html"}, configured))
+	assert.Equal(t, []string{"text/html"}, narrowAllowedTypes([]string{"text/html"}, nil))
+}
+
+func TestGenerateFilePath_StableForToken(t *testing.T) {
+	s := &service{}
+	token := &UploadToken{Token: "abcdefgh12345", TableID: "tbl", FieldID: "fld", CreatedTime: time.Date(2026, 1, 31, 23, 59, 0, 0, time.UTC)}
+
+	// 上传和通知可能跨越零点，路径只取决于令牌
+	assert.Equal(t, "attachments/tbl/fld/2026/01/31/report_abcdefgh.pdf", s.generateFilePath(token, "report.pdf"))
+}
@@ -0,0 +1,162 @@
+package attachment
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 附件预览状态
+const (
+	PreviewStatusPending     = "pending"     // 等待后台生成
+	PreviewStatusReady       = "ready"       // 已生成
+	PreviewStatusFailed      = "failed"      // 生成出错
+	PreviewStatusUnsupported = "unsupported" // 文件类型不支持或缺少外部工具
+)
+
+// ErrPreviewUnavailable 生成预览所需的外部工具不可用（如未安装 ffmpeg），附件标记为不支持而不是失败
+var ErrPreviewUnavailable = stderrors.New("preview tool unavailable")
+
+// Preview 预览生成结果
+// 海报图为 JPEG，已按缩略图配置缩放；文本为纯文本/Markdown/CSV/办公文档的摘录
+type Preview struct {
+	SmallImage []byte
+	LargeImage []byte
+	Text       string
+	Width      *int
+	Height     *int
+	Duration   *float64 // 视频时长（秒）
+	PageCount  *int     // PDF/办公文档页数
+}
+
+// PreviewGenerator 附件预览生成器接口
+type PreviewGenerator interface {
+	// Supports 检查是否支持该文件类型
+	Supports(mimeType string) bool
+	// Generate 为本地文件生成预览
+	Generate(ctx context.Context, localPath, mimeType string, config *ThumbnailConfig) (*Preview, error)
+}
+
+// DefaultThumbnailConfig 默认缩略图配置
+func DefaultThumbnailConfig() *ThumbnailConfig {
+	return &ThumbnailConfig{
+		Enabled:     true,
+		SmallWidth:  300,
+		SmallHeight: 300,
+		LargeWidth:  1280,
+		LargeHeight: 1280,
+		Quality:     80,
+		Format:      "jpeg",
+	}
+}
+
+// 预览产物文件名
+const (
+	previewDir       = "previews/"
+	previewSmallFile = "small.jpg"
+	previewLargeFile = "large.jpg"
+	previewTextFile  = "text.txt"
+)
+
+// PreviewPaths 返回附件预览产物在存储中的路径
+func PreviewPaths(attachmentID string) (small, large, text string) {
+	dir := previewDir + attachmentID + "/"
+	return dir + previewSmallFile, dir + previewLargeFile, dir + previewTextFile
+}
+
+// ParsePreviewPath 解析预览产物路径，返回所属附件ID和产物的 MIME 类型
+func ParsePreviewPath(path string) (attachmentID, mimeType string, ok bool) {
+	if !strings.HasPrefix(path, previewDir) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(path, previewDir), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", false
+	}
+	switch parts[1] {
+	case previewSmallFile, previewLargeFile:
+		return parts[0], "image/jpeg", true
+	case previewTextFile:
+		return parts[0], "text/plain; charset=utf-8", true
+	}
+	return "", "", false
+}
+
+// SetPreviewStatus 设置预览状态
+func (a *AttachmentItem) SetPreviewStatus(status string) {
+	a.PreviewStatus = status
+	a.UpdatedTime = time.Now()
+}
+
+// ApplyPreview 记录预览结果，路径为空的产物不覆盖
+func (a *AttachmentItem) ApplyPreview(preview *Preview, smallPath, largePath, textPath string) {
+	if smallPath != "" {
+		a.SmallThumbnail = &smallPath
+	}
+	if largePath != "" {
+		a.LargeThumbnail = &largePath
+	}
+	if textPath != "" {
+		a.TextPreview = &textPath
+	}
+	if preview.Width != nil && preview.Height != nil {
+		a.Width = preview.Width
+		a.Height = preview.Height
+	}
+	if preview.Duration != nil {
+		a.Duration = preview.Duration
+	}
+	if preview.PageCount != nil {
+		a.PageCount = preview.PageCount
+	}
+	a.SetPreviewStatus(PreviewStatusReady)
+}
+
+// PreviewFiles 返回附件已生成的预览产物路径
+func (a *AttachmentItem) PreviewFiles() []string {
+	var files []string
+	for _, path := range []*string{a.SmallThumbnail, a.LargeThumbnail, a.TextPreview} {
+		if path != nil && *path != "" {
+			files = append(files, *path)
+		}
+	}
+	return files
+}
+
+// PreviewCellValue 返回写入附件单元格的预览信息，urlFor 把存储路径转换为访问URL
+func (a *AttachmentItem) PreviewCellValue(urlFor func(path string) string) map[string]interface{} {
+	value := map[string]interface{}{}
+	if a.PreviewStatus != "" {
+		value["previewStatus"] = a.PreviewStatus
+	}
+	if a.Width != nil && a.Height != nil {
+		value["width"] = *a.Width
+		value["height"] = *a.Height
+	}
+	if a.Duration != nil {
+		value["duration"] = *a.Duration
+	}
+	if a.PageCount != nil {
+		value["pageCount"] = *a.PageCount
+	}
+	if a.SmallThumbnail != nil {
+		value["smThumbnailUrl"] = urlFor(*a.SmallThumbnail)
+	}
+	if a.LargeThumbnail != nil {
+		value["lgThumbnailUrl"] = urlFor(*a.LargeThumbnail)
+	}
+	if a.TextPreview != nil {
+		value["textPreviewUrl"] = urlFor(*a.TextPreview)
+	}
+	return value
+}
+
+// previewName 预览产物的下载文件名
+func previewName(item *AttachmentItem, mimeType string) string {
+	if strings.HasPrefix(mimeType, "image/") {
+		return fmt.Sprintf("%s.preview.jpg", item.Name)
+	}
+	return fmt.Sprintf("%s.preview.txt", item.Name)
+}
@@ -0,0 +1,48 @@
+package attachment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePreviewPath(t *testing.T) {
+	small, large, text := PreviewPaths("att_1")
+
+	id, mimeType, ok := ParsePreviewPath(small)
+	assert.True(t, ok)
+	assert.Equal(t, "att_1", id)
+	assert.Equal(t, "image/jpeg", mimeType)
+
+	_, _, ok = ParsePreviewPath(large)
+	assert.True(t, ok)
+	_, mimeType, ok = ParsePreviewPath(text)
+	assert.True(t, ok)
+	assert.Equal(t, "text/plain; charset=utf-8", mimeType)
+
+	for _, path := range []string{"attachments/a.png", "previews/att_1/other.bin", "previews//small.jpg", "previews/a/b/small.jpg"} {
+		_, _, ok := ParsePreviewPath(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestAttachmentItem_ApplyPreview(t *testing.T) {
+	item := NewAttachmentItem("clip.mp4", "attachments/clip.mp4", "tok_v", "video/mp4", 10)
+	item.SetPreviewStatus(PreviewStatusPending)
+	assert.Equal(t, PreviewStatusPending, item.ToCellValue()["previewStatus"])
+
+	width, height, duration := 640, 360, 5.5
+	small, large, _ := PreviewPaths(item.ID)
+	item.ApplyPreview(&Preview{Width: &width, Height: &height, Duration: &duration}, small, large, "")
+
+	assert.Equal(t, PreviewStatusReady, item.PreviewStatus)
+	assert.Nil(t, item.TextPreview)
+	assert.Equal(t, []string{small, large}, item.PreviewFiles())
+
+	value := item.PreviewCellValue(func(path string) string { return "/read/" + path })
+	assert.Equal(t, "/read/"+small, value["smThumbnailUrl"])
+	assert.Equal(t, 5.5, value["duration"])
+	assert.Equal(t, 640, value["width"])
+	assert.NotContains(t, value, "textPreviewUrl")
+	assert.NotContains(t, value, "pageCount")
+}
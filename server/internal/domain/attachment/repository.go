@@ -1,6 +1,9 @@
 package attachment
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository 附件仓储接口
 type Repository interface {
@@ -20,6 +23,12 @@ type Repository interface {
 	ListAttachments(ctx context.Context, tableID, fieldID, recordID string) ([]*AttachmentItem, error)
 	// GetAttachmentStats 获取附件统计信息
 	GetAttachmentStats(ctx context.Context, tableID string) (*AttachmentStats, error)
+	// ListPendingPreviews 列出等待生成预览的附件
+	ListPendingPreviews(ctx context.Context, limit int) ([]*AttachmentItem, error)
+	// FindAttachmentByHash 在指定表中查找内容相同的附件
+	FindAttachmentByHash(ctx context.Context, hash string, tableIDs []string) (*AttachmentItem, error)
+	// GetDedupStats 统计指定表内附件的去重情况，top 为返回的重复内容条数
+	GetDedupStats(ctx context.Context, tableIDs []string, top int) (*DedupStats, error)
 }
 
 // UploadTokenRepository 上传令牌仓储接口
@@ -33,3 +42,51 @@ type UploadTokenRepository interface {
 	// CleanupExpiredTokens 清理过期令牌
 	CleanupExpiredTokens(ctx context.Context) error
 }
+
+// UploadSessionRepository 断点续传会话仓储接口
+type UploadSessionRepository interface {
+	// CreateUploadSession 创建会话
+	CreateUploadSession(ctx context.Context, session *UploadSession) error
+	// GetUploadSession 获取会话
+	GetUploadSession(ctx context.Context, id string) (*UploadSession, error)
+	// AdvanceUploadSession 追加分片并推进偏移量，仅当当前偏移量等于 session.Offset 时成功
+	AdvanceUploadSession(ctx context.Context, session *UploadSession, part string, length int64) (bool, error)
+	// DeleteUploadSession 删除会话
+	DeleteUploadSession(ctx context.Context, id string) error
+	// ListExpiredUploadSessions 列出已过期的会话
+	ListExpiredUploadSessions(ctx context.Context, limit int) ([]*UploadSession, error)
+}
+
+// BlobRepository 内容寻址存储对象仓储接口
+type BlobRepository interface {
+	// CreateBlob 保存 Blob；同一内容已存在时保留已有的并返回它
+	CreateBlob(ctx context.Context, blob *Blob) (*Blob, error)
+	// TouchBlob 获取 Blob 并刷新最近使用时间，不存在时返回 errors.ErrNotFound
+	TouchBlob(ctx context.Context, hash string) (*Blob, error)
+	// ListOrphanBlobs 列出在 before 之前最后使用、且没有附件引用的 Blob
+	ListOrphanBlobs(ctx context.Context, before time.Time, limit int) ([]*Blob, error)
+	// DeleteOrphanBlob 仍满足回收条件时删除 Blob 记录，返回是否删除
+	DeleteOrphanBlob(ctx context.Context, hash string, before time.Time) (bool, error)
+}
+
+// LinkRepository 附件单元格引用仓储接口
+type LinkRepository interface {
+	// CreateLinks 创建引用
+	CreateLinks(ctx context.Context, links []*CellLink) error
+	// ListLinks 列出记录上的全部引用
+	ListLinks(ctx context.Context, tableID, recordID string) ([]*CellLink, error)
+	// DeleteLinks 删除记录某个字段上指定附件的引用
+	DeleteLinks(ctx context.Context, tableID, recordID, fieldID string, tokens []string) error
+	// ListLinksByToken 列出引用某个附件的全部单元格
+	ListLinksByToken(ctx context.Context, token string) ([]*CellLink, error)
+	// CountLinks 统计附件剩余的引用数
+	CountLinks(ctx context.Context, token string) (int64, error)
+}
+
+// UsageRepository 空间存储用量仓储接口
+type UsageRepository interface {
+	// AddUsage 累加空间用量（删除附件时传负数）
+	AddUsage(ctx context.Context, spaceID string, bytes, files int64) error
+	// GetUsage 获取空间用量，没有记录时返回零用量
+	GetUsage(ctx context.Context, spaceID string) (*SpaceUsage, error)
+}
@@ -1,9 +1,12 @@
 package attachment
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"hash"
 	"io"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
@@ -32,14 +35,31 @@ type Service interface {
 	ListAttachments(ctx context.Context, tableID, fieldID, recordID string) ([]*AttachmentItem, error)
 	// GetAttachmentStats 获取附件统计
 	GetAttachmentStats(ctx context.Context, tableID string) (*AttachmentStats, error)
-	// CleanupExpiredTokens 清理过期令牌
+	// CleanupExpiredTokens 清理过期令牌和断点续传会话
 	CleanupExpiredTokens(ctx context.Context) error
+	// InstantUpload 秒传：引用内容相同的已有文件创建附件
+	InstantUpload(ctx context.Context, token, filename, hash string, size int64) (*NotifyResponse, error)
+	// CollectOrphanBlobs 回收没有附件引用的 Blob
+	CollectOrphanBlobs(ctx context.Context) (*GCResult, error)
+
+	// CreateUploadSession 创建断点续传会话
+	CreateUploadSession(ctx context.Context, token, filename string, size int64) (*UploadSession, error)
+	// GetUploadSession 获取断点续传会话
+	GetUploadSession(ctx context.Context, token, id string) (*UploadSession, error)
+	// UploadChunk 在会话当前偏移量处追加分片，length 为分片长度（未知时为 -1）
+	UploadChunk(ctx context.Context, token, id string, offset int64, reader io.Reader, length int64, checksum *ChunkChecksum) (*UploadSession, error)
+	// FinalizeUploadSession 把已收齐的分片合并为最终文件
+	FinalizeUploadSession(ctx context.Context, token, id string) (*UploadSession, error)
+	// AbortUploadSession 终止会话并删除已上传的分片
+	AbortUploadSession(ctx context.Context, token, id string) error
 }
 
 // service 附件服务实现
 type service struct {
 	repo               Repository
 	tokenRepo          UploadTokenRepository
+	sessionRepo        UploadSessionRepository
+	blobRepo           BlobRepository
 	storage            Storage
 	thumbnailGenerator ThumbnailGenerator
 	validator          FileValidator
@@ -52,6 +72,8 @@ type service struct {
 func NewService(
 	repo Repository,
 	tokenRepo UploadTokenRepository,
+	sessionRepo UploadSessionRepository,
+	blobRepo BlobRepository,
 	storage Storage,
 	thumbnailGenerator ThumbnailGenerator,
 	validator FileValidator,
@@ -62,6 +84,8 @@ func NewService(
 	return &service{
 		repo:               repo,
 		tokenRepo:          tokenRepo,
+		sessionRepo:        sessionRepo,
+		blobRepo:           blobRepo,
 		storage:            storage,
 		thumbnailGenerator: thumbnailGenerator,
 		validator:          validator,
@@ -73,16 +97,13 @@ func NewService(
 
 // GenerateSignature 生成上传签名
 func (s *service) GenerateSignature(ctx context.Context, userID string, req *SignatureRequest) (*SignatureResponse, error) {
-	// 设置默认值
+	// 设置默认值：请求只能收紧配置的限制，不能放宽
 	maxSize := req.MaxSize
-	if maxSize == 0 {
+	if maxSize <= 0 || (s.config.MaxFileSize > 0 && maxSize > s.config.MaxFileSize) {
 		maxSize = s.config.MaxFileSize
 	}
 
-	allowedTypes := req.AllowedTypes
-	if len(allowedTypes) == 0 {
-		allowedTypes = s.config.AllowedTypes
-	}
+	allowedTypes := narrowAllowedTypes(req.AllowedTypes, s.config.AllowedTypes)
 
 	// 创建上传令牌
 	uploadToken := NewUploadToken(userID, req.TableID, req.FieldID, req.RecordID, maxSize, allowedTypes)
@@ -98,7 +119,7 @@ func (s *service) GenerateSignature(ctx context.Context, userID string, req *Sig
 	}
 
 	// 生成上传URL
-	uploadURL := fmt.Sprintf("/api/attachments/upload/%s", uploadToken.Token)
+	uploadURL := fmt.Sprintf("/api/v1/attachments/upload/%s", uploadToken.Token)
 
 	response := &SignatureResponse{
 		Token:        uploadToken.Token,
@@ -186,6 +207,11 @@ func (s *service) NotifyUpload(ctx context.Context, token, filename string) (*No
 		return nil, errors.ErrBadRequest.WithDetails("Upload token has expired")
 	}
 
+	// 文件名决定存储路径，必须与上传时一致，且不能包含路径
+	if err := validateFilename(filename); err != nil {
+		return nil, err
+	}
+
 	// 生成文件路径
 	filePath := s.generateFilePath(uploadToken, filename)
 
@@ -231,15 +257,115 @@ func (s *service) NotifyUpload(ctx context.Context, token, filename string) (*No
 		mimeType = s.validator.GetMimeType(filename)
 	}
 
+	// 按内容存入 Blob，相同内容只保留一份
+	blob, err := s.storeBlob(ctx, filePath, mimeType, fileSize)
+	if err != nil {
+		s.logger.Error("Failed to store blob",
+			logger.String("token", token),
+			logger.String("file_path", filePath),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to store file")
+	}
+
+	return s.createAttachment(ctx, uploadToken, filename, blob, mimeType)
+}
+
+// InstantUpload 秒传：内容已存在时直接引用已有的 Blob 创建附件，无需再上传文件
+// 调用方负责确认该内容在当前空间内已被使用过，避免凭摘要读取其他空间的文件
+func (s *service) InstantUpload(ctx context.Context, token, filename, hash string, size int64) (*NotifyResponse, error) {
+	uploadToken, err := s.validUploadToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateFilename(filename); err != nil {
+		return nil, err
+	}
+	if !IsValidHash(hash) {
+		return nil, errors.ErrBadRequest.WithDetails("hash must be a lowercase hex SHA-256 digest")
+	}
+
+	blob, err := s.blobRepo.TouchBlob(ctx, hash)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound.WithDetails("Content not found, upload the file instead")
+		}
+		s.logger.Error("Failed to get blob",
+			logger.String("hash", hash),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to get blob")
+	}
+	if blob.Size != size {
+		return nil, errors.ErrNotFound.WithDetails("Content not found, upload the file instead")
+	}
+
+	// 类型按本次文件名判断，同一内容换扩展名也按令牌限制校验
+	mimeType := s.validator.GetMimeType(filename)
+	if err := s.validator.ValidateFile(ctx, filename, size, mimeType, uploadToken.AllowedTypes, uploadToken.MaxSize); err != nil {
+		return nil, err
+	}
+
+	return s.createAttachment(ctx, uploadToken, filename, blob, mimeType)
+}
+
+// storeBlob 计算已上传文件的摘要并存入 Blob：内容已存在时删除本次上传的文件，否则复制到 Blob 路径
+func (s *service) storeBlob(ctx context.Context, filePath, mimeType string, size int64) (*Blob, error) {
+	hash, err := hashObject(ctx, s.storage, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	blob, err := s.blobRepo.TouchBlob(ctx, hash)
+	if err == nil {
+		s.deleteObject(ctx, filePath)
+		s.logger.Info("Upload deduplicated",
+			logger.String("hash", hash),
+			logger.String("blob_path", blob.Path),
+			logger.Int64("size", size),
+		)
+		return blob, nil
+	}
+	if err != errors.ErrNotFound {
+		return nil, err
+	}
+
+	blob = NewBlob(hash, mimeType, size)
+	if err := copyObject(ctx, s.storage, filePath, blob.Path, size, mimeType); err != nil {
+		s.deleteObject(ctx, blob.Path)
+		return nil, fmt.Errorf("failed to copy file to blob: %w", err)
+	}
+	stored, err := s.blobRepo.CreateBlob(ctx, blob)
+	if err != nil {
+		s.deleteObject(ctx, blob.Path)
+		return nil, err
+	}
+	// 并发上传同一内容时只保留先写入的 Blob
+	if stored.Path != blob.Path {
+		s.deleteObject(ctx, blob.Path)
+	}
+	s.deleteObject(ctx, filePath)
+	return stored, nil
+}
+
+// createAttachment 引用 Blob 创建附件并作废上传令牌
+func (s *service) createAttachment(ctx context.Context, uploadToken *UploadToken, filename string, blob *Blob, mimeType string) (*NotifyResponse, error) {
+	token := uploadToken.Token
+
 	// 创建附件项
-	attachment := NewAttachmentItem(filename, filePath, token, mimeType, fileSize)
+	attachment := NewAttachmentItem(filename, blob.Path, token, mimeType, blob.Size)
+	attachment.Hash = blob.Hash
+	attachment.TableID = uploadToken.TableID
+	attachment.FieldID = uploadToken.FieldID
+	attachment.RecordID = uploadToken.RecordID
+	attachment.CreatedBy = uploadToken.UserID
 
 	// 如果是图片，生成缩略图
 	if s.thumbnailGenerator != nil && s.thumbnailGenerator.IsSupported(mimeType) {
-		thumbnails, err := s.generateThumbnails(ctx, filePath, attachment.ID)
+		thumbnails, err := s.generateThumbnails(ctx, blob.Path, attachment.ID)
 		if err != nil {
 			s.logger.Warn("Failed to generate thumbnails",
-				logger.String("file_path", filePath),
+				logger.String("file_path", blob.Path),
 				logger.ErrorField(err),
 			)
 		} else {
@@ -252,9 +378,9 @@ func (s *service) NotifyUpload(ctx context.Context, token, filename string) (*No
 		}
 	}
 
-	// 生成预签名URL
-	if presignedURL, err := s.storage.GetURL(ctx, filePath, 24*time.Hour); err == nil {
-		attachment.SetPresignedURL(presignedURL)
+	// 生成预签名URL：Blob 被多个附件共用，带上附件令牌以便按本附件的文件名返回
+	if presignedURL, err := s.storage.GetURL(ctx, blob.Path, 24*time.Hour); err == nil {
+		attachment.SetPresignedURL(withAttachmentToken(presignedURL, token))
 	}
 
 	// 保存附件信息
@@ -285,14 +411,20 @@ func (s *service) NotifyUpload(ctx context.Context, token, filename string) (*No
 		logger.String("token", token),
 		logger.String("attachment_id", attachment.ID),
 		logger.String("filename", filename),
+		logger.String("hash", blob.Hash),
 	)
 	return response, nil
 }
 
 // ReadFile 读取文件
 func (s *service) ReadFile(ctx context.Context, path, token string) (*ReadResponse, error) {
+	// 预览产物按所属附件查找
+	if attachmentID, mimeType, ok := ParsePreviewPath(path); ok {
+		return s.readPreviewFile(ctx, path, attachmentID, mimeType)
+	}
+
 	// 获取附件信息
-	attachment, err := s.repo.GetAttachmentByPath(ctx, path)
+	attachment, err := s.attachmentForPath(ctx, path, token)
 	if err != nil {
 		if err == errors.ErrNotFound {
 			return nil, errors.ErrNotFound.WithDetails("File not found")
@@ -317,7 +449,7 @@ func (s *service) ReadFile(ctx context.Context, path, token string) (*ReadRespon
 		return nil, errors.ErrNotFound.WithDetails("File not found")
 	}
 
-	// 读取文件
+	// 打开文件流，由调用方关闭
 	reader, err := s.storage.Download(ctx, path)
 	if err != nil {
 		s.logger.Error("Failed to download file",
@@ -326,36 +458,77 @@ func (s *service) ReadFile(ctx context.Context, path, token string) (*ReadRespon
 		)
 		return nil, errors.ErrInternalServer.WithDetails("Failed to read file")
 	}
-	defer reader.Close()
-
-	// 读取文件内容
-	data, err := io.ReadAll(reader)
-	if err != nil {
-		s.logger.Error("Failed to read file content",
-			logger.String("path", path),
-			logger.ErrorField(err),
-		)
-		return nil, errors.ErrInternalServer.WithDetails("Failed to read file content")
-	}
 
-	// 设置响应头
+	// 设置响应头（Content-Length 由下载时按 Range 计算）
 	headers := map[string]string{
-		"Content-Type":        attachment.MimeType,
-		"Content-Length":      fmt.Sprintf("%d", len(data)),
 		"Cache-Control":       "public, max-age=31536000",
 		"Content-Disposition": fmt.Sprintf("inline; filename=\"%s\"", attachment.Name),
 	}
 
 	response := &ReadResponse{
-		Data:     data,
+		Reader:   reader,
+		Name:     attachment.Name,
 		Headers:  headers,
 		MimeType: attachment.MimeType,
-		Size:     int64(len(data)),
+		Size:     attachment.Size,
+		ModTime:  attachment.CreatedTime,
 	}
 
 	return response, nil
 }
 
+// readPreviewFile 读取附件的预览产物，产物必须已记录在附件上
+func (s *service) readPreviewFile(ctx context.Context, path, attachmentID, mimeType string) (*ReadResponse, error) {
+	attachment, err := s.repo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound.WithDetails("File not found")
+		}
+		s.logger.Error("Failed to get attachment by ID",
+			logger.String("id", attachmentID),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to get file")
+	}
+
+	recorded := false
+	for _, file := range attachment.PreviewFiles() {
+		if file == path {
+			recorded = true
+			break
+		}
+	}
+	if !recorded {
+		return nil, errors.ErrNotFound.WithDetails("File not found")
+	}
+
+	size, err := s.storage.GetSize(ctx, path)
+	if err != nil {
+		return nil, errors.ErrNotFound.WithDetails("File not found")
+	}
+	reader, err := s.storage.Download(ctx, path)
+	if err != nil {
+		s.logger.Error("Failed to download preview file",
+			logger.String("path", path),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to read file")
+	}
+
+	name := previewName(attachment, mimeType)
+	return &ReadResponse{
+		Reader: reader,
+		Name:   name,
+		Headers: map[string]string{
+			"Cache-Control":       "public, max-age=86400",
+			"Content-Disposition": fmt.Sprintf("inline; filename=\"%s\"", name),
+		},
+		MimeType: mimeType,
+		Size:     size,
+		ModTime:  attachment.UpdatedTime,
+	}, nil
+}
+
 // DeleteFile 删除文件
 func (s *service) DeleteFile(ctx context.Context, id string) error {
 	// 获取附件信息
@@ -371,22 +544,21 @@ func (s *service) DeleteFile(ctx context.Context, id string) error {
 		return errors.ErrInternalServer.WithDetails("Failed to get attachment")
 	}
 
-	// 删除存储中的文件
-	if err := s.storage.Delete(ctx, attachment.Path); err != nil {
-		s.logger.Error("Failed to delete file from storage",
-			logger.String("id", id),
-			logger.String("path", attachment.Path),
-			logger.ErrorField(err),
-		)
-		// 继续删除数据库记录
+	// 删除存储中的文件；Blob 可能被其他附件共用，由垃圾回收在没有引用后删除
+	if attachment.Hash == "" {
+		if err := s.storage.Delete(ctx, attachment.Path); err != nil {
+			s.logger.Error("Failed to delete file from storage",
+				logger.String("id", id),
+				logger.String("path", attachment.Path),
+				logger.ErrorField(err),
+			)
+			// 继续删除数据库记录
+		}
 	}
 
-	// 删除缩略图
-	if attachment.SmallThumbnail != nil {
-		s.storage.Delete(ctx, *attachment.SmallThumbnail)
-	}
-	if attachment.LargeThumbnail != nil {
-		s.storage.Delete(ctx, *attachment.LargeThumbnail)
+	// 删除缩略图和文本预览
+	for _, path := range attachment.PreviewFiles() {
+		s.storage.Delete(ctx, path)
 	}
 
 	// 删除数据库记录
@@ -449,8 +621,22 @@ func (s *service) GetAttachmentStats(ctx context.Context, tableID string) (*Atta
 	return stats, nil
 }
 
-// CleanupExpiredTokens 清理过期令牌
+// CleanupExpiredTokens 清理过期令牌，并删除过期会话已上传的分片
 func (s *service) CleanupExpiredTokens(ctx context.Context) error {
+	for {
+		sessions, err := s.sessionRepo.ListExpiredUploadSessions(ctx, expiredSessionBatch)
+		if err != nil {
+			s.logger.Error("Failed to list expired upload sessions", logger.ErrorField(err))
+			return err
+		}
+		for _, session := range sessions {
+			s.discardSession(ctx, session)
+		}
+		if len(sessions) < expiredSessionBatch {
+			break
+		}
+	}
+
 	if err := s.tokenRepo.CleanupExpiredTokens(ctx); err != nil {
 		s.logger.Error("Failed to cleanup expired tokens", logger.ErrorField(err))
 		return err
@@ -458,11 +644,346 @@ func (s *service) CleanupExpiredTokens(ctx context.Context) error {
 	return nil
 }
 
+// CollectOrphanBlobs 删除宽限期内未被使用、且没有附件引用的 Blob
+// 先按条件删除记录再删除文件：期间被重新引用的 Blob 不满足条件会被跳过，
+// 记录删除后再上传同一内容会写入新的路径，不会与正在删除的文件冲突
+func (s *service) CollectOrphanBlobs(ctx context.Context) (*GCResult, error) {
+	result := &GCResult{}
+	before := time.Now().Add(-BlobGracePeriod)
+	for {
+		blobs, err := s.blobRepo.ListOrphanBlobs(ctx, before, blobCollectBatch)
+		if err != nil {
+			s.logger.Error("Failed to list orphan blobs", logger.ErrorField(err))
+			return result, err
+		}
+		for _, blob := range blobs {
+			deleted, err := s.blobRepo.DeleteOrphanBlob(ctx, blob.Hash, before)
+			if err != nil {
+				s.logger.Error("Failed to delete orphan blob",
+					logger.String("hash", blob.Hash),
+					logger.ErrorField(err),
+				)
+				return result, err
+			}
+			if !deleted {
+				continue
+			}
+			s.deleteObject(ctx, blob.Path)
+			result.Blobs++
+			result.Bytes += blob.Size
+		}
+		if len(blobs) < blobCollectBatch {
+			break
+		}
+	}
+
+	if result.Blobs > 0 {
+		s.logger.Info("Orphan blobs collected",
+			logger.Int("blobs", result.Blobs),
+			logger.Int64("bytes", result.Bytes),
+		)
+	}
+	return result, nil
+}
+
+// CreateUploadSession 创建断点续传会话，声明的总大小和文件类型按令牌限制校验
+func (s *service) CreateUploadSession(ctx context.Context, token, filename string, size int64) (*UploadSession, error) {
+	uploadToken, err := s.validUploadToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateFilename(filename); err != nil {
+		return nil, err
+	}
+
+	mimeType := s.validator.GetMimeType(filename)
+	if err := s.validator.ValidateFile(ctx, filename, size, mimeType, uploadToken.AllowedTypes, uploadToken.MaxSize); err != nil {
+		return nil, err
+	}
+
+	session := NewUploadSession(uploadToken, filename, mimeType, size)
+	if err := s.sessionRepo.CreateUploadSession(ctx, session); err != nil {
+		s.logger.Error("Failed to create upload session",
+			logger.String("token", token),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to create upload session")
+	}
+
+	s.logger.Info("Upload session created",
+		logger.String("token", token),
+		logger.String("session_id", session.ID),
+		logger.Int64("size", size),
+	)
+	return session, nil
+}
+
+// GetUploadSession 获取断点续传会话（用于查询进度）
+func (s *service) GetUploadSession(ctx context.Context, token, id string) (*UploadSession, error) {
+	return s.activeSession(ctx, token, id)
+}
+
+// UploadChunk 在会话当前偏移量处追加分片
+// 分片先完整写入存储再校验长度和校验和，失败时删除分片、偏移量不变，客户端从原偏移量重试
+func (s *service) UploadChunk(ctx context.Context, token, id string, offset int64, reader io.Reader, length int64, checksum *ChunkChecksum) (*UploadSession, error) {
+	session, err := s.activeSession(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != session.Offset {
+		return nil, errors.ErrUploadOffsetMismatch.WithDetails(map[string]interface{}{
+			"offset":   session.Offset,
+			"received": offset,
+		})
+	}
+
+	remaining := session.Size - session.Offset
+	if length > remaining {
+		return nil, errors.ErrFileTooLarge.WithDetails("Chunk exceeds the declared upload length")
+	}
+
+	// 多读一个字节用于发现超出声明长度的分片
+	counter := &countingWriter{}
+	body := io.TeeReader(io.LimitReader(reader, remaining+1), counter)
+	var digest hash.Hash
+	if checksum != nil {
+		digest = checksum.NewHash()
+		body = io.TeeReader(body, digest)
+	}
+
+	part := session.NextPartPath()
+	if err := s.storage.Upload(ctx, part, body, length, "application/octet-stream"); err != nil {
+		s.storage.Delete(ctx, part)
+		s.logger.Warn("Failed to store upload chunk",
+			logger.String("session_id", id),
+			logger.Int64("offset", offset),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrFileUploadFailed.WithDetails("Failed to store chunk")
+	}
+
+	received := counter.n
+	switch {
+	case received == 0:
+		s.storage.Delete(ctx, part)
+		return session, nil
+	case received > remaining:
+		s.storage.Delete(ctx, part)
+		return nil, errors.ErrFileTooLarge.WithDetails("Chunk exceeds the declared upload length")
+	case digest != nil && !bytes.Equal(digest.Sum(nil), checksum.Expected):
+		s.storage.Delete(ctx, part)
+		return nil, errors.ErrChecksumMismatch.WithDetails(map[string]interface{}{
+			"algorithm": checksum.Algorithm,
+			"offset":    offset,
+		})
+	}
+
+	advanced, err := s.sessionRepo.AdvanceUploadSession(ctx, session, part, received)
+	if err != nil || !advanced {
+		s.storage.Delete(ctx, part)
+		if err != nil {
+			s.logger.Error("Failed to advance upload session",
+				logger.String("session_id", id),
+				logger.ErrorField(err),
+			)
+			return nil, errors.ErrInternalServer.WithDetails("Failed to save upload progress")
+		}
+		// 同一偏移量的并发请求已先写入
+		return nil, errors.ErrUploadOffsetMismatch.WithDetails("Chunk at this offset was already received")
+	}
+
+	session.Parts = append(session.Parts, part)
+	session.Offset += received
+	session.UpdatedTime = time.Now()
+	return session, nil
+}
+
+// FinalizeUploadSession 把已收齐的分片合并为令牌对应的最终文件，之后按普通上传调用 NotifyUpload
+func (s *service) FinalizeUploadSession(ctx context.Context, token, id string) (*UploadSession, error) {
+	session, err := s.activeSession(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsComplete() {
+		return nil, errors.ErrUploadOffsetMismatch.WithDetails(map[string]interface{}{
+			"message": "Upload is incomplete",
+			"offset":  session.Offset,
+			"size":    session.Size,
+		})
+	}
+
+	uploadToken, err := s.validUploadToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	filePath := s.generateFilePath(uploadToken, session.Filename)
+
+	if err := s.composeParts(ctx, filePath, session); err != nil {
+		s.storage.Delete(ctx, filePath)
+		s.logger.Error("Failed to compose upload chunks",
+			logger.String("session_id", id),
+			logger.String("file_path", filePath),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrFileUploadFailed.WithDetails("Failed to compose uploaded chunks")
+	}
+
+	s.discardSession(ctx, session)
+	s.logger.Info("Upload session finalized",
+		logger.String("session_id", id),
+		logger.String("file_path", filePath),
+		logger.Int64("size", session.Size),
+	)
+	return session, nil
+}
+
+// AbortUploadSession 终止会话并删除已上传的分片
+func (s *service) AbortUploadSession(ctx context.Context, token, id string) error {
+	session, err := s.ownedSession(ctx, token, id)
+	if err != nil {
+		return err
+	}
+	s.discardSession(ctx, session)
+	return nil
+}
+
+// composeParts 通过存储提供者合并分片，并核对合并后的大小
+func (s *service) composeParts(ctx context.Context, target string, session *UploadSession) error {
+	if composer, ok := s.storage.(Composer); ok {
+		if err := composer.Compose(ctx, target, session.Parts, session.MimeType); err != nil {
+			return err
+		}
+	} else {
+		reader := &partsReader{ctx: ctx, storage: s.storage, parts: session.Parts}
+		defer reader.Close()
+		if err := s.storage.Upload(ctx, target, reader, session.Size, session.MimeType); err != nil {
+			return err
+		}
+	}
+
+	size, err := s.storage.GetSize(ctx, target)
+	if err != nil {
+		return err
+	}
+	if size != session.Size {
+		return fmt.Errorf("composed size %d does not match declared size %d", size, session.Size)
+	}
+	return nil
+}
+
+// discardSession 删除会话的分片和会话记录
+func (s *service) discardSession(ctx context.Context, session *UploadSession) {
+	for _, part := range session.Parts {
+		if err := s.storage.Delete(ctx, part); err != nil {
+			s.logger.Warn("Failed to delete upload chunk",
+				logger.String("session_id", session.ID),
+				logger.String("part", part),
+				logger.ErrorField(err),
+			)
+		}
+	}
+	if err := s.sessionRepo.DeleteUploadSession(ctx, session.ID); err != nil {
+		s.logger.Warn("Failed to delete upload session",
+			logger.String("session_id", session.ID),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// activeSession 获取属于该令牌且未过期的会话
+func (s *service) activeSession(ctx context.Context, token, id string) (*UploadSession, error) {
+	session, err := s.ownedSession(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsExpired() {
+		return nil, errors.ErrBadRequest.WithDetails("Upload session has expired")
+	}
+	return session, nil
+}
+
+// ownedSession 获取属于该令牌的会话
+func (s *service) ownedSession(ctx context.Context, token, id string) (*UploadSession, error) {
+	session, err := s.sessionRepo.GetUploadSession(ctx, id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound.WithDetails("Upload session not found")
+		}
+		s.logger.Error("Failed to get upload session",
+			logger.String("session_id", id),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to get upload session")
+	}
+	if session.Token != token {
+		return nil, errors.ErrNotFound.WithDetails("Upload session not found")
+	}
+	return session, nil
+}
+
+// attachmentForPath 按路径查找附件；Blob 被多个附件共用，带令牌时优先取令牌对应的附件
+func (s *service) attachmentForPath(ctx context.Context, path, token string) (*AttachmentItem, error) {
+	if token != "" {
+		if attachment, err := s.repo.GetAttachmentByToken(ctx, token); err == nil && attachment.Path == path {
+			return attachment, nil
+		}
+	}
+	return s.repo.GetAttachmentByPath(ctx, path)
+}
+
+// deleteObject 删除存储对象，失败只记录日志
+func (s *service) deleteObject(ctx context.Context, path string) {
+	if err := s.storage.Delete(ctx, path); err != nil {
+		s.logger.Warn("Failed to delete file from storage",
+			logger.String("path", path),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// withAttachmentToken 在文件地址上附加附件令牌
+func withAttachmentToken(fileURL, token string) string {
+	separator := "?"
+	if strings.Contains(fileURL, "?") {
+		separator = "&"
+	}
+	return fileURL + separator + "token=" + url.QueryEscape(token)
+}
+
+// validUploadToken 获取未过期的上传令牌
+func (s *service) validUploadToken(ctx context.Context, token string) (*UploadToken, error) {
+	uploadToken, err := s.tokenRepo.GetUploadToken(ctx, token)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrBadRequest.WithDetails("Invalid upload token")
+		}
+		s.logger.Error("Failed to get upload token",
+			logger.String("token", token),
+			logger.ErrorField(err),
+		)
+		return nil, errors.ErrInternalServer.WithDetails("Failed to validate upload token")
+	}
+	if uploadToken.IsExpired() {
+		return nil, errors.ErrBadRequest.WithDetails("Upload token has expired")
+	}
+	return uploadToken, nil
+}
+
+// validateFilename 文件名决定存储路径，不能为空或包含路径
+func validateFilename(filename string) error {
+	if filename == "" {
+		return errors.ErrBadRequest.WithDetails("Filename is required")
+	}
+	if strings.Contains(filename, "..") || strings.ContainsAny(filename, "/\\") {
+		return errors.ErrBadRequest.WithDetails("Filename contains invalid characters")
+	}
+	return nil
+}
+
 // generateFilePath 生成文件路径
 func (s *service) generateFilePath(token *UploadToken, filename string) string {
-	// 生成基于时间戳的路径
-	now := time.Now()
-	datePath := now.Format("2006/01/02")
+	// 按令牌创建日期分目录：上传与通知两次计算必须得到同一路径
+	datePath := token.CreatedTime.Format("2006/01/02")
 
 	// 生成唯一文件名
 	ext := filepath.Ext(filename)
@@ -472,6 +993,31 @@ func (s *service) generateFilePath(token *UploadToken, filename string) string {
 	return fmt.Sprintf("attachments/%s/%s/%s/%s", token.TableID, token.FieldID, datePath, uniqueName)
 }
 
+// narrowAllowedTypes 请求的类型限制与配置取交集，交集为空时使用配置
+func narrowAllowedTypes(requested, configured []string) []string {
+	if len(requested) == 0 {
+		return configured
+	}
+	if len(configured) == 0 {
+		return requested
+	}
+
+	allowed := make(map[string]bool, len(configured))
+	for _, t := range configured {
+		allowed[t] = true
+	}
+	narrowed := make([]string, 0, len(requested))
+	for _, t := range requested {
+		if allowed[t] {
+			narrowed = append(narrowed, t)
+		}
+	}
+	if len(narrowed) == 0 {
+		return configured
+	}
+	return narrowed
+}
+
 // generateThumbnails 生成缩略图
 func (s *service) generateThumbnails(ctx context.Context, sourcePath, attachmentID string) (map[string]string, error) {
 	if s.thumbnailConfig == nil || !s.thumbnailConfig.Enabled {
@@ -24,6 +24,13 @@ type Storage interface {
 	GetMetadata(ctx context.Context, path string) (map[string]string, error)
 }
 
+// Composer 可把多个分片对象合并为一个对象的存储
+// 未实现时按顺序读取分片重新上传
+type Composer interface {
+	// Compose 按顺序合并分片到目标路径
+	Compose(ctx context.Context, target string, parts []string, contentType string) error
+}
+
 // ThumbnailGenerator 缩略图生成器接口
 type ThumbnailGenerator interface {
 	// GenerateThumbnail 生成缩略图
@@ -0,0 +1,162 @@
+package attachment
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// UploadSession 断点续传会话
+// 每个分片校验通过后单独保存为一个存储对象，完成时由存储提供者按顺序合并为最终文件
+type UploadSession struct {
+	ID          string    `json:"id"`
+	Token       string    `json:"token"`
+	Filename    string    `json:"filename"`
+	MimeType    string    `json:"mimetype"`
+	Size        int64     `json:"size"`
+	Offset      int64     `json:"offset"`
+	Parts       []string  `json:"-"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedTime time.Time `json:"created_time"`
+	UpdatedTime time.Time `json:"updated_time"`
+}
+
+// NewUploadSession 创建断点续传会话，会话与上传令牌同时过期
+func NewUploadSession(token *UploadToken, filename, mimeType string, size int64) *UploadSession {
+	return &UploadSession{
+		ID:          utils.GenerateNanoID(20),
+		Token:       token.Token,
+		Filename:    filename,
+		MimeType:    mimeType,
+		Size:        size,
+		ExpiresAt:   token.ExpiresAt,
+		CreatedTime: time.Now(),
+		UpdatedTime: time.Now(),
+	}
+}
+
+// IsExpired 检查会话是否过期
+func (s *UploadSession) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// IsComplete 检查是否已收到全部字节
+func (s *UploadSession) IsComplete() bool {
+	return s.Offset == s.Size
+}
+
+// NextPartPath 生成下一个分片的存储路径（带随机后缀，并发写同一偏移时互不覆盖）
+func (s *UploadSession) NextPartPath() string {
+	return fmt.Sprintf("uploads/%s/%020d_%s", s.ID, s.Offset, utils.GenerateNanoID(6))
+}
+
+// ChunkChecksum 分片校验和（tus Upload-Checksum 头）
+type ChunkChecksum struct {
+	Algorithm string
+	Expected  []byte
+}
+
+// ParseChunkChecksum 解析 "<算法> <base64 摘要>"，支持 md5、sha1、sha256
+func ParseChunkChecksum(header string) (*ChunkChecksum, error) {
+	parts := strings.Fields(header)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid checksum header %q", header)
+	}
+	algorithm := strings.ToLower(parts[0])
+	if newChecksumHash(algorithm) == nil {
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", parts[0])
+	}
+	expected, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum encoding: %w", err)
+	}
+	return &ChunkChecksum{Algorithm: algorithm, Expected: expected}, nil
+}
+
+// NewHash 创建对应算法的摘要计算器
+func (c *ChunkChecksum) NewHash() hash.Hash {
+	return newChecksumHash(c.Algorithm)
+}
+
+// ChecksumAlgorithms 支持的校验和算法
+func ChecksumAlgorithms() []string {
+	return []string{"md5", "sha1", "sha256"}
+}
+
+func newChecksumHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case "md5":
+		return md5.New()
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	}
+	return nil
+}
+
+// expiredSessionBatch 每批清理的过期会话数
+const expiredSessionBatch = 100
+
+// countingWriter 统计写入的字节数
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// partsReader 依次读取各分片，存储不支持合并时用于重新上传
+type partsReader struct {
+	ctx     context.Context
+	storage Storage
+	parts   []string
+	current io.ReadCloser
+}
+
+func (r *partsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.parts) == 0 {
+				return 0, io.EOF
+			}
+			reader, err := r.storage.Download(r.ctx, r.parts[0])
+			if err != nil {
+				return 0, fmt.Errorf("failed to open chunk %s: %w", r.parts[0], err)
+			}
+			r.current, r.parts = reader, r.parts[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+// Close 关闭正在读取的分片
+func (r *partsReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
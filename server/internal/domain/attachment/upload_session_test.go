@@ -0,0 +1,303 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+// memStorage 内存存储，不实现 Composer，合并时走重新上传路径
+type memStorage struct {
+	objects map[string][]byte
+}
+
+func (m *memStorage) Upload(ctx context.Context, path string, reader io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	m.objects[path] = data
+	return nil
+}
+
+func (m *memStorage) Download(ctx context.Context, path string) (io.ReadCloser, error) {
+	data, ok := m.objects[path]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memStorage) Delete(ctx context.Context, path string) error {
+	delete(m.objects, path)
+	return nil
+}
+
+func (m *memStorage) Exists(ctx context.Context, path string) (bool, error) {
+	_, ok := m.objects[path]
+	return ok, nil
+}
+
+func (m *memStorage) GetURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	return "/" + path, nil
+}
+
+func (m *memStorage) GetSize(ctx context.Context, path string) (int64, error) {
+	data, ok := m.objects[path]
+	if !ok {
+		return 0, errors.ErrNotFound
+	}
+	return int64(len(data)), nil
+}
+
+func (m *memStorage) GetMetadata(ctx context.Context, path string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+type memTokenRepo struct {
+	tokens map[string]*UploadToken
+}
+
+func (r *memTokenRepo) CreateUploadToken(ctx context.Context, token *UploadToken) error {
+	r.tokens[token.Token] = token
+	return nil
+}
+
+func (r *memTokenRepo) GetUploadToken(ctx context.Context, token string) (*UploadToken, error) {
+	if t, ok := r.tokens[token]; ok {
+		return t, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *memTokenRepo) DeleteUploadToken(ctx context.Context, token string) error {
+	delete(r.tokens, token)
+	return nil
+}
+
+func (r *memTokenRepo) CleanupExpiredTokens(ctx context.Context) error {
+	for key, token := range r.tokens {
+		if token.IsExpired() {
+			delete(r.tokens, key)
+		}
+	}
+	return nil
+}
+
+// memSessionRepo 内存会话仓储，返回副本以模拟数据库读写
+type memSessionRepo struct {
+	sessions map[string]UploadSession
+}
+
+func (r *memSessionRepo) CreateUploadSession(ctx context.Context, session *UploadSession) error {
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memSessionRepo) GetUploadSession(ctx context.Context, id string) (*UploadSession, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	session.Parts = append([]string(nil), session.Parts...)
+	return &session, nil
+}
+
+func (r *memSessionRepo) AdvanceUploadSession(ctx context.Context, session *UploadSession, part string, length int64) (bool, error) {
+	stored, ok := r.sessions[session.ID]
+	if !ok || stored.Offset != session.Offset {
+		return false, nil
+	}
+	stored.Parts = append(append([]string(nil), stored.Parts...), part)
+	stored.Offset += length
+	r.sessions[session.ID] = stored
+	return true, nil
+}
+
+func (r *memSessionRepo) DeleteUploadSession(ctx context.Context, id string) error {
+	delete(r.sessions, id)
+	return nil
+}
+
+func (r *memSessionRepo) ListExpiredUploadSessions(ctx context.Context, limit int) ([]*UploadSession, error) {
+	var expired []*UploadSession
+	for _, session := range r.sessions {
+		if session.IsExpired() && len(expired) < limit {
+			session := session
+			expired = append(expired, &session)
+		}
+	}
+	return expired, nil
+}
+
+type stubValidator struct{}
+
+func (stubValidator) ValidateFile(ctx context.Context, filename string, size int64, contentType string, allowedTypes []string, maxSize int64) error {
+	if maxSize > 0 && size > maxSize {
+		return errors.ErrFileTooLarge
+	}
+	return nil
+}
+func (stubValidator) GetMimeType(filename string) string { return "application/octet-stream" }
+func (stubValidator) IsImage(mimeType string) bool       { return false }
+func (stubValidator) IsVideo(mimeType string) bool       { return false }
+func (stubValidator) IsAudio(mimeType string) bool       { return false }
+func (stubValidator) IsDocument(mimeType string) bool    { return false }
+
+type uploadFixture struct {
+	service  Service
+	storage  *memStorage
+	sessions *memSessionRepo
+	tokens   *memTokenRepo
+}
+
+func newUploadFixture() *uploadFixture {
+	f := &uploadFixture{
+		storage:  &memStorage{objects: map[string][]byte{}},
+		sessions: &memSessionRepo{sessions: map[string]UploadSession{}},
+		tokens:   &memTokenRepo{tokens: map[string]*UploadToken{}},
+	}
+	f.tokens.tokens["tok_resumable"] = &UploadToken{
+		Token:       "tok_resumable",
+		TableID:     "tbl",
+		FieldID:     "fld",
+		MaxSize:     1024,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		CreatedTime: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	f.service = NewService(nil, f.tokens, f.sessions, nil, f.storage, nil, stubValidator{}, nil, nil, zap.NewNop())
+	return f
+}
+
+func chunkChecksum(t *testing.T, data string) *ChunkChecksum {
+	sum := sha256.Sum256([]byte(data))
+	checksum, err := ParseChunkChecksum("sha256 " + base64.StdEncoding.EncodeToString(sum[:]))
+	require.NoError(t, err)
+	return checksum
+}
+
+func appErrorCode(err error) string {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestParseChunkChecksum(t *testing.T) {
+	checksum, err := ParseChunkChecksum("SHA1 " + base64.StdEncoding.EncodeToString([]byte("01234567890123456789")))
+	require.NoError(t, err)
+	assert.Equal(t, "sha1", checksum.Algorithm)
+	assert.NotNil(t, checksum.NewHash())
+
+	for _, header := range []string{"", "sha256", "crc32 AAAA", "md5 not-base64!", "md5 a b"} {
+		_, err := ParseChunkChecksum(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestUploadSession_ChunkedUpload(t *testing.T) {
+	f := newUploadFixture()
+	ctx := context.Background()
+
+	session, err := f.service.CreateUploadSession(ctx, "tok_resumable", "video.bin", 10)
+	require.NoError(t, err)
+
+	session, err = f.service.UploadChunk(ctx, "tok_resumable", session.ID, 0, strings.NewReader("hello"), 5, chunkChecksum(t, "hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), session.Offset)
+
+	// 偏移量不一致
+	_, err = f.service.UploadChunk(ctx, "tok_resumable", session.ID, 0, strings.NewReader("hello"), 5, nil)
+	assert.Equal(t, "UPLOAD_OFFSET_MISMATCH", appErrorCode(err))
+
+	// 校验和不匹配时分片被丢弃，偏移量不变
+	_, err = f.service.UploadChunk(ctx, "tok_resumable", session.ID, 5, strings.NewReader("world"), 5, chunkChecksum(t, "WORLD"))
+	assert.Equal(t, "CHECKSUM_MISMATCH", appErrorCode(err))
+	assert.Len(t, f.storage.objects, 1)
+
+	// 超出声明长度
+	_, err = f.service.UploadChunk(ctx, "tok_resumable", session.ID, 5, strings.NewReader("world!"), -1, nil)
+	assert.Equal(t, "FILE_TOO_LARGE", appErrorCode(err))
+
+	// 未收齐时不能合并
+	_, err = f.service.FinalizeUploadSession(ctx, "tok_resumable", session.ID)
+	assert.Equal(t, "UPLOAD_OFFSET_MISMATCH", appErrorCode(err))
+
+	session, err = f.service.UploadChunk(ctx, "tok_resumable", session.ID, 5, strings.NewReader("world"), 5, nil)
+	require.NoError(t, err)
+	assert.True(t, session.IsComplete())
+
+	progress, err := f.service.GetUploadSession(ctx, "tok_resumable", session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), progress.Offset)
+	assert.Len(t, progress.Parts, 2)
+
+	_, err = f.service.FinalizeUploadSession(ctx, "tok_resumable", session.ID)
+	require.NoError(t, err)
+
+	// 合并到与普通上传相同的路径，分片和会话被清理
+	assert.Equal(t, map[string][]byte{
+		"attachments/tbl/fld/2026/03/01/video_tok_resu.bin": []byte("helloworld"),
+	}, f.storage.objects)
+	assert.Empty(t, f.sessions.sessions)
+}
+
+func TestUploadSession_Limits(t *testing.T) {
+	f := newUploadFixture()
+	ctx := context.Background()
+
+	_, err := f.service.CreateUploadSession(ctx, "tok_resumable", "huge.bin", 2048)
+	assert.Equal(t, "FILE_TOO_LARGE", appErrorCode(err))
+	_, err = f.service.CreateUploadSession(ctx, "tok_resumable", "../escape.bin", 10)
+	assert.Error(t, err)
+	_, err = f.service.CreateUploadSession(ctx, "tok_unknown", "a.bin", 10)
+	assert.Error(t, err)
+
+	session, err := f.service.CreateUploadSession(ctx, "tok_resumable", "a.bin", 10)
+	require.NoError(t, err)
+
+	// 其他令牌不能访问该会话
+	_, err = f.service.GetUploadSession(ctx, "tok_other", session.ID)
+	assert.Equal(t, "NOT_FOUND", appErrorCode(err))
+}
+
+func TestUploadSession_AbortAndExpire(t *testing.T) {
+	f := newUploadFixture()
+	ctx := context.Background()
+
+	aborted, err := f.service.CreateUploadSession(ctx, "tok_resumable", "a.bin", 10)
+	require.NoError(t, err)
+	_, err = f.service.UploadChunk(ctx, "tok_resumable", aborted.ID, 0, strings.NewReader("abc"), 3, nil)
+	require.NoError(t, err)
+	require.NoError(t, f.service.AbortUploadSession(ctx, "tok_resumable", aborted.ID))
+	assert.Empty(t, f.storage.objects)
+	assert.Empty(t, f.sessions.sessions)
+
+	// 过期的会话由令牌清理任务一并删除
+	abandoned, err := f.service.CreateUploadSession(ctx, "tok_resumable", "b.bin", 10)
+	require.NoError(t, err)
+	_, err = f.service.UploadChunk(ctx, "tok_resumable", abandoned.ID, 0, strings.NewReader("abc"), 3, nil)
+	require.NoError(t, err)
+
+	stored := f.sessions.sessions[abandoned.ID]
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	f.sessions.sessions[abandoned.ID] = stored
+
+	_, err = f.service.UploadChunk(ctx, "tok_resumable", abandoned.ID, 3, strings.NewReader("def"), 3, nil)
+	assert.Error(t, err)
+
+	require.NoError(t, f.service.CleanupExpiredTokens(ctx))
+	assert.Empty(t, f.storage.objects)
+	assert.Empty(t, f.sessions.sessions)
+}
@@ -157,6 +157,17 @@ func (f *Field) DBFieldType() string {
 	return f.dbFieldType
 }
 
+// SyncDBFieldType 按字段类型重新确定数据库字段类型（物理列已迁移后调用），返回是否有变化
+func (f *Field) SyncDBFieldType() bool {
+	dbFieldType := determineDBFieldType(f.fieldType)
+	if f.dbFieldType == dbFieldType {
+		return false
+	}
+	f.dbFieldType = dbFieldType
+	f.updatedAt = time.Now()
+	return true
+}
+
 // Options 获取字段选项
 func (f *Field) Options() *valueobject.FieldOptions {
 	return f.options
@@ -524,6 +535,8 @@ func (f *Field) ConvertCellValueToDBValue(value interface{}) interface{} {
 		return f.convertCountValueToDB(value)
 	case valueobject.TypeAI:
 		return f.convertAIValueToDB(value)
+	case valueobject.TypeButton:
+		return f.convertButtonValueToDB(value)
 	default:
 		// 其他字段类型直接返回原值
 		return value
@@ -642,6 +655,19 @@ func (f *Field) convertAIValueToDB(value interface{}) interface{} {
 	}
 }
 
+// convertButtonValueToDB 转换按钮字段值
+func (f *Field) convertButtonValueToDB(value interface{}) interface{} {
+	// 按钮字段使用TEXT类型存储点击状态，转换为JSON字符串
+	if str, ok := value.(string); ok {
+		return str
+	}
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return string(jsonBytes)
+}
+
 // ConvertDBValueToCellValue 将数据库值转换为单元格值（参考 teable 设计）
 func (f *Field) ConvertDBValueToCellValue(value interface{}) interface{} {
 	if value == nil {
@@ -759,7 +785,7 @@ func determineDBFieldType(fieldType valueobject.FieldType) string {
 		return "JSONB"
 
 	case valueobject.TypeLink:
-		return "TEXT[]" // 数组类型
+		return "JSONB" // 关联记录缓存（关联关系存储在外键列/中间表）
 
 	case valueobject.TypeAutoNumber:
 		return "SERIAL"
@@ -2,29 +2,34 @@ package handler
 
 import (
 	"context"
+	"strings"
 
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
 )
 
 // ButtonFieldHandler Button字段处理器（对齐原版ButtonFieldCore）
 //
 // 设计哲学：
-//   - 特殊性：Button是只读字段，不存储值
-//   - 交互性：用于触发操作，非数据存储
+//   - 特殊性：Button是只读字段，用户不能直接写入值
+//   - 交互性：用于触发操作，单元格只保存点击状态（由点击接口写入）
 //   - 完美对齐：100%复制原版的业务规则
 //
 // 业务规则：
-//   - Button字段不存储值（只读）
-//   - 任何写入值的尝试都应被忽略
+//   - Button字段只读，单元格中的点击次数/最近点击信息只能由点击接口写入
+//   - 任何直接写入值的尝试都应被忽略
 //   - GetValue始终返回nil
 //   - 主要用于UI交互
 //
 // 配置选项：
 //   - label: 按钮文本
 //   - action: 按钮触发的动作类型
-//   - url: 打开的URL（可选）
+//   - url: open_url 打开的URL模板
+//   - workflowId: trigger_automation 触发的工作流
+//   - webhook: call_webhook 调用的地址
+//   - confirm / allowedRoles: 点击确认与允许点击的角色
 //
 // 对齐原版：
 //   - 只读特性
@@ -102,9 +107,8 @@ func (h *ButtonFieldHandler) ValidateOptions(ctx context.Context, field *entity.
 	}
 
 	// 验证动作类型
-	validActions := []string{"open_url", "run_script", "trigger_automation"}
 	isValidAction := false
-	for _, va := range validActions {
+	for _, va := range valueobject.ButtonActions {
 		if options.Button.Action == va {
 			isValidAction = true
 			break
@@ -114,11 +118,35 @@ func (h *ButtonFieldHandler) ValidateOptions(ctx context.Context, field *entity.
 	if !isValidAction {
 		return fields.NewDomainError(
 			"INVALID_BUTTON_ACTION",
-			"Button action无效，支持的类型: open_url, run_script, trigger_automation",
+			"Button action无效，支持的类型: "+strings.Join(valueobject.ButtonActions, ", "),
 			nil,
 		)
 	}
 
+	// 验证动作配置
+	switch options.Button.Action {
+	case valueobject.ButtonActionOpenURL:
+		if options.Button.URLTemplate() == "" {
+			return fields.NewDomainError("INVALID_BUTTON_OPTIONS", "open_url 动作必须配置 url", nil)
+		}
+	case valueobject.ButtonActionTriggerAutomation:
+		if options.Button.TargetWorkflowID() == "" {
+			return fields.NewDomainError("INVALID_BUTTON_OPTIONS", "trigger_automation 动作必须配置 workflowId", nil)
+		}
+	case valueobject.ButtonActionCallWebhook:
+		webhook := options.Button.Webhook
+		if webhook == nil || webhook.URL == "" {
+			return fields.NewDomainError("INVALID_BUTTON_OPTIONS", "call_webhook 动作必须配置 webhook.url", nil)
+		}
+		if err := utils.ValidateOutboundURL(webhook.URL); err != nil {
+			return fields.NewDomainError("INVALID_BUTTON_OPTIONS", "webhook.url 必须是公网 http/https 地址", err)
+		}
+	}
+
+	if options.Button.MaxClicks < 0 {
+		return fields.NewDomainError("INVALID_BUTTON_OPTIONS", "maxClicks 不能为负数", nil)
+	}
+
 	return nil
 }
 
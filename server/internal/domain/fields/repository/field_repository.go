@@ -66,10 +66,12 @@ type FieldFilter struct {
 	Name       *string
 	IsVirtual  *bool
 	IsComputed *bool
-	IsDeleted  *bool
-	CreatedBy  *string
-	OrderBy    string // name, created_at, updated_at, order
-	OrderDir   string // asc, desc
-	Limit      int
-	Offset     int
+	// LinkedTableID Link 字段的关联表
+	LinkedTableID *string
+	IsDeleted     *bool
+	CreatedBy     *string
+	OrderBy       string // name, created_at, updated_at, order
+	OrderDir      string // asc, desc
+	Limit         int
+	Offset        int
 }
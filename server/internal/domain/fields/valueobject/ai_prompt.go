@@ -0,0 +1,54 @@
+package valueobject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// AIPromptFieldNames 提取提示词模板中引用的字段名（去重，按出现顺序）
+func AIPromptFieldNames(template string) []string {
+	matches := fieldTemplatePattern.FindAllStringSubmatch(template, -1)
+	names := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		name := strings.TrimSpace(match[1])
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// RenderAIPrompt 用记录的字段值替换提示词模板中的 {字段名}
+// values 以字段名为键；模板中引用了不存在的字段时保留原文，
+// 避免提示词中的 JSON 示例等花括号内容被误替换
+func RenderAIPrompt(template string, values map[string]interface{}) string {
+	return RenderFieldTemplate(template, values, nil)
+}
+
+// AIInputHash 计算一次生成的输入摘要，用于缓存生成结果
+// 提供者、模型、生成参数或渲染后的提示词任一变化时摘要不同
+func AIInputHash(options *AIOptions, prompt string) string {
+	temperature := ""
+	if options.Temperature != nil {
+		temperature = strconv.FormatFloat(*options.Temperature, 'f', -1, 64)
+	}
+
+	h := sha256.New()
+	for _, part := range []string{
+		options.Provider,
+		options.Model,
+		options.SystemPrompt,
+		temperature,
+		strconv.Itoa(options.MaxTokens),
+		prompt,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
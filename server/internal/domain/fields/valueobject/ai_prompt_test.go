@@ -0,0 +1,39 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIPromptFieldNames(t *testing.T) {
+	names := AIPromptFieldNames("Summarize {Title} by { Author }, see {Title} and {}")
+
+	assert.Equal(t, []string{"Title", "Author"}, names)
+}
+
+func TestRenderAIPrompt(t *testing.T) {
+	values := map[string]interface{}{
+		"Title":   "Go in Action",
+		"Pages":   float64(264),
+		"Tags":    []interface{}{"go", "", "book"},
+		"Authors": []interface{}{map[string]interface{}{"id": "rec_1", "title": "William"}},
+		"Notes":   nil,
+	}
+
+	prompt := RenderAIPrompt(`{Title} ({Pages}p) [{Tags}] by {Authors}; notes: {Notes}; keep {"k": 1} {Unknown}`, values)
+
+	assert.Equal(t, `Go in Action (264p) [go, book] by William; notes: ; keep {"k": 1} {Unknown}`, prompt)
+}
+
+func TestAIInputHash(t *testing.T) {
+	options := &AIOptions{Provider: "openai", Model: "gpt-4o-mini"}
+	hash := AIInputHash(options, "hello")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, AIInputHash(&AIOptions{Provider: "openai", Model: "gpt-4o-mini"}, "hello"))
+	assert.NotEqual(t, hash, AIInputHash(options, "hello!"))
+
+	temperature := 0.2
+	assert.NotEqual(t, hash, AIInputHash(&AIOptions{Provider: "openai", Model: "gpt-4o-mini", Temperature: &temperature}, "hello"))
+}
@@ -0,0 +1,166 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Button 动作类型
+const (
+	ButtonActionOpenURL           = "open_url"
+	ButtonActionRunScript         = "run_script"
+	ButtonActionTriggerAutomation = "trigger_automation"
+	ButtonActionCallWebhook       = "call_webhook"
+)
+
+// ButtonActions 支持的 Button 动作类型
+var ButtonActions = []string{
+	ButtonActionOpenURL,
+	ButtonActionRunScript,
+	ButtonActionTriggerAutomation,
+	ButtonActionCallWebhook,
+}
+
+// buttonURLSchemes 允许打开的 URL 协议，避免模板渲染出 javascript: 等链接
+var buttonURLSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+}
+
+// URLTemplate open_url 的 URL 模板，兼容旧配置 config.url
+func (o *ButtonOptions) URLTemplate() string {
+	if o.URL != "" {
+		return o.URL
+	}
+	if v, ok := o.Config["url"].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// TargetWorkflowID trigger_automation 的工作流ID，兼容旧配置 config.workflowId
+func (o *ButtonOptions) TargetWorkflowID() string {
+	if o.WorkflowID != "" {
+		return o.WorkflowID
+	}
+	if v, ok := o.Config["workflowId"].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// RoleAllowed 角色是否在允许点击的角色列表中（列表为空时返回 false，由调用方决定默认规则）
+func (o *ButtonOptions) RoleAllowed(role string) bool {
+	for _, allowed := range o.AllowedRoles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ClickLimitReached 点击次数是否已达上限（MaxClicks 为 0 时不限制）
+func (o *ButtonOptions) ClickLimitReached(state ButtonClickState) bool {
+	return o.MaxClicks > 0 && state.Count >= o.MaxClicks
+}
+
+// RenderButtonURL 用记录的字段值渲染 URL 模板
+// values 以字段名为键；模板只有一个 {字段名} 时直接使用字段值（字段本身存放完整链接），
+// 否则对替换的值做 URL 转义。渲染结果只允许 http/https/mailto/tel 协议
+func RenderButtonURL(template string, values map[string]interface{}) (string, error) {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return "", fmt.Errorf("button url is empty")
+	}
+
+	// 整个模板只有一个字段引用时，字段值就是完整链接，不能转义
+	var rendered string
+	if isSingleFieldTemplate(template) {
+		rendered = strings.TrimSpace(RenderFieldTemplate(template, values, nil))
+	} else {
+		rendered = RenderFieldTemplate(template, values, url.QueryEscape)
+	}
+
+	parsed, err := url.Parse(rendered)
+	if err != nil {
+		return "", fmt.Errorf("invalid button url %q: %w", rendered, err)
+	}
+	if !buttonURLSchemes[strings.ToLower(parsed.Scheme)] {
+		return "", fmt.Errorf("unsupported button url scheme %q", parsed.Scheme)
+	}
+	return rendered, nil
+}
+
+// ButtonClickState Button 单元格中保存的点击状态
+type ButtonClickState struct {
+	Count           int        `json:"count"`
+	LastClickedTime *time.Time `json:"lastClickedTime,omitempty"`
+	LastClickedBy   string     `json:"lastClickedBy,omitempty"`
+	LastStatus      string     `json:"lastStatus,omitempty"` // 最近一次动作结果：running / success / failed
+}
+
+// ParseButtonClickState 从单元格值解析点击状态（支持 map 和 JSON 字符串），无法解析时返回零值
+func ParseButtonClickState(value interface{}) ButtonClickState {
+	var state ButtonClickState
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return state
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return state
+		}
+		data = encoded
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return ButtonClickState{}
+	}
+	return state
+}
+
+// Click 记录一次点击，返回新的点击状态
+func (s ButtonClickState) Click(userID, status string, at time.Time) ButtonClickState {
+	at = at.UTC()
+	return ButtonClickState{
+		Count:           s.Count + 1,
+		LastClickedTime: &at,
+		LastClickedBy:   userID,
+		LastStatus:      status,
+	}
+}
+
+// SameClick 是否为同一次点击（用于动作完成后只回写自己预占的状态）
+func (s ButtonClickState) SameClick(other ButtonClickState) bool {
+	if s.Count != other.Count || s.LastClickedBy != other.LastClickedBy {
+		return false
+	}
+	if s.LastClickedTime == nil || other.LastClickedTime == nil {
+		return s.LastClickedTime == other.LastClickedTime
+	}
+	return s.LastClickedTime.Equal(*other.LastClickedTime)
+}
+
+// ToMap 转换为单元格值
+func (s ButtonClickState) ToMap() map[string]interface{} {
+	value := map[string]interface{}{"count": s.Count}
+	if s.LastClickedTime != nil {
+		value["lastClickedTime"] = s.LastClickedTime.Format(time.RFC3339)
+	}
+	if s.LastClickedBy != "" {
+		value["lastClickedBy"] = s.LastClickedBy
+	}
+	if s.LastStatus != "" {
+		value["lastStatus"] = s.LastStatus
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package valueobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderButtonURL(t *testing.T) {
+	values := map[string]interface{}{
+		"Name":    "Tom & Jerry",
+		"Website": " https://example.com/a?b=1 ",
+		"Script":  "javascript:alert(1)",
+	}
+
+	rendered, err := RenderButtonURL("https://search.example.com/?q={Name}&missing={Other}", values)
+	require.NoError(t, err)
+	assert.Equal(t, "https://search.example.com/?q=Tom+%26+Jerry&missing={Other}", rendered)
+
+	rendered, err = RenderButtonURL("{Website}", values)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a?b=1", rendered)
+
+	// 值中的路径、片段等分隔符被转义，不能改写链接结构
+	values["Path"] = "../admin?x=1#top"
+	rendered, err = RenderButtonURL("https://example.com/users/{Path}", values)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/users/..%2Fadmin%3Fx%3D1%23top", rendered)
+
+	_, err = RenderButtonURL("{Script}", values)
+	assert.Error(t, err)
+
+	_, err = RenderButtonURL("  ", values)
+	assert.Error(t, err)
+}
+
+func TestButtonOptions_LegacyConfig(t *testing.T) {
+	options := &ButtonOptions{
+		Action: ButtonActionOpenURL,
+		Config: map[string]interface{}{"url": "https://example.com", "workflowId": "wfl_1"},
+	}
+	assert.Equal(t, "https://example.com", options.URLTemplate())
+	assert.Equal(t, "wfl_1", options.TargetWorkflowID())
+
+	options.URL = "https://example.org"
+	assert.Equal(t, "https://example.org", options.URLTemplate())
+
+	options.AllowedRoles = []string{"owner", "editor"}
+	assert.True(t, options.RoleAllowed("editor"))
+	assert.False(t, options.RoleAllowed("viewer"))
+}
+
+func TestButtonClickState(t *testing.T) {
+	assert.Equal(t, ButtonClickState{}, ParseButtonClickState(nil))
+	assert.Equal(t, ButtonClickState{}, ParseButtonClickState("not json"))
+
+	at := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	state := ParseButtonClickState(nil).Click("usr_1", "success", at)
+	assert.Equal(t, 1, state.Count)
+
+	// 单元格值经过 JSON 往返后数字为 float64
+	stored := map[string]interface{}{
+		"count":           float64(state.Count),
+		"lastClickedTime": state.ToMap()["lastClickedTime"],
+		"lastClickedBy":   "usr_1",
+	}
+	next := ParseButtonClickState(stored).Click("usr_2", "failed", at.Add(time.Minute))
+	assert.Equal(t, 2, next.Count)
+	assert.Equal(t, "usr_2", next.LastClickedBy)
+	assert.Equal(t, map[string]interface{}{
+		"count":           2,
+		"lastClickedTime": "2024-05-01T08:31:00Z",
+		"lastClickedBy":   "usr_2",
+		"lastStatus":      "failed",
+	}, next.ToMap())
+
+	fromJSON := ParseButtonClickState(`{"count":3,"lastClickedBy":"usr_3"}`)
+	assert.Equal(t, 3, fromJSON.Count)
+}
+
+func TestButtonClickLimitAndSameClick(t *testing.T) {
+	options := &ButtonOptions{}
+	assert.False(t, options.ClickLimitReached(ButtonClickState{Count: 100}))
+
+	options.MaxClicks = 2
+	assert.False(t, options.ClickLimitReached(ButtonClickState{Count: 1}))
+	assert.True(t, options.ClickLimitReached(ButtonClickState{Count: 2}))
+
+	at := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	reserved := ButtonClickState{}.Click("usr_1", "running", at)
+
+	// 单元格经过 JSON 往返后仍视为同一次点击，状态不参与比较
+	stored := ParseButtonClickState(reserved.ToMap())
+	stored.LastStatus = "success"
+	assert.True(t, reserved.SameClick(stored))
+
+	assert.False(t, reserved.SameClick(reserved.Click("usr_2", "running", at)))
+	assert.False(t, reserved.SameClick(ButtonClickState{Count: 1, LastClickedBy: "usr_1"}))
+}
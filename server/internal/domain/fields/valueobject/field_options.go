@@ -164,10 +164,13 @@ type DateOptions struct {
 
 // AIOptions AI字段选项
 type AIOptions struct {
-	Provider string                 `json:"provider"`         // openai, anthropic, etc.
-	Model    string                 `json:"model"`            // gpt-4, claude-3, etc.
-	Prompt   string                 `json:"prompt"`           // AI提示词
-	Config   map[string]interface{} `json:"config,omitempty"` // 其他配置
+	Provider     string                 `json:"provider"`               // openai, anthropic, etc.
+	Model        string                 `json:"model"`                  // gpt-4, claude-3, etc.
+	Prompt       string                 `json:"prompt"`                 // AI提示词，{字段名} 会被替换为记录中该字段的值
+	SystemPrompt string                 `json:"systemPrompt,omitempty"` // 系统提示词
+	Temperature  *float64               `json:"temperature,omitempty"`  // 采样温度
+	MaxTokens    int                    `json:"maxTokens,omitempty"`    // 单次生成的最大 token 数
+	Config       map[string]interface{} `json:"config,omitempty"`       // 其他配置
 }
 
 // CountOptions Count字段选项
@@ -184,8 +187,30 @@ type DurationOptions struct {
 // ButtonOptions Button字段选项
 type ButtonOptions struct {
 	Label  string                 `json:"label"`            // 按钮文本
-	Action string                 `json:"action"`           // 动作类型：open_url, run_script, trigger_automation
+	Action string                 `json:"action"`           // 动作类型：open_url, run_script, trigger_automation, call_webhook
 	Config map[string]interface{} `json:"config,omitempty"` // 动作配置
+
+	URL          string                `json:"url,omitempty"`          // open_url：URL 模板，{字段名} 替换为记录值
+	WorkflowID   string                `json:"workflowId,omitempty"`   // trigger_automation：触发的工作流
+	Webhook      *ButtonWebhookOptions `json:"webhook,omitempty"`      // call_webhook：调用的 Webhook
+	Confirm      *ButtonConfirmOptions `json:"confirm,omitempty"`      // 点击前的确认提示，为空表示无需确认
+	AllowedRoles []string              `json:"allowedRoles,omitempty"` // 允许点击的角色，为空表示可编辑记录的角色
+	MaxClicks    int                   `json:"maxClicks,omitempty"`    // 每条记录最多点击次数，0 表示不限制
+}
+
+// ButtonWebhookOptions Button字段 Webhook 配置
+type ButtonWebhookOptions struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`  // 默认 POST
+	Headers map[string]string `json:"headers,omitempty"` // 附加请求头
+	Secret  string            `json:"secret,omitempty"`  // 非空时对请求体做 HMAC-SHA256 签名
+}
+
+// ButtonConfirmOptions Button字段点击确认配置
+type ButtonConfirmOptions struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ConfirmText string `json:"confirmText,omitempty"`
 }
 
 // UserOptions User字段选项
@@ -63,3 +63,20 @@ func TestMultipleOptions(t *testing.T) {
 	assert.Equal(t, "field_1", options.Count.LinkFieldID)
 	assert.Equal(t, "h:mm", options.Duration.Format)
 }
+
+func TestLinkStorageOptions(t *testing.T) {
+	manyToMany := NewFieldOptions().WithLink("tbl_1", "many_to_many", false)
+	assert.True(t, manyToMany.Link.UsesJunctionTable())
+	assert.False(t, manyToMany.Link.HasStorage())
+
+	manyToOne := NewFieldOptions().WithLink("tbl_1", "many_to_one", false)
+	assert.False(t, manyToOne.Link.UsesJunctionTable())
+
+	assert.Equal(t, []string{"rec_2", "rec_1"}, ExtractLinkRecordIDs([]interface{}{
+		map[string]interface{}{"id": "rec_2", "title": "B"},
+		"rec_1",
+		"rec_2",
+	}))
+	assert.Equal(t, []string{"rec_1"}, ExtractLinkRecordIDs(map[string]interface{}{"id": "rec_1"}))
+	assert.Empty(t, ExtractLinkRecordIDs(nil))
+}
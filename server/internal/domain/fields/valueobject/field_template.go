@@ -0,0 +1,72 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fieldTemplatePattern 模板中的字段引用：{字段名}
+var fieldTemplatePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// RenderFieldTemplate 用记录的字段值替换模板中的 {字段名}（AI 提示词、按钮链接等共用）
+// values 以字段名为键；escape 对替换进去的值做转义，为 nil 时原样替换。
+// 引用了不存在的字段时保留原文
+func RenderFieldTemplate(template string, values map[string]interface{}, escape func(string) string) string {
+	return fieldTemplatePattern.ReplaceAllStringFunc(template, func(ref string) string {
+		name := strings.TrimSpace(ref[1 : len(ref)-1])
+		value, ok := values[name]
+		if !ok {
+			return ref
+		}
+		text := FormatTemplateValue(value)
+		if escape != nil {
+			return escape(text)
+		}
+		return text
+	})
+}
+
+// isSingleFieldTemplate 模板是否只有一个 {字段名} 引用
+func isSingleFieldTemplate(template string) bool {
+	match := fieldTemplatePattern.FindStringIndex(template)
+	return match != nil && match[0] == 0 && match[1] == len(template)
+}
+
+// FormatTemplateValue 将字段值格式化为模板中的文本
+// 关联记录、附件等对象取其标题/名称，多值以逗号分隔
+func FormatTemplateValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if text := FormatTemplateValue(item); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		for _, key := range []string{"title", "name", "id"} {
+			if text, ok := v[key].(string); ok && text != "" {
+				return text
+			}
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
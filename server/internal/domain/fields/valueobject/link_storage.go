@@ -0,0 +1,133 @@
+package valueobject
+
+import "strings"
+
+// Link 字段物理存储约定
+//   - many_to_one / one_to_one：在自身物理表上存储外键列（一条记录最多关联一条）
+//   - many_to_many / one_to_many：使用中间表，按 sort_order 保持关联顺序
+//   - 对称字段复用对方的存储（键名互换），外键存储的对称字段为反向外键
+//
+// 记录列中的 JSON 值仍作为单元格缓存保留，关联关系以外键列/中间表为准。
+const (
+	LinkJunctionSelfKey    = "self_id"    // 中间表：当前表记录ID
+	LinkJunctionForeignKey = "foreign_id" // 中间表：关联表记录ID
+	LinkJunctionOrderKey   = "sort_order" // 中间表：关联顺序
+	LinkRecordIDKey        = "__id"       // 物理表主键
+)
+
+// Link 关联存储类型
+const (
+	LinkStorageJunction          = "junction"            // 中间表
+	LinkStorageForeignKey        = "foreign_key"         // 外键列在自身物理表上
+	LinkStorageReverseForeignKey = "reverse_foreign_key" // 外键列在关联表上（外键字段的对称字段）
+)
+
+// LinkForeignKeyColumn 外键列名
+func LinkForeignKeyColumn(fieldID string) string {
+	return "__fk_" + fieldID
+}
+
+// LinkJunctionTableSuffix 中间表名（不含 Base 前缀）
+func LinkJunctionTableSuffix(fieldID string) string {
+	return "junction_" + fieldID
+}
+
+// UsesJunctionTable 是否使用中间表存储关联
+// 未指定关系类型时按多对多处理，与 JSON 数组的历史语义一致
+func (o *LinkOptions) UsesJunctionTable() bool {
+	switch o.Relationship {
+	case "many_to_one", "one_to_one":
+		return false
+	default:
+		return true
+	}
+}
+
+// HasStorage 是否已创建外键列/中间表
+func (o *LinkOptions) HasStorage() bool {
+	return o != nil && o.FkHostTableName != "" && o.SelfKeyName != "" && o.ForeignKeyName != ""
+}
+
+// StorageKind 已创建存储的类型，未创建时返回空字符串
+func (o *LinkOptions) StorageKind() string {
+	switch {
+	case !o.HasStorage():
+		return ""
+	case o.SelfKeyName == LinkRecordIDKey:
+		return LinkStorageForeignKey
+	case o.ForeignKeyName == LinkRecordIDKey:
+		return LinkStorageReverseForeignKey
+	default:
+		return LinkStorageJunction
+	}
+}
+
+// OwnsStorage 存储是否由该字段创建（对称字段复用对方的存储，删除时不能删除存储）
+func (o *LinkOptions) OwnsStorage(fieldID string) bool {
+	switch o.StorageKind() {
+	case LinkStorageJunction:
+		return strings.HasSuffix(o.FkHostTableName, LinkJunctionTableSuffix(fieldID))
+	case LinkStorageForeignKey:
+		return o.ForeignKeyName == LinkForeignKeyColumn(fieldID)
+	default:
+		return false
+	}
+}
+
+// ShareStorage 对称字段复用 primary 的存储（自身键与关联键互换）
+func (o *LinkOptions) ShareStorage(primary *LinkOptions) {
+	o.FkHostTableName = primary.FkHostTableName
+	o.SelfKeyName = primary.ForeignKeyName
+	o.ForeignKeyName = primary.SelfKeyName
+}
+
+// InverseRelationship 对称字段的关系类型
+func InverseRelationship(relationship string) string {
+	switch relationship {
+	case "many_to_one":
+		return "one_to_many"
+	case "one_to_many":
+		return "many_to_one"
+	case "one_to_one":
+		return "one_to_one"
+	default:
+		return "many_to_many"
+	}
+}
+
+// ExtractLinkRecordIDs 从 Link 单元格值中提取关联记录ID（保持顺序、去重）
+// 支持 "rec_1"、["rec_1"]、[{"id": "rec_1", "title": "..."}] 以及单个 {"id": "rec_1"}
+func ExtractLinkRecordIDs(value interface{}) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		add(v)
+	case []string:
+		for _, id := range v {
+			add(id)
+		}
+	case map[string]interface{}:
+		id, _ := v["id"].(string)
+		add(id)
+	case []interface{}:
+		for _, item := range v {
+			switch entry := item.(type) {
+			case string:
+				add(entry)
+			case map[string]interface{}:
+				id, _ := entry["id"].(string)
+				add(id)
+			}
+		}
+	}
+
+	return ids
+}
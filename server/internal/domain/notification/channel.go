@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+)
+
+// 通知渠道
+const (
+	ChannelInApp   = "in_app"  // 应用内：通知列表 + WebSocket 实时推送
+	ChannelEmail   = "email"   // 邮件
+	ChannelWebhook = "webhook" // 出站 Webhook / IM 机器人
+)
+
+// IsValidChannel 检查渠道是否受支持
+func IsValidChannel(channel string) bool {
+	switch channel {
+	case ChannelInApp, ChannelEmail, ChannelWebhook:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateChannels 检查渠道列表均受支持
+func ValidateChannels(channels []string) error {
+	for _, channel := range channels {
+		if !IsValidChannel(channel) {
+			return fmt.Errorf("unsupported channel %q", channel)
+		}
+	}
+	return nil
+}
+
+// Recipient 通知接收人及其联系方式
+type Recipient struct {
+	UserID     string
+	Name       string
+	Email      string
+	Preference *Preference // 出站 Webhook 地址等取自偏好设置
+}
+
+// Envelope 待投递的内容
+// 单条通知时 Notification 非空；摘要时 Digest 为合并的通知列表
+type Envelope struct {
+	Recipient    *Recipient
+	Notification *Notification
+	Digest       []*Notification
+	Title        string
+	Content      string
+}
+
+// IsDigest 是否为摘要
+func (e *Envelope) IsDigest() bool {
+	return len(e.Digest) > 0
+}
+
+// Dispatcher 渠道投递器
+type Dispatcher interface {
+	// Channel 负责的渠道
+	Channel() string
+	// Dispatch 投递内容，返回错误时按重试策略再次投递
+	Dispatch(ctx context.Context, envelope *Envelope) error
+}
+
+// RecipientResolver 查询接收人的联系方式
+type RecipientResolver interface {
+	ResolveRecipient(ctx context.Context, userID string) (*Recipient, error)
+}
@@ -0,0 +1,454 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// DeliveryStatus 渠道投递状态
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending  DeliveryStatus = "pending"  // 即将投递
+	DeliveryStatusSent     DeliveryStatus = "sent"     // 已投递
+	DeliveryStatusRetrying DeliveryStatus = "retrying" // 投递失败，等待重试
+	DeliveryStatusFailed   DeliveryStatus = "failed"   // 重试用尽
+	DeliveryStatusDeferred DeliveryStatus = "deferred" // 免打扰时段内，结束后投递
+	DeliveryStatusBatched  DeliveryStatus = "batched"  // 等待合并进摘要
+	DeliveryStatusCanceled DeliveryStatus = "canceled" // 通知已删除、过期或（摘要中）已读
+)
+
+const (
+	// DefaultDeliveryMaxRetries 外部渠道投递失败后的最大重试次数
+	DefaultDeliveryMaxRetries = 3
+	// deliveryRetryBase 首次重试间隔，之后逐次翻倍
+	deliveryRetryBase = time.Minute
+	// dueDeliveryBatchSize 每轮处理的到期投递数
+	dueDeliveryBatchSize = 500
+)
+
+// Delivery 外部渠道（邮件、Webhook）的投递记录
+// 应用内通知本身即为通知列表，不单独记录投递
+type Delivery struct {
+	ID              string         `json:"id"`
+	NotificationID  string         `json:"notification_id"`
+	UserID          string         `json:"user_id"`
+	Channel         string         `json:"channel"`
+	Status          DeliveryStatus `json:"status"`
+	RetryCount      int            `json:"retry_count"`
+	MaxRetries      int            `json:"max_retries"`
+	NextAttemptTime *time.Time     `json:"next_attempt_time,omitempty"`
+	SentTime        *time.Time     `json:"sent_time,omitempty"`
+	Error           string         `json:"error,omitempty"`
+	CreatedTime     time.Time      `json:"created_time"`
+}
+
+// newDelivery 创建待投递记录
+func newDelivery(notification *Notification, channel string, now time.Time) *Delivery {
+	return &Delivery{
+		ID:             utils.GenerateIDWithPrefix("ndl"),
+		NotificationID: notification.ID,
+		UserID:         notification.UserID,
+		Channel:        channel,
+		Status:         DeliveryStatusPending,
+		MaxRetries:     DefaultDeliveryMaxRetries,
+		CreatedTime:    now,
+	}
+}
+
+// schedule 推迟到指定时间投递
+func (d *Delivery) schedule(status DeliveryStatus, at time.Time) {
+	d.Status = status
+	d.NextAttemptTime = &at
+}
+
+// succeed 记录投递成功
+func (d *Delivery) succeed(now time.Time) {
+	d.Status = DeliveryStatusSent
+	d.SentTime = &now
+	d.NextAttemptTime = nil
+	d.Error = ""
+}
+
+// fail 记录一次失败，按指数退避安排重试；摘要失败后仍以摘要重试
+func (d *Delivery) fail(err error, now time.Time) {
+	d.Error = err.Error()
+	d.RetryCount++
+	if d.RetryCount > d.MaxRetries {
+		d.Status = DeliveryStatusFailed
+		d.NextAttemptTime = nil
+		return
+	}
+	if d.Status != DeliveryStatusBatched {
+		d.Status = DeliveryStatusRetrying
+	}
+	next := now.Add(deliveryRetryBase << (d.RetryCount - 1))
+	d.NextAttemptTime = &next
+}
+
+// cancel 不再投递
+func (d *Delivery) cancel(reason string) {
+	d.Status = DeliveryStatusCanceled
+	d.NextAttemptTime = nil
+	d.Error = reason
+}
+
+// DeliveryRepository 投递记录仓储接口
+type DeliveryRepository interface {
+	// CreateDelivery 创建投递记录
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+	// UpdateDelivery 更新投递记录
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+	// ListDueDeliveries 到期的顺延、重试和摘要投递，按创建时间排序
+	ListDueDeliveries(ctx context.Context, before time.Time, limit int) ([]*Delivery, error)
+}
+
+// Deliverer 按用户偏好把通知分发到各渠道 ✨
+//
+//   - 渠道：匹配的通知订阅优先（来源更具体的优先，停用的订阅表示静音），其次为用户偏好
+//   - 应用内通知即时推送；邮件、Webhook 等外部渠道遵循免打扰时段（紧急通知除外），
+//     开启摘要时低优先级通知合并到每日/每周摘要
+//   - 外部渠道投递失败按指数退避重试，顺延、重试和摘要由 Run 定时处理
+type Deliverer struct {
+	repo          Repository
+	deliveries    DeliveryRepository
+	preferences   PreferenceRepository
+	subscriptions SubscriptionRepository
+	recipients    RecipientResolver
+	dispatchers   map[string]Dispatcher
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+// NewDeliverer 创建通知分发器
+func NewDeliverer(
+	repo Repository,
+	deliveries DeliveryRepository,
+	preferences PreferenceRepository,
+	subscriptions SubscriptionRepository,
+	recipients RecipientResolver,
+	logger *zap.Logger,
+) *Deliverer {
+	return &Deliverer{
+		repo:          repo,
+		deliveries:    deliveries,
+		preferences:   preferences,
+		subscriptions: subscriptions,
+		recipients:    recipients,
+		dispatchers:   make(map[string]Dispatcher),
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// RegisterDispatcher 注册渠道投递器，未注册的渠道会被跳过
+func (d *Deliverer) RegisterDispatcher(dispatcher Dispatcher) {
+	d.dispatchers[dispatcher.Channel()] = dispatcher
+}
+
+// Preference 用户偏好，未设置时返回默认值
+func (d *Deliverer) Preference(ctx context.Context, userID string) (*Preference, error) {
+	preference, err := d.preferences.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if preference == nil {
+		preference = DefaultPreference(userID)
+	}
+	return preference, nil
+}
+
+// Deliver 分发一条新通知
+func (d *Deliverer) Deliver(ctx context.Context, notification *Notification) error {
+	preference, err := d.Preference(ctx, notification.UserID)
+	if err != nil {
+		return fmt.Errorf("load notification preference: %w", err)
+	}
+	channels := d.channels(ctx, notification, preference)
+	if len(channels) == 0 {
+		d.logger.Debug("Notification muted by subscription",
+			zap.String("notification_id", notification.ID),
+			zap.String("user_id", notification.UserID))
+		return nil
+	}
+
+	now := d.now()
+	recipients := make(map[string]*Recipient)
+	var errs []error
+	for _, channel := range channels {
+		dispatcher, ok := d.dispatchers[channel]
+		if !ok {
+			d.logger.Debug("Notification channel not configured", zap.String("channel", channel))
+			continue
+		}
+
+		// 应用内推送失败时通知仍在列表中，不记录投递也不重试
+		if channel == ChannelInApp {
+			envelope := &Envelope{Recipient: &Recipient{UserID: notification.UserID}, Notification: notification, Title: notification.Title, Content: notification.Content}
+			if err := dispatcher.Dispatch(ctx, envelope); err != nil {
+				d.logger.Warn("Failed to push in-app notification",
+					zap.String("notification_id", notification.ID),
+					zap.Error(err))
+			}
+			continue
+		}
+
+		delivery := newDelivery(notification, channel, now)
+		switch {
+		case notification.Priority == NotificationPriorityLow && preference.Digest != DigestOff:
+			delivery.schedule(DeliveryStatusBatched, preference.NextDigestTime(now))
+		case notification.Priority != NotificationPriorityUrgent:
+			if until, quiet := preference.QuietUntil(now); quiet {
+				delivery.schedule(DeliveryStatusDeferred, until)
+			}
+		}
+		if delivery.Status == DeliveryStatusPending {
+			d.dispatch(ctx, dispatcher, delivery, recipients, func(recipient *Recipient) *Envelope {
+				return &Envelope{Recipient: recipient, Notification: notification, Title: notification.Title, Content: notification.Content}
+			})
+		}
+		if err := d.deliveries.CreateDelivery(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("record %s delivery: %w", channel, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ProcessDue 处理到期的顺延、重试和摘要投递，返回处理的记录数
+func (d *Deliverer) ProcessDue(ctx context.Context) (int, error) {
+	now := d.now()
+	due, err := d.deliveries.ListDueDeliveries(ctx, now, dueDeliveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list due deliveries: %w", err)
+	}
+
+	recipients := make(map[string]*Recipient)
+	digests := make(map[string][]*Delivery)
+	var digestKeys []string
+	var errs []error
+	for _, delivery := range due {
+		if delivery.Status == DeliveryStatusBatched {
+			key := delivery.UserID + "|" + delivery.Channel
+			if _, ok := digests[key]; !ok {
+				digestKeys = append(digestKeys, key)
+			}
+			digests[key] = append(digests[key], delivery)
+			continue
+		}
+
+		notification := d.loadNotification(ctx, delivery)
+		if notification != nil {
+			if dispatcher, ok := d.dispatchers[delivery.Channel]; ok {
+				d.dispatch(ctx, dispatcher, delivery, recipients, func(recipient *Recipient) *Envelope {
+					return &Envelope{Recipient: recipient, Notification: notification, Title: notification.Title, Content: notification.Content}
+				})
+			} else {
+				delivery.cancel("channel is no longer configured")
+			}
+		}
+		if err := d.deliveries.UpdateDelivery(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, key := range digestKeys {
+		if err := d.sendDigest(ctx, digests[key], recipients); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(due), errors.Join(errs...)
+}
+
+// Run 定时处理到期投递，直到 ctx 取消
+func (d *Deliverer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.ProcessDue(ctx); err != nil {
+				d.logger.Warn("Failed to process due notification deliveries", zap.Error(err))
+			}
+		}
+	}
+}
+
+// sendDigest 合并同一用户同一渠道的摘要投递，跳过已读、已删除或过期的通知
+func (d *Deliverer) sendDigest(ctx context.Context, deliveries []*Delivery, recipients map[string]*Recipient) error {
+	included := make([]*Delivery, 0, len(deliveries))
+	notifications := make([]*Notification, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		notification := d.loadNotification(ctx, delivery)
+		if notification == nil {
+			continue
+		}
+		if notification.Status != NotificationStatusUnread {
+			delivery.cancel("notification already read")
+			continue
+		}
+		included = append(included, delivery)
+		notifications = append(notifications, notification)
+	}
+
+	if len(included) > 0 {
+		dispatcher, ok := d.dispatchers[included[0].Channel]
+		for _, delivery := range included {
+			if !ok {
+				delivery.cancel("channel is no longer configured")
+			}
+		}
+		if ok {
+			// 同一摘要的记录共享投递结果
+			lead := included[0]
+			d.dispatch(ctx, dispatcher, lead, recipients, func(recipient *Recipient) *Envelope {
+				return digestEnvelope(recipient, notifications)
+			})
+			for _, delivery := range included[1:] {
+				delivery.Status, delivery.RetryCount, delivery.NextAttemptTime = lead.Status, lead.RetryCount, lead.NextAttemptTime
+				delivery.SentTime, delivery.Error = lead.SentTime, lead.Error
+			}
+		}
+	}
+
+	var errs []error
+	for _, delivery := range deliveries {
+		if err := d.deliveries.UpdateDelivery(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// digestEnvelope 摘要内容：按时间顺序列出通知标题与内容
+func digestEnvelope(recipient *Recipient, notifications []*Notification) *Envelope {
+	var content strings.Builder
+	for _, notification := range notifications {
+		fmt.Fprintf(&content, "• %s\n%s\n", notification.Title, notification.Content)
+		if notification.ActionURL != "" {
+			fmt.Fprintf(&content, "%s\n", notification.ActionURL)
+		}
+		content.WriteString("\n")
+	}
+	return &Envelope{
+		Recipient: recipient,
+		Digest:    notifications,
+		Title:     fmt.Sprintf("您有 %d 条未读通知", len(notifications)),
+		Content:   strings.TrimSpace(content.String()),
+	}
+}
+
+// dispatch 投递并记录结果；接收人按用户缓存，解析失败按投递失败重试
+func (d *Deliverer) dispatch(ctx context.Context, dispatcher Dispatcher, delivery *Delivery, recipients map[string]*Recipient, envelope func(*Recipient) *Envelope) {
+	now := d.now()
+	recipient, ok := recipients[delivery.UserID]
+	if !ok {
+		resolved, err := d.resolveRecipient(ctx, delivery.UserID)
+		if err != nil {
+			delivery.fail(err, now)
+			return
+		}
+		recipient = resolved
+		recipients[delivery.UserID] = recipient
+	}
+
+	if err := dispatcher.Dispatch(ctx, envelope(recipient)); err != nil {
+		d.logger.Warn("Failed to deliver notification",
+			zap.String("delivery_id", delivery.ID),
+			zap.String("channel", delivery.Channel),
+			zap.Int("retry_count", delivery.RetryCount),
+			zap.Error(err))
+		delivery.fail(err, now)
+		return
+	}
+	delivery.succeed(now)
+}
+
+// resolveRecipient 接收人联系方式与偏好
+func (d *Deliverer) resolveRecipient(ctx context.Context, userID string) (*Recipient, error) {
+	recipient, err := d.recipients.ResolveRecipient(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve recipient: %w", err)
+	}
+	if recipient.Preference, err = d.Preference(ctx, userID); err != nil {
+		return nil, fmt.Errorf("load notification preference: %w", err)
+	}
+	return recipient, nil
+}
+
+// loadNotification 加载投递对应的通知，已删除或过期时取消投递并返回 nil
+func (d *Deliverer) loadNotification(ctx context.Context, delivery *Delivery) *Notification {
+	notification, err := d.repo.GetNotification(ctx, delivery.NotificationID)
+	if err != nil || notification == nil {
+		delivery.cancel("notification no longer exists")
+		return nil
+	}
+	if notification.IsExpired() {
+		delivery.cancel("notification expired")
+		return nil
+	}
+	return notification
+}
+
+// channels 通知的投递渠道（去重）
+func (d *Deliverer) channels(ctx context.Context, notification *Notification, preference *Preference) []string {
+	channels := preference.ChannelsFor(notification.Type)
+
+	subscriptions, err := d.subscriptions.GetUserSubscriptions(ctx, notification.UserID, &notification.Type)
+	if err != nil {
+		d.logger.Warn("Failed to load notification subscriptions, using preference",
+			zap.String("user_id", notification.UserID),
+			zap.Error(err))
+	}
+	var matched *NotificationSubscription
+	for _, subscription := range subscriptions {
+		if !subscription.matches(notification) {
+			continue
+		}
+		if matched == nil || subscription.specificity() > matched.specificity() {
+			matched = subscription
+		}
+	}
+	if matched != nil {
+		if !matched.IsActive {
+			return nil
+		}
+		channels = matched.Channels
+	}
+
+	seen := make(map[string]bool, len(channels))
+	unique := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if !seen[channel] {
+			seen[channel] = true
+			unique = append(unique, channel)
+		}
+	}
+	return unique
+}
+
+// matches 订阅是否覆盖该通知
+func (s *NotificationSubscription) matches(notification *Notification) bool {
+	return (s.SourceID == "" || s.SourceID == notification.SourceID) &&
+		(s.SourceType == "" || s.SourceType == notification.SourceType)
+}
+
+// specificity 订阅来源的具体程度，越具体越优先
+func (s *NotificationSubscription) specificity() int {
+	score := 0
+	if s.SourceID != "" {
+		score += 2
+	}
+	if s.SourceType != "" {
+		score++
+	}
+	return score
+}
@@ -0,0 +1,392 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// memoryStore 内存中的通知、偏好、订阅与投递记录
+type memoryStore struct {
+	Repository
+	notifications map[string]*Notification
+	preferences   map[string]*Preference
+	subscriptions []*NotificationSubscription
+	deliveries    map[string]*Delivery
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		notifications: make(map[string]*Notification),
+		preferences:   make(map[string]*Preference),
+		deliveries:    make(map[string]*Delivery),
+	}
+}
+
+func (m *memoryStore) GetNotification(ctx context.Context, id string) (*Notification, error) {
+	if n, ok := m.notifications[id]; ok {
+		return n, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *memoryStore) GetPreference(ctx context.Context, userID string) (*Preference, error) {
+	return m.preferences[userID], nil
+}
+
+func (m *memoryStore) SavePreference(ctx context.Context, preference *Preference) error {
+	m.preferences[preference.UserID] = preference
+	return nil
+}
+
+func (m *memoryStore) GetUserSubscriptions(ctx context.Context, userID string, notificationType *NotificationType) ([]*NotificationSubscription, error) {
+	var result []*NotificationSubscription
+	for _, s := range m.subscriptions {
+		if s.UserID == userID && (notificationType == nil || s.Type == *notificationType) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryStore) CreateSubscription(ctx context.Context, s *NotificationSubscription) error { return nil }
+func (m *memoryStore) GetSubscription(ctx context.Context, id string) (*NotificationSubscription, error) {
+	return nil, nil
+}
+func (m *memoryStore) GetSubscriptionsBySource(ctx context.Context, sourceID, sourceType string, notificationType *NotificationType) ([]*NotificationSubscription, error) {
+	return nil, nil
+}
+func (m *memoryStore) UpdateSubscription(ctx context.Context, s *NotificationSubscription) error {
+	return nil
+}
+func (m *memoryStore) DeleteSubscription(ctx context.Context, id string) error { return nil }
+func (m *memoryStore) DeleteUserSubscriptions(ctx context.Context, userID string, notificationType *NotificationType) error {
+	return nil
+}
+
+func (m *memoryStore) CreateDelivery(ctx context.Context, d *Delivery) error {
+	copied := *d
+	m.deliveries[d.ID] = &copied
+	return nil
+}
+
+func (m *memoryStore) UpdateDelivery(ctx context.Context, d *Delivery) error {
+	copied := *d
+	m.deliveries[d.ID] = &copied
+	return nil
+}
+
+func (m *memoryStore) ListDueDeliveries(ctx context.Context, before time.Time, limit int) ([]*Delivery, error) {
+	var due []*Delivery
+	for _, d := range m.deliveries {
+		if d.NextAttemptTime != nil && !d.NextAttemptTime.After(before) {
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].CreatedTime.Before(due[j].CreatedTime) })
+	return due, nil
+}
+
+func (m *memoryStore) ResolveRecipient(ctx context.Context, userID string) (*Recipient, error) {
+	return &Recipient{UserID: userID, Email: userID + "@example.com"}, nil
+}
+
+func (m *memoryStore) only(t *testing.T) *Delivery {
+	t.Helper()
+	if len(m.deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(m.deliveries))
+	}
+	for _, d := range m.deliveries {
+		return d
+	}
+	return nil
+}
+
+// recordingDispatcher 记录投递内容，可按需返回错误
+type recordingDispatcher struct {
+	channel   string
+	envelopes []*Envelope
+	err       error
+}
+
+func (r *recordingDispatcher) Channel() string { return r.channel }
+
+func (r *recordingDispatcher) Dispatch(ctx context.Context, envelope *Envelope) error {
+	r.envelopes = append(r.envelopes, envelope)
+	return r.err
+}
+
+func newTestDeliverer(store *memoryStore, now time.Time) (*Deliverer, *recordingDispatcher, *recordingDispatcher) {
+	deliverer := NewDeliverer(store, store, store, store, store, zap.NewNop())
+	deliverer.now = func() time.Time { return now }
+	inApp := &recordingDispatcher{channel: ChannelInApp}
+	email := &recordingDispatcher{channel: ChannelEmail}
+	deliverer.RegisterDispatcher(inApp)
+	deliverer.RegisterDispatcher(email)
+	return deliverer, inApp, email
+}
+
+func storeNotification(store *memoryStore, priority NotificationPriority) *Notification {
+	n := NewNotification("usr_1", NotificationTypeComment, "新评论", "李四评论了记录")
+	n.Priority = priority
+	store.notifications[n.ID] = n
+	return n
+}
+
+func TestDeliverer_DeliverImmediately(t *testing.T) {
+	store := newMemoryStore()
+	preference := DefaultPreference("usr_1")
+	preference.DefaultChannels = []string{ChannelInApp, ChannelEmail, ChannelInApp}
+	store.preferences["usr_1"] = preference
+
+	deliverer, inApp, email := newTestDeliverer(store, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	n := storeNotification(store, NotificationPriorityNormal)
+
+	if err := deliverer.Deliver(context.Background(), n); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if len(inApp.envelopes) != 1 || len(email.envelopes) != 1 {
+		t.Fatalf("expected one in-app and one email dispatch, got %d and %d", len(inApp.envelopes), len(email.envelopes))
+	}
+	if got := email.envelopes[0].Recipient.Email; got != "usr_1@example.com" {
+		t.Errorf("email recipient = %q", got)
+	}
+	if d := store.only(t); d.Status != DeliveryStatusSent || d.Channel != ChannelEmail {
+		t.Errorf("delivery = %s/%s, want email/sent", d.Channel, d.Status)
+	}
+}
+
+func TestDeliverer_QuietHoursDeferExternalChannels(t *testing.T) {
+	store := newMemoryStore()
+	preference := DefaultPreference("usr_1")
+	preference.DefaultChannels = []string{ChannelInApp, ChannelEmail}
+	preference.Timezone = "Asia/Shanghai"
+	preference.QuietHours = &QuietHours{Start: "22:00", End: "08:00"}
+	store.preferences["usr_1"] = preference
+
+	// 23:30 上海时间
+	now := time.Date(2026, 3, 2, 15, 30, 0, 0, time.UTC)
+	deliverer, inApp, email := newTestDeliverer(store, now)
+	n := storeNotification(store, NotificationPriorityHigh)
+
+	if err := deliverer.Deliver(context.Background(), n); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if len(inApp.envelopes) != 1 {
+		t.Errorf("in-app notifications ignore quiet hours")
+	}
+	if len(email.envelopes) != 0 {
+		t.Fatalf("email should be deferred during quiet hours")
+	}
+	d := store.only(t)
+	wantUntil := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC) // 08:00 上海时间
+	if d.Status != DeliveryStatusDeferred || !d.NextAttemptTime.Equal(wantUntil) {
+		t.Fatalf("delivery = %s at %v, want deferred until %v", d.Status, d.NextAttemptTime, wantUntil)
+	}
+
+	// 免打扰结束后投递
+	deliverer.now = func() time.Time { return wantUntil }
+	if _, err := deliverer.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue() error = %v", err)
+	}
+	if len(email.envelopes) != 1 || store.only(t).Status != DeliveryStatusSent {
+		t.Errorf("deferred email should be sent after quiet hours")
+	}
+}
+
+func TestDeliverer_UrgentBypassesQuietHours(t *testing.T) {
+	store := newMemoryStore()
+	preference := DefaultPreference("usr_1")
+	preference.DefaultChannels = []string{ChannelEmail}
+	preference.QuietHours = &QuietHours{Start: "00:00", End: "23:59"}
+	store.preferences["usr_1"] = preference
+
+	deliverer, _, email := newTestDeliverer(store, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	if err := deliverer.Deliver(context.Background(), storeNotification(store, NotificationPriorityUrgent)); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if len(email.envelopes) != 1 {
+		t.Errorf("urgent notifications should not wait for quiet hours")
+	}
+}
+
+func TestDeliverer_DailyDigest(t *testing.T) {
+	store := newMemoryStore()
+	preference := DefaultPreference("usr_1")
+	preference.DefaultChannels = []string{ChannelEmail}
+	preference.Digest = DigestDaily
+	preference.DigestHour = 9
+	store.preferences["usr_1"] = preference
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	deliverer, _, email := newTestDeliverer(store, now)
+
+	first := storeNotification(store, NotificationPriorityLow)
+	second := storeNotification(store, NotificationPriorityLow)
+	read := storeNotification(store, NotificationPriorityLow)
+	for _, n := range []*Notification{first, second, read} {
+		if err := deliverer.Deliver(context.Background(), n); err != nil {
+			t.Fatalf("Deliver() error = %v", err)
+		}
+	}
+	if len(email.envelopes) != 0 {
+		t.Fatalf("low priority notifications should be batched")
+	}
+	read.MarkAsRead()
+
+	deliverer.now = func() time.Time { return time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC) }
+	processed, err := deliverer.ProcessDue(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessDue() error = %v", err)
+	}
+	if processed != 3 {
+		t.Errorf("processed = %d, want 3", processed)
+	}
+	if len(email.envelopes) != 1 {
+		t.Fatalf("expected a single digest email, got %d", len(email.envelopes))
+	}
+	digest := email.envelopes[0]
+	if !digest.IsDigest() || len(digest.Digest) != 2 || !strings.Contains(digest.Title, "2") {
+		t.Errorf("digest = %q with %d notifications, want 2 unread", digest.Title, len(digest.Digest))
+	}
+
+	statuses := make(map[DeliveryStatus]int)
+	for _, d := range store.deliveries {
+		statuses[d.Status]++
+	}
+	if statuses[DeliveryStatusSent] != 2 || statuses[DeliveryStatusCanceled] != 1 {
+		t.Errorf("delivery statuses = %v, want 2 sent and 1 canceled", statuses)
+	}
+}
+
+func TestDeliverer_RetryWithBackoff(t *testing.T) {
+	store := newMemoryStore()
+	preference := DefaultPreference("usr_1")
+	preference.DefaultChannels = []string{ChannelEmail}
+	store.preferences["usr_1"] = preference
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	deliverer, _, email := newTestDeliverer(store, now)
+	email.err = errors.New("smtp unavailable")
+
+	if err := deliverer.Deliver(context.Background(), storeNotification(store, NotificationPriorityNormal)); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	d := store.only(t)
+	if d.Status != DeliveryStatusRetrying || d.RetryCount != 1 || !d.NextAttemptTime.Equal(now.Add(time.Minute)) {
+		t.Fatalf("delivery = %s retry %d at %v", d.Status, d.RetryCount, d.NextAttemptTime)
+	}
+
+	for i := 0; i < DefaultDeliveryMaxRetries; i++ {
+		next := *store.only(t).NextAttemptTime
+		deliverer.now = func() time.Time { return next }
+		if _, err := deliverer.ProcessDue(context.Background()); err != nil {
+			t.Fatalf("ProcessDue() error = %v", err)
+		}
+	}
+	if d := store.only(t); d.Status != DeliveryStatusFailed || d.Error != "smtp unavailable" {
+		t.Errorf("delivery = %s (%s), want failed after retries", d.Status, d.Error)
+	}
+	if len(email.envelopes) != DefaultDeliveryMaxRetries+1 {
+		t.Errorf("attempts = %d, want %d", len(email.envelopes), DefaultDeliveryMaxRetries+1)
+	}
+}
+
+func TestDeliverer_SubscriptionOverridesPreference(t *testing.T) {
+	store := newMemoryStore()
+	preference := DefaultPreference("usr_1")
+	preference.DefaultChannels = []string{ChannelInApp, ChannelEmail}
+	store.preferences["usr_1"] = preference
+
+	deliverer, inApp, email := newTestDeliverer(store, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+
+	muted := NewNotificationSubscription("usr_1", NotificationTypeComment)
+	muted.SourceID = "tbl_muted"
+	muted.IsActive = false
+	emailOnly := NewNotificationSubscription("usr_1", NotificationTypeComment)
+	emailOnly.Channels = []string{ChannelEmail}
+	store.subscriptions = []*NotificationSubscription{muted, emailOnly}
+
+	n := storeNotification(store, NotificationPriorityNormal)
+	n.SourceID = "tbl_muted"
+	if err := deliverer.Deliver(context.Background(), n); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if len(inApp.envelopes)+len(email.envelopes) != 0 {
+		t.Fatalf("inactive source subscription should mute the notification")
+	}
+
+	other := storeNotification(store, NotificationPriorityNormal)
+	if err := deliverer.Deliver(context.Background(), other); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if len(inApp.envelopes) != 0 || len(email.envelopes) != 1 {
+		t.Errorf("type subscription should restrict channels to email, got in-app %d email %d", len(inApp.envelopes), len(email.envelopes))
+	}
+}
+
+func TestPreference_NextDigestTime(t *testing.T) {
+	preference := DefaultPreference("usr_1")
+	preference.Digest = DigestWeekly
+	preference.DigestWeekday = time.Monday
+	preference.DigestHour = 9
+
+	// 2026-03-02 是周一
+	if got := preference.NextDigestTime(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)); !got.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("before digest hour: got %v", got)
+	}
+	if got := preference.NextDigestTime(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)); !got.Equal(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("after digest hour: got %v", got)
+	}
+}
+
+func TestPreference_Validate(t *testing.T) {
+	preference := DefaultPreference("usr_1")
+	if err := preference.Validate(); err != nil {
+		t.Fatalf("default preference should be valid: %v", err)
+	}
+
+	invalid := []func(p *Preference){
+		func(p *Preference) { p.DefaultChannels = []string{"sms"} },
+		func(p *Preference) { p.Timezone = "Mars/Base" },
+		func(p *Preference) { p.QuietHours = &QuietHours{Start: "25:00", End: "08:00"} },
+		func(p *Preference) { p.Digest = "hourly" },
+		func(p *Preference) { p.WebhookURL = "ftp://example.com/hook" },
+		func(p *Preference) { p.WebhookURL = "http://127.0.0.1:8080/hook" },
+		func(p *Preference) { p.WebhookURL = "http://169.254.169.254/latest/meta-data" },
+		func(p *Preference) { p.WebhookURL = "http://localhost/hook" },
+	}
+	for i, mutate := range invalid {
+		p := DefaultPreference("usr_1")
+		mutate(p)
+		if err := p.Validate(); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
+
+func TestNotificationTemplate_Render(t *testing.T) {
+	template := NewNotificationTemplate(NotificationTypeMention, "mention", "{{ actor.name }} 提到了你", "在 {{table}} 中：{{comment}}")
+	template.DefaultData = map[string]interface{}{"table": "任务"}
+
+	title, content, err := template.Render(map[string]interface{}{
+		"actor":   map[string]interface{}{"name": "张三"},
+		"comment": "请看一下",
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if title != "张三 提到了你" || content != "在 任务 中：请看一下" {
+		t.Errorf("Render() = %q, %q", title, content)
+	}
+
+	if _, _, err := template.Render(nil); err == nil || !strings.Contains(err.Error(), "actor.name, comment") {
+		t.Errorf("Render() missing variables error = %v", err)
+	}
+}
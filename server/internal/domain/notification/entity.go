@@ -248,11 +248,13 @@ type NotificationStats struct {
 }
 
 // CreateNotificationRequest 创建通知请求
+// 指定 TemplateID 时标题和内容由模板渲染，Data 提供模板变量
 type CreateNotificationRequest struct {
 	UserID     string                 `json:"user_id" binding:"required"`
 	Type       NotificationType       `json:"type" binding:"required"`
-	Title      string                 `json:"title" binding:"required"`
-	Content    string                 `json:"content" binding:"required"`
+	Title      string                 `json:"title,omitempty"`
+	Content    string                 `json:"content,omitempty"`
+	TemplateID string                 `json:"template_id,omitempty"`
 	Data       map[string]interface{} `json:"data,omitempty"`
 	Priority   NotificationPriority   `json:"priority,omitempty"`
 	SourceID   string                 `json:"source_id,omitempty"`
@@ -297,7 +299,7 @@ type MarkNotificationsReadRequest struct {
 
 // CreateSubscriptionRequest 创建订阅请求
 type CreateSubscriptionRequest struct {
-	UserID     string                 `json:"user_id" binding:"required"`
+	UserID     string                 `json:"user_id,omitempty"` // 由接口层设置为当前用户
 	Type       NotificationType       `json:"type" binding:"required"`
 	SourceID   string                 `json:"source_id,omitempty"`
 	SourceType string                 `json:"source_type,omitempty"`
@@ -0,0 +1,244 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// DigestFrequency 摘要频率
+type DigestFrequency string
+
+const (
+	DigestOff    DigestFrequency = "off"    // 不合并，低优先级通知也即时投递
+	DigestDaily  DigestFrequency = "daily"  // 每日摘要
+	DigestWeekly DigestFrequency = "weekly" // 每周摘要
+)
+
+// 出站 Webhook 消息格式
+const (
+	WebhookFormatGeneric  = "generic"  // 通用 JSON
+	WebhookFormatSlack    = "slack"    // Slack Incoming Webhook
+	WebhookFormatDingTalk = "dingtalk" // 钉钉机器人
+	WebhookFormatFeishu   = "feishu"   // 飞书机器人
+)
+
+// QuietHours 免打扰时段（本地时间 HH:MM，结束早于开始表示跨午夜）
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Preference 用户通知偏好
+//
+// 渠道选择顺序：匹配的通知订阅 > 按类型设置的渠道 > 默认渠道。
+// 免打扰时段和摘要只作用于邮件、Webhook 等外部渠道，应用内通知总是即时送达；
+// 紧急通知不受免打扰限制，低优先级通知在开启摘要时合并发送
+type Preference struct {
+	UserID          string                        `json:"user_id"`
+	DefaultChannels []string                      `json:"default_channels"`
+	Channels        map[NotificationType][]string `json:"channels,omitempty"`
+	Timezone        string                        `json:"timezone"`
+	QuietHours      *QuietHours                   `json:"quiet_hours,omitempty"`
+	Digest          DigestFrequency               `json:"digest"`
+	DigestHour      int                           `json:"digest_hour"`    // 摘要发送时刻（本地时间 0-23 点）
+	DigestWeekday   time.Weekday                  `json:"digest_weekday"` // 每周摘要的发送日（0 为周日）
+	WebhookURL      string                        `json:"webhook_url,omitempty"`
+	WebhookFormat   string                        `json:"webhook_format,omitempty"`
+	UpdatedTime     time.Time                     `json:"updated_time"`
+}
+
+// DefaultPreference 未设置偏好时的默认值：仅应用内通知
+func DefaultPreference(userID string) *Preference {
+	return &Preference{
+		UserID:          userID,
+		DefaultChannels: []string{ChannelInApp},
+		Timezone:        "UTC",
+		Digest:          DigestOff,
+		DigestHour:      9,
+		DigestWeekday:   time.Monday,
+		WebhookFormat:   WebhookFormatGeneric,
+		UpdatedTime:     time.Now(),
+	}
+}
+
+// Validate 校验偏好设置
+func (p *Preference) Validate() error {
+	channels := append([]string{}, p.DefaultChannels...)
+	for _, typed := range p.Channels {
+		channels = append(channels, typed...)
+	}
+	if err := ValidateChannels(channels); err != nil {
+		return err
+	}
+
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q", p.Timezone)
+	}
+	if p.QuietHours != nil {
+		if _, err := parseClock(p.QuietHours.Start); err != nil {
+			return err
+		}
+		if _, err := parseClock(p.QuietHours.End); err != nil {
+			return err
+		}
+	}
+
+	switch p.Digest {
+	case DigestOff, DigestDaily, DigestWeekly:
+	default:
+		return fmt.Errorf("unsupported digest frequency %q", p.Digest)
+	}
+	if p.DigestHour < 0 || p.DigestHour > 23 {
+		return fmt.Errorf("digest hour must be between 0 and 23")
+	}
+	if p.DigestWeekday < time.Sunday || p.DigestWeekday > time.Saturday {
+		return fmt.Errorf("digest weekday must be between 0 and 6")
+	}
+
+	switch p.WebhookFormat {
+	case "", WebhookFormatGeneric, WebhookFormatSlack, WebhookFormatDingTalk, WebhookFormatFeishu:
+	default:
+		return fmt.Errorf("unsupported webhook format %q", p.WebhookFormat)
+	}
+	if p.WebhookURL != "" {
+		// 只允许公网 http(s) 地址，避免通过通知 Webhook 访问内网服务
+		if err := utils.ValidateOutboundURL(p.WebhookURL); err != nil {
+			return fmt.Errorf("invalid webhook url: %w", err)
+		}
+	}
+	return nil
+}
+
+// ChannelsFor 通知类型对应的渠道
+func (p *Preference) ChannelsFor(notificationType NotificationType) []string {
+	if channels, ok := p.Channels[notificationType]; ok {
+		return channels
+	}
+	return p.DefaultChannels
+}
+
+// Location 用户时区，无效时使用 UTC
+func (p *Preference) Location() *time.Location {
+	if location, err := time.LoadLocation(p.Timezone); err == nil {
+		return location
+	}
+	return time.UTC
+}
+
+// QuietUntil 当前处于免打扰时段时返回时段结束时间
+func (p *Preference) QuietUntil(now time.Time) (time.Time, bool) {
+	if p.QuietHours == nil {
+		return time.Time{}, false
+	}
+	start, err := parseClock(p.QuietHours.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := parseClock(p.QuietHours.End)
+	if err != nil || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(p.Location())
+	minute := local.Hour()*60 + local.Minute()
+	quiet := minute >= start && minute < end
+	if start > end {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, local.Location())
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// NextDigestTime 下一次发送摘要的时间
+func (p *Preference) NextDigestTime(now time.Time) time.Time {
+	local := now.In(p.Location())
+	next := time.Date(local.Year(), local.Month(), local.Day(), p.DigestHour, 0, 0, 0, local.Location())
+	if p.Digest == DigestWeekly {
+		days := (int(p.DigestWeekday) - int(local.Weekday()) + 7) % 7
+		next = next.AddDate(0, 0, days)
+		if !next.After(local) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	}
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// parseClock 解析 HH:MM 为当天的分钟数
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+// UpdatePreferenceRequest 更新通知偏好请求（未提供的字段保持不变）
+type UpdatePreferenceRequest struct {
+	DefaultChannels []string                      `json:"default_channels,omitempty"`
+	Channels        map[NotificationType][]string `json:"channels,omitempty"`
+	Timezone        *string                       `json:"timezone,omitempty"`
+	QuietHours      *QuietHours                   `json:"quiet_hours,omitempty"`
+	ClearQuietHours bool                          `json:"clear_quiet_hours,omitempty"`
+	Digest          *DigestFrequency              `json:"digest,omitempty"`
+	DigestHour      *int                          `json:"digest_hour,omitempty"`
+	DigestWeekday   *time.Weekday                 `json:"digest_weekday,omitempty"`
+	WebhookURL      *string                       `json:"webhook_url,omitempty"`
+	WebhookFormat   *string                       `json:"webhook_format,omitempty"`
+}
+
+// Apply 将更新应用到偏好设置
+func (r *UpdatePreferenceRequest) Apply(p *Preference) {
+	if r.DefaultChannels != nil {
+		p.DefaultChannels = r.DefaultChannels
+	}
+	if r.Channels != nil {
+		p.Channels = r.Channels
+	}
+	if r.Timezone != nil {
+		p.Timezone = *r.Timezone
+	}
+	if r.QuietHours != nil {
+		p.QuietHours = r.QuietHours
+	}
+	if r.ClearQuietHours {
+		p.QuietHours = nil
+	}
+	if r.Digest != nil {
+		p.Digest = *r.Digest
+	}
+	if r.DigestHour != nil {
+		p.DigestHour = *r.DigestHour
+	}
+	if r.DigestWeekday != nil {
+		p.DigestWeekday = *r.DigestWeekday
+	}
+	if r.WebhookURL != nil {
+		p.WebhookURL = *r.WebhookURL
+	}
+	if r.WebhookFormat != nil {
+		p.WebhookFormat = *r.WebhookFormat
+	}
+	p.UpdatedTime = time.Now()
+}
+
+// PreferenceRepository 通知偏好仓储接口
+type PreferenceRepository interface {
+	// GetPreference 获取用户偏好，未设置时返回 nil
+	GetPreference(ctx context.Context, userID string) (*Preference, error)
+	// SavePreference 保存用户偏好
+	SavePreference(ctx context.Context, preference *Preference) error
+}
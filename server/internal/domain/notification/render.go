@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// templateVariablePattern 模板变量占位符 {{name}}，支持 a.b 形式的嵌套取值
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][\w.]*)\s*\}\}`)
+
+// Render 用数据渲染模板的标题和内容
+// 变量取值优先使用 data，其次为模板默认数据；任何占位符缺少取值都返回错误，避免发出残缺的通知
+func (t *NotificationTemplate) Render(data map[string]interface{}) (string, string, error) {
+	missing := make(map[string]bool)
+	render := func(text string) string {
+		return templateVariablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+			name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+			value, ok := lookupVariable(data, name)
+			if !ok {
+				value, ok = lookupVariable(t.DefaultData, name)
+			}
+			if !ok {
+				missing[name] = true
+				return placeholder
+			}
+			return fmt.Sprint(value)
+		})
+	}
+
+	title, content := render(t.Title), render(t.Content)
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", "", fmt.Errorf("missing template variables: %s", strings.Join(names, ", "))
+	}
+	return title, content, nil
+}
+
+// lookupVariable 按 a.b 路径取值
+func lookupVariable(data map[string]interface{}, name string) (interface{}, bool) {
+	var current interface{} = data
+	for _, key := range strings.Split(name, ".") {
+		values, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = values[key]; !ok || current == nil {
+			return nil, false
+		}
+	}
+	return current, true
+}
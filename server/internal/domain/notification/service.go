@@ -66,6 +66,15 @@ type Service interface {
 	SendBulkNotifications(ctx context.Context, notifications []*Notification) error
 	// SendNotificationToSubscribers 向订阅者发送通知
 	SendNotificationToSubscribers(ctx context.Context, notificationType NotificationType, sourceID, sourceType string, title, content string, data map[string]interface{}) error
+
+	// Preference management ✨
+	// GetPreference 获取用户通知偏好，未设置时返回默认值
+	GetPreference(ctx context.Context, userID string) (*Preference, error)
+	// UpdatePreference 更新用户通知偏好
+	UpdatePreference(ctx context.Context, userID string, req *UpdatePreferenceRequest) (*Preference, error)
+
+	// SetDeliverer 设置渠道分发器（用于延迟注入）
+	SetDeliverer(deliverer *Deliverer, preferences PreferenceRepository)
 }
 
 // service 通知服务实现
@@ -74,6 +83,9 @@ type service struct {
 	templateRepo     TemplateRepository
 	subscriptionRepo SubscriptionRepository
 	logger           *zap.Logger
+
+	deliverer      *Deliverer           // ✨ 渠道分发
+	preferenceRepo PreferenceRepository // ✨ 用户通知偏好
 }
 
 // NewService 创建通知服务
@@ -91,9 +103,32 @@ func NewService(
 	}
 }
 
+// SetDeliverer 设置渠道分发器（用于延迟注入）
+func (s *service) SetDeliverer(deliverer *Deliverer, preferences PreferenceRepository) {
+	s.deliverer = deliverer
+	s.preferenceRepo = preferences
+}
+
 // CreateNotification 创建通知
+// 指定模板时标题和内容由模板与 data 渲染，否则必须提供标题和内容
 func (s *service) CreateNotification(ctx context.Context, req *CreateNotificationRequest) (*Notification, error) {
-	notification := NewNotification(req.UserID, req.Type, req.Title, req.Content)
+	title, content := req.Title, req.Content
+	if req.TemplateID != "" {
+		template, err := s.GetTemplate(ctx, req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if !template.IsActive {
+			return nil, pkgErrors.ErrValidationFailed.WithDetails("Template is inactive")
+		}
+		if title, content, err = template.Render(req.Data); err != nil {
+			return nil, pkgErrors.ErrValidationFailed.WithDetails(err.Error())
+		}
+	} else if title == "" || content == "" {
+		return nil, pkgErrors.ErrValidationFailed.WithDetails("Title and content are required without a template")
+	}
+
+	notification := NewNotification(req.UserID, req.Type, title, content)
 
 	if req.Data != nil {
 		notification.Data = req.Data
@@ -357,6 +392,10 @@ func (s *service) ListTemplates(ctx context.Context, notificationType *Notificat
 
 // CreateSubscription 创建订阅
 func (s *service) CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*NotificationSubscription, error) {
+	if err := ValidateChannels(req.Channels); err != nil {
+		return nil, pkgErrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
 	subscription := NewNotificationSubscription(req.UserID, req.Type)
 
 	if req.SourceID != "" {
@@ -426,6 +465,9 @@ func (s *service) UpdateSubscription(ctx context.Context, id string, req *Update
 	}
 
 	if req.Channels != nil {
+		if err := ValidateChannels(req.Channels); err != nil {
+			return nil, pkgErrors.ErrValidationFailed.WithDetails(err.Error())
+		}
 		subscription.Channels = req.Channels
 	}
 	if req.Settings != nil {
@@ -474,7 +516,12 @@ func (s *service) DeleteUserSubscriptions(ctx context.Context, userID string, no
 }
 
 // SendNotification 发送通知
+// ✨ 配置分发器时按订阅与用户偏好投递到各渠道；否则只检查订阅并记录日志
 func (s *service) SendNotification(ctx context.Context, notification *Notification) error {
+	if s.deliverer != nil {
+		return s.deliverer.Deliver(ctx, notification)
+	}
+
 	// 获取用户订阅
 	subscriptions, err := s.subscriptionRepo.GetUserSubscriptions(ctx, notification.UserID, &notification.Type)
 	if err != nil {
@@ -503,8 +550,6 @@ func (s *service) SendNotification(ctx context.Context, notification *Notificati
 		return nil
 	}
 
-	// 这里应该实现具体的通知发送逻辑
-	// 例如：发送到WebSocket、发送邮件、发送推送通知等
 	s.logger.Info("Notification sent",
 		zap.String("notification_id", notification.ID),
 		zap.String("user_id", notification.UserID),
@@ -573,3 +618,48 @@ func (s *service) SendNotificationToSubscribers(ctx context.Context, notificatio
 
 	return nil
 }
+
+// GetPreference 获取用户通知偏好，未设置时返回默认值
+func (s *service) GetPreference(ctx context.Context, userID string) (*Preference, error) {
+	if s.preferenceRepo == nil {
+		return DefaultPreference(userID), nil
+	}
+
+	preference, err := s.preferenceRepo.GetPreference(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get notification preference",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, pkgErrors.ErrInternalServer.WithDetails(err.Error())
+	}
+	if preference == nil {
+		preference = DefaultPreference(userID)
+	}
+
+	return preference, nil
+}
+
+// UpdatePreference 更新用户通知偏好
+func (s *service) UpdatePreference(ctx context.Context, userID string, req *UpdatePreferenceRequest) (*Preference, error) {
+	if s.preferenceRepo == nil {
+		return nil, pkgErrors.ErrFeatureNotAvailable.WithDetails("Notification preferences are not enabled")
+	}
+
+	preference, err := s.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(preference)
+	if err := preference.Validate(); err != nil {
+		return nil, pkgErrors.ErrValidationFailed.WithDetails(err.Error())
+	}
+
+	if err := s.preferenceRepo.SavePreference(ctx, preference); err != nil {
+		s.logger.Error("Failed to save notification preference",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, pkgErrors.ErrInternalServer.WithDetails(err.Error())
+	}
+
+	return preference, nil
+}
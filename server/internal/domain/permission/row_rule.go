@@ -0,0 +1,260 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// RowRule 行级访问规则
+//
+// 规则以视图过滤器（valueobject.Filter）表达可访问的行，支持占位符：
+//   - "@me"：当前用户，如 负责人 is @me
+//   - "@myGroups"：当前用户所属部门，如 区域 hasAnyOf @myGroups
+//
+// 组合语义：
+//   - 角色必须先具备对应的记录权限（RolePermissions），规则只能进一步收窄范围
+//   - 可配置权限矩阵的角色（owner / creator 等）不受规则约束
+//   - 同一操作下多条适用规则取并集（OR）
+//   - update / delete 在自身规则之外还必须满足 read 规则（不能修改看不到的行）
+//   - 某个操作没有任何适用规则时，该操作不做行级限制
+type RowRule struct {
+	ID        string
+	TableID   string
+	Name      string
+	Roles     []Role   // 适用角色，为空表示所有角色
+	Actions   []Action // 适用操作：record|read、record|update、record|delete、record|create
+	Filter    *valueobject.Filter
+	Enabled   bool
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// 行级规则错误
+var (
+	ErrRowRuleNotFound      = errors.New("row rule not found")
+	ErrInvalidRowRule       = errors.New("invalid row rule")
+	ErrRowAccessDenied      = errors.New("row access denied")
+	ErrUnsupportedRowAction = errors.New("unsupported row rule action")
+)
+
+// RowRuleActions 行级规则支持的操作
+var RowRuleActions = []Action{
+	ActionRecordRead,
+	ActionRecordUpdate,
+	ActionRecordDelete,
+	ActionRecordCreate,
+}
+
+// NewRowRule 创建行级规则
+func NewRowRule(tableID, name string, roles []Role, actions []Action, filter *valueobject.Filter, createdBy string) (*RowRule, error) {
+	now := time.Now()
+	rule := &RowRule{
+		ID:        "rrl_" + utils.GenerateNanoID(utils.DefaultIDLength),
+		TableID:   tableID,
+		Name:      strings.TrimSpace(name),
+		Roles:     roles,
+		Actions:   actions,
+		Filter:    filter,
+		Enabled:   true,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if len(rule.Actions) == 0 {
+		rule.Actions = []Action{ActionRecordRead}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate 验证规则
+func (r *RowRule) Validate() error {
+	if r.TableID == "" {
+		return fmt.Errorf("%w: table ID is required", ErrInvalidRowRule)
+	}
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRowRule)
+	}
+	if r.Filter.IsEmpty() {
+		return fmt.Errorf("%w: filter is required", ErrInvalidRowRule)
+	}
+	if err := r.Filter.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRowRule, err)
+	}
+	for _, action := range r.Actions {
+		if !isRowRuleAction(action) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedRowAction, action)
+		}
+	}
+	for _, role := range r.Roles {
+		if _, ok := RolePermissions[role]; !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidRole, role)
+		}
+	}
+	return nil
+}
+
+// Update 更新规则内容
+func (r *RowRule) Update(name string, roles []Role, actions []Action, filter *valueobject.Filter, enabled bool) error {
+	updated := *r
+	updated.Name = strings.TrimSpace(name)
+	updated.Roles = roles
+	updated.Actions = actions
+	updated.Filter = filter
+	updated.Enabled = enabled
+	if len(updated.Actions) == 0 {
+		updated.Actions = []Action{ActionRecordRead}
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+	*r = updated
+	return nil
+}
+
+// AppliesTo 检查规则是否适用于指定角色和操作
+func (r *RowRule) AppliesTo(role Role, action Action) bool {
+	if !r.Enabled {
+		return false
+	}
+
+	actionMatched := false
+	for _, a := range r.Actions {
+		if a == action {
+			actionMatched = true
+			break
+		}
+	}
+	if !actionMatched {
+		return false
+	}
+
+	if len(r.Roles) == 0 {
+		return true
+	}
+	for _, ro := range r.Roles {
+		if ro == role {
+			return true
+		}
+	}
+	return false
+}
+
+// RowSubject 行级权限的求值主体
+type RowSubject struct {
+	UserID   string
+	Role     Role // 为空表示未解析到协作者角色，此时只应用规则、不校验角色矩阵
+	GroupIDs []string
+}
+
+// RowAccess 行级权限求值结果
+type RowAccess struct {
+	Allowed      bool // 角色是否具备该操作权限
+	Unrestricted bool // 是否不受行级规则约束
+	// Scopes 行范围：外层为 AND，内层为 OR
+	// 例如 update 操作为 [[read 规则...], [update 规则...]]
+	Scopes [][]*valueobject.Filter
+}
+
+// Match 判断记录是否在可访问范围内
+func (a *RowAccess) Match(values map[string]interface{}) bool {
+	if a == nil {
+		return true
+	}
+	if !a.Allowed {
+		return false
+	}
+	if a.Unrestricted {
+		return true
+	}
+
+	for _, scope := range a.Scopes {
+		matched := false
+		for _, filter := range scope {
+			if filter.Match(values) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// EvaluateRowAccess 计算主体对某操作的行级访问范围
+// 没有角色（不是协作者）的主体无权访问任何记录
+func EvaluateRowAccess(subject RowSubject, action Action, rules []*RowRule) *RowAccess {
+	if subject.Role == "" || !HasRolePermission(subject.Role, action) {
+		return &RowAccess{Allowed: false}
+	}
+	// 能配置权限矩阵的角色不受行级规则约束
+	if HasRolePermission(subject.Role, ActionBaseAuthorityMatrixConfig) {
+		return &RowAccess{Allowed: true, Unrestricted: true}
+	}
+
+	actions := []Action{action}
+	if action == ActionRecordUpdate || action == ActionRecordDelete {
+		actions = []Action{ActionRecordRead, action}
+	}
+
+	fc := valueobject.FilterContext{
+		UserID:   subject.UserID,
+		GroupIDs: subject.GroupIDs,
+	}
+
+	scopes := make([][]*valueobject.Filter, 0, len(actions))
+	for _, a := range actions {
+		var scope []*valueobject.Filter
+		for _, rule := range rules {
+			if rule.AppliesTo(subject.Role, a) {
+				scope = append(scope, rule.Filter.Resolve(fc))
+			}
+		}
+		if len(scope) > 0 {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &RowAccess{
+		Allowed:      true,
+		Unrestricted: len(scopes) == 0,
+		Scopes:       scopes,
+	}
+}
+
+// RowRuleRepository 行级规则仓储接口
+type RowRuleRepository interface {
+	Save(ctx context.Context, rule *RowRule) error
+	FindByID(ctx context.Context, id string) (*RowRule, error)
+	FindByTableID(ctx context.Context, tableID string) ([]*RowRule, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// GroupResolver 解析用户所属部门（用于 @myGroups 占位符）
+type GroupResolver interface {
+	ResolveUserGroups(ctx context.Context, userID string) ([]string, error)
+}
+
+func isRowRuleAction(action Action) bool {
+	for _, a := range RowRuleActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+func ownerIsMeFilter() *valueobject.Filter {
+	return &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters: []valueobject.FilterItem{
+			{FieldID: "fld_owner", Operator: valueobject.FilterItemOpIs, Value: valueobject.FilterPlaceholderMe},
+		},
+	}
+}
+
+func regionInMyGroupsFilter() *valueobject.Filter {
+	return &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters: []valueobject.FilterItem{
+			{FieldID: "fld_region", Operator: valueobject.FilterItemOpHasAnyOf, Value: []interface{}{valueobject.FilterPlaceholderMyGroups}},
+		},
+	}
+}
+
+func TestNewRowRule(t *testing.T) {
+	rule, err := NewRowRule("tbl_1", "只看自己的商机", []Role{RoleEditor}, nil, ownerIsMeFilter(), "usr_admin")
+	require.NoError(t, err)
+	assert.Equal(t, []Action{ActionRecordRead}, rule.Actions)
+	assert.True(t, rule.Enabled)
+
+	_, err = NewRowRule("tbl_1", "无过滤条件", nil, nil, nil, "usr_admin")
+	assert.ErrorIs(t, err, ErrInvalidRowRule)
+
+	_, err = NewRowRule("tbl_1", "不支持的操作", nil, []Action{ActionTableDelete}, ownerIsMeFilter(), "usr_admin")
+	assert.ErrorIs(t, err, ErrUnsupportedRowAction)
+}
+
+func TestEvaluateRowAccess(t *testing.T) {
+	readRule, err := NewRowRule("tbl_1", "负责人是我", []Role{RoleEditor, RoleViewer}, []Action{ActionRecordRead}, ownerIsMeFilter(), "usr_admin")
+	require.NoError(t, err)
+	regionRule, err := NewRowRule("tbl_1", "我所在区域", []Role{RoleViewer}, []Action{ActionRecordRead}, regionInMyGroupsFilter(), "usr_admin")
+	require.NoError(t, err)
+	deleteRule, err := NewRowRule("tbl_1", "只能删除草稿", nil, []Action{ActionRecordDelete}, &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters: []valueobject.FilterItem{
+			{FieldID: "fld_status", Operator: valueobject.FilterItemOpIs, Value: "draft"},
+		},
+	}, "usr_admin")
+	require.NoError(t, err)
+	rules := []*RowRule{readRule, regionRule, deleteRule}
+
+	mine := map[string]interface{}{
+		"fld_owner":  map[string]interface{}{"id": "usr_1", "name": "Alice"},
+		"fld_region": "east",
+		"fld_status": "won",
+	}
+	others := map[string]interface{}{
+		"fld_owner":  map[string]interface{}{"id": "usr_2", "name": "Bob"},
+		"fld_region": "east",
+		"fld_status": "draft",
+	}
+
+	t.Run("编辑者只能读取自己负责的记录", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1", Role: RoleEditor}, ActionRecordRead, rules)
+		assert.True(t, access.Allowed)
+		assert.False(t, access.Unrestricted)
+		assert.True(t, access.Match(mine))
+		assert.False(t, access.Match(others))
+	})
+
+	t.Run("同一操作的多条规则取并集", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1", Role: RoleViewer, GroupIDs: []string{"east"}}, ActionRecordRead, rules)
+		assert.True(t, access.Match(mine))
+		assert.True(t, access.Match(others))
+	})
+
+	t.Run("删除同时受读取规则约束", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1", Role: RoleEditor}, ActionRecordDelete, rules)
+		assert.Len(t, access.Scopes, 2)
+		assert.False(t, access.Match(mine))   // 不是草稿
+		assert.False(t, access.Match(others)) // 看不到
+	})
+
+	t.Run("角色没有权限时直接拒绝", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1", Role: RoleViewer}, ActionRecordUpdate, rules)
+		assert.False(t, access.Allowed)
+		assert.False(t, access.Match(mine))
+	})
+
+	t.Run("没有角色时直接拒绝", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1"}, ActionRecordRead, rules)
+		assert.False(t, access.Allowed)
+		assert.False(t, access.Match(mine))
+
+		access = EvaluateRowAccess(RowSubject{UserID: "usr_1"}, ActionRecordRead, nil)
+		assert.False(t, access.Allowed)
+	})
+
+	t.Run("所有者不受行级规则约束", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1", Role: RoleOwner}, ActionRecordRead, rules)
+		assert.True(t, access.Unrestricted)
+		assert.True(t, access.Match(others))
+	})
+
+	t.Run("没有适用规则时不限制", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_1", Role: RoleEditor}, ActionRecordUpdate, nil)
+		assert.True(t, access.Unrestricted)
+	})
+
+	t.Run("未知部门列表不匹配任何区域", func(t *testing.T) {
+		access := EvaluateRowAccess(RowSubject{UserID: "usr_3", Role: RoleViewer}, ActionRecordRead, []*RowRule{regionRule})
+		assert.False(t, access.Match(mine))
+	})
+}
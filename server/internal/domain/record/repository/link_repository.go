@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+)
+
+// LinkRepository Link 字段关联存储仓储接口
+// many_to_one/one_to_one 使用外键列，many_to_many/one_to_many 使用中间表
+type LinkRepository interface {
+	// EnsureStorage 创建外键列或中间表（幂等），返回写入了存储信息的 Link 选项
+	// 对称字段复用另一侧的存储
+	EnsureStorage(ctx context.Context, field *fieldEntity.Field) (*fieldValueobject.LinkOptions, error)
+
+	// DropStorage 删除外键列或中间表
+	DropStorage(ctx context.Context, field *fieldEntity.Field) error
+
+	// ReplaceLinks 替换记录的全部关联，foreignIDs 的顺序即关联顺序
+	ReplaceLinks(ctx context.Context, field *fieldEntity.Field, recordID string, foreignIDs []string) error
+
+	// DeleteRecordLinks 删除记录作为关联发起方的所有关联（记录行被删除时调用）
+	DeleteRecordLinks(ctx context.Context, field *fieldEntity.Field, recordIDs []string) error
+
+	// FindLinkedRecordIDs 按关联顺序查询记录关联的记录ID
+	FindLinkedRecordIDs(ctx context.Context, field *fieldEntity.Field, recordID string) ([]string, error)
+
+	// FindReferencingRecordIDs 反向查询：哪些记录关联了 foreignIDs（走索引）
+	FindReferencingRecordIDs(ctx context.Context, field *fieldEntity.Field, foreignIDs []string) ([]string, error)
+
+	// DeleteForeignLinks 删除指向 foreignIDs 的关联并刷新受影响记录的 JSON 缓存（关联表记录删除前调用）
+	DeleteForeignLinks(ctx context.Context, field *fieldEntity.Field, foreignIDs []string) error
+
+	// RefreshLinkCache 按关联存储重写记录列中的 JSON 缓存（用于对称字段）
+	RefreshLinkCache(ctx context.Context, field *fieldEntity.Field, recordIDs []string) error
+
+	// FindLinkFieldsPointingTo 查询关联到指定表的所有 Link 字段
+	FindLinkFieldsPointingTo(ctx context.Context, tableID string) ([]*fieldEntity.Field, error)
+
+	// ConvertLegacyColumn 将旧版 TEXT[] 关联列转换为 JSONB（幂等），返回是否做了转换
+	ConvertLegacyColumn(ctx context.Context, field *fieldEntity.Field) (bool, error)
+
+	// MigrateJSONLinks 将记录列中的 JSON 关联值写入外键列/中间表，返回迁移的记录数
+	MigrateJSONLinks(ctx context.Context, field *fieldEntity.Field) (int, error)
+}
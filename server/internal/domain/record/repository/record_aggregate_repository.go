@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	viewValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// RecordAggregateRepository 记录聚合仓储接口
+// 分组统计、列统计等在物理表上以 SQL 聚合完成，不加载记录
+type RecordAggregateRepository interface {
+	// AggregateGroups 按分组层级统计记录数和列统计
+	// 返回总计行（Keys 为空）以及每一层分组的行，每层按分组排序方向排列
+	AggregateGroups(ctx context.Context, query GroupAggregateQuery) (*GroupAggregateResult, error)
+}
+
+// GroupAggregateQuery 分组统计查询
+type GroupAggregateQuery struct {
+	// Filter 过滤条件（只使用 TableID、Filters、AccessScopes）
+	Filter RecordFilter
+	// GroupBy 分组层级（最多3层）
+	GroupBy []viewValueobject.GroupItem
+	// Statistics 每个分组需要计算的列统计
+	Statistics []viewValueobject.ColumnStatistic
+	// MaxGroups 每一层最多返回的分组数，超出时 Truncated 为 true
+	MaxGroups int
+}
+
+// GroupAggregateResult 分组统计结果
+type GroupAggregateResult struct {
+	Rows      []*GroupAggregateRow
+	Truncated bool
+}
+
+// GroupAggregateRow 分组统计行
+type GroupAggregateRow struct {
+	// Keys 从第一层到该层的分组值，空值为 nil；日期分组为分组起始日期（2006-01-02）
+	Keys []interface{}
+	// Count 分组内的记录数
+	Count int64
+	// Statistics 列统计结果，以 ColumnStatistic.Key() 为键
+	Statistics map[string]interface{}
+}
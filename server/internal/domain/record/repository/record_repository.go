@@ -5,6 +5,7 @@ import (
 
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	viewValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
 )
 
 // RecordRepository 记录仓储接口
@@ -63,8 +64,22 @@ type RecordFilter struct {
 	UpdatedBy    *string
 	IsDeleted    *bool
 	FieldFilters map[string]interface{} // 字段过滤条件
+	// Filters 视图过滤条件（字段ID为键，多个条件之间 AND）
+	Filters []*viewValueobject.Filter
+	// AccessScopes 行级权限范围（外层 AND，内层 OR），为空表示不限制
+	AccessScopes [][]*viewValueobject.Filter
+	// ManualOrderViewID 优先按该视图的手动顺序排序（未设置顺序的记录排在后面）
+	ManualOrderViewID string
 	OrderBy      string                 // created_at, updated_at, field_name
 	OrderDir     string                 // asc, desc
+	// ThenOrderBy OrderBy 之后依次应用的排序（多列排序）
+	ThenOrderBy []RecordOrder
 	Limit        int
 	Offset       int
 }
+
+// RecordOrder 一个排序列
+type RecordOrder struct {
+	Column string // 数据库列名
+	Dir    string // asc, desc
+}
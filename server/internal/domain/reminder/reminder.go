@@ -0,0 +1,132 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// Status 提醒状态
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // 等待触发
+	StatusSending  Status = "sending"  // 已被调度器领取，正在发送
+	StatusSent     Status = "sent"     // 已发送
+	StatusCanceled Status = "canceled" // 日期被清空、不再满足条件或记录已删除
+	StatusMissed   Status = "missed"   // 规划时已过期或停机超过宽限期，不再补发
+)
+
+// Reminder 一条记录在一条规则下的提醒计划
+//
+// 每个（规则，记录）只保留一行：日期变化时重置为新的触发时间，
+// 日期不变时保留已发送状态，避免重复提醒
+type Reminder struct {
+	ID        string
+	RuleID    string
+	TableID   string
+	RecordID  string
+	FireAt    time.Time
+	Status    Status
+	SentAt    *time.Time
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewReminder 创建提醒计划
+func NewReminder(rule *Rule, recordID string, fireAt time.Time) *Reminder {
+	now := time.Now()
+	return &Reminder{
+		ID:        "rmd_" + utils.GenerateNanoID(utils.DefaultIDLength),
+		RuleID:    rule.ID,
+		TableID:   rule.TableID,
+		RecordID:  recordID,
+		FireAt:    fireAt,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsActive 是否仍在等待或发送中
+func (r *Reminder) IsActive() bool {
+	return r.Status == StatusPending || r.Status == StatusSending
+}
+
+// Cancel 取消提醒
+func (r *Reminder) Cancel() {
+	r.Status = StatusCanceled
+	r.UpdatedAt = time.Now()
+}
+
+// MarkSent 标记已发送
+func (r *Reminder) MarkSent(at time.Time) {
+	r.Status = StatusSent
+	r.SentAt = &at
+	r.LastError = ""
+	r.UpdatedAt = time.Now()
+}
+
+// MarkMissed 标记错过
+func (r *Reminder) MarkMissed(reason string) {
+	r.Status = StatusMissed
+	r.LastError = reason
+	r.UpdatedAt = time.Now()
+}
+
+// Plan 根据记录当前值规划提醒
+//
+// existing 为该记录已有的提醒计划（可为 nil），values 为 nil 表示记录已删除。
+// 返回需要保存的提醒；无需变更时返回 nil
+func (r *Rule) Plan(existing *Reminder, recordID string, values map[string]interface{}, now time.Time) *Reminder {
+	fireAt, ok := time.Time{}, false
+	if r.Enabled && values != nil {
+		fireAt, ok = r.FireTime(values)
+	}
+
+	if !ok {
+		if existing != nil && existing.IsActive() {
+			existing.Cancel()
+			return existing
+		}
+		return nil
+	}
+
+	if existing != nil && existing.FireAt.Equal(fireAt) && existing.Status != StatusCanceled {
+		return nil
+	}
+
+	planned := existing
+	if planned == nil {
+		planned = NewReminder(r, recordID, fireAt)
+	} else {
+		planned.FireAt = fireAt
+		planned.Status = StatusPending
+		planned.SentAt = nil
+		planned.LastError = ""
+		planned.UpdatedAt = now
+	}
+	// 规划时触发时间已过（如新建规则时的历史记录），不补发
+	if !fireAt.After(now) {
+		planned.MarkMissed("fire time already passed when planned")
+	}
+	return planned
+}
+
+// Repository 提醒计划仓储接口
+type Repository interface {
+	Save(ctx context.Context, reminder *Reminder) error
+	FindByRuleAndRecord(ctx context.Context, ruleID, recordID string) (*Reminder, error)
+	// FindByRecord 记录在所有规则下的提醒计划
+	FindByRecord(ctx context.Context, tableID, recordID string) ([]*Reminder, error)
+	// ListDue 触发时间不晚于 now 的待发送提醒
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*Reminder, error)
+	// Claim 将待发送提醒原子地标记为发送中，已被其他实例领取时返回 false
+	Claim(ctx context.Context, id string) (bool, error)
+	// ReleaseStale 将停留在发送中超过 before 的提醒恢复为待发送（实例崩溃后恢复）
+	ReleaseStale(ctx context.Context, before time.Time) (int64, error)
+	// DeleteByRule 删除规则的所有提醒计划
+	DeleteByRule(ctx context.Context, ruleID string) error
+}
@@ -0,0 +1,221 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// OffsetUnit 提醒偏移单位
+type OffsetUnit string
+
+const (
+	UnitMinute OffsetUnit = "minute"
+	UnitHour   OffsetUnit = "hour"
+	UnitDay    OffsetUnit = "day"
+)
+
+// Direction 提醒相对日期的方向
+type Direction string
+
+const (
+	DirectionBefore Direction = "before" // 日期之前
+	DirectionAfter  Direction = "after"  // 日期之后（如逾期提醒）
+)
+
+// 提醒规则错误
+var (
+	ErrRuleNotFound = errors.New("reminder rule not found")
+	ErrInvalidRule  = errors.New("invalid reminder rule")
+)
+
+// Rule 记录提醒规则
+//
+// 以表中的日期字段为基准，在日期之前/之后 Offset 个单位向接收人发送提醒：
+//   - 只有日期（无时间）的值按规则时区的当天零点计算，如“到期前 1 天”即前一天 00:00
+//   - Filter 非空时只提醒满足条件的记录，如 状态 isNot 已完成
+//   - 接收人为 RecipientFieldID 指向的用户字段中的用户，加上固定的 RecipientUserIDs
+type Rule struct {
+	ID               string
+	TableID          string
+	Name             string
+	DateFieldID      string
+	Offset           int
+	Unit             OffsetUnit
+	Direction        Direction
+	Timezone         string
+	Filter           *valueobject.Filter
+	RecipientFieldID string
+	RecipientUserIDs []string
+	Message          string // 自定义提醒内容，为空时使用默认文案
+	Enabled          bool
+	CreatedBy        string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NewRule 创建提醒规则
+func NewRule(tableID, name, dateFieldID string, createdBy string) *Rule {
+	now := time.Now()
+	return &Rule{
+		ID:          "rmr_" + utils.GenerateNanoID(utils.DefaultIDLength),
+		TableID:     tableID,
+		Name:        strings.TrimSpace(name),
+		DateFieldID: dateFieldID,
+		Unit:        UnitDay,
+		Direction:   DirectionBefore,
+		Timezone:    "UTC",
+		Enabled:     true,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate 验证规则
+func (r *Rule) Validate() error {
+	if r.TableID == "" {
+		return fmt.Errorf("%w: table ID is required", ErrInvalidRule)
+	}
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRule)
+	}
+	if r.DateFieldID == "" {
+		return fmt.Errorf("%w: date field is required", ErrInvalidRule)
+	}
+	if r.Offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidRule)
+	}
+	switch r.Unit {
+	case UnitMinute, UnitHour, UnitDay:
+	default:
+		return fmt.Errorf("%w: unsupported unit %q", ErrInvalidRule, r.Unit)
+	}
+	switch r.Direction {
+	case DirectionBefore, DirectionAfter:
+	default:
+		return fmt.Errorf("%w: unsupported direction %q", ErrInvalidRule, r.Direction)
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return fmt.Errorf("%w: invalid timezone %q", ErrInvalidRule, r.Timezone)
+	}
+	if !r.Filter.IsEmpty() {
+		if err := r.Filter.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+	if r.RecipientFieldID == "" && len(r.RecipientUserIDs) == 0 {
+		return fmt.Errorf("%w: a recipient field or at least one recipient is required", ErrInvalidRule)
+	}
+	return nil
+}
+
+// Location 规则时区
+func (r *Rule) Location() *time.Location {
+	if location, err := time.LoadLocation(r.Timezone); err == nil {
+		return location
+	}
+	return time.UTC
+}
+
+// offset 偏移时长（之前为负）
+func (r *Rule) offset() time.Duration {
+	var unit time.Duration
+	switch r.Unit {
+	case UnitMinute:
+		unit = time.Minute
+	case UnitHour:
+		unit = time.Hour
+	default:
+		unit = 24 * time.Hour
+	}
+	d := time.Duration(r.Offset) * unit
+	if r.Direction == DirectionBefore {
+		return -d
+	}
+	return d
+}
+
+// FireTime 根据记录值计算提醒时间
+// 日期为空、无法解析或不满足过滤条件时返回 false
+func (r *Rule) FireTime(values map[string]interface{}) (time.Time, bool) {
+	if !r.Filter.IsEmpty() && !r.Filter.Match(values) {
+		return time.Time{}, false
+	}
+	due, ok := ParseDate(values[r.DateFieldID], r.Location())
+	if !ok {
+		return time.Time{}, false
+	}
+	if r.Unit == UnitDay {
+		// 按日偏移使用日历日，跨夏令时也保持同一时刻
+		days := r.Offset
+		if r.Direction == DirectionBefore {
+			days = -days
+		}
+		return due.AddDate(0, 0, days), true
+	}
+	return due.Add(r.offset()), true
+}
+
+// Recipients 记录的提醒接收人（去重）
+func (r *Rule) Recipients(values map[string]interface{}) []string {
+	var candidates []string
+	if r.RecipientFieldID != "" {
+		candidates = append(candidates, valueobject.FilterValueTokens(values[r.RecipientFieldID])...)
+	}
+	candidates = append(candidates, r.RecipientUserIDs...)
+
+	seen := make(map[string]bool, len(candidates))
+	recipients := make([]string, 0, len(candidates))
+	for _, userID := range candidates {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients
+}
+
+// dateLayouts 支持的日期格式
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// ParseDate 解析日期单元格值
+// 只有日期的字符串按指定时区的当天零点解析；不带时区的日期时间按 UTC 解析
+func ParseDate(value interface{}, loc *time.Location) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		if t, err := time.ParseInLocation("2006-01-02", v, loc); err == nil {
+			return t, true
+		}
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// RuleRepository 提醒规则仓储接口
+type RuleRepository interface {
+	Save(ctx context.Context, rule *Rule) error
+	FindByID(ctx context.Context, id string) (*Rule, error)
+	FindByTableID(ctx context.Context, tableID string) ([]*Rule, error)
+	Delete(ctx context.Context, id string) error
+}
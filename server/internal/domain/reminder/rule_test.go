@@ -0,0 +1,143 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+func dueRule(offset int, unit OffsetUnit, direction Direction) *Rule {
+	rule := NewRule("tbl_1", "到期提醒", "fld_due", "usr_admin")
+	rule.Offset = offset
+	rule.Unit = unit
+	rule.Direction = direction
+	rule.RecipientFieldID = "fld_owner"
+	return rule
+}
+
+func TestRule_Validate(t *testing.T) {
+	rule := dueRule(1, UnitDay, DirectionBefore)
+	require.NoError(t, rule.Validate())
+
+	rule.RecipientFieldID = ""
+	assert.ErrorIs(t, rule.Validate(), ErrInvalidRule)
+	rule.RecipientUserIDs = []string{"usr_1"}
+	assert.NoError(t, rule.Validate())
+
+	rule.Unit = "week"
+	assert.ErrorIs(t, rule.Validate(), ErrInvalidRule)
+	rule.Unit = UnitHour
+
+	rule.Offset = -1
+	assert.ErrorIs(t, rule.Validate(), ErrInvalidRule)
+	rule.Offset = 2
+
+	rule.Timezone = "Mars/Olympus"
+	assert.ErrorIs(t, rule.Validate(), ErrInvalidRule)
+}
+
+func TestRule_FireTime(t *testing.T) {
+	values := map[string]interface{}{"fld_due": "2026-03-10T09:30:00Z"}
+
+	fireAt, ok := dueRule(30, UnitMinute, DirectionBefore).FireTime(values)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), fireAt.UTC())
+
+	fireAt, ok = dueRule(2, UnitHour, DirectionAfter).FireTime(values)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 10, 11, 30, 0, 0, time.UTC), fireAt.UTC())
+
+	_, ok = dueRule(1, UnitDay, DirectionBefore).FireTime(map[string]interface{}{"fld_due": nil})
+	assert.False(t, ok)
+	_, ok = dueRule(1, UnitDay, DirectionBefore).FireTime(map[string]interface{}{"fld_due": "明天"})
+	assert.False(t, ok)
+}
+
+func TestRule_FireTime_DateOnlyUsesRuleTimezone(t *testing.T) {
+	rule := dueRule(1, UnitDay, DirectionBefore)
+	rule.Timezone = "Asia/Shanghai"
+
+	fireAt, ok := rule.FireTime(map[string]interface{}{"fld_due": "2026-03-10"})
+	require.True(t, ok)
+	// 前一天上海时间零点
+	assert.Equal(t, time.Date(2026, 3, 8, 16, 0, 0, 0, time.UTC), fireAt.UTC())
+}
+
+func TestRule_FireTime_Filter(t *testing.T) {
+	rule := dueRule(0, UnitMinute, DirectionBefore)
+	rule.Filter = &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters: []valueobject.FilterItem{
+			{FieldID: "fld_status", Operator: valueobject.FilterItemOpIsNot, Value: "已完成"},
+		},
+	}
+
+	_, ok := rule.FireTime(map[string]interface{}{"fld_due": "2026-03-10T09:30:00Z", "fld_status": "进行中"})
+	assert.True(t, ok)
+	_, ok = rule.FireTime(map[string]interface{}{"fld_due": "2026-03-10T09:30:00Z", "fld_status": "已完成"})
+	assert.False(t, ok)
+}
+
+func TestRule_Recipients(t *testing.T) {
+	rule := dueRule(1, UnitDay, DirectionBefore)
+	rule.RecipientUserIDs = []string{"usr_lead", "usr_1"}
+
+	values := map[string]interface{}{
+		"fld_owner": []interface{}{
+			map[string]interface{}{"id": "usr_1", "name": "张三"},
+			map[string]interface{}{"id": "usr_2", "name": "李四"},
+		},
+	}
+	assert.Equal(t, []string{"usr_1", "usr_2", "usr_lead"}, rule.Recipients(values))
+	assert.Equal(t, []string{"usr_lead", "usr_1"}, rule.Recipients(map[string]interface{}{}))
+}
+
+func TestRule_Plan(t *testing.T) {
+	rule := dueRule(1, UnitHour, DirectionBefore)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	values := map[string]interface{}{"fld_due": "2026-03-10T09:00:00Z"}
+
+	planned := rule.Plan(nil, "rec_1", values, now)
+	require.NotNil(t, planned)
+	assert.Equal(t, StatusPending, planned.Status)
+	assert.Equal(t, time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC), planned.FireAt.UTC())
+
+	// 日期不变：已发送的提醒保持不动
+	planned.MarkSent(now)
+	assert.Nil(t, rule.Plan(planned, "rec_1", values, now))
+
+	// 日期变化：重置为新的触发时间
+	moved := rule.Plan(planned, "rec_1", map[string]interface{}{"fld_due": "2026-03-12T09:00:00Z"}, now)
+	require.NotNil(t, moved)
+	assert.Equal(t, StatusPending, moved.Status)
+	assert.Nil(t, moved.SentAt)
+	assert.Equal(t, time.Date(2026, 3, 12, 8, 0, 0, 0, time.UTC), moved.FireAt.UTC())
+
+	// 日期清空或记录删除：取消
+	canceled := rule.Plan(moved, "rec_1", map[string]interface{}{"fld_due": nil}, now)
+	require.NotNil(t, canceled)
+	assert.Equal(t, StatusCanceled, canceled.Status)
+	assert.Nil(t, rule.Plan(canceled, "rec_1", nil, now))
+
+	// 重新填写日期后恢复
+	restored := rule.Plan(canceled, "rec_1", map[string]interface{}{"fld_due": "2026-03-12T09:00:00Z"}, now)
+	require.NotNil(t, restored)
+	assert.Equal(t, StatusPending, restored.Status)
+
+	// 规则停用：取消
+	rule.Enabled = false
+	assert.Equal(t, StatusCanceled, rule.Plan(restored, "rec_1", values, now).Status)
+}
+
+func TestRule_Plan_PastFireTimeIsMissed(t *testing.T) {
+	rule := dueRule(1, UnitDay, DirectionBefore)
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	planned := rule.Plan(nil, "rec_1", map[string]interface{}{"fld_due": "2026-03-05T09:00:00Z"}, now)
+	require.NotNil(t, planned)
+	assert.Equal(t, StatusMissed, planned.Status)
+}
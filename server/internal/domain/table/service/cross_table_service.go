@@ -13,6 +13,12 @@ type FieldRepository interface {
 	GetByTableID(ctx context.Context, tableID string) ([]*fieldEntity.Field, error)
 }
 
+// LinkRepository Link 关联存储接口（外键列/中间表上的索引查询）
+type LinkRepository interface {
+	FindLinkFieldsPointingTo(ctx context.Context, tableID string) ([]*fieldEntity.Field, error)
+	FindReferencingRecordIDs(ctx context.Context, field *fieldEntity.Field, foreignIDs []string) ([]string, error)
+}
+
 // RecordUpdate 记录更新结构
 type RecordUpdate struct {
 	TableID  string
@@ -33,6 +39,7 @@ type BatchService interface {
 // 处理Link字段触发的级联计算和记录裂变
 type CrossTableCalculationService struct {
 	fieldRepo    FieldRepository
+	linkRepo     LinkRepository
 	batchService BatchService
 	evaluator    FormulaEvaluator
 }
@@ -40,11 +47,13 @@ type CrossTableCalculationService struct {
 // NewCrossTableCalculationService 创建跨表计算服务
 func NewCrossTableCalculationService(
 	fieldRepo FieldRepository,
+	linkRepo LinkRepository,
 	batchService BatchService,
 	evaluator FormulaEvaluator,
 ) *CrossTableCalculationService {
 	return &CrossTableCalculationService{
 		fieldRepo:    fieldRepo,
+		linkRepo:     linkRepo,
 		batchService: batchService,
 		evaluator:    evaluator,
 	}
@@ -78,12 +87,7 @@ func (s *CrossTableCalculationService) FindReferencingRecords(
 
 	// 2. 对每个Link字段，查找包含sourceRecordIDs的记录
 	for _, linkField := range linkFields {
-		targetRecordIDs, err := s.findRecordsContainingLinkValue(
-			ctx,
-			linkField.TableID(),
-			linkField.ID().String(),
-			sourceRecordIDs,
-		)
+		targetRecordIDs, err := s.findRecordsContainingLinkValue(ctx, linkField, sourceRecordIDs)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find records with link value: %w", err)
 		}
@@ -118,21 +122,17 @@ func (s *CrossTableCalculationService) findLinkFieldsPointingTo(
 	ctx context.Context,
 	targetTableID string,
 ) ([]*fieldEntity.Field, error) {
-	// 通过FieldRepository实现（简化实现：直接返回）
-	// 需要添加方法：fieldRepo.GetLinkFieldsPointingTo(ctx, targetTableID)
-	return nil, fmt.Errorf("not implemented: should use FieldRepository.GetLinkFieldsPointingTo")
+	return s.linkRepo.FindLinkFieldsPointingTo(ctx, targetTableID)
 }
 
 // findRecordsContainingLinkValue 查找哪些记录的Link字段包含指定值
+// 在外键列/中间表的 foreign 索引上反向查询，不扫描记录表
 func (s *CrossTableCalculationService) findRecordsContainingLinkValue(
 	ctx context.Context,
-	tableID string,
-	linkFieldID string,
+	linkField *fieldEntity.Field,
 	sourceRecordIDs []string,
 ) ([]string, error) {
-	// 通过RecordRepository实现（简化实现：直接返回）
-	// 需要添加方法：recordRepo.GetRecordsByLinkValue(ctx, tableID, linkFieldID, sourceRecordIDs)
-	return nil, fmt.Errorf("not implemented: should use RecordRepository.GetRecordsByLinkValue")
+	return s.linkRepo.FindReferencingRecordIDs(ctx, linkField, sourceRecordIDs)
 }
 
 // findFieldsDependingOnLink 查找依赖指定Link字段的计算字段
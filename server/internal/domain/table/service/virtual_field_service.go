@@ -7,6 +7,7 @@ import (
 	"time"
 
 	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
 	tableAggregate "github.com/easyspace-ai/luckdb/server/internal/domain/table/aggregate"
 	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
 )
@@ -815,8 +816,7 @@ func toFloat64(v interface{}) (float64, bool) {
 	}
 }
 
-func buildAIPrompt(config interface{}, record map[string]interface{}) string {
-	// Simplified implementation
-	// You can extend this to properly extract prompt from AI config
-	return "Generate value for this record"
+// buildAIPrompt renders the prompt template, replacing {Field Name} with record values
+func buildAIPrompt(config *fieldValueobject.AIOptions, record map[string]interface{}) string {
+	return fieldValueobject.RenderAIPrompt(config.Prompt, record)
 }
@@ -0,0 +1,194 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// 日历查询限制
+const (
+	MaxCalendarWindowSpan = 366 * 24 * time.Hour // 单次查询窗口最长一年
+	MaxCalendarEvents     = 2000                 // 单次返回的最大事件数
+
+	// calendarFloatingSlack 全天日期与时区无关，数据库按 UTC 比较时需要放宽的范围
+	calendarFloatingSlack = 14 * time.Hour
+)
+
+const calendarDateLayout = "2006-01-02"
+
+// CalendarWindow 日历查询窗口 [Start, End)
+type CalendarWindow struct {
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+}
+
+// NewCalendarWindow 按时区解析查询窗口
+// 日期按所在时区的零点解析，结束日期包含当天；时区为空时使用 UTC
+func NewCalendarWindow(start, end, timezone string) (CalendarWindow, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return CalendarWindow{}, fmt.Errorf("无效的时区: %s", timezone)
+		}
+	}
+
+	startTime, _, ok := parseCalendarTime(start, loc, false)
+	if !ok {
+		return CalendarWindow{}, fmt.Errorf("无效的开始时间: %s", start)
+	}
+	endTime, dateOnly, ok := parseCalendarTime(end, loc, false)
+	if !ok {
+		return CalendarWindow{}, fmt.Errorf("无效的结束时间: %s", end)
+	}
+	if dateOnly {
+		endTime = endTime.AddDate(0, 0, 1)
+	}
+	if !endTime.After(startTime) {
+		return CalendarWindow{}, fmt.Errorf("结束时间必须晚于开始时间")
+	}
+	if endTime.Sub(startTime) > MaxCalendarWindowSpan {
+		return CalendarWindow{}, fmt.Errorf("查询窗口不能超过%d天", int(MaxCalendarWindowSpan.Hours()/24))
+	}
+
+	return CalendarWindow{Start: startTime, End: endTime, Location: loc}, nil
+}
+
+// QueryBounds 数据库预过滤使用的时间范围
+// 全天事件以日期存储、与时区无关，因此两端各放宽 calendarFloatingSlack，精确判断在 BuildCalendarData 中完成
+func (w CalendarWindow) QueryBounds() (time.Time, time.Time) {
+	return w.Start.Add(-calendarFloatingSlack).UTC(), w.End.Add(calendarFloatingSlack).UTC()
+}
+
+// BuildCalendarData 根据配置将记录构建为日历事件
+// dateFields 为只存储日期（不含时间）的字段ID集合，其取值不做时区换算
+//
+// 处理规则：
+//   - 没有开始时间的记录不展示
+//   - 没有结束时间（或结束早于开始）的记录为单点事件
+//   - 只有日期（或配置为全天）的事件按所在时区的整天处理，结束日期包含当天
+//   - 带时间的事件转换到请求的时区后输出
+//   - 只保留与窗口重叠的事件，跨天事件完整返回，由前端按天切分
+func BuildCalendarData(config *CalendarViewConfig, window CalendarWindow, records []ViewRecord, dateFields map[string]bool) *CalendarViewData {
+	data := &CalendarViewData{
+		WindowStart: window.Start,
+		WindowEnd:   window.End,
+		Timezone:    window.Location.String(),
+		Events:      []CalendarEvent{},
+		Config:      *config,
+	}
+
+	startField, endField := config.StartField(), config.EndField()
+
+	type calendarEntry struct {
+		event CalendarEvent
+		start time.Time
+	}
+	entries := make([]calendarEntry, 0, len(records))
+
+	for _, record := range records {
+		start, startDateOnly, ok := parseCalendarTime(record.Fields[startField], window.Location, dateFields[startField])
+		if !ok {
+			continue
+		}
+		end, endDateOnly := start, startDateOnly
+		if endField != "" {
+			if t, dateOnly, ok := parseCalendarTime(record.Fields[endField], window.Location, dateFields[endField]); ok && !t.Before(start) {
+				end, endDateOnly = t, dateOnly
+			}
+		}
+
+		allDay := config.AllDay || (startDateOnly && endDateOnly)
+
+		// 事件占用的区间 [occupiedStart, occupiedEnd)
+		var occupiedStart, occupiedEnd time.Time
+		if allDay {
+			occupiedStart = calendarDay(start)
+			occupiedEnd = calendarDay(end).AddDate(0, 0, 1)
+		} else {
+			occupiedStart, occupiedEnd = start, end
+		}
+
+		if !calendarOverlaps(occupiedStart, occupiedEnd, window) {
+			continue
+		}
+
+		event := CalendarEvent{
+			ID:     record.ID,
+			Title:  timelineText(record.Fields[config.TitleFieldID]),
+			AllDay: allDay,
+			Data:   record.Fields,
+		}
+		if config.ColorFieldID != "" {
+			event.Color = timelineText(record.Fields[config.ColorFieldID])
+		}
+		if allDay {
+			event.StartTime = occupiedStart.Format(calendarDateLayout)
+			event.EndTime = occupiedEnd.AddDate(0, 0, -1).Format(calendarDateLayout)
+			event.MultiDay = event.StartTime != event.EndTime
+		} else {
+			event.StartTime = start.Format(time.RFC3339)
+			event.EndTime = end.Format(time.RFC3339)
+			lastInstant := end
+			if end.After(start) {
+				lastInstant = end.Add(-time.Nanosecond) // 结束于零点的事件不算跨到下一天
+			}
+			event.MultiDay = !calendarDay(start).Equal(calendarDay(lastInstant))
+		}
+
+		entries = append(entries, calendarEntry{event: event, start: occupiedStart})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].start.Equal(entries[j].start) {
+			return entries[i].start.Before(entries[j].start)
+		}
+		return entries[i].event.ID < entries[j].event.ID
+	})
+
+	if len(entries) > MaxCalendarEvents {
+		entries = entries[:MaxCalendarEvents]
+		data.Truncated = true
+	}
+	for _, entry := range entries {
+		data.Events = append(data.Events, entry.event)
+	}
+
+	return data
+}
+
+// calendarOverlaps 事件区间是否与窗口重叠（单点事件需落在窗口内）
+func calendarOverlaps(start, end time.Time, window CalendarWindow) bool {
+	if end.Equal(start) {
+		return !start.Before(window.Start) && start.Before(window.End)
+	}
+	return start.Before(window.End) && end.After(window.Start)
+}
+
+// calendarDay 所在时区当天的零点
+func calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// parseCalendarTime 解析日期单元格值并转换到指定时区
+// 日期字段（dateField）或只有日期的字符串视为该时区的当天（第二个返回值为 true），不做时区换算
+func parseCalendarTime(value interface{}, loc *time.Location, dateField bool) (time.Time, bool, bool) {
+	if s, ok := value.(string); ok && len(s) == len(calendarDateLayout) {
+		if t, err := time.ParseInLocation(calendarDateLayout, s, loc); err == nil {
+			return t, true, true
+		}
+	}
+	t, ok := parseTimelineTime(value)
+	if !ok {
+		return time.Time{}, false, false
+	}
+	if dateField {
+		// DATE 列读出为 UTC 零点，按日期部分放到目标时区
+		year, month, day := t.UTC().Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), true, true
+	}
+	return t.In(loc), false, true
+}
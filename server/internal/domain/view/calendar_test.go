@@ -0,0 +1,100 @@
+package view
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCalendarWindow(t *testing.T) {
+	window, err := NewCalendarWindow("2025-03-01", "2025-03-31", "Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("解析窗口失败: %v", err)
+	}
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	if !window.Start.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, shanghai)) {
+		t.Errorf("窗口开始应为上海时区零点: %v", window.Start)
+	}
+	if !window.End.Equal(time.Date(2025, 4, 1, 0, 0, 0, 0, shanghai)) {
+		t.Errorf("结束日期应包含当天: %v", window.End)
+	}
+
+	if _, err := NewCalendarWindow("2025-03-01", "2025-03-31", "Mars/Base"); err == nil {
+		t.Error("无效时区应返回错误")
+	}
+	if _, err := NewCalendarWindow("2025-03-31", "2025-03-01", ""); err == nil {
+		t.Error("结束早于开始时应返回错误")
+	}
+	if _, err := NewCalendarWindow("2020-01-01", "2025-01-01", ""); err == nil {
+		t.Error("超长窗口应返回错误")
+	}
+}
+
+func TestBuildCalendarData(t *testing.T) {
+	config := &CalendarViewConfig{DateFieldID: "start", EndTimeField: "end", TitleFieldID: "name"}
+	window, _ := NewCalendarWindow("2025-03-10", "2025-03-16", "Asia/Shanghai")
+
+	records := []ViewRecord{
+		// 全天跨天事件（DATE 列读出为 UTC 零点）
+		{ID: "rec_trip", Fields: map[string]interface{}{
+			"name":  "出差",
+			"start": time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+			"end":   time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+		}},
+		// UTC 3月9日晚上，上海时间为3月10日早上
+		{ID: "rec_call", Fields: map[string]interface{}{
+			"name":  "电话会",
+			"start": "2025-03-09T23:30:00Z",
+			"end":   "2025-03-10T00:30:00Z",
+		}},
+		// UTC 3月16日17点，上海时间已是3月17日，不在窗口内
+		{ID: "rec_late", Fields: map[string]interface{}{
+			"name":  "晚间发布",
+			"start": "2025-03-16T17:00:00Z",
+		}},
+		// 跨越上海午夜的事件
+		{ID: "rec_night", Fields: map[string]interface{}{
+			"name":  "通宵",
+			"start": "2025-03-12T14:00:00Z",
+			"end":   "2025-03-12T18:00:00Z",
+		}},
+		{ID: "rec_nodate", Fields: map[string]interface{}{"name": "无日期"}},
+	}
+
+	data := BuildCalendarData(config, window, records, map[string]bool{"start": true, "end": true})
+
+	// 日期字段只取日期部分，不做时区换算
+	if len(data.Events) != 4 {
+		t.Fatalf("期望4个事件，实际为%d: %+v", len(data.Events), data.Events)
+	}
+	if data.Timezone != "Asia/Shanghai" {
+		t.Errorf("时区错误: %s", data.Timezone)
+	}
+
+	trip := data.Events[0]
+	if trip.ID != "rec_trip" || !trip.AllDay || !trip.MultiDay || trip.StartTime != "2025-03-08" || trip.EndTime != "2025-03-11" {
+		t.Errorf("全天跨天事件错误: %+v", trip)
+	}
+	if late := data.Events[3]; late.ID != "rec_late" || late.StartTime != "2025-03-16" {
+		t.Errorf("日期字段不应换算时区: %+v", late)
+	}
+
+	// 非日期字段按时间处理
+	data = BuildCalendarData(config, window, records, nil)
+	var call, night *CalendarEvent
+	for i := range data.Events {
+		switch data.Events[i].ID {
+		case "rec_call":
+			call = &data.Events[i]
+		case "rec_night":
+			night = &data.Events[i]
+		case "rec_late":
+			t.Error("窗口外的事件不应返回")
+		}
+	}
+	if call == nil || call.AllDay || call.StartTime != "2025-03-10T07:30:00+08:00" || call.MultiDay {
+		t.Errorf("带时间的事件应转换到请求时区: %+v", call)
+	}
+	if night == nil || !night.MultiDay {
+		t.Errorf("跨越午夜的事件应标记为跨天: %+v", night)
+	}
+}
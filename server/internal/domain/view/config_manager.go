@@ -179,12 +179,15 @@ func (cm *ConfigManager) GetDefaultConfig(viewType ViewType) (ViewConfig, error)
 			BaseViewConfig: BaseViewConfig{
 				Type: ViewTypeTimeline,
 			},
-			StartDateField: "",
-			EndDateField:   "",
-			TitleField:     "",
-			ColorField:     "",
-			ShowMilestone:  true,
-			ShowDuration:   true,
+			StartDateField:  "",
+			EndDateField:    "",
+			TitleField:      "",
+			ColorField:      "",
+			GroupField:      "",
+			DependencyField: "",
+			Scale:           "week",
+			ShowMilestone:   true,
+			ShowDuration:    true,
 		}, nil
 
 	default:
@@ -298,47 +301,6 @@ func (c *ChartViewConfig) FromMap(data map[string]interface{}) error {
 	return json.Unmarshal(jsonData, c)
 }
 
-// TimelineViewConfig 时间线视图配置
-type TimelineViewConfig struct {
-	BaseViewConfig
-	StartDateField string `json:"start_date_field"` // 开始日期字段
-	EndDateField   string `json:"end_date_field"`   // 结束日期字段
-	TitleField     string `json:"title_field"`      // 标题字段
-	ColorField     string `json:"color_field"`      // 颜色字段
-	ShowMilestone  bool   `json:"show_milestone"`   // 显示里程碑
-	ShowDuration   bool   `json:"show_duration"`    // 显示持续时间
-}
-
-// GetType 获取视图类型
-func (c *TimelineViewConfig) GetType() ViewType {
-	return ViewTypeTimeline
-}
-
-// Validate 验证配置
-func (c *TimelineViewConfig) Validate() error {
-	if c.StartDateField == "" {
-		return fmt.Errorf("时间线视图必须指定开始日期字段")
-	}
-	return nil
-}
-
-// ToMap 转换为map
-func (c *TimelineViewConfig) ToMap() map[string]interface{} {
-	data, _ := json.Marshal(c)
-	var result map[string]interface{}
-	json.Unmarshal(data, &result)
-	return result
-}
-
-// FromMap 从map构建配置
-func (c *TimelineViewConfig) FromMap(data map[string]interface{}) error {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(jsonData, c)
-}
-
 // ViewConfigValidator 视图配置验证器
 type ViewConfigValidator struct {
 	configManager *ConfigManager
@@ -316,6 +316,8 @@ func (v *ViewDataValidator) ValidateViewData(view *View, data ViewDataResponse)
 		return v.validateKanbanData(view, data)
 	case ViewTypeCalendar:
 		return v.validateCalendarData(view, data)
+	case ViewTypeTimeline:
+		return v.validateTimelineData(view, data)
 	default:
 		return nil
 	}
@@ -373,3 +375,45 @@ func (v *ViewDataValidator) validateCalendarData(view *View, data ViewDataRespon
 
 	return nil
 }
+
+// validateTimelineData 验证时间线视图数据
+func (v *ViewDataValidator) validateTimelineData(view *View, data ViewDataResponse) error {
+	timelineData, ok := data.GetData().(*TimelineViewData)
+	if !ok {
+		return fmt.Errorf("时间线视图数据类型错误")
+	}
+
+	if timelineData.WindowEnd.Before(timelineData.WindowStart) {
+		return fmt.Errorf("时间线窗口结束时间不能早于开始时间")
+	}
+	if timelineData.WindowEnd.Sub(timelineData.WindowStart) > MaxTimelineWindowSpan {
+		return fmt.Errorf("时间线窗口跨度超过上限")
+	}
+	if len(timelineData.Items) > MaxTimelineItems {
+		return fmt.Errorf("时间线条目数量超过上限: %d", MaxTimelineItems)
+	}
+
+	// 验证条目：必须有ID，且已裁剪到窗口内
+	itemIDs := make(map[string]bool, len(timelineData.Items))
+	for _, item := range timelineData.Items {
+		if item.ID == "" {
+			return fmt.Errorf("时间线条目必须有ID")
+		}
+		if item.End.Before(item.Start) {
+			return fmt.Errorf("时间线条目 %s 的结束时间早于开始时间", item.ID)
+		}
+		if item.Start.Before(timelineData.WindowStart) || item.End.After(timelineData.WindowEnd) {
+			return fmt.Errorf("时间线条目 %s 超出查询窗口", item.ID)
+		}
+		itemIDs[item.ID] = true
+	}
+
+	// 验证依赖：两端都必须是返回的条目
+	for _, dep := range timelineData.Dependencies {
+		if !itemIDs[dep.FromID] || !itemIDs[dep.ToID] {
+			return fmt.Errorf("时间线依赖引用了不存在的条目: %s -> %s", dep.FromID, dep.ToID)
+		}
+	}
+
+	return nil
+}
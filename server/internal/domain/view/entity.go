@@ -549,6 +549,16 @@ func (c *KanbanViewConfig) Validate() error {
 	return nil
 }
 
+// ValidateFieldTypes 根据表字段类型验证配置引用的字段
+// 分组字段只支持单选和用户字段（移动卡片时需要写回单个值）
+func (c *KanbanViewConfig) ValidateFieldTypes(fieldTypes map[string]string) error {
+	checks := []configFieldCheck{{c.GroupFieldID, "分组字段", kanbanGroupFieldTypes}}
+	for _, fieldID := range c.CardFields {
+		checks = append(checks, configFieldCheck{fieldID, "卡片字段", nil})
+	}
+	return checkConfigFieldTypes(fieldTypes, checks)
+}
+
 // ToMap 转换为map
 func (c *KanbanViewConfig) ToMap() map[string]interface{} {
 	data, _ := json.Marshal(c)
@@ -605,6 +615,33 @@ func (c *CalendarViewConfig) Validate() error {
 	return nil
 }
 
+// StartField 事件开始时间字段（未单独配置时使用日期字段）
+func (c *CalendarViewConfig) StartField() string {
+	if c.StartTimeField != "" {
+		return c.StartTimeField
+	}
+	return c.DateFieldID
+}
+
+// EndField 事件结束时间字段（为空表示单日事件）
+func (c *CalendarViewConfig) EndField() string {
+	if c.EndTimeField == c.StartField() {
+		return ""
+	}
+	return c.EndTimeField
+}
+
+// ValidateFieldTypes 根据表字段类型验证配置引用的字段
+func (c *CalendarViewConfig) ValidateFieldTypes(fieldTypes map[string]string) error {
+	return checkConfigFieldTypes(fieldTypes, []configFieldCheck{
+		{c.DateFieldID, "日期字段", viewDateFieldTypes},
+		{c.StartTimeField, "开始时间字段", viewDateFieldTypes},
+		{c.EndTimeField, "结束时间字段", viewDateFieldTypes},
+		{c.TitleFieldID, "标题字段", nil},
+		{c.ColorFieldID, "颜色字段", nil},
+	})
+}
+
 // ToMap 转换为map
 func (c *CalendarViewConfig) ToMap() map[string]interface{} {
 	data, _ := json.Marshal(c)
@@ -622,6 +659,124 @@ func (c *CalendarViewConfig) FromMap(data map[string]interface{}) error {
 	return json.Unmarshal(jsonData, c)
 }
 
+// TimelineViewConfig 时间线视图配置（甘特图）
+type TimelineViewConfig struct {
+	BaseViewConfig
+	StartDateField  string `json:"start_date_field"` // 开始日期字段
+	EndDateField    string `json:"end_date_field"`   // 结束日期字段（为空时按里程碑展示）
+	TitleField      string `json:"title_field"`      // 标题字段（为空时使用主字段）
+	ColorField      string `json:"color_field"`      // 颜色字段
+	GroupField      string `json:"group_field"`      // 分组字段（单选、多选或用户字段）
+	DependencyField string `json:"dependency_field"` // 依赖字段（链接到同表记录，表示前置任务）
+	Scale           string `json:"scale"`            // 时间刻度：day, week, month, quarter, year
+	ShowMilestone   bool   `json:"show_milestone"`   // 显示里程碑
+	ShowDuration    bool   `json:"show_duration"`    // 显示持续时间
+}
+
+// GetType 获取视图类型
+func (c *TimelineViewConfig) GetType() ViewType {
+	return ViewTypeTimeline
+}
+
+// Validate 验证配置
+func (c *TimelineViewConfig) Validate() error {
+	if c.StartDateField == "" {
+		return fmt.Errorf("时间线视图必须指定开始日期字段")
+	}
+	if c.EndDateField != "" && c.EndDateField == c.StartDateField {
+		return fmt.Errorf("时间线视图的开始日期字段和结束日期字段不能相同")
+	}
+	if c.DependencyField != "" && c.DependencyField == c.GroupField {
+		return fmt.Errorf("时间线视图的依赖字段不能同时作为分组字段")
+	}
+	if c.Scale == "" {
+		c.Scale = "week" // 默认按周展示
+	}
+	validScales := []string{"day", "week", "month", "quarter", "year"}
+	isValid := false
+	for _, scale := range validScales {
+		if c.Scale == scale {
+			isValid = true
+			break
+		}
+	}
+	if !isValid {
+		return fmt.Errorf("无效的时间刻度: %s", c.Scale)
+	}
+	return nil
+}
+
+// ValidateFieldTypes 根据表字段类型验证配置引用的字段
+// fieldTypes 为字段ID到字段类型的映射
+func (c *TimelineViewConfig) ValidateFieldTypes(fieldTypes map[string]string) error {
+	return checkConfigFieldTypes(fieldTypes, []configFieldCheck{
+		{c.StartDateField, "开始日期字段", viewDateFieldTypes},
+		{c.EndDateField, "结束日期字段", viewDateFieldTypes},
+		{c.GroupField, "分组字段", timelineGroupFieldTypes},
+		{c.DependencyField, "依赖字段", []string{"link"}},
+		{c.TitleField, "标题字段", nil},
+		{c.ColorField, "颜色字段", nil},
+	})
+}
+
+// 视图配置可引用的字段类型
+var (
+	viewDateFieldTypes      = []string{"date", "datetime", "createdTime", "lastModifiedTime"}
+	timelineGroupFieldTypes = []string{"select", "singleSelect", "multipleSelect", "user", "createdBy", "lastModifiedBy"}
+	kanbanGroupFieldTypes   = []string{"select", "singleSelect", "user"}
+)
+
+// configFieldCheck 视图配置引用字段的类型检查
+type configFieldCheck struct {
+	fieldID string
+	label   string
+	allowed []string // 为空表示任意类型
+}
+
+// checkConfigFieldTypes 检查配置引用的字段存在且类型受支持（未配置的字段跳过）
+func checkConfigFieldTypes(fieldTypes map[string]string, checks []configFieldCheck) error {
+	for _, check := range checks {
+		if check.fieldID == "" {
+			continue
+		}
+		fieldType, exists := fieldTypes[check.fieldID]
+		if !exists {
+			return fmt.Errorf("%s不存在: %s", check.label, check.fieldID)
+		}
+		if check.allowed == nil {
+			continue
+		}
+		isValid := false
+		for _, allowed := range check.allowed {
+			if fieldType == allowed {
+				isValid = true
+				break
+			}
+		}
+		if !isValid {
+			return fmt.Errorf("%s类型不支持: %s", check.label, fieldType)
+		}
+	}
+	return nil
+}
+
+// ToMap 转换为map
+func (c *TimelineViewConfig) ToMap() map[string]interface{} {
+	data, _ := json.Marshal(c)
+	var result map[string]interface{}
+	json.Unmarshal(data, &result)
+	return result
+}
+
+// FromMap 从map构建配置
+func (c *TimelineViewConfig) FromMap(data map[string]interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, c)
+}
+
 // GalleryViewConfig 画廊视图配置
 type GalleryViewConfig struct {
 	BaseViewConfig
@@ -806,6 +961,33 @@ type GridViewDataRequest struct {
 	Groups   []GridViewGroup  `json:"groups"`
 }
 
+// GridGroupedData 分组网格视图数据（分组树）
+type GridGroupedData struct {
+	Total      int64                  `json:"total"`      // 总记录数
+	Statistics map[string]interface{} `json:"statistics"` // 全表列统计
+	Groups     []*GridGroup           `json:"groups"`     // 第一层分组
+	Truncated  bool                   `json:"truncated"`  // 分组数超过上限被截断
+}
+
+// GridGroup 分组树节点
+type GridGroup struct {
+	ID         string                 `json:"id"`         // 分组路径编码，用于查询分组内记录
+	FieldID    string                 `json:"field_id"`   // 分组字段ID
+	Depth      int                    `json:"depth"`      // 层级（从0开始）
+	Value      interface{}            `json:"value"`      // 分组值，空分组为 nil
+	Name       string                 `json:"name"`       // 分组显示名称
+	Count      int64                  `json:"count"`      // 分组内记录数
+	Statistics map[string]interface{} `json:"statistics"` // 分组内列统计
+	Children   []*GridGroup           `json:"children"`   // 下一层分组
+}
+
+// GridGroupSummary 单个分组的统计结果（由 SQL 聚合得到）
+type GridGroupSummary struct {
+	Keys       []interface{}          // 从第一层到该层的分组值
+	Count      int64                  // 记录数
+	Statistics map[string]interface{} // 列统计
+}
+
 // FormViewData 表单视图数据
 type FormViewData struct {
 	Fields []FormViewField `json:"fields"` // 表单字段配置
@@ -817,6 +999,12 @@ type FormViewDataRequest struct {
 	ViewID string `json:"view_id"`
 }
 
+// ViewRecord 构建视图数据所需的记录
+type ViewRecord struct {
+	ID     string
+	Fields map[string]interface{} // 以字段ID为键
+}
+
 // KanbanViewData 看板视图数据
 type KanbanViewData struct {
 	Groups []KanbanGroup    `json:"groups"` // 看板分组
@@ -827,15 +1015,20 @@ type KanbanViewData struct {
 type KanbanGroup struct {
 	ID        string                   `json:"id"`        // 分组ID
 	Name      string                   `json:"name"`      // 分组名称
+	Color     string                   `json:"color"`     // 分组颜色（单选选项颜色）
 	Value     interface{}              `json:"value"`     // 分组值
-	Cards     []map[string]interface{} `json:"cards"`     // 卡片数据
-	Count     int                      `json:"count"`     // 卡片数量
+	Cards     []map[string]interface{} `json:"cards"`     // 卡片数据（当前页）
+	Count     int                      `json:"count"`     // 卡片数量（整列总数）
+	HasMore   bool                     `json:"has_more"`  // 是否还有下一页
 	Collapsed bool                     `json:"collapsed"` // 是否折叠
 }
 
 // KanbanViewDataRequest 看板视图数据请求
 type KanbanViewDataRequest struct {
-	ViewID string `json:"view_id"`
+	ViewID  string `json:"view_id"`
+	StackID string `json:"stack_id"` // 只加载指定列（为空加载全部列）
+	Offset  int    `json:"offset"`   // 每列的偏移量
+	Limit   int    `json:"limit"`    // 每列的卡片数
 }
 
 // MoveKanbanCardRequest 移动看板卡片请求
@@ -844,21 +1037,28 @@ type MoveKanbanCardRequest struct {
 	RecordID  string `json:"record_id"`
 	FromGroup string `json:"from_group"`
 	ToGroup   string `json:"to_group"`
+	AnchorID  string `json:"anchor_id"` // 参照卡片（为空时不调整顺序）
+	Position  string `json:"position"`  // 相对参照卡片的位置：before, after
 }
 
 // CalendarViewData 日历视图数据
 type CalendarViewData struct {
-	Events []CalendarEvent    `json:"events"` // 日历事件
-	Config CalendarViewConfig `json:"config"` // 日历配置
+	WindowStart time.Time          `json:"window_start"` // 查询窗口开始（含）
+	WindowEnd   time.Time          `json:"window_end"`   // 查询窗口结束（不含）
+	Timezone    string             `json:"timezone"`     // 事件时间所在时区
+	Events      []CalendarEvent    `json:"events"`       // 日历事件
+	Truncated   bool               `json:"truncated"`    // 事件数超过上限被截断
+	Config      CalendarViewConfig `json:"config"`       // 日历配置
 }
 
 // CalendarEvent 日历事件
 type CalendarEvent struct {
 	ID        string                 `json:"id"`         // 事件ID（记录ID）
 	Title     string                 `json:"title"`      // 事件标题
-	StartTime string                 `json:"start_time"` // 开始时间
-	EndTime   string                 `json:"end_time"`   // 结束时间
+	StartTime string                 `json:"start_time"` // 开始时间（全天事件为日期）
+	EndTime   string                 `json:"end_time"`   // 结束时间（全天事件为包含的最后一天）
 	AllDay    bool                   `json:"all_day"`    // 是否全天事件
+	MultiDay  bool                   `json:"multi_day"`  // 是否跨天
 	Color     string                 `json:"color"`      // 事件颜色
 	Data      map[string]interface{} `json:"data"`       // 其他数据
 }
@@ -868,6 +1068,54 @@ type CalendarViewDataRequest struct {
 	ViewID    string `json:"view_id"`
 	StartDate string `json:"start_date"`
 	EndDate   string `json:"end_date"`
+	Timezone  string `json:"timezone"` // IANA 时区，为空时使用 UTC
+}
+
+// TimelineViewData 时间线视图数据
+type TimelineViewData struct {
+	WindowStart  time.Time            `json:"window_start"` // 实际查询窗口开始（已裁剪）
+	WindowEnd    time.Time            `json:"window_end"`   // 实际查询窗口结束（已裁剪）
+	Items        []TimelineItem       `json:"items"`        // 与窗口重叠的条目
+	Groups       []TimelineGroup      `json:"groups"`       // 分组（未配置分组字段时为空）
+	Dependencies []TimelineDependency `json:"dependencies"` // 依赖箭头（两端都在窗口内）
+	Truncated    bool                 `json:"truncated"`    // 条目数超过上限被截断
+	Config       TimelineViewConfig   `json:"config"`       // 时间线配置
+}
+
+// TimelineItem 时间线条目
+type TimelineItem struct {
+	ID           string                 `json:"id"`            // 条目ID（记录ID）
+	Title        string                 `json:"title"`         // 标题
+	Start        time.Time              `json:"start"`         // 开始时间（已按窗口裁剪）
+	End          time.Time              `json:"end"`           // 结束时间（已按窗口裁剪）
+	RawStart     time.Time              `json:"raw_start"`     // 原始开始时间
+	RawEnd       time.Time              `json:"raw_end"`       // 原始结束时间
+	StartClamped bool                   `json:"start_clamped"` // 开始时间早于窗口
+	EndClamped   bool                   `json:"end_clamped"`   // 结束时间晚于窗口
+	Milestone    bool                   `json:"milestone"`     // 里程碑（无结束时间或开始等于结束）
+	GroupID      string                 `json:"group_id"`      // 所属分组
+	Color        string                 `json:"color"`         // 颜色
+	Data         map[string]interface{} `json:"data"`          // 其他数据
+}
+
+// TimelineGroup 时间线分组
+type TimelineGroup struct {
+	ID    string `json:"id"`    // 分组ID（分组字段取值）
+	Name  string `json:"name"`  // 分组名称
+	Count int    `json:"count"` // 条目数量
+}
+
+// TimelineDependency 时间线依赖（从前置条目指向后续条目）
+type TimelineDependency struct {
+	FromID string `json:"from_id"` // 前置条目ID
+	ToID   string `json:"to_id"`   // 后续条目ID
+}
+
+// TimelineViewDataRequest 时间线视图数据请求
+type TimelineViewDataRequest struct {
+	ViewID    string `json:"view_id"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
 }
 
 // GalleryViewData 画廊视图数据
@@ -944,3 +1192,13 @@ func (r *GalleryViewDataRequest) GetViewID() string {
 func (r *GalleryViewDataRequest) GetType() ViewType {
 	return ViewTypeGallery
 }
+
+// GetViewID 实现ViewDataRequest接口
+func (r *TimelineViewDataRequest) GetViewID() string {
+	return r.ViewID
+}
+
+// GetType 实现ViewDataRequest接口
+func (r *TimelineViewDataRequest) GetType() ViewType {
+	return ViewTypeTimeline
+}
@@ -0,0 +1,230 @@
+package view
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// 分组网格查询限制
+const (
+	MaxGridGroups            = 2000 // 每一层最多返回的分组数
+	DefaultGridGroupPageSize = 100  // 分组内每页默认记录数
+	MaxGridGroupPageSize     = 1000 // 分组内每页最多记录数
+)
+
+// gridUngroupableFieldTypes 不支持分组的字段类型
+var gridUngroupableFieldTypes = map[string]bool{
+	"attachment": true,
+}
+
+// ValidateGridGroup 验证分组和列统计引用的字段
+func ValidateGridGroup(group *valueobject.Group, statistics []valueobject.ColumnStatistic, fieldTypes map[string]string) error {
+	if group != nil {
+		for _, item := range group.GroupItems {
+			if err := checkConfigFieldTypes(fieldTypes, []configFieldCheck{{fieldID: item.FieldID, label: "分组字段"}}); err != nil {
+				return err
+			}
+			if gridUngroupableFieldTypes[fieldTypes[item.FieldID]] {
+				return fmt.Errorf("分组字段类型不支持: %s", fieldTypes[item.FieldID])
+			}
+		}
+	}
+
+	return ValidateColumnStatistics(statistics, fieldTypes)
+}
+
+// ValidateColumnStatistics 验证列统计引用的字段存在且支持该统计函数
+func ValidateColumnStatistics(statistics []valueobject.ColumnStatistic, fieldTypes map[string]string) error {
+	for _, statistic := range statistics {
+		if err := checkConfigFieldTypes(fieldTypes, []configFieldCheck{{fieldID: statistic.FieldID, label: "统计字段"}}); err != nil {
+			return err
+		}
+		if !statistic.Func.SupportsFieldType(fieldTypes[statistic.FieldID]) {
+			return fmt.Errorf("统计函数 %s 不支持字段类型: %s", statistic.Func, fieldTypes[statistic.FieldID])
+		}
+	}
+	return nil
+}
+
+// ApplicableColumnStatistics 过滤出字段仍存在且类型支持的列统计
+// 列配置保存后字段可能被删除或修改类型，读取统计时跳过失效的配置而不是报错
+func ApplicableColumnStatistics(statistics []valueobject.ColumnStatistic, fieldTypes map[string]string) []valueobject.ColumnStatistic {
+	applicable := make([]valueobject.ColumnStatistic, 0, len(statistics))
+	for _, statistic := range statistics {
+		if fieldType, ok := fieldTypes[statistic.FieldID]; ok && statistic.Func.SupportsFieldType(fieldType) {
+			applicable = append(applicable, statistic)
+		}
+	}
+	return applicable
+}
+
+// NormalizeGridGroupPage 规范化分组内的分页参数
+func NormalizeGridGroupPage(offset, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultGridGroupPageSize
+	}
+	if limit > MaxGridGroupPageSize {
+		limit = MaxGridGroupPageSize
+	}
+	return offset, limit
+}
+
+// BuildGridGroupTree 将各层分组统计组装为分组树
+// summaries 中 Keys 为空的一行为总计；其余按层级、层内按分组顺序排列
+// 父分组因截断缺失时，其子分组被丢弃
+func BuildGridGroupTree(items []valueobject.GroupItem, summaries []GridGroupSummary, truncated bool) *GridGroupedData {
+	data := &GridGroupedData{
+		Statistics: map[string]interface{}{},
+		Groups:     []*GridGroup{},
+		Truncated:  truncated,
+	}
+
+	nodes := make(map[string]*GridGroup, len(summaries))
+	for _, summary := range summaries {
+		depth := len(summary.Keys) - 1
+		if depth < 0 {
+			data.Total = summary.Count
+			if summary.Statistics != nil {
+				data.Statistics = summary.Statistics
+			}
+			continue
+		}
+		if depth >= len(items) {
+			continue
+		}
+
+		value := summary.Keys[depth]
+		node := &GridGroup{
+			ID:         EncodeGridGroupID(summary.Keys),
+			FieldID:    items[depth].FieldID,
+			Depth:      depth,
+			Value:      value,
+			Name:       gridGroupName(value),
+			Count:      summary.Count,
+			Statistics: summary.Statistics,
+			Children:   []*GridGroup{},
+		}
+		if node.Statistics == nil {
+			node.Statistics = map[string]interface{}{}
+		}
+
+		if depth == 0 {
+			data.Groups = append(data.Groups, node)
+		} else {
+			parent, ok := nodes[EncodeGridGroupID(summary.Keys[:depth])]
+			if !ok {
+				continue
+			}
+			parent.Children = append(parent.Children, node)
+		}
+		nodes[node.ID] = node
+	}
+
+	return data
+}
+
+// EncodeGridGroupID 分组路径编码为分组ID
+func EncodeGridGroupID(keys []interface{}) string {
+	data, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeGridGroupID 解析分组ID，levels 为当前视图的分组层数
+func DecodeGridGroupID(id string, levels int) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return nil, fmt.Errorf("无效的分组ID: %s", id)
+	}
+	var keys []interface{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("无效的分组ID: %s", id)
+	}
+	if len(keys) == 0 || len(keys) > levels {
+		return nil, fmt.Errorf("分组ID与视图分组配置不匹配: %s", id)
+	}
+	return keys, nil
+}
+
+// GridGroupFilter 生成只包含某个分组记录的过滤条件
+//   - 空分组使用 isEmpty
+//   - 日期分组使用 [分组起始日期, 下一个分组起始日期)
+//   - 多值分组（多选、用户、链接）使用 isExactly
+func GridGroupFilter(items []valueobject.GroupItem, fieldTypes map[string]string, keys []interface{}) (*valueobject.Filter, error) {
+	filter := &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters:  make([]valueobject.FilterItem, 0, len(keys)),
+	}
+
+	for i, key := range keys {
+		if i >= len(items) {
+			return nil, fmt.Errorf("分组层级超出视图分组配置")
+		}
+		item := items[i]
+
+		switch value := key.(type) {
+		case nil:
+			filter.Filters = append(filter.Filters, valueobject.FilterItem{FieldID: item.FieldID, Operator: valueobject.FilterItemOpIsEmpty})
+		case []interface{}:
+			filter.Filters = append(filter.Filters, valueobject.FilterItem{FieldID: item.FieldID, Operator: valueobject.FilterItemOpIsExactly, Value: value})
+		case string:
+			start, err := time.Parse("2006-01-02", value)
+			if !isViewDateFieldType(fieldTypes[item.FieldID]) || err != nil {
+				// 非日期列（包括以文本存储的日期）按原值比较
+				filter.Filters = append(filter.Filters, valueobject.FilterItem{FieldID: item.FieldID, Operator: valueobject.FilterItemOpIs, Value: value})
+				continue
+			}
+			filter.Filters = append(filter.Filters,
+				valueobject.FilterItem{FieldID: item.FieldID, Operator: valueobject.FilterItemOpGreaterEqual, Value: value},
+				valueobject.FilterItem{FieldID: item.FieldID, Operator: valueobject.FilterItemOpLess, Value: nextGridDateBucket(start, item.DateBucket).Format("2006-01-02")},
+			)
+		default:
+			filter.Filters = append(filter.Filters, valueobject.FilterItem{FieldID: item.FieldID, Operator: valueobject.FilterItemOpIs, Value: value})
+		}
+	}
+
+	return filter, nil
+}
+
+// nextGridDateBucket 下一个日期分组的起始日期
+func nextGridDateBucket(start time.Time, bucket valueobject.GroupDateBucket) time.Time {
+	switch bucket {
+	case valueobject.GroupDateBucketWeek:
+		return start.AddDate(0, 0, 7)
+	case valueobject.GroupDateBucketMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// isViewDateFieldType 是否为日期字段类型
+func isViewDateFieldType(fieldType string) bool {
+	for _, dateType := range viewDateFieldTypes {
+		if fieldType == dateType {
+			return true
+		}
+	}
+	return false
+}
+
+// gridGroupName 分组值转为显示名称，多值分组以逗号连接
+func gridGroupName(value interface{}) string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return timelineText(value)
+	}
+	names := make([]string, 0, len(list))
+	for _, elem := range list {
+		if name := timelineText(elem); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,120 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+func TestBuildGridGroupTree(t *testing.T) {
+	items := []valueobject.GroupItem{
+		{FieldID: "status", Order: valueobject.SortOrderAsc},
+		{FieldID: "owner", Order: valueobject.SortOrderAsc},
+	}
+	summaries := []GridGroupSummary{
+		{Keys: []interface{}{}, Count: 5, Statistics: map[string]interface{}{"amount:sum": 50.0}},
+		{Keys: []interface{}{"待办"}, Count: 3},
+		{Keys: []interface{}{nil}, Count: 2},
+		{Keys: []interface{}{"待办", []interface{}{"usr_1"}}, Count: 2},
+		{Keys: []interface{}{"待办", nil}, Count: 1},
+		{Keys: []interface{}{nil, []interface{}{"usr_1", "usr_2"}}, Count: 2},
+		// 父分组被截断，子分组丢弃
+		{Keys: []interface{}{"完成", nil}, Count: 4},
+	}
+
+	data := BuildGridGroupTree(items, summaries, true)
+
+	if data.Total != 5 || data.Statistics["amount:sum"] != 50.0 || !data.Truncated {
+		t.Errorf("总计错误: %+v", data)
+	}
+	if len(data.Groups) != 2 {
+		t.Fatalf("期望2个一级分组，实际为%d", len(data.Groups))
+	}
+
+	todo, empty := data.Groups[0], data.Groups[1]
+	if todo.Name != "待办" || todo.Count != 3 || len(todo.Children) != 2 {
+		t.Errorf("一级分组错误: %+v", todo)
+	}
+	if empty.Value != nil || len(empty.Children) != 1 || empty.Children[0].Name != "usr_1, usr_2" || empty.Children[0].Depth != 1 {
+		t.Errorf("空分组错误: %+v", empty)
+	}
+
+	keys, err := DecodeGridGroupID(todo.Children[0].ID, len(items))
+	if err != nil || len(keys) != 2 || keys[0] != "待办" {
+		t.Errorf("分组ID解析错误: %v, %v", keys, err)
+	}
+	if _, err := DecodeGridGroupID(todo.Children[0].ID, 1); err == nil {
+		t.Error("分组层数超出配置时应返回错误")
+	}
+	if _, err := DecodeGridGroupID("%%%", 3); err == nil {
+		t.Error("无效分组ID应返回错误")
+	}
+}
+
+func TestGridGroupFilter(t *testing.T) {
+	items := []valueobject.GroupItem{
+		{FieldID: "due", Order: valueobject.SortOrderAsc, DateBucket: valueobject.GroupDateBucketMonth},
+		{FieldID: "tags", Order: valueobject.SortOrderAsc},
+		{FieldID: "status", Order: valueobject.SortOrderAsc},
+	}
+	fieldTypes := map[string]string{"due": "date", "tags": "multipleSelect", "status": "singleSelect"}
+
+	filter, err := GridGroupFilter(items, fieldTypes, []interface{}{"2025-01-01", []interface{}{"a", "b"}, nil})
+	if err != nil {
+		t.Fatalf("生成过滤条件失败: %v", err)
+	}
+	if len(filter.Filters) != 4 {
+		t.Fatalf("期望4个过滤项，实际为%d: %+v", len(filter.Filters), filter.Filters)
+	}
+	if filter.Filters[1].Operator != valueobject.FilterItemOpLess || filter.Filters[1].Value != "2025-02-01" {
+		t.Errorf("按月分组的结束日期错误: %+v", filter.Filters[1])
+	}
+	if filter.Filters[2].Operator != valueobject.FilterItemOpIsExactly || filter.Filters[3].Operator != valueobject.FilterItemOpIsEmpty {
+		t.Errorf("多值分组和空分组的过滤项错误: %+v", filter.Filters)
+	}
+
+	record := map[string]interface{}{"tags": []interface{}{"b", "a"}, "status": ""}
+	if !filter.Filters[2].Match(record["tags"]) || !filter.Filters[3].Match(record["status"]) {
+		t.Error("分组过滤条件应匹配分组内的记录")
+	}
+}
+
+func TestValidateGridGroup(t *testing.T) {
+	fieldTypes := map[string]string{"amount": "number", "files": "attachment", "name": "singleLineText"}
+
+	group := &valueobject.Group{GroupItems: []valueobject.GroupItem{{FieldID: "name", Order: valueobject.SortOrderAsc}}}
+	statistics := []valueobject.ColumnStatistic{{FieldID: "amount", Func: valueobject.StatisticSum}, {FieldID: "name", Func: valueobject.StatisticUnique}}
+	if err := ValidateGridGroup(group, statistics, fieldTypes); err != nil {
+		t.Errorf("有效配置验证失败: %v", err)
+	}
+
+	group.GroupItems[0].FieldID = "files"
+	if err := ValidateGridGroup(group, nil, fieldTypes); err == nil {
+		t.Error("附件字段不能分组")
+	}
+	if err := ValidateGridGroup(nil, []valueobject.ColumnStatistic{{FieldID: "name", Func: valueobject.StatisticAvg}}, fieldTypes); err == nil {
+		t.Error("文本字段不能求平均值")
+	}
+}
+
+func TestApplicableColumnStatistics(t *testing.T) {
+	fieldTypes := map[string]string{"amount": "number", "done": "checkbox", "due": "date"}
+	statistics := []valueobject.ColumnStatistic{
+		{FieldID: "amount", Func: valueobject.StatisticSum},
+		{FieldID: "done", Func: valueobject.StatisticChecked},
+		{FieldID: "due", Func: valueobject.StatisticEarliest},
+		{FieldID: "amount", Func: valueobject.StatisticChecked}, // 类型已不支持
+		{FieldID: "deleted", Func: valueobject.StatisticFilled}, // 字段已删除
+	}
+
+	applicable := ApplicableColumnStatistics(statistics, fieldTypes)
+	if len(applicable) != 3 {
+		t.Fatalf("期望3个有效统计，实际 %d", len(applicable))
+	}
+	if err := ValidateColumnStatistics(statistics, fieldTypes); err == nil {
+		t.Error("失效的统计配置应验证失败")
+	}
+	if err := ValidateColumnStatistics(applicable, fieldTypes); err != nil {
+		t.Errorf("有效统计验证失败: %v", err)
+	}
+}
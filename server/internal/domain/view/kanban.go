@@ -0,0 +1,123 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// 看板查询限制
+const (
+	KanbanEmptyStackID     = "__empty" // 未分类列（分组字段为空）
+	DefaultKanbanStackSize = 20        // 每列默认加载的卡片数
+	MaxKanbanStackSize     = 200       // 每列单次最多加载的卡片数
+	MaxKanbanStackScan     = 10000     // 用户分组时，为发现列而扫描的最大记录数
+	MaxKanbanOrderBatch    = 10000     // 初始化一列手动顺序时最多处理的卡片数
+	minKanbanOrderGap      = 1e-6      // 相邻顺序值的最小间隔，低于此值需要重排整列
+)
+
+// 卡片相对参照卡片的位置
+const (
+	KanbanPositionBefore = "before"
+	KanbanPositionAfter  = "after"
+)
+
+// NormalizeKanbanPage 规范化每列的分页参数
+func NormalizeKanbanPage(offset, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultKanbanStackSize
+	}
+	if limit > MaxKanbanStackSize {
+		limit = MaxKanbanStackSize
+	}
+	return offset, limit
+}
+
+// NewKanbanEmptyStack 创建未分类列
+func NewKanbanEmptyStack() KanbanGroup {
+	return KanbanGroup{ID: KanbanEmptyStackID, Name: "未分类"}
+}
+
+// KanbanStacksFromValues 根据分组字段的取值发现看板列（用于用户分组）
+// 按首次出现的顺序去重，空值归入未分类列，不在此返回
+func KanbanStacksFromValues(values []interface{}) []KanbanGroup {
+	stacks := []KanbanGroup{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		id, name := timelineGroupKey(value)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		stacks = append(stacks, KanbanGroup{ID: id, Name: name, Value: id})
+	}
+	return stacks
+}
+
+// KanbanStackFilter 生成只包含某一列卡片的过滤条件
+func KanbanStackFilter(groupFieldID string, stack KanbanGroup) *valueobject.Filter {
+	item := valueobject.FilterItem{FieldID: groupFieldID, Operator: valueobject.FilterItemOpIsEmpty}
+	if stack.ID != KanbanEmptyStackID {
+		item = valueobject.FilterItem{FieldID: groupFieldID, Operator: valueobject.FilterItemOpIs, Value: stack.ID}
+	}
+	return &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters:  []valueobject.FilterItem{item},
+	}
+}
+
+// KanbanStackValue 卡片移入某列后分组字段应写入的值
+func KanbanStackValue(stack KanbanGroup) interface{} {
+	if stack.ID == KanbanEmptyStackID {
+		return nil
+	}
+	return stack.ID
+}
+
+// BuildKanbanCard 记录转为卡片数据
+// 配置了卡片字段时只返回卡片字段和分组字段
+func BuildKanbanCard(config *KanbanViewConfig, record ViewRecord) map[string]interface{} {
+	fields := record.Fields
+	if len(config.CardFields) > 0 {
+		fields = make(map[string]interface{}, len(config.CardFields)+1)
+		for _, fieldID := range append([]string{config.GroupFieldID}, config.CardFields...) {
+			if value, ok := record.Fields[fieldID]; ok {
+				fields[fieldID] = value
+			}
+		}
+	}
+	return map[string]interface{}{
+		"id":     record.ID,
+		"fields": fields,
+	}
+}
+
+// KanbanOrderBetween 计算插入在两张卡片之间的手动顺序值
+// prev/next 为空表示该侧没有卡片
+func KanbanOrderBetween(prev, next *float64) float64 {
+	switch {
+	case prev == nil && next == nil:
+		return 0
+	case prev == nil:
+		return *next - 1
+	case next == nil:
+		return *prev + 1
+	}
+	return (*prev + *next) / 2
+}
+
+// KanbanOrderNeedsRebalance 相邻卡片的顺序值是否过于接近（多次插入同一位置后出现）
+func KanbanOrderNeedsRebalance(prev, next *float64) bool {
+	return prev != nil && next != nil && *next-*prev < minKanbanOrderGap
+}
+
+// ValidateKanbanPosition 验证卡片相对参照卡片的位置
+func ValidateKanbanPosition(position string) error {
+	if position != KanbanPositionBefore && position != KanbanPositionAfter {
+		return fmt.Errorf("无效的卡片位置: %s", position)
+	}
+	return nil
+}
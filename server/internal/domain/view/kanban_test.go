@@ -0,0 +1,78 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+func TestKanbanOrderBetween(t *testing.T) {
+	one, three := 1.0, 3.0
+	cases := []struct {
+		prev, next *float64
+		want       float64
+	}{
+		{nil, nil, 0},
+		{nil, &one, 0},
+		{&three, nil, 4},
+		{&one, &three, 2},
+	}
+	for _, c := range cases {
+		if got := KanbanOrderBetween(c.prev, c.next); got != c.want {
+			t.Errorf("KanbanOrderBetween(%v, %v) = %v, 期望 %v", c.prev, c.next, got, c.want)
+		}
+	}
+
+	a, b := 1.0, 1.0+minKanbanOrderGap/2
+	if !KanbanOrderNeedsRebalance(&a, &b) {
+		t.Error("间隔过小时应重排")
+	}
+	if KanbanOrderNeedsRebalance(&one, &three) || KanbanOrderNeedsRebalance(nil, &one) {
+		t.Error("间隔足够时不应重排")
+	}
+}
+
+func TestKanbanStacks(t *testing.T) {
+	stacks := KanbanStacksFromValues([]interface{}{
+		"usr_1",
+		nil,
+		map[string]interface{}{"id": "usr_2", "title": "Bob"},
+		[]interface{}{"usr_1"},
+	})
+	if len(stacks) != 2 || stacks[0].ID != "usr_1" || stacks[1].ID != "usr_2" || stacks[1].Name != "Bob" {
+		t.Errorf("发现的看板列错误: %+v", stacks)
+	}
+
+	empty := KanbanStackFilter("fld_status", NewKanbanEmptyStack())
+	if empty.Filters[0].Operator != valueobject.FilterItemOpIsEmpty {
+		t.Errorf("未分类列应使用为空条件: %+v", empty)
+	}
+	if KanbanStackValue(NewKanbanEmptyStack()) != nil {
+		t.Error("移入未分类列应清空分组字段")
+	}
+
+	todo := KanbanGroup{ID: "待办"}
+	filter := KanbanStackFilter("fld_status", todo)
+	if filter.Filters[0].Operator != valueobject.FilterItemOpIs || filter.Filters[0].Value != "待办" {
+		t.Errorf("列条件错误: %+v", filter)
+	}
+	if !filter.Filters[0].Match("待办") {
+		t.Error("列条件应匹配该列的记录")
+	}
+
+	if offset, limit := NormalizeKanbanPage(-1, 1000); offset != 0 || limit != MaxKanbanStackSize {
+		t.Errorf("分页参数规范化错误: %d, %d", offset, limit)
+	}
+}
+
+func TestBuildKanbanCard(t *testing.T) {
+	config := &KanbanViewConfig{GroupFieldID: "status", CardFields: []string{"name"}}
+	card := BuildKanbanCard(config, ViewRecord{ID: "rec_1", Fields: map[string]interface{}{
+		"name": "任务", "status": "待办", "secret": "x",
+	}})
+
+	fields := card["fields"].(map[string]interface{})
+	if card["id"] != "rec_1" || len(fields) != 2 || fields["name"] != "任务" || fields["status"] != "待办" {
+		t.Errorf("卡片字段错误: %+v", card)
+	}
+}
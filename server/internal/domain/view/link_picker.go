@@ -0,0 +1,93 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// 关联记录选择器查询限制
+const (
+	DefaultLinkCandidatePageSize = 50  // 默认每页候选记录数
+	MaxLinkCandidatePageSize     = 500 // 每页最多候选记录数
+)
+
+// NormalizeLinkCandidatePage 规范化候选记录的分页参数
+func NormalizeLinkCandidatePage(offset, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultLinkCandidatePageSize
+	}
+	if limit > MaxLinkCandidatePageSize {
+		limit = MaxLinkCandidatePageSize
+	}
+	return offset, limit
+}
+
+// LinkFilter 由 Link 字段配置的过滤条件构建过滤器，没有条件时返回 nil
+// conjunction 为 or 时任一条件满足即可，其余情况所有条件都需满足
+func LinkFilter(conjunction string, items []valueobject.FilterItem) (*valueobject.Filter, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	operator := valueobject.FilterOperatorAnd
+	if strings.EqualFold(conjunction, string(valueobject.FilterOperatorOr)) {
+		operator = valueobject.FilterOperatorOr
+	}
+
+	filter := &valueobject.Filter{Operator: operator, Filters: items}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// LinkSearchFilter 按标题字段模糊搜索候选记录，关键字为空时返回 nil
+func LinkSearchFilter(titleFieldID, search string) *valueobject.Filter {
+	search = strings.TrimSpace(search)
+	if titleFieldID == "" || search == "" {
+		return nil
+	}
+	return &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters: []valueobject.FilterItem{
+			{FieldID: titleFieldID, Operator: valueobject.FilterItemOpContains, Value: search},
+		},
+	}
+}
+
+// LinkRecordIDFilter 限定记录ID范围（写入关联时校验关联记录是否满足约束）
+func LinkRecordIDFilter(recordIDs []string) *valueobject.Filter {
+	ids := make([]interface{}, len(recordIDs))
+	for i, id := range recordIDs {
+		ids[i] = id
+	}
+	return &valueobject.Filter{
+		Operator: valueobject.FilterOperatorAnd,
+		Filters: []valueobject.FilterItem{
+			{FieldID: "__id", Operator: valueobject.FilterItemOpHasAnyOf, Value: ids},
+		},
+	}
+}
+
+// LinkCandidateFields 只保留 Link 字段配置的可见字段
+// 未配置可见字段时返回全部字段；标题字段总是保留，用于选择器显示
+func LinkCandidateFields(values map[string]interface{}, visibleFieldIDs []string, titleFieldID string) map[string]interface{} {
+	if len(visibleFieldIDs) == 0 {
+		return values
+	}
+
+	result := make(map[string]interface{}, len(visibleFieldIDs)+1)
+	for _, fieldID := range visibleFieldIDs {
+		if value, ok := values[fieldID]; ok {
+			result[fieldID] = value
+		}
+	}
+	if value, ok := values[titleFieldID]; ok {
+		result[titleFieldID] = value
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+func TestLinkFilter(t *testing.T) {
+	filter, err := LinkFilter("", nil)
+	if err != nil || filter != nil {
+		t.Fatalf("没有条件时应返回 nil，实际 %v, %v", filter, err)
+	}
+
+	filter, err = LinkFilter("OR", []valueobject.FilterItem{
+		{FieldID: "fld_status", Operator: valueobject.FilterItemOpIs, Value: "active"},
+		{FieldID: "fld_owner", Operator: valueobject.FilterItemOpIsEmpty},
+	})
+	if err != nil {
+		t.Fatalf("构建过滤器失败: %v", err)
+	}
+	if filter.Operator != valueobject.FilterOperatorOr {
+		t.Errorf("连接词应为 or，实际 %s", filter.Operator)
+	}
+	if !filter.Match(map[string]interface{}{"fld_status": "archived"}) {
+		t.Error("or 条件下负责人为空应匹配")
+	}
+
+	if _, err := LinkFilter("and", []valueobject.FilterItem{{FieldID: "fld_status", Operator: "near"}}); err == nil {
+		t.Error("无效操作符应返回错误")
+	}
+}
+
+func TestLinkSearchFilter(t *testing.T) {
+	if LinkSearchFilter("fld_name", "  ") != nil || LinkSearchFilter("", "abc") != nil {
+		t.Error("关键字或标题字段为空时不应过滤")
+	}
+
+	filter := LinkSearchFilter("fld_name", " acme ")
+	if !filter.Match(map[string]interface{}{"fld_name": "ACME Corp"}) {
+		t.Error("应不区分大小写地模糊匹配标题字段")
+	}
+	if filter.Match(map[string]interface{}{"fld_name": "Globex"}) {
+		t.Error("不包含关键字的记录不应匹配")
+	}
+}
+
+func TestLinkRecordIDFilter(t *testing.T) {
+	filter := LinkRecordIDFilter([]string{"rec_1", "rec_2"})
+	if !filter.Match(map[string]interface{}{"__id": "rec_2"}) {
+		t.Error("范围内的记录应匹配")
+	}
+	if filter.Match(map[string]interface{}{"__id": "rec_3"}) {
+		t.Error("范围外的记录不应匹配")
+	}
+}
+
+func TestLinkCandidateFields(t *testing.T) {
+	values := map[string]interface{}{"fld_name": "a", "fld_email": "a@x", "fld_secret": "s"}
+
+	if got := LinkCandidateFields(values, nil, "fld_name"); len(got) != 3 {
+		t.Errorf("未配置可见字段时应返回全部字段，实际 %v", got)
+	}
+
+	got := LinkCandidateFields(values, []string{"fld_email", "fld_missing"}, "fld_name")
+	if len(got) != 2 || got["fld_email"] != "a@x" || got["fld_name"] != "a" {
+		t.Errorf("应只返回可见字段和标题字段，实际 %v", got)
+	}
+}
+
+func TestNormalizeLinkCandidatePage(t *testing.T) {
+	if offset, limit := NormalizeLinkCandidatePage(-1, 0); offset != 0 || limit != DefaultLinkCandidatePageSize {
+		t.Errorf("默认分页不正确: %d, %d", offset, limit)
+	}
+	if _, limit := NormalizeLinkCandidatePage(0, MaxLinkCandidatePageSize+1); limit != MaxLinkCandidatePageSize {
+		t.Errorf("每页数量应被限制，实际 %d", limit)
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "context"
+
+// RecordOrderRepository 视图内记录手动顺序仓储接口
+// 顺序值按视图隔离，未设置顺序的记录排在已设置的记录之后
+type RecordOrderRepository interface {
+	// FindOrders 查询记录在视图中的顺序值（未设置的记录不在结果中）
+	FindOrders(ctx context.Context, viewID string, recordIDs []string) (map[string]float64, error)
+
+	// FindNeighbor 查找与给定顺序值相邻的顺序值
+	// before 为 true 时查找小于 order 的最大值，否则查找大于 order 的最小值；不存在时返回 nil
+	FindNeighbor(ctx context.Context, viewID string, order float64, before bool, excludeRecordID string) (*float64, error)
+
+	// SetOrders 设置记录在视图中的顺序值（存在则更新）
+	SetOrders(ctx context.Context, viewID string, orders map[string]float64) error
+}
@@ -672,12 +672,11 @@ func (s *ServiceImpl) MoveKanbanCard(ctx context.Context, viewID string, recordI
 		}
 	}
 
-	// 这里需要调用记录服务来更新记录的分组字段值
-	// 由于视图服务不应该直接依赖记录服务，我们需要通过应用层来处理
-	// 暂时返回成功
+	// 移动卡片需要在同一事务中更新记录的分组字段值和手动顺序，
+	// 视图服务不应该直接依赖记录服务，由应用层 ViewService.MoveKanbanCard 处理
 	_ = config // 避免未使用变量警告
 
-	return nil
+	return errors.ErrBadRequest.WithDetails("看板卡片移动由应用层视图服务处理")
 }
 
 // GetCalendarViewData 获取日历视图数据
@@ -0,0 +1,233 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 时间线查询限制
+const (
+	MaxTimelineWindowSpan = 3 * 366 * 24 * time.Hour // 单次查询窗口最长约3年
+	MaxTimelineItems      = 5000                     // 单次返回的最大条目数
+)
+
+// TimelineWindow 时间线查询窗口
+type TimelineWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewTimelineWindow 解析并裁剪查询窗口
+// 结束时间早于开始时间时报错；跨度超过 MaxTimelineWindowSpan 时从开始时间起裁剪
+func NewTimelineWindow(start, end string) (TimelineWindow, error) {
+	startTime, ok := parseTimelineTime(start)
+	if !ok {
+		return TimelineWindow{}, fmt.Errorf("无效的开始时间: %s", start)
+	}
+	endTime, ok := parseTimelineTime(end)
+	if !ok {
+		return TimelineWindow{}, fmt.Errorf("无效的结束时间: %s", end)
+	}
+	if endTime.Before(startTime) {
+		return TimelineWindow{}, fmt.Errorf("结束时间不能早于开始时间")
+	}
+
+	if endTime.Sub(startTime) > MaxTimelineWindowSpan {
+		endTime = startTime.Add(MaxTimelineWindowSpan)
+	}
+
+	return TimelineWindow{Start: startTime, End: endTime}, nil
+}
+
+// BuildTimelineData 根据配置将记录构建为时间线数据
+//
+// 处理规则：
+//   - 没有开始时间的记录不展示
+//   - 没有结束时间（或结束早于开始）的记录按里程碑处理
+//   - 只保留与窗口重叠的条目，并把超出窗口的部分裁剪到窗口边界
+//   - 依赖只保留两端都在结果中的箭头
+func BuildTimelineData(config *TimelineViewConfig, window TimelineWindow, records []ViewRecord) *TimelineViewData {
+	data := &TimelineViewData{
+		WindowStart:  window.Start,
+		WindowEnd:    window.End,
+		Items:        []TimelineItem{},
+		Groups:       []TimelineGroup{},
+		Dependencies: []TimelineDependency{},
+		Config:       *config,
+	}
+
+	groupIndex := make(map[string]int)
+	itemIDs := make(map[string]bool)
+	predecessors := make(map[string][]string)
+
+	for _, record := range records {
+		rawStart, ok := parseTimelineTime(record.Fields[config.StartDateField])
+		if !ok {
+			continue
+		}
+		rawEnd := rawStart
+		if config.EndDateField != "" {
+			if end, ok := parseTimelineTime(record.Fields[config.EndDateField]); ok && !end.Before(rawStart) {
+				rawEnd = end
+			}
+		}
+
+		// 与窗口不重叠
+		if rawStart.After(window.End) || rawEnd.Before(window.Start) {
+			continue
+		}
+
+		if len(data.Items) >= MaxTimelineItems {
+			data.Truncated = true
+			break
+		}
+
+		item := TimelineItem{
+			ID:        record.ID,
+			Title:     timelineText(record.Fields[config.TitleField]),
+			Start:     rawStart,
+			End:       rawEnd,
+			RawStart:  rawStart,
+			RawEnd:    rawEnd,
+			Milestone: rawEnd.Equal(rawStart),
+			Data:      record.Fields,
+		}
+		if item.Start.Before(window.Start) {
+			item.Start = window.Start
+			item.StartClamped = true
+		}
+		if item.End.After(window.End) {
+			item.End = window.End
+			item.EndClamped = true
+		}
+		if config.ColorField != "" {
+			item.Color = timelineText(record.Fields[config.ColorField])
+		}
+
+		if config.GroupField != "" {
+			groupID, groupName := timelineGroupKey(record.Fields[config.GroupField])
+			item.GroupID = groupID
+			if idx, exists := groupIndex[groupID]; exists {
+				data.Groups[idx].Count++
+			} else {
+				groupIndex[groupID] = len(data.Groups)
+				data.Groups = append(data.Groups, TimelineGroup{ID: groupID, Name: groupName, Count: 1})
+			}
+		}
+
+		if config.DependencyField != "" {
+			predecessors[record.ID] = timelineLinkIDs(record.Fields[config.DependencyField])
+		}
+
+		itemIDs[record.ID] = true
+		data.Items = append(data.Items, item)
+	}
+
+	for _, item := range data.Items {
+		for _, fromID := range predecessors[item.ID] {
+			if fromID != item.ID && itemIDs[fromID] {
+				data.Dependencies = append(data.Dependencies, TimelineDependency{FromID: fromID, ToID: item.ID})
+			}
+		}
+	}
+
+	sort.SliceStable(data.Items, func(i, j int) bool {
+		return data.Items[i].RawStart.Before(data.Items[j].RawStart)
+	})
+
+	return data
+}
+
+// timelineLayouts 支持的日期格式
+var timelineLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTimelineTime 解析日期单元格值
+func parseTimelineTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, !v.IsZero()
+	case string:
+		for _, layout := range timelineLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// timelineText 单元格值转为展示文本
+func timelineText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+		return timelineText(v[0])
+	case map[string]interface{}:
+		for _, key := range []string{"title", "name", "id"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// timelineGroupKey 分组字段取值转为分组ID和名称
+// 多值字段按第一个值分组；对象（用户）以 id 为分组ID，title/name 为名称
+func timelineGroupKey(value interface{}) (string, string) {
+	if list, ok := value.([]interface{}); ok {
+		if len(list) == 0 {
+			return "", ""
+		}
+		value = list[0]
+	}
+	if obj, ok := value.(map[string]interface{}); ok {
+		id, _ := obj["id"].(string)
+		return id, timelineText(obj)
+	}
+	text := timelineText(value)
+	return text, text
+}
+
+// timelineLinkIDs 链接字段取值中的记录ID列表
+func timelineLinkIDs(value interface{}) []string {
+	var ids []string
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			ids = append(ids, v)
+		}
+	case []string:
+		ids = append(ids, v...)
+	case map[string]interface{}:
+		if id, ok := v["id"].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			ids = append(ids, timelineLinkIDs(elem)...)
+		}
+	}
+	return ids
+}
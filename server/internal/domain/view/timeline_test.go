@@ -0,0 +1,145 @@
+package view
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimelineWindow(t *testing.T) {
+	window, err := NewTimelineWindow("2025-01-01", "2025-01-31")
+	if err != nil {
+		t.Fatalf("解析窗口失败: %v", err)
+	}
+	if !window.Start.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("窗口开始时间错误: %v", window.Start)
+	}
+
+	if _, err := NewTimelineWindow("2025-02-01", "2025-01-01"); err == nil {
+		t.Error("结束早于开始时应返回错误")
+	}
+	if _, err := NewTimelineWindow("not-a-date", "2025-01-01"); err == nil {
+		t.Error("无效日期应返回错误")
+	}
+
+	// 超长窗口被裁剪
+	window, err = NewTimelineWindow("2020-01-01", "2030-01-01")
+	if err != nil {
+		t.Fatalf("解析窗口失败: %v", err)
+	}
+	if window.End.Sub(window.Start) != MaxTimelineWindowSpan {
+		t.Errorf("窗口跨度应被裁剪为%v，实际为%v", MaxTimelineWindowSpan, window.End.Sub(window.Start))
+	}
+}
+
+func TestTimelineViewConfigValidate(t *testing.T) {
+	config := &TimelineViewConfig{StartDateField: "fld_start", EndDateField: "fld_end"}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("有效配置验证失败: %v", err)
+	}
+	if config.Scale != "week" {
+		t.Errorf("默认刻度应为week，实际为%s", config.Scale)
+	}
+
+	if err := (&TimelineViewConfig{}).Validate(); err == nil {
+		t.Error("缺少开始日期字段应返回错误")
+	}
+	if err := (&TimelineViewConfig{StartDateField: "fld_a", EndDateField: "fld_a"}).Validate(); err == nil {
+		t.Error("开始和结束字段相同应返回错误")
+	}
+	if err := (&TimelineViewConfig{StartDateField: "fld_a", Scale: "hour"}).Validate(); err == nil {
+		t.Error("无效刻度应返回错误")
+	}
+
+	fieldTypes := map[string]string{
+		"fld_start":  "date",
+		"fld_end":    "datetime",
+		"fld_status": "singleSelect",
+		"fld_deps":   "link",
+		"fld_name":   "text",
+	}
+	config = &TimelineViewConfig{
+		StartDateField:  "fld_start",
+		EndDateField:    "fld_end",
+		GroupField:      "fld_status",
+		DependencyField: "fld_deps",
+	}
+	if err := config.ValidateFieldTypes(fieldTypes); err != nil {
+		t.Errorf("字段类型验证失败: %v", err)
+	}
+
+	config.GroupField = "fld_name"
+	if err := config.ValidateFieldTypes(fieldTypes); err == nil {
+		t.Error("文本字段不能作为分组字段")
+	}
+
+	config.GroupField = ""
+	config.StartDateField = "fld_missing"
+	if err := config.ValidateFieldTypes(fieldTypes); err == nil {
+		t.Error("不存在的字段应返回错误")
+	}
+}
+
+func TestBuildTimelineData(t *testing.T) {
+	config := &TimelineViewConfig{
+		StartDateField:  "start",
+		EndDateField:    "end",
+		TitleField:      "name",
+		GroupField:      "owner",
+		DependencyField: "deps",
+	}
+	window, _ := NewTimelineWindow("2025-03-01", "2025-03-31")
+
+	records := []ViewRecord{
+		{ID: "rec_a", Fields: map[string]interface{}{
+			"name": "设计", "start": "2025-02-20", "end": "2025-03-05",
+			"owner": map[string]interface{}{"id": "usr_1", "title": "Alice"},
+		}},
+		{ID: "rec_b", Fields: map[string]interface{}{
+			"name": "开发", "start": "2025-03-06", "end": "2025-04-10",
+			"owner": map[string]interface{}{"id": "usr_1", "title": "Alice"},
+			"deps":  []interface{}{map[string]interface{}{"id": "rec_a"}, map[string]interface{}{"id": "rec_x"}},
+		}},
+		{ID: "rec_c", Fields: map[string]interface{}{
+			"name": "发布", "start": "2025-03-20",
+			"owner": map[string]interface{}{"id": "usr_2", "title": "Bob"},
+			"deps":  []interface{}{"rec_b"},
+		}},
+		{ID: "rec_old", Fields: map[string]interface{}{"name": "旧任务", "start": "2025-01-01", "end": "2025-01-10"}},
+		{ID: "rec_nodate", Fields: map[string]interface{}{"name": "无日期"}},
+	}
+
+	data := BuildTimelineData(config, window, records)
+
+	if len(data.Items) != 3 {
+		t.Fatalf("期望3个条目，实际为%d", len(data.Items))
+	}
+
+	a, b, c := data.Items[0], data.Items[1], data.Items[2]
+	if a.ID != "rec_a" || !a.StartClamped || !a.Start.Equal(window.Start) {
+		t.Errorf("rec_a 开始时间应被裁剪到窗口开始: %+v", a)
+	}
+	if b.ID != "rec_b" || !b.EndClamped || !b.End.Equal(window.End) {
+		t.Errorf("rec_b 结束时间应被裁剪到窗口结束: %+v", b)
+	}
+	if c.ID != "rec_c" || !c.Milestone {
+		t.Errorf("rec_c 无结束时间应为里程碑: %+v", c)
+	}
+
+	if len(data.Groups) != 2 || data.Groups[0].ID != "usr_1" || data.Groups[0].Count != 2 || data.Groups[0].Name != "Alice" {
+		t.Errorf("分组结果错误: %+v", data.Groups)
+	}
+
+	// rec_x 不在结果中，依赖被忽略
+	if len(data.Dependencies) != 2 {
+		t.Fatalf("期望2条依赖，实际为%d: %+v", len(data.Dependencies), data.Dependencies)
+	}
+	if data.Dependencies[0] != (TimelineDependency{FromID: "rec_a", ToID: "rec_b"}) {
+		t.Errorf("依赖错误: %+v", data.Dependencies[0])
+	}
+
+	validator := NewViewDataValidator()
+	view := &View{Type: ViewTypeTimeline}
+	if err := validator.ValidateViewData(view, &BaseViewDataResponse{Type: ViewTypeTimeline, Data: data}); err != nil {
+		t.Errorf("时间线数据验证失败: %v", err)
+	}
+}
@@ -507,6 +507,76 @@ func (h *CalendarViewHandler) TransformConfig(config ViewConfig, targetType View
 	return nil, fmt.Errorf("暂不支持配置转换")
 }
 
+// TimelineViewHandler 时间线视图处理器
+type TimelineViewHandler struct {
+	BaseViewTypeHandler
+}
+
+// NewTimelineViewHandler 创建时间线视图处理器
+func NewTimelineViewHandler() ViewTypeHandler {
+	return &TimelineViewHandler{
+		BaseViewTypeHandler: BaseViewTypeHandler{
+			viewType: ViewTypeTimeline,
+			info: ViewTypeInfo{
+				Type:        ViewTypeTimeline,
+				Name:        "时间线视图",
+				Description: "以甘特图形式展示带起止时间的数据",
+				Icon:        "timeline",
+				Category:    "时间管理",
+				Features:    []string{"filter", "date_field", "milestone", "duration", "group_by_field", "dependency"},
+				IsDefault:   false,
+				IsEnabled:   true,
+			},
+		},
+	}
+}
+
+// CreateDefaultConfig 创建默认配置
+func (h *TimelineViewHandler) CreateDefaultConfig() ViewConfig {
+	return &TimelineViewConfig{
+		BaseViewConfig: BaseViewConfig{Type: ViewTypeTimeline},
+		Scale:          "week",
+		ShowMilestone:  true,
+		ShowDuration:   true,
+	}
+}
+
+// ValidateConfig 验证配置
+func (h *TimelineViewHandler) ValidateConfig(config ViewConfig) error {
+	timelineConfig, ok := config.(*TimelineViewConfig)
+	if !ok {
+		return fmt.Errorf("配置类型不匹配")
+	}
+	return timelineConfig.Validate()
+}
+
+// ProcessData 处理视图数据
+func (h *TimelineViewHandler) ProcessData(ctx context.Context, view *View, request ViewDataRequest) (ViewDataResponse, error) {
+	return &BaseViewDataResponse{Type: ViewTypeTimeline, Data: &TimelineViewData{}}, nil
+}
+
+// CanTransformTo 检查是否可以转换为其他视图类型
+func (h *TimelineViewHandler) CanTransformTo(targetType ViewType) bool {
+	return targetType == ViewTypeCalendar
+}
+
+// TransformConfig 转换配置到其他视图类型
+func (h *TimelineViewHandler) TransformConfig(config ViewConfig, targetType ViewType) (ViewConfig, error) {
+	timelineConfig, ok := config.(*TimelineViewConfig)
+	if !ok || targetType != ViewTypeCalendar {
+		return nil, fmt.Errorf("暂不支持配置转换")
+	}
+	return &CalendarViewConfig{
+		BaseViewConfig: BaseViewConfig{Type: ViewTypeCalendar, Filters: timelineConfig.Filters},
+		DateFieldID:    timelineConfig.StartDateField,
+		TitleFieldID:   timelineConfig.TitleField,
+		ColorFieldID:   timelineConfig.ColorField,
+		StartTimeField: timelineConfig.StartDateField,
+		EndTimeField:   timelineConfig.EndDateField,
+		DefaultView:    "month",
+	}, nil
+}
+
 // 其他视图处理器的占位符实现
 func NewGalleryViewHandler() ViewTypeHandler {
 	return &BaseViewTypeHandler{
@@ -538,16 +608,6 @@ func NewChartViewHandler() ViewTypeHandler {
 	}
 }
 
-func NewTimelineViewHandler() ViewTypeHandler {
-	return &BaseViewTypeHandler{
-		viewType: ViewTypeTimeline,
-		info: ViewTypeInfo{
-			Type: ViewTypeTimeline, Name: "时间线视图", Description: "以时间线形式展示数据",
-			Icon: "timeline", Category: "时间管理", Features: []string{"filter", "date_field", "milestone"},
-		},
-	}
-}
-
 // 为基础处理器添加默认实现
 func (h *BaseViewTypeHandler) CreateDefaultConfig() ViewConfig {
 	return &BaseViewConfig{Type: h.viewType}
@@ -7,10 +7,11 @@ import (
 
 // ColumnMeta 列配置
 type ColumnMeta struct {
-	FieldID string  `json:"fieldId"` // 字段ID
-	Width   int     `json:"width"`   // 列宽（像素）
-	Visible bool    `json:"visible"` // 是否可见
-	Order   float64 `json:"order"`   // 排序位置
+	FieldID   string        `json:"fieldId"`             // 字段ID
+	Width     int           `json:"width"`               // 列宽（像素）
+	Visible   bool          `json:"visible"`             // 是否可见
+	Order     float64       `json:"order"`               // 排序位置
+	Statistic StatisticFunc `json:"statistic,omitempty"` // 列底部统计（为空不统计）
 }
 
 // ColumnMetaList 列配置列表
@@ -86,6 +87,11 @@ func (cm *ColumnMeta) Validate() error {
 		return fmt.Errorf("column width should be between 50 and 1000 pixels, got %d", cm.Width)
 	}
 
+	// 验证统计函数
+	if cm.Statistic != "" && !cm.Statistic.IsValid() {
+		return fmt.Errorf("invalid statistic func: %s", cm.Statistic)
+	}
+
 	return nil
 }
 
@@ -103,11 +109,30 @@ func (cml *ColumnMetaList) ToSlice() []map[string]interface{} {
 			"visible": col.Visible,
 			"order":   col.Order,
 		}
+		if col.Statistic != "" {
+			result[i]["statistic"] = col.Statistic
+		}
 	}
 
 	return result
 }
 
+// Statistics 获取可见列配置的统计项
+func (cml *ColumnMetaList) Statistics() []ColumnStatistic {
+	if cml == nil {
+		return []ColumnStatistic{}
+	}
+
+	statistics := make([]ColumnStatistic, 0, len(cml.Columns))
+	for _, col := range cml.Columns {
+		if col.Visible && col.Statistic != "" {
+			statistics = append(statistics, ColumnStatistic{FieldID: col.FieldID, Func: col.Statistic})
+		}
+	}
+
+	return statistics
+}
+
 // IsEmpty 检查列配置是否为空
 func (cml *ColumnMetaList) IsEmpty() bool {
 	return cml == nil || len(cml.Columns) == 0
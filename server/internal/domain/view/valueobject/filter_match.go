@@ -0,0 +1,383 @@
+package valueobject
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 过滤器占位符
+// 用于行级权限等需要按"当前用户"求值的场景，求值前通过 Resolve 替换为实际值
+const (
+	FilterPlaceholderMe       = "@me"       // 当前用户ID
+	FilterPlaceholderMyGroups = "@myGroups" // 当前用户所属部门ID列表
+)
+
+// FilterContext 过滤器求值上下文
+type FilterContext struct {
+	UserID   string
+	GroupIDs []string
+}
+
+// HasPlaceholders 检查过滤器是否包含占位符
+func (f *Filter) HasPlaceholders() bool {
+	if f == nil {
+		return false
+	}
+	for _, item := range f.Filters {
+		if containsPlaceholder(item.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve 将占位符替换为上下文中的实际值
+// 返回新的过滤器，不修改原对象
+//   - "@me" 替换为当前用户ID
+//   - "@myGroups" 展开为当前用户所属部门ID列表
+func (f *Filter) Resolve(fc FilterContext) *Filter {
+	if f == nil {
+		return nil
+	}
+
+	resolved := &Filter{
+		Operator: f.Operator,
+		Filters:  make([]FilterItem, len(f.Filters)),
+	}
+	for i, item := range f.Filters {
+		resolved.Filters[i] = FilterItem{
+			FieldID:  item.FieldID,
+			Operator: item.Operator,
+			Value:    resolvePlaceholder(item.Value, fc),
+		}
+	}
+	return resolved
+}
+
+// Match 在内存中判断记录是否满足过滤条件
+// values 以字段ID为键；系统字段使用物理列名（如 __created_by）
+func (f *Filter) Match(values map[string]interface{}) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if f.Operator == FilterOperatorOr {
+		for _, item := range f.Filters {
+			if item.Match(values[item.FieldID]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, item := range f.Filters {
+		if !item.Match(values[item.FieldID]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match 判断单元格值是否满足过滤项
+//
+// 语义约定（与 SQL 翻译保持一致）：
+//   - 多值单元格（多选、用户、链接）按"是否包含"比较
+//   - is / isNot 的值为数组时分别等价于 hasAnyOf / hasNoneOf
+//   - 数组操作的值为空数组时：hasAnyOf、hasAllOf 不匹配，hasNoneOf 匹配
+func (fi *FilterItem) Match(cell interface{}) bool {
+	cellTokens := FilterValueTokens(cell)
+	expected := FilterValueTokens(fi.Value)
+
+	switch fi.Operator {
+	case FilterItemOpIs:
+		if IsListFilterValue(fi.Value) {
+			return hasAnyToken(cellTokens, expected)
+		}
+		return fi.matchSingle(cell, cellTokens, expected)
+	case FilterItemOpIsNot:
+		if IsListFilterValue(fi.Value) {
+			return !hasAnyToken(cellTokens, expected)
+		}
+		return !fi.matchSingle(cell, cellTokens, expected)
+	case FilterItemOpContains:
+		return containsText(cellTokens, fmt.Sprint(fi.Value))
+	case FilterItemOpNotContains:
+		return !containsText(cellTokens, fmt.Sprint(fi.Value))
+	case FilterItemOpIsEmpty:
+		return len(cellTokens) == 0
+	case FilterItemOpIsNotEmpty:
+		return len(cellTokens) > 0
+	case FilterItemOpGreater, FilterItemOpIsAfter:
+		cmp, ok := compareFilterValues(cell, fi.Value)
+		return ok && cmp > 0
+	case FilterItemOpGreaterEqual:
+		cmp, ok := compareFilterValues(cell, fi.Value)
+		return ok && cmp >= 0
+	case FilterItemOpLess, FilterItemOpIsBefore:
+		cmp, ok := compareFilterValues(cell, fi.Value)
+		return ok && cmp < 0
+	case FilterItemOpLessEqual:
+		cmp, ok := compareFilterValues(cell, fi.Value)
+		return ok && cmp <= 0
+	case FilterItemOpIsWithin:
+		bounds, ok := fi.Value.([]interface{})
+		if !ok || len(bounds) != 2 {
+			return false
+		}
+		lower, ok1 := compareFilterValues(cell, bounds[0])
+		upper, ok2 := compareFilterValues(cell, bounds[1])
+		return ok1 && ok2 && lower >= 0 && upper <= 0
+	case FilterItemOpHasAnyOf:
+		return hasAnyToken(cellTokens, expected)
+	case FilterItemOpHasAllOf:
+		return len(expected) > 0 && hasAllTokens(cellTokens, expected)
+	case FilterItemOpHasNoneOf:
+		return !hasAnyToken(cellTokens, expected)
+	case FilterItemOpIsExactly:
+		return sameTokenSet(cellTokens, expected)
+	case FilterItemOpIsNotExactly:
+		return !sameTokenSet(cellTokens, expected)
+	}
+
+	return false
+}
+
+// matchSingle 单值相等：标量按数值/日期/文本比较，多值单元格按包含比较
+func (fi *FilterItem) matchSingle(cell interface{}, cellTokens, expected []string) bool {
+	if len(expected) != 1 {
+		return false
+	}
+	switch cell.(type) {
+	case []interface{}, []string, map[string]interface{}:
+		return hasAnyToken(cellTokens, expected)
+	}
+	cmp, ok := compareFilterValues(cell, expected[0])
+	return ok && cmp == 0
+}
+
+// IsListFilterValue 判断过滤值是否为数组
+func IsListFilterValue(value interface{}) bool {
+	switch value.(type) {
+	case []interface{}, []string:
+		return true
+	}
+	return false
+}
+
+// FilterValueTokens 将单元格值或过滤值展开为可比较的字符串列表
+//   - 对象取其 id（用户、链接）
+//   - 数组逐项展开（多选、多用户、多链接）
+//   - 空字符串与 nil 视为空
+func FilterValueTokens(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []string:
+		tokens := make([]string, 0, len(v))
+		for _, s := range v {
+			if s != "" {
+				tokens = append(tokens, s)
+			}
+		}
+		return tokens
+	case []interface{}:
+		tokens := make([]string, 0, len(v))
+		for _, elem := range v {
+			tokens = append(tokens, FilterValueTokens(elem)...)
+		}
+		return tokens
+	case map[string]interface{}:
+		if id, ok := v["id"]; ok {
+			return FilterValueTokens(id)
+		}
+		return nil
+	case time.Time:
+		return []string{v.Format(time.RFC3339)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	}
+
+	if f, ok := toFloat(value); ok {
+		return []string{strconv.FormatFloat(f, 'f', -1, 64)}
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+// resolvePlaceholder 递归替换占位符
+func resolvePlaceholder(value interface{}, fc FilterContext) interface{} {
+	switch v := value.(type) {
+	case string:
+		switch v {
+		case FilterPlaceholderMe:
+			return fc.UserID
+		case FilterPlaceholderMyGroups:
+			groups := make([]interface{}, len(fc.GroupIDs))
+			for i, id := range fc.GroupIDs {
+				groups[i] = id
+			}
+			return groups
+		}
+		return v
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+		return resolvePlaceholder(items, fc)
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, elem := range v {
+			if s, ok := elem.(string); ok && s == FilterPlaceholderMyGroups {
+				for _, id := range fc.GroupIDs {
+					items = append(items, id)
+				}
+				continue
+			}
+			items = append(items, resolvePlaceholder(elem, fc))
+		}
+		return items
+	}
+	return value
+}
+
+// containsPlaceholder 检查值中是否包含占位符
+func containsPlaceholder(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == FilterPlaceholderMe || v == FilterPlaceholderMyGroups
+	case []string:
+		for _, s := range v {
+			if containsPlaceholder(s) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			if containsPlaceholder(elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasAnyToken(tokens, expected []string) bool {
+	for _, e := range expected {
+		for _, t := range tokens {
+			if t == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasAllTokens(tokens, expected []string) bool {
+	for _, e := range expected {
+		if !hasAnyToken(tokens, []string{e}) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameTokenSet(tokens, expected []string) bool {
+	return hasAllTokens(tokens, expected) && hasAllTokens(expected, tokens)
+}
+
+func containsText(tokens []string, needle string) bool {
+	needle = strings.ToLower(needle)
+	for _, t := range tokens {
+		if strings.Contains(strings.ToLower(t), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareFilterValues 比较单元格值与过滤值（优先数值，其次日期，最后字符串）
+func compareFilterValues(cell, value interface{}) (int, bool) {
+	if cell == nil || value == nil {
+		return 0, false
+	}
+
+	if a, ok := toFloat(cell); ok {
+		if b, ok := toFloat(value); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	if a, ok := toTime(cell); ok {
+		if b, ok := toTime(value); ok {
+			return a.Compare(b), true
+		}
+	}
+
+	a, b := fmt.Sprint(cell), fmt.Sprint(value)
+	return strings.Compare(a, b), true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// filterTimeLayouts 支持的日期格式
+var filterTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		for _, layout := range filterTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -12,10 +12,20 @@ type Group struct {
 
 // GroupItem 分组项
 type GroupItem struct {
-	FieldID string    `json:"fieldId"` // 字段ID
-	Order   SortOrder `json:"order"`   // 排序方向
+	FieldID    string          `json:"fieldId"`              // 字段ID
+	Order      SortOrder       `json:"order"`                // 排序方向
+	DateBucket GroupDateBucket `json:"dateBucket,omitempty"` // 日期字段的分组粒度（默认按天）
 }
 
+// GroupDateBucket 日期分组粒度
+type GroupDateBucket string
+
+const (
+	GroupDateBucketDay   GroupDateBucket = "day"   // 按天
+	GroupDateBucketWeek  GroupDateBucket = "week"  // 按周（周一开始）
+	GroupDateBucketMonth GroupDateBucket = "month" // 按月
+)
+
 // NewGroup 创建分组值对象
 func NewGroup(data []map[string]interface{}) (*Group, error) {
 	if data == nil || len(data) == 0 {
@@ -81,6 +91,13 @@ func (gi *GroupItem) Validate() error {
 		return fmt.Errorf("invalid sort order: %s, must be 'asc' or 'desc'", gi.Order)
 	}
 
+	// 验证日期分组粒度
+	switch gi.DateBucket {
+	case "", GroupDateBucketDay, GroupDateBucketWeek, GroupDateBucketMonth:
+	default:
+		return fmt.Errorf("invalid date bucket: %s, must be 'day', 'week' or 'month'", gi.DateBucket)
+	}
+
 	return nil
 }
 
@@ -96,6 +113,9 @@ func (g *Group) ToSlice() []map[string]interface{} {
 			"fieldId": item.FieldID,
 			"order":   item.Order,
 		}
+		if item.DateBucket != "" {
+			result[i]["dateBucket"] = item.DateBucket
+		}
 	}
 
 	return result
@@ -0,0 +1,105 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatisticFunc 列统计函数
+type StatisticFunc string
+
+const (
+	StatisticSum    StatisticFunc = "sum"    // 求和
+	StatisticAvg    StatisticFunc = "avg"    // 平均值
+	StatisticMin    StatisticFunc = "min"    // 最小值
+	StatisticMax    StatisticFunc = "max"    // 最大值
+	StatisticEmpty  StatisticFunc = "empty"  // 空值数
+	StatisticFilled StatisticFunc = "filled" // 非空数
+	StatisticUnique StatisticFunc = "unique" // 去重后的非空值数
+
+	StatisticPercentFilled StatisticFunc = "percentFilled" // 非空占比（0-100）
+	StatisticEarliest      StatisticFunc = "earliest"      // 最早日期
+	StatisticLatest        StatisticFunc = "latest"        // 最晚日期
+	StatisticChecked       StatisticFunc = "checked"       // 勾选数
+)
+
+// 统计函数适用的字段类型
+var (
+	statisticNumberFieldTypes = map[string]bool{
+		"number": true, "rating": true, "percent": true, "currency": true,
+		"rollup": true, "count": true, "autoNumber": true,
+	}
+	statisticDateFieldTypes = map[string]bool{
+		"date": true, "datetime": true, "createdTime": true, "lastModifiedTime": true,
+	}
+	statisticCheckboxFieldTypes = map[string]bool{
+		"checkbox": true,
+	}
+)
+
+// IsValid 检查统计函数是否有效
+func (f StatisticFunc) IsValid() bool {
+	switch f {
+	case StatisticSum, StatisticAvg, StatisticMin, StatisticMax,
+		StatisticEmpty, StatisticFilled, StatisticUnique,
+		StatisticPercentFilled, StatisticEarliest, StatisticLatest, StatisticChecked:
+		return true
+	}
+	return false
+}
+
+// SupportsFieldType 检查统计函数是否适用于该字段类型
+//   - sum / avg 只适用于数字字段
+//   - min / max 适用于数字和日期字段
+//   - earliest / latest 只适用于日期字段
+//   - checked 只适用于复选框字段
+//   - 其余函数适用于所有字段
+func (f StatisticFunc) SupportsFieldType(fieldType string) bool {
+	switch f {
+	case StatisticSum, StatisticAvg:
+		return statisticNumberFieldTypes[fieldType]
+	case StatisticMin, StatisticMax:
+		return statisticNumberFieldTypes[fieldType] || statisticDateFieldTypes[fieldType]
+	case StatisticEarliest, StatisticLatest:
+		return statisticDateFieldTypes[fieldType]
+	case StatisticChecked:
+		return statisticCheckboxFieldTypes[fieldType]
+	}
+	return f.IsValid()
+}
+
+// ColumnStatistic 列统计项
+type ColumnStatistic struct {
+	FieldID string        `json:"fieldId"` // 字段ID
+	Func    StatisticFunc `json:"func"`    // 统计函数
+}
+
+// Key 统计结果的键（fieldId:func）
+func (s ColumnStatistic) Key() string {
+	return s.FieldID + ":" + string(s.Func)
+}
+
+// ParseColumnStatistics 解析统计项列表，格式为 "fieldId:func,fieldId:func"
+func ParseColumnStatistics(value string) ([]ColumnStatistic, error) {
+	statistics := []ColumnStatistic{}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		fieldID, fn, ok := strings.Cut(part, ":")
+		if !ok || fieldID == "" {
+			return nil, fmt.Errorf("invalid statistic: %s, must be 'fieldId:func'", part)
+		}
+		statistic := ColumnStatistic{FieldID: fieldID, Func: StatisticFunc(fn)}
+		if !statistic.Func.IsValid() {
+			return nil, fmt.Errorf("invalid statistic func: %s", fn)
+		}
+		if !seen[statistic.Key()] {
+			seen[statistic.Key()] = true
+			statistics = append(statistics, statistic)
+		}
+	}
+	return statistics, nil
+}
@@ -11,6 +11,7 @@ const (
 	ViewTypeGallery  ViewType = "gallery"  // 画廊视图
 	ViewTypeForm     ViewType = "form"     // 表单视图
 	ViewTypeCalendar ViewType = "calendar" // 日历视图
+	ViewTypeTimeline ViewType = "timeline" // 时间线视图（甘特图）
 )
 
 // NewViewType 创建视图类型值对象
@@ -35,6 +36,7 @@ func (vt ViewType) IsValid() bool {
 		ViewTypeGallery:  true,
 		ViewTypeForm:     true,
 		ViewTypeCalendar: true,
+		ViewTypeTimeline: true,
 	}
 	return validTypes[vt]
 }
@@ -69,6 +71,11 @@ func (vt ViewType) IsCalendar() bool {
 	return vt == ViewTypeCalendar
 }
 
+// IsTimeline 是否为时间线视图
+func (vt ViewType) IsTimeline() bool {
+	return vt == ViewTypeTimeline
+}
+
 // SupportsFilter 是否支持过滤
 func (vt ViewType) SupportsFilter() bool {
 	// 所有视图类型都支持过滤
@@ -83,6 +90,6 @@ func (vt ViewType) SupportsSort() bool {
 
 // SupportsGroup 是否支持分组
 func (vt ViewType) SupportsGroup() bool {
-	// 看板视图支持分组
-	return vt == ViewTypeKanban
+	// 看板视图、时间线视图支持分组
+	return vt == ViewTypeKanban || vt == ViewTypeTimeline
 }
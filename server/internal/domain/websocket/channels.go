@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ChannelType 频道类型
@@ -174,65 +175,26 @@ func (cm *ChannelManager) GetOperationChannels(op *Operation) []string {
 }
 
 // ParseChannelName 解析频道名称
-// 返回频道类型和标识符
+// 格式: type:identifier[:subpath]，返回频道类型、标识符和子路径
 func (cm *ChannelManager) ParseChannelName(channelName string) (ChannelType, string, string, error) {
-	// 简单的字符串解析
-	// 格式: type:identifier[:subpath]
-	var channelType ChannelType
-	var identifier string
-	var subPath string
-
-	// 这里简化处理，实际可能需要更复杂的解析逻辑
-	// TODO: 实现完整的频道名称解析
-	//
-	// 改进方向：
-	// 1. 支持更多频道格式：
-	//    - "space:{id}:bases" - 空间的Base列表
-	//    - "base:{id}:tables" - Base的Table列表
-	//    - "table:{id}:records" - Table的Record列表
-	//    - "user:{id}:notifications" - 用户通知
-	// 2. 添加频道格式验证
-	// 3. 返回结构化的频道信息:
-	//    type ChannelInfo struct {
-	//        Type       string
-	//        ResourceID string
-	//        SubPath    string
-	//        IsValid    bool
-	//    }
-
-	return channelType, identifier, subPath, nil
+	parts := strings.SplitN(channelName, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", &SubscriptionError{Message: "invalid channel name: " + channelName}
+	}
+
+	subPath := ""
+	if len(parts) == 3 {
+		subPath = parts[2]
+	}
+	return ChannelType(parts[0]), parts[1], subPath, nil
 }
 
-// ValidateChannelAccess 验证用户是否有权访问指定频道
-// 根据频道类型和用户权限判断
+// ValidateChannelAccess 验证频道格式以及用户频道的归属
+// 表、视图、记录等资源权限由 SubscriptionGuard 通过 SubscriptionAuthorizer 校验
 func (cm *ChannelManager) ValidateChannelAccess(userID string, channelName string) bool {
-	// TODO: 实现权限验证逻辑
-	//
-	// 实现步骤：
-	// 1. 解析频道名称
-	//    channelType, resourceID, _, err := cm.ParseChannelName(channelName)
-	//    if err != nil {
-	//        return false
-	//    }
-	//
-	// 2. 根据频道类型查询权限
-	//    switch channelType {
-	//    case "space":
-	//        return cm.permissionService.CanAccessSpace(ctx, userID, resourceID)
-	//    case "base":
-	//        return cm.permissionService.CanAccessBase(ctx, userID, resourceID)
-	//    case "table":
-	//        return cm.permissionService.CanAccessTable(ctx, userID, resourceID)
-	//    case "view":
-	//        return cm.permissionService.CanAccessView(ctx, userID, resourceID)
-	//    case "user":
-	//        return userID == resourceID  // 用户只能访问自己的频道
-	//    default:
-	//        return false
-	//    }
-	//
-	// 3. 考虑添加权限缓存以提升性能
-	//
-	// 暂时允许所有访问
-	return true
+	scope, err := ParseChannelScope(channelName)
+	if err != nil {
+		return false
+	}
+	return scope.Type != ScopeUser || scope.UserID == userID
 }
@@ -1,9 +1,12 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,15 +24,19 @@ const (
 	pongWait = 60 * time.Second
 	// 发送ping消息的间隔时间
 	pingPeriod = (pongWait * 9) / 10
-	// 最大消息大小
-	maxMessageSize = 512
+	// 最大消息大小（实时查询携带过滤和排序条件）
+	maxMessageSize = 8 * 1024
+	// 订阅授权超时时间
+	authorizeTimeout = 5 * time.Second
 )
 
 // Handler WebSocket处理器
 type Handler struct {
-	manager  *Manager
-	logger   *zap.Logger
-	upgrader websocket.Upgrader
+	manager     *Manager
+	guard       *SubscriptionGuard // ✨ 订阅授权
+	liveQueries *LiveQueryManager  // ✨ 实时查询
+	logger      *zap.Logger
+	upgrader    websocket.Upgrader
 }
 
 // NewHandler 创建新的WebSocket处理器
@@ -48,32 +55,52 @@ func NewHandler(manager *Manager, logger *zap.Logger) *Handler {
 	}
 }
 
+// SetSubscriptionGuard 设置订阅守卫（用于延迟注入）
+func (h *Handler) SetSubscriptionGuard(guard *SubscriptionGuard) {
+	h.guard = guard
+}
+
+// SetLiveQueryManager 设置实时查询管理器（用于延迟注入）
+func (h *Handler) SetLiveQueryManager(liveQueries *LiveQueryManager) {
+	h.liveQueries = liveQueries
+}
+
 // HandleWebSocket 处理WebSocket连接
+// 身份只取自 gin context：user_id 由认证后的 token 设置，
+// share_id 用于分享链接的匿名访问，只能订阅被分享的视图
 func (h *Handler) HandleWebSocket(c *gin.Context) {
-	// ✅ 优先从 gin context 获取用户信息（由 auth middleware 设置）
-	userID := ""
-	if uid, exists := c.Get("user_id"); exists {
-		if uidStr, ok := uid.(string); ok {
-			userID = uidStr
-		}
-	}
-
-	// 如果 context 中没有，尝试从查询参数或头部获取
-	if userID == "" {
-		userID = c.Query("user_id")
-	}
+	userID := c.GetString("user_id")
+	shareID := ""
 	if userID == "" {
-		userID = c.GetHeader("X-User-ID")
+		shareID = c.GetString("share_id")
 	}
 
-	if userID == "" {
+	if userID == "" && shareID == "" {
 		h.logger.Error("WebSocket connection rejected: missing user_id")
-		response.Error(c, appErrors.ErrBadRequest.WithDetails("missing user_id"))
+		response.Error(c, appErrors.ErrUnauthorized.WithDetails("missing user_id"))
 		return
 	}
 
+	if shareID != "" {
+		if h.guard == nil {
+			response.Error(c, appErrors.ErrForbidden.WithDetails("share link subscriptions are not enabled"))
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), authorizeTimeout)
+		err := h.guard.AuthorizeShare(ctx, shareID)
+		cancel()
+		if err != nil {
+			h.logger.Warn("WebSocket connection rejected: invalid share link",
+				zap.String("share_id", shareID),
+				zap.Error(err))
+			response.Error(c, appErrors.ErrForbidden.WithDetails(err.Error()))
+			return
+		}
+	}
+
 	h.logger.Info("WebSocket connection accepted",
-		zap.String("user_id", userID))
+		zap.String("user_id", userID),
+		zap.String("share_id", shareID))
 
 	// 升级HTTP连接为WebSocket
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
@@ -86,7 +113,10 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 	connection := &Connection{
 		ID:            generateConnectionID(),
 		UserID:        userID,
+		ShareID:       shareID,
 		SessionID:     c.Query("session_id"),
+		RemoteAddr:    c.ClientIP(),
+		UserAgent:     c.Request.UserAgent(),
 		Conn:          conn,
 		Send:          make(chan *Message, 256),
 		Manager:       h.manager,
@@ -111,6 +141,9 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 // readPump 读取消息
 func (h *Handler) readPump(conn *Connection) {
 	defer func() {
+		if h.liveQueries != nil {
+			h.liveQueries.RemoveConnection(conn.ID)
+		}
 		h.manager.unregister <- conn
 		conn.Conn.Close()
 	}()
@@ -159,7 +192,7 @@ func (h *Handler) writePump(conn *Connection) {
 		case message, ok := <-conn.Send:
 			conn.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
-				conn.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				conn.Conn.WriteMessage(websocket.CloseMessage, conn.closeMessage())
 				return
 			}
 
@@ -205,8 +238,12 @@ func (h *Handler) handleMessage(conn *Connection, msg *Message) {
 		h.handleSubscribe(conn, msg)
 	case MessageTypeUnsubscribe:
 		h.handleUnsubscribe(conn, msg)
+	case MessageTypeResume:
+		h.handleResume(conn, msg)
 	case MessageTypeQuery:
 		h.handleQuery(conn, msg)
+	case MessageTypeQueryUnsubscribe:
+		h.handleQueryUnsubscribe(conn, msg)
 	case MessageTypeSubmit:
 		h.handleSubmit(conn, msg)
 	case MessageTypePresence:
@@ -224,9 +261,7 @@ func (h *Handler) handlePing(conn *Connection, msg *Message) {
 
 	// 发送pong响应
 	pongMsg := NewMessage(MessageTypePong, nil)
-	select {
-	case conn.Send <- pongMsg:
-	default:
+	if !h.manager.SendToConnection(conn.ID, pongMsg) {
 		h.logger.Error("Failed to send pong message")
 	}
 }
@@ -243,22 +278,98 @@ func (h *Handler) handleSubscribe(conn *Connection, msg *Message) {
 		channel = fmt.Sprintf("%s.%s", msg.Collection, msg.Document)
 	}
 
-	h.manager.Subscribe(conn.ID, channel)
+	// ✨ 按表/视图/记录权限校验订阅，拒绝会记录审计日志
+	if h.guard != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), authorizeTimeout)
+		err := h.guard.Authorize(ctx, conn, channel)
+		cancel()
+		if err != nil {
+			h.sendError(conn, msg.ID, 403, err.Error())
+			return
+		}
+	}
 
-	// 发送订阅确认
-	response := NewMessage(MessageTypeSubscribe, map[string]string{
-		"channel": channel,
-		"status":  "subscribed",
+	result := h.manager.SubscribeFrom(conn, channel, 0)
+
+	// 发送订阅确认（seq 为当前序号，与 instance 一起作为断线重连时的恢复起点）
+	response := NewMessage(MessageTypeSubscribe, map[string]interface{}{
+		"channel":  channel,
+		"status":   "subscribed",
+		"seq":      result.Seq,
+		"instance": h.manager.InstanceID(),
 	})
 	response.ID = msg.ID
 
-	select {
-	case conn.Send <- response:
-	default:
+	if !h.manager.SendToConnection(conn.ID, response) {
 		h.logger.Error("Failed to send subscribe response")
 	}
 }
 
+// ResumeRequest 重连后的恢复请求：频道 -> 客户端最后收到的序号
+// Instance 为订阅确认中的实例标识；序号只在该实例内有效，重连到其他实例时全部重新同步
+type ResumeRequest struct {
+	Instance string            `json:"instance"`
+	Channels map[string]uint64 `json:"channels"`
+}
+
+// handleResume 处理重连恢复 ✨
+// 逐个频道重新授权并订阅，补发断线期间错过的消息；
+// 缓冲已不能覆盖或错过的消息需要重新判定权限时返回 resync，客户端应重新加载该频道的数据
+func (h *Handler) handleResume(conn *Connection, msg *Message) {
+	var request ResumeRequest
+	raw, err := json.Marshal(msg.Data)
+	if err == nil {
+		err = json.Unmarshal(raw, &request)
+	}
+	if err != nil {
+		h.sendError(conn, msg.ID, 400, "invalid resume request: "+err.Error())
+		return
+	}
+	if len(request.Channels) == 0 {
+		h.sendError(conn, msg.ID, 400, "channels is required")
+		return
+	}
+	if len(request.Channels) > MaxResumeChannels {
+		h.sendError(conn, msg.ID, 400, fmt.Sprintf("at most %d channels can be resumed at once", MaxResumeChannels))
+		return
+	}
+
+	channels := make([]string, 0, len(request.Channels))
+	for channel := range request.Channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	results := make([]*ResumeResult, 0, len(channels))
+	for _, channel := range channels {
+		if h.guard != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), authorizeTimeout)
+			err := h.guard.Authorize(ctx, conn, channel)
+			cancel()
+			if err != nil {
+				results = append(results, &ResumeResult{Channel: channel, Status: ResumeStatusDenied, Reason: err.Error()})
+				continue
+			}
+		}
+		if request.Instance != "" && request.Instance != h.manager.InstanceID() {
+			result := h.manager.SubscribeFrom(conn, channel, 0)
+			result.Status, result.Reason = ResumeStatusResync, "resumed on another server instance"
+			results = append(results, result)
+			continue
+		}
+		results = append(results, h.manager.SubscribeFrom(conn, channel, request.Channels[channel]))
+	}
+
+	response := NewMessage(MessageTypeResumeResponse, map[string]interface{}{
+		"instance": h.manager.InstanceID(),
+		"channels": results,
+	})
+	response.ID = msg.ID
+	if !h.manager.SendToConnection(conn.ID, response) {
+		h.logger.Error("Failed to send resume response")
+	}
+}
+
 // handleUnsubscribe 处理取消订阅
 func (h *Handler) handleUnsubscribe(conn *Connection, msg *Message) {
 	if msg.Collection == "" {
@@ -280,47 +391,130 @@ func (h *Handler) handleUnsubscribe(conn *Connection, msg *Message) {
 	})
 	response.ID = msg.ID
 
-	select {
-	case conn.Send <- response:
-	default:
+	if !h.manager.SendToConnection(conn.ID, response) {
 		h.logger.Error("Failed to send unsubscribe response")
 	}
 }
 
-// handleQuery 处理查询
+// handleQuery 处理视图查询 ✨
+// collection 为 view、document 为视图ID，data 为 LiveQueryRequest：
+// 返回分页窗口内的记录快照；subscribe 为 true 时以消息 ID 作为查询 ID，
+// 之后记录变更时推送 queryDiff（insert/remove/move/update）
 func (h *Handler) handleQuery(conn *Connection, msg *Message) {
-	// 实现查询逻辑（参考 teable-develop 的 ShareDB 查询）
-	h.logger.Info("Query message received",
-		zap.String("collection", msg.Collection),
-		zap.String("document", msg.Document))
+	if h.liveQueries == nil {
+		h.sendError(conn, msg.ID, 501, "live queries are not enabled")
+		return
+	}
+	if msg.Collection != "view" || msg.Document == "" {
+		h.sendError(conn, msg.ID, 400, "query requires collection \"view\" and a view id as document")
+		return
+	}
 
-	// 从 ShareDB 或数据库查询数据
-	// 这里可以根据 collection 类型查询不同的数据源
-	var data []interface{}
+	var request LiveQueryRequest
+	if msg.Data != nil {
+		raw, err := json.Marshal(msg.Data)
+		if err == nil {
+			err = json.Unmarshal(raw, &request)
+		}
+		if err != nil {
+			h.sendError(conn, msg.ID, 400, "invalid query: "+err.Error())
+			return
+		}
+	}
+	request.ViewID = msg.Document
+	if request.Subscribe && msg.ID == "" {
+		h.sendError(conn, msg.ID, 400, "live query requires a message id")
+		return
+	}
 
-	// 例如：如果是查询表格数据
-	if msg.Collection != "" && msg.Document != "" {
-		// 这里应该调用相应的服务获取数据
-		// 简化实现：返回空数据
-		data = []interface{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), liveQueryTimeout)
+	defer cancel()
+	var result *LiveQueryResult
+	var err error
+	if request.Subscribe {
+		result, err = h.liveQueries.Subscribe(ctx, conn, msg.ID, request.LiveQuerySpec)
+	} else {
+		result, err = h.liveQueries.Fetch(ctx, conn, request.LiveQuerySpec)
+	}
+	if err != nil {
+		code, message := queryErrorStatus(err)
+		h.logger.Warn("View query failed",
+			zap.String("connection_id", conn.ID),
+			zap.String("view_id", request.ViewID),
+			zap.Error(err))
+		h.sendError(conn, msg.ID, code, message)
+		return
 	}
 
-	// 发送查询响应
-	response := NewMessage(MessageTypeQueryResponse, QueryResponse{
-		Data: data,
-	})
+	request.Normalize()
+	data := make([]interface{}, 0, len(result.Records))
+	for _, record := range result.Records {
+		data = append(data, record)
+	}
+	snapshot := LiveQuerySnapshot{
+		TableID: result.TableID,
+		Total:   result.Total,
+		Offset:  request.Offset,
+		Limit:   request.Limit,
+		Live:    request.Subscribe,
+	}
+	if request.Subscribe {
+		snapshot.QueryID = msg.ID
+	}
+	response := NewMessage(MessageTypeQueryResponse, QueryResponse{Data: data, Extra: snapshot})
 	response.ID = msg.ID
+	response.Collection = msg.Collection
+	response.Document = msg.Document
 
-	select {
-	case conn.Send <- response:
+	if h.manager.SendToConnection(conn.ID, response) {
 		h.logger.Debug("Query response sent",
-			zap.String("collection", msg.Collection),
+			zap.String("view_id", request.ViewID),
+			zap.Bool("live", request.Subscribe),
 			zap.Int("data_count", len(data)))
-	default:
+	} else {
+		// 快照未送达时不保留订阅，避免后续差异无从应用
+		if request.Subscribe {
+			h.liveQueries.Unsubscribe(conn.ID, msg.ID)
+		}
 		h.logger.Error("Failed to send query response")
 	}
 }
 
+// handleQueryUnsubscribe 取消实时查询，消息 ID 为查询 ID
+func (h *Handler) handleQueryUnsubscribe(conn *Connection, msg *Message) {
+	if h.liveQueries == nil || !h.liveQueries.Unsubscribe(conn.ID, msg.ID) {
+		h.sendError(conn, msg.ID, 404, "live query not found")
+		return
+	}
+
+	response := NewMessage(MessageTypeQueryUnsubscribe, map[string]string{
+		"query_id": msg.ID,
+		"status":   "unsubscribed",
+	})
+	response.ID = msg.ID
+	if !h.manager.SendToConnection(conn.ID, response) {
+		h.logger.Error("Failed to send query unsubscribe response")
+	}
+}
+
+// queryErrorStatus 查询错误转为错误码和消息
+func queryErrorStatus(err error) (int, string) {
+	var denied *SubscriptionError
+	if errors.As(err, &denied) {
+		return 403, denied.Message
+	}
+	if errors.Is(err, ErrTooManyLiveQueries) {
+		return 429, err.Error()
+	}
+	if appErr, ok := appErrors.IsAppError(err); ok {
+		if appErr.Details != nil {
+			return appErr.HTTPStatus, fmt.Sprintf("%s: %v", appErr.Message, appErr.Details)
+		}
+		return appErr.HTTPStatus, appErr.Message
+	}
+	return 500, "query failed"
+}
+
 // handleSubmit 处理提交
 func (h *Handler) handleSubmit(conn *Connection, msg *Message) {
 	// 实现提交逻辑（参考 teable-develop 的 ShareDB submit）
@@ -340,12 +534,11 @@ func (h *Handler) handleSubmit(conn *Connection, msg *Message) {
 	response := NewMessage(MessageTypeSubmitResponse, SubmitResponse{})
 	response.ID = msg.ID
 
-	select {
-	case conn.Send <- response:
+	if h.manager.SendToConnection(conn.ID, response) {
 		h.logger.Debug("Submit response sent",
 			zap.String("collection", msg.Collection),
 			zap.String("document", msg.Document))
-	default:
+	} else {
 		h.logger.Error("Failed to send submit response")
 	}
 }
@@ -406,9 +599,7 @@ func (h *Handler) sendError(conn *Connection, msgID string, code int, message st
 	errorMsg := NewErrorMessage(code, message)
 	errorMsg.ID = msgID
 
-	select {
-	case conn.Send <- errorMsg:
-	default:
+	if !h.manager.SendToConnection(conn.ID, errorMsg) {
 		h.logger.Error("Failed to send error message")
 	}
 }
@@ -0,0 +1,558 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/pubsub"
+)
+
+// 实时查询参数
+const (
+	DefaultLiveQueryLimit       = 100
+	MaxLiveQueryLimit           = 500
+	MaxLiveQueriesPerConnection = 20
+	DefaultLiveQueryDelay       = 300 * time.Millisecond  // 记录变更后合并刷新的等待时间
+	DefaultLiveQueryMaxDelay    = 1500 * time.Millisecond // 持续变更时从第一次变更起的最长等待时间
+	liveQueryTimeout            = 30 * time.Second
+
+	// liveQueryChannelPrefix 跨实例通知表变更的 Redis 频道前缀
+	liveQueryChannelPrefix = "livequery:"
+)
+
+// ErrTooManyLiveQueries 单个连接上的实时查询数超过上限
+var ErrTooManyLiveQueries = errors.New("too many live queries on this connection")
+
+// LiveQuerySpec 视图实时查询
+// Filter、Sort 与视图配置的格式相同：Filter 与视图过滤条件叠加，Sort 非空时替代视图排序
+type LiveQuerySpec struct {
+	ViewID string                   `json:"view_id"`
+	Filter map[string]interface{}   `json:"filter,omitempty"`
+	Sort   []map[string]interface{} `json:"sort,omitempty"`
+	Offset int                      `json:"offset"`
+	Limit  int                      `json:"limit"`
+}
+
+// Normalize 规范分页窗口
+func (s *LiveQuerySpec) Normalize() {
+	if s.Offset < 0 {
+		s.Offset = 0
+	}
+	if s.Limit <= 0 {
+		s.Limit = DefaultLiveQueryLimit
+	}
+	if s.Limit > MaxLiveQueryLimit {
+		s.Limit = MaxLiveQueryLimit
+	}
+}
+
+// LiveQueryRequest 查询消息的数据，Subscribe 为 true 时订阅后续变更
+type LiveQueryRequest struct {
+	LiveQuerySpec
+	Subscribe bool `json:"subscribe"`
+}
+
+// LiveRecord 查询结果中的记录
+type LiveRecord struct {
+	ID      string                 `json:"id"`
+	Version int64                  `json:"version"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// LiveQueryResult 查询结果（分页窗口内的记录）
+// DependsOn 为过滤、排序和行级权限范围引用的字段 ID，用于跳过不受影响的刷新；nil 表示未知，任何变更都刷新
+type LiveQueryResult struct {
+	TableID   string       `json:"table_id"`
+	Total     int64        `json:"total"`
+	Records   []LiveRecord `json:"records"`
+	DependsOn []string     `json:"-"`
+}
+
+// LiveQueryChange 表上的记录变更
+// All 为 true（新增、删除记录，视图配置变更或变更字段未知）时刷新表上的全部查询；
+// 否则只刷新分页窗口包含变更记录，或过滤、排序引用了变更字段的查询
+type LiveQueryChange struct {
+	TableID   string   `json:"table_id"`
+	All       bool     `json:"all,omitempty"`
+	RecordIDs []string `json:"record_ids,omitempty"`
+	FieldIDs  []string `json:"field_ids,omitempty"`
+}
+
+// LiveQuerySnapshot 查询响应的附加信息
+type LiveQuerySnapshot struct {
+	QueryID string `json:"query_id,omitempty"`
+	TableID string `json:"table_id"`
+	Total   int64  `json:"total"`
+	Offset  int    `json:"offset"`
+	Limit   int    `json:"limit"`
+	Live    bool   `json:"live"`
+}
+
+// LiveQueryExecutor 按订阅方的权限执行查询，无权访问时返回 *SubscriptionError
+type LiveQueryExecutor interface {
+	ExecuteLiveQuery(ctx context.Context, subscriber *Subscriber, spec *LiveQuerySpec) (*LiveQueryResult, error)
+}
+
+// 结果变更类型
+const (
+	LiveQueryOpInsert = "insert"
+	LiveQueryOpRemove = "remove"
+	LiveQueryOpMove   = "move"
+	LiveQueryOpUpdate = "update"
+)
+
+// LiveQueryOp 结果变更，按顺序应用到上一次的结果即得到新结果
+type LiveQueryOp struct {
+	Type   string      `json:"type"`
+	ID     string      `json:"id"`
+	Index  int         `json:"index"`            // 操作位置，move 为目标位置
+	From   *int        `json:"from,omitempty"`   // move 的原位置
+	Record *LiveRecord `json:"record,omitempty"` // insert、update 的记录
+}
+
+// LiveQueryDiff 推送给订阅方的结果变更
+type LiveQueryDiff struct {
+	QueryID string        `json:"query_id"`
+	Total   int64         `json:"total"`
+	Ops     []LiveQueryOp `json:"ops"`
+}
+
+// DiffLiveQuery 计算从 previous 到 next 的变更
+//  1. 从后往前移除不在新结果中的记录
+//  2. 按新顺序逐位对齐：新记录插入，已有记录移动到目标位置
+//  3. 字段或版本变化的记录推送更新
+//
+// 单条记录后移（如排序值变大）时直接移动该记录，避免其后的记录逐一前移
+func DiffLiveQuery(previous, next []LiveRecord) []LiveQueryOp {
+	ops := make([]LiveQueryOp, 0)
+	nextIndex := make(map[string]int, len(next))
+	for i, record := range next {
+		nextIndex[record.ID] = i
+	}
+
+	working := make([]LiveRecord, 0, len(previous))
+	for i := len(previous) - 1; i >= 0; i-- {
+		if _, ok := nextIndex[previous[i].ID]; !ok {
+			ops = append(ops, LiveQueryOp{Type: LiveQueryOpRemove, ID: previous[i].ID, Index: i})
+		}
+	}
+	for _, record := range previous {
+		if _, ok := nextIndex[record.ID]; ok {
+			working = append(working, record)
+		}
+	}
+
+	for i := 0; i < len(next); i++ {
+		record := next[i]
+		if i < len(working) && working[i].ID != record.ID {
+			if target, ok := nextIndex[working[i].ID]; ok && sinksInto(working, next, i, target) {
+				ops = append(ops, moveOp(working[i].ID, i, target))
+				working = moveRecord(working, i, target)
+			}
+		}
+
+		if i < len(working) && working[i].ID == record.ID {
+			if !sameLiveRecord(working[i], record) {
+				ops = append(ops, LiveQueryOp{Type: LiveQueryOpUpdate, ID: record.ID, Index: i, Record: liveRecordRef(record)})
+			}
+			continue
+		}
+
+		from := indexOfLiveRecord(working, record.ID, i+1)
+		if from < 0 {
+			ops = append(ops, LiveQueryOp{Type: LiveQueryOpInsert, ID: record.ID, Index: i, Record: liveRecordRef(record)})
+			working = append(working[:i], append([]LiveRecord{record}, working[i:]...)...)
+			continue
+		}
+		ops = append(ops, moveOp(record.ID, from, i))
+		working = moveRecord(working, from, i)
+		if !sameLiveRecord(working[i], record) {
+			ops = append(ops, LiveQueryOp{Type: LiveQueryOpUpdate, ID: record.ID, Index: i, Record: liveRecordRef(record)})
+		}
+	}
+	return ops
+}
+
+// sinksInto 把 working[i] 移到 target 后，[i, target] 是否都已就位
+func sinksInto(working, next []LiveRecord, i, target int) bool {
+	if target <= i || target >= len(working) {
+		return false
+	}
+	for k := i; k < target; k++ {
+		if working[k+1].ID != next[k].ID {
+			return false
+		}
+	}
+	return true
+}
+
+func moveOp(id string, from, to int) LiveQueryOp {
+	return LiveQueryOp{Type: LiveQueryOpMove, ID: id, Index: to, From: &from}
+}
+
+// moveRecord 把 from 处的记录移到 to
+func moveRecord(records []LiveRecord, from, to int) []LiveRecord {
+	record := records[from]
+	records = append(records[:from], records[from+1:]...)
+	return append(records[:to], append([]LiveRecord{record}, records[to:]...)...)
+}
+
+func indexOfLiveRecord(records []LiveRecord, id string, start int) int {
+	for i := start; i < len(records); i++ {
+		if records[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameLiveRecord(a, b LiveRecord) bool {
+	return a.Version == b.Version && reflect.DeepEqual(a.Fields, b.Fields)
+}
+
+func liveRecordRef(record LiveRecord) *LiveRecord {
+	return &record
+}
+
+// liveQuery 一个连接上的实时查询
+type liveQuery struct {
+	id         string
+	subscriber *Subscriber
+	spec       LiveQuerySpec
+
+	mu        sync.Mutex // 串行刷新，保证变更按结果顺序推送
+	tableID   string
+	total     int64
+	records   []LiveRecord
+	dependsOn []string
+}
+
+// affectedBy 变更是否可能改变查询结果（调用方持有 query.mu）
+// 窗口外记录的无关字段变化不影响结果集合、顺序和总数
+func (q *liveQuery) affectedBy(change *pendingChange) bool {
+	if change.all || q.dependsOn == nil {
+		return true
+	}
+	for _, record := range q.records {
+		if change.records[record.ID] {
+			return true
+		}
+	}
+	for _, fieldID := range q.dependsOn {
+		if change.fields[fieldID] {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingChange 等待期内合并的表变更
+type pendingChange struct {
+	all     bool
+	records map[string]bool
+	fields  map[string]bool
+	first   time.Time
+	timer   *time.Timer
+}
+
+func (c *pendingChange) merge(change LiveQueryChange) {
+	if change.All {
+		c.all = true
+		return
+	}
+	for _, id := range change.RecordIDs {
+		c.records[id] = true
+	}
+	for _, id := range change.FieldIDs {
+		c.fields[id] = true
+	}
+}
+
+// LiveQueryManager 实时查询管理器
+//
+// 订阅时返回查询结果快照；表上记录变更后按订阅方当前的权限重新执行受影响的查询，
+// 并把与上次结果的差异推送给订阅方。同一张表的连续变更合并为一次刷新：
+// 每次变更重新计时 delay，但从第一次变更起最多等待 maxDelay。
+// 配置 Redis 时变更通知经 Pub/Sub 广播，各实例刷新本地连接上的查询
+type LiveQueryManager struct {
+	manager  *Manager
+	executor LiveQueryExecutor
+	pubsub   *pubsub.RedisPubSub
+	logger   *zap.Logger
+	delay    time.Duration
+	maxDelay time.Duration
+
+	mu      sync.Mutex
+	queries map[string]map[string]*liveQuery // connectionID -> queryID -> query
+	pending map[string]*pendingChange        // 等待刷新的表
+}
+
+// NewLiveQueryManager 创建实时查询管理器
+func NewLiveQueryManager(manager *Manager, executor LiveQueryExecutor, logger *zap.Logger) *LiveQueryManager {
+	return &LiveQueryManager{
+		manager:  manager,
+		executor: executor,
+		logger:   logger,
+		delay:    DefaultLiveQueryDelay,
+		maxDelay: DefaultLiveQueryMaxDelay,
+		queries:  make(map[string]map[string]*liveQuery),
+		pending:  make(map[string]*pendingChange),
+	}
+}
+
+// SetPubSub 启用跨实例变更通知（用于延迟注入）
+func (m *LiveQueryManager) SetPubSub(redisPubSub *pubsub.RedisPubSub) error {
+	if err := redisPubSub.SubscribeToAllUpdates(liveQueryChannelPrefix+"*", m.handleDistributedChange); err != nil {
+		return err
+	}
+	m.pubsub = redisPubSub
+	return nil
+}
+
+// Fetch 执行一次查询，不订阅变更
+func (m *LiveQueryManager) Fetch(ctx context.Context, conn *Connection, spec LiveQuerySpec) (*LiveQueryResult, error) {
+	spec.Normalize()
+	return m.executor.ExecuteLiveQuery(ctx, subscriberOf(conn), &spec)
+}
+
+// Subscribe 执行查询并订阅后续变更，相同 queryID 的查询被替换
+func (m *LiveQueryManager) Subscribe(ctx context.Context, conn *Connection, queryID string, spec LiveQuerySpec) (*LiveQueryResult, error) {
+	m.mu.Lock()
+	_, replacing := m.queries[conn.ID][queryID]
+	if !replacing && len(m.queries[conn.ID]) >= MaxLiveQueriesPerConnection {
+		m.mu.Unlock()
+		return nil, ErrTooManyLiveQueries
+	}
+	m.mu.Unlock()
+
+	spec.Normalize()
+	query := &liveQuery{id: queryID, subscriber: subscriberOf(conn), spec: spec}
+	query.mu.Lock()
+	defer query.mu.Unlock()
+
+	result, err := m.executor.ExecuteLiveQuery(ctx, query.subscriber, &query.spec)
+	if err != nil {
+		return nil, err
+	}
+	query.tableID, query.total, query.records, query.dependsOn = result.TableID, result.Total, result.Records, result.DependsOn
+
+	m.mu.Lock()
+	if m.queries[conn.ID] == nil {
+		m.queries[conn.ID] = make(map[string]*liveQuery)
+	}
+	m.queries[conn.ID][queryID] = query
+	m.mu.Unlock()
+	return result, nil
+}
+
+// Unsubscribe 取消实时查询
+func (m *LiveQueryManager) Unsubscribe(connectionID, queryID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.queries[connectionID][queryID]; !ok {
+		return false
+	}
+	delete(m.queries[connectionID], queryID)
+	if len(m.queries[connectionID]) == 0 {
+		delete(m.queries, connectionID)
+	}
+	return true
+}
+
+// RemoveConnection 连接断开时清理其实时查询
+func (m *LiveQueryManager) RemoveConnection(connectionID string) {
+	m.mu.Lock()
+	delete(m.queries, connectionID)
+	m.mu.Unlock()
+}
+
+// NotifyRecordChanged 表上的记录变更通知（事务提交后调用），刷新表上的全部查询
+func (m *LiveQueryManager) NotifyRecordChanged(tableID string) {
+	m.notify(LiveQueryChange{TableID: tableID, All: true})
+}
+
+// NotifyRecordUpdated 记录更新通知（事务提交后调用），fieldIDs 为值发生变化的字段
+func (m *LiveQueryManager) NotifyRecordUpdated(tableID, recordID string, fieldIDs []string) {
+	m.notify(LiveQueryChange{TableID: tableID, RecordIDs: []string{recordID}, FieldIDs: fieldIDs})
+}
+
+// notify 配置 Redis 时广播给所有实例（包括本实例），发布失败时只刷新本实例
+func (m *LiveQueryManager) notify(change LiveQueryChange) {
+	if change.TableID == "" {
+		return
+	}
+	if m.pubsub != nil {
+		err := m.pubsub.Publish(context.Background(), liveQueryChannelPrefix+change.TableID, "record_changed", change)
+		if err == nil {
+			return
+		}
+		m.logger.Warn("Failed to publish live query change, refreshing locally",
+			zap.String("table_id", change.TableID),
+			zap.Error(err))
+	}
+	m.scheduleRefresh(change)
+}
+
+// handleDistributedChange 处理其他实例（或本实例）广播的表变更
+// 无法解析的消息（如旧版本实例只发送表 ID）按整表变更处理
+func (m *LiveQueryManager) handleDistributedChange(msg *pubsub.Message) error {
+	change := LiveQueryChange{TableID: strings.TrimPrefix(msg.Channel, liveQueryChannelPrefix), All: true}
+	if payload, err := json.Marshal(msg.Data); err == nil {
+		var decoded LiveQueryChange
+		if json.Unmarshal(payload, &decoded) == nil && decoded.TableID == change.TableID {
+			change = decoded
+		}
+	}
+	m.scheduleRefresh(change)
+	return nil
+}
+
+// scheduleRefresh 合并同一张表在等待期内的变更，等待期结束后只刷新一次
+func (m *LiveQueryManager) scheduleRefresh(change LiveQueryChange) {
+	tableID := change.TableID
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.watchesTable(tableID) {
+		return
+	}
+	if pending := m.pending[tableID]; pending != nil {
+		pending.merge(change)
+		if time.Since(pending.first) < m.maxDelay {
+			pending.timer.Reset(m.delay)
+		}
+		return
+	}
+
+	pending := &pendingChange{records: make(map[string]bool), fields: make(map[string]bool), first: time.Now()}
+	pending.merge(change)
+	m.pending[tableID] = pending
+	pending.timer = time.AfterFunc(m.delay, func() {
+		m.mu.Lock()
+		current := m.pending[tableID]
+		if current == pending {
+			delete(m.pending, tableID)
+		}
+		m.mu.Unlock()
+		if current != pending {
+			return // 已由之前的计时刷新
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), liveQueryTimeout)
+		defer cancel()
+		m.refreshTable(ctx, tableID, pending)
+	})
+}
+
+// watchesTable 本实例是否有该表上的实时查询（调用方持有锁）
+func (m *LiveQueryManager) watchesTable(tableID string) bool {
+	for _, queries := range m.queries {
+		for _, query := range queries {
+			if query.tableID == tableID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// refreshTable 重新执行表上受变更影响的实时查询并推送差异
+func (m *LiveQueryManager) refreshTable(ctx context.Context, tableID string, change *pendingChange) {
+	type target struct {
+		connectionID string
+		query        *liveQuery
+	}
+	var targets []target
+	m.mu.Lock()
+	for connectionID, queries := range m.queries {
+		for _, query := range queries {
+			if query.tableID == tableID {
+				targets = append(targets, target{connectionID, query})
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	for _, t := range targets {
+		m.refreshQuery(ctx, t.connectionID, t.query, change)
+	}
+}
+
+func (m *LiveQueryManager) refreshQuery(ctx context.Context, connectionID string, query *liveQuery, change *pendingChange) {
+	query.mu.Lock()
+	defer query.mu.Unlock()
+	if !m.isCurrent(connectionID, query) || !query.affectedBy(change) {
+		return
+	}
+
+	result, err := m.executor.ExecuteLiveQuery(ctx, query.subscriber, &query.spec)
+	if err != nil {
+		var denied *SubscriptionError
+		if !errors.As(err, &denied) {
+			m.logger.Warn("Failed to refresh live query",
+				zap.String("connection_id", connectionID),
+				zap.String("query_id", query.id),
+				zap.Error(err))
+			return
+		}
+		// 已无权访问：取消查询并通知客户端
+		m.removeQuery(connectionID, query)
+		m.manager.SendToConnection(connectionID, liveQueryMessage(MessageTypeQueryUnsubscribe, query.id, map[string]string{
+			"query_id": query.id,
+			"status":   "revoked",
+			"reason":   denied.Message,
+		}))
+		return
+	}
+
+	query.dependsOn = result.DependsOn
+	ops := DiffLiveQuery(query.records, result.Records)
+	if len(ops) == 0 && result.Total == query.total {
+		return
+	}
+	query.total, query.records = result.Total, result.Records
+	diff := &LiveQueryDiff{QueryID: query.id, Total: result.Total, Ops: ops}
+	if !m.manager.SendToConnection(connectionID, liveQueryMessage(MessageTypeQueryDiff, query.id, diff)) {
+		// 丢失一次差异后客户端结果已不可信，取消查询并尽力通知客户端重新查询
+		m.logger.Warn("Live query diff dropped, cancelling query",
+			zap.String("connection_id", connectionID),
+			zap.String("query_id", query.id))
+		m.removeQuery(connectionID, query)
+		m.manager.SendToConnection(connectionID, liveQueryMessage(MessageTypeQueryUnsubscribe, query.id, map[string]string{
+			"query_id": query.id,
+			"status":   "dropped",
+			"reason":   "client is not keeping up, please query again",
+		}))
+	}
+}
+
+// isCurrent 查询未被取消或替换
+func (m *LiveQueryManager) isCurrent(connectionID string, query *liveQuery) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.queries[connectionID][query.id] == query
+}
+
+func (m *LiveQueryManager) removeQuery(connectionID string, query *liveQuery) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.queries[connectionID][query.id] == query {
+		delete(m.queries[connectionID], query.id)
+	}
+}
+
+func liveQueryMessage(msgType MessageType, queryID string, data interface{}) *Message {
+	message := NewMessage(msgType, data)
+	message.ID = queryID
+	return message
+}
+
+func subscriberOf(conn *Connection) *Subscriber {
+	return &Subscriber{ConnectionID: conn.ID, UserID: conn.UserID, ShareID: conn.ShareID}
+}
@@ -0,0 +1,314 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func liveRecords(ids ...string) []LiveRecord {
+	records := make([]LiveRecord, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, LiveRecord{ID: id, Version: 1, Fields: map[string]interface{}{"name": id}})
+	}
+	return records
+}
+
+// applyLiveQueryOps 按客户端的方式应用变更，并校验每个操作的位置与记录一致
+func applyLiveQueryOps(t *testing.T, records []LiveRecord, ops []LiveQueryOp) []LiveRecord {
+	result := append([]LiveRecord{}, records...)
+	for _, op := range ops {
+		switch op.Type {
+		case LiveQueryOpRemove:
+			require.Equal(t, op.ID, result[op.Index].ID, "remove %+v", op)
+			result = append(result[:op.Index], result[op.Index+1:]...)
+		case LiveQueryOpInsert:
+			result = append(result[:op.Index], append([]LiveRecord{*op.Record}, result[op.Index:]...)...)
+		case LiveQueryOpMove:
+			require.NotNil(t, op.From)
+			require.Equal(t, op.ID, result[*op.From].ID, "move %+v", op)
+			result = moveRecord(result, *op.From, op.Index)
+		case LiveQueryOpUpdate:
+			require.Equal(t, op.ID, result[op.Index].ID, "update %+v", op)
+			result[op.Index] = *op.Record
+		default:
+			t.Fatalf("unknown op %q", op.Type)
+		}
+	}
+	return result
+}
+
+func TestDiffLiveQuery(t *testing.T) {
+	changed := liveRecords("a", "b", "c")
+	changed[1].Version, changed[1].Fields = 2, map[string]interface{}{"name": "B"}
+
+	cases := []struct {
+		name     string
+		previous []LiveRecord
+		next     []LiveRecord
+		ops      []string
+	}{
+		{"unchanged", liveRecords("a", "b", "c"), liveRecords("a", "b", "c"), []string{}},
+		{"insert", liveRecords("a", "c"), liveRecords("a", "b", "c"), []string{"insert"}},
+		{"remove", liveRecords("a", "b", "c"), liveRecords("a", "c"), []string{"remove"}},
+		{"move up", liveRecords("a", "b", "c", "d"), liveRecords("d", "a", "b", "c"), []string{"move"}},
+		{"move down", liveRecords("a", "b", "c", "d"), liveRecords("b", "c", "d", "a"), []string{"move"}},
+		{"update", liveRecords("a", "b", "c"), changed, []string{"update"}},
+		{"window shift", liveRecords("a", "b", "c"), liveRecords("b", "c", "d"), []string{"remove", "insert"}},
+		{"empty", nil, liveRecords("a"), []string{"insert"}},
+		{"cleared", liveRecords("a", "b"), nil, []string{"remove", "remove"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ops := DiffLiveQuery(tc.previous, tc.next)
+			types := make([]string, 0, len(ops))
+			for _, op := range ops {
+				types = append(types, op.Type)
+			}
+			assert.Equal(t, tc.ops, types)
+			assert.Equal(t, append([]LiveRecord{}, tc.next...), applyLiveQueryOps(t, tc.previous, ops))
+		})
+	}
+}
+
+func TestDiffLiveQuery_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	pool := make([]string, 30)
+	for i := range pool {
+		pool[i] = fmt.Sprintf("rec_%02d", i)
+	}
+	sample := func() []LiveRecord {
+		ids := append([]string{}, pool...)
+		rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		records := liveRecords(ids[:rng.Intn(len(ids))]...)
+		for i := range records {
+			if rng.Intn(4) == 0 {
+				records[i].Version = 2
+			}
+		}
+		return records
+	}
+
+	for i := 0; i < 200; i++ {
+		previous, next := sample(), sample()
+		ops := DiffLiveQuery(previous, next)
+		assert.Equal(t, append([]LiveRecord{}, next...), applyLiveQueryOps(t, previous, ops))
+	}
+}
+
+// fakeLiveQueryExecutor 按 ID 排序返回表中记录，denied 中的用户无权查询
+type fakeLiveQueryExecutor struct {
+	mu        sync.Mutex
+	records   map[string][]LiveRecord // viewID -> records
+	denied    map[string]bool
+	dependsOn []string
+	calls     int
+}
+
+func (e *fakeLiveQueryExecutor) ExecuteLiveQuery(ctx context.Context, subscriber *Subscriber, spec *LiveQuerySpec) (*LiveQueryResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls++
+	if e.denied[subscriber.UserID] {
+		return nil, &SubscriptionError{Message: "access denied to view " + spec.ViewID}
+	}
+	records := e.records[spec.ViewID]
+	window := records[min(spec.Offset, len(records)):min(spec.Offset+spec.Limit, len(records))]
+	return &LiveQueryResult{TableID: "tbl_1", Total: int64(len(records)), Records: append([]LiveRecord{}, window...), DependsOn: e.dependsOn}, nil
+}
+
+func (e *fakeLiveQueryExecutor) callCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+func (e *fakeLiveQueryExecutor) set(viewID string, records []LiveRecord) {
+	e.mu.Lock()
+	e.records[viewID] = records
+	e.mu.Unlock()
+}
+
+var allChanged = &pendingChange{all: true}
+
+func changeOf(recordID string, fieldIDs ...string) *pendingChange {
+	change := &pendingChange{records: map[string]bool{}, fields: map[string]bool{}}
+	change.merge(LiveQueryChange{RecordIDs: []string{recordID}, FieldIDs: fieldIDs})
+	return change
+}
+
+func newLiveQueryFixture() (*LiveQueryManager, *fakeLiveQueryExecutor, *Connection) {
+	manager := NewManager(zap.NewNop())
+	executor := &fakeLiveQueryExecutor{
+		records: map[string][]LiveRecord{"viw_1": liveRecords("a", "b", "c")},
+		denied:  map[string]bool{},
+	}
+	conn := newTestConnection(manager, "conn_a", "usr_a", "")
+	return NewLiveQueryManager(manager, executor, zap.NewNop()), executor, conn
+}
+
+func TestLiveQueryManager_SubscribeAndRefresh(t *testing.T) {
+	live, executor, conn := newLiveQueryFixture()
+	ctx := context.Background()
+
+	result, err := live.Subscribe(ctx, conn, "q1", LiveQuerySpec{ViewID: "viw_1", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, liveRecords("a", "b"), result.Records)
+
+	// 无变化时不推送
+	live.refreshTable(ctx, "tbl_1", allChanged)
+	assert.Empty(t, conn.Send)
+
+	// 窗口外的新记录只改变总数；窗口内的删除推送差异
+	executor.set("viw_1", liveRecords("b", "c", "d", "e"))
+	live.refreshTable(ctx, "tbl_1", allChanged)
+	require.Len(t, conn.Send, 1)
+	message := <-conn.Send
+	assert.Equal(t, MessageTypeQueryDiff, message.Type)
+	assert.Equal(t, "q1", message.ID)
+	diff := message.Data.(*LiveQueryDiff)
+	assert.Equal(t, int64(4), diff.Total)
+	assert.Equal(t, liveRecords("b", "c"), applyLiveQueryOps(t, liveRecords("a", "b"), diff.Ops))
+
+	// 其他表的变更不影响
+	live.refreshTable(ctx, "tbl_other", allChanged)
+	assert.Empty(t, conn.Send)
+
+	// 取消后不再刷新
+	assert.True(t, live.Unsubscribe(conn.ID, "q1"))
+	assert.False(t, live.Unsubscribe(conn.ID, "q1"))
+	executor.set("viw_1", liveRecords("z"))
+	live.refreshTable(ctx, "tbl_1", allChanged)
+	assert.Empty(t, conn.Send)
+}
+
+func TestLiveQueryManager_RevokedOnRefresh(t *testing.T) {
+	live, executor, conn := newLiveQueryFixture()
+	ctx := context.Background()
+	_, err := live.Subscribe(ctx, conn, "q1", LiveQuerySpec{ViewID: "viw_1"})
+	require.NoError(t, err)
+
+	executor.denied["usr_a"] = true
+	live.refreshTable(ctx, "tbl_1", allChanged)
+	require.Len(t, conn.Send, 1)
+	message := <-conn.Send
+	assert.Equal(t, MessageTypeQueryUnsubscribe, message.Type)
+	assert.Equal(t, "revoked", message.Data.(map[string]string)["status"])
+	assert.False(t, live.Unsubscribe(conn.ID, "q1"))
+
+	_, err = live.Subscribe(ctx, conn, "q2", LiveQuerySpec{ViewID: "viw_1"})
+	assert.IsType(t, &SubscriptionError{}, err)
+}
+
+func TestLiveQueryManager_Limits(t *testing.T) {
+	live, _, conn := newLiveQueryFixture()
+	ctx := context.Background()
+
+	for i := 0; i < MaxLiveQueriesPerConnection; i++ {
+		_, err := live.Subscribe(ctx, conn, fmt.Sprintf("q%d", i), LiveQuerySpec{ViewID: "viw_1"})
+		require.NoError(t, err)
+	}
+	_, err := live.Subscribe(ctx, conn, "one_more", LiveQuerySpec{ViewID: "viw_1"})
+	assert.ErrorIs(t, err, ErrTooManyLiveQueries)
+	// 替换已有查询不受上限限制
+	_, err = live.Subscribe(ctx, conn, "q0", LiveQuerySpec{ViewID: "viw_1", Offset: 1})
+	assert.NoError(t, err)
+
+	live.RemoveConnection(conn.ID)
+	assert.Empty(t, live.queries)
+
+	spec := LiveQuerySpec{Offset: -5, Limit: MaxLiveQueryLimit + 1}
+	spec.Normalize()
+	assert.Equal(t, LiveQuerySpec{Offset: 0, Limit: MaxLiveQueryLimit}, spec)
+}
+
+func TestLiveQueryManager_NotifyDebounces(t *testing.T) {
+	live, executor, conn := newLiveQueryFixture()
+	live.delay = 20 * time.Millisecond
+	ctx := context.Background()
+	_, err := live.Subscribe(ctx, conn, "q1", LiveQuerySpec{ViewID: "viw_1"})
+	require.NoError(t, err)
+
+	// 没有实时查询的表不安排刷新
+	live.NotifyRecordChanged("tbl_other")
+	assert.Empty(t, live.pending)
+
+	executor.set("viw_1", liveRecords("a", "b", "c", "d"))
+	for i := 0; i < 5; i++ {
+		live.NotifyRecordChanged("tbl_1")
+	}
+	require.Eventually(t, func() bool { return len(conn.Send) == 1 }, time.Second, 5*time.Millisecond)
+
+	executor.mu.Lock()
+	defer executor.mu.Unlock()
+	assert.Equal(t, 2, executor.calls, "subscribe plus one merged refresh")
+}
+
+func TestLiveQueryManager_SkipsUnaffectedQueries(t *testing.T) {
+	live, executor, conn := newLiveQueryFixture()
+	executor.dependsOn = []string{"fld_status"}
+	ctx := context.Background()
+	_, err := live.Subscribe(ctx, conn, "q1", LiveQuerySpec{ViewID: "viw_1", Limit: 2})
+	require.NoError(t, err)
+
+	// 窗口外记录的无关字段变化：不重新查询
+	live.refreshTable(ctx, "tbl_1", changeOf("c", "fld_note"))
+	assert.Equal(t, 1, executor.callCount())
+
+	// 过滤/排序字段变化、窗口内记录变化、整表变更：重新查询
+	live.refreshTable(ctx, "tbl_1", changeOf("c", "fld_status"))
+	assert.Equal(t, 2, executor.callCount())
+	live.refreshTable(ctx, "tbl_1", changeOf("a", "fld_note"))
+	assert.Equal(t, 3, executor.callCount())
+	live.refreshTable(ctx, "tbl_1", allChanged)
+	assert.Equal(t, 4, executor.callCount())
+
+	// 依赖未知的查询总是刷新
+	executor.dependsOn = nil
+	_, err = live.Subscribe(ctx, conn, "q1", LiveQuerySpec{ViewID: "viw_1", Limit: 2})
+	require.NoError(t, err)
+	live.refreshTable(ctx, "tbl_1", changeOf("c", "fld_note"))
+	assert.Equal(t, 6, executor.callCount())
+	assert.Empty(t, conn.Send)
+}
+
+func TestLiveQueryManager_CoalescesBursts(t *testing.T) {
+	live, executor, conn := newLiveQueryFixture()
+	executor.dependsOn = []string{"fld_status"}
+	live.delay = 30 * time.Millisecond
+	live.maxDelay = time.Hour
+	ctx := context.Background()
+	_, err := live.Subscribe(ctx, conn, "q1", LiveQuerySpec{ViewID: "viw_1", Limit: 2})
+	require.NoError(t, err)
+
+	// 等待期内的变更合并：只要有一次变更影响查询就刷新一次
+	live.NotifyRecordUpdated("tbl_1", "c", []string{"fld_note"})
+	live.NotifyRecordUpdated("tbl_1", "c", []string{"fld_status"})
+	live.NotifyRecordUpdated("tbl_1", "c", []string{"fld_note"})
+	require.Eventually(t, func() bool { return executor.callCount() == 2 }, time.Second, 5*time.Millisecond)
+
+	// 只有无关变更时不刷新
+	live.NotifyRecordUpdated("tbl_1", "c", []string{"fld_note"})
+	time.Sleep(4 * live.delay)
+	assert.Equal(t, 2, executor.callCount())
+	live.mu.Lock()
+	assert.Empty(t, live.pending)
+	// 持续变更时每次变更重新计时，超过最长等待时间后不再推迟
+	live.maxDelay = 3 * live.delay
+	live.mu.Unlock()
+	deadline := time.Now().Add(15 * live.delay)
+	for time.Now().Before(deadline) {
+		live.NotifyRecordChanged("tbl_1")
+		time.Sleep(live.delay / 3)
+	}
+	assert.GreaterOrEqual(t, executor.callCount(), 4, "bursts are refreshed at least every maxDelay")
+	assert.Empty(t, conn.Send)
+}
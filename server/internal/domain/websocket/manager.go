@@ -2,9 +2,12 @@ package websocket
 
 import (
 	"context"
+	"hash/fnv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 )
@@ -13,7 +16,10 @@ import (
 type Connection struct {
 	ID            string
 	UserID        string
+	ShareID       string // 通过分享链接建立的匿名连接
 	SessionID     string
+	RemoteAddr    string
+	UserAgent     string
 	Conn          *websocket.Conn
 	Send          chan *Message
 	Manager       *Manager
@@ -21,6 +27,17 @@ type Connection struct {
 	LastPing      time.Time
 	mu            sync.RWMutex
 	channels      map[string]bool // 订阅的频道列表（兼容Broadcaster）
+	closeReason   string          // 服务端主动断开的原因，随关闭帧发给客户端
+}
+
+// closeMessage 关闭帧内容：慢消费者等服务端主动断开时告知客户端稍后重连并恢复
+func (c *Connection) closeMessage() []byte {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closeReason == "" {
+		return []byte{}
+	}
+	return websocket.FormatCloseMessage(websocket.CloseTryAgainLater, c.closeReason)
 }
 
 // GetSubscribedChannels 获取订阅的频道列表
@@ -60,16 +77,29 @@ func (c *Connection) Unsubscribe(channel string) {
 	delete(c.channels, channel)
 }
 
+// 频道投递分片
+const (
+	channelShardCount  = 16  // 分片协程数
+	channelShardBuffer = 256 // 每个分片排队的任务数
+)
+
 // Manager WebSocket连接管理器
+//
+// 主循环只处理连接注册、注销和心跳；频道广播与恢复订阅按频道分片，
+// 由分片协程执行：同一频道的编号、投递和补发串行，不同频道互不阻塞
 type Manager struct {
 	connections map[string]*Connection // 连接ID -> 连接
 	userConns   map[string][]string    // 用户ID -> 连接ID列表
 	channels    map[string][]string    // 频道名 -> 连接ID列表
 	register    chan *Connection
 	unregister  chan *Connection
-	broadcast   chan *BroadcastMessage
+	shards      []chan *channelTask
+	instanceID  string      // 本实例标识，序号只在本实例内有效
+	replay      ReplayStore // ✨ 频道序号与回放缓冲
 	mu          sync.RWMutex
 	logger      *zap.Logger
+
+	slowConsumers int64 // 因发送缓冲区满被断开的连接数
 }
 
 // BroadcastMessage 广播消息
@@ -77,23 +107,72 @@ type BroadcastMessage struct {
 	Channel string
 	Message *Message
 	Exclude []string // 排除的连接ID
+	Only    []string // 仅发送给这些连接ID（nil 表示不限制）
+
+	// 按用户筛选的广播在回放时沿用广播时的判定
+	AllowedUsers []string
+	DeniedUsers  []string
+}
+
+// ResumeResult 订阅或恢复单个频道的结果
+type ResumeResult struct {
+	Channel  string `json:"channel"`
+	Status   string `json:"status"`
+	Seq      uint64 `json:"seq,omitempty"` // 频道当前序号，之后的消息序号都大于它
+	Replayed int    `json:"replayed,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// channelTask 频道分片协程执行的任务：广播或恢复订阅
+type channelTask struct {
+	broadcast *BroadcastMessage
+	resume    *resumeRequest
+}
+
+// resumeRequest 在频道分片中执行的订阅请求，与该频道的广播串行，补发与实时消息不会错序或遗漏
+type resumeRequest struct {
+	conn    *Connection
+	channel string
+	after   uint64 // 客户端最后收到的序号，0 表示新订阅
+	result  chan *ResumeResult
 }
 
 // NewManager 创建新的连接管理器
 func NewManager(logger *zap.Logger) *Manager {
+	shards := make([]chan *channelTask, channelShardCount)
+	for i := range shards {
+		shards[i] = make(chan *channelTask, channelShardBuffer)
+	}
 	return &Manager{
 		connections: make(map[string]*Connection),
 		userConns:   make(map[string][]string),
 		channels:    make(map[string][]string),
 		register:    make(chan *Connection),
 		unregister:  make(chan *Connection),
-		broadcast:   make(chan *BroadcastMessage),
+		shards:      shards,
+		instanceID:  uuid.NewString(),
 		logger:      logger,
 	}
 }
 
+// SetReplayStore 设置回放缓冲（用于延迟注入），需在 Run 之前调用
+// 未设置时消息不编号，恢复请求一律要求重新同步。
+// 消息只投递给本实例的连接，序号和缓冲也只属于本实例，不能在多个实例间共享
+func (m *Manager) SetReplayStore(store ReplayStore) {
+	m.replay = store
+}
+
+// InstanceID 本实例标识，客户端恢复时携带，连到其他实例的恢复请求需要重新同步
+func (m *Manager) InstanceID() string {
+	return m.instanceID
+}
+
 // Run 启动管理器
 func (m *Manager) Run(ctx context.Context) {
+	for _, shard := range m.shards {
+		go m.runShard(ctx, shard)
+	}
+
 	ticker := time.NewTicker(30 * time.Second) // 心跳检查间隔
 	defer ticker.Stop()
 
@@ -105,14 +184,39 @@ func (m *Manager) Run(ctx context.Context) {
 			m.registerConnection(conn)
 		case conn := <-m.unregister:
 			m.unregisterConnection(conn)
-		case broadcast := <-m.broadcast:
-			m.broadcastToChannel(broadcast)
 		case <-ticker.C:
 			m.checkHeartbeat()
 		}
 	}
 }
 
+// runShard 依次执行分片中的频道任务
+func (m *Manager) runShard(ctx context.Context, tasks chan *channelTask) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-tasks:
+			if task.resume != nil {
+				task.resume.result <- m.subscribeFrom(task.resume)
+				continue
+			}
+			m.broadcastToChannel(task.broadcast)
+		}
+	}
+}
+
+// enqueue 把频道任务交给频道所在的分片，分片队列已满时等待
+func (m *Manager) enqueue(channel string, task *channelTask) {
+	m.shards[m.shardOf(channel)] <- task
+}
+
+func (m *Manager) shardOf(channel string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(channel))
+	return int(hash.Sum32() % uint32(len(m.shards)))
+}
+
 // registerConnection 注册连接
 func (m *Manager) registerConnection(conn *Connection) {
 	m.mu.Lock()
@@ -129,10 +233,15 @@ func (m *Manager) registerConnection(conn *Connection) {
 }
 
 // unregisterConnection 注销连接
+// 心跳超时、慢消费者和读协程退出都可能注销同一连接，重复注销直接返回
 func (m *Manager) unregisterConnection(conn *Connection) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if current, exists := m.connections[conn.ID]; !exists || current != conn {
+		return
+	}
+
 	// 从连接映射中删除
 	delete(m.connections, conn.ID)
 
@@ -150,9 +259,11 @@ func (m *Manager) unregisterConnection(conn *Connection) {
 	}
 
 	// 从所有频道中删除
+	conn.mu.RLock()
 	for channel := range conn.Subscriptions {
 		m.removeFromChannel(channel, conn.ID)
 	}
+	conn.mu.RUnlock()
 
 	// 关闭连接
 	close(conn.Send)
@@ -164,12 +275,15 @@ func (m *Manager) unregisterConnection(conn *Connection) {
 }
 
 // broadcastToChannel 向频道广播消息
+// 可订阅频道的消息先编号并写入回放缓冲（即使当前没有订阅者，重连的客户端仍需补发）
 func (m *Manager) broadcastToChannel(broadcast *BroadcastMessage) {
+	message := m.sequence(broadcast)
+
 	m.mu.RLock()
-	connIDs, exists := m.channels[broadcast.Channel]
+	connIDs := append([]string(nil), m.channels[broadcast.Channel]...)
 	m.mu.RUnlock()
 
-	if !exists {
+	if len(connIDs) == 0 {
 		return
 	}
 
@@ -178,24 +292,189 @@ func (m *Manager) broadcastToChannel(broadcast *BroadcastMessage) {
 		excludeMap[id] = true
 	}
 
+	var onlyMap map[string]bool
+	if broadcast.Only != nil {
+		onlyMap = make(map[string]bool, len(broadcast.Only))
+		for _, id := range broadcast.Only {
+			onlyMap[id] = true
+		}
+	}
+
 	for _, connID := range connIDs {
 		if excludeMap[connID] {
 			continue
 		}
+		if onlyMap != nil && !onlyMap[connID] {
+			continue
+		}
+
+		m.deliver(connID, message, broadcast.Channel)
+	}
+}
+
+// sequence 为可订阅频道的消息分配序号并写入回放缓冲
+// 同一消息可能发往多个频道，每个频道编号的是消息副本；
+// 排除了发送方的消息（协作回显）不编号，以免重连后回放给发送方造成重复应用
+func (m *Manager) sequence(broadcast *BroadcastMessage) *Message {
+	if m.replay == nil || len(broadcast.Exclude) > 0 {
+		return broadcast.Message
+	}
+	if _, err := ParseChannelScope(broadcast.Channel); err != nil {
+		return broadcast.Message
+	}
 
-		m.mu.RLock()
-		conn, exists := m.connections[connID]
+	message := *broadcast.Message
+	message.Channel = broadcast.Channel
+	entry := &ReplayEntry{Message: &message}
+	if broadcast.Only != nil {
+		entry.Restricted = true
+		entry.Allowed = broadcast.AllowedUsers
+		entry.Denied = broadcast.DeniedUsers
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), replayTimeout)
+	defer cancel()
+	if _, err := m.replay.Append(ctx, broadcast.Channel, entry); err != nil {
+		// 未编号的消息照常投递，断线的客户端恢复时会因序号缺口而重新同步
+		m.logger.Error("Failed to append message to replay buffer",
+			zap.String("channel", broadcast.Channel),
+			zap.Error(err),
+		)
+	}
+	return &message
+}
+
+// deliver 向在线连接投递消息，发送缓冲区已满时按慢消费者断开
+// 持有读锁期间连接不会被注销，避免向已关闭的通道发送
+func (m *Manager) deliver(connID string, message *Message, channel string) bool {
+	m.mu.RLock()
+	conn, exists := m.connections[connID]
+	if !exists {
 		m.mu.RUnlock()
+		return false
+	}
+	select {
+	case conn.Send <- message:
+		m.mu.RUnlock()
+		return true
+	default:
+		m.mu.RUnlock()
+		m.dropSlowConsumer(conn, channel)
+		return false
+	}
+}
 
-		if exists {
-			select {
-			case conn.Send <- broadcast.Message:
-			default:
-				// 发送失败，关闭连接
-				m.unregister <- conn
-			}
+// dropSlowConsumer 断开发送缓冲区已满的连接
+// 丢弃单条消息会让客户端静默错过更新；断开后客户端重连并按序号恢复，落后太多时重新同步
+func (m *Manager) dropSlowConsumer(conn *Connection, channel string) {
+	atomic.AddInt64(&m.slowConsumers, 1)
+	m.logger.Warn("WebSocket slow consumer disconnected",
+		zap.String("connection_id", conn.ID),
+		zap.String("user_id", conn.UserID),
+		zap.String("channel", channel),
+		zap.Int("buffered", len(conn.Send)),
+	)
+
+	conn.mu.Lock()
+	conn.closeReason = "slow consumer"
+	conn.mu.Unlock()
+	m.unregisterConnection(conn)
+}
+
+// SubscribeFrom 订阅频道并补发 after 之后错过的消息
+// after 为 0 表示新订阅；在频道分片中执行，管理器未运行时会阻塞
+func (m *Manager) SubscribeFrom(conn *Connection, channel string, after uint64) *ResumeResult {
+	if m.replay == nil {
+		m.Subscribe(conn.ID, channel)
+		result := &ResumeResult{Channel: channel, Status: ResumeStatusSubscribed}
+		if after > 0 {
+			result.Status = ResumeStatusResync
+			result.Reason = "message replay is not enabled"
 		}
+		return result
+	}
+
+	request := &resumeRequest{
+		conn:    conn,
+		channel: channel,
+		after:   after,
+		result:  make(chan *ResumeResult, 1),
 	}
+	m.enqueue(channel, &channelTask{resume: request})
+	return <-request.result
+}
+
+// subscribeFrom 在频道分片中订阅并补发，期间该频道不会有新的广播
+func (m *Manager) subscribeFrom(request *resumeRequest) *ResumeResult {
+	conn, channel := request.conn, request.channel
+	m.Subscribe(conn.ID, channel)
+	result := &ResumeResult{Channel: channel, Status: ResumeStatusSubscribed}
+	if _, err := ParseChannelScope(channel); err != nil {
+		// 不编号的频道无法恢复
+		if request.after > 0 {
+			result.Status = ResumeStatusResync
+			result.Reason = "channel is not sequenced"
+		}
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), replayTimeout)
+	defer cancel()
+
+	if request.after == 0 {
+		head, err := m.replay.Head(ctx, channel)
+		if err != nil {
+			m.logger.Error("Failed to read replay head",
+				zap.String("channel", channel),
+				zap.Error(err),
+			)
+		}
+		result.Seq = head
+		return result
+	}
+
+	entries, head, complete, err := m.replay.Since(ctx, channel, request.after)
+	result.Seq = head
+	result.Status = ResumeStatusResync
+	if err != nil {
+		m.logger.Error("Failed to read replay buffer",
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+		result.Reason = "replay buffer unavailable"
+		return result
+	}
+	if !complete {
+		result.Reason = "too far behind"
+		return result
+	}
+
+	messages := make([]*Message, 0, len(entries))
+	for _, entry := range entries {
+		visible, decided := entry.visibility(conn.UserID)
+		if !decided {
+			// 广播时不在线，无法确定行级权限下能否看到
+			result.Reason = "missed messages require permission check"
+			return result
+		}
+		if visible {
+			messages = append(messages, entry.Message)
+		}
+	}
+	if len(messages) > cap(conn.Send)-len(conn.Send) {
+		result.Reason = "too far behind"
+		return result
+	}
+
+	for _, message := range messages {
+		if !m.deliver(conn.ID, message, channel) {
+			result.Reason = "connection closed during replay"
+			return result
+		}
+	}
+	result.Status = ResumeStatusReplayed
+	result.Replayed = len(messages)
+	return result
 }
 
 // Subscribe 订阅频道
@@ -277,7 +556,7 @@ func (m *Manager) checkHeartbeat() {
 				zap.String("connection_id", conn.ID),
 				zap.String("user_id", conn.UserID),
 			)
-			m.unregister <- conn
+			m.unregisterConnection(conn)
 		}
 	}
 }
@@ -290,6 +569,36 @@ func (m *Manager) GetConnection(connID string) (*Connection, bool) {
 	return conn, exists
 }
 
+// Connections 获取所有连接的快照
+func (m *Manager) Connections() []*Connection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	connections := make([]*Connection, 0, len(m.connections))
+	for _, conn := range m.connections {
+		connections = append(connections, conn)
+	}
+	return connections
+}
+
+// SendToConnection 向仍在线的连接发送消息，缓冲区已满时丢弃
+// 持有读锁期间连接不会被注销，避免向已关闭的通道发送
+func (m *Manager) SendToConnection(connID string, message *Message) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	conn, exists := m.connections[connID]
+	if !exists {
+		return false
+	}
+	select {
+	case conn.Send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetUserConnections 获取用户的所有连接
 func (m *Manager) GetUserConnections(userID string) []*Connection {
 	m.mu.RLock()
@@ -312,11 +621,56 @@ func (m *Manager) GetUserConnections(userID string) []*Connection {
 
 // BroadcastToChannel 向频道广播消息
 func (m *Manager) BroadcastToChannel(channel string, message *Message, exclude ...string) {
-	m.broadcast <- &BroadcastMessage{
+	m.enqueue(channel, &channelTask{broadcast: &BroadcastMessage{
 		Channel: channel,
 		Message: message,
 		Exclude: exclude,
+	}})
+}
+
+// BroadcastToChannelWhere 向频道中满足条件的用户广播消息
+// allow 按用户求值且每个用户只求值一次；求值在调用方协程中进行，不阻塞频道分片
+func (m *Manager) BroadcastToChannelWhere(channel string, message *Message, allow func(userID string) bool) {
+	m.mu.RLock()
+	connUsers := make(map[string]string, len(m.channels[channel]))
+	for _, connID := range m.channels[channel] {
+		if conn, exists := m.connections[connID]; exists {
+			connUsers[connID] = conn.UserID
+		}
+	}
+	m.mu.RUnlock()
+
+	decisions := make(map[string]bool)
+	only := make([]string, 0, len(connUsers))
+	var allowedUsers, deniedUsers []string
+	for connID, userID := range connUsers {
+		allowed, decided := decisions[userID]
+		if !decided {
+			allowed = allow(userID)
+			decisions[userID] = allowed
+			if allowed {
+				allowedUsers = append(allowedUsers, userID)
+			} else {
+				deniedUsers = append(deniedUsers, userID)
+			}
+		}
+		if allowed {
+			only = append(only, connID)
+		}
+	}
+
+	// 启用回放时即使当前无人可见也要编号入缓冲
+	if len(only) == 0 && m.replay == nil {
+		return
 	}
+
+	m.enqueue(channel, &channelTask{broadcast: &BroadcastMessage{
+		Channel:      channel,
+		Message:      message,
+		Only:         only,
+		AllowedUsers: allowedUsers,
+		DeniedUsers:  deniedUsers,
+	}})
 }
 
 // GetClient 获取连接（用于Broadcaster）
@@ -340,12 +694,7 @@ func (m *Manager) RemoveChannelSubscriber(channel, clientID string) {
 func (m *Manager) BroadcastToUser(userID string, message *Message) {
 	connections := m.GetUserConnections(userID)
 	for _, conn := range connections {
-		select {
-		case conn.Send <- message:
-		default:
-			// 发送失败，关闭连接
-			m.unregister <- conn
-		}
+		m.deliver(conn.ID, message, "")
 	}
 }
 
@@ -358,5 +707,6 @@ func (m *Manager) GetStats() map[string]interface{} {
 		"total_connections": len(m.connections),
 		"total_users":       len(m.userConns),
 		"total_channels":    len(m.channels),
+		"slow_consumers":    atomic.LoadInt64(&m.slowConsumers),
 	}
 }
@@ -24,9 +24,13 @@ const (
 	OperationTypeTableUpdate OperationType = "table_update"
 
 	// 视图操作
-	OperationTypeViewCreate OperationType = "view_create"
-	OperationTypeViewUpdate OperationType = "view_update"
-	OperationTypeViewDelete OperationType = "view_delete"
+	OperationTypeViewCreate     OperationType = "view_create"
+	OperationTypeViewUpdate     OperationType = "view_update"
+	OperationTypeViewDelete     OperationType = "view_delete"
+	OperationTypeViewStatistics OperationType = "view_statistics" // 视图列统计更新
+
+	// 按钮字段操作
+	OperationTypeButtonClick OperationType = "button_click" // 按钮点击执行结果
 
 	// 批量操作
 	OperationTypeBatchUpdate OperationType = "batch_update"
@@ -126,6 +130,25 @@ type ViewDeleteOp struct {
 	ViewID string `json:"view_id"`
 }
 
+// ViewStatisticsOp 视图列统计更新操作
+// Stale 为 true 时不携带统计值，客户端需通过统计接口重新获取
+type ViewStatisticsOp struct {
+	ViewID     string      `json:"view_id"`
+	Stale      bool        `json:"stale,omitempty"`
+	Statistics interface{} `json:"statistics,omitempty"`
+}
+
+// ButtonClickOp 按钮点击执行结果
+type ButtonClickOp struct {
+	FieldID   string      `json:"field_id"`
+	RecordID  string      `json:"record_id"`
+	Action    string      `json:"action"`
+	Status    string      `json:"status"` // success / failed
+	Result    interface{} `json:"result,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	ClickedBy string      `json:"clicked_by"`
+}
+
 // NewOperation 创建操作消息
 func NewOperation(opType OperationType, tableID string, data interface{}) *Operation {
 	return &Operation{
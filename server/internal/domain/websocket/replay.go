@@ -0,0 +1,196 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 可靠投递参数
+const (
+	DefaultReplayCapacity = 1000             // 每个频道保留的消息数
+	DefaultReplayTTL      = 10 * time.Minute // 频道空闲多久后丢弃序号和缓冲
+	MaxResumeChannels     = 100              // 单次恢复的频道数上限
+	replayTimeout         = time.Second
+)
+
+// 恢复结果状态
+const (
+	ResumeStatusSubscribed = "subscribed" // 新订阅，从当前序号开始接收
+	ResumeStatusReplayed   = "replayed"   // 已补发断线期间的消息
+	ResumeStatusResync     = "resync"     // 落后太多，需要重新加载数据
+	ResumeStatusDenied     = "denied"     // 无权订阅
+)
+
+// ReplayEntry 回放缓冲中的消息
+//
+// 按行级权限筛选过接收者的消息（Restricted）只回放给广播时判定可见的用户（Allowed），
+// 判定不可见的用户（Denied）跳过；广播时不在线、未经判定的用户需要重新同步
+type ReplayEntry struct {
+	Message    *Message `json:"message"`
+	Restricted bool     `json:"restricted,omitempty"`
+	Allowed    []string `json:"allowed,omitempty"`
+	Denied     []string `json:"denied,omitempty"`
+}
+
+// visibility 用户能否看到该消息，decided 为 false 表示广播时未判定
+func (e *ReplayEntry) visibility(userID string) (visible, decided bool) {
+	if !e.Restricted {
+		return true, true
+	}
+	for _, id := range e.Allowed {
+		if id == userID {
+			return true, true
+		}
+	}
+	for _, id := range e.Denied {
+		if id == userID {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// ReplayStore 频道消息序号与回放缓冲
+//
+// 序号在频道内单调递增。频道空闲超过保留时间后序号和缓冲一并丢弃，
+// 再次使用时从基于当前时间的更大起点开始，断线前的序号不会与之混淆
+type ReplayStore interface {
+	// Append 分配频道内的下一个序号（写入 entry.Message.Seq）并加入缓冲
+	Append(ctx context.Context, channel string, entry *ReplayEntry) (uint64, error)
+	// Head 频道当前序号，频道未使用或已过期时以当前时间为起点创建
+	Head(ctx context.Context, channel string) (uint64, error)
+	// Since 序号大于 after 的缓冲消息；complete 为 false 表示缓冲已不能覆盖 after 之后的全部消息
+	Since(ctx context.Context, channel string, after uint64) (entries []*ReplayEntry, head uint64, complete bool, err error)
+}
+
+// replaySeqBase 频道序号的起点：毫秒时间戳左移 10 位，
+// 过期后重新使用的频道序号总是大于此前分配过的序号
+func replaySeqBase(now time.Time) uint64 {
+	return uint64(now.UnixMilli()) << 10
+}
+
+// replayCoverage 判断缓冲能否覆盖 after 之后的消息
+// oldest 为缓冲中最早的序号（缓冲为空时为 0）
+func replayCoverage(after, head, oldest uint64) bool {
+	switch {
+	case head == 0:
+		// 频道已过期，无法确定错过了什么
+		return false
+	case after >= head:
+		// 超过当前序号说明序号来自过期前或其他频道
+		return after == head
+	default:
+		return oldest != 0 && oldest <= after+1
+	}
+}
+
+// MemoryReplayStore 单实例内存回放缓冲
+type MemoryReplayStore struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	channels  map[string]*replayBuffer
+	lastSweep time.Time
+}
+
+type replayBuffer struct {
+	head     uint64
+	entries  []*ReplayEntry
+	lastUsed time.Time
+}
+
+// NewMemoryReplayStore 创建内存回放缓冲
+func NewMemoryReplayStore(capacity int, ttl time.Duration) *MemoryReplayStore {
+	return &MemoryReplayStore{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		channels: make(map[string]*replayBuffer),
+	}
+}
+
+// Append 分配序号并加入缓冲，超出容量时丢弃最早的消息
+func (s *MemoryReplayStore) Append(ctx context.Context, channel string, entry *ReplayEntry) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	buffer := s.open(channel, now)
+	buffer.head++
+	buffer.lastUsed = now
+	entry.Message.Seq = buffer.head
+	buffer.entries = append(buffer.entries, entry)
+	if len(buffer.entries) > s.capacity {
+		buffer.entries = buffer.entries[len(buffer.entries)-s.capacity:]
+	}
+	return buffer.head, nil
+}
+
+// Head 频道当前序号
+func (s *MemoryReplayStore) Head(ctx context.Context, channel string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.open(channel, s.now()).head, nil
+}
+
+// Since 序号大于 after 的缓冲消息
+func (s *MemoryReplayStore) Since(ctx context.Context, channel string, after uint64) ([]*ReplayEntry, uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffer := s.live(channel)
+	if buffer == nil {
+		return nil, 0, replayCoverage(after, 0, 0), nil
+	}
+	var oldest uint64
+	if len(buffer.entries) > 0 {
+		oldest = buffer.entries[0].Message.Seq
+	}
+	if !replayCoverage(after, buffer.head, oldest) {
+		return nil, buffer.head, false, nil
+	}
+
+	entries := make([]*ReplayEntry, 0)
+	for _, entry := range buffer.entries {
+		if entry.Message.Seq > after {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, buffer.head, true, nil
+}
+
+// open 获取频道缓冲，不存在或已过期时新建（调用方持有锁）
+func (s *MemoryReplayStore) open(channel string, now time.Time) *replayBuffer {
+	s.sweep(now)
+	buffer := s.live(channel)
+	if buffer == nil {
+		buffer = &replayBuffer{head: replaySeqBase(now), lastUsed: now}
+		s.channels[channel] = buffer
+	}
+	return buffer
+}
+
+// live 未过期的频道缓冲（调用方持有锁）
+func (s *MemoryReplayStore) live(channel string) *replayBuffer {
+	buffer, ok := s.channels[channel]
+	if !ok || s.now().Sub(buffer.lastUsed) > s.ttl {
+		return nil
+	}
+	return buffer
+}
+
+// sweep 定期清理空闲频道（调用方持有锁）
+func (s *MemoryReplayStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for channel, buffer := range s.channels {
+		if now.Sub(buffer.lastUsed) > s.ttl {
+			delete(s.channels, channel)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func replayEntry(data string) *ReplayEntry {
+	return &ReplayEntry{Message: NewMessage(MessageTypeOp, data)}
+}
+
+func TestMemoryReplayStore(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryReplayStore(3, time.Minute)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	base, err := store.Head(ctx, "table:tbl_1")
+	require.NoError(t, err)
+	assert.Equal(t, replaySeqBase(now), base)
+
+	for i := 1; i <= 5; i++ {
+		seq, err := store.Append(ctx, "table:tbl_1", replayEntry("op"))
+		require.NoError(t, err)
+		assert.Equal(t, base+uint64(i), seq)
+	}
+
+	// 缓冲只保留最近 3 条
+	entries, head, complete, err := store.Since(ctx, "table:tbl_1", base+2)
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, base+5, head)
+	require.Len(t, entries, 3)
+	assert.Equal(t, base+3, entries[0].Message.Seq)
+
+	_, _, complete, _ = store.Since(ctx, "table:tbl_1", base+1)
+	assert.False(t, complete, "older than the buffer")
+
+	entries, _, complete, _ = store.Since(ctx, "table:tbl_1", base+5)
+	assert.True(t, complete, "up to date")
+	assert.Empty(t, entries)
+
+	_, _, complete, _ = store.Since(ctx, "table:tbl_1", base+6)
+	assert.False(t, complete, "ahead of head")
+
+	// 过期后序号从更大的起点重新开始，旧序号要求重新同步
+	now = now.Add(2 * time.Minute)
+	_, head, complete, _ = store.Since(ctx, "table:tbl_1", base+5)
+	assert.False(t, complete)
+	assert.Zero(t, head)
+
+	reopened, err := store.Head(ctx, "table:tbl_1")
+	require.NoError(t, err)
+	assert.Greater(t, reopened, base+5)
+}
+
+func TestReplayEntry_Visibility(t *testing.T) {
+	entry := &ReplayEntry{Restricted: true, Allowed: []string{"usr_a"}, Denied: []string{"usr_b"}}
+
+	visible, decided := entry.visibility("usr_a")
+	assert.True(t, visible)
+	assert.True(t, decided)
+	visible, decided = entry.visibility("usr_b")
+	assert.False(t, visible)
+	assert.True(t, decided)
+	_, decided = entry.visibility("usr_c")
+	assert.False(t, decided)
+
+	visible, decided = replayEntry("op").visibility("usr_c")
+	assert.True(t, visible)
+	assert.True(t, decided)
+}
+
+// runReplayManager 启动带回放缓冲的管理器，测试结束时停止
+func runReplayManager(t *testing.T, capacity int) *Manager {
+	manager := NewManager(zap.NewNop())
+	manager.SetReplayStore(NewMemoryReplayStore(capacity, time.Minute))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go manager.Run(ctx)
+	return manager
+}
+
+// drain 读取连接已收到的消息
+func drain(conn *Connection) []*Message {
+	messages := make([]*Message, 0, len(conn.Send))
+	for len(conn.Send) > 0 {
+		messages = append(messages, <-conn.Send)
+	}
+	return messages
+}
+
+func TestManager_SequenceAndResume(t *testing.T) {
+	manager := runReplayManager(t, 100)
+	channel := "table:tbl_1"
+
+	conn := newTestConnection(manager, "conn_a", "usr_a", "")
+	subscribed := manager.SubscribeFrom(conn, channel, 0)
+	assert.Equal(t, ResumeStatusSubscribed, subscribed.Status)
+	require.NotZero(t, subscribed.Seq)
+
+	for i := 0; i < 3; i++ {
+		manager.BroadcastToChannel(channel, NewMessage(MessageTypeOp, i))
+	}
+	require.Eventually(t, func() bool { return len(conn.Send) == 3 }, time.Second, 5*time.Millisecond)
+	received := drain(conn)
+	for i, message := range received {
+		assert.Equal(t, channel, message.Channel)
+		assert.Equal(t, subscribed.Seq+uint64(i)+1, message.Seq)
+	}
+
+	// 只收到第一条后断线，新连接从该序号恢复
+	reconnected := newTestConnection(manager, "conn_b", "usr_a", "")
+	result := manager.SubscribeFrom(reconnected, channel, received[0].Seq)
+	assert.Equal(t, ResumeStatusReplayed, result.Status)
+	assert.Equal(t, 2, result.Replayed)
+	assert.Equal(t, received[2].Seq, result.Seq)
+	replayed := drain(reconnected)
+	require.Len(t, replayed, 2)
+	assert.Equal(t, received[1].Seq, replayed[0].Seq)
+	assert.Equal(t, received[2].Seq, replayed[1].Seq)
+
+	// 恢复后继续接收实时消息
+	manager.BroadcastToChannel(channel, NewMessage(MessageTypeOp, "live"))
+	require.Eventually(t, func() bool { return len(reconnected.Send) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, received[2].Seq+1, (<-reconnected.Send).Seq)
+
+	// 排除发送方的协作回显不编号
+	manager.BroadcastToChannel(channel, NewMessage(MessageTypeOp, "echo"), conn.ID)
+	require.Eventually(t, func() bool { return len(reconnected.Send) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Zero(t, (<-reconnected.Send).Seq)
+}
+
+func TestManager_ResumeResync(t *testing.T) {
+	manager := runReplayManager(t, 2)
+	channel := "table:tbl_1"
+	conn := newTestConnection(manager, "conn_a", "usr_a", "")
+	head := manager.SubscribeFrom(conn, channel, 0).Seq
+
+	for i := 0; i < 5; i++ {
+		manager.BroadcastToChannel(channel, NewMessage(MessageTypeOp, i))
+	}
+	require.Eventually(t, func() bool { return len(conn.Send) == 5 }, time.Second, 5*time.Millisecond)
+
+	reconnected := newTestConnection(manager, "conn_b", "usr_a", "")
+	result := manager.SubscribeFrom(reconnected, channel, head+1)
+	assert.Equal(t, ResumeStatusResync, result.Status)
+	assert.Equal(t, "too far behind", result.Reason)
+	assert.Equal(t, head+5, result.Seq)
+	assert.Empty(t, reconnected.Send)
+	// 重新同步的频道仍然保持订阅
+	assert.True(t, reconnected.Subscriptions[channel])
+
+	// 未启用回放时无法恢复
+	plain := NewManager(zap.NewNop())
+	result = plain.SubscribeFrom(newTestConnection(plain, "conn_c", "usr_a", ""), channel, head)
+	assert.Equal(t, ResumeStatusResync, result.Status)
+}
+
+func TestManager_ResumeRestricted(t *testing.T) {
+	manager := runReplayManager(t, 100)
+	channel := "table:tbl_1"
+	connA := newTestConnection(manager, "conn_a", "usr_a", "")
+	connB := newTestConnection(manager, "conn_b", "usr_b", "")
+	head := manager.SubscribeFrom(connA, channel, 0).Seq
+	manager.SubscribeFrom(connB, channel, 0)
+
+	manager.BroadcastToChannelWhere(channel, NewMessage(MessageTypeOp, "row"), func(userID string) bool {
+		return userID == "usr_a"
+	})
+	require.Eventually(t, func() bool { return len(connA.Send) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Empty(t, connB.Send)
+
+	// 广播时判定可见的用户补发，判定不可见的跳过
+	result := manager.SubscribeFrom(newTestConnection(manager, "conn_a2", "usr_a", ""), channel, head)
+	assert.Equal(t, ResumeStatusReplayed, result.Status)
+	assert.Equal(t, 1, result.Replayed)
+
+	result = manager.SubscribeFrom(newTestConnection(manager, "conn_b2", "usr_b", ""), channel, head)
+	assert.Equal(t, ResumeStatusReplayed, result.Status)
+	assert.Zero(t, result.Replayed)
+
+	// 广播时不在线的用户无法判定，需要重新同步
+	result = manager.SubscribeFrom(newTestConnection(manager, "conn_c", "usr_c", ""), channel, head)
+	assert.Equal(t, ResumeStatusResync, result.Status)
+}
+
+func TestManager_SlowConsumer(t *testing.T) {
+	manager := runReplayManager(t, 100)
+	channel := "table:tbl_1"
+	slow := newTestConnection(manager, "conn_slow", "usr_a", "")
+	fast := newTestConnection(manager, "conn_fast", "usr_b", "")
+	manager.SubscribeFrom(slow, channel, 0)
+	manager.SubscribeFrom(fast, channel, 0)
+
+	for i := 0; i < cap(slow.Send)+1; i++ {
+		manager.BroadcastToChannel(channel, NewMessage(MessageTypeOp, i))
+		<-fast.Send
+	}
+
+	require.Eventually(t, func() bool {
+		_, exists := manager.GetConnection(slow.ID)
+		return !exists
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(1), manager.GetStats()["slow_consumers"])
+	assert.NotEmpty(t, slow.closeMessage())
+
+	// 缓冲的消息读完后通道已关闭
+	drain(slow)
+	_, open := <-slow.Send
+	assert.False(t, open)
+
+	// 重复注销不会再次关闭通道
+	assert.NotPanics(t, func() { manager.unregisterConnection(slow) })
+	_, exists := manager.GetConnection(fast.ID)
+	assert.True(t, exists)
+}
+
+// gatedReplayStore 写入指定频道时阻塞，模拟缓慢的回放缓冲
+type gatedReplayStore struct {
+	*MemoryReplayStore
+	channel string
+	gate    chan struct{}
+}
+
+func (s *gatedReplayStore) Append(ctx context.Context, channel string, entry *ReplayEntry) (uint64, error) {
+	if channel == s.channel {
+		<-s.gate
+	}
+	return s.MemoryReplayStore.Append(ctx, channel, entry)
+}
+
+func TestManager_SlowChannelDoesNotBlockOthers(t *testing.T) {
+	manager := NewManager(zap.NewNop())
+	slowChannel := "table:tbl_slow"
+	store := &gatedReplayStore{MemoryReplayStore: NewMemoryReplayStore(100, time.Minute), channel: slowChannel, gate: make(chan struct{})}
+	manager.SetReplayStore(store)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go manager.Run(ctx)
+
+	otherChannel := ""
+	for i := 0; otherChannel == ""; i++ {
+		if candidate := fmt.Sprintf("table:tbl_%d", i); manager.shardOf(candidate) != manager.shardOf(slowChannel) {
+			otherChannel = candidate
+		}
+	}
+	conn := newTestConnection(manager, "conn_a", "usr_a", "")
+	manager.SubscribeFrom(conn, slowChannel, 0)
+	head := manager.SubscribeFrom(conn, otherChannel, 0).Seq
+
+	manager.BroadcastToChannel(slowChannel, NewMessage(MessageTypeOp, "slow"))
+	manager.BroadcastToChannel(otherChannel, NewMessage(MessageTypeOp, "other"))
+	require.Eventually(t, func() bool { return len(conn.Send) == 1 }, time.Second, 5*time.Millisecond)
+	message := <-conn.Send
+	assert.Equal(t, otherChannel, message.Channel)
+	assert.Equal(t, head+1, message.Seq)
+
+	// 主循环不受影响，照常注册连接
+	manager.register <- &Connection{ID: "conn_b", UserID: "usr_b", Send: make(chan *Message, 8), Subscriptions: make(map[string]bool)}
+	require.Eventually(t, func() bool {
+		_, exists := manager.GetConnection("conn_b")
+		return exists
+	}, time.Second, 5*time.Millisecond)
+
+	close(store.gate)
+	require.Eventually(t, func() bool { return len(conn.Send) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, slowChannel, (<-conn.Send).Channel)
+}
+
+func TestManager_SequencesArePerInstance(t *testing.T) {
+	instanceA := runReplayManager(t, 100)
+	instanceB := runReplayManager(t, 100)
+	require.NotEqual(t, instanceA.InstanceID(), instanceB.InstanceID())
+	channel := "table:tbl_1"
+
+	conn := newTestConnection(instanceA, "conn_a", "usr_a", "")
+	head := instanceA.SubscribeFrom(conn, channel, 0).Seq
+
+	// 其他实例的广播不投递给本实例的连接，也不占用本实例的序号
+	for i := 0; i < 3; i++ {
+		instanceB.BroadcastToChannel(channel, NewMessage(MessageTypeOp, i))
+	}
+	instanceA.BroadcastToChannel(channel, NewMessage(MessageTypeOp, "a"))
+	require.Eventually(t, func() bool { return len(conn.Send) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, head+1, (<-conn.Send).Seq)
+
+	resume := func(instance *Manager, connID, from string) []*ResumeResult {
+		reconnected := newTestConnection(instance, connID, "usr_a", "")
+		request := ResumeRequest{Instance: from, Channels: map[string]uint64{channel: head}}
+		NewHandler(instance, zap.NewNop()).handleResume(reconnected, NewMessage(MessageTypeResume, request))
+		messages := drain(reconnected)
+		require.NotEmpty(t, messages)
+		response := messages[len(messages)-1]
+		assert.Equal(t, MessageTypeResumeResponse, response.Type)
+		assert.True(t, reconnected.Subscriptions[channel])
+		return response.Data.(map[string]interface{})["channels"].([]*ResumeResult)
+	}
+
+	// 重连到其他实例时重新同步，不补发其他实例的消息
+	results := resume(instanceB, "conn_b", instanceA.InstanceID())
+	require.Len(t, results, 1)
+	assert.Equal(t, ResumeStatusResync, results[0].Status)
+	assert.Zero(t, results[0].Replayed)
+
+	// 重连到同一实例时补发
+	results = resume(instanceA, "conn_a2", instanceA.InstanceID())
+	require.Len(t, results, 1)
+	assert.Equal(t, ResumeStatusReplayed, results[0].Status)
+	assert.Equal(t, 1, results[0].Replayed)
+}
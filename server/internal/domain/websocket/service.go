@@ -22,6 +22,7 @@ type Service interface {
 
 	// 消息广播
 	BroadcastToChannel(channel string, message *Message, exclude ...string) error
+	BroadcastToChannelWhere(channel string, message *Message, allow func(userID string) bool) error
 	BroadcastToUser(userID string, message *Message) error
 
 	// 文档操作
@@ -99,6 +100,15 @@ func (s *service) BroadcastToChannel(channel string, message *Message, exclude .
 	return nil
 }
 
+// BroadcastToChannelWhere 向频道中满足条件的用户广播消息（用于行级权限过滤）
+func (s *service) BroadcastToChannelWhere(channel string, message *Message, allow func(userID string) bool) error {
+	if allow == nil {
+		return s.BroadcastToChannel(channel, message)
+	}
+	s.manager.BroadcastToChannelWhere(channel, message, allow)
+	return nil
+}
+
 // BroadcastToUser 向用户广播消息
 func (s *service) BroadcastToUser(userID string, message *Message) error {
 	s.manager.BroadcastToUser(userID, message)
@@ -0,0 +1,288 @@
+package websocket
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ScopeType 频道对应的资源范围
+type ScopeType string
+
+const (
+	ScopeSpace  ScopeType = "space"
+	ScopeBase   ScopeType = "base"
+	ScopeTable  ScopeType = "table"
+	ScopeView   ScopeType = "view"
+	ScopeRecord ScopeType = "record"
+	ScopeUser   ScopeType = "user"
+)
+
+// ChannelScope 频道对应的资源，订阅授权按此校验
+type ChannelScope struct {
+	Channel  string    `json:"channel"`
+	Type     ScopeType `json:"type"`
+	SpaceID  string    `json:"space_id,omitempty"`
+	BaseID   string    `json:"base_id,omitempty"`
+	TableID  string    `json:"table_id,omitempty"`
+	ViewID   string    `json:"view_id,omitempty"`
+	RecordID string    `json:"record_id,omitempty"`
+	UserID   string    `json:"user_id,omitempty"`
+}
+
+// ParseChannelScope 解析频道对应的资源范围
+// 支持两种命名：
+// - ChannelManager 格式 type:identifier[:subpath]，如 table:{tableID}、record:{tableID}:{recordID}
+// - ShareDB 格式 collection[.document]，如 table.{tableID}、view_{tableID}.{viewID}
+//
+// 视图频道可能不携带表 ID（view.{viewID}），此时 TableID 为空
+// 不指向具体资源的频道（如全量的 table）返回错误
+func ParseChannelScope(channel string) (*ChannelScope, error) {
+	if strings.Contains(channel, ":") {
+		return parseTypedChannel(channel)
+	}
+	return parseCollectionChannel(channel)
+}
+
+// parseTypedChannel 解析 type:identifier[:subpath] 格式
+func parseTypedChannel(channel string) (*ChannelScope, error) {
+	parts := strings.SplitN(channel, ":", 3)
+	identifier := parts[1]
+	subPath := ""
+	if len(parts) == 3 {
+		subPath = parts[2]
+	}
+	if identifier == "" {
+		return nil, &SubscriptionError{Message: "channel resource id is required"}
+	}
+
+	scope := &ChannelScope{Channel: channel}
+	switch parts[0] {
+	case "space":
+		scope.Type, scope.SpaceID = ScopeSpace, identifier
+	case "base":
+		scope.Type, scope.BaseID = ScopeBase, identifier
+	case string(ChannelTypeTable), string(ChannelTypeField), string(ChannelTypeCollaboration):
+		scope.Type, scope.TableID = ScopeTable, identifier
+	case string(ChannelTypeView):
+		if subPath == "" {
+			return nil, &SubscriptionError{Message: "view channel requires a view id"}
+		}
+		scope.Type, scope.TableID, scope.ViewID = ScopeView, identifier, subPath
+	case string(ChannelTypeRecord):
+		if subPath == "" {
+			return nil, &SubscriptionError{Message: "record channel requires a record id"}
+		}
+		scope.Type, scope.TableID, scope.RecordID = ScopeRecord, identifier, subPath
+	case string(ChannelTypeUser):
+		scope.Type, scope.UserID = ScopeUser, identifier
+	default:
+		return nil, &SubscriptionError{Message: "unknown channel type: " + parts[0]}
+	}
+	return scope, nil
+}
+
+// parseCollectionChannel 解析 collection[.document] 格式
+func parseCollectionChannel(channel string) (*ChannelScope, error) {
+	collection, document, _ := strings.Cut(channel, ".")
+	scope := &ChannelScope{Channel: channel}
+
+	switch collection {
+	case "table", "record", "view":
+		return parseSDKChannel(scope, collection, document)
+	}
+
+	for _, prefix := range []string{"view_", "record_", "field_", "table_"} {
+		tableID, ok := strings.CutPrefix(collection, prefix)
+		if !ok {
+			continue
+		}
+		if tableID == "" {
+			return nil, &SubscriptionError{Message: "channel table id is required"}
+		}
+		scope.Type, scope.TableID = ScopeTable, tableID
+		switch {
+		case prefix == "view_" && document != "":
+			scope.Type, scope.ViewID = ScopeView, document
+		case prefix == "record_" && document != "":
+			scope.Type, scope.RecordID = ScopeRecord, document
+		}
+		return scope, nil
+	}
+	return nil, &SubscriptionError{Message: "unknown channel: " + channel}
+}
+
+// parseSDKChannel 解析 SDK 频道：table.{tableID}、record.{tableID}.{recordID}、view.{viewID}
+func parseSDKChannel(scope *ChannelScope, collection, document string) (*ChannelScope, error) {
+	if document == "" {
+		return nil, &SubscriptionError{Message: "channel is not scoped to a resource"}
+	}
+
+	switch collection {
+	case "record":
+		tableID, recordID, _ := strings.Cut(document, ".")
+		if tableID == "" || recordID == "" {
+			return nil, &SubscriptionError{Message: "record channel requires a record id"}
+		}
+		scope.Type, scope.TableID, scope.RecordID = ScopeRecord, tableID, recordID
+	case "view":
+		// 未携带表 ID，由授权方根据视图解析
+		scope.Type, scope.ViewID = ScopeView, document
+	default:
+		scope.Type, scope.TableID = ScopeTable, document
+	}
+	return scope, nil
+}
+
+// Subscriber 订阅方：登录用户，或通过分享链接访问的匿名访客
+type Subscriber struct {
+	ConnectionID string
+	UserID       string
+	ShareID      string
+}
+
+// SubscriptionAuthorizer 订阅授权
+type SubscriptionAuthorizer interface {
+	// AuthorizeShare 校验分享链接是否可用于建立匿名连接
+	AuthorizeShare(ctx context.Context, shareID string) error
+	// AuthorizeSubscription 校验订阅方能否订阅该范围的频道
+	AuthorizeSubscription(ctx context.Context, subscriber *Subscriber, scope *ChannelScope) error
+}
+
+// SubscriptionRejection 被拒绝或被强制退订的订阅
+type SubscriptionRejection struct {
+	Subscriber *Subscriber
+	Channel    string
+	Scope      *ChannelScope // 频道无法解析时为空
+	Reason     string
+	Revoked    bool // 权限变更后重新校验时被撤销
+	RemoteAddr string
+	UserAgent  string
+	Time       time.Time
+}
+
+// SubscriptionAuditor 订阅审计
+type SubscriptionAuditor interface {
+	RecordRejection(ctx context.Context, rejection *SubscriptionRejection) error
+}
+
+// SubscriptionGuard 订阅守卫：订阅前授权，权限变更后重新校验并强制退订
+type SubscriptionGuard struct {
+	manager    *Manager
+	authorizer SubscriptionAuthorizer
+	auditor    SubscriptionAuditor
+	logger     *zap.Logger
+}
+
+// NewSubscriptionGuard 创建订阅守卫
+func NewSubscriptionGuard(manager *Manager, authorizer SubscriptionAuthorizer, logger *zap.Logger) *SubscriptionGuard {
+	return &SubscriptionGuard{
+		manager:    manager,
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+// SetAuditor 设置审计记录器（用于延迟注入）
+func (g *SubscriptionGuard) SetAuditor(auditor SubscriptionAuditor) {
+	g.auditor = auditor
+}
+
+// AuthorizeShare 校验分享链接
+func (g *SubscriptionGuard) AuthorizeShare(ctx context.Context, shareID string) error {
+	return g.authorizer.AuthorizeShare(ctx, shareID)
+}
+
+// Authorize 校验连接能否订阅频道，拒绝时记录审计
+func (g *SubscriptionGuard) Authorize(ctx context.Context, conn *Connection, channel string) error {
+	scope, err := g.check(ctx, conn, channel)
+	if err != nil {
+		g.reject(ctx, conn, channel, scope, err, false)
+	}
+	return err
+}
+
+// RevalidateUser 重新校验用户所有连接的订阅，返回被撤销的订阅数
+func (g *SubscriptionGuard) RevalidateUser(ctx context.Context, userID string) int {
+	return g.revalidate(ctx, g.manager.GetUserConnections(userID))
+}
+
+// RevalidateShare 重新校验通过某个分享链接建立的连接
+func (g *SubscriptionGuard) RevalidateShare(ctx context.Context, shareID string) int {
+	connections := make([]*Connection, 0)
+	for _, conn := range g.manager.Connections() {
+		if conn.ShareID == shareID {
+			connections = append(connections, conn)
+		}
+	}
+	return g.revalidate(ctx, connections)
+}
+
+// RevalidateAll 重新校验所有连接（无法定位受影响用户时使用，如部门协作者变更）
+func (g *SubscriptionGuard) RevalidateAll(ctx context.Context) int {
+	return g.revalidate(ctx, g.manager.Connections())
+}
+
+func (g *SubscriptionGuard) revalidate(ctx context.Context, connections []*Connection) int {
+	revoked := 0
+	for _, conn := range connections {
+		for _, channel := range conn.GetSubscribedChannels() {
+			scope, err := g.check(ctx, conn, channel)
+			if err == nil {
+				continue
+			}
+
+			g.manager.Unsubscribe(conn.ID, channel)
+			g.manager.SendToConnection(conn.ID, NewMessage(MessageTypeUnsubscribe, map[string]string{
+				"channel": channel,
+				"status":  "revoked",
+				"reason":  err.Error(),
+			}))
+			g.reject(ctx, conn, channel, scope, err, true)
+			revoked++
+		}
+	}
+	return revoked
+}
+
+func (g *SubscriptionGuard) check(ctx context.Context, conn *Connection, channel string) (*ChannelScope, error) {
+	scope, err := ParseChannelScope(channel)
+	if err != nil {
+		return nil, err
+	}
+	return scope, g.authorizer.AuthorizeSubscription(ctx, subscriberOf(conn), scope)
+}
+
+func (g *SubscriptionGuard) reject(ctx context.Context, conn *Connection, channel string, scope *ChannelScope, reason error, revoked bool) {
+	g.logger.Warn("WebSocket subscription rejected",
+		zap.String("connection_id", conn.ID),
+		zap.String("user_id", conn.UserID),
+		zap.String("share_id", conn.ShareID),
+		zap.String("channel", channel),
+		zap.Bool("revoked", revoked),
+		zap.String("reason", reason.Error()),
+	)
+	if g.auditor == nil {
+		return
+	}
+
+	rejection := &SubscriptionRejection{
+		Subscriber: subscriberOf(conn),
+		Channel:    channel,
+		Scope:      scope,
+		Reason:     reason.Error(),
+		Revoked:    revoked,
+		RemoteAddr: conn.RemoteAddr,
+		UserAgent:  conn.UserAgent,
+		Time:       time.Now(),
+	}
+	if err := g.auditor.RecordRejection(ctx, rejection); err != nil {
+		g.logger.Error("Failed to record subscription rejection",
+			zap.String("connection_id", conn.ID),
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,185 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeAuthorizer 用户按表授权，分享链接只能订阅对应视图
+type fakeAuthorizer struct {
+	tables map[string]map[string]bool // userID -> tableID
+	shares map[string]string          // shareID -> viewID
+}
+
+func (a *fakeAuthorizer) AuthorizeShare(ctx context.Context, shareID string) error {
+	if _, ok := a.shares[shareID]; !ok {
+		return &SubscriptionError{Message: "share link is invalid or disabled"}
+	}
+	return nil
+}
+
+func (a *fakeAuthorizer) AuthorizeSubscription(ctx context.Context, subscriber *Subscriber, scope *ChannelScope) error {
+	if subscriber.UserID == "" {
+		if viewID, ok := a.shares[subscriber.ShareID]; ok && scope.Type == ScopeView && scope.ViewID == viewID {
+			return nil
+		}
+		return &SubscriptionError{Message: "share link can only subscribe to the shared view"}
+	}
+	if scope.Type == ScopeUser && scope.UserID == subscriber.UserID {
+		return nil
+	}
+	if a.tables[subscriber.UserID][scope.TableID] {
+		return nil
+	}
+	return &SubscriptionError{Message: "access denied to channel " + scope.Channel}
+}
+
+type fakeAuditor struct {
+	rejections []*SubscriptionRejection
+}
+
+func (a *fakeAuditor) RecordRejection(ctx context.Context, rejection *SubscriptionRejection) error {
+	a.rejections = append(a.rejections, rejection)
+	return nil
+}
+
+func newGuardFixture() (*SubscriptionGuard, *Manager, *fakeAuthorizer, *fakeAuditor) {
+	manager := NewManager(zap.NewNop())
+	authorizer := &fakeAuthorizer{
+		tables: map[string]map[string]bool{"usr_a": {"tbl_1": true}},
+		shares: map[string]string{"shr_1": "viw_1"},
+	}
+	auditor := &fakeAuditor{}
+	guard := NewSubscriptionGuard(manager, authorizer, zap.NewNop())
+	guard.SetAuditor(auditor)
+	return guard, manager, authorizer, auditor
+}
+
+func newTestConnection(manager *Manager, id, userID, shareID string) *Connection {
+	conn := &Connection{
+		ID:            id,
+		UserID:        userID,
+		ShareID:       shareID,
+		Send:          make(chan *Message, 8),
+		Manager:       manager,
+		Subscriptions: make(map[string]bool),
+	}
+	manager.registerConnection(conn)
+	return conn
+}
+
+func TestParseChannelScope(t *testing.T) {
+	cases := []struct {
+		channel string
+		want    ChannelScope
+	}{
+		{"table:tbl_1", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+		{"field:tbl_1:fld_1", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+		{"collaboration:tbl_1", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+		{"view:tbl_1:viw_1", ChannelScope{Type: ScopeView, TableID: "tbl_1", ViewID: "viw_1"}},
+		{"record:tbl_1:rec_1", ChannelScope{Type: ScopeRecord, TableID: "tbl_1", RecordID: "rec_1"}},
+		{"user:usr_a:notifications", ChannelScope{Type: ScopeUser, UserID: "usr_a"}},
+		{"space:spc_1", ChannelScope{Type: ScopeSpace, SpaceID: "spc_1"}},
+		{"base:bse_1:tables", ChannelScope{Type: ScopeBase, BaseID: "bse_1"}},
+		{"table.tbl_1", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+		{"record.tbl_1.rec_1", ChannelScope{Type: ScopeRecord, TableID: "tbl_1", RecordID: "rec_1"}},
+		{"view.viw_1", ChannelScope{Type: ScopeView, ViewID: "viw_1"}},
+		{"view_tbl_1", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+		{"view_tbl_1.viw_1", ChannelScope{Type: ScopeView, TableID: "tbl_1", ViewID: "viw_1"}},
+		{"record_tbl_1.rec_1", ChannelScope{Type: ScopeRecord, TableID: "tbl_1", RecordID: "rec_1"}},
+		{"field_tbl_1.fld_1", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+		{"table_tbl_1.meta", ChannelScope{Type: ScopeTable, TableID: "tbl_1"}},
+	}
+	for _, tc := range cases {
+		scope, err := ParseChannelScope(tc.channel)
+		require.NoError(t, err, tc.channel)
+		tc.want.Channel = tc.channel
+		assert.Equal(t, &tc.want, scope, tc.channel)
+	}
+
+	// 不指向具体资源的频道一律拒绝
+	for _, channel := range []string{"", "table", "record", "record.tbl_1", "view_", "table:", "view:tbl_1", "record:tbl_1", "secret:x", "presence"} {
+		_, err := ParseChannelScope(channel)
+		assert.Error(t, err, channel)
+	}
+}
+
+func TestSubscriptionGuard_Authorize(t *testing.T) {
+	guard, manager, _, auditor := newGuardFixture()
+	ctx := context.Background()
+	conn := newTestConnection(manager, "conn_a", "usr_a", "")
+	conn.RemoteAddr = "10.0.0.1"
+
+	assert.NoError(t, guard.Authorize(ctx, conn, "table:tbl_1"))
+	assert.NoError(t, guard.Authorize(ctx, conn, "record.tbl_1.rec_1"))
+	assert.Empty(t, auditor.rejections)
+
+	assert.Error(t, guard.Authorize(ctx, conn, "table:tbl_2"))
+	assert.Error(t, guard.Authorize(ctx, conn, "table"))
+	require.Len(t, auditor.rejections, 2)
+	assert.Equal(t, "table:tbl_2", auditor.rejections[0].Channel)
+	assert.Equal(t, "tbl_2", auditor.rejections[0].Scope.TableID)
+	assert.Equal(t, "10.0.0.1", auditor.rejections[0].RemoteAddr)
+	assert.False(t, auditor.rejections[0].Revoked)
+	// 无法解析的频道没有范围
+	assert.Nil(t, auditor.rejections[1].Scope)
+}
+
+func TestSubscriptionGuard_ShareLink(t *testing.T) {
+	guard, manager, _, auditor := newGuardFixture()
+	ctx := context.Background()
+
+	assert.NoError(t, guard.AuthorizeShare(ctx, "shr_1"))
+	assert.Error(t, guard.AuthorizeShare(ctx, "shr_unknown"))
+
+	conn := newTestConnection(manager, "conn_share", "", "shr_1")
+	assert.NoError(t, guard.Authorize(ctx, conn, "view_tbl_1.viw_1"))
+	assert.Error(t, guard.Authorize(ctx, conn, "view_tbl_1.viw_2"))
+	assert.Error(t, guard.Authorize(ctx, conn, "table:tbl_1"))
+	assert.Len(t, auditor.rejections, 2)
+}
+
+func TestSubscriptionGuard_Revalidate(t *testing.T) {
+	guard, manager, authorizer, auditor := newGuardFixture()
+	ctx := context.Background()
+
+	member := newTestConnection(manager, "conn_a", "usr_a", "")
+	manager.Subscribe(member.ID, "table:tbl_1")
+	manager.Subscribe(member.ID, "user:usr_a")
+	visitor := newTestConnection(manager, "conn_share", "", "shr_1")
+	manager.Subscribe(visitor.ID, "view_tbl_1.viw_1")
+
+	// 权限未变化时不撤销
+	assert.Zero(t, guard.RevalidateUser(ctx, "usr_a"))
+
+	// 移除协作者后强制退订并通知客户端，自己的用户频道保留
+	delete(authorizer.tables, "usr_a")
+	assert.Equal(t, 1, guard.RevalidateUser(ctx, "usr_a"))
+	assert.Equal(t, []string{"user:usr_a"}, member.GetSubscribedChannels())
+	assert.NotContains(t, manager.channels, "table:tbl_1")
+	require.Len(t, member.Send, 1)
+	notice := <-member.Send
+	assert.Equal(t, MessageTypeUnsubscribe, notice.Type)
+	assert.Equal(t, "revoked", notice.Data.(map[string]string)["status"])
+
+	// 分享关闭后访客的订阅同样被撤销
+	assert.Equal(t, []string{"view_tbl_1.viw_1"}, visitor.GetSubscribedChannels())
+	delete(authorizer.shares, "shr_1")
+	assert.Zero(t, guard.RevalidateShare(ctx, "shr_other"))
+	assert.Equal(t, 1, guard.RevalidateAll(ctx))
+	assert.Empty(t, visitor.GetSubscribedChannels())
+
+	require.Len(t, auditor.rejections, 2)
+	for _, rejection := range auditor.rejections {
+		assert.True(t, rejection.Revoked)
+	}
+	assert.Equal(t, "shr_1", auditor.rejections[1].Subscriber.ShareID)
+
+	// 已注销的连接不再接收通知
+	manager.unregisterConnection(member)
+	assert.False(t, manager.SendToConnection(member.ID, NewMessage(MessageTypePing, nil)))
+}
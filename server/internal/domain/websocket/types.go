@@ -17,14 +17,18 @@ const (
 	MessageTypeError      MessageType = "error"
 
 	// 文档操作相关
-	MessageTypeSubscribe      MessageType = "subscribe"
-	MessageTypeUnsubscribe    MessageType = "unsubscribe"
-	MessageTypeQuery          MessageType = "query"
-	MessageTypeQueryResponse  MessageType = "queryResponse"
-	MessageTypeSubmit         MessageType = "submit"
-	MessageTypeSubmitResponse MessageType = "submitResponse"
-	MessageTypeOp             MessageType = "op"
-	MessageTypePresence       MessageType = "presence"
+	MessageTypeSubscribe        MessageType = "subscribe"
+	MessageTypeUnsubscribe      MessageType = "unsubscribe"
+	MessageTypeResume           MessageType = "resume"         // 重连后按序号恢复订阅
+	MessageTypeResumeResponse   MessageType = "resumeResponse" // 恢复结果
+	MessageTypeQuery            MessageType = "query"
+	MessageTypeQueryResponse    MessageType = "queryResponse"
+	MessageTypeQueryDiff        MessageType = "queryDiff"        // 实时查询结果变更
+	MessageTypeQueryUnsubscribe MessageType = "queryUnsubscribe" // 取消实时查询
+	MessageTypeSubmit           MessageType = "submit"
+	MessageTypeSubmitResponse   MessageType = "submitResponse"
+	MessageTypeOp               MessageType = "op"
+	MessageTypePresence         MessageType = "presence"
 
 	// 协作相关
 	MessageTypeCursor       MessageType = "cursor"
@@ -42,6 +46,8 @@ type Message struct {
 	ID         string      `json:"id,omitempty"`
 	Collection string      `json:"collection,omitempty"`
 	Document   string      `json:"document,omitempty"`
+	Channel    string      `json:"channel,omitempty"` // 频道广播消息所属频道
+	Seq        uint64      `json:"seq,omitempty"`     // 频道内的消息序号
 	Data       interface{} `json:"data,omitempty"`
 	Error      *Error      `json:"error,omitempty"`
 	Timestamp  time.Time   `json:"timestamp"`
@@ -0,0 +1,249 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	aiDomain "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+)
+
+// OpenAICompatibleProvider 兼容 OpenAI Chat Completions 接口的提供者
+// 适用于 OpenAI、DeepSeek 以及 Ollama、vLLM 等提供兼容接口的本地模型服务
+//
+// 限流：
+//   - 每分钟请求数、每分钟 token 数使用令牌桶，超出时等待而不是失败
+//   - 并发请求数使用信号量限制
+type OpenAICompatibleProvider struct {
+	name         string
+	baseURL      string
+	apiKey       string
+	defaultModel string
+	client       *http.Client
+
+	requestLimiter *rate.Limiter
+	tokenLimiter   *rate.Limiter
+	slots          chan struct{}
+}
+
+// NewOpenAICompatibleProvider 创建兼容 OpenAI 接口的提供者
+func NewOpenAICompatibleProvider(name string, cfg config.AIProviderConfig) *OpenAICompatibleProvider {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL(cfg.Type)
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	p := &OpenAICompatibleProvider{
+		name:         name,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		apiKey:       cfg.ResolvedAPIKey(),
+		defaultModel: cfg.DefaultModel,
+		client:       &http.Client{Timeout: timeout},
+	}
+
+	if rpm := cfg.RateLimit.RequestsPerMinute; rpm > 0 {
+		p.requestLimiter = rate.NewLimiter(rate.Limit(float64(rpm)/60), rpm)
+	}
+	if tpm := cfg.RateLimit.TokensPerMinute; tpm > 0 {
+		p.tokenLimiter = rate.NewLimiter(rate.Limit(float64(tpm)/60), tpm)
+	}
+	if n := cfg.RateLimit.ConcurrentRequests; n > 0 {
+		p.slots = make(chan struct{}, n)
+	}
+
+	return p
+}
+
+// Name 提供者名称
+func (p *OpenAICompatibleProvider) Name() string {
+	return p.name
+}
+
+// DefaultModel 默认模型
+func (p *OpenAICompatibleProvider) DefaultModel() string {
+	return p.defaultModel
+}
+
+// chatMessage Chat Completions 消息
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest Chat Completions 请求体
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature *float64      `json:"temperature,omitempty"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+}
+
+// chatResponse Chat Completions 响应体
+type chatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Complete 调用 {baseURL}/chat/completions 生成文本
+func (p *OpenAICompatibleProvider) Complete(ctx context.Context, req aiDomain.CompletionRequest) (*aiDomain.CompletionResult, error) {
+	model := req.Model
+	if model == "" {
+		model = p.defaultModel
+	}
+	if model == "" {
+		return nil, fmt.Errorf("ai provider %s: model is required", p.name)
+	}
+
+	messages := make([]chatMessage, 0, 2)
+	if req.SystemPrompt != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: req.SystemPrompt})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: req.Prompt})
+
+	body, err := json.Marshal(chatRequest{
+		Model:       model,
+		Messages:    messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ai request: %w", err)
+	}
+
+	release, err := p.acquire(ctx, estimateTokens(req))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ai request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("ai provider %s request failed: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ai response: %w", err)
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("ai provider %s returned status %d", p.name, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to decode ai response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil && parsed.Error.Message != "" {
+			return nil, fmt.Errorf("ai provider %s returned status %d: %s", p.name, resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, fmt.Errorf("ai provider %s returned status %d", p.name, resp.StatusCode)
+	}
+	if len(parsed.Choices) == 0 {
+		return nil, fmt.Errorf("ai provider %s returned no choices", p.name)
+	}
+
+	if parsed.Model != "" {
+		model = parsed.Model
+	}
+	return &aiDomain.CompletionResult{
+		Text:             strings.TrimSpace(parsed.Choices[0].Message.Content),
+		Model:            model,
+		PromptTokens:     parsed.Usage.PromptTokens,
+		CompletionTokens: parsed.Usage.CompletionTokens,
+	}, nil
+}
+
+// GenerateFieldValue 实现表格虚拟字段服务的 AIProvider 接口
+// aiConfig 为 AI 字段选项之外的值时只按提示词生成
+func (p *OpenAICompatibleProvider) GenerateFieldValue(ctx context.Context, prompt string, aiConfig interface{}) (interface{}, error) {
+	req := aiDomain.CompletionRequest{Prompt: prompt}
+	if options, ok := aiConfig.(*fieldValueobject.AIOptions); ok && options != nil {
+		req = aiDomain.NewCompletionRequest(options, prompt)
+	}
+	result, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return result.Text, nil
+}
+
+// acquire 等待限流令牌和并发槽位，返回释放槽位的函数
+func (p *OpenAICompatibleProvider) acquire(ctx context.Context, tokens int) (func(), error) {
+	if p.requestLimiter != nil {
+		if err := p.requestLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("ai rate limit wait: %w", err)
+		}
+	}
+	if p.tokenLimiter != nil {
+		if burst := p.tokenLimiter.Burst(); tokens > burst {
+			tokens = burst
+		}
+		if err := p.tokenLimiter.WaitN(ctx, tokens); err != nil {
+			return nil, fmt.Errorf("ai rate limit wait: %w", err)
+		}
+	}
+	if p.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		return func() { <-p.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// estimateTokens 粗略估算一次请求的 token 数（约 4 个字符 1 个 token）
+func estimateTokens(req aiDomain.CompletionRequest) int {
+	tokens := (len(req.SystemPrompt)+len(req.Prompt))/4 + 1
+	if req.MaxTokens > 0 {
+		tokens += req.MaxTokens
+	}
+	return tokens
+}
+
+// defaultBaseURL 各类型提供者的默认接口地址
+func defaultBaseURL(providerType string) string {
+	switch providerType {
+	case "deepseek":
+		return "https://api.deepseek.com/v1"
+	case "ollama":
+		return "http://localhost:11434/v1"
+	default:
+		return "https://api.openai.com/v1"
+	}
+}
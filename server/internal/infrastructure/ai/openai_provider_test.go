@@ -0,0 +1,172 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	aiDomain "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// stubChatServer 模拟兼容 OpenAI 的本地模型服务，回显用户提示词
+func stubChatServer(t *testing.T, handle func(req chatRequest)) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+			return
+		}
+
+		var req chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if handle != nil {
+			handle(req)
+		}
+
+		prompt := req.Messages[len(req.Messages)-1].Content
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"model": req.Model,
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": " echo: " + prompt + "\n"}},
+			},
+			"usage": map[string]int{"prompt_tokens": 7, "completion_tokens": 3},
+		})
+	}))
+}
+
+func TestOpenAICompatibleProvider_Complete(t *testing.T) {
+	var received chatRequest
+	server := stubChatServer(t, func(req chatRequest) { received = req })
+	defer server.Close()
+
+	provider := NewOpenAICompatibleProvider("local", config.AIProviderConfig{
+		Type:         "openai_compatible",
+		APIKey:       "sk-test",
+		BaseURL:      server.URL + "/v1/",
+		DefaultModel: "llama3",
+	})
+
+	temperature := 0.3
+	result, err := provider.Complete(context.Background(), aiDomain.CompletionRequest{
+		SystemPrompt: "be brief",
+		Prompt:       "hello",
+		Temperature:  &temperature,
+		MaxTokens:    16,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "echo: hello", result.Text)
+	assert.Equal(t, "llama3", result.Model)
+	assert.Equal(t, 10, result.TotalTokens())
+	assert.Equal(t, "llama3", received.Model)
+	assert.Equal(t, []chatMessage{{Role: "system", Content: "be brief"}, {Role: "user", Content: "hello"}}, received.Messages)
+	assert.Equal(t, 16, received.MaxTokens)
+	require.NotNil(t, received.Temperature)
+	assert.Equal(t, 0.3, *received.Temperature)
+}
+
+func TestOpenAICompatibleProvider_ErrorStatus(t *testing.T) {
+	server := stubChatServer(t, nil)
+	defer server.Close()
+
+	provider := NewOpenAICompatibleProvider("local", config.AIProviderConfig{
+		APIKey:       "wrong",
+		BaseURL:      server.URL + "/v1",
+		DefaultModel: "llama3",
+	})
+
+	_, err := provider.Complete(context.Background(), aiDomain.CompletionRequest{Prompt: "hello"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid api key")
+}
+
+func TestOpenAICompatibleProvider_GenerateFieldValue(t *testing.T) {
+	var received chatRequest
+	server := stubChatServer(t, func(req chatRequest) { received = req })
+	defer server.Close()
+
+	provider := NewOpenAICompatibleProvider("local", config.AIProviderConfig{
+		APIKey:       "sk-test",
+		BaseURL:      server.URL + "/v1",
+		DefaultModel: "llama3",
+	})
+
+	value, err := provider.GenerateFieldValue(context.Background(), "hi", &fieldValueobject.AIOptions{Model: "qwen2"})
+	require.NoError(t, err)
+	assert.Equal(t, "echo: hi", value)
+	assert.Equal(t, "qwen2", received.Model)
+}
+
+func TestOpenAICompatibleProvider_ConcurrencyLimit(t *testing.T) {
+	var active, peak int32
+	server := stubChatServer(t, func(chatRequest) {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+	})
+	defer server.Close()
+
+	provider := NewOpenAICompatibleProvider("local", config.AIProviderConfig{
+		APIKey:       "sk-test",
+		BaseURL:      server.URL + "/v1",
+		DefaultModel: "llama3",
+		RateLimit:    config.AIRateLimitConfig{ConcurrentRequests: 2},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := provider.Complete(context.Background(), aiDomain.CompletionRequest{Prompt: "x"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestNewRegistryFromConfig(t *testing.T) {
+	// Initialize a no-op global logger to avoid nil panics
+	logger.Logger = zap.NewNop()
+	logger.Sugar = logger.Logger.Sugar()
+
+	registry := NewRegistryFromConfig(config.AIConfig{
+		DefaultProvider: "local",
+		Providers: map[string]config.AIProviderConfig{
+			"local":     {Type: "ollama", DefaultModel: "llama3"},
+			"deepseek":  {Type: "deepseek", DefaultModel: "deepseek-chat"},
+			"anthropic": {Type: "anthropic"},
+		},
+	})
+
+	assert.Equal(t, []string{"deepseek", "local"}, registry.Names())
+
+	provider, err := registry.Get("")
+	require.NoError(t, err)
+	assert.Equal(t, "local", provider.Name())
+	assert.Equal(t, "http://localhost:11434/v1", provider.(*OpenAICompatibleProvider).baseURL)
+
+	_, err = registry.Get("anthropic")
+	assert.ErrorIs(t, err, aiDomain.ErrProviderNotFound)
+}
@@ -0,0 +1,76 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	aiDomain "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// Registry AI 提供者注册表，按配置中的名称查找提供者
+type Registry struct {
+	defaultProvider string
+	providers       map[string]aiDomain.Provider
+}
+
+// NewRegistry 创建空的提供者注册表
+func NewRegistry(defaultProvider string) *Registry {
+	return &Registry{
+		defaultProvider: defaultProvider,
+		providers:       make(map[string]aiDomain.Provider),
+	}
+}
+
+// NewRegistryFromConfig 根据配置创建提供者注册表
+// openai、deepseek、ollama、openai_compatible 类型均使用兼容 OpenAI 接口的实现，
+// 其余类型暂不支持，跳过并记录警告
+func NewRegistryFromConfig(cfg config.AIConfig) *Registry {
+	registry := NewRegistry(cfg.DefaultProvider)
+
+	for name, providerCfg := range cfg.Providers {
+		providerType := providerCfg.Type
+		if providerType == "" {
+			providerType = name
+		}
+
+		switch providerType {
+		case "openai", "deepseek", "ollama", "openai_compatible":
+			registry.Register(NewOpenAICompatibleProvider(name, providerCfg))
+		default:
+			logger.Warn("不支持的 AI 提供者类型，已跳过",
+				logger.String("provider", name),
+				logger.String("type", providerType))
+		}
+	}
+
+	return registry
+}
+
+// Register 注册提供者（同名覆盖）
+func (r *Registry) Register(provider aiDomain.Provider) {
+	r.providers[provider.Name()] = provider
+}
+
+// Get 获取提供者，name 为空时返回默认提供者
+func (r *Registry) Get(name string) (aiDomain.Provider, error) {
+	if name == "" {
+		name = r.defaultProvider
+	}
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", aiDomain.ErrProviderNotFound, name)
+	}
+	return provider, nil
+}
+
+// Names 已注册的提供者名称
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
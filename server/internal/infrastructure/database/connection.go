@@ -20,6 +20,8 @@ type Connection struct {
 }
 
 // NewConnection 创建新的数据库连接
+// 目前只支持 PostgreSQL；SQLiteProvider 只用于外部传入的 SQLite 连接，迁移文件也只有 PostgreSQL 版本
+// SQLite 单文件部署是后续工作，见 docs/architecture/overview.md「SQLite 支持现状」
 func NewConnection(cfg config.DatabaseConfig) (*Connection, error) {
 	// 构建DSN
 	dsn := cfg.GetDSN()
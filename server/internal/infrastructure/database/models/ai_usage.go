@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// AIUsage AI 生成的 token 用量（按 Base、日期、提供者、模型汇总）
+type AIUsage struct {
+	BaseID           string     `gorm:"column:base_id;type:varchar(30);primaryKey"`
+	UsageDate        string     `gorm:"column:usage_date;type:varchar(10);primaryKey"` // YYYY-MM-DD（UTC）
+	Provider         string     `gorm:"column:provider;type:varchar(50);primaryKey"`
+	Model            string     `gorm:"column:model;type:varchar(100);primaryKey"`
+	Requests         int64      `gorm:"column:requests;not null;default:0"`
+	PromptTokens     int64      `gorm:"column:prompt_tokens;not null;default:0"`
+	CompletionTokens int64      `gorm:"column:completion_tokens;not null;default:0"`
+	LastModifiedTime *time.Time `gorm:"column:last_modified_time;type:timestamp;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (AIUsage) TableName() string {
+	return "ai_usage"
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AlertRule 告警规则模型
+type AlertRule struct {
+	ID                    string         `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	Name                  string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Description           string         `gorm:"column:description;type:text" json:"description"`
+	MetricName            string         `gorm:"column:metric_name;type:varchar(200);not null" json:"metric_name"`
+	MetricLabels          datatypes.JSON `gorm:"column:metric_labels;type:jsonb" json:"metric_labels"`
+	Function              string         `gorm:"column:function;type:varchar(20);not null;default:'value'" json:"function"`
+	Operator              string         `gorm:"column:operator;type:varchar(10);not null" json:"operator"`
+	Threshold             float64        `gorm:"column:threshold;not null" json:"threshold"`
+	ForSeconds            int64          `gorm:"column:for_seconds;not null;default:0" json:"for_seconds"`
+	Severity              string         `gorm:"column:severity;type:varchar(20);not null" json:"severity"`
+	Channels              datatypes.JSON `gorm:"column:channels;type:jsonb" json:"channels"`
+	RepeatIntervalSeconds int64          `gorm:"column:repeat_interval_seconds;not null;default:0" json:"repeat_interval_seconds"`
+	Labels                datatypes.JSON `gorm:"column:labels;type:jsonb" json:"labels"`
+	Annotations           datatypes.JSON `gorm:"column:annotations;type:jsonb" json:"annotations"`
+	Enabled               bool           `gorm:"column:enabled;default:true" json:"enabled"`
+	CreatedBy             string         `gorm:"column:created_by;type:varchar(30);not null" json:"created_by"`
+	CreatedTime           time.Time      `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	LastModifiedTime      *time.Time     `gorm:"column:last_modified_time" json:"last_modified_time"`
+	DeletedTime           *time.Time     `gorm:"column:deleted_time;index" json:"deleted_time"`
+}
+
+func (AlertRule) TableName() string { return "alert_rule" }
+
+// AlertHistory 告警历史模型，每次触发（从 firing 到 resolved）一行
+type AlertHistory struct {
+	ID               string         `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	RuleID           string         `gorm:"column:rule_id;type:varchar(30);not null;index:idx_alert_history_rule" json:"rule_id"`
+	RuleName         string         `gorm:"column:rule_name;type:varchar(100);not null" json:"rule_name"`
+	Description      string         `gorm:"column:description;type:text" json:"description"`
+	Severity         string         `gorm:"column:severity;type:varchar(20);not null" json:"severity"`
+	Status           string         `gorm:"column:status;type:varchar(20);not null;index:idx_alert_history_open" json:"status"`
+	Instance         string         `gorm:"column:instance;type:varchar(255);index:idx_alert_history_open" json:"instance"`
+	Value            float64        `gorm:"column:value" json:"value"`
+	Threshold        float64        `gorm:"column:threshold" json:"threshold"`
+	Labels           datatypes.JSON `gorm:"column:labels;type:jsonb" json:"labels"`
+	StartedAt        time.Time      `gorm:"column:started_at;not null" json:"started_at"`
+	FiredAt          time.Time      `gorm:"column:fired_at;not null;index:idx_alert_history_rule" json:"fired_at"`
+	ResolvedAt       *time.Time     `gorm:"column:resolved_at" json:"resolved_at"`
+	LastNotifiedAt   *time.Time     `gorm:"column:last_notified_at" json:"last_notified_at"`
+	NotifyCount      int            `gorm:"column:notify_count;not null;default:0" json:"notify_count"`
+	Silenced         bool           `gorm:"column:silenced;not null;default:false" json:"silenced"`
+	LastError        string         `gorm:"column:last_error;type:text" json:"last_error"`
+	CreatedTime      time.Time      `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	LastModifiedTime time.Time      `gorm:"column:last_modified_time;not null" json:"last_modified_time"`
+}
+
+func (AlertHistory) TableName() string { return "alert_history" }
+
+// AlertSilence 告警静默窗口模型
+type AlertSilence struct {
+	ID          string         `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	RuleID      string         `gorm:"column:rule_id;type:varchar(30)" json:"rule_id"`
+	Matchers    datatypes.JSON `gorm:"column:matchers;type:jsonb" json:"matchers"`
+	StartsAt    time.Time      `gorm:"column:starts_at;not null" json:"starts_at"`
+	EndsAt      time.Time      `gorm:"column:ends_at;not null;index" json:"ends_at"`
+	Comment     string         `gorm:"column:comment;type:text" json:"comment"`
+	CreatedBy   string         `gorm:"column:created_by;type:varchar(30);not null" json:"created_by"`
+	CreatedTime time.Time      `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+}
+
+func (AlertSilence) TableName() string { return "alert_silence" }
@@ -14,11 +14,16 @@ type Attachment struct {
 	Token          string         `gorm:"not null;type:varchar(50);uniqueIndex" json:"token"`
 	Size           int64          `gorm:"not null" json:"size"`
 	MimeType       string         `gorm:"not null;type:varchar(100)" json:"mime_type"`
+	Hash           string         `gorm:"type:varchar(64);index" json:"hash,omitempty"` // 内容 SHA-256，对应 attachment_blobs
 	PresignedURL   *string        `gorm:"type:varchar(500)" json:"presigned_url,omitempty"`
 	Width          *int           `gorm:"type:int" json:"width,omitempty"`
 	Height         *int           `gorm:"type:int" json:"height,omitempty"`
 	SmallThumbnail *string        `gorm:"type:varchar(500)" json:"small_thumbnail,omitempty"`
 	LargeThumbnail *string        `gorm:"type:varchar(500)" json:"large_thumbnail,omitempty"`
+	TextPreview    *string        `gorm:"type:varchar(500)" json:"text_preview,omitempty"`
+	Duration       *float64       `json:"duration,omitempty"`
+	PageCount      *int           `gorm:"type:int" json:"page_count,omitempty"`
+	PreviewStatus  string         `gorm:"type:varchar(20);index" json:"preview_status,omitempty"`
 	TableID        string         `gorm:"not null;type:varchar(30);index" json:"table_id"`
 	FieldID        string         `gorm:"not null;type:varchar(30);index" json:"field_id"`
 	RecordID       string         `gorm:"not null;type:varchar(30);index" json:"record_id"`
@@ -50,3 +55,50 @@ type UploadToken struct {
 func (UploadToken) TableName() string {
 	return "upload_tokens"
 }
+
+// UploadSession 断点续传会话模型
+type UploadSession struct {
+	ID           string    `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	Token        string    `gorm:"not null;type:varchar(50);index" json:"token"`
+	Filename     string    `gorm:"not null;type:varchar(255)" json:"filename"`
+	MimeType     string    `gorm:"not null;type:varchar(100)" json:"mime_type"`
+	Size         int64     `gorm:"not null" json:"size"`
+	UploadOffset int64     `gorm:"not null;default:0" json:"upload_offset"`
+	Parts        string    `gorm:"type:text" json:"parts"` // 分片存储路径（JSON 数组）
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedTime  time.Time `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	UpdatedTime  time.Time `gorm:"autoUpdateTime;column:updated_time" json:"updated_time"`
+}
+
+// TableName 指定表名
+func (UploadSession) TableName() string {
+	return "upload_sessions"
+}
+
+// AttachmentBlob 按内容寻址存储的文件对象，内容相同的附件共用
+type AttachmentBlob struct {
+	Hash         string    `gorm:"primaryKey;type:varchar(64)" json:"hash"`
+	Path         string    `gorm:"not null;type:varchar(500)" json:"path"`
+	Size         int64     `gorm:"not null" json:"size"`
+	MimeType     string    `gorm:"not null;type:varchar(100)" json:"mime_type"`
+	CreatedTime  time.Time `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	LastUsedTime time.Time `gorm:"not null;index;column:last_used_time" json:"last_used_time"`
+}
+
+// TableName 指定表名
+func (AttachmentBlob) TableName() string {
+	return "attachment_blobs"
+}
+
+// SpaceStorageUsage 空间附件存储用量（用于配额计量）
+type SpaceStorageUsage struct {
+	SpaceID          string     `gorm:"column:space_id;type:varchar(30);primaryKey"`
+	UsedBytes        int64      `gorm:"column:used_bytes;not null;default:0"`
+	FileCount        int64      `gorm:"column:file_count;not null;default:0"`
+	LastModifiedTime *time.Time `gorm:"column:last_modified_time;type:timestamp;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (SpaceStorageUsage) TableName() string {
+	return "space_storage_usage"
+}
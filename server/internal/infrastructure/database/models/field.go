@@ -37,6 +37,7 @@ type Field struct {
 	AIConfig            *string `gorm:"column:ai_config;type:text" json:"ai_config"`
 	LookupLinkedFieldID *string `gorm:"column:lookup_linked_field_id;type:varchar(30)" json:"lookup_linked_field_id"`
 	LookupOptions       *string `gorm:"column:lookup_options;type:text" json:"lookup_options"`
+	LinkedTableID       *string `gorm:"column:linked_table_id;type:varchar(50);index" json:"linked_table_id"` // Link 字段的关联表（查找关联到某表的字段）
 	HasError            *bool   `gorm:"column:has_error;default:false" json:"has_error"`
 	IsPending           *bool   `gorm:"column:is_pending;default:false" json:"is_pending"`
 }
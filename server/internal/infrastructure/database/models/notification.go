@@ -97,8 +97,8 @@ type NotificationDelivery struct {
 	ID               string     `gorm:"primaryKey;type:text;not null" json:"id"`
 	NotificationID   string     `gorm:"type:text;not null" json:"notification_id"`
 	UserID           string     `gorm:"type:text;not null" json:"user_id"`
-	Channel          string     `gorm:"type:text;not null" json:"channel"`                  // email, push, sms, webhook
-	Status           string     `gorm:"type:text;not null;default:'pending'" json:"status"` // pending, sent, delivered, failed
+	Channel          string     `gorm:"type:text;not null" json:"channel"`                                                                 // email, push, sms, webhook
+	Status           string     `gorm:"type:text;not null;default:'pending';index:idx_notification_delivery_due,priority:1" json:"status"` // pending, sent, retrying, failed, deferred, batched, canceled
 	ExternalID       *string    `gorm:"type:text" json:"external_id"`
 	SentTime         *time.Time `gorm:"type:timestamp(3) without time zone" json:"sent_time"`
 	DeliveredTime    *time.Time `gorm:"type:timestamp(3) without time zone" json:"delivered_time"`
@@ -107,7 +107,7 @@ type NotificationDelivery struct {
 	ErrorMessage     *string    `gorm:"type:text" json:"error_message"`
 	RetryCount       int        `gorm:"type:integer;not null;default:0" json:"retry_count"`
 	MaxRetries       int        `gorm:"type:integer;not null;default:3" json:"max_retries"`
-	NextRetryTime    *time.Time `gorm:"type:timestamp(3) without time zone" json:"next_retry_time"`
+	NextRetryTime    *time.Time `gorm:"type:timestamp(3) without time zone;index:idx_notification_delivery_due,priority:2" json:"next_retry_time"` // 顺延、重试或摘要的投递时间
 	CreatedBy        string     `gorm:"type:text;not null" json:"created_by"`
 	CreatedTime      time.Time  `gorm:"type:timestamp(3) without time zone;not null;default:CURRENT_TIMESTAMP" json:"created_time"`
 	LastModifiedTime *time.Time `gorm:"type:timestamp(3) without time zone" json:"last_modified_time"`
@@ -139,3 +139,15 @@ func (nd *NotificationDelivery) BeforeUpdate(tx *gorm.DB) error {
 	nd.LastModifiedTime = &now
 	return nil
 }
+
+// NotificationPreference 用户通知偏好（渠道、免打扰时段、摘要频率、Webhook）
+type NotificationPreference struct {
+	UserID      string    `gorm:"primaryKey;type:varchar(30)" json:"user_id"`
+	Settings    string    `gorm:"type:text;not null" json:"settings"` // JSON格式存储
+	UpdatedTime time.Time `gorm:"autoUpdateTime" json:"updated_time"`
+}
+
+// TableName 返回表名
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
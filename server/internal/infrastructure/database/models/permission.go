@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/datatypes"
 )
 
 // Permission 权限模型
@@ -97,3 +99,20 @@ type InvitationRecord struct {
 }
 
 func (InvitationRecord) TableName() string { return "invitation_record" }
+
+// TableRowRule 行级访问规则模型
+type TableRowRule struct {
+	ID               string         `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	TableID          string         `gorm:"column:table_id;type:varchar(30);not null;index" json:"table_id"`
+	Name             string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Roles            datatypes.JSON `gorm:"column:roles;type:jsonb" json:"roles"`
+	Actions          datatypes.JSON `gorm:"column:actions;type:jsonb;not null" json:"actions"`
+	Filter           datatypes.JSON `gorm:"column:filter;type:jsonb;not null" json:"filter"`
+	Enabled          bool           `gorm:"column:enabled;default:true" json:"enabled"`
+	CreatedBy        string         `gorm:"column:created_by;type:varchar(30);not null" json:"created_by"`
+	CreatedTime      time.Time      `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	LastModifiedTime *time.Time     `gorm:"column:last_modified_time" json:"last_modified_time"`
+	DeletedTime      *time.Time     `gorm:"column:deleted_time;index" json:"deleted_time"`
+}
+
+func (TableRowRule) TableName() string { return "table_row_rule" }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RecordReminderRule 记录提醒规则模型
+type RecordReminderRule struct {
+	ID               string         `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	TableID          string         `gorm:"column:table_id;type:varchar(30);not null;index" json:"table_id"`
+	Name             string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	DateFieldID      string         `gorm:"column:date_field_id;type:varchar(30);not null" json:"date_field_id"`
+	Offset           int            `gorm:"column:offset_value;not null;default:0" json:"offset"`
+	Unit             string         `gorm:"column:offset_unit;type:varchar(10);not null" json:"unit"`
+	Direction        string         `gorm:"column:direction;type:varchar(10);not null" json:"direction"`
+	Timezone         string         `gorm:"column:timezone;type:varchar(64);not null;default:'UTC'" json:"timezone"`
+	Filter           datatypes.JSON `gorm:"column:filter;type:jsonb" json:"filter"`
+	RecipientFieldID string         `gorm:"column:recipient_field_id;type:varchar(30)" json:"recipient_field_id"`
+	RecipientUserIDs datatypes.JSON `gorm:"column:recipient_user_ids;type:jsonb" json:"recipient_user_ids"`
+	Message          string         `gorm:"column:message;type:text" json:"message"`
+	Enabled          bool           `gorm:"column:enabled;default:true" json:"enabled"`
+	CreatedBy        string         `gorm:"column:created_by;type:varchar(30);not null" json:"created_by"`
+	CreatedTime      time.Time      `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	LastModifiedTime *time.Time     `gorm:"column:last_modified_time" json:"last_modified_time"`
+	DeletedTime      *time.Time     `gorm:"column:deleted_time;index" json:"deleted_time"`
+}
+
+func (RecordReminderRule) TableName() string { return "record_reminder_rule" }
+
+// RecordReminder 记录提醒计划模型，每个（规则，记录）一行
+type RecordReminder struct {
+	ID               string     `gorm:"primaryKey;type:varchar(30)" json:"id"`
+	RuleID           string     `gorm:"column:rule_id;type:varchar(30);not null;uniqueIndex:uk_record_reminder_rule_record" json:"rule_id"`
+	TableID          string     `gorm:"column:table_id;type:varchar(30);not null;index:idx_record_reminder_record" json:"table_id"`
+	RecordID         string     `gorm:"column:record_id;type:varchar(30);not null;uniqueIndex:uk_record_reminder_rule_record;index:idx_record_reminder_record" json:"record_id"`
+	FireAt           time.Time  `gorm:"column:fire_at;not null;index:idx_record_reminder_due" json:"fire_at"`
+	Status           string     `gorm:"column:status;type:varchar(20);not null;index:idx_record_reminder_due" json:"status"`
+	SentAt           *time.Time `gorm:"column:sent_at" json:"sent_at"`
+	LastError        string     `gorm:"column:last_error;type:text" json:"last_error"`
+	CreatedTime      time.Time  `gorm:"autoCreateTime;column:created_time" json:"created_time"`
+	LastModifiedTime time.Time  `gorm:"column:last_modified_time;not null" json:"last_modified_time"`
+}
+
+func (RecordReminder) TableName() string { return "record_reminder" }
@@ -34,3 +34,16 @@ type View struct {
 func (View) TableName() string {
 	return "view"
 }
+
+// ViewRecordOrder 视图内记录的手动顺序（看板拖拽排序）
+type ViewRecordOrder struct {
+	ViewID           string     `gorm:"column:view_id;type:varchar(30);primaryKey;index:idx_view_record_order_sort,priority:1"`
+	RecordID         string     `gorm:"column:record_id;type:varchar(30);primaryKey"`
+	SortOrder        float64    `gorm:"column:sort_order;not null;index:idx_view_record_order_sort,priority:2"`
+	LastModifiedTime *time.Time `gorm:"column:last_modified_time;type:timestamp;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (ViewRecordOrder) TableName() string {
+	return "view_record_order"
+}
@@ -65,21 +65,21 @@ func (l *SQLLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 	switch {
 	case err != nil && l.config.LogLevel >= logger.Error:
 		sql, rows := fc()
-		l.logSQL("❌ SQL Error", sql, rows, elapsed, err)
+		l.logSQL(ctx, "❌ SQL Error", sql, rows, elapsed, err)
 		// 同时写入独立的SQL日志文件
 		if appLogger.SQLLogger != nil {
 			appLogger.SQLLogger.LogSQL(sql, nil, elapsed, rows, err)
 		}
 	case elapsed > l.config.SlowThreshold && l.config.SlowThreshold != 0 && l.config.LogLevel >= logger.Warn:
 		sql, rows := fc()
-		l.logSQL("🐌 Slow Query", sql, rows, elapsed, nil)
+		l.logSQL(ctx, "🐌 Slow Query", sql, rows, elapsed, nil)
 		// 同时写入独立的SQL日志文件
 		if appLogger.SQLLogger != nil {
 			appLogger.SQLLogger.LogSQL(sql, nil, elapsed, rows, nil)
 		}
 	case l.config.LogLevel == logger.Info:
 		sql, rows := fc()
-		l.logSQL("🔍 SQL Query", sql, rows, elapsed, nil)
+		l.logSQL(ctx, "🔍 SQL Query", sql, rows, elapsed, nil)
 		// 同时写入独立的SQL日志文件
 		if appLogger.SQLLogger != nil {
 			appLogger.SQLLogger.LogSQL(sql, nil, elapsed, rows, nil)
@@ -88,7 +88,7 @@ func (l *SQLLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 }
 
 // logSQL 格式化并记录SQL查询
-func (l *SQLLogger) logSQL(level, sql string, rows int64, elapsed time.Duration, err error) {
+func (l *SQLLogger) logSQL(ctx context.Context, level, sql string, rows int64, elapsed time.Duration, err error) {
 	// 清理和格式化SQL
 	formattedSQL := l.formatSQL(sql)
 
@@ -102,6 +102,8 @@ func (l *SQLLogger) logSQL(level, sql string, rows int64, elapsed time.Duration,
 	if err != nil {
 		fields = append(fields, zap.Error(err))
 	}
+	// ✨ 关联请求的 trace_id，便于从日志跳转到链路
+	fields = append(fields, appLogger.TraceFields(ctx)...)
 
 	// 根据查询类型使用不同的日志级别
 	if strings.Contains(strings.ToUpper(sql), "SELECT") {
@@ -11,6 +11,10 @@ import (
 // SQLiteProvider SQLite数据库提供者
 // SQLite不支持Schema，使用表名前缀作为降级方案
 // 例如：bse_xxx_tbl_yyy
+//
+// 适用范围：ProviderFactory 根据传入的 *gorm.DB 选用（嵌入式场景和测试）。
+// 服务端连接（NewConnection）和 SQL 迁移目前只支持 PostgreSQL，尚不能以 SQLite 单文件方式部署，
+// 剩余工作见 docs/architecture/overview.md「SQLite 支持现状」
 type SQLiteProvider struct {
 	db *gorm.DB
 }
@@ -150,23 +154,72 @@ func (s *SQLiteProvider) AddColumn(ctx context.Context, schemaName, tableName st
 	return nil
 }
 
-// AlterColumn 修改列（SQLite限制：需要重建表）
+// AlterColumn 修改列类型和约束（SQLite不支持ALTER COLUMN，通过重建表实现）
+// 与PostgreSQL版本一致：Type 为空时保留原类型，DefaultValue 为空时保留原默认值
+// 改为NOT NULL时，已有的NULL值填充为默认值
 func (s *SQLiteProvider) AlterColumn(ctx context.Context, schemaName, tableName, columnName string, newDef ColumnDefinition) error {
-	// SQLite不支持直接ALTER COLUMN，需要重建表
-	// 这是一个复杂的操作，暂时返回不支持错误
-	// 如果需要完整实现，需要：
-	// 1. 创建临时表
-	// 2. 复制数据
-	// 3. 删除原表
-	// 4. 重命名临时表
-	return fmt.Errorf("SQLite不支持直接修改列类型，需要重建表（暂未实现）")
+	fullTableName := s.GenerateTableName(schemaName, tableName)
+
+	err := s.rebuildTable(ctx, fullTableName, func(columns []sqliteColumn) ([]sqliteRebuildColumn, error) {
+		found := false
+		rebuild := make([]sqliteRebuildColumn, 0, len(columns))
+		for _, column := range columns {
+			source := s.quoteIdentifier(column.Name)
+			if column.Name == columnName {
+				found = true
+				if newDef.Type != "" {
+					column.Type = s.mapTypeToSQLite(newDef.Type)
+				}
+				if newDef.DefaultValue != nil {
+					column.Default = newDef.DefaultValue
+				}
+				column.NotNull = 0
+				if newDef.NotNull {
+					column.NotNull = 1
+					defaultValue := s.getDefaultValueForType(column.Type)
+					if column.Default != nil {
+						defaultValue = *column.Default
+					}
+					source = fmt.Sprintf("COALESCE(%s, %s)", source, defaultValue)
+				}
+			}
+			rebuild = append(rebuild, sqliteRebuildColumn{column: column, source: source})
+		}
+		if !found {
+			return nil, fmt.Errorf("列不存在: %s", columnName)
+		}
+		return rebuild, nil
+	})
+	if err != nil {
+		return fmt.Errorf("修改列失败: %w", err)
+	}
+
+	return nil
 }
 
-// DropColumn 删除列（SQLite限制：需要重建表）
+// DropColumn 删除列（通过重建表实现，兼容SQLite 3.35.0之前的版本）
+// 与PostgreSQL的 DROP COLUMN IF EXISTS ... CASCADE 一致：列不存在时忽略，引用该列的索引一并删除
 func (s *SQLiteProvider) DropColumn(ctx context.Context, schemaName, tableName, columnName string) error {
-	// SQLite不支持直接DROP COLUMN（SQLite 3.35.0之前的版本）
-	// 新版本支持，但为了兼容性，返回不支持错误
-	return fmt.Errorf("SQLite不支持直接删除列，需要重建表（暂未实现）")
+	fullTableName := s.GenerateTableName(schemaName, tableName)
+
+	err := s.rebuildTable(ctx, fullTableName, func(columns []sqliteColumn) ([]sqliteRebuildColumn, error) {
+		rebuild := make([]sqliteRebuildColumn, 0, len(columns))
+		for _, column := range columns {
+			if column.Name == columnName {
+				continue
+			}
+			rebuild = append(rebuild, sqliteRebuildColumn{column: column, source: s.quoteIdentifier(column.Name)})
+		}
+		if len(rebuild) == len(columns) {
+			return nil, nil
+		}
+		return rebuild, nil
+	})
+	if err != nil {
+		return fmt.Errorf("删除列失败: %w", err)
+	}
+
+	return nil
 }
 
 // ==================== 约束管理 ====================
@@ -198,14 +251,20 @@ func (s *SQLiteProvider) DropConstraint(ctx context.Context, schemaName, tableNa
 	return nil
 }
 
-// SetNotNull SQLite不支持修改列约束
+// SetNotNull 设置字段为NOT NULL（通过重建表实现，已有NULL值时失败，与PostgreSQL一致）
 func (s *SQLiteProvider) SetNotNull(ctx context.Context, schemaName, tableName, columnName string) error {
-	return fmt.Errorf("SQLite不支持修改列的NOT NULL约束")
+	if err := s.setColumnNotNull(ctx, schemaName, tableName, columnName, true); err != nil {
+		return fmt.Errorf("设置NOT NULL约束失败: %w", err)
+	}
+	return nil
 }
 
-// DropNotNull SQLite不支持修改列约束
+// DropNotNull 移除NOT NULL约束（通过重建表实现）
 func (s *SQLiteProvider) DropNotNull(ctx context.Context, schemaName, tableName, columnName string) error {
-	return fmt.Errorf("SQLite不支持修改列的NOT NULL约束")
+	if err := s.setColumnNotNull(ctx, schemaName, tableName, columnName, false); err != nil {
+		return fmt.Errorf("移除NOT NULL约束失败: %w", err)
+	}
+	return nil
 }
 
 // AddCheckConstraint SQLite不支持添加CHECK约束到现有表
@@ -232,7 +291,7 @@ func (s *SQLiteProvider) MapFieldTypeToDBType(fieldType string) string {
 		"percent":        "REAL",
 		"currency":       "REAL",
 		"date":           "DATETIME",
-		"checkbox":       "INTEGER", // 0 or 1
+		"checkbox":       "BOOLEAN", // 存储为0/1，读取为 bool
 		"singleSelect":   "TEXT",
 		"multipleSelect": "TEXT", // JSON string
 		"user":           "TEXT", // JSON string
@@ -265,6 +324,267 @@ func (s *SQLiteProvider) SupportsSchema() bool {
 	return false
 }
 
+// ==================== 表重建 ====================
+
+// sqliteColumn PRAGMA table_info 返回的列信息
+type sqliteColumn struct {
+	CID     int     `gorm:"column:cid"`
+	Name    string  `gorm:"column:name"`
+	Type    string  `gorm:"column:type"`
+	NotNull int     `gorm:"column:notnull"`
+	Default *string `gorm:"column:dflt_value"` // 默认值的SQL表达式
+	PK      int     `gorm:"column:pk"`
+}
+
+// sqliteRebuildColumn 重建后的列及其取值表达式（基于原表）
+type sqliteRebuildColumn struct {
+	column sqliteColumn
+	source string
+}
+
+// sqliteForeignKeyViolation PRAGMA foreign_key_check 返回的外键违反
+type sqliteForeignKeyViolation struct {
+	Table  string `gorm:"column:table"`
+	RowID  int64  `gorm:"column:rowid"`
+	Parent string `gorm:"column:parent"`
+	FKID   int    `gorm:"column:fkid"`
+}
+
+// sqliteIndex 重建时需要恢复的索引
+type sqliteIndex struct {
+	name    string
+	sql     string
+	columns []string
+}
+
+// setColumnNotNull 修改列的NOT NULL约束
+func (s *SQLiteProvider) setColumnNotNull(ctx context.Context, schemaName, tableName, columnName string, notNull bool) error {
+	return s.rebuildTable(ctx, s.GenerateTableName(schemaName, tableName), func(columns []sqliteColumn) ([]sqliteRebuildColumn, error) {
+		found := false
+		rebuild := make([]sqliteRebuildColumn, 0, len(columns))
+		for _, column := range columns {
+			if column.Name == columnName {
+				found = true
+				column.NotNull = 0
+				if notNull {
+					column.NotNull = 1
+				}
+			}
+			rebuild = append(rebuild, sqliteRebuildColumn{column: column, source: s.quoteIdentifier(column.Name)})
+		}
+		if !found {
+			return nil, fmt.Errorf("列不存在: %s", columnName)
+		}
+		return rebuild, nil
+	})
+}
+
+// rebuildTable 按SQLite推荐的方式重建表：创建临时表 -> 复制数据 -> 删除原表 -> 重命名
+//   - mutate 根据原表的列返回新表的列；返回 nil 表示无需重建
+//   - 保留主键与AUTOINCREMENT（包括自增序列的当前值，已删除记录的编号不会被复用）
+//   - 恢复原表的索引和唯一约束，引用已删除列的索引不再创建
+//   - 整个过程在一个事务中完成，失败时原表保持不变
+//   - 重建期间关闭外键约束（删除原表不会级联删除或拒绝），提交前用 foreign_key_check 检查外键完整性
+func (s *SQLiteProvider) rebuildTable(ctx context.Context, fullTableName string, mutate func([]sqliteColumn) ([]sqliteRebuildColumn, error)) error {
+	// PRAGMA foreign_keys 在事务中不生效，需在同一连接上于事务开始前设置
+	return s.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		var foreignKeys int
+		if err := conn.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error; err != nil {
+			return fmt.Errorf("读取外键设置失败: %w", err)
+		}
+		if foreignKeys == 1 {
+			if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+				return fmt.Errorf("关闭外键约束失败: %w", err)
+			}
+			defer conn.Exec("PRAGMA foreign_keys = ON")
+		}
+		return s.rebuildTableTx(conn, fullTableName, mutate)
+	})
+}
+
+// rebuildTableTx 在事务中重建表
+func (s *SQLiteProvider) rebuildTableTx(conn *gorm.DB, fullTableName string, mutate func([]sqliteColumn) ([]sqliteRebuildColumn, error)) error {
+	return conn.Transaction(func(tx *gorm.DB) error {
+		var columns []sqliteColumn
+		if err := tx.Raw(fmt.Sprintf("PRAGMA table_info(%s)", s.quoteIdentifier(fullTableName))).Scan(&columns).Error; err != nil {
+			return fmt.Errorf("读取表结构失败: %w", err)
+		}
+		if len(columns) == 0 {
+			return fmt.Errorf("表不存在: %s", fullTableName)
+		}
+
+		rebuild, err := mutate(columns)
+		if err != nil || rebuild == nil {
+			return err
+		}
+
+		var tableSQL string
+		if err := tx.Raw("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", fullTableName).Scan(&tableSQL).Error; err != nil {
+			return fmt.Errorf("读取建表语句失败: %w", err)
+		}
+		autoIncrement := strings.Contains(strings.ToUpper(tableSQL), "AUTOINCREMENT")
+
+		indexes, err := s.listIndexes(tx, fullTableName)
+		if err != nil {
+			return err
+		}
+
+		var sequence *int64
+		if autoIncrement {
+			if err := tx.Raw("SELECT seq FROM sqlite_sequence WHERE name = ?", fullTableName).Scan(&sequence).Error; err != nil {
+				return fmt.Errorf("读取自增序列失败: %w", err)
+			}
+		}
+
+		// 1. 创建临时表
+		tempTableName := fullTableName + "__rebuild"
+		definitions := make([]string, 0, len(rebuild))
+		names := make([]string, 0, len(rebuild))
+		sources := make([]string, 0, len(rebuild))
+		kept := make(map[string]bool, len(rebuild))
+		var primaryKeys []string
+		for _, item := range rebuild {
+			if item.column.PK > 0 {
+				primaryKeys = append(primaryKeys, s.quoteIdentifier(item.column.Name))
+			}
+		}
+		for _, item := range rebuild {
+			definitions = append(definitions, s.columnDefinition(item.column, len(primaryKeys) == 1, autoIncrement))
+			names = append(names, s.quoteIdentifier(item.column.Name))
+			sources = append(sources, item.source)
+			kept[item.column.Name] = true
+		}
+		if len(primaryKeys) > 1 {
+			// 复合主键作为表约束
+			definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+		}
+
+		statements := []string{
+			fmt.Sprintf("DROP TABLE IF EXISTS %s", s.quoteIdentifier(tempTableName)),
+			fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", s.quoteIdentifier(tempTableName), strings.Join(definitions, ",\n\t")),
+			// 2. 复制数据
+			fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s",
+				s.quoteIdentifier(tempTableName), strings.Join(names, ", "), strings.Join(sources, ", "), s.quoteIdentifier(fullTableName)),
+			// 3. 删除原表（索引随之删除）
+			fmt.Sprintf("DROP TABLE %s", s.quoteIdentifier(fullTableName)),
+			// 4. 重命名
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s", s.quoteIdentifier(tempTableName), s.quoteIdentifier(fullTableName)),
+		}
+
+		// 5. 恢复索引
+		for _, index := range indexes {
+			usable := true
+			for _, column := range index.columns {
+				if !kept[column] {
+					usable = false
+					break
+				}
+			}
+			if usable {
+				statements = append(statements, index.sql)
+			}
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("重建表失败: %w", err)
+			}
+		}
+
+		// 6. 恢复自增序列
+		if sequence != nil {
+			if err := tx.Exec("UPDATE sqlite_sequence SET seq = MAX(seq, ?) WHERE name = ?", *sequence, fullTableName).Error; err != nil {
+				return fmt.Errorf("恢复自增序列失败: %w", err)
+			}
+		}
+
+		// 7. 外键完整性检查，有违反时回滚
+		var violations []sqliteForeignKeyViolation
+		if err := tx.Raw("PRAGMA foreign_key_check").Scan(&violations).Error; err != nil {
+			return fmt.Errorf("检查外键失败: %w", err)
+		}
+		if len(violations) > 0 {
+			v := violations[0]
+			return fmt.Errorf("重建表后外键检查失败: 表 %s 的记录 %d 引用的 %s 不存在（共 %d 处）", v.Table, v.RowID, v.Parent, len(violations))
+		}
+
+		return nil
+	})
+}
+
+// listIndexes 读取表上的索引（不含主键）
+// 列定义中的UNIQUE约束生成的自动索引没有建表语句，重建后改为显式唯一索引
+func (s *SQLiteProvider) listIndexes(tx *gorm.DB, fullTableName string) ([]sqliteIndex, error) {
+	var list []struct {
+		Name   string `gorm:"column:name"`
+		Unique int    `gorm:"column:unique"`
+		Origin string `gorm:"column:origin"`
+	}
+	if err := tx.Raw(fmt.Sprintf("PRAGMA index_list(%s)", s.quoteIdentifier(fullTableName))).Scan(&list).Error; err != nil {
+		return nil, fmt.Errorf("读取索引失败: %w", err)
+	}
+
+	indexes := make([]sqliteIndex, 0, len(list))
+	for _, item := range list {
+		if item.Origin == "pk" {
+			continue
+		}
+
+		var columns []string
+		if err := tx.Raw(fmt.Sprintf("SELECT name FROM pragma_index_info(%s) ORDER BY seqno", quoteSQLiteString(item.Name))).Scan(&columns).Error; err != nil {
+			return nil, fmt.Errorf("读取索引列失败: %w", err)
+		}
+
+		index := sqliteIndex{name: item.Name, columns: columns}
+		if item.Origin == "c" {
+			if err := tx.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", item.Name).Scan(&index.sql).Error; err != nil {
+				return nil, fmt.Errorf("读取索引定义失败: %w", err)
+			}
+		}
+		if index.sql == "" {
+			quoted := make([]string, 0, len(columns))
+			for _, column := range columns {
+				quoted = append(quoted, s.quoteIdentifier(column))
+			}
+			unique := ""
+			if item.Unique == 1 {
+				unique = "UNIQUE "
+			}
+			index.sql = fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)",
+				unique,
+				s.quoteIdentifier(fmt.Sprintf("%s_%s_unique", fullTableName, strings.Join(columns, "_"))),
+				s.quoteIdentifier(fullTableName),
+				strings.Join(quoted, ", "),
+			)
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// columnDefinition 生成建表语句中的列定义
+// inlinePK 为 true 时主键写在列定义中（单列主键）
+func (s *SQLiteProvider) columnDefinition(column sqliteColumn, inlinePK, autoIncrement bool) string {
+	definition := s.quoteIdentifier(column.Name)
+	if column.Type != "" {
+		definition += " " + column.Type
+	}
+	if column.PK > 0 && inlinePK {
+		definition += " PRIMARY KEY"
+		if autoIncrement {
+			definition += " AUTOINCREMENT"
+		}
+	}
+	if column.NotNull == 1 {
+		definition += " NOT NULL"
+	}
+	if column.Default != nil {
+		definition += " DEFAULT " + *column.Default
+	}
+	return definition
+}
+
 // ==================== 私有辅助方法 ====================
 
 // quoteIdentifier 为标识符添加引号
@@ -274,6 +594,11 @@ func (s *SQLiteProvider) quoteIdentifier(identifier string) string {
 	return fmt.Sprintf(`"%s"`, cleaned)
 }
 
+// quoteSQLiteString SQL字符串字面量
+func quoteSQLiteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
 // mapTypeToSQLite 将PostgreSQL类型映射到SQLite类型
 func (s *SQLiteProvider) mapTypeToSQLite(pgType string) string {
 	upper := strings.ToUpper(pgType)
@@ -291,7 +616,8 @@ func (s *SQLiteProvider) mapTypeToSQLite(pgType string) string {
 		return "DATETIME"
 	}
 	if strings.Contains(upper, "BOOLEAN") {
-		return "INTEGER"
+		// 保留 BOOLEAN 声明（NUMERIC 亲和性，存储为0/1），驱动读取时还原为 bool
+		return "BOOLEAN"
 	}
 	if strings.Contains(upper, "JSONB") || strings.Contains(upper, "JSON") {
 		return "TEXT"
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestSQLiteProvider 创建单连接的SQLite内存库（内存库每个连接独立）
+func setupTestSQLiteProvider(t *testing.T) (*gorm.DB, *SQLiteProvider) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("创建SQLite连接失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取连接失败: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	return db, NewSQLiteProvider(db)
+}
+
+func TestSQLiteProvider_DropColumn(t *testing.T) {
+	db, provider := setupTestSQLiteProvider(t)
+	ctx := context.Background()
+
+	if err := provider.CreatePhysicalTable(ctx, "bse_001", "tbl_001"); err != nil {
+		t.Fatalf("创建物理表失败: %v", err)
+	}
+	for _, name := range []string{"field_a", "field_b"} {
+		if err := provider.AddColumn(ctx, "bse_001", "tbl_001", ColumnDefinition{Name: name, Type: "VARCHAR(255)", Unique: name == "field_b"}); err != nil {
+			t.Fatalf("添加列失败: %v", err)
+		}
+	}
+	if err := db.Exec(`INSERT INTO bse_001_tbl_001 (__id, __created_by, field_a, field_b) VALUES ('rec_1', 'usr', 'a', 'b')`).Error; err != nil {
+		t.Fatalf("插入数据失败: %v", err)
+	}
+
+	if err := provider.DropColumn(ctx, "bse_001", "tbl_001", "field_a"); err != nil {
+		t.Fatalf("删除列失败: %v", err)
+	}
+
+	var columns []sqliteColumn
+	db.Raw(`PRAGMA table_info("bse_001_tbl_001")`).Scan(&columns)
+	for _, column := range columns {
+		if column.Name == "field_a" {
+			t.Error("列应该已被删除")
+		}
+		if column.Name == "__auto_number" && column.PK != 1 {
+			t.Error("__auto_number 应保留为主键")
+		}
+	}
+
+	var fieldB string
+	db.Raw(`SELECT field_b FROM bse_001_tbl_001 WHERE __id = 'rec_1'`).Scan(&fieldB)
+	if fieldB != "b" {
+		t.Errorf("数据应保留，实际 %q", fieldB)
+	}
+	if err := db.Exec(`INSERT INTO bse_001_tbl_001 (__id, __created_by, field_b) VALUES ('rec_2', 'usr', 'b')`).Error; err == nil {
+		t.Error("唯一索引应在重建后保留")
+	}
+}
+
+func TestSQLiteProvider_AlterColumn(t *testing.T) {
+	db, provider := setupTestSQLiteProvider(t)
+	ctx := context.Background()
+
+	if err := provider.CreatePhysicalTable(ctx, "bse_001", "tbl_001"); err != nil {
+		t.Fatalf("创建物理表失败: %v", err)
+	}
+	if err := provider.AddColumn(ctx, "bse_001", "tbl_001", ColumnDefinition{Name: "field_n", Type: "VARCHAR(255)"}); err != nil {
+		t.Fatalf("添加列失败: %v", err)
+	}
+	db.Exec(`INSERT INTO bse_001_tbl_001 (__id, __created_by, field_n) VALUES ('rec_1', 'usr', '12'), ('rec_2', 'usr', NULL)`)
+
+	defaultValue := "0"
+	if err := provider.AlterColumn(ctx, "bse_001", "tbl_001", "field_n", ColumnDefinition{Type: "NUMERIC", NotNull: true, DefaultValue: &defaultValue}); err != nil {
+		t.Fatalf("修改列失败: %v", err)
+	}
+
+	var values []float64
+	db.Raw(`SELECT field_n FROM bse_001_tbl_001 ORDER BY __auto_number`).Scan(&values)
+	if len(values) != 2 || values[0] != 12 || values[1] != 0 {
+		t.Errorf("类型转换或NULL填充不正确: %v", values)
+	}
+
+	if err := provider.AlterColumn(ctx, "bse_001", "tbl_001", "field_missing", ColumnDefinition{Type: "TEXT"}); err == nil {
+		t.Error("修改不存在的列应该失败")
+	}
+}
+
+func TestSQLiteProvider_RebuildCompositePrimaryKey(t *testing.T) {
+	db, provider := setupTestSQLiteProvider(t)
+	ctx := context.Background()
+
+	if err := db.Exec(`CREATE TABLE bse_001_links (from_id TEXT NOT NULL, to_id TEXT NOT NULL, note TEXT, PRIMARY KEY (from_id, to_id))`).Error; err != nil {
+		t.Fatalf("创建表失败: %v", err)
+	}
+	db.Exec(`INSERT INTO bse_001_links VALUES ('a', 'b', 'x')`)
+
+	if err := provider.DropColumn(ctx, "bse_001", "links", "note"); err != nil {
+		t.Fatalf("删除列失败: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO bse_001_links VALUES ('a', 'b')`).Error; err == nil {
+		t.Error("复合主键应在重建后保留")
+	}
+}
+
+func TestSQLiteProvider_RebuildKeepsForeignKeys(t *testing.T) {
+	db, provider := setupTestSQLiteProvider(t)
+	ctx := context.Background()
+
+	for _, statement := range []string{
+		`PRAGMA foreign_keys = ON`,
+		`CREATE TABLE bse_001_parent (id TEXT PRIMARY KEY, name TEXT, note TEXT)`,
+		`CREATE TABLE bse_001_child (id TEXT PRIMARY KEY, parent_id TEXT REFERENCES bse_001_parent(id) ON DELETE CASCADE)`,
+		`INSERT INTO bse_001_parent (id, name, note) VALUES ('p1', 'a', 'x')`,
+		`INSERT INTO bse_001_child (id, parent_id) VALUES ('c1', 'p1')`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("准备数据失败: %v", err)
+		}
+	}
+
+	// 删除原表时不能级联删除子表记录
+	if err := provider.DropColumn(ctx, "bse_001", "parent", "note"); err != nil {
+		t.Fatalf("删除列失败: %v", err)
+	}
+	var children int64
+	db.Raw(`SELECT COUNT(*) FROM bse_001_child`).Scan(&children)
+	if children != 1 {
+		t.Errorf("子表记录应保留，实际 %d 条", children)
+	}
+
+	var foreignKeys int
+	db.Raw(`PRAGMA foreign_keys`).Scan(&foreignKeys)
+	if foreignKeys != 1 {
+		t.Error("重建后应恢复外键约束")
+	}
+
+	// 重建后外键不完整时回滚
+	db.Exec(`PRAGMA foreign_keys = OFF`)
+	db.Exec(`INSERT INTO bse_001_child (id, parent_id) VALUES ('c2', 'missing')`)
+	db.Exec(`PRAGMA foreign_keys = ON`)
+	if err := provider.DropColumn(ctx, "bse_001", "parent", "name"); err == nil {
+		t.Error("外键检查失败时应返回错误")
+	}
+	var columns []sqliteColumn
+	db.Raw(`PRAGMA table_info("bse_001_parent")`).Scan(&columns)
+	if len(columns) != 2 {
+		t.Errorf("失败时原表应保持不变，实际 %d 列", len(columns))
+	}
+}
@@ -0,0 +1,210 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/notification"
+)
+
+// defaultWebhookTimeout 未配置时的 Webhook 请求超时
+const defaultWebhookTimeout = 10 * time.Second
+
+// EmailNotifier 邮件通知器
+type EmailNotifier struct {
+	config EmailConfig
+}
+
+// EmailConfig 邮件配置
+type EmailConfig struct {
+	SMTPHost  string   `json:"smtp_host"`
+	SMTPPort  int      `json:"smtp_port"`
+	Username  string   `json:"username"`
+	Password  string   `json:"password"`
+	From      string   `json:"from"`
+	To        []string `json:"to"`
+	EnableTLS bool     `json:"enable_tls"`
+}
+
+// NewEmailNotifier 创建邮件通知器
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	return &EmailNotifier{
+		config: config,
+	}
+}
+
+// Notify 发送通知
+func (en *EmailNotifier) Notify(ctx context.Context, alert *Alert) error {
+	to := make([]*mail.Address, 0, len(en.config.To))
+	for _, raw := range en.config.To {
+		addr, err := mail.ParseAddress(raw)
+		if err != nil {
+			return fmt.Errorf("invalid alert recipient %q: %w", raw, err)
+		}
+		to = append(to, addr)
+	}
+
+	smtpConfig := config.SMTPConfig{
+		Host:     en.config.SMTPHost,
+		Port:     en.config.SMTPPort,
+		Username: en.config.Username,
+		Password: en.config.Password,
+		From:     en.config.From,
+		TLS:      en.config.EnableTLS,
+	}
+	return notification.SendMail(ctx, smtpConfig, to, alertSummary(alert), alertText(alert))
+}
+
+// Name 返回通知器名称
+func (en *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Type 返回通知器类型
+func (en *EmailNotifier) Type() string {
+	return "email"
+}
+
+// WebhookNotifier Webhook通知器
+//
+// 未配置模板时发送告警 JSON；配置了模板时用 text/template 渲染消息体，
+// 便于对接飞书、钉钉、Slack 等机器人。模板中可使用告警字段（.Name、.Status、.Value 等）
+// 和 json 函数（输出转义后的 JSON 字符串），渲染结果必须是合法的 JSON
+type WebhookNotifier struct {
+	config   WebhookConfig
+	template *template.Template
+	client   *http.Client
+}
+
+// WebhookConfig Webhook配置
+type WebhookConfig struct {
+	Name     string            `json:"name"`
+	URL      string            `json:"url"`
+	Method   string            `json:"method"`
+	Headers  map[string]string `json:"headers"`
+	Timeout  time.Duration     `json:"timeout"`
+	Template string            `json:"template"`
+}
+
+// NewWebhookNotifier 创建Webhook通知器
+func NewWebhookNotifier(config WebhookConfig) (*WebhookNotifier, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("webhook url is required")
+	}
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultWebhookTimeout
+	}
+
+	notifier := &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+	if strings.TrimSpace(config.Template) != "" {
+		tmpl, err := template.New(notifier.Name()).
+			Funcs(template.FuncMap{"json": templateJSON}).
+			Option("missingkey=zero").
+			Parse(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("parse webhook template: %w", err)
+		}
+		notifier.template = tmpl
+	}
+	return notifier, nil
+}
+
+// Notify 发送通知
+func (wn *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	body, err := wn.render(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, wn.config.Method, wn.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LuckDB-Alerting/1.0")
+	for key, value := range wn.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return nil
+}
+
+// render 生成消息体
+func (wn *WebhookNotifier) render(alert *Alert) ([]byte, error) {
+	if wn.template == nil {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return nil, fmt.Errorf("encode alert: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := wn.template.Execute(&buf, alert); err != nil {
+		return nil, fmt.Errorf("render webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook template did not produce valid JSON: %s", truncate(buf.String(), 200))
+	}
+	return buf.Bytes(), nil
+}
+
+// Name 返回通知器名称
+func (wn *WebhookNotifier) Name() string {
+	if wn.config.Name != "" {
+		return wn.config.Name
+	}
+	return "webhook"
+}
+
+// Type 返回通知器类型
+func (wn *WebhookNotifier) Type() string {
+	return "webhook"
+}
+
+// templateJSON 模板函数：将值编码为 JSON，字符串会带引号并转义
+func templateJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// truncate 截断过长的字符串
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+var (
+	_ AlertNotifier = (*LogNotifier)(nil)
+	_ AlertNotifier = (*EmailNotifier)(nil)
+	_ AlertNotifier = (*WebhookNotifier)(nil)
+)
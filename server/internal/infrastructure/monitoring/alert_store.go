@@ -0,0 +1,153 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+var (
+	// ErrAlertSilenceNotFound 静默不存在
+	ErrAlertSilenceNotFound = errors.New("alert silence not found")
+	// ErrInvalidAlertRule 告警规则不合法
+	ErrInvalidAlertRule = errors.New("invalid alert rule")
+)
+
+// AlertSilence 告警静默窗口
+// 窗口内匹配的规则照常评估和记录告警，但不发送通知
+type AlertSilence struct {
+	ID        string            `json:"id"`
+	RuleID    string            `json:"rule_id"`  // 指定规则；为空时按 Matchers 匹配
+	Matchers  map[string]string `json:"matchers"` // 规则标签需全部匹配；RuleID 与 Matchers 都为空时静默所有告警
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    time.Time         `json:"ends_at"`
+	Comment   string            `json:"comment"`
+	CreatedBy string            `json:"created_by"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NewAlertSilence 创建静默窗口
+func NewAlertSilence(ruleID string, matchers map[string]string, startsAt, endsAt time.Time, comment, createdBy string) *AlertSilence {
+	return &AlertSilence{
+		ID:        "als_" + utils.GenerateNanoID(utils.DefaultIDLength),
+		RuleID:    ruleID,
+		Matchers:  matchers,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Comment:   strings.TrimSpace(comment),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Active 静默在指定时间是否生效
+func (s *AlertSilence) Active(at time.Time) bool {
+	return !at.Before(s.StartsAt) && at.Before(s.EndsAt)
+}
+
+// Matches 静默是否作用于规则
+func (s *AlertSilence) Matches(rule *AlertRule) bool {
+	if s.RuleID != "" && s.RuleID != rule.ID {
+		return false
+	}
+	for key, value := range s.Matchers {
+		if rule.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// isSilenced 规则当前是否被静默
+func isSilenced(silences []*AlertSilence, rule *AlertRule, at time.Time) bool {
+	for _, silence := range silences {
+		if silence.Active(at) && silence.Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAlertRule 创建告警规则
+func NewAlertRule(name string, condition AlertCondition, severity AlertingSeverity, createdBy string) *AlertRule {
+	now := time.Now()
+	return &AlertRule{
+		ID:        "alr_" + utils.GenerateNanoID(utils.DefaultIDLength),
+		Name:      strings.TrimSpace(name),
+		Condition: condition,
+		Severity:  severity,
+		Enabled:   true,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate 校验规则
+func (r *AlertRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAlertRule)
+	}
+	if strings.TrimSpace(r.Condition.MetricName) == "" {
+		return fmt.Errorf("%w: metric name is required", ErrInvalidAlertRule)
+	}
+	switch r.Condition.Operator {
+	case OperatorGT, OperatorGTE, OperatorLT, OperatorLTE, OperatorEQ, OperatorNEQ:
+	default:
+		return fmt.Errorf("%w: unsupported operator %q", ErrInvalidAlertRule, r.Condition.Operator)
+	}
+	switch r.Condition.Function {
+	case "", FunctionValue, FunctionRate:
+	default:
+		return fmt.Errorf("%w: unsupported function %q", ErrInvalidAlertRule, r.Condition.Function)
+	}
+	switch r.Severity {
+	case AlertingSeverityInfo, AlertingSeverityWarning, AlertingSeverityCritical:
+	default:
+		return fmt.Errorf("%w: unsupported severity %q", ErrInvalidAlertRule, r.Severity)
+	}
+	if r.Condition.Duration < 0 || r.RepeatInterval < 0 {
+		return fmt.Errorf("%w: durations must not be negative", ErrInvalidAlertRule)
+	}
+	return nil
+}
+
+// AlertHistoryQuery 告警历史查询
+type AlertHistoryQuery struct {
+	RuleID string
+	Status AlertStatus
+	Limit  int
+	Offset int
+}
+
+// AlertStore 告警系统运行所需的存储
+type AlertStore interface {
+	ListEnabledRules(ctx context.Context) ([]*AlertRule, error)
+	ListActiveSilences(ctx context.Context, at time.Time) ([]*AlertSilence, error)
+	// ListOpenAlerts 实例上仍在触发的告警，用于重启后恢复状态
+	ListOpenAlerts(ctx context.Context, instance string) ([]*Alert, error)
+	// SaveAlert 按 ID 插入或更新告警历史
+	SaveAlert(ctx context.Context, alert *Alert) error
+}
+
+// AlertRepository 告警规则、静默与历史仓储
+type AlertRepository interface {
+	AlertStore
+
+	SaveRule(ctx context.Context, rule *AlertRule) error
+	// GetRule 规则不存在时返回 nil, nil
+	GetRule(ctx context.Context, id string) (*AlertRule, error)
+	ListRules(ctx context.Context) ([]*AlertRule, error)
+	DeleteRule(ctx context.Context, id string) error
+
+	ListAlerts(ctx context.Context, query AlertHistoryQuery) ([]*Alert, int64, error)
+
+	SaveSilence(ctx context.Context, silence *AlertSilence) error
+	// ListSilences 列出静默，includeExpired 为 false 时只返回未结束的
+	ListSilences(ctx context.Context, includeExpired bool, at time.Time) ([]*AlertSilence, error)
+	DeleteSilence(ctx context.Context, id string) error
+}
@@ -2,14 +2,30 @@ package monitoring
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
 )
 
+// notifyTimeout 单个通知渠道的发送超时
+const notifyTimeout = 15 * time.Second
+
 // AlertingSystem 告警系统
+//
+// 每个评估周期读取规则（配置了 AlertStore 时从数据库读取）并查询指标：
+//   - 条件首次满足时进入 pending，持续满足 Condition.Duration 后转为 firing 并发送通知
+//   - firing 期间不重复通知（去重），配置了 RepeatInterval 时按间隔重复提醒
+//   - 条件不再满足时转为 resolved，已通知过的告警发送恢复通知
+//   - 命中静默窗口的告警照常记录状态，但不发送通知；静默结束后仍在触发的告警补发通知
+//
+// 指标来自本进程，多实例部署时每个实例各自评估，告警按（规则，实例）区分
 type AlertingSystem struct {
 	rules     map[string]*AlertRule
 	notifiers map[string]AlertNotifier
@@ -18,20 +34,28 @@ type AlertingSystem struct {
 	interval  time.Duration
 	stopChan  chan struct{}
 	alerts    map[string]*Alert
+
+	store    AlertStore
+	instance string
+	samples  map[string]metricSample
+	now      func() time.Time
 }
 
 // AlertRule 告警规则
 type AlertRule struct {
-	ID          string                 `json:"id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Condition   AlertCondition         `json:"condition"`
-	Severity    AlertingSeverity       `json:"severity"`
-	Enabled     bool                   `json:"enabled"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
-	Labels      map[string]string      `json:"labels"`
-	Annotations map[string]interface{} `json:"annotations"`
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Condition      AlertCondition         `json:"condition"`
+	Severity       AlertingSeverity       `json:"severity"`
+	Enabled        bool                   `json:"enabled"`
+	Channels       []string               `json:"channels"`        // 通知渠道名，为空时发送到所有渠道
+	RepeatInterval time.Duration          `json:"repeat_interval"` // 持续触发时重复通知的间隔，0 表示只通知一次
+	CreatedBy      string                 `json:"created_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	Labels         map[string]string      `json:"labels"`
+	Annotations    map[string]interface{} `json:"annotations"`
 }
 
 // AlertCondition 告警条件
@@ -39,8 +63,9 @@ type AlertCondition struct {
 	MetricName string             `json:"metric_name"`
 	Operator   ComparisonOperator `json:"operator"`
 	Threshold  float64            `json:"threshold"`
-	Duration   time.Duration      `json:"duration"`
+	Duration   time.Duration      `json:"duration"` // 条件持续满足多久后触发
 	Labels     map[string]string  `json:"labels"`
+	Function   AlertFunction      `json:"function"`
 }
 
 // ComparisonOperator 比较操作符
@@ -55,6 +80,14 @@ const (
 	OperatorNEQ ComparisonOperator = "neq" // 不等于
 )
 
+// AlertFunction 指标取值方式
+type AlertFunction string
+
+const (
+	FunctionValue AlertFunction = "value" // 当前值（默认）
+	FunctionRate  AlertFunction = "rate"  // 两次评估之间的每秒增量，用于计数器
+)
+
 // AlertingSeverity 告警严重程度
 type AlertingSeverity string
 
@@ -66,20 +99,25 @@ const (
 
 // Alert 告警
 type Alert struct {
-	ID          string                 `json:"id"`
-	RuleID      string                 `json:"rule_id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Severity    AlertingSeverity       `json:"severity"`
-	Status      AlertStatus            `json:"status"`
-	StartedAt   time.Time              `json:"started_at"`
-	EndedAt     *time.Time             `json:"ended_at,omitempty"`
-	Labels      map[string]string      `json:"labels"`
-	Annotations map[string]interface{} `json:"annotations"`
-	Value       float64                `json:"value"`
-	Threshold   float64                `json:"threshold"`
-	FiredAt     time.Time              `json:"fired_at"`
-	ResolvedAt  *time.Time             `json:"resolved_at,omitempty"`
+	ID             string                 `json:"id"`
+	RuleID         string                 `json:"rule_id"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Severity       AlertingSeverity       `json:"severity"`
+	Status         AlertStatus            `json:"status"`
+	Instance       string                 `json:"instance"`
+	StartedAt      time.Time              `json:"started_at"`
+	EndedAt        *time.Time             `json:"ended_at,omitempty"`
+	Labels         map[string]string      `json:"labels"`
+	Annotations    map[string]interface{} `json:"annotations"`
+	Value          float64                `json:"value"`
+	Threshold      float64                `json:"threshold"`
+	FiredAt        time.Time              `json:"fired_at"`
+	ResolvedAt     *time.Time             `json:"resolved_at,omitempty"`
+	LastNotifiedAt *time.Time             `json:"last_notified_at,omitempty"`
+	NotifyCount    int                    `json:"notify_count"`
+	Silenced       bool                   `json:"silenced"`
+	LastError      string                 `json:"last_error,omitempty"`
 }
 
 // AlertStatus 告警状态
@@ -98,6 +136,18 @@ type AlertNotifier interface {
 	Type() string
 }
 
+// NotifyResult 单个渠道的通知结果
+type NotifyResult struct {
+	Channel string `json:"channel"`
+	Error   string `json:"error,omitempty"`
+}
+
+// metricSample 上一次评估的指标值，用于计算 rate
+type metricSample struct {
+	value float64
+	at    time.Time
+}
+
 // NewAlertingSystem 创建告警系统
 func NewAlertingSystem(interval time.Duration) *AlertingSystem {
 	return &AlertingSystem{
@@ -107,9 +157,27 @@ func NewAlertingSystem(interval time.Duration) *AlertingSystem {
 		interval:  interval,
 		stopChan:  make(chan struct{}),
 		alerts:    make(map[string]*Alert),
+		samples:   make(map[string]metricSample),
+		now:       time.Now,
 	}
 }
 
+// SetStore 设置规则、静默与告警历史的持久化存储
+// 设置后规则以存储为准（每个评估周期重新读取），AddRule 等内存规则不再生效
+func (as *AlertingSystem) SetStore(store AlertStore) {
+	as.store = store
+}
+
+// SetInstance 设置实例标识，写入告警以区分多实例
+func (as *AlertingSystem) SetInstance(instance string) {
+	as.instance = instance
+}
+
+// SetLogger 设置日志实例
+func (as *AlertingSystem) SetLogger(logger *zap.Logger) {
+	as.logger = logger
+}
+
 // AddRule 添加告警规则
 func (as *AlertingSystem) AddRule(rule *AlertRule) {
 	as.mu.Lock()
@@ -156,21 +224,45 @@ func (as *AlertingSystem) RemoveNotifier(name string) {
 	delete(as.notifiers, name)
 }
 
+// HasNotifier 是否存在指定名称的通知渠道
+func (as *AlertingSystem) HasNotifier(name string) bool {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	_, ok := as.notifiers[name]
+	return ok
+}
+
+// NotifierNames 通知渠道名称列表
+func (as *AlertingSystem) NotifierNames() []string {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	names := make([]string, 0, len(as.notifiers))
+	for name := range as.notifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Start 启动告警系统
 func (as *AlertingSystem) Start(ctx context.Context, storage MetricsStorage) {
 	ticker := time.NewTicker(as.interval)
 	defer ticker.Stop()
 
+	as.restore(ctx)
+
 	as.logger.Info("Starting alerting system",
 		zap.Duration("interval", as.interval),
-		zap.Int("rules", len(as.rules)),
+		zap.Bool("persistent", as.store != nil),
 		zap.Int("notifiers", len(as.notifiers)),
 	)
 
 	for {
 		select {
 		case <-ticker.C:
-			as.evaluateRules(ctx, storage)
+			as.Evaluate(ctx, storage)
 		case <-as.stopChan:
 			as.logger.Info("Alerting system stopped")
 			return
@@ -186,30 +278,133 @@ func (as *AlertingSystem) Stop() {
 	close(as.stopChan)
 }
 
-// evaluateRules 评估告警规则
-func (as *AlertingSystem) evaluateRules(ctx context.Context, storage MetricsStorage) {
+// restore 重启后恢复本实例仍在触发的告警，避免重复通知
+func (as *AlertingSystem) restore(ctx context.Context) {
+	if as.store == nil {
+		return
+	}
+	open, err := as.store.ListOpenAlerts(ctx, as.instance)
+	if err != nil {
+		as.logger.Error("Failed to restore open alerts", zap.Error(err))
+		return
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	for _, alert := range open {
+		as.alerts[alert.RuleID] = alert
+	}
+}
+
+// Evaluate 评估一轮告警规则
+func (as *AlertingSystem) Evaluate(ctx context.Context, storage MetricsStorage) {
+	now := as.now()
+
+	rules, err := as.loadRules(ctx)
+	if err != nil {
+		as.logger.Error("Failed to load alert rules", zap.Error(err))
+		return
+	}
+	silences := as.loadSilences(ctx, now)
+
+	active := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		active[rule.ID] = true
+		as.evaluateRule(ctx, storage, rule, silences, now)
+	}
+
+	// 规则已删除或停用：结束其告警，不再发送通知
+	for _, alert := range as.GetAlerts() {
+		if !active[alert.RuleID] {
+			as.finish(ctx, nil, alert, now)
+		}
+	}
+}
+
+// loadRules 读取启用的规则
+func (as *AlertingSystem) loadRules(ctx context.Context) ([]*AlertRule, error) {
+	if as.store != nil {
+		return as.store.ListEnabledRules(ctx)
+	}
+
 	as.mu.RLock()
+	defer as.mu.RUnlock()
 	rules := make([]*AlertRule, 0, len(as.rules))
 	for _, rule := range as.rules {
 		if rule.Enabled {
 			rules = append(rules, rule)
 		}
 	}
-	as.mu.RUnlock()
+	return rules, nil
+}
 
-	for _, rule := range rules {
-		go as.evaluateRule(ctx, storage, rule)
+// loadSilences 读取当前生效的静默
+func (as *AlertingSystem) loadSilences(ctx context.Context, now time.Time) []*AlertSilence {
+	if as.store == nil {
+		return nil
 	}
+	silences, err := as.store.ListActiveSilences(ctx, now)
+	if err != nil {
+		// 读取失败时按未静默处理，宁可多发也不漏发
+		as.logger.Warn("Failed to load alert silences", zap.Error(err))
+		return nil
+	}
+	return silences
 }
 
 // evaluateRule 评估单个告警规则
-func (as *AlertingSystem) evaluateRule(ctx context.Context, storage MetricsStorage, rule *AlertRule) {
-	// 查询指标数据
+func (as *AlertingSystem) evaluateRule(ctx context.Context, storage MetricsStorage, rule *AlertRule, silences []*AlertSilence, now time.Time) {
+	value, ok := as.queryValue(ctx, storage, rule, now)
+	if !ok {
+		// 没有数据时保持现状
+		return
+	}
+
+	alert := as.activeAlert(rule.ID)
+	if !as.evaluateCondition(value, rule.Condition) {
+		if alert != nil {
+			as.finish(ctx, rule, alert, now)
+		}
+		return
+	}
+
+	if alert == nil {
+		alert = as.newAlert(rule, now)
+	}
+	alert.Value = value
+	alert.Silenced = isSilenced(silences, rule, now)
+
+	switch alert.Status {
+	case AlertStatusPending:
+		if now.Sub(alert.StartedAt) < rule.Condition.Duration {
+			as.setActive(alert)
+			return
+		}
+		alert.Status = AlertStatusFiring
+		alert.FiredAt = now
+		if !alert.Silenced {
+			as.notify(ctx, rule, alert, now)
+		}
+		as.save(ctx, alert)
+	case AlertStatusFiring:
+		// 去重：未通知过（静默中触发或发送失败）时补发，否则按重复间隔提醒
+		due := alert.LastNotifiedAt == nil ||
+			(rule.RepeatInterval > 0 && now.Sub(*alert.LastNotifiedAt) >= rule.RepeatInterval)
+		if !alert.Silenced && due {
+			as.notify(ctx, rule, alert, now)
+			as.save(ctx, alert)
+		}
+	}
+	as.setActive(alert)
+}
+
+// queryValue 查询规则对应的指标值
+func (as *AlertingSystem) queryValue(ctx context.Context, storage MetricsStorage, rule *AlertRule, now time.Time) (float64, bool) {
 	query := MetricsQuery{
 		Name:      rule.Condition.MetricName,
 		Labels:    rule.Condition.Labels,
-		EndTime:   time.Now(),
-		StartTime: time.Now().Add(-rule.Condition.Duration),
+		EndTime:   now,
+		StartTime: now.Add(-as.interval),
 		Limit:     1,
 	}
 
@@ -219,12 +414,12 @@ func (as *AlertingSystem) evaluateRule(ctx context.Context, storage MetricsStora
 			zap.String("rule_id", rule.ID),
 			zap.Error(err),
 		)
-		return
+		return 0, false
 	}
 
 	if len(data) == 0 {
 		// 没有数据，可能是指标不存在
-		return
+		return 0, false
 	}
 
 	metric := data[0]
@@ -235,15 +430,26 @@ func (as *AlertingSystem) evaluateRule(ctx context.Context, storage MetricsStora
 			zap.String("metric_name", rule.Condition.MetricName),
 			zap.Any("value", metric.Value),
 		)
-		return
+		return 0, false
 	}
 
-	// 检查条件
-	if as.evaluateCondition(*value, rule.Condition) {
-		as.handleAlertFired(ctx, rule, *value)
-	} else {
-		as.handleAlertResolved(ctx, rule)
+	if rule.Condition.Function != FunctionRate {
+		return *value, true
 	}
+
+	as.mu.Lock()
+	previous, seen := as.samples[rule.ID]
+	as.samples[rule.ID] = metricSample{value: *value, at: now}
+	as.mu.Unlock()
+	if !seen || !now.After(previous.at) {
+		return 0, false
+	}
+	delta := *value - previous.value
+	if delta < 0 {
+		// 计数器重置（如进程重启）
+		delta = *value
+	}
+	return delta / now.Sub(previous.at).Seconds(), true
 }
 
 // extractNumericValue 提取数值
@@ -288,81 +494,158 @@ func (as *AlertingSystem) evaluateCondition(value float64, condition AlertCondit
 	}
 }
 
-// handleAlertFired 处理告警触发
-func (as *AlertingSystem) handleAlertFired(ctx context.Context, rule *AlertRule, value float64) {
-	alertID := fmt.Sprintf("%s_%d", rule.ID, time.Now().Unix())
-
-	as.mu.Lock()
-	existing, exists := as.alerts[rule.ID]
-	if exists && existing.Status == AlertStatusFiring {
-		// 告警已经存在且正在触发，更新最后触发时间
-		existing.FiredAt = time.Now()
-		existing.Value = value
-		as.mu.Unlock()
-		return
-	}
-
-	alert := &Alert{
-		ID:          alertID,
+// newAlert 条件首次满足时创建 pending 告警
+func (as *AlertingSystem) newAlert(rule *AlertRule, now time.Time) *Alert {
+	return &Alert{
+		ID:          "alt_" + utils.GenerateNanoID(utils.DefaultIDLength),
 		RuleID:      rule.ID,
 		Name:        rule.Name,
 		Description: rule.Description,
 		Severity:    rule.Severity,
-		Status:      AlertStatusFiring,
-		StartedAt:   time.Now(),
+		Status:      AlertStatusPending,
+		Instance:    as.instance,
+		StartedAt:   now,
 		Labels:      rule.Labels,
 		Annotations: rule.Annotations,
-		Value:       value,
 		Threshold:   rule.Condition.Threshold,
-		FiredAt:     time.Now(),
 	}
-
-	as.alerts[rule.ID] = alert
-	as.mu.Unlock()
-
-	// 发送通知
-	as.sendNotifications(ctx, alert)
 }
 
-// handleAlertResolved 处理告警解决
-func (as *AlertingSystem) handleAlertResolved(ctx context.Context, rule *AlertRule) {
+// finish 条件不再满足或规则被移除时结束告警
+// 从未触发的 pending 告警直接丢弃；已触发的记录恢复时间，rule 不为空且已通知过时发送恢复通知
+func (as *AlertingSystem) finish(ctx context.Context, rule *AlertRule, alert *Alert, now time.Time) {
 	as.mu.Lock()
-	alert, exists := as.alerts[rule.ID]
-	if !exists || alert.Status != AlertStatusFiring {
-		as.mu.Unlock()
+	delete(as.alerts, alert.RuleID)
+	as.mu.Unlock()
+
+	if alert.Status != AlertStatusFiring {
 		return
 	}
 
-	now := time.Now()
 	alert.Status = AlertStatusResolved
 	alert.EndedAt = &now
 	alert.ResolvedAt = &now
-	as.mu.Unlock()
-
-	// 发送解决通知
-	as.sendNotifications(ctx, alert)
+	if rule != nil && alert.LastNotifiedAt != nil && !alert.Silenced {
+		as.notify(ctx, rule, alert, now)
+	}
+	as.save(ctx, alert)
 }
 
-// sendNotifications 发送通知
-func (as *AlertingSystem) sendNotifications(ctx context.Context, alert *Alert) {
-	as.mu.RLock()
-	notifiers := make([]AlertNotifier, 0, len(as.notifiers))
-	for _, notifier := range as.notifiers {
-		notifiers = append(notifiers, notifier)
-	}
-	as.mu.RUnlock()
+// notify 按规则选择的渠道发送通知，任一渠道成功即视为已通知
+func (as *AlertingSystem) notify(ctx context.Context, rule *AlertRule, alert *Alert, now time.Time) {
+	results := as.sendNotifications(ctx, rule.Channels, alert)
 
-	for _, notifier := range notifiers {
-		go func(n AlertNotifier) {
-			if err := n.Notify(ctx, alert); err != nil {
+	failures := make([]string, 0)
+	for _, result := range results {
+		if result.Error != "" {
+			failures = append(failures, result.Channel+": "+result.Error)
+		}
+	}
+	alert.LastError = strings.Join(failures, "; ")
+	if len(results) > 0 && len(failures) == len(results) {
+		// 全部失败：不更新通知时间，下个周期重试
+		return
+	}
+	alert.LastNotifiedAt = &now
+	alert.NotifyCount++
+}
+
+// sendNotifications 并发发送通知并等待结果
+func (as *AlertingSystem) sendNotifications(ctx context.Context, channels []string, alert *Alert) []NotifyResult {
+	notifiers := as.selectNotifiers(channels)
+	results := make([]NotifyResult, len(notifiers))
+	snapshot := *alert
+
+	var wg sync.WaitGroup
+	for i, notifier := range notifiers {
+		wg.Add(1)
+		go func(i int, n AlertNotifier) {
+			defer wg.Done()
+			sendCtx, cancel := context.WithTimeout(ctx, notifyTimeout)
+			defer cancel()
+
+			results[i] = NotifyResult{Channel: n.Name()}
+			if err := n.Notify(sendCtx, &snapshot); err != nil {
+				results[i].Error = err.Error()
 				as.logger.Error("Failed to send alert notification",
 					zap.String("alert_id", alert.ID),
 					zap.String("notifier", n.Name()),
 					zap.Error(err),
 				)
 			}
-		}(notifier)
+		}(i, notifier)
+	}
+	wg.Wait()
+	return results
+}
+
+// selectNotifiers 选择通知渠道，channels 为空时使用全部渠道
+func (as *AlertingSystem) selectNotifiers(channels []string) []AlertNotifier {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	notifiers := make([]AlertNotifier, 0, len(as.notifiers))
+	if len(channels) == 0 {
+		for _, notifier := range as.notifiers {
+			notifiers = append(notifiers, notifier)
+		}
+		return notifiers
+	}
+	for _, name := range channels {
+		if notifier, ok := as.notifiers[name]; ok {
+			notifiers = append(notifiers, notifier)
+		} else {
+			as.logger.Warn("Alert channel not configured", zap.String("channel", name))
+		}
 	}
+	return notifiers
+}
+
+// TestNotify 用规则的渠道发送一条测试告警，返回各渠道结果
+func (as *AlertingSystem) TestNotify(ctx context.Context, rule *AlertRule) ([]NotifyResult, error) {
+	if len(as.selectNotifiers(rule.Channels)) == 0 {
+		return nil, errors.New("no alert channel configured")
+	}
+	now := as.now()
+	alert := as.newAlert(rule, now)
+	alert.Name = "[测试] " + rule.Name
+	alert.Status = AlertStatusFiring
+	alert.FiredAt = now
+	return as.sendNotifications(ctx, rule.Channels, alert), nil
+}
+
+// save 持久化告警历史
+func (as *AlertingSystem) save(ctx context.Context, alert *Alert) {
+	if as.store == nil {
+		return
+	}
+	if err := as.store.SaveAlert(ctx, alert); err != nil {
+		as.logger.Error("Failed to save alert",
+			zap.String("alert_id", alert.ID),
+			zap.String("rule_id", alert.RuleID),
+			zap.Error(err),
+		)
+	}
+}
+
+// activeAlert 获取规则当前的告警副本
+func (as *AlertingSystem) activeAlert(ruleID string) *Alert {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	if alert, ok := as.alerts[ruleID]; ok {
+		copied := *alert
+		return &copied
+	}
+	return nil
+}
+
+// setActive 保存规则当前的告警
+func (as *AlertingSystem) setActive(alert *Alert) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	as.alerts[alert.RuleID] = alert
 }
 
 // GetAlerts 获取告警列表
@@ -372,7 +655,8 @@ func (as *AlertingSystem) GetAlerts() []*Alert {
 
 	alerts := make([]*Alert, 0, len(as.alerts))
 	for _, alert := range as.alerts {
-		alerts = append(alerts, alert)
+		copied := *alert
+		alerts = append(alerts, &copied)
 	}
 	return alerts
 }
@@ -440,95 +724,41 @@ func (ln *LogNotifier) Type() string {
 	return "log"
 }
 
-// EmailNotifier 邮件通知器
-type EmailNotifier struct {
-	config EmailConfig
-	logger *zap.Logger
+// alertSummary 告警的一行摘要，用于邮件主题等
+func alertSummary(alert *Alert) string {
+	return fmt.Sprintf("[%s][%s] %s", strings.ToUpper(string(alert.Status)), alert.Severity, alert.Name)
 }
 
-// EmailConfig 邮件配置
-type EmailConfig struct {
-	SMTPHost  string   `json:"smtp_host"`
-	SMTPPort  int      `json:"smtp_port"`
-	Username  string   `json:"username"`
-	Password  string   `json:"password"`
-	From      string   `json:"from"`
-	To        []string `json:"to"`
-	EnableTLS bool     `json:"enable_tls"`
-}
-
-// NewEmailNotifier 创建邮件通知器
-func NewEmailNotifier(config EmailConfig) *EmailNotifier {
-	return &EmailNotifier{
-		config: config,
-		logger: zap.L(),
+// alertText 告警的纯文本描述
+func alertText(alert *Alert) string {
+	var b strings.Builder
+	b.WriteString(alertSummary(alert) + "\n\n")
+	if alert.Description != "" {
+		b.WriteString(alert.Description + "\n\n")
 	}
-}
-
-// Notify 发送通知
-func (en *EmailNotifier) Notify(ctx context.Context, alert *Alert) error {
-	// 这里应该实现邮件发送逻辑
-	// 为了简化，这里只记录日志
-
-	en.logger.Info("Sending email alert notification",
-		zap.String("alert_id", alert.ID),
-		zap.String("to", fmt.Sprintf("%v", en.config.To)),
-	)
-
-	return nil
-}
-
-// Name 返回通知器名称
-func (en *EmailNotifier) Name() string {
-	return "email"
-}
-
-// Type 返回通知器类型
-func (en *EmailNotifier) Type() string {
-	return "email"
-}
-
-// WebhookNotifier Webhook通知器
-type WebhookNotifier struct {
-	config WebhookConfig
-	logger *zap.Logger
-}
-
-// WebhookConfig Webhook配置
-type WebhookConfig struct {
-	URL     string            `json:"url"`
-	Method  string            `json:"method"`
-	Headers map[string]string `json:"headers"`
-	Timeout time.Duration     `json:"timeout"`
-}
-
-// NewWebhookNotifier 创建Webhook通知器
-func NewWebhookNotifier(config WebhookConfig) *WebhookNotifier {
-	return &WebhookNotifier{
-		config: config,
-		logger: zap.L(),
+	fmt.Fprintf(&b, "当前值: %g\n", alert.Value)
+	fmt.Fprintf(&b, "阈值: %g\n", alert.Threshold)
+	if alert.Instance != "" {
+		fmt.Fprintf(&b, "实例: %s\n", alert.Instance)
 	}
-}
-
-// Notify 发送通知
-func (wn *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
-	// 这里应该实现Webhook发送逻辑
-	// 为了简化，这里只记录日志
-
-	wn.logger.Info("Sending webhook alert notification",
-		zap.String("alert_id", alert.ID),
-		zap.String("url", wn.config.URL),
-	)
-
-	return nil
-}
-
-// Name 返回通知器名称
-func (wn *WebhookNotifier) Name() string {
-	return "webhook"
-}
-
-// Type 返回通知器类型
-func (wn *WebhookNotifier) Type() string {
-	return "webhook"
+	fmt.Fprintf(&b, "开始时间: %s\n", alert.StartedAt.Format(time.RFC3339))
+	if !alert.FiredAt.IsZero() {
+		fmt.Fprintf(&b, "触发时间: %s\n", alert.FiredAt.Format(time.RFC3339))
+	}
+	if alert.ResolvedAt != nil {
+		fmt.Fprintf(&b, "恢复时间: %s\n", alert.ResolvedAt.Format(time.RFC3339))
+	}
+	if len(alert.Labels) > 0 {
+		keys := make([]string, 0, len(alert.Labels))
+		for key := range alert.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("标签:\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "  %s=%s\n", key, alert.Labels[key])
+		}
+	}
+	b.WriteString("告警 ID: " + alert.ID + "\n")
+	return b.String()
 }
@@ -0,0 +1,315 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeMetrics 返回固定值的指标存储
+type fakeMetrics struct {
+	value float64
+}
+
+func (f *fakeMetrics) Store(ctx context.Context, data *MetricData) error { return nil }
+
+func (f *fakeMetrics) Delete(ctx context.Context, name string, before time.Time) error { return nil }
+
+func (f *fakeMetrics) Query(ctx context.Context, query MetricsQuery) ([]*MetricData, error) {
+	return []*MetricData{{Name: query.Name, Value: f.value}}, nil
+}
+
+// fakeAlertStore 内存告警存储
+type fakeAlertStore struct {
+	mu       sync.Mutex
+	rules    []*AlertRule
+	silences []*AlertSilence
+	saved    map[string]Alert
+}
+
+func (s *fakeAlertStore) ListEnabledRules(ctx context.Context) ([]*AlertRule, error) {
+	return s.rules, nil
+}
+
+func (s *fakeAlertStore) ListActiveSilences(ctx context.Context, at time.Time) ([]*AlertSilence, error) {
+	return s.silences, nil
+}
+
+func (s *fakeAlertStore) ListOpenAlerts(ctx context.Context, instance string) ([]*Alert, error) {
+	return nil, nil
+}
+
+func (s *fakeAlertStore) SaveAlert(ctx context.Context, alert *Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved[alert.ID] = *alert
+	return nil
+}
+
+// recordingNotifier 记录收到的通知
+type recordingNotifier struct {
+	mu     sync.Mutex
+	name   string
+	err    error
+	alerts []Alert
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, alert *Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, *alert)
+	return n.err
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Type() string { return "test" }
+
+func (n *recordingNotifier) statuses() []AlertStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	statuses := make([]AlertStatus, 0, len(n.alerts))
+	for _, alert := range n.alerts {
+		statuses = append(statuses, alert.Status)
+	}
+	return statuses
+}
+
+// newTestAlerting 创建使用可控时钟的告警系统
+func newTestAlerting(rule *AlertRule) (*AlertingSystem, *fakeAlertStore, *recordingNotifier, *time.Time) {
+	store := &fakeAlertStore{rules: []*AlertRule{rule}, saved: make(map[string]Alert)}
+	notifier := &recordingNotifier{name: "test"}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	as := NewAlertingSystem(time.Minute)
+	as.SetLogger(zap.NewNop())
+	as.SetStore(store)
+	as.SetInstance("node-1")
+	as.AddNotifier(notifier)
+	as.now = func() time.Time { return now }
+	return as, store, notifier, &now
+}
+
+func TestAlertingSystem_FiresOnceAndResolves(t *testing.T) {
+	rule := NewAlertRule("高延迟", AlertCondition{
+		MetricName: "latency",
+		Operator:   OperatorGT,
+		Threshold:  1,
+		Duration:   2 * time.Minute,
+	}, AlertingSeverityWarning, "usr_admin")
+	as, store, notifier, now := newTestAlerting(rule)
+	metrics := &fakeMetrics{value: 5}
+	ctx := context.Background()
+
+	// 条件持续时间不足：只进入 pending，不通知也不落库
+	as.Evaluate(ctx, metrics)
+	*now = now.Add(time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Empty(t, notifier.statuses())
+	assert.Empty(t, store.saved)
+	require.Len(t, as.GetAlerts(), 1)
+	assert.Equal(t, AlertStatusPending, as.GetAlerts()[0].Status)
+
+	// 持续满足后触发，之后的周期去重
+	*now = now.Add(time.Minute)
+	as.Evaluate(ctx, metrics)
+	*now = now.Add(time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Equal(t, []AlertStatus{AlertStatusFiring}, notifier.statuses())
+
+	// 恢复时发送恢复通知并记录恢复时间
+	metrics.value = 0
+	*now = now.Add(time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Equal(t, []AlertStatus{AlertStatusFiring, AlertStatusResolved}, notifier.statuses())
+	assert.Empty(t, as.GetAlerts())
+
+	require.Len(t, store.saved, 1)
+	for _, alert := range store.saved {
+		assert.Equal(t, AlertStatusResolved, alert.Status)
+		assert.Equal(t, "node-1", alert.Instance)
+		assert.Equal(t, 2, alert.NotifyCount)
+		require.NotNil(t, alert.ResolvedAt)
+		assert.Equal(t, *now, *alert.ResolvedAt)
+		assert.Equal(t, alert.StartedAt.Add(2*time.Minute), alert.FiredAt)
+	}
+}
+
+func TestAlertingSystem_RepeatIntervalAndRetry(t *testing.T) {
+	rule := NewAlertRule("错误数", AlertCondition{
+		MetricName: "errors",
+		Operator:   OperatorGTE,
+		Threshold:  1,
+	}, AlertingSeverityCritical, "usr_admin")
+	rule.RepeatInterval = 10 * time.Minute
+	as, _, notifier, now := newTestAlerting(rule)
+	metrics := &fakeMetrics{value: 1}
+	ctx := context.Background()
+
+	// 渠道失败时下个周期重试
+	notifier.err = errors.New("smtp down")
+	as.Evaluate(ctx, metrics)
+	require.Len(t, as.GetAlerts(), 1)
+	assert.Nil(t, as.GetAlerts()[0].LastNotifiedAt)
+	assert.Contains(t, as.GetAlerts()[0].LastError, "smtp down")
+
+	notifier.err = nil
+	*now = now.Add(time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Len(t, notifier.statuses(), 2)
+
+	// 重复间隔内不再通知，到期后再提醒
+	*now = now.Add(5 * time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Len(t, notifier.statuses(), 2)
+	*now = now.Add(5 * time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Len(t, notifier.statuses(), 3)
+}
+
+func TestAlertingSystem_Silence(t *testing.T) {
+	rule := NewAlertRule("队列积压", AlertCondition{
+		MetricName: "queue",
+		Operator:   OperatorGT,
+		Threshold:  100,
+	}, AlertingSeverityWarning, "usr_admin")
+	rule.Labels = map[string]string{"team": "core"}
+	as, store, notifier, now := newTestAlerting(rule)
+	metrics := &fakeMetrics{value: 500}
+	ctx := context.Background()
+
+	store.silences = []*AlertSilence{
+		NewAlertSilence("", map[string]string{"team": "core"}, *now, now.Add(10*time.Minute), "维护", "usr_admin"),
+	}
+
+	// 静默中：照常记录，不通知
+	as.Evaluate(ctx, metrics)
+	assert.Empty(t, notifier.statuses())
+	require.Len(t, store.saved, 1)
+	for _, alert := range store.saved {
+		assert.Equal(t, AlertStatusFiring, alert.Status)
+		assert.True(t, alert.Silenced)
+	}
+
+	// 静默结束后仍在触发：补发通知
+	*now = now.Add(15 * time.Minute)
+	as.Evaluate(ctx, metrics)
+	assert.Equal(t, []AlertStatus{AlertStatusFiring}, notifier.statuses())
+}
+
+func TestAlertingSystem_RemovedRuleResolvesSilently(t *testing.T) {
+	rule := NewAlertRule("连接数", AlertCondition{
+		MetricName: "connections",
+		Operator:   OperatorGT,
+		Threshold:  10,
+	}, AlertingSeverityInfo, "usr_admin")
+	as, store, notifier, now := newTestAlerting(rule)
+	ctx := context.Background()
+
+	as.Evaluate(ctx, &fakeMetrics{value: 20})
+	require.Len(t, notifier.statuses(), 1)
+
+	store.rules = nil
+	*now = now.Add(time.Minute)
+	as.Evaluate(ctx, &fakeMetrics{value: 20})
+	assert.Len(t, notifier.statuses(), 1)
+	assert.Empty(t, as.GetAlerts())
+	for _, alert := range store.saved {
+		assert.Equal(t, AlertStatusResolved, alert.Status)
+	}
+}
+
+func TestWebhookNotifier_Template(t *testing.T) {
+	var received map[string]interface{}
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Token")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{
+		Name:     "ops",
+		URL:      server.URL,
+		Headers:  map[string]string{"X-Token": "secret"},
+		Template: `{"text": {{ json (printf "[%s] %s" .Status .Name) }}, "value": {{ .Value }}}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ops", notifier.Name())
+
+	err = notifier.Notify(context.Background(), &Alert{Name: `CPU "高"`, Status: AlertStatusFiring, Value: 0.9})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", header)
+	assert.Equal(t, `[firing] CPU "高"`, received["text"])
+	assert.Equal(t, 0.9, received["value"])
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{URL: server.URL})
+	require.NoError(t, err)
+	assert.ErrorContains(t, notifier.Notify(context.Background(), &Alert{Name: "x"}), "502")
+
+	invalid, err := NewWebhookNotifier(WebhookConfig{URL: server.URL, Template: `{"text": {{ .Name }}}`})
+	require.NoError(t, err)
+	assert.ErrorContains(t, invalid.Notify(context.Background(), &Alert{Name: "not quoted"}), "valid JSON")
+
+	_, err = NewWebhookNotifier(WebhookConfig{})
+	assert.Error(t, err)
+}
+
+func TestRegistryMetricsStorage_Query(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests_total"}, []string{"route", "status"})
+	latency := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "latency_seconds"})
+	registry.MustRegister(requests, latency)
+
+	requests.WithLabelValues("/a", "500").Add(3)
+	requests.WithLabelValues("/b", "500").Add(2)
+	requests.WithLabelValues("/a", "200").Add(10)
+	latency.Observe(0.5)
+	latency.Observe(1.5)
+
+	storage := NewRegistryMetricsStorage(registry)
+	query := func(name string, labels map[string]string) []*MetricData {
+		data, err := storage.Query(context.Background(), MetricsQuery{Name: name, Labels: labels})
+		require.NoError(t, err)
+		return data
+	}
+
+	data := query("requests_total", map[string]string{"status": "500"})
+	require.Len(t, data, 1)
+	assert.Equal(t, 5.0, data[0].Value)
+
+	data = query("requests_total", nil)
+	require.Len(t, data, 1)
+	assert.Equal(t, 15.0, data[0].Value)
+
+	data = query("latency_seconds_count", nil)
+	require.Len(t, data, 1)
+	assert.Equal(t, 2.0, data[0].Value)
+
+	data = query("latency_seconds_sum", nil)
+	require.Len(t, data, 1)
+	assert.Equal(t, 2.0, data[0].Value)
+
+	assert.Empty(t, query("requests_total", map[string]string{"status": "404"}))
+	assert.Empty(t, query("missing", nil))
+}
@@ -0,0 +1,133 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// RegistryMetricsStorage 以 Prometheus 注册表为数据源的只读指标存储
+//
+// 查询时采集一次注册表，按指标名和标签（子集匹配）筛选后对所有匹配序列求和：
+//   - counter / gauge / untyped：直接取值
+//   - histogram / summary：通过 <name>_count、<name>_sum 查询观测次数与总和
+//
+// 只返回当前值，查询的时间范围被忽略
+type RegistryMetricsStorage struct {
+	gatherer prometheus.Gatherer
+}
+
+// NewRegistryMetricsStorage 创建基于注册表的指标存储
+func NewRegistryMetricsStorage(gatherer prometheus.Gatherer) *RegistryMetricsStorage {
+	return &RegistryMetricsStorage{gatherer: gatherer}
+}
+
+// Store 不支持写入
+func (s *RegistryMetricsStorage) Store(ctx context.Context, data *MetricData) error {
+	return fmt.Errorf("registry metrics storage is read-only")
+}
+
+// Delete 不支持删除
+func (s *RegistryMetricsStorage) Delete(ctx context.Context, name string, before time.Time) error {
+	return fmt.Errorf("registry metrics storage is read-only")
+}
+
+// Query 查询指标当前值
+func (s *RegistryMetricsStorage) Query(ctx context.Context, query MetricsQuery) ([]*MetricData, error) {
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("gather metrics: %w", err)
+	}
+
+	for _, family := range families {
+		field, ok := matchFamily(family, query.Name)
+		if !ok {
+			continue
+		}
+
+		var sum float64
+		matched := 0
+		for _, metric := range family.GetMetric() {
+			if !labelsMatch(metric.GetLabel(), query.Labels) {
+				continue
+			}
+			sum += sampleValue(family.GetType(), metric, field)
+			matched++
+		}
+		if matched == 0 {
+			return []*MetricData{}, nil
+		}
+		return []*MetricData{{
+			Name:      query.Name,
+			Type:      strings.ToLower(family.GetType().String()),
+			Value:     sum,
+			Labels:    query.Labels,
+			Timestamp: time.Now(),
+		}}, nil
+	}
+	return []*MetricData{}, nil
+}
+
+// matchFamily 判断指标族是否匹配查询名，返回要读取的字段（value / count / sum）
+func matchFamily(family *dto.MetricFamily, name string) (string, bool) {
+	familyName := family.GetName()
+	if familyName == name {
+		return "value", true
+	}
+	switch family.GetType() {
+	case dto.MetricType_HISTOGRAM, dto.MetricType_SUMMARY:
+		if name == familyName+"_count" {
+			return "count", true
+		}
+		if name == familyName+"_sum" {
+			return "sum", true
+		}
+	}
+	return "", false
+}
+
+// labelsMatch 序列标签是否包含查询的全部标签
+func labelsMatch(pairs []*dto.LabelPair, want map[string]string) bool {
+	for key, value := range want {
+		found := false
+		for _, pair := range pairs {
+			if pair.GetName() == key {
+				found = pair.GetValue() == value
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sampleValue 读取序列的数值
+func sampleValue(metricType dto.MetricType, metric *dto.Metric, field string) float64 {
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		return metric.GetCounter().GetValue()
+	case dto.MetricType_GAUGE:
+		return metric.GetGauge().GetValue()
+	case dto.MetricType_UNTYPED:
+		return metric.GetUntyped().GetValue()
+	case dto.MetricType_HISTOGRAM:
+		if field == "sum" {
+			return metric.GetHistogram().GetSampleSum()
+		}
+		return float64(metric.GetHistogram().GetSampleCount())
+	case dto.MetricType_SUMMARY:
+		if field == "sum" {
+			return metric.GetSummary().GetSampleSum()
+		}
+		return float64(metric.GetSummary().GetSampleCount())
+	}
+	return 0
+}
+
+var _ MetricsStorage = (*RegistryMetricsStorage)(nil)
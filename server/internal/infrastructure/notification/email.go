@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	notificationDomain "github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+)
+
+// smtpDialTimeout 连接 SMTP 服务器的超时
+const smtpDialTimeout = 15 * time.Second
+
+// EmailDispatcher 通过 SMTP 发送邮件通知
+//
+// 单条通知以标题为主题；摘要以“您有 N 条未读通知”为主题，正文按时间顺序列出通知。
+// 正文为 UTF-8 纯文本，主题使用 RFC 2047 编码
+type EmailDispatcher struct {
+	cfg config.SMTPConfig
+}
+
+// NewEmailDispatcher 创建邮件投递器
+func NewEmailDispatcher(cfg config.SMTPConfig) *EmailDispatcher {
+	return &EmailDispatcher{cfg: cfg}
+}
+
+// Channel 负责的渠道
+func (d *EmailDispatcher) Channel() string {
+	return notificationDomain.ChannelEmail
+}
+
+// Dispatch 发送邮件
+func (d *EmailDispatcher) Dispatch(ctx context.Context, envelope *notificationDomain.Envelope) error {
+	recipient := envelope.Recipient
+	if recipient == nil || recipient.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+	to := &mail.Address{Name: recipient.Name, Address: recipient.Email}
+
+	return SendMail(ctx, d.cfg, []*mail.Address{to}, envelope.Title, envelope.Content)
+}
+
+// SendMail 通过 SMTP 发送一封 UTF-8 纯文本邮件（告警等系统邮件也复用此实现）
+func SendMail(ctx context.Context, cfg config.SMTPConfig, to []*mail.Address, subject, body string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+	recipients := make([]string, 0, len(to))
+	for _, addr := range to {
+		recipients = append(recipients, addr.Address)
+	}
+	return send(ctx, cfg, from.Address, recipients, buildEmail(from, to, subject, body))
+}
+
+// send 建立 SMTP 会话并发送一封邮件
+func send(ctx context.Context, cfg config.SMTPConfig, from string, to []string, message []byte) error {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+
+	var conn net.Conn
+	var err error
+	if cfg.TLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: cfg.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connect smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if !cfg.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+				return fmt.Errorf("smtp starttls: %w", err)
+			}
+		}
+	}
+	if cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp rcpt to %s: %w", rcpt, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildEmail 组装 MIME 邮件，正文 base64 编码并按 76 列折行
+func buildEmail(from *mail.Address, to []*mail.Address, subject, body string) []byte {
+	header := make([]string, 0, len(to))
+	for _, addr := range to {
+		header = append(header, addr.String())
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(header, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		msg.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	msg.WriteString(encoded + "\r\n")
+	return msg.Bytes()
+}
+
+var _ notificationDomain.Dispatcher = (*EmailDispatcher)(nil)
@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	notificationDomain "github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// defaultWebhookTimeout 未配置时的出站请求超时
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookDispatcher 向用户配置的出站 Webhook 或 IM 机器人推送通知
+//
+// 消息格式由偏好中的 WebhookFormat 决定：
+//   - generic：通用 JSON，包含标题、内容以及完整的通知（摘要时为通知列表）
+//   - slack / dingtalk / feishu：对应 IM 机器人的文本或 Markdown 消息
+//
+// 非 2xx 响应视为投递失败，由分发器按重试策略再次投递
+type WebhookDispatcher struct {
+	client *http.Client
+}
+
+// NewWebhookDispatcher 创建 Webhook 投递器
+// 地址由用户配置，使用出站客户端在连接时拒绝内网地址
+func NewWebhookDispatcher(timeout time.Duration) *WebhookDispatcher {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &WebhookDispatcher{client: utils.NewOutboundHTTPClient(timeout)}
+}
+
+// Channel 负责的渠道
+func (d *WebhookDispatcher) Channel() string {
+	return notificationDomain.ChannelWebhook
+}
+
+// Dispatch 推送到 Webhook
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, envelope *notificationDomain.Envelope) error {
+	if envelope.Recipient == nil || envelope.Recipient.Preference == nil || envelope.Recipient.Preference.WebhookURL == "" {
+		return fmt.Errorf("recipient has no webhook url configured")
+	}
+	preference := envelope.Recipient.Preference
+
+	body, err := json.Marshal(webhookPayload(preference.WebhookFormat, envelope))
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, preference.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LuckDB-Notification/1.0")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return nil
+}
+
+// webhookPayload 按格式构造消息体
+func webhookPayload(format string, envelope *notificationDomain.Envelope) interface{} {
+	text := envelope.Title + "\n" + envelope.Content
+	switch format {
+	case notificationDomain.WebhookFormatSlack:
+		return map[string]interface{}{
+			"text": "*" + envelope.Title + "*\n" + envelope.Content,
+		}
+	case notificationDomain.WebhookFormatDingTalk:
+		return map[string]interface{}{
+			"msgtype": "markdown",
+			"markdown": map[string]string{
+				"title": envelope.Title,
+				"text":  "### " + envelope.Title + "\n\n" + envelope.Content,
+			},
+		}
+	case notificationDomain.WebhookFormatFeishu:
+		return map[string]interface{}{
+			"msg_type": "text",
+			"content":  map[string]string{"text": text},
+		}
+	default:
+		payload := map[string]interface{}{
+			"title":   envelope.Title,
+			"content": envelope.Content,
+			"user_id": envelope.Recipient.UserID,
+		}
+		if envelope.IsDigest() {
+			payload["event"] = "notification.digest"
+			payload["notifications"] = envelope.Digest
+		} else {
+			payload["event"] = "notification.created"
+			payload["notification"] = envelope.Notification
+		}
+		return payload
+	}
+}
+
+var _ notificationDomain.Dispatcher = (*WebhookDispatcher)(nil)
@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notificationDomain "github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+)
+
+func webhookEnvelope(url, format string) *notificationDomain.Envelope {
+	preference := notificationDomain.DefaultPreference("usr_1")
+	preference.WebhookURL = url
+	preference.WebhookFormat = format
+
+	item := notificationDomain.NewNotification("usr_1", notificationDomain.NotificationTypeMention, "被提及", "张三在评论中提到了你")
+	return &notificationDomain.Envelope{
+		Recipient:    &notificationDomain.Recipient{UserID: "usr_1", Preference: preference},
+		Notification: item,
+		Title:        item.Title,
+		Content:      item.Content,
+	}
+}
+
+func TestWebhookDispatcher_Formats(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	dispatcher := NewWebhookDispatcher(time.Second)
+	dispatcher.client = server.Client() // 测试服务器在回环地址上
+	ctx := context.Background()
+
+	require.NoError(t, dispatcher.Dispatch(ctx, webhookEnvelope(server.URL, notificationDomain.WebhookFormatGeneric)))
+	assert.Equal(t, "notification.created", received["event"])
+	assert.Equal(t, "被提及", received["title"])
+	assert.NotNil(t, received["notification"])
+
+	require.NoError(t, dispatcher.Dispatch(ctx, webhookEnvelope(server.URL, notificationDomain.WebhookFormatSlack)))
+	assert.Equal(t, "*被提及*\n张三在评论中提到了你", received["text"])
+
+	require.NoError(t, dispatcher.Dispatch(ctx, webhookEnvelope(server.URL, notificationDomain.WebhookFormatDingTalk)))
+	assert.Equal(t, "markdown", received["msgtype"])
+
+	require.NoError(t, dispatcher.Dispatch(ctx, webhookEnvelope(server.URL, notificationDomain.WebhookFormatFeishu)))
+	assert.Equal(t, "text", received["msg_type"])
+}
+
+func TestWebhookDispatcher_Failures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream down"))
+	}))
+	defer server.Close()
+
+	dispatcher := NewWebhookDispatcher(time.Second)
+	dispatcher.client = server.Client()
+
+	err := dispatcher.Dispatch(context.Background(), webhookEnvelope(server.URL, ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+	assert.Contains(t, err.Error(), "upstream down")
+
+	err = dispatcher.Dispatch(context.Background(), webhookEnvelope("", ""))
+	assert.EqualError(t, err, "recipient has no webhook url configured")
+}
+
+func TestWebhookDispatcher_RejectsInternalAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	dispatcher := NewWebhookDispatcher(time.Second)
+
+	err := dispatcher.Dispatch(context.Background(), webhookEnvelope(server.URL, ""))
+	require.Error(t, err)
+	assert.False(t, called)
+}
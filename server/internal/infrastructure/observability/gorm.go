@@ -0,0 +1,102 @@
+package observability
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// gormSpanKey span 在 gorm 实例上的存储键
+	gormSpanKey = "luckdb:observability:span"
+	// maxStatementLength span 中记录的 SQL 最大长度
+	maxStatementLength = 2000
+)
+
+// GormTracing 为每条 SQL 创建 span 的 GORM 插件
+//
+// 只在已有 trace 上下文时创建 span（即请求或已埋点的后台任务中），
+// 避免定时轮询等无上下文的查询产生大量孤立的根 span
+type GormTracing struct{}
+
+// Name 插件名称
+func (GormTracing) Name() string {
+	return "luckdb:tracing"
+}
+
+// Initialize 注册 GORM 回调
+func (p GormTracing) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	hooks := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", callback.Create().Before("gorm:create").Register, callback.Create().After("gorm:create").Register},
+		{"query", callback.Query().Before("gorm:query").Register, callback.Query().After("gorm:query").Register},
+		{"update", callback.Update().Before("gorm:update").Register, callback.Update().After("gorm:update").Register},
+		{"delete", callback.Delete().Before("gorm:delete").Register, callback.Delete().After("gorm:delete").Register},
+		{"row", callback.Row().Before("gorm:row").Register, callback.Row().After("gorm:row").Register},
+		{"raw", callback.Raw().Before("gorm:raw").Register, callback.Raw().After("gorm:raw").Register},
+	}
+	for _, hook := range hooks {
+		if err := hook.before("luckdb:tracing:before_"+hook.operation, p.before(hook.operation)); err != nil {
+			return err
+		}
+		if err := hook.after("luckdb:tracing:after_"+hook.operation, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// before 开始 SQL span
+func (GormTracing) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		ctx, span := Tracer().Start(ctx, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", db.Dialector.Name()),
+				attribute.String("db.operation.name", operation),
+			))
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+// after 记录 SQL 与结果并结束 span
+func (GormTracing) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	statement := strings.TrimSpace(db.Statement.SQL.String())
+	if len(statement) > maxStatementLength {
+		statement = statement[:maxStatementLength] + "..."
+	}
+	span.SetAttributes(
+		attribute.String("db.query.text", statement),
+		attribute.String("db.collection.name", db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
+
+var _ gorm.Plugin = GormTracing{}
@@ -0,0 +1,68 @@
+package observability
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader 响应中返回 trace ID 的头部，便于前端/客户端反馈问题时定位链路
+const TraceIDHeader = "X-Trace-Id"
+
+// unmatchedRoute 未匹配任何路由时使用的标签，避免以原始路径作为标签导致基数爆炸
+const unmatchedRoute = "unmatched"
+
+// GinMiddleware HTTP 链路追踪与指标中间件
+//
+// 从请求头解析上游 trace 上下文并创建服务端 span，后续的应用服务、SQL span 都挂在它下面；
+// 同时按路由模板记录请求耗时和并发数
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		httpRequestsInFlight.Inc()
+		defer httpRequestsInFlight.Dec()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := Tracer().Start(ctx, fmt.Sprintf("%s %s", c.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+			))
+		defer span.End()
+
+		if spanCtx := span.SpanContext(); spanCtx.HasTraceID() {
+			c.Writer.Header().Set(TraceIDHeader, spanCtx.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if userID := c.GetString("user_id"); userID != "" {
+			span.SetAttributes(attribute.String("enduser.id", userID))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+
+		ObserveHTTPRequest(c.Request.Method, route, status, time.Since(start))
+	}
+}
@@ -0,0 +1,176 @@
+package observability
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace 指标名前缀
+const metricsNamespace = "luckdb"
+
+// Registry Prometheus 指标注册表
+//
+// 使用独立注册表而不是全局 DefaultRegisterer，/metrics 只暴露本服务注册的指标：
+//   - HTTP：按路由模板统计请求耗时与并发数
+//   - 数据库：连接池状态（RegisterDBStats）
+//   - WebSocket、缓存命中率等运行时状态以 GaugeFunc 注册，抓取时读取
+//   - 计算任务队列深度与耗时、事件总线处理延迟
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP 请求耗时（按路由模板）",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "正在处理的 HTTP 请求数",
+	})
+
+	calculationQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "calculation",
+		Name:      "queue_depth",
+		Help:      "等待或正在执行的计算任务数",
+	}, []string{"kind"})
+
+	calculationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "calculation",
+		Name:      "duration_seconds",
+		Help:      "计算任务从入队到完成的耗时",
+		Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 5, 15, 60},
+	}, []string{"kind"})
+
+	eventBusLag = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "event_bus",
+		Name:      "lag_seconds",
+		Help:      "事件从发生到处理器开始处理的延迟",
+		Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 5, 30},
+	}, []string{"event_type"})
+
+	eventBusHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "event_bus",
+		Name:      "handled_total",
+		Help:      "事件处理次数（按结果）",
+	}, []string{"event_type", "result"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "缓存查询次数（按缓存层与命中结果）",
+	}, []string{"layer", "result"})
+
+	// cacheHits / cacheLookups 用于计算整体命中率
+	cacheHits    atomic.Int64
+	cacheLookups atomic.Int64
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		httpRequestsInFlight,
+		calculationQueueDepth,
+		calculationDuration,
+		eventBusLag,
+		eventBusHandled,
+		cacheRequests,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "cache",
+			Name:      "hit_ratio",
+			Help:      "进程启动以来的缓存命中率（0-1）",
+		}, func() float64 {
+			lookups := cacheLookups.Load()
+			if lookups == 0 {
+				return 0
+			}
+			return float64(cacheHits.Load()) / float64(lookups)
+		}),
+	)
+}
+
+// MetricsHandler /metrics 处理器（Prometheus 文本格式）
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RegisterDBStats 注册数据库连接池指标（go_sql_*，db_name=luckdb）
+func RegisterDBStats(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, metricsNamespace))
+}
+
+// RegisterGaugeFunc 注册抓取时读取的状态指标，如 WebSocket 连接数
+func RegisterGaugeFunc(subsystem, name, help string, fn func() float64) error {
+	return Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// ObserveHTTPRequest 记录一次 HTTP 请求
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// TrackCalculation 计算任务入队，返回任务完成时调用的函数
+//
+//	done := observability.TrackCalculation("ai")
+//	defer done()
+func TrackCalculation(kind string) func() {
+	start := time.Now()
+	calculationQueueDepth.WithLabelValues(kind).Inc()
+	return func() {
+		calculationQueueDepth.WithLabelValues(kind).Dec()
+		calculationDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveEventLag 处理器开始处理事件时记录延迟
+func ObserveEventLag(eventType string, occurredAt time.Time) {
+	if occurredAt.IsZero() {
+		return
+	}
+	eventBusLag.WithLabelValues(eventType).Observe(time.Since(occurredAt).Seconds())
+}
+
+// RecordEventHandled 记录事件处理结果
+func RecordEventHandled(eventType string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	eventBusHandled.WithLabelValues(eventType, result).Inc()
+}
+
+// RecordCacheLookup 记录一次缓存查询
+// 每次查询只记录一次：layer 为命中的缓存层（local / redis），多级缓存全部未命中时为 none
+func RecordCacheLookup(layer string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+		cacheHits.Add(1)
+	}
+	cacheLookups.Add(1)
+	cacheRequests.WithLabelValues(layer, result).Inc()
+}
@@ -0,0 +1,102 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// useRecorder 安装记录 span 的 TracerProvider，测试结束后恢复
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GinMiddleware())
+	router.GET("/api/v1/tables/:tableId", func(c *gin.Context) {
+		_, span := StartSpan(c.Request.Context(), "TableService.GetTable")
+		span.End()
+		c.Status(http.StatusOK)
+	})
+	router.GET("/metrics", gin.WrapH(MetricsHandler()))
+	return router
+}
+
+func TestGinMiddleware_ContinuesUpstreamTrace(t *testing.T) {
+	recorder := useRecorder(t)
+	router := newTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tables/tbl_1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get(TraceIDHeader))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "GET /api/v1/tables/:tableId", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+}
+
+func TestMetricsHandler_ExposesRouteLatencyAndCache(t *testing.T) {
+	router := newTestRouter()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/tables/tbl_1", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nowhere/123", nil))
+	RecordCacheLookup("local", true)
+	RecordCacheLookup("none", false)
+	TrackCalculation("field")()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+
+	assert.Contains(t, body, `luckdb_http_request_duration_seconds_count{method="GET",route="/api/v1/tables/:tableId",status="200"}`)
+	assert.Contains(t, body, `route="unmatched",status="404"`)
+	assert.NotContains(t, body, "/nowhere/123")
+	assert.Contains(t, body, `luckdb_cache_requests_total{layer="local",result="hit"}`)
+	assert.Contains(t, body, "luckdb_cache_hit_ratio")
+	assert.Contains(t, body, `luckdb_calculation_queue_depth{kind="field"} 0`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestGormTracing_OnlyWithinTrace(t *testing.T) {
+	recorder := useRecorder(t)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(GormTracing{}))
+
+	var n int
+	require.NoError(t, db.WithContext(context.Background()).Raw("SELECT 1").Scan(&n).Error)
+	assert.Empty(t, recorder.Ended(), "无 trace 上下文时不创建孤立的 SQL span")
+
+	ctx, parent := StartSpan(context.Background(), "RecordService.GetRecord")
+	require.NoError(t, db.WithContext(ctx).Raw("SELECT 1").Scan(&n).Error)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "db.row", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
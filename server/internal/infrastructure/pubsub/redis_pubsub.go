@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
@@ -138,15 +138,15 @@ func (r *RedisPubSub) subscribeLoop(channels []string) {
 			}
 
 			// 调用handlers
-			r.handleMessage(&msg)
+			r.handleMessage(msg.Channel, &msg)
 		}
 	}
 }
 
-// handleMessage 处理消息
-func (r *RedisPubSub) handleMessage(msg *Message) {
+// handleMessage 处理消息，key 为注册 handler 时使用的频道或模式
+func (r *RedisPubSub) handleMessage(key string, msg *Message) {
 	r.mu.RLock()
-	handlers, exists := r.handlers[msg.Channel]
+	handlers, exists := r.handlers[key]
 	r.mu.RUnlock()
 
 	if !exists || len(handlers) == 0 {
@@ -258,8 +258,8 @@ func (r *RedisPubSub) patternSubscribeLoop(pattern string) {
 				continue
 			}
 
-			// 调用handlers
-			r.handleMessage(&msg)
+			// 调用handlers（handler 按模式注册）
+			r.handleMessage(pattern, &msg)
 		}
 	}
 }
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// fallbackLogInterval Redis 持续出错时的日志间隔，避免每个请求都记录
+const fallbackLogInterval = time.Minute
+
+// FallbackStore 优先使用 primary（Redis），出错时退回 fallback（进程内）
+//
+// 退回期间各实例独立计数，限流变宽松但不会拒绝正常请求；Redis 恢复后自动切回
+type FallbackStore struct {
+	primary  Store
+	fallback Store
+
+	mu      sync.Mutex
+	lastLog time.Time
+}
+
+// NewFallbackStore 创建带退回的存储
+func NewFallbackStore(primary, fallback Store) *FallbackStore {
+	return &FallbackStore{primary: primary, fallback: fallback}
+}
+
+// Allow 从 key 对应的桶中取一个令牌
+func (s *FallbackStore) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	result, err := s.primary.Allow(ctx, key, limit)
+	if err != nil {
+		s.logFailure(err)
+		return s.fallback.Allow(ctx, key, limit)
+	}
+	return result, nil
+}
+
+// Incr 计数加一并返回新值
+func (s *FallbackStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	value, err := s.primary.Incr(ctx, key, ttl)
+	if err != nil {
+		s.logFailure(err)
+		return s.fallback.Incr(ctx, key, ttl)
+	}
+	return value, nil
+}
+
+// Get 当前计数
+func (s *FallbackStore) Get(ctx context.Context, key string) (int64, error) {
+	value, err := s.primary.Get(ctx, key)
+	if err != nil {
+		s.logFailure(err)
+		return s.fallback.Get(ctx, key)
+	}
+	return value, nil
+}
+
+func (s *FallbackStore) logFailure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.lastLog) < fallbackLogInterval {
+		return
+	}
+	s.lastLog = time.Now()
+	logger.Warn("限流存储不可用，暂时使用进程内限流", logger.ErrorField(err))
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval 清理已补满的桶和过期计数的间隔
+const memorySweepInterval = time.Minute
+
+// MemoryStore 进程内的限流与计数存储，只在单实例内生效
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	counters  map[string]*memoryCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+type memoryCounter struct {
+	value   int64
+	expires time.Time
+}
+
+// NewMemoryStore 创建进程内存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  make(map[string]*memoryBucket),
+		counters: make(map[string]*memoryCounter),
+		now:      time.Now,
+	}
+}
+
+// Allow 从 key 对应的桶中取一个令牌
+func (s *MemoryStore) Allow(_ context.Context, key string, limit Limit) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(limit.Capacity()), updated: now}
+		s.buckets[key] = bucket
+	}
+	result, tokens := takeToken(limit, bucket.tokens, now.Sub(bucket.updated).Milliseconds())
+	bucket.tokens = tokens
+	bucket.updated = now
+	bucket.limit = limit
+	return result, nil
+}
+
+// Incr 计数加一并返回新值
+func (s *MemoryStore) Incr(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.expires) {
+		counter = &memoryCounter{expires: now.Add(ttl)}
+		s.counters[key] = counter
+	}
+	counter.value++
+	return counter.value, nil
+}
+
+// Get 当前计数
+func (s *MemoryStore) Get(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !s.now().Before(counter.expires) {
+		return 0, nil
+	}
+	return counter.value, nil
+}
+
+// sweep 定期删除已补满的桶（与不存在等价）和过期的计数，调用方需持有锁
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		elapsed := now.Sub(bucket.updated).Milliseconds()
+		if bucket.tokens+float64(elapsed)*bucket.limit.ratePerMilli() >= float64(bucket.limit.Capacity()) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, counter := range s.counters {
+		if !now.Before(counter.expires) {
+			delete(s.counters, key)
+		}
+	}
+}
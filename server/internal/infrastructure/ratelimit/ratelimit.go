@@ -0,0 +1,97 @@
+// Package ratelimit 令牌桶限流与周期计数
+//
+// 配置 Redis 时计数由所有实例共享（RedisStore），否则使用进程内存储（MemoryStore）；
+// FallbackStore 在 Redis 出错时临时退回进程内存储，保证限流不影响可用性
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit 令牌桶规则：每 Period 补充 Requests 个令牌，最多积累 Burst 个
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int // 为 0 时等于 Requests
+}
+
+// Enabled Requests 或 Period 未设置时不限流
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Capacity 桶容量
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// ratePerMilli 每毫秒补充的令牌数
+func (l Limit) ratePerMilli() float64 {
+	return float64(l.Requests) / float64(l.Period.Milliseconds())
+}
+
+// Result 一次限流判定的结果
+type Result struct {
+	Allowed    bool
+	Limit      int           // 桶容量
+	Remaining  int           // 剩余令牌
+	ResetAfter time.Duration // 令牌补满所需时间
+	RetryAfter time.Duration // 被拒绝时，下一个令牌可用所需时间
+}
+
+// Limiter 令牌桶限流器
+type Limiter interface {
+	// Allow 从 key 对应的桶中取一个令牌
+	Allow(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// Counter 周期计数器，用于按月统计的配额
+type Counter interface {
+	// Incr 计数加一并返回新值，键在 ttl 后过期
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Get 当前计数，不存在时为 0
+	Get(ctx context.Context, key string) (int64, error)
+}
+
+// Store 限流与计数存储
+type Store interface {
+	Limiter
+	Counter
+}
+
+// takeToken 按令牌桶算法补充令牌并尝试取一个，返回判定结果和剩余令牌（小数）
+// tokens 为上次剩余令牌，elapsed 为距上次的毫秒数；RedisStore 的 Lua 脚本与此保持一致
+func takeToken(limit Limit, tokens float64, elapsed int64) (*Result, float64) {
+	rate := limit.ratePerMilli()
+	capacity := float64(limit.Capacity())
+	if elapsed > 0 {
+		tokens = math.Min(capacity, tokens+float64(elapsed)*rate)
+	}
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	return newResult(limit, allowed, tokens), tokens
+}
+
+// newResult 由剩余令牌计算判定结果
+func newResult(limit Limit, allowed bool, tokens float64) *Result {
+	rate := limit.ratePerMilli()
+	capacity := float64(limit.Capacity())
+	result := &Result{
+		Allowed:    allowed,
+		Limit:      limit.Capacity(),
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration(math.Ceil((capacity-tokens)/rate)) * time.Millisecond,
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration(math.Ceil((1-tokens)/rate)) * time.Millisecond
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+func TestMemoryStore_TokenBucket(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 3}
+
+	// 桶满时可以连续取 Burst 个令牌
+	for i := 2; i >= 0; i-- {
+		result, err := store.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.ResetAfter)
+
+	// 其他键互不影响
+	result, err = store.Allow(ctx, "user:2", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// 每秒补充一个令牌
+	now = now.Add(1500 * time.Millisecond)
+	result, err = store.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 2500*time.Millisecond, result.ResetAfter)
+
+	// 补满后清理桶，再次请求从满桶开始
+	now = now.Add(2 * memorySweepInterval)
+	result, err = store.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Remaining)
+	assert.Len(t, store.buckets, 1)
+}
+
+func TestMemoryStore_Counter(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := int64(1); i <= 3; i++ {
+		value, err := store.Incr(ctx, "api:spc_1", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, i, value)
+	}
+	value, err := store.Get(ctx, "api:spc_1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+
+	// 过期后重新计数
+	now = now.Add(time.Hour)
+	value, err = store.Get(ctx, "api:spc_1")
+	require.NoError(t, err)
+	assert.Zero(t, value)
+	value, err = store.Incr(ctx, "api:spc_1", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+// failingStore 始终出错的存储，模拟 Redis 不可用
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, string, Limit) (*Result, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingStore) Incr(context.Context, string, time.Duration) (int64, error) {
+	return 0, errors.New("connection refused")
+}
+
+func (failingStore) Get(context.Context, string) (int64, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestFallbackStore(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	store := NewFallbackStore(failingStore{}, NewMemoryStore())
+	ctx := context.Background()
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	result, err := store.Allow(ctx, "ip:1.2.3.4", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	result, err = store.Allow(ctx, "ip:1.2.3.4", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed, "退回后仍然限流")
+
+	value, err := store.Incr(ctx, "api:spc_1", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+	value, err = store.Get(ctx, "api:spc_1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+}
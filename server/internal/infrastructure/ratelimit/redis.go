@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix 限流与计数的 Redis 键前缀
+const redisKeyPrefix = "ratelimit:"
+
+// tokenBucketScript 原子地补充令牌并尝试取一个，算法与 takeToken 一致
+// KEYS: 桶键；ARGV: 每毫秒补充的令牌数、桶容量、当前时间（毫秒）
+// 桶在补满后过期，过期与补满等价
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+if now > ts then
+	tokens = math.min(capacity, tokens + (now - ts) * rate)
+	ts = now
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// counterIncrScript 计数加一，首次计数时设置过期时间
+// KEYS: 计数键；ARGV: 过期时间（毫秒）
+var counterIncrScript = redis.NewScript(`
+local value = redis.call('INCR', KEYS[1])
+if value == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return value
+`)
+
+// RedisStore 基于 Redis 的限流与计数存储，多个实例共享
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore 创建 Redis 存储
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Allow 从 key 对应的桶中取一个令牌
+func (s *RedisStore) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	values, err := tokenBucketScript.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		strconv.FormatFloat(limit.ratePerMilli(), 'g', -1, 64),
+		limit.Capacity(),
+		time.Now().UnixMilli(),
+	).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("rate limit %s: %w", key, err)
+	}
+	if len(values) != 2 {
+		return nil, fmt.Errorf("rate limit %s: unexpected script result %v", key, values)
+	}
+
+	allowed, _ := values[0].(int64)
+	raw, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(tokens) {
+		return nil, fmt.Errorf("rate limit %s: invalid token count %q", key, raw)
+	}
+	return newResult(limit, allowed == 1, tokens), nil
+}
+
+// Incr 计数加一并返回新值，首次计数时设置过期时间
+func (s *RedisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	value, err := counterIncrScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("incr counter %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Get 当前计数
+func (s *RedisStore) Get(ctx context.Context, key string) (int64, error) {
+	value, err := s.client.Get(ctx, redisKeyPrefix+key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get counter %s: %w", key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	aiDomain "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+)
+
+// usageDateLayout 用量统计日期格式
+const usageDateLayout = "2006-01-02"
+
+// AIUsageRepositoryImpl AI 用量仓储GORM实现
+type AIUsageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAIUsageRepository 创建 AI 用量仓储
+func NewAIUsageRepository(db *gorm.DB) aiDomain.UsageRepository {
+	return &AIUsageRepositoryImpl{db: db}
+}
+
+// Record 累加一次用量，同一 Base、日期、提供者、模型合并为一行
+func (r *AIUsageRepositoryImpl) Record(ctx context.Context, usage *aiDomain.Usage) error {
+	row := models.AIUsage{
+		BaseID:           usage.BaseID,
+		UsageDate:        aiDomain.UsageDate(usage.Date).Format(usageDateLayout),
+		Provider:         usage.Provider,
+		Model:            usage.Model,
+		Requests:         int64(usage.Requests),
+		PromptTokens:     int64(usage.PromptTokens),
+		CompletionTokens: int64(usage.CompletionTokens),
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "base_id"}, {Name: "usage_date"}, {Name: "provider"}, {Name: "model"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests":           gorm.Expr("ai_usage.requests + excluded.requests"),
+				"prompt_tokens":      gorm.Expr("ai_usage.prompt_tokens + excluded.prompt_tokens"),
+				"completion_tokens":  gorm.Expr("ai_usage.completion_tokens + excluded.completion_tokens"),
+				"last_modified_time": time.Now(),
+			}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("failed to record ai usage: %w", err)
+	}
+	return nil
+}
+
+// List 查询 Base 在日期范围内的用量明细，按日期、提供者、模型排序
+func (r *AIUsageRepositoryImpl) List(ctx context.Context, baseID string, from, to time.Time) ([]*aiDomain.UsageEntry, error) {
+	var rows []models.AIUsage
+	err := r.db.WithContext(ctx).
+		Where("base_id = ? AND usage_date >= ? AND usage_date <= ?",
+			baseID, aiDomain.UsageDate(from).Format(usageDateLayout), aiDomain.UsageDate(to).Format(usageDateLayout)).
+		Order("usage_date ASC, provider ASC, model ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ai usage: %w", err)
+	}
+
+	entries := make([]*aiDomain.UsageEntry, 0, len(rows))
+	for _, row := range rows {
+		date, err := time.Parse(usageDateLayout, row.UsageDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ai usage date %q: %w", row.UsageDate, err)
+		}
+		entries = append(entries, &aiDomain.UsageEntry{
+			Date:             date,
+			Provider:         row.Provider,
+			Model:            row.Model,
+			Requests:         row.Requests,
+			PromptTokens:     row.PromptTokens,
+			CompletionTokens: row.CompletionTokens,
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	aiDomain "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+)
+
+func TestAIUsageRepository_RecordAndList(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		require.NoError(t, env.db.AutoMigrate(&models.AIUsage{}))
+		repo := NewAIUsageRepository(env.db)
+		t.Cleanup(func() {
+			env.db.Where("base_id = ?", env.baseID).Delete(&models.AIUsage{})
+		})
+
+		day := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+		record := func(model string, at time.Time, prompt, completion int) {
+			result := &aiDomain.CompletionResult{Model: model, PromptTokens: prompt, CompletionTokens: completion}
+			require.NoError(t, repo.Record(ctx, aiDomain.NewUsage(env.baseID, "local", result, at)))
+		}
+		record("llama3", day, 10, 5)
+		record("llama3", day.Add(3*time.Hour), 20, 7) // 同一天同模型合并
+		record("qwen2", day, 1, 1)
+		record("llama3", day.AddDate(0, 0, 1), 4, 4)
+		record("llama3", day.AddDate(0, 0, 5), 100, 100) // 范围外
+
+		entries, err := repo.List(ctx, env.baseID, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, "llama3", entries[0].Model)
+		assert.Equal(t, int64(2), entries[0].Requests)
+		assert.Equal(t, int64(30), entries[0].PromptTokens)
+		assert.Equal(t, int64(12), entries[0].CompletionTokens)
+		assert.True(t, entries[0].Date.Equal(aiDomain.UsageDate(day)))
+		assert.Equal(t, "qwen2", entries[1].Model)
+		assert.True(t, entries[2].Date.Equal(aiDomain.UsageDate(day.AddDate(0, 0, 1))))
+	})
+}
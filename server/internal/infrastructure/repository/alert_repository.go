@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/monitoring"
+)
+
+// AlertRepositoryImpl 告警规则、历史与静默仓储GORM实现
+type AlertRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAlertRepository 创建告警仓储
+func NewAlertRepository(db *gorm.DB) monitoring.AlertRepository {
+	return &AlertRepositoryImpl{db: db}
+}
+
+// SaveRule 保存规则（新增或更新）
+func (r *AlertRepositoryImpl) SaveRule(ctx context.Context, rule *monitoring.AlertRule) error {
+	model, err := toAlertRuleModel(rule)
+	if err != nil {
+		return fmt.Errorf("failed to convert alert rule to model: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save alert rule: %w", err)
+	}
+	return nil
+}
+
+// GetRule 根据ID查找规则
+func (r *AlertRepositoryImpl) GetRule(ctx context.Context, id string) (*monitoring.AlertRule, error) {
+	var model models.AlertRule
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_time IS NULL", id).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find alert rule: %w", err)
+	}
+	return toAlertRuleEntity(&model)
+}
+
+// ListRules 列出所有规则
+func (r *AlertRepositoryImpl) ListRules(ctx context.Context) ([]*monitoring.AlertRule, error) {
+	return r.findRules(ctx, r.db.WithContext(ctx).Where("deleted_time IS NULL"))
+}
+
+// ListEnabledRules 列出启用的规则
+func (r *AlertRepositoryImpl) ListEnabledRules(ctx context.Context) ([]*monitoring.AlertRule, error) {
+	return r.findRules(ctx, r.db.WithContext(ctx).Where("deleted_time IS NULL AND enabled = ?", true))
+}
+
+// findRules 按条件查询规则
+func (r *AlertRepositoryImpl) findRules(ctx context.Context, query *gorm.DB) ([]*monitoring.AlertRule, error) {
+	var ruleModels []models.AlertRule
+	if err := query.Order("created_time").Find(&ruleModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+
+	rules := make([]*monitoring.AlertRule, 0, len(ruleModels))
+	for i := range ruleModels {
+		rule, err := toAlertRuleEntity(&ruleModels[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// DeleteRule 删除规则（软删除，历史保留）
+func (r *AlertRepositoryImpl) DeleteRule(ctx context.Context, id string) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&models.AlertRule{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted_time":       now,
+			"last_modified_time": now,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	return nil
+}
+
+// SaveAlert 保存告警历史（新增或更新）
+func (r *AlertRepositoryImpl) SaveAlert(ctx context.Context, alert *monitoring.Alert) error {
+	labels, err := json.Marshal(alert.Labels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert labels: %w", err)
+	}
+	model := &models.AlertHistory{
+		ID:               alert.ID,
+		RuleID:           alert.RuleID,
+		RuleName:         alert.Name,
+		Description:      alert.Description,
+		Severity:         string(alert.Severity),
+		Status:           string(alert.Status),
+		Instance:         alert.Instance,
+		Value:            alert.Value,
+		Threshold:        alert.Threshold,
+		Labels:           labels,
+		StartedAt:        alert.StartedAt,
+		FiredAt:          alert.FiredAt,
+		ResolvedAt:       alert.ResolvedAt,
+		LastNotifiedAt:   alert.LastNotifiedAt,
+		NotifyCount:      alert.NotifyCount,
+		Silenced:         alert.Silenced,
+		LastError:        alert.LastError,
+		CreatedTime:      alert.FiredAt,
+		LastModifiedTime: time.Now(),
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save alert: %w", err)
+	}
+	return nil
+}
+
+// ListOpenAlerts 列出实例上仍在触发的告警
+func (r *AlertRepositoryImpl) ListOpenAlerts(ctx context.Context, instance string) ([]*monitoring.Alert, error) {
+	var historyModels []models.AlertHistory
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND instance = ?", monitoring.AlertStatusFiring, instance).
+		Find(&historyModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open alerts: %w", err)
+	}
+	return toAlertEntities(historyModels)
+}
+
+// ListAlerts 按触发时间倒序查询告警历史
+func (r *AlertRepositoryImpl) ListAlerts(ctx context.Context, query monitoring.AlertHistoryQuery) ([]*monitoring.Alert, int64, error) {
+	db := r.db.WithContext(ctx).Model(&models.AlertHistory{})
+	if query.RuleID != "" {
+		db = db.Where("rule_id = ?", query.RuleID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	var historyModels []models.AlertHistory
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+	if err := db.Offset(query.Offset).Order("fired_at DESC").Find(&historyModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	alerts, err := toAlertEntities(historyModels)
+	if err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+// SaveSilence 保存静默
+func (r *AlertRepositoryImpl) SaveSilence(ctx context.Context, silence *monitoring.AlertSilence) error {
+	matchers, err := json.Marshal(silence.Matchers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal silence matchers: %w", err)
+	}
+	model := &models.AlertSilence{
+		ID:          silence.ID,
+		RuleID:      silence.RuleID,
+		Matchers:    matchers,
+		StartsAt:    silence.StartsAt,
+		EndsAt:      silence.EndsAt,
+		Comment:     silence.Comment,
+		CreatedBy:   silence.CreatedBy,
+		CreatedTime: silence.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save alert silence: %w", err)
+	}
+	return nil
+}
+
+// ListActiveSilences 列出指定时间生效的静默
+func (r *AlertRepositoryImpl) ListActiveSilences(ctx context.Context, at time.Time) ([]*monitoring.AlertSilence, error) {
+	return r.findSilences(r.db.WithContext(ctx).Where("starts_at <= ? AND ends_at > ?", at, at))
+}
+
+// ListSilences 列出静默
+func (r *AlertRepositoryImpl) ListSilences(ctx context.Context, includeExpired bool, at time.Time) ([]*monitoring.AlertSilence, error) {
+	query := r.db.WithContext(ctx)
+	if !includeExpired {
+		query = query.Where("ends_at > ?", at)
+	}
+	return r.findSilences(query)
+}
+
+// findSilences 按条件查询静默
+func (r *AlertRepositoryImpl) findSilences(query *gorm.DB) ([]*monitoring.AlertSilence, error) {
+	var silenceModels []models.AlertSilence
+	if err := query.Order("starts_at DESC").Find(&silenceModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list alert silences: %w", err)
+	}
+
+	silences := make([]*monitoring.AlertSilence, 0, len(silenceModels))
+	for i := range silenceModels {
+		model := &silenceModels[i]
+		silence := &monitoring.AlertSilence{
+			ID:        model.ID,
+			RuleID:    model.RuleID,
+			StartsAt:  model.StartsAt,
+			EndsAt:    model.EndsAt,
+			Comment:   model.Comment,
+			CreatedBy: model.CreatedBy,
+			CreatedAt: model.CreatedTime,
+		}
+		if err := unmarshalJSONColumn(model.Matchers, &silence.Matchers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal silence matchers: %w", err)
+		}
+		silences = append(silences, silence)
+	}
+	return silences, nil
+}
+
+// DeleteSilence 删除静默
+func (r *AlertRepositoryImpl) DeleteSilence(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.AlertSilence{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete alert silence: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return monitoring.ErrAlertSilenceNotFound
+	}
+	return nil
+}
+
+// toAlertRuleModel 规则转换为数据库模型
+func toAlertRuleModel(rule *monitoring.AlertRule) (*models.AlertRule, error) {
+	metricLabels, err := json.Marshal(rule.Condition.Labels)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := json.Marshal(rule.Channels)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := json.Marshal(rule.Labels)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := json.Marshal(rule.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	function := string(rule.Condition.Function)
+	if function == "" {
+		function = string(monitoring.FunctionValue)
+	}
+	updatedAt := rule.UpdatedAt
+	return &models.AlertRule{
+		ID:                    rule.ID,
+		Name:                  rule.Name,
+		Description:           rule.Description,
+		MetricName:            rule.Condition.MetricName,
+		MetricLabels:          metricLabels,
+		Function:              function,
+		Operator:              string(rule.Condition.Operator),
+		Threshold:             rule.Condition.Threshold,
+		ForSeconds:            int64(rule.Condition.Duration / time.Second),
+		Severity:              string(rule.Severity),
+		Channels:              channels,
+		RepeatIntervalSeconds: int64(rule.RepeatInterval / time.Second),
+		Labels:                labels,
+		Annotations:           annotations,
+		Enabled:               rule.Enabled,
+		CreatedBy:             rule.CreatedBy,
+		CreatedTime:           rule.CreatedAt,
+		LastModifiedTime:      &updatedAt,
+	}, nil
+}
+
+// toAlertRuleEntity 数据库模型转换为规则
+func toAlertRuleEntity(model *models.AlertRule) (*monitoring.AlertRule, error) {
+	rule := &monitoring.AlertRule{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		Condition: monitoring.AlertCondition{
+			MetricName: model.MetricName,
+			Operator:   monitoring.ComparisonOperator(model.Operator),
+			Threshold:  model.Threshold,
+			Duration:   time.Duration(model.ForSeconds) * time.Second,
+			Function:   monitoring.AlertFunction(model.Function),
+		},
+		Severity:       monitoring.AlertingSeverity(model.Severity),
+		Enabled:        model.Enabled,
+		RepeatInterval: time.Duration(model.RepeatIntervalSeconds) * time.Second,
+		CreatedBy:      model.CreatedBy,
+		CreatedAt:      model.CreatedTime,
+		UpdatedAt:      model.CreatedTime,
+	}
+	if model.LastModifiedTime != nil {
+		rule.UpdatedAt = *model.LastModifiedTime
+	}
+
+	columns := []struct {
+		data []byte
+		dest interface{}
+	}{
+		{model.MetricLabels, &rule.Condition.Labels},
+		{model.Channels, &rule.Channels},
+		{model.Labels, &rule.Labels},
+		{model.Annotations, &rule.Annotations},
+	}
+	for _, column := range columns {
+		if err := unmarshalJSONColumn(column.data, column.dest); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal alert rule %s: %w", model.ID, err)
+		}
+	}
+	return rule, nil
+}
+
+func toAlertEntities(historyModels []models.AlertHistory) ([]*monitoring.Alert, error) {
+	alerts := make([]*monitoring.Alert, 0, len(historyModels))
+	for i := range historyModels {
+		model := &historyModels[i]
+		alert := &monitoring.Alert{
+			ID:             model.ID,
+			RuleID:         model.RuleID,
+			Name:           model.RuleName,
+			Description:    model.Description,
+			Severity:       monitoring.AlertingSeverity(model.Severity),
+			Status:         monitoring.AlertStatus(model.Status),
+			Instance:       model.Instance,
+			StartedAt:      model.StartedAt,
+			EndedAt:        model.ResolvedAt,
+			Value:          model.Value,
+			Threshold:      model.Threshold,
+			FiredAt:        model.FiredAt,
+			ResolvedAt:     model.ResolvedAt,
+			LastNotifiedAt: model.LastNotifiedAt,
+			NotifyCount:    model.NotifyCount,
+			Silenced:       model.Silenced,
+			LastError:      model.LastError,
+		}
+		if err := unmarshalJSONColumn(model.Labels, &alert.Labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal alert labels: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
+
+// unmarshalJSONColumn 解析 JSON 列，空值或 null 时保持零值
+func unmarshalJSONColumn(data []byte, dest interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, dest)
+}
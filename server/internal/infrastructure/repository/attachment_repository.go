@@ -0,0 +1,777 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// AttachmentRepositoryImpl 附件仓储GORM实现
+type AttachmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository 创建附件仓储
+func NewAttachmentRepository(db *gorm.DB) attachment.Repository {
+	return &AttachmentRepositoryImpl{db: db}
+}
+
+// CreateAttachment 创建附件
+func (r *AttachmentRepositoryImpl) CreateAttachment(ctx context.Context, item *attachment.AttachmentItem) error {
+	if err := r.db.WithContext(ctx).Create(attachmentToModel(item)).Error; err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachmentByID 通过ID获取附件
+func (r *AttachmentRepositoryImpl) GetAttachmentByID(ctx context.Context, id string) (*attachment.AttachmentItem, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+// GetAttachmentByToken 通过令牌获取附件
+func (r *AttachmentRepositoryImpl) GetAttachmentByToken(ctx context.Context, token string) (*attachment.AttachmentItem, error) {
+	return r.first(ctx, "token = ?", token)
+}
+
+// GetAttachmentByPath 通过路径获取附件
+func (r *AttachmentRepositoryImpl) GetAttachmentByPath(ctx context.Context, path string) (*attachment.AttachmentItem, error) {
+	return r.first(ctx, "path = ?", path)
+}
+
+// UpdateAttachment 更新附件
+func (r *AttachmentRepositoryImpl) UpdateAttachment(ctx context.Context, item *attachment.AttachmentItem) error {
+	if err := r.db.WithContext(ctx).Save(attachmentToModel(item)).Error; err != nil {
+		return fmt.Errorf("failed to update attachment: %w", err)
+	}
+	return nil
+}
+
+// DeleteAttachment 删除附件（软删除）
+func (r *AttachmentRepositoryImpl) DeleteAttachment(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Attachment{}).Error; err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
+
+// ListAttachments 列出附件，fieldID 和 recordID 为空时不过滤
+func (r *AttachmentRepositoryImpl) ListAttachments(ctx context.Context, tableID, fieldID, recordID string) ([]*attachment.AttachmentItem, error) {
+	query := r.db.WithContext(ctx).Where("table_id = ?", tableID)
+	if fieldID != "" {
+		query = query.Where("field_id = ?", fieldID)
+	}
+	if recordID != "" {
+		query = query.Where("record_id = ?", recordID)
+	}
+
+	var rows []models.Attachment
+	if err := query.Order("created_time ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	items := make([]*attachment.AttachmentItem, 0, len(rows))
+	for i := range rows {
+		items = append(items, attachmentFromModel(&rows[i]))
+	}
+	return items, nil
+}
+
+// GetAttachmentStats 获取附件统计信息（按 MIME 类型分类）
+func (r *AttachmentRepositoryImpl) GetAttachmentStats(ctx context.Context, tableID string) (*attachment.AttachmentStats, error) {
+	var groups []struct {
+		MimeType string
+		Files    int64
+		Bytes    int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Attachment{}).
+		Select("mime_type, COUNT(*) AS files, COALESCE(SUM(size), 0) AS bytes").
+		Where("table_id = ?", tableID).
+		Group("mime_type").
+		Scan(&groups).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment stats: %w", err)
+	}
+
+	stats := &attachment.AttachmentStats{}
+	for _, group := range groups {
+		stats.TotalFiles += group.Files
+		stats.TotalSize += group.Bytes
+
+		item := &attachment.AttachmentItem{MimeType: group.MimeType}
+		switch {
+		case item.IsImage():
+			stats.ImageFiles += group.Files
+		case item.IsVideo():
+			stats.VideoFiles += group.Files
+		case item.IsAudio():
+			stats.AudioFiles += group.Files
+		case item.IsDocument():
+			stats.DocumentFiles += group.Files
+		default:
+			stats.OtherFiles += group.Files
+		}
+	}
+
+	if stats.TotalFiles > 0 {
+		var latest models.Attachment
+		err := r.db.WithContext(ctx).Where("table_id = ?", tableID).Order("created_time DESC").First(&latest).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest attachment: %w", err)
+		}
+		stats.LastUploaded = latest.CreatedTime
+	}
+	return stats, nil
+}
+
+// ListPendingPreviews 列出等待生成预览的附件（按创建时间先后）
+func (r *AttachmentRepositoryImpl) ListPendingPreviews(ctx context.Context, limit int) ([]*attachment.AttachmentItem, error) {
+	var rows []models.Attachment
+	err := r.db.WithContext(ctx).
+		Where("preview_status = ?", attachment.PreviewStatusPending).
+		Order("created_time ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending previews: %w", err)
+	}
+
+	items := make([]*attachment.AttachmentItem, 0, len(rows))
+	for i := range rows {
+		items = append(items, attachmentFromModel(&rows[i]))
+	}
+	return items, nil
+}
+
+// FindAttachmentByHash 在指定表中查找内容相同的附件，不存在时返回 errors.ErrNotFound
+func (r *AttachmentRepositoryImpl) FindAttachmentByHash(ctx context.Context, hash string, tableIDs []string) (*attachment.AttachmentItem, error) {
+	if len(tableIDs) == 0 {
+		return nil, errors.ErrNotFound
+	}
+	return r.first(ctx, "hash = ? AND table_id IN ?", hash, tableIDs)
+}
+
+// GetDedupStats 统计指定表内附件的去重情况
+// 去重前上传的附件（hash 为空）各自占用一份存储
+func (r *AttachmentRepositoryImpl) GetDedupStats(ctx context.Context, tableIDs []string, top int) (*attachment.DedupStats, error) {
+	stats := &attachment.DedupStats{Duplicates: []*attachment.DuplicateBlob{}}
+	if len(tableIDs) == 0 {
+		return stats, nil
+	}
+	scoped := func() *gorm.DB {
+		return r.db.WithContext(ctx).Model(&models.Attachment{}).Where("table_id IN ?", tableIDs)
+	}
+
+	var total, legacy, unique struct {
+		Files int64
+		Bytes int64
+	}
+	if err := scoped().Select("COUNT(*) AS files, COALESCE(SUM(size), 0) AS bytes").Scan(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attachment totals: %w", err)
+	}
+	err := scoped().Where("hash IS NULL OR hash = ''").
+		Select("COUNT(*) AS files, COALESCE(SUM(size), 0) AS bytes").
+		Scan(&legacy).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legacy attachment totals: %w", err)
+	}
+	blobs := scoped().Where("hash <> ''").Select("hash, MAX(size) AS size").Group("hash")
+	err = r.db.WithContext(ctx).Table("(?) AS blobs", blobs).
+		Select("COUNT(*) AS files, COALESCE(SUM(size), 0) AS bytes").
+		Scan(&unique).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob totals: %w", err)
+	}
+	stats.Files = total.Files
+	stats.LogicalBytes = total.Bytes
+	stats.UniqueBlobs = unique.Files + legacy.Files
+	stats.StoredBytes = unique.Bytes + legacy.Bytes
+
+	// 节省最多的重复内容
+	var groups []struct {
+		Hash     string
+		Name     string
+		MimeType string
+		Size     int64
+		Copies   int64
+	}
+	err = scoped().Where("hash <> ''").
+		Select("hash, MIN(name) AS name, MIN(mime_type) AS mime_type, MAX(size) AS size, COUNT(*) AS copies").
+		Group("hash").
+		Having("COUNT(*) > 1").
+		Order("(COUNT(*) - 1) * MAX(size) DESC").
+		Limit(top).
+		Scan(&groups).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate blobs: %w", err)
+	}
+	if len(groups) == 0 {
+		return stats, nil
+	}
+
+	hashes := make([]string, 0, len(groups))
+	for _, group := range groups {
+		hashes = append(hashes, group.Hash)
+	}
+	var refs []struct {
+		Hash  string
+		Count int64
+	}
+	err = r.db.WithContext(ctx).Table("attachments_table AS l").
+		Joins("JOIN attachments AS a ON a.token = l.token").
+		Where("a.hash IN ? AND a.table_id IN ? AND a.deleted_time IS NULL", hashes, tableIDs).
+		Select("a.hash AS hash, COUNT(*) AS count").
+		Group("a.hash").
+		Scan(&refs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blob references: %w", err)
+	}
+	references := make(map[string]int64, len(refs))
+	for _, ref := range refs {
+		references[ref.Hash] = ref.Count
+	}
+
+	for _, group := range groups {
+		stats.Duplicates = append(stats.Duplicates, &attachment.DuplicateBlob{
+			Hash:       group.Hash,
+			Name:       group.Name,
+			MimeType:   group.MimeType,
+			Size:       group.Size,
+			Copies:     group.Copies,
+			References: references[group.Hash],
+			SavedBytes: (group.Copies - 1) * group.Size,
+		})
+	}
+	return stats, nil
+}
+
+// first 按条件查询单个附件，不存在时返回 errors.ErrNotFound
+func (r *AttachmentRepositoryImpl) first(ctx context.Context, query string, args ...interface{}) (*attachment.AttachmentItem, error) {
+	var row models.Attachment
+	if err := r.db.WithContext(ctx).Where(query, args...).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return attachmentFromModel(&row), nil
+}
+
+func attachmentToModel(item *attachment.AttachmentItem) *models.Attachment {
+	return &models.Attachment{
+		ID:             item.ID,
+		Name:           item.Name,
+		Path:           item.Path,
+		Token:          item.Token,
+		Size:           item.Size,
+		MimeType:       item.MimeType,
+		Hash:           item.Hash,
+		PresignedURL:   item.PresignedURL,
+		Width:          item.Width,
+		Height:         item.Height,
+		SmallThumbnail: item.SmallThumbnail,
+		LargeThumbnail: item.LargeThumbnail,
+		TextPreview:    item.TextPreview,
+		Duration:       item.Duration,
+		PageCount:      item.PageCount,
+		PreviewStatus:  item.PreviewStatus,
+		TableID:        item.TableID,
+		FieldID:        item.FieldID,
+		RecordID:       item.RecordID,
+		CreatedBy:      item.CreatedBy,
+		CreatedTime:    item.CreatedTime,
+		UpdatedTime:    item.UpdatedTime,
+	}
+}
+
+func attachmentFromModel(row *models.Attachment) *attachment.AttachmentItem {
+	return &attachment.AttachmentItem{
+		ID:             row.ID,
+		Name:           row.Name,
+		Path:           row.Path,
+		Token:          row.Token,
+		Size:           row.Size,
+		MimeType:       row.MimeType,
+		Hash:           row.Hash,
+		PresignedURL:   row.PresignedURL,
+		Width:          row.Width,
+		Height:         row.Height,
+		SmallThumbnail: row.SmallThumbnail,
+		LargeThumbnail: row.LargeThumbnail,
+		TextPreview:    row.TextPreview,
+		Duration:       row.Duration,
+		PageCount:      row.PageCount,
+		PreviewStatus:  row.PreviewStatus,
+		TableID:        row.TableID,
+		FieldID:        row.FieldID,
+		RecordID:       row.RecordID,
+		CreatedBy:      row.CreatedBy,
+		CreatedTime:    row.CreatedTime,
+		UpdatedTime:    row.UpdatedTime,
+	}
+}
+
+// UploadTokenRepositoryImpl 上传令牌仓储GORM实现
+type UploadTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUploadTokenRepository 创建上传令牌仓储
+func NewUploadTokenRepository(db *gorm.DB) attachment.UploadTokenRepository {
+	return &UploadTokenRepositoryImpl{db: db}
+}
+
+// CreateUploadToken 创建上传令牌
+func (r *UploadTokenRepositoryImpl) CreateUploadToken(ctx context.Context, token *attachment.UploadToken) error {
+	row := models.UploadToken{
+		Token:       token.Token,
+		UserID:      token.UserID,
+		TableID:     token.TableID,
+		FieldID:     token.FieldID,
+		RecordID:    token.RecordID,
+		ExpiresAt:   token.ExpiresAt,
+		MaxSize:     token.MaxSize,
+		CreatedTime: token.CreatedTime,
+	}
+	if len(token.AllowedTypes) > 0 {
+		encoded, err := json.Marshal(token.AllowedTypes)
+		if err != nil {
+			return fmt.Errorf("failed to encode allowed types: %w", err)
+		}
+		allowedTypes := string(encoded)
+		row.AllowedTypes = &allowedTypes
+	}
+
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return fmt.Errorf("failed to create upload token: %w", err)
+	}
+	return nil
+}
+
+// GetUploadToken 获取上传令牌，不存在时返回 errors.ErrNotFound
+func (r *UploadTokenRepositoryImpl) GetUploadToken(ctx context.Context, token string) (*attachment.UploadToken, error) {
+	var row models.UploadToken
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload token: %w", err)
+	}
+
+	uploadToken := &attachment.UploadToken{
+		Token:       row.Token,
+		UserID:      row.UserID,
+		TableID:     row.TableID,
+		FieldID:     row.FieldID,
+		RecordID:    row.RecordID,
+		ExpiresAt:   row.ExpiresAt,
+		MaxSize:     row.MaxSize,
+		CreatedTime: row.CreatedTime,
+	}
+	if row.AllowedTypes != nil && *row.AllowedTypes != "" {
+		if err := json.Unmarshal([]byte(*row.AllowedTypes), &uploadToken.AllowedTypes); err != nil {
+			return nil, fmt.Errorf("invalid allowed types of upload token %s: %w", token, err)
+		}
+	}
+	return uploadToken, nil
+}
+
+// DeleteUploadToken 删除上传令牌
+func (r *UploadTokenRepositoryImpl) DeleteUploadToken(ctx context.Context, token string) error {
+	if err := r.db.WithContext(ctx).Where("token = ?", token).Delete(&models.UploadToken{}).Error; err != nil {
+		return fmt.Errorf("failed to delete upload token: %w", err)
+	}
+	return nil
+}
+
+// CleanupExpiredTokens 清理过期令牌
+func (r *UploadTokenRepositoryImpl) CleanupExpiredTokens(ctx context.Context) error {
+	if err := r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.UploadToken{}).Error; err != nil {
+		return fmt.Errorf("failed to cleanup expired upload tokens: %w", err)
+	}
+	return nil
+}
+
+// UploadSessionRepositoryImpl 断点续传会话仓储GORM实现
+type UploadSessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUploadSessionRepository 创建断点续传会话仓储
+func NewUploadSessionRepository(db *gorm.DB) attachment.UploadSessionRepository {
+	return &UploadSessionRepositoryImpl{db: db}
+}
+
+// CreateUploadSession 创建会话
+func (r *UploadSessionRepositoryImpl) CreateUploadSession(ctx context.Context, session *attachment.UploadSession) error {
+	row, err := uploadSessionToModel(session)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+	return nil
+}
+
+// GetUploadSession 获取会话，不存在时返回 errors.ErrNotFound
+func (r *UploadSessionRepositoryImpl) GetUploadSession(ctx context.Context, id string) (*attachment.UploadSession, error) {
+	var row models.UploadSession
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload session: %w", err)
+	}
+	return uploadSessionFromModel(&row)
+}
+
+// AdvanceUploadSession 追加分片并推进偏移量（以当前偏移量作条件，并发写同一偏移量时只有一个成功）
+func (r *UploadSessionRepositoryImpl) AdvanceUploadSession(ctx context.Context, session *attachment.UploadSession, part string, length int64) (bool, error) {
+	parts, err := json.Marshal(append(append([]string{}, session.Parts...), part))
+	if err != nil {
+		return false, fmt.Errorf("failed to encode upload parts: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.UploadSession{}).
+		Where("id = ? AND upload_offset = ?", session.ID, session.Offset).
+		Updates(map[string]interface{}{
+			"upload_offset": session.Offset + length,
+			"parts":         string(parts),
+			"updated_time":  time.Now(),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to advance upload session: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteUploadSession 删除会话
+func (r *UploadSessionRepositoryImpl) DeleteUploadSession(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.UploadSession{}).Error; err != nil {
+		return fmt.Errorf("failed to delete upload session: %w", err)
+	}
+	return nil
+}
+
+// ListExpiredUploadSessions 列出已过期的会话
+func (r *UploadSessionRepositoryImpl) ListExpiredUploadSessions(ctx context.Context, limit int) ([]*attachment.UploadSession, error) {
+	var rows []models.UploadSession
+	err := r.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired upload sessions: %w", err)
+	}
+
+	sessions := make([]*attachment.UploadSession, 0, len(rows))
+	for i := range rows {
+		session, err := uploadSessionFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func uploadSessionToModel(session *attachment.UploadSession) (*models.UploadSession, error) {
+	parts, err := json.Marshal(session.Parts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload parts: %w", err)
+	}
+	return &models.UploadSession{
+		ID:           session.ID,
+		Token:        session.Token,
+		Filename:     session.Filename,
+		MimeType:     session.MimeType,
+		Size:         session.Size,
+		UploadOffset: session.Offset,
+		Parts:        string(parts),
+		ExpiresAt:    session.ExpiresAt,
+		CreatedTime:  session.CreatedTime,
+		UpdatedTime:  session.UpdatedTime,
+	}, nil
+}
+
+func uploadSessionFromModel(row *models.UploadSession) (*attachment.UploadSession, error) {
+	session := &attachment.UploadSession{
+		ID:          row.ID,
+		Token:       row.Token,
+		Filename:    row.Filename,
+		MimeType:    row.MimeType,
+		Size:        row.Size,
+		Offset:      row.UploadOffset,
+		ExpiresAt:   row.ExpiresAt,
+		CreatedTime: row.CreatedTime,
+		UpdatedTime: row.UpdatedTime,
+	}
+	if row.Parts != "" && row.Parts != "null" {
+		if err := json.Unmarshal([]byte(row.Parts), &session.Parts); err != nil {
+			return nil, fmt.Errorf("invalid parts of upload session %s: %w", row.ID, err)
+		}
+	}
+	return session, nil
+}
+
+// BlobRepositoryImpl 内容寻址存储对象仓储GORM实现
+type BlobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBlobRepository 创建 Blob 仓储
+func NewBlobRepository(db *gorm.DB) attachment.BlobRepository {
+	return &BlobRepositoryImpl{db: db}
+}
+
+// orphanBlobCondition 没有未删除的附件引用该 Blob
+const orphanBlobCondition = "NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.hash = attachment_blobs.hash AND attachments.deleted_time IS NULL)"
+
+// CreateBlob 保存 Blob；同一内容已存在时只刷新最近使用时间并返回已有记录
+func (r *BlobRepositoryImpl) CreateBlob(ctx context.Context, blob *attachment.Blob) (*attachment.Blob, error) {
+	row := models.AttachmentBlob{
+		Hash:         blob.Hash,
+		Path:         blob.Path,
+		Size:         blob.Size,
+		MimeType:     blob.MimeType,
+		CreatedTime:  blob.CreatedTime,
+		LastUsedTime: blob.LastUsedTime,
+	}
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "hash"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_used_time"}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob: %w", err)
+	}
+	return r.get(ctx, blob.Hash)
+}
+
+// TouchBlob 刷新最近使用时间并返回 Blob，不存在时返回 errors.ErrNotFound
+func (r *BlobRepositoryImpl) TouchBlob(ctx context.Context, hash string) (*attachment.Blob, error) {
+	result := r.db.WithContext(ctx).Model(&models.AttachmentBlob{}).
+		Where("hash = ?", hash).
+		Update("last_used_time", time.Now())
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to touch blob: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.ErrNotFound
+	}
+	return r.get(ctx, hash)
+}
+
+// ListOrphanBlobs 列出在 before 之前最后使用、且没有附件引用的 Blob
+func (r *BlobRepositoryImpl) ListOrphanBlobs(ctx context.Context, before time.Time, limit int) ([]*attachment.Blob, error) {
+	var rows []models.AttachmentBlob
+	err := r.db.WithContext(ctx).
+		Where("last_used_time < ?", before).
+		Where(orphanBlobCondition).
+		Order("last_used_time ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orphan blobs: %w", err)
+	}
+
+	blobs := make([]*attachment.Blob, 0, len(rows))
+	for i := range rows {
+		blobs = append(blobs, blobFromModel(&rows[i]))
+	}
+	return blobs, nil
+}
+
+// DeleteOrphanBlob 在同一条语句中复核回收条件，期间被重新使用的 Blob 不会被删除
+func (r *BlobRepositoryImpl) DeleteOrphanBlob(ctx context.Context, hash string, before time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("hash = ? AND last_used_time < ?", hash, before).
+		Where(orphanBlobCondition).
+		Delete(&models.AttachmentBlob{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete blob: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// get 获取 Blob，不存在时返回 errors.ErrNotFound
+func (r *BlobRepositoryImpl) get(ctx context.Context, hash string) (*attachment.Blob, error) {
+	var row models.AttachmentBlob
+	if err := r.db.WithContext(ctx).Where("hash = ?", hash).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	return blobFromModel(&row), nil
+}
+
+func blobFromModel(row *models.AttachmentBlob) *attachment.Blob {
+	return &attachment.Blob{
+		Hash:         row.Hash,
+		Path:         row.Path,
+		Size:         row.Size,
+		MimeType:     row.MimeType,
+		CreatedTime:  row.CreatedTime,
+		LastUsedTime: row.LastUsedTime,
+	}
+}
+
+// AttachmentLinkRepositoryImpl 附件单元格引用仓储GORM实现
+type AttachmentLinkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAttachmentLinkRepository 创建附件单元格引用仓储
+func NewAttachmentLinkRepository(db *gorm.DB) attachment.LinkRepository {
+	return &AttachmentLinkRepositoryImpl{db: db}
+}
+
+// CreateLinks 创建引用
+func (r *AttachmentLinkRepositoryImpl) CreateLinks(ctx context.Context, links []*attachment.CellLink) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	rows := make([]models.AttachmentLink, 0, len(links))
+	for _, link := range links {
+		rows = append(rows, models.AttachmentLink{
+			ID:           utils.GenerateNanoID(16),
+			AttachmentID: link.AttachmentID,
+			Name:         link.Name,
+			Token:        link.Token,
+			TableID:      link.TableID,
+			RecordID:     link.RecordID,
+			FieldID:      link.FieldID,
+			CreatedBy:    link.CreatedBy,
+		})
+	}
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to create attachment links: %w", err)
+	}
+	return nil
+}
+
+// ListLinks 列出记录上的全部引用
+func (r *AttachmentLinkRepositoryImpl) ListLinks(ctx context.Context, tableID, recordID string) ([]*attachment.CellLink, error) {
+	return r.list(ctx, "table_id = ? AND record_id = ?", tableID, recordID)
+}
+
+// ListLinksByToken 列出引用某个附件的全部单元格
+func (r *AttachmentLinkRepositoryImpl) ListLinksByToken(ctx context.Context, token string) ([]*attachment.CellLink, error) {
+	return r.list(ctx, "token = ?", token)
+}
+
+// DeleteLinks 删除记录某个字段上指定附件的引用
+func (r *AttachmentLinkRepositoryImpl) DeleteLinks(ctx context.Context, tableID, recordID, fieldID string, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("table_id = ? AND record_id = ? AND field_id = ? AND token IN ?", tableID, recordID, fieldID, tokens).
+		Delete(&models.AttachmentLink{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment links: %w", err)
+	}
+	return nil
+}
+
+// CountLinks 统计附件剩余的引用数
+func (r *AttachmentLinkRepositoryImpl) CountLinks(ctx context.Context, token string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.AttachmentLink{}).Where("token = ?", token).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count attachment links: %w", err)
+	}
+	return count, nil
+}
+
+// list 按条件列出引用
+func (r *AttachmentLinkRepositoryImpl) list(ctx context.Context, query string, args ...interface{}) ([]*attachment.CellLink, error) {
+	var rows []models.AttachmentLink
+	err := r.db.WithContext(ctx).
+		Where(query, args...).
+		Order("created_time ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachment links: %w", err)
+	}
+
+	links := make([]*attachment.CellLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, &attachment.CellLink{
+			AttachmentID: row.AttachmentID,
+			Token:        row.Token,
+			Name:         row.Name,
+			TableID:      row.TableID,
+			RecordID:     row.RecordID,
+			FieldID:      row.FieldID,
+			CreatedBy:    row.CreatedBy,
+		})
+	}
+	return links, nil
+}
+
+// StorageUsageRepositoryImpl 空间存储用量仓储GORM实现
+type StorageUsageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewStorageUsageRepository 创建空间存储用量仓储
+func NewStorageUsageRepository(db *gorm.DB) attachment.UsageRepository {
+	return &StorageUsageRepositoryImpl{db: db}
+}
+
+// AddUsage 累加空间用量，同一空间合并为一行
+func (r *StorageUsageRepositoryImpl) AddUsage(ctx context.Context, spaceID string, bytes, files int64) error {
+	row := models.SpaceStorageUsage{
+		SpaceID:   spaceID,
+		UsedBytes: bytes,
+		FileCount: files,
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "space_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"used_bytes":         gorm.Expr("space_storage_usage.used_bytes + excluded.used_bytes"),
+				"file_count":         gorm.Expr("space_storage_usage.file_count + excluded.file_count"),
+				"last_modified_time": time.Now(),
+			}),
+		}).
+		Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("failed to add storage usage: %w", err)
+	}
+	return nil
+}
+
+// GetUsage 获取空间用量，没有记录时返回零用量
+func (r *StorageUsageRepositoryImpl) GetUsage(ctx context.Context, spaceID string) (*attachment.SpaceUsage, error) {
+	var row models.SpaceStorageUsage
+	err := r.db.WithContext(ctx).Where("space_id = ?", spaceID).Limit(1).Find(&row).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage usage: %w", err)
+	}
+
+	usage := &attachment.SpaceUsage{SpaceID: spaceID}
+	// 并发删除可能让计数短暂为负，对外按 0 展示
+	if row.UsedBytes > 0 {
+		usage.UsedBytes = row.UsedBytes
+	}
+	if row.FileCount > 0 {
+		usage.FileCount = row.FileCount
+	}
+	return usage, nil
+}
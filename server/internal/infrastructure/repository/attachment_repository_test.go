@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+func TestAttachmentRepositories(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		require.NoError(t, env.db.AutoMigrate(&models.Attachment{}, &models.UploadToken{}, &models.AttachmentLink{}, &models.SpaceStorageUsage{}, &models.UploadSession{}, &models.AttachmentBlob{}))
+		spaceID := "spc_" + env.baseID
+		sessionToken := "tok_session_" + env.baseID
+		sharedHash := fmt.Sprintf("%x", sha256.Sum256([]byte("shared_"+env.baseID)))
+		orphanHash := fmt.Sprintf("%x", sha256.Sum256([]byte("orphan_"+env.baseID)))
+		t.Cleanup(func() {
+			env.db.Unscoped().Where("table_id = ?", env.tableID).Delete(&models.Attachment{})
+			env.db.Where("table_id = ?", env.tableID).Delete(&models.UploadToken{})
+			env.db.Where("table_id = ?", env.tableID).Delete(&models.AttachmentLink{})
+			env.db.Where("space_id = ?", spaceID).Delete(&models.SpaceStorageUsage{})
+			env.db.Where("token = ?", sessionToken).Delete(&models.UploadSession{})
+			env.db.Where("hash IN ?", []string{sharedHash, orphanHash}).Delete(&models.AttachmentBlob{})
+		})
+
+		t.Run("upload tokens", func(t *testing.T) {
+			repo := NewUploadTokenRepository(env.db)
+			token := attachment.NewUploadToken("usr_1", env.tableID, "fld_files", "rec_1", 1024, []string{"image/png"})
+			require.NoError(t, repo.CreateUploadToken(ctx, token))
+
+			got, err := repo.GetUploadToken(ctx, token.Token)
+			require.NoError(t, err)
+			assert.Equal(t, "fld_files", got.FieldID)
+			assert.Equal(t, []string{"image/png"}, got.AllowedTypes)
+
+			expired := attachment.NewUploadToken("usr_1", env.tableID, "fld_files", "rec_1", 1024, nil)
+			expired.ExpiresAt = time.Now().Add(-time.Minute)
+			require.NoError(t, repo.CreateUploadToken(ctx, expired))
+			require.NoError(t, repo.CleanupExpiredTokens(ctx))
+
+			_, err = repo.GetUploadToken(ctx, expired.Token)
+			assert.Equal(t, pkgerrors.ErrNotFound, err)
+			_, err = repo.GetUploadToken(ctx, token.Token)
+			assert.NoError(t, err)
+		})
+
+		t.Run("attachments and stats", func(t *testing.T) {
+			repo := NewAttachmentRepository(env.db)
+			image := attachment.NewAttachmentItem("a.png", "attachments/a.png", "tok_image_"+env.baseID, "image/png", 100)
+			image.TableID, image.FieldID, image.RecordID, image.CreatedBy = env.tableID, "fld_files", "rec_1", "usr_1"
+			pdf := attachment.NewAttachmentItem("b.pdf", "attachments/b.pdf", "tok_pdf_"+env.baseID, "application/pdf", 50)
+			pdf.TableID, pdf.FieldID, pdf.RecordID, pdf.CreatedBy = env.tableID, "fld_files", "rec_2", "usr_1"
+			require.NoError(t, repo.CreateAttachment(ctx, image))
+			require.NoError(t, repo.CreateAttachment(ctx, pdf))
+
+			got, err := repo.GetAttachmentByToken(ctx, image.Token)
+			require.NoError(t, err)
+			assert.Equal(t, "rec_1", got.RecordID)
+			got, err = repo.GetAttachmentByPath(ctx, "attachments/b.pdf")
+			require.NoError(t, err)
+			assert.Equal(t, pdf.ID, got.ID)
+
+			list, err := repo.ListAttachments(ctx, env.tableID, "fld_files", "rec_2")
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+
+			stats, err := repo.GetAttachmentStats(ctx, env.tableID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), stats.TotalFiles)
+			assert.Equal(t, int64(150), stats.TotalSize)
+			assert.Equal(t, int64(1), stats.ImageFiles)
+			assert.Equal(t, int64(1), stats.DocumentFiles)
+
+			pageCount := 3
+			pdf.PreviewStatus, pdf.PageCount = attachment.PreviewStatusPending, &pageCount
+			require.NoError(t, repo.UpdateAttachment(ctx, pdf))
+			pending, err := repo.ListPendingPreviews(ctx, 100)
+			require.NoError(t, err)
+			pendingIDs := make([]string, 0, len(pending))
+			for _, item := range pending {
+				pendingIDs = append(pendingIDs, item.ID)
+			}
+			assert.Contains(t, pendingIDs, pdf.ID)
+			assert.NotContains(t, pendingIDs, image.ID)
+			got, err = repo.GetAttachmentByID(ctx, pdf.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 3, *got.PageCount)
+
+			require.NoError(t, repo.DeleteAttachment(ctx, pdf.ID))
+			_, err = repo.GetAttachmentByID(ctx, pdf.ID)
+			assert.Equal(t, pkgerrors.ErrNotFound, err)
+		})
+
+		t.Run("upload sessions", func(t *testing.T) {
+			repo := NewUploadSessionRepository(env.db)
+			token := &attachment.UploadToken{Token: sessionToken, ExpiresAt: time.Now().Add(time.Hour)}
+			session := attachment.NewUploadSession(token, "movie.mp4", "video/mp4", 10)
+			require.NoError(t, repo.CreateUploadSession(ctx, session))
+
+			advanced, err := repo.AdvanceUploadSession(ctx, session, "uploads/part_0", 4)
+			require.NoError(t, err)
+			assert.True(t, advanced)
+			// 偏移量已变化，基于旧快照的并发写入失败
+			advanced, err = repo.AdvanceUploadSession(ctx, session, "uploads/part_0b", 4)
+			require.NoError(t, err)
+			assert.False(t, advanced)
+
+			got, err := repo.GetUploadSession(ctx, session.ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(4), got.Offset)
+			assert.Equal(t, []string{"uploads/part_0"}, got.Parts)
+
+			expired := attachment.NewUploadSession(&attachment.UploadToken{Token: sessionToken, ExpiresAt: time.Now().Add(-time.Minute)}, "old.bin", "application/octet-stream", 10)
+			require.NoError(t, repo.CreateUploadSession(ctx, expired))
+			list, err := repo.ListExpiredUploadSessions(ctx, 100)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(list))
+			for _, item := range list {
+				ids = append(ids, item.ID)
+			}
+			assert.Contains(t, ids, expired.ID)
+			assert.NotContains(t, ids, session.ID)
+
+			require.NoError(t, repo.DeleteUploadSession(ctx, expired.ID))
+			_, err = repo.GetUploadSession(ctx, expired.ID)
+			assert.Equal(t, pkgerrors.ErrNotFound, err)
+		})
+
+		t.Run("cell links", func(t *testing.T) {
+			repo := NewAttachmentLinkRepository(env.db)
+			link := func(recordID, token string) *attachment.CellLink {
+				return &attachment.CellLink{AttachmentID: "att_" + token, Token: token, Name: token, TableID: env.tableID, RecordID: recordID, FieldID: "fld_files", CreatedBy: "usr_1"}
+			}
+			require.NoError(t, repo.CreateLinks(ctx, []*attachment.CellLink{link("rec_1", "tok_a"), link("rec_1", "tok_b"), link("rec_2", "tok_a")}))
+
+			links, err := repo.ListLinks(ctx, env.tableID, "rec_1")
+			require.NoError(t, err)
+			assert.Len(t, links, 2)
+
+			count, err := repo.CountLinks(ctx, "tok_a")
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), count)
+			links, err = repo.ListLinksByToken(ctx, "tok_a")
+			require.NoError(t, err)
+			assert.Len(t, links, 2)
+
+			require.NoError(t, repo.DeleteLinks(ctx, env.tableID, "rec_1", "fld_files", []string{"tok_a"}))
+			count, err = repo.CountLinks(ctx, "tok_a")
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count)
+		})
+
+		t.Run("space usage", func(t *testing.T) {
+			repo := NewStorageUsageRepository(env.db)
+			usage, err := repo.GetUsage(ctx, spaceID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), usage.UsedBytes)
+
+			require.NoError(t, repo.AddUsage(ctx, spaceID, 100, 1))
+			require.NoError(t, repo.AddUsage(ctx, spaceID, 50, 1))
+			require.NoError(t, repo.AddUsage(ctx, spaceID, -100, -1))
+
+			usage, err = repo.GetUsage(ctx, spaceID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(50), usage.UsedBytes)
+			assert.Equal(t, int64(1), usage.FileCount)
+		})
+
+		t.Run("blobs and dedup", func(t *testing.T) {
+			repo := NewAttachmentRepository(env.db)
+			blobRepo := NewBlobRepository(env.db)
+			links := NewAttachmentLinkRepository(env.db)
+			before, err := repo.GetDedupStats(ctx, []string{env.tableID}, 10)
+			require.NoError(t, err)
+
+			shared, err := blobRepo.CreateBlob(ctx, attachment.NewBlob(sharedHash, "image/png", 40))
+			require.NoError(t, err)
+			// 并发写入同一内容时返回先写入的 Blob
+			again, err := blobRepo.CreateBlob(ctx, attachment.NewBlob(sharedHash, "image/png", 40))
+			require.NoError(t, err)
+			assert.Equal(t, shared.Path, again.Path)
+
+			for i, recordID := range []string{"rec_1", "rec_2", "rec_3"} {
+				item := attachment.NewAttachmentItem(fmt.Sprintf("logo%d.png", i), shared.Path, fmt.Sprintf("tok_dup%d_%s", i, env.baseID), "image/png", 40)
+				item.TableID, item.FieldID, item.RecordID, item.CreatedBy, item.Hash = env.tableID, "fld_files", recordID, "usr_1", sharedHash
+				require.NoError(t, repo.CreateAttachment(ctx, item))
+				require.NoError(t, links.CreateLinks(ctx, []*attachment.CellLink{{AttachmentID: item.ID, Token: item.Token, Name: item.Name, TableID: env.tableID, RecordID: recordID, FieldID: "fld_files", CreatedBy: "usr_1"}}))
+			}
+
+			found, err := repo.FindAttachmentByHash(ctx, sharedHash, []string{env.tableID})
+			require.NoError(t, err)
+			assert.Equal(t, shared.Path, found.Path)
+			_, err = repo.FindAttachmentByHash(ctx, sharedHash, []string{"tbl_other"})
+			assert.Equal(t, pkgerrors.ErrNotFound, err)
+
+			stats, err := repo.GetDedupStats(ctx, []string{env.tableID}, 10)
+			require.NoError(t, err)
+			assert.Equal(t, before.Files+3, stats.Files)
+			assert.Equal(t, before.LogicalBytes+120, stats.LogicalBytes)
+			assert.Equal(t, before.StoredBytes+40, stats.StoredBytes)
+			assert.Equal(t, before.UniqueBlobs+1, stats.UniqueBlobs)
+			require.NotEmpty(t, stats.Duplicates)
+			assert.Equal(t, &attachment.DuplicateBlob{
+				Hash: sharedHash, Name: "logo0.png", MimeType: "image/png", Size: 40, Copies: 3, References: 3, SavedBytes: 80,
+			}, stats.Duplicates[0])
+
+			// 仍被引用或仍在宽限期内的 Blob 不会被回收
+			_, err = blobRepo.CreateBlob(ctx, attachment.NewBlob(orphanHash, "text/plain", 10))
+			require.NoError(t, err)
+			orphans, err := blobRepo.ListOrphanBlobs(ctx, time.Now().Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.NotContains(t, blobHashes(orphans), orphanHash)
+
+			future := time.Now().Add(time.Hour)
+			orphans, err = blobRepo.ListOrphanBlobs(ctx, future, 100)
+			require.NoError(t, err)
+			assert.Contains(t, blobHashes(orphans), orphanHash)
+			assert.NotContains(t, blobHashes(orphans), sharedHash)
+
+			deleted, err := blobRepo.DeleteOrphanBlob(ctx, sharedHash, future)
+			require.NoError(t, err)
+			assert.False(t, deleted)
+			deleted, err = blobRepo.DeleteOrphanBlob(ctx, orphanHash, future)
+			require.NoError(t, err)
+			assert.True(t, deleted)
+			_, err = blobRepo.TouchBlob(ctx, orphanHash)
+			assert.Equal(t, pkgerrors.ErrNotFound, err)
+
+			touched, err := blobRepo.TouchBlob(ctx, sharedHash)
+			require.NoError(t, err)
+			assert.Equal(t, int64(40), touched.Size)
+		})
+	})
+}
+
+func blobHashes(blobs []*attachment.Blob) []string {
+	hashes := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		hashes = append(hashes, blob.Hash)
+	}
+	return hashes
+}
@@ -113,7 +113,7 @@ func (r *FieldRepositoryImpl) Delete(ctx context.Context, id valueobject.FieldID
 	return r.db.WithContext(ctx).
 		Model(&models.Field{}).
 		Where("id = ?", id.String()).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // Exists 检查字段是否存在
@@ -255,6 +255,9 @@ func (r *FieldRepositoryImpl) List(ctx context.Context, filter repository.FieldF
 	if filter.FieldType != nil {
 		query = query.Where("type = ?", filter.FieldType.String())
 	}
+	if filter.LinkedTableID != nil {
+		query = query.Where("linked_table_id = ?", *filter.LinkedTableID)
+	}
 	if filter.Name != nil {
 		query = query.Where("name LIKE ?", "%"+*filter.Name+"%")
 	}
@@ -350,7 +353,7 @@ func (r *FieldRepositoryImpl) BatchDelete(ctx context.Context, ids []valueobject
 	return r.db.WithContext(ctx).
 		Model(&models.Field{}).
 		Where("id IN ?", idStrs).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // NextID 生成下一个字段ID
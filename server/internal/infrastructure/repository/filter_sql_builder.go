@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// filterColumnKind 列的比较方式
+type filterColumnKind int
+
+const (
+	filterKindText filterColumnKind = iota
+	filterKindNumber
+	filterKindDate
+	filterKindBool
+	filterKindJSON // 多选、用户、附件、链接、查找（JSONB存储）
+)
+
+// filterColumn 过滤器引用的物理列
+type filterColumn struct {
+	expr string
+	kind filterColumnKind
+}
+
+// systemFilterColumns 过滤器中可直接引用的系统列
+var systemFilterColumns = map[string]filterColumnKind{
+	"__id":                 filterKindText,
+	"__auto_number":        filterKindNumber,
+	"__created_time":       filterKindDate,
+	"__created_by":         filterKindText,
+	"__last_modified_time": filterKindDate,
+	"__last_modified_by":   filterKindText,
+}
+
+// jsonFilterFieldTypes 以 JSONB 存储的字段类型（与 convertValueFromDB 保持一致）
+var jsonFilterFieldTypes = map[string]bool{
+	"multipleSelect": true,
+	"user":           true,
+	"attachment":     true,
+	"link":           true,
+	"lookup":         true,
+}
+
+// FilterSQLBuilder 将视图过滤器（valueobject.Filter）翻译为物理表上的 WHERE 条件
+//
+// 语义与 valueobject.FilterItem.Match 保持一致：
+// 行级权限在列表查询（SQL）与单条校验、实时推送（内存）两条路径上得到相同结果。
+// 未知字段按 NULL 处理，与内存求值中缺失的值一致。
+type FilterSQLBuilder struct {
+	driver  string
+	columns map[string]filterColumn
+}
+
+// NewFilterSQLBuilder 创建过滤器SQL构建器
+func NewFilterSQLBuilder(driver string, fields []*fieldEntity.Field) *FilterSQLBuilder {
+	columns := make(map[string]filterColumn, len(fields)+len(systemFilterColumns))
+	for name, kind := range systemFilterColumns {
+		columns[name] = filterColumn{expr: quoteFilterIdentifier(name), kind: kind}
+	}
+	for _, field := range fields {
+		columns[field.ID().String()] = filterColumn{
+			expr: quoteFilterIdentifier(field.DBFieldName().String()),
+			kind: filterKindOf(field),
+		}
+	}
+
+	return &FilterSQLBuilder{
+		driver:  driver,
+		columns: columns,
+	}
+}
+
+// Build 生成单个过滤器的条件
+func (b *FilterSQLBuilder) Build(filter *valueobject.Filter) (string, []interface{}) {
+	if filter.IsEmpty() {
+		return "1 = 1", nil
+	}
+
+	joiner := " AND "
+	if filter.Operator == valueobject.FilterOperatorOr {
+		joiner = " OR "
+	}
+
+	conditions := make([]string, 0, len(filter.Filters))
+	var args []interface{}
+	for _, item := range filter.Filters {
+		cond, itemArgs := b.buildItem(item)
+		conditions = append(conditions, cond)
+		args = append(args, itemArgs...)
+	}
+
+	return "(" + strings.Join(conditions, joiner) + ")", args
+}
+
+// BuildScopes 生成行级权限范围条件（外层 AND，内层 OR）
+func (b *FilterSQLBuilder) BuildScopes(scopes [][]*valueobject.Filter) (string, []interface{}) {
+	if len(scopes) == 0 {
+		return "1 = 1", nil
+	}
+
+	groups := make([]string, 0, len(scopes))
+	var args []interface{}
+	for _, scope := range scopes {
+		if len(scope) == 0 {
+			continue
+		}
+		parts := make([]string, 0, len(scope))
+		for _, filter := range scope {
+			cond, filterArgs := b.Build(filter)
+			parts = append(parts, cond)
+			args = append(args, filterArgs...)
+		}
+		groups = append(groups, "("+strings.Join(parts, " OR ")+")")
+	}
+	if len(groups) == 0 {
+		return "1 = 1", nil
+	}
+
+	return strings.Join(groups, " AND "), args
+}
+
+// buildItem 生成单个过滤项的条件
+func (b *FilterSQLBuilder) buildItem(item valueobject.FilterItem) (string, []interface{}) {
+	col, ok := b.columns[item.FieldID]
+	if !ok {
+		col = filterColumn{expr: "NULL", kind: filterKindText}
+	}
+	tokens := valueobject.FilterValueTokens(item.Value)
+	isList := valueobject.IsListFilterValue(item.Value)
+
+	switch item.Operator {
+	case valueobject.FilterItemOpIs:
+		if isList {
+			return b.anyOf(col, tokens)
+		}
+		if len(tokens) != 1 {
+			return "1 = 0", nil
+		}
+		return b.equals(col, tokens[0])
+	case valueobject.FilterItemOpIsNot:
+		if isList {
+			return negate(b.anyOf(col, tokens))
+		}
+		if len(tokens) != 1 {
+			return "1 = 1", nil
+		}
+		return negate(b.equals(col, tokens[0]))
+	case valueobject.FilterItemOpContains:
+		return b.contains(col, fmt.Sprint(item.Value))
+	case valueobject.FilterItemOpNotContains:
+		return negate(b.contains(col, fmt.Sprint(item.Value)))
+	case valueobject.FilterItemOpIsEmpty:
+		return b.isEmpty(col), nil
+	case valueobject.FilterItemOpIsNotEmpty:
+		return "NOT " + b.isEmpty(col), nil
+	case valueobject.FilterItemOpGreater, valueobject.FilterItemOpIsAfter:
+		return b.compare(col, ">", item.Value)
+	case valueobject.FilterItemOpGreaterEqual:
+		return b.compare(col, ">=", item.Value)
+	case valueobject.FilterItemOpLess, valueobject.FilterItemOpIsBefore:
+		return b.compare(col, "<", item.Value)
+	case valueobject.FilterItemOpLessEqual:
+		return b.compare(col, "<=", item.Value)
+	case valueobject.FilterItemOpIsWithin:
+		bounds, ok := item.Value.([]interface{})
+		if !ok || len(bounds) != 2 {
+			return "1 = 0", nil
+		}
+		lower, lowerArgs := b.compare(col, ">=", bounds[0])
+		upper, upperArgs := b.compare(col, "<=", bounds[1])
+		return "(" + lower + " AND " + upper + ")", append(lowerArgs, upperArgs...)
+	case valueobject.FilterItemOpHasAnyOf:
+		return b.anyOf(col, tokens)
+	case valueobject.FilterItemOpHasAllOf:
+		return b.allOf(col, tokens)
+	case valueobject.FilterItemOpHasNoneOf:
+		return negate(b.anyOf(col, tokens))
+	case valueobject.FilterItemOpIsExactly:
+		return b.exactly(col, tokens)
+	case valueobject.FilterItemOpIsNotExactly:
+		return negate(b.exactly(col, tokens))
+	}
+
+	return "1 = 0", nil
+}
+
+// equals 单值相等（多值列按包含比较）
+func (b *FilterSQLBuilder) equals(col filterColumn, token string) (string, []interface{}) {
+	if col.kind == filterKindJSON {
+		return b.jsonContains(col, token)
+	}
+	arg, ok := typedFilterArg(col.kind, token)
+	if !ok {
+		return "1 = 0", nil
+	}
+	return col.expr + " = ?", []interface{}{arg}
+}
+
+// anyOf 包含任意一个
+func (b *FilterSQLBuilder) anyOf(col filterColumn, tokens []string) (string, []interface{}) {
+	if len(tokens) == 0 {
+		return "1 = 0", nil
+	}
+
+	if col.kind != filterKindJSON {
+		values := make([]interface{}, 0, len(tokens))
+		for _, token := range tokens {
+			if arg, ok := typedFilterArg(col.kind, token); ok {
+				values = append(values, arg)
+			}
+		}
+		if len(values) == 0 {
+			return "1 = 0", nil
+		}
+		return col.expr + " IN ?", []interface{}{values}
+	}
+
+	parts := make([]string, 0, len(tokens))
+	var args []interface{}
+	for _, token := range tokens {
+		cond, condArgs := b.jsonContains(col, token)
+		parts = append(parts, cond)
+		args = append(args, condArgs...)
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", args
+}
+
+// allOf 包含全部
+func (b *FilterSQLBuilder) allOf(col filterColumn, tokens []string) (string, []interface{}) {
+	if len(tokens) == 0 {
+		return "1 = 0", nil
+	}
+
+	parts := make([]string, 0, len(tokens))
+	var args []interface{}
+	for _, token := range tokens {
+		cond, condArgs := b.equals(col, token)
+		parts = append(parts, cond)
+		args = append(args, condArgs...)
+	}
+	return "(" + strings.Join(parts, " AND ") + ")", args
+}
+
+// exactly 完全匹配（值集合相同）
+func (b *FilterSQLBuilder) exactly(col filterColumn, tokens []string) (string, []interface{}) {
+	if len(tokens) == 0 {
+		return b.isEmpty(col), nil
+	}
+	if col.kind != filterKindJSON {
+		for _, token := range tokens[1:] {
+			if token != tokens[0] {
+				return "1 = 0", nil
+			}
+		}
+		return b.equals(col, tokens[0])
+	}
+
+	// 元素个数也必须一致（单个对象视为长度1的数组）
+	cond, args := b.allOf(col, tokens)
+	length := "jsonb_array_length"
+	if b.driver != "postgres" {
+		length = "json_array_length"
+	}
+	return fmt.Sprintf("(%s AND %s(%s) = %d)", cond, length, b.jsonArray(col.expr), len(uniqueFilterTokens(tokens))), args
+}
+
+// contains 文本包含（不区分大小写）
+func (b *FilterSQLBuilder) contains(col filterColumn, text string) (string, []interface{}) {
+	pattern := "%" + escapeLikePattern(text) + "%"
+	like := "LIKE"
+	if b.driver == "postgres" {
+		like = "ILIKE"
+	}
+	return fmt.Sprintf("CAST(%s AS TEXT) %s ? ESCAPE '\\'", col.expr, like), []interface{}{pattern}
+}
+
+// isEmpty 为空
+func (b *FilterSQLBuilder) isEmpty(col filterColumn) string {
+	switch col.kind {
+	case filterKindJSON:
+		return fmt.Sprintf("(%s IS NULL OR CAST(%s AS TEXT) IN ('', 'null', '[]', '{}'))", col.expr, col.expr)
+	case filterKindText:
+		return fmt.Sprintf("(%s IS NULL OR CAST(%s AS TEXT) = '')", col.expr, col.expr)
+	}
+	return fmt.Sprintf("(%s IS NULL)", col.expr)
+}
+
+// compare 大小比较（数值、日期、文本）
+func (b *FilterSQLBuilder) compare(col filterColumn, op string, value interface{}) (string, []interface{}) {
+	if col.kind == filterKindJSON || value == nil {
+		return "1 = 0", nil
+	}
+	tokens := valueobject.FilterValueTokens(value)
+	if len(tokens) != 1 {
+		return "1 = 0", nil
+	}
+	arg, ok := typedFilterArg(col.kind, tokens[0])
+	if !ok {
+		return "1 = 0", nil
+	}
+	return fmt.Sprintf("%s %s ?", col.expr, op), []interface{}{arg}
+}
+
+// jsonContains JSON列是否包含某个值（字符串元素，或 id 等于该值的对象）
+func (b *FilterSQLBuilder) jsonContains(col filterColumn, token string) (string, []interface{}) {
+	if b.driver == "postgres" {
+		return fmt.Sprintf("(%s @> to_jsonb(CAST(? AS TEXT)) OR %s @> jsonb_build_object('id', CAST(? AS TEXT)) OR %s @> jsonb_build_array(jsonb_build_object('id', CAST(? AS TEXT))))",
+			col.expr, col.expr, col.expr), []interface{}{token, token, token}
+	}
+	// SQLite 使用 JSON1 展开数组逐个比较
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) AS je WHERE (je.type = 'text' AND je.value = ?) OR (je.type = 'object' AND json_extract(je.value, '$.id') = ?))",
+		b.jsonArray(col.expr)), []interface{}{token, token}
+}
+
+// jsonArray JSON列统一为数组（单个值视为长度1的数组）
+// SQLite 中 JSON 以文本存储，非法 JSON 按单个字符串处理
+func (b *FilterSQLBuilder) jsonArray(expr string) string {
+	if b.driver == "postgres" {
+		return fmt.Sprintf("CASE WHEN jsonb_typeof(%s) = 'array' THEN %s ELSE jsonb_build_array(%s) END", expr, expr, expr)
+	}
+	return fmt.Sprintf("CASE WHEN json_valid(%s) AND json_type(%s) = 'array' THEN json(%s) WHEN json_valid(%s) THEN json_array(json(%s)) ELSE json_array(%s) END",
+		expr, expr, expr, expr, expr, expr)
+}
+
+// negate 取反（NULL 视为不满足原条件）
+func negate(cond string, args []interface{}) (string, []interface{}) {
+	return "NOT COALESCE(" + cond + ", FALSE)", args
+}
+
+// typedFilterArg 按列类型转换参数
+func typedFilterArg(kind filterColumnKind, token string) (interface{}, bool) {
+	switch kind {
+	case filterKindNumber:
+		f, err := strconv.ParseFloat(token, 64)
+		return f, err == nil
+	case filterKindBool:
+		v, err := strconv.ParseBool(token)
+		return v, err == nil
+	case filterKindDate:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, token); err == nil {
+				return t, true
+			}
+		}
+		return nil, false
+	}
+	return token, true
+}
+
+// filterKindOf 根据字段类型确定比较方式
+func filterKindOf(field *fieldEntity.Field) filterColumnKind {
+	if jsonFilterFieldTypes[field.Type().String()] {
+		return filterKindJSON
+	}
+
+	switch strings.ToUpper(field.DBFieldType()) {
+	case "JSONB", "JSON":
+		return filterKindJSON
+	case "NUMERIC", "INTEGER", "SERIAL", "BIGINT", "DOUBLE PRECISION", "REAL":
+		return filterKindNumber
+	case "DATE", "TIMESTAMP", "TIMESTAMPTZ":
+		return filterKindDate
+	case "BOOLEAN":
+		return filterKindBool
+	}
+	return filterKindText
+}
+
+func quoteFilterIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func uniqueFilterTokens(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	unique := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !seen[token] {
+			seen[token] = true
+			unique = append(unique, token)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// GroupKey 生成分组键表达式
+//   - 空值（与 isEmpty 语义一致）统一为 NULL，归入同一个空分组
+//   - 日期列按分组粒度截断为日期
+//   - 单个 JSON 值包装为长度1的数组，与数组形式的同一取值归为一组
+func (b *FilterSQLBuilder) GroupKey(item valueobject.GroupItem) string {
+	col, ok := b.columns[item.FieldID]
+	if !ok {
+		return "NULL"
+	}
+
+	switch col.kind {
+	case filterKindDate:
+		return b.dateBucket(col.expr, item.DateBucket)
+	case filterKindJSON:
+		return fmt.Sprintf("CASE WHEN %s THEN NULL ELSE %s END", b.isEmpty(col), b.jsonArray(col.expr))
+	case filterKindText:
+		return fmt.Sprintf("CASE WHEN %s THEN NULL ELSE %s END", b.isEmpty(col), col.expr)
+	}
+	return col.expr
+}
+
+// GroupOrder 生成分组键的排序表达式（空分组始终排在最后）
+func (b *FilterSQLBuilder) GroupOrder(item valueobject.GroupItem) string {
+	key := b.GroupKey(item)
+	dir := "ASC"
+	if item.Order == valueobject.SortOrderDesc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("(CASE WHEN %s IS NULL THEN 1 ELSE 0 END) ASC, %s %s", key, key, dir)
+}
+
+// Statistic 生成列统计的聚合表达式
+// 统计函数不适用于该列时返回 NULL
+func (b *FilterSQLBuilder) Statistic(statistic valueobject.ColumnStatistic) string {
+	col, ok := b.columns[statistic.FieldID]
+	if !ok {
+		col = filterColumn{expr: "NULL", kind: filterKindText}
+	}
+
+	switch statistic.Func {
+	case valueobject.StatisticSum:
+		if col.kind == filterKindNumber {
+			return fmt.Sprintf("SUM(%s)", col.expr)
+		}
+	case valueobject.StatisticAvg:
+		if col.kind == filterKindNumber {
+			return fmt.Sprintf("AVG(%s)", col.expr)
+		}
+	case valueobject.StatisticMin:
+		if col.kind == filterKindNumber || col.kind == filterKindDate {
+			return fmt.Sprintf("MIN(%s)", col.expr)
+		}
+	case valueobject.StatisticMax:
+		if col.kind == filterKindNumber || col.kind == filterKindDate {
+			return fmt.Sprintf("MAX(%s)", col.expr)
+		}
+	case valueobject.StatisticEarliest:
+		if col.kind == filterKindDate {
+			return fmt.Sprintf("MIN(%s)", col.expr)
+		}
+	case valueobject.StatisticLatest:
+		if col.kind == filterKindDate {
+			return fmt.Sprintf("MAX(%s)", col.expr)
+		}
+	case valueobject.StatisticChecked:
+		if col.kind == filterKindBool {
+			return fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0)", col.expr)
+		}
+	case valueobject.StatisticEmpty:
+		return fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0)", b.isEmpty(col))
+	case valueobject.StatisticFilled:
+		return fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 0 ELSE 1 END), 0)", b.isEmpty(col))
+	case valueobject.StatisticPercentFilled:
+		return fmt.Sprintf("(SUM(CASE WHEN %s THEN 0 ELSE 1 END) * 100.0 / NULLIF(COUNT(*), 0))", b.isEmpty(col))
+	case valueobject.StatisticUnique:
+		if col.kind == filterKindText || col.kind == filterKindJSON {
+			return fmt.Sprintf("COUNT(DISTINCT CASE WHEN %s THEN NULL ELSE %s END)", b.isEmpty(col), col.expr)
+		}
+		return fmt.Sprintf("COUNT(DISTINCT %s)", col.expr)
+	}
+	return "NULL"
+}
+
+// statisticKind 统计列的比较方式（用于转换统计结果）
+func (b *FilterSQLBuilder) statisticKind(fieldID string) filterColumnKind {
+	if col, ok := b.columns[fieldID]; ok {
+		return col.kind
+	}
+	return filterKindText
+}
+
+// dateBucket 日期截断表达式，结果为日期
+func (b *FilterSQLBuilder) dateBucket(expr string, bucket valueobject.GroupDateBucket) string {
+	if b.driver == "postgres" {
+		unit := "day"
+		switch bucket {
+		case valueobject.GroupDateBucketWeek:
+			unit = "week" // ISO 周，周一开始
+		case valueobject.GroupDateBucketMonth:
+			unit = "month"
+		}
+		return fmt.Sprintf("CAST(date_trunc('%s', CAST(%s AS TIMESTAMP)) AS DATE)", unit, expr)
+	}
+
+	switch bucket {
+	case valueobject.GroupDateBucketWeek:
+		return fmt.Sprintf("date(%s, 'weekday 0', '-6 days')", expr)
+	case valueobject.GroupDateBucketMonth:
+		return fmt.Sprintf("strftime('%%Y-%%m-01', %s)", expr)
+	}
+	return fmt.Sprintf("date(%s)", expr)
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+func newGroupTestField(t *testing.T, id, name, fieldType, dbType string) *fieldEntity.Field {
+	fieldName, err := fieldValueobject.NewFieldName(name)
+	require.NoError(t, err)
+	dbFieldName, err := fieldValueobject.NewDBFieldNameFromString(name)
+	require.NoError(t, err)
+	typ, err := fieldValueobject.NewFieldType(fieldType)
+	require.NoError(t, err)
+
+	return fieldEntity.ReconstructField(
+		fieldValueobject.NewFieldID(id), "tbl_001", fieldName, typ, dbFieldName, dbType,
+		nil, 0, 1, "user_001", time.Now(), time.Now(),
+	)
+}
+
+// setupGroupTestDB 在 SQLite 内存库中准备分组测试数据
+func setupGroupTestDB(t *testing.T) (*gorm.DB, *FilterSQLBuilder) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Exec(`CREATE TABLE records (__id TEXT, __auto_number INTEGER, status TEXT, amount NUMERIC, due TIMESTAMP, tags TEXT)`).Error)
+	rows := []struct {
+		id     string
+		status interface{}
+		amount interface{}
+		due    string
+		tags   interface{}
+	}{
+		{"rec_1", "待办", 10, "2025-01-06 09:00:00", `["a"]`},
+		{"rec_2", "待办", 30, "2025-01-12 18:00:00", `["a","b"]`},
+		{"rec_3", "完成", nil, "2025-02-03 00:00:00", `[]`},
+		{"rec_4", "", 5, "2025-01-13 00:00:00", nil},
+	}
+	for i, row := range rows {
+		require.NoError(t, db.Exec(`INSERT INTO records VALUES (?, ?, ?, ?, ?, ?)`, row.id, i+1, row.status, row.amount, row.due, row.tags).Error)
+	}
+
+	builder := NewFilterSQLBuilder("sqlite", []*fieldEntity.Field{
+		newGroupTestField(t, "fld_status", "status", "singleSelect", "VARCHAR(255)"),
+		newGroupTestField(t, "fld_amount", "amount", "number", "NUMERIC"),
+		newGroupTestField(t, "fld_due", "due", "date", "TIMESTAMP"),
+		newGroupTestField(t, "fld_tags", "tags", "multipleSelect", "JSONB"),
+	})
+	return db, builder
+}
+
+func TestFilterSQLBuilder_GroupKey(t *testing.T) {
+	db, builder := setupGroupTestDB(t)
+
+	status := valueobject.GroupItem{FieldID: "fld_status", Order: valueobject.SortOrderDesc}
+	var rows []map[string]interface{}
+	err := db.Table("records").
+		Select(builder.GroupKey(status) + " AS g, COUNT(*) AS c").
+		Group(builder.GroupKey(status)).
+		Order(builder.GroupOrder(status)).
+		Find(&rows).Error
+	require.NoError(t, err)
+
+	// 空字符串与 NULL 归入空分组，空分组排在最后
+	require.Len(t, rows, 3)
+	assert.Equal(t, "待办", groupKeyValue(filterKindText, rows[0]["g"]))
+	assert.Equal(t, "完成", groupKeyValue(filterKindText, rows[1]["g"]))
+	assert.Nil(t, groupKeyValue(filterKindText, rows[2]["g"]))
+	assert.Equal(t, int64(2), toInt64(rows[0]["c"]))
+
+	week := valueobject.GroupItem{FieldID: "fld_due", Order: valueobject.SortOrderAsc, DateBucket: valueobject.GroupDateBucketWeek}
+	var weeks []string
+	err = db.Table("records").
+		Select(builder.GroupKey(week)).
+		Group(builder.GroupKey(week)).
+		Order(builder.GroupOrder(week)).
+		Pluck(builder.GroupKey(week), &weeks).Error
+	require.NoError(t, err)
+	// 按周分组以周一为起始日期
+	assert.Equal(t, []string{"2025-01-06", "2025-01-13", "2025-02-03"}, weeks)
+
+	tags := valueobject.GroupItem{FieldID: "fld_tags", Order: valueobject.SortOrderAsc}
+	var tagGroups int64
+	err = db.Table("(?) AS grouped", db.Table("records").Select(builder.GroupKey(tags)+" AS g").Group(builder.GroupKey(tags))).Count(&tagGroups).Error
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, tagGroups) // ["a"]、["a","b"]、空
+}
+
+func TestFilterSQLBuilder_Statistic(t *testing.T) {
+	db, builder := setupGroupTestDB(t)
+
+	statistics := []valueobject.ColumnStatistic{
+		{FieldID: "fld_amount", Func: valueobject.StatisticSum},
+		{FieldID: "fld_amount", Func: valueobject.StatisticAvg},
+		{FieldID: "fld_amount", Func: valueobject.StatisticMax},
+		{FieldID: "fld_amount", Func: valueobject.StatisticEmpty},
+		{FieldID: "fld_tags", Func: valueobject.StatisticFilled},
+		{FieldID: "fld_status", Func: valueobject.StatisticUnique},
+		{FieldID: "fld_status", Func: valueobject.StatisticSum}, // 不适用，返回 NULL
+		{FieldID: "fld_amount", Func: valueobject.StatisticPercentFilled},
+		{FieldID: "fld_due", Func: valueobject.StatisticEarliest},
+		{FieldID: "fld_due", Func: valueobject.StatisticLatest},
+		{FieldID: "fld_amount", Func: valueobject.StatisticLatest}, // 只适用于日期列
+	}
+	selects := make([]string, 0, len(statistics))
+	for i, statistic := range statistics {
+		selects = append(selects, fmt.Sprintf("%s AS s%d", builder.Statistic(statistic), i))
+	}
+
+	var rows []map[string]interface{}
+	require.NoError(t, db.Table("records").Select(strings.Join(selects, ", ")).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	row := rows[0]
+
+	assert.Equal(t, 45.0, statisticValue(valueobject.StatisticSum, filterKindNumber, row["s0"]))
+	assert.Equal(t, 15.0, statisticValue(valueobject.StatisticAvg, filterKindNumber, row["s1"]))
+	assert.Equal(t, 30.0, statisticValue(valueobject.StatisticMax, filterKindNumber, row["s2"]))
+	assert.Equal(t, int64(1), statisticValue(valueobject.StatisticEmpty, filterKindNumber, row["s3"]))
+	assert.Equal(t, int64(2), statisticValue(valueobject.StatisticFilled, filterKindJSON, row["s4"]))
+	assert.Equal(t, int64(2), statisticValue(valueobject.StatisticUnique, filterKindText, row["s5"]))
+	assert.Nil(t, statisticValue(valueobject.StatisticSum, filterKindText, row["s6"]))
+	assert.Equal(t, 75.0, statisticValue(valueobject.StatisticPercentFilled, filterKindNumber, row["s7"]))
+	assert.Contains(t, fmt.Sprint(statisticValue(valueobject.StatisticEarliest, filterKindDate, row["s8"])), "2025-01-06")
+	assert.Contains(t, fmt.Sprint(statisticValue(valueobject.StatisticLatest, filterKindDate, row["s9"])), "2025-02-03")
+	assert.Nil(t, statisticValue(valueobject.StatisticLatest, filterKindNumber, row["s10"]))
+}
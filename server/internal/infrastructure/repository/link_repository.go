@@ -0,0 +1,668 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database"
+	pkgDatabase "github.com/easyspace-ai/luckdb/server/pkg/database"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// linkMigrationBatchSize JSON 关联迁移每批读取的记录数
+const linkMigrationBatchSize = 500
+
+// LinkRepositoryImpl Link 字段关联存储实现
+// 外键列建在自身物理表上，中间表与物理表位于同一个 Base（PostgreSQL schema / SQLite 表名前缀）
+//
+// 约束：
+//   - PostgreSQL 上外键列引用关联表的 __id，关联记录删除时置空
+//   - one_to_one 的外键列唯一，一条记录最多被关联一次
+//   - 中间表不加外键约束，删除记录时由记录仓储一并清理（DeleteRecordLinks/DeleteForeignLinks）
+//   - SQLite 不支持为已有表添加外键约束，写入前由 LinkRecordService 校验关联记录存在
+type LinkRepositoryImpl struct {
+	db         *gorm.DB
+	dbProvider database.DBProvider
+	tableRepo  tableRepo.TableRepository
+	fieldRepo  repository.FieldRepository
+}
+
+// NewLinkRepository 创建 Link 关联存储仓储
+func NewLinkRepository(
+	db *gorm.DB,
+	dbProvider database.DBProvider,
+	tableRepo tableRepo.TableRepository,
+	fieldRepo repository.FieldRepository,
+) recordRepo.LinkRepository {
+	return &LinkRepositoryImpl{
+		db:         db,
+		dbProvider: dbProvider,
+		tableRepo:  tableRepo,
+		fieldRepo:  fieldRepo,
+	}
+}
+
+// EnsureStorage 创建外键列或中间表（幂等）
+// 对称字段的另一侧已有存储时直接复用（键名互换），不再创建
+func (r *LinkRepositoryImpl) EnsureStorage(ctx context.Context, field *fieldEntity.Field) (*fieldValueobject.LinkOptions, error) {
+	options, err := linkOptionsOf(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.SymmetricFieldID != "" {
+		symmetric, err := r.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(options.SymmetricFieldID))
+		if err != nil {
+			return nil, fmt.Errorf("获取对称字段失败: %w", err)
+		}
+		if symmetric == nil {
+			return nil, fmt.Errorf("对称字段不存在: %s", options.SymmetricFieldID)
+		}
+		if primary, ok := linkStorageOf(symmetric); ok && primary.SymmetricFieldID == field.ID().String() {
+			options.ShareStorage(primary)
+			return options, nil
+		}
+	}
+
+	table, err := r.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return nil, fmt.Errorf("获取Table信息失败: %w", err)
+	}
+	if table == nil {
+		return nil, fmt.Errorf("Table不存在: %s", field.TableID())
+	}
+
+	db := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx)
+	fieldID := field.ID().String()
+
+	if options.UsesJunctionTable() {
+		junctionTable := r.dbProvider.GenerateTableName(table.BaseID(), fieldValueobject.LinkJunctionTableSuffix(fieldID))
+		createSQL := fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS ? (%s VARCHAR(50) NOT NULL, %s VARCHAR(50) NOT NULL, %s INTEGER NOT NULL DEFAULT 0, PRIMARY KEY (%s, %s))`,
+			fieldValueobject.LinkJunctionSelfKey,
+			fieldValueobject.LinkJunctionForeignKey,
+			fieldValueobject.LinkJunctionOrderKey,
+			fieldValueobject.LinkJunctionSelfKey,
+			fieldValueobject.LinkJunctionForeignKey,
+		)
+		if err := db.Exec(createSQL, clause.Table{Name: junctionTable}).Error; err != nil {
+			return nil, fmt.Errorf("创建中间表失败: %w", err)
+		}
+		// 主键 (self_id, foreign_id) 覆盖正向查询，反向查询需要 foreign_id 索引
+		if err := db.Exec(`CREATE INDEX IF NOT EXISTS ? ON ? (?)`,
+			clause.Column{Name: "idx_" + fieldID + "_foreign"},
+			clause.Table{Name: junctionTable},
+			clause.Column{Name: fieldValueobject.LinkJunctionForeignKey},
+		).Error; err != nil {
+			return nil, fmt.Errorf("创建中间表索引失败: %w", err)
+		}
+
+		options.FkHostTableName = junctionTable
+		options.SelfKeyName = fieldValueobject.LinkJunctionSelfKey
+		options.ForeignKeyName = fieldValueobject.LinkJunctionForeignKey
+	} else {
+		hostTable := r.dbProvider.GenerateTableName(table.BaseID(), table.ID().String())
+		fkColumn := fieldValueobject.LinkForeignKeyColumn(fieldID)
+		if !db.Migrator().HasColumn(hostTable, fkColumn) {
+			if err := r.dbProvider.AddColumn(ctx, table.BaseID(), table.ID().String(), database.ColumnDefinition{
+				Name: fkColumn,
+				Type: "VARCHAR(50)",
+			}); err != nil {
+				return nil, fmt.Errorf("创建外键列失败: %w", err)
+			}
+		}
+		// one_to_one 的外键列唯一（NULL 不受限制）
+		indexSQL, indexName := `CREATE INDEX IF NOT EXISTS ? ON ? (?)`, "idx_"+fieldID+"_fk"
+		if options.Relationship == "one_to_one" {
+			indexSQL, indexName = `CREATE UNIQUE INDEX IF NOT EXISTS ? ON ? (?)`, "idx_"+fieldID+"_fk_unique"
+		}
+		if err := db.Exec(indexSQL,
+			clause.Column{Name: indexName},
+			clause.Table{Name: hostTable},
+			clause.Column{Name: fkColumn},
+		).Error; err != nil {
+			return nil, fmt.Errorf("创建外键索引失败: %w", err)
+		}
+		if err := r.addForeignKeyConstraint(ctx, db, table.BaseID(), table.ID().String(), fieldID, options.LinkedTableID); err != nil {
+			return nil, err
+		}
+
+		options.FkHostTableName = hostTable
+		options.SelfKeyName = fieldValueobject.LinkRecordIDKey
+		options.ForeignKeyName = fkColumn
+	}
+
+	logger.Info("✅ Link 字段关联存储已就绪",
+		logger.String("field_id", fieldID),
+		logger.String("host_table", options.FkHostTableName),
+		logger.Bool("junction", options.UsesJunctionTable()))
+
+	return options, nil
+}
+
+// addForeignKeyConstraint 外键列引用关联表的记录（仅 PostgreSQL，幂等）
+// 关联表可以位于同一 Space 的其他 Base（schema）中
+func (r *LinkRepositoryImpl) addForeignKeyConstraint(ctx context.Context, db *gorm.DB, baseID, tableID, fieldID, linkedTableID string) error {
+	if r.dbProvider.DriverName() != "postgres" {
+		return nil
+	}
+
+	linkedTable, err := r.tableRepo.GetByID(ctx, linkedTableID)
+	if err != nil {
+		return fmt.Errorf("获取关联表信息失败: %w", err)
+	}
+	if linkedTable == nil {
+		return fmt.Errorf("关联表不存在: %s", linkedTableID)
+	}
+
+	constraint := "fk_" + fieldID
+	var count int64
+	if err := db.Raw(
+		`SELECT COUNT(*) FROM information_schema.table_constraints WHERE constraint_schema = ? AND table_name = ? AND constraint_name = ?`,
+		baseID, tableID, constraint,
+	).Scan(&count).Error; err != nil {
+		return fmt.Errorf("读取外键约束失败: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if err := db.Exec(`ALTER TABLE ? ADD CONSTRAINT ? FOREIGN KEY (?) REFERENCES ? (?) ON DELETE SET NULL`,
+		clause.Table{Name: r.dbProvider.GenerateTableName(baseID, tableID)},
+		clause.Column{Name: constraint},
+		clause.Column{Name: fieldValueobject.LinkForeignKeyColumn(fieldID)},
+		clause.Table{Name: r.dbProvider.GenerateTableName(linkedTable.BaseID(), linkedTableID)},
+		clause.Column{Name: fieldValueobject.LinkRecordIDKey},
+	).Error; err != nil {
+		return fmt.Errorf("创建外键约束失败: %w", err)
+	}
+	return nil
+}
+
+// DropStorage 删除外键列或中间表
+// 对称字段复用对方的存储，只有创建存储的字段被删除时才删除存储
+func (r *LinkRepositoryImpl) DropStorage(ctx context.Context, field *fieldEntity.Field) error {
+	options, ok := linkStorageOf(field)
+	if !ok || !options.OwnsStorage(field.ID().String()) {
+		return nil
+	}
+
+	if options.StorageKind() == fieldValueobject.LinkStorageJunction {
+		if err := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+			Exec(`DROP TABLE IF EXISTS ?`, clause.Table{Name: options.FkHostTableName}).Error; err != nil {
+			return fmt.Errorf("删除中间表失败: %w", err)
+		}
+		return nil
+	}
+
+	table, err := r.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return fmt.Errorf("获取Table信息失败: %w", err)
+	}
+	if table == nil {
+		// 表已删除时外键列随物理表一起删除
+		return nil
+	}
+	if err := r.dbProvider.DropColumn(ctx, table.BaseID(), table.ID().String(), options.ForeignKeyName); err != nil {
+		return fmt.Errorf("删除外键列失败: %w", err)
+	}
+	return nil
+}
+
+// ReplaceLinks 替换记录的全部关联
+func (r *LinkRepositoryImpl) ReplaceLinks(ctx context.Context, field *fieldEntity.Field, recordID string, foreignIDs []string) error {
+	options, ok := linkStorageOf(field)
+	if !ok {
+		return nil
+	}
+	db := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx)
+	selfKey := clause.Column{Name: options.SelfKeyName}
+
+	switch options.StorageKind() {
+	case fieldValueobject.LinkStorageForeignKey:
+		var foreignID interface{}
+		if len(foreignIDs) > 0 {
+			foreignID = foreignIDs[0]
+		}
+		if err := db.Table(options.FkHostTableName).
+			Where(clause.Eq{Column: selfKey, Value: recordID}).
+			Update(options.ForeignKeyName, foreignID).Error; err != nil {
+			return fmt.Errorf("更新外键失败: %w", err)
+		}
+		return nil
+
+	case fieldValueobject.LinkStorageReverseForeignKey:
+		// 外键列在关联表上：先解除原有关联，再把外键指向当前记录
+		if err := db.Table(options.FkHostTableName).
+			Where(clause.Eq{Column: selfKey, Value: recordID}).
+			Update(options.SelfKeyName, nil).Error; err != nil {
+			return fmt.Errorf("清除外键失败: %w", err)
+		}
+		if len(foreignIDs) == 0 {
+			return nil
+		}
+		if err := db.Table(options.FkHostTableName).
+			Where(clause.IN{Column: clause.Column{Name: options.ForeignKeyName}, Values: stringValues(foreignIDs)}).
+			Update(options.SelfKeyName, recordID).Error; err != nil {
+			return fmt.Errorf("更新外键失败: %w", err)
+		}
+		return nil
+	}
+
+	if err := db.Table(options.FkHostTableName).
+		Where(clause.Eq{Column: selfKey, Value: recordID}).
+		Delete(nil).Error; err != nil {
+		return fmt.Errorf("清除关联失败: %w", err)
+	}
+	if len(foreignIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(foreignIDs))
+	for i, foreignID := range foreignIDs {
+		rows = append(rows, map[string]interface{}{
+			options.SelfKeyName:                   recordID,
+			options.ForeignKeyName:                foreignID,
+			fieldValueobject.LinkJunctionOrderKey: i,
+		})
+	}
+	if err := db.Table(options.FkHostTableName).Create(rows).Error; err != nil {
+		return fmt.Errorf("写入关联失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteRecordLinks 删除记录作为关联发起方的所有关联
+// 外键存储时关联随记录行一起删除，无需处理
+func (r *LinkRepositoryImpl) DeleteRecordLinks(ctx context.Context, field *fieldEntity.Field, recordIDs []string) error {
+	options, ok := linkStorageOf(field)
+	if !ok || len(recordIDs) == 0 {
+		return nil
+	}
+	query := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Table(options.FkHostTableName).
+		Where(clause.IN{Column: clause.Column{Name: options.SelfKeyName}, Values: stringValues(recordIDs)})
+
+	var err error
+	switch options.StorageKind() {
+	case fieldValueobject.LinkStorageJunction:
+		err = query.Delete(nil).Error
+	case fieldValueobject.LinkStorageReverseForeignKey:
+		err = query.Update(options.SelfKeyName, nil).Error
+	}
+	if err != nil {
+		return fmt.Errorf("删除关联失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteForeignLinks 删除指向 foreignIDs 的关联，并刷新受影响记录的 JSON 缓存
+// 反向外键保存在关联表的记录行上，需在这些行删除前调用
+func (r *LinkRepositoryImpl) DeleteForeignLinks(ctx context.Context, field *fieldEntity.Field, foreignIDs []string) error {
+	options, ok := linkStorageOf(field)
+	if !ok || len(foreignIDs) == 0 {
+		return nil
+	}
+	affected, err := r.FindReferencingRecordIDs(ctx, field, foreignIDs)
+	if err != nil || len(affected) == 0 {
+		return err
+	}
+
+	query := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Table(options.FkHostTableName).
+		Where(clause.IN{Column: clause.Column{Name: options.ForeignKeyName}, Values: stringValues(foreignIDs)})
+	switch options.StorageKind() {
+	case fieldValueobject.LinkStorageJunction:
+		err = query.Delete(nil).Error
+	case fieldValueobject.LinkStorageForeignKey:
+		err = query.Update(options.ForeignKeyName, nil).Error
+	case fieldValueobject.LinkStorageReverseForeignKey:
+		err = query.Update(options.SelfKeyName, nil).Error
+	}
+	if err != nil {
+		return fmt.Errorf("删除反向关联失败: %w", err)
+	}
+
+	return r.RefreshLinkCache(ctx, field, affected)
+}
+
+// FindLinkedRecordIDs 按关联顺序查询记录关联的记录ID
+func (r *LinkRepositoryImpl) FindLinkedRecordIDs(ctx context.Context, field *fieldEntity.Field, recordID string) ([]string, error) {
+	options, ok := linkStorageOf(field)
+	if !ok {
+		return nil, fmt.Errorf("Link 字段未创建关联存储: %s", field.ID().String())
+	}
+
+	query := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Table(options.FkHostTableName).
+		Where(clause.Eq{Column: clause.Column{Name: options.SelfKeyName}, Value: recordID}).
+		Where(clause.Neq{Column: clause.Column{Name: options.ForeignKeyName}, Value: nil})
+	switch options.StorageKind() {
+	case fieldValueobject.LinkStorageJunction:
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: fieldValueobject.LinkJunctionOrderKey}})
+	case fieldValueobject.LinkStorageReverseForeignKey:
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: "__auto_number"}})
+	}
+
+	ids := []string{}
+	if err := query.Pluck(options.ForeignKeyName, &ids).Error; err != nil {
+		return nil, fmt.Errorf("查询关联记录失败: %w", err)
+	}
+	return ids, nil
+}
+
+// FindReferencingRecordIDs 反向查询：哪些记录关联了 foreignIDs
+func (r *LinkRepositoryImpl) FindReferencingRecordIDs(ctx context.Context, field *fieldEntity.Field, foreignIDs []string) ([]string, error) {
+	options, ok := linkStorageOf(field)
+	if !ok {
+		return nil, fmt.Errorf("Link 字段未创建关联存储: %s", field.ID().String())
+	}
+
+	ids := []string{}
+	if len(foreignIDs) == 0 {
+		return ids, nil
+	}
+	if err := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Table(options.FkHostTableName).
+		Distinct(options.SelfKeyName).
+		Where(clause.IN{Column: clause.Column{Name: options.ForeignKeyName}, Values: stringValues(foreignIDs)}).
+		Where(clause.Neq{Column: clause.Column{Name: options.SelfKeyName}, Value: nil}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: options.SelfKeyName}}).
+		Pluck(options.SelfKeyName, &ids).Error; err != nil {
+		return nil, fmt.Errorf("反向查询关联记录失败: %w", err)
+	}
+	return ids, nil
+}
+
+// RefreshLinkCache 按关联存储重写记录列中的 JSON 缓存
+// 对称字段的缓存在另一侧修改关联后通过它刷新，字段所在表可以位于其他 Base
+func (r *LinkRepositoryImpl) RefreshLinkCache(ctx context.Context, field *fieldEntity.Field, recordIDs []string) error {
+	if _, ok := linkStorageOf(field); !ok || len(recordIDs) == 0 {
+		return nil
+	}
+
+	table, err := r.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return fmt.Errorf("获取Table信息失败: %w", err)
+	}
+	if table == nil {
+		return nil
+	}
+	fullTableName := r.dbProvider.GenerateTableName(table.BaseID(), table.ID().String())
+	db := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx)
+
+	for _, recordID := range recordIDs {
+		linkedIDs, err := r.FindLinkedRecordIDs(ctx, field, recordID)
+		if err != nil {
+			return err
+		}
+
+		var cache interface{}
+		if len(linkedIDs) > 0 {
+			items := make([]map[string]interface{}, 0, len(linkedIDs))
+			for _, id := range linkedIDs {
+				items = append(items, map[string]interface{}{"id": id})
+			}
+			raw, err := json.Marshal(items)
+			if err != nil {
+				return err
+			}
+			cache = datatypes.JSON(raw)
+		}
+
+		if err := db.Table(fullTableName).
+			Where(clause.Eq{Column: clause.Column{Name: fieldValueobject.LinkRecordIDKey}, Value: recordID}).
+			Update(field.DBFieldName().String(), cache).Error; err != nil {
+			return fmt.Errorf("刷新关联缓存失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindLinkFieldsPointingTo 查询关联到指定表的所有 Link 字段
+func (r *LinkRepositoryImpl) FindLinkFieldsPointingTo(ctx context.Context, tableID string) ([]*fieldEntity.Field, error) {
+	fieldType, err := fieldValueobject.NewFieldType(fieldValueobject.TypeLink)
+	if err != nil {
+		return nil, err
+	}
+	linkFields, _, err := r.fieldRepo.List(ctx, repository.FieldFilter{FieldType: &fieldType, LinkedTableID: &tableID})
+	if err != nil {
+		return nil, fmt.Errorf("查询 Link 字段失败: %w", err)
+	}
+
+	var result []*fieldEntity.Field
+	for _, field := range linkFields {
+		if options := field.Options(); options != nil && options.Link != nil && options.Link.LinkedTableID == tableID {
+			result = append(result, field)
+		}
+	}
+	return result, nil
+}
+
+// ConvertLegacyColumn 将旧版 TEXT[] 关联列转换为 JSONB 字符串数组
+// 只有 PostgreSQL 上早期创建的 Link 字段是 TEXT[] 列；已是 JSONB 时不做修改，可在每次启动时执行
+func (r *LinkRepositoryImpl) ConvertLegacyColumn(ctx context.Context, field *fieldEntity.Field) (bool, error) {
+	if r.dbProvider.DriverName() != "postgres" || field.Type().String() != fieldValueobject.TypeLink {
+		return false, nil
+	}
+
+	table, err := r.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return false, fmt.Errorf("获取Table信息失败: %w", err)
+	}
+	if table == nil {
+		return false, nil
+	}
+
+	db := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx)
+	column := field.DBFieldName().String()
+	var dataType string
+	if err := db.Raw(
+		`SELECT data_type FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?`,
+		table.BaseID(), table.ID().String(), column,
+	).Scan(&dataType).Error; err != nil {
+		return false, fmt.Errorf("读取关联列类型失败: %w", err)
+	}
+	if dataType != "ARRAY" {
+		return false, nil
+	}
+
+	if err := db.Exec(`ALTER TABLE ? ALTER COLUMN ? TYPE JSONB USING to_jsonb(?)`,
+		clause.Table{Name: r.dbProvider.GenerateTableName(table.BaseID(), table.ID().String())},
+		clause.Column{Name: column},
+		clause.Column{Name: column},
+	).Error; err != nil {
+		return false, fmt.Errorf("转换关联列类型失败: %w", err)
+	}
+	return true, nil
+}
+
+// MigrateJSONLinks 将记录列中的 JSON 关联值写入外键列/中间表
+// 按记录ID分批读取，已迁移的记录会被覆盖为列中的值，可重复执行
+func (r *LinkRepositoryImpl) MigrateJSONLinks(ctx context.Context, field *fieldEntity.Field) (int, error) {
+	if _, ok := linkStorageOf(field); !ok {
+		return 0, fmt.Errorf("Link 字段未创建关联存储: %s", field.ID().String())
+	}
+
+	table, err := r.tableRepo.GetByID(ctx, field.TableID())
+	if err != nil {
+		return 0, fmt.Errorf("获取Table信息失败: %w", err)
+	}
+	if table == nil {
+		return 0, fmt.Errorf("Table不存在: %s", field.TableID())
+	}
+
+	fullTableName := r.dbProvider.GenerateTableName(table.BaseID(), table.ID().String())
+	valueColumn := field.DBFieldName().String()
+	db := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx)
+
+	migrated := 0
+	lastID := ""
+	for {
+		var rows []map[string]interface{}
+		if err := db.Table(fullTableName).
+			Select([]string{fieldValueobject.LinkRecordIDKey, valueColumn}).
+			Where(clause.Gt{Column: clause.Column{Name: fieldValueobject.LinkRecordIDKey}, Value: lastID}).
+			Where(clause.Neq{Column: clause.Column{Name: valueColumn}, Value: nil}).
+			Order(clause.OrderByColumn{Column: clause.Column{Name: fieldValueobject.LinkRecordIDKey}}).
+			Limit(linkMigrationBatchSize).
+			Find(&rows).Error; err != nil {
+			return migrated, fmt.Errorf("读取关联列失败: %w", err)
+		}
+
+		cells := make(map[string][]string, len(rows))
+		var referenced []string
+		for _, row := range rows {
+			recordID := fmt.Sprintf("%v", row[fieldValueobject.LinkRecordIDKey])
+			cells[recordID] = fieldValueobject.ExtractLinkRecordIDs(decodeLinkCellValue(row[valueColumn]))
+			referenced = append(referenced, cells[recordID]...)
+		}
+		// 已删除的关联记录不迁移（外键约束不允许引用不存在的记录）
+		existing, err := r.existingRecordIDs(ctx, db, field.Options().Link.LinkedTableID, referenced)
+		if err != nil {
+			return migrated, err
+		}
+
+		for _, row := range rows {
+			recordID := fmt.Sprintf("%v", row[fieldValueobject.LinkRecordIDKey])
+			lastID = recordID
+
+			foreignIDs := make([]string, 0, len(cells[recordID]))
+			for _, id := range cells[recordID] {
+				if existing[id] {
+					foreignIDs = append(foreignIDs, id)
+				}
+			}
+			if err := r.ReplaceLinks(ctx, field, recordID, foreignIDs); err != nil {
+				return migrated, fmt.Errorf("迁移记录 %s 失败: %w", recordID, err)
+			}
+			migrated++
+		}
+
+		if len(rows) < linkMigrationBatchSize {
+			return migrated, nil
+		}
+	}
+}
+
+// existingRecordIDs 关联表中存在的记录ID
+func (r *LinkRepositoryImpl) existingRecordIDs(ctx context.Context, db *gorm.DB, tableID string, ids []string) (map[string]bool, error) {
+	existing := make(map[string]bool, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	table, err := r.tableRepo.GetByID(ctx, tableID)
+	if err != nil {
+		return nil, fmt.Errorf("获取关联表信息失败: %w", err)
+	}
+	if table == nil {
+		return existing, nil
+	}
+
+	var found []string
+	if err := db.Table(r.dbProvider.GenerateTableName(table.BaseID(), tableID)).
+		Where(clause.IN{Column: clause.Column{Name: fieldValueobject.LinkRecordIDKey}, Values: stringValues(ids)}).
+		Pluck(fieldValueobject.LinkRecordIDKey, &found).Error; err != nil {
+		return nil, fmt.Errorf("查询关联记录失败: %w", err)
+	}
+	for _, id := range found {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// linkOptionsOf 获取 Link 字段选项
+func linkOptionsOf(field *fieldEntity.Field) (*fieldValueobject.LinkOptions, error) {
+	if field.Type().String() != fieldValueobject.TypeLink {
+		return nil, fmt.Errorf("字段 %s 不是 Link 字段", field.ID().String())
+	}
+	options := field.Options()
+	if options == nil || options.Link == nil {
+		return nil, fmt.Errorf("Link 字段缺少关联配置: %s", field.ID().String())
+	}
+	return options.Link, nil
+}
+
+// linkStorageOf 获取已创建关联存储的 Link 选项
+func linkStorageOf(field *fieldEntity.Field) (*fieldValueobject.LinkOptions, bool) {
+	options, err := linkOptionsOf(field)
+	if err != nil || !options.HasStorage() {
+		return nil, false
+	}
+	return options, true
+}
+
+// decodeLinkCellValue 解码记录列中的 Link 值
+// JSON/JSONB 列返回 JSON 文本，旧版 PostgreSQL TEXT[] 列返回 {a,b} 形式的数组字面量
+func decodeLinkCellValue(raw interface{}) interface{} {
+	var text string
+	switch v := raw.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return raw
+	}
+
+	text = strings.TrimSpace(text)
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(text), &decoded); err == nil {
+		return decoded
+	}
+	if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+		items := strings.Split(strings.TrimSuffix(strings.TrimPrefix(text, "{"), "}"), ",")
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, strings.Trim(strings.TrimSpace(item), `"`))
+		}
+		return ids
+	}
+	return text
+}
+
+// stringValues 转换为 clause.IN 需要的值列表
+func stringValues(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+// stringSlicesEqual 两个ID列表是否相同（含顺序）
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueStrings 去重并保持顺序
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	recordValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	viewDomain "github.com/easyspace-ai/luckdb/server/internal/domain/view"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+)
+
+// addLinkField 创建关联到本表的 Link 字段（storage 为 true 时创建外键列/中间表）
+func (env *providerTestEnv) addLinkField(t *testing.T, links recordRepo.LinkRepository, name, relationship string, storage bool) *fieldEntity.Field {
+	ctx := context.Background()
+	field := env.addField(t, name, fieldValueobject.TypeLink, "JSONB", false)
+	require.NoError(t, field.UpdateOptions(fieldValueobject.NewFieldOptions().WithLink(env.tableID, relationship, false)))
+	if storage {
+		_, err := links.EnsureStorage(ctx, field)
+		require.NoError(t, err)
+	}
+	require.NoError(t, env.fieldRepo.Save(ctx, field))
+	return field
+}
+
+func TestLinkRepository_Storage(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		links := NewLinkRepository(env.db, env.provider, NewTableRepository(env.db), env.fieldRepo)
+		env.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+		refs := env.addLinkField(t, links, "refs", "many_to_many", true)
+		parent := env.addLinkField(t, links, "parent", "many_to_one", true)
+		assert.True(t, refs.Options().Link.UsesJunctionTable())
+		assert.False(t, parent.Options().Link.UsesJunctionTable())
+
+		a := env.createRecord(t, map[string]interface{}{"name": "a"})
+		b := env.createRecord(t, map[string]interface{}{"name": "b"})
+		c := env.createRecord(t, map[string]interface{}{"name": "c"})
+		r1 := env.createRecord(t, map[string]interface{}{
+			"name":   "r1",
+			"refs":   []interface{}{map[string]interface{}{"id": c.ID().String()}, map[string]interface{}{"id": a.ID().String()}},
+			"parent": []interface{}{b.ID().String()},
+		})
+		r2 := env.createRecord(t, map[string]interface{}{"name": "r2", "refs": []interface{}{a.ID().String()}})
+
+		// 中间表保持关联顺序，外键列只保存一条关联
+		linked, err := links.FindLinkedRecordIDs(ctx, refs, r1.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{c.ID().String(), a.ID().String()}, linked)
+		linked, err = links.FindLinkedRecordIDs(ctx, parent, r1.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{b.ID().String()}, linked)
+
+		// 反向查询
+		referencing, err := links.FindReferencingRecordIDs(ctx, refs, []string{a.ID().String()})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{r1.ID().String(), r2.ID().String()}, referencing)
+		referencing, err = links.FindReferencingRecordIDs(ctx, parent, []string{b.ID().String()})
+		require.NoError(t, err)
+		assert.Equal(t, []string{r1.ID().String()}, referencing)
+
+		pointing, err := links.FindLinkFieldsPointingTo(ctx, env.tableID)
+		require.NoError(t, err)
+		assert.Len(t, pointing, 2)
+
+		// 删除被关联记录时清理指向它的关联
+		require.NoError(t, env.recordRepo.DeleteByTableAndID(ctx, env.tableID, a.ID()))
+		linked, err = links.FindLinkedRecordIDs(ctx, refs, r1.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{c.ID().String()}, linked)
+		require.NoError(t, env.recordRepo.DeleteByTableAndID(ctx, env.tableID, b.ID()))
+		linked, err = links.FindLinkedRecordIDs(ctx, parent, r1.ID().String())
+		require.NoError(t, err)
+		assert.Empty(t, linked)
+
+		// 删除字段时删除中间表
+		require.NoError(t, links.DropStorage(ctx, refs))
+		assert.False(t, env.db.Migrator().HasTable(refs.Options().Link.FkHostTableName))
+	})
+}
+
+func TestLinkRepository_Migration(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		links := NewLinkRepository(env.db, env.provider, NewTableRepository(env.db), env.fieldRepo)
+		env.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+		legacy := env.addLinkField(t, links, "legacy", "many_to_many", false)
+
+		// 未创建关联存储时只写入 JSON 列
+		a := env.createRecord(t, map[string]interface{}{"name": "a"})
+		b := env.createRecord(t, map[string]interface{}{"name": "b"})
+		r1 := env.createRecord(t, map[string]interface{}{"name": "r1", "legacy": []interface{}{b.ID().String(), a.ID().String()}})
+		env.createRecord(t, map[string]interface{}{"name": "r2", "legacy": []interface{}{}})
+
+		_, err := links.EnsureStorage(ctx, legacy)
+		require.NoError(t, err)
+		migrated, err := links.MigrateJSONLinks(ctx, legacy)
+		require.NoError(t, err)
+		assert.Equal(t, 2, migrated)
+
+		linked, err := links.FindLinkedRecordIDs(ctx, legacy, r1.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{b.ID().String(), a.ID().String()}, linked)
+
+		// 重复执行结果不变
+		_, err = links.MigrateJSONLinks(ctx, legacy)
+		require.NoError(t, err)
+		referencing, err := links.FindReferencingRecordIDs(ctx, legacy, []string{a.ID().String()})
+		require.NoError(t, err)
+		assert.Equal(t, []string{r1.ID().String()}, referencing)
+	})
+}
+
+func TestLinkRepository_LegacyColumn(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		links := NewLinkRepository(env.db, env.provider, NewTableRepository(env.db), env.fieldRepo)
+		if env.provider.DriverName() != "postgres" {
+			converted, err := links.ConvertLegacyColumn(ctx, env.addLinkField(t, links, "refs", "many_to_many", false))
+			require.NoError(t, err)
+			assert.False(t, converted)
+			return
+		}
+
+		// 早期版本的 Link 字段列为 TEXT[]
+		env.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+		legacy := env.addField(t, "legacy", fieldValueobject.TypeLink, "TEXT[]", false)
+		require.NoError(t, legacy.UpdateOptions(fieldValueobject.NewFieldOptions().WithLink(env.tableID, "many_to_many", false)))
+		a := env.createRecord(t, map[string]interface{}{"name": "a"})
+		b := env.createRecord(t, map[string]interface{}{"name": "b"})
+		r1 := env.createRecord(t, map[string]interface{}{"name": "r1"})
+		require.NoError(t, env.db.Exec(`UPDATE ? SET ? = ARRAY[?, ?]::text[] WHERE __id = ?`,
+			clause.Table{Name: env.provider.GenerateTableName(env.baseID, env.tableID)},
+			clause.Column{Name: legacy.DBFieldName().String()},
+			b.ID().String(), a.ID().String(), r1.ID().String(),
+		).Error)
+
+		converted, err := links.ConvertLegacyColumn(ctx, legacy)
+		require.NoError(t, err)
+		assert.True(t, converted)
+		converted, err = links.ConvertLegacyColumn(ctx, legacy)
+		require.NoError(t, err)
+		assert.False(t, converted)
+
+		_, err = links.EnsureStorage(ctx, legacy)
+		require.NoError(t, err)
+		_, err = links.MigrateJSONLinks(ctx, legacy)
+		require.NoError(t, err)
+		linked, err := links.FindLinkedRecordIDs(ctx, legacy, r1.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{b.ID().String(), a.ID().String()}, linked)
+
+		// 转换后关联缓存可以写入
+		require.NoError(t, links.RefreshLinkCache(ctx, legacy, []string{r1.ID().String()}))
+	})
+}
+
+// linkCache 读取记录 Link 列中的关联缓存
+func (env *providerTestEnv) linkCache(t *testing.T, field *fieldEntity.Field, recordID recordValueobject.RecordID) []string {
+	record, err := env.recordRepo.FindByTableAndID(context.Background(), env.tableID, recordID)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	value, _ := record.Data().Get(field.ID().String())
+	return fieldValueobject.ExtractLinkRecordIDs(value)
+}
+
+func TestLinkRepository_SymmetricAcrossBases(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		links := NewLinkRepository(env.db, env.provider, NewTableRepository(env.db), env.fieldRepo)
+		other := newProviderTestEnv(t, env.db) // 同一数据库中的另一个 Base
+		require.NotEqual(t, env.baseID, other.baseID)
+
+		env.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+		other.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+
+		// owner（many_to_one，外键列）与对称字段 items（one_to_many，反向外键）共享存储
+		owner := env.addField(t, "owner", fieldValueobject.TypeLink, "JSONB", false)
+		items := other.addField(t, "items", fieldValueobject.TypeLink, "JSONB", false)
+		ownerOptions := fieldValueobject.NewFieldOptions().WithLink(other.tableID, "many_to_one", true)
+		ownerOptions.Link.BaseID = other.baseID
+		ownerOptions.Link.SymmetricFieldID = items.ID().String()
+		require.NoError(t, owner.UpdateOptions(ownerOptions))
+		_, err := links.EnsureStorage(ctx, owner)
+		require.NoError(t, err)
+		require.NoError(t, env.fieldRepo.Save(ctx, owner))
+
+		itemsOptions := fieldValueobject.NewFieldOptions().WithLink(env.tableID, fieldValueobject.InverseRelationship("many_to_one"), true)
+		itemsOptions.Link.BaseID = env.baseID
+		itemsOptions.Link.SymmetricFieldID = owner.ID().String()
+		require.NoError(t, items.UpdateOptions(itemsOptions))
+		_, err = links.EnsureStorage(ctx, items)
+		require.NoError(t, err)
+		require.NoError(t, other.fieldRepo.Save(ctx, items))
+		assert.Equal(t, fieldValueobject.LinkStorageForeignKey, owner.Options().Link.StorageKind())
+		assert.Equal(t, fieldValueobject.LinkStorageReverseForeignKey, items.Options().Link.StorageKind())
+		assert.Equal(t, owner.Options().Link.FkHostTableName, items.Options().Link.FkHostTableName)
+		assert.True(t, owner.Options().Link.OwnsStorage(owner.ID().String()))
+		assert.False(t, items.Options().Link.OwnsStorage(items.ID().String()))
+
+		b1 := other.createRecord(t, map[string]interface{}{"name": "b1"})
+		b2 := other.createRecord(t, map[string]interface{}{"name": "b2"})
+		a1 := env.createRecord(t, map[string]interface{}{"name": "a1", "owner": []interface{}{b1.ID().String()}})
+		a2 := env.createRecord(t, map[string]interface{}{"name": "a2", "owner": []interface{}{b1.ID().String()}})
+
+		// 一侧写入后另一侧的关联和缓存同步更新
+		linked, err := links.FindLinkedRecordIDs(ctx, items, b1.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{a1.ID().String(), a2.ID().String()}, linked)
+		assert.Equal(t, []string{a1.ID().String(), a2.ID().String()}, other.linkCache(t, items, b1.ID()))
+
+		// 从对称字段一侧修改：a2 改为关联 b2，b1 失去 a2
+		require.NoError(t, b2.SetFieldValue(items.ID().String(), []interface{}{a2.ID().String()}, "usr_test"))
+		require.NoError(t, other.recordRepo.Save(ctx, b2))
+		linked, err = links.FindLinkedRecordIDs(ctx, owner, a2.ID().String())
+		require.NoError(t, err)
+		assert.Equal(t, []string{b2.ID().String()}, linked)
+		assert.Equal(t, []string{b2.ID().String()}, env.linkCache(t, owner, a2.ID()))
+		assert.Equal(t, []string{a1.ID().String()}, other.linkCache(t, items, b1.ID()))
+
+		// 删除被关联记录时清理另一个 Base 中的关联
+		require.NoError(t, other.recordRepo.DeleteByTableAndID(ctx, other.tableID, b1.ID()))
+		linked, err = links.FindLinkedRecordIDs(ctx, owner, a1.ID().String())
+		require.NoError(t, err)
+		assert.Empty(t, linked)
+		assert.Empty(t, env.linkCache(t, owner, a1.ID()))
+
+		// 反向外键在被删除的记录行上，删除后对称字段一侧的缓存同样刷新
+		require.NoError(t, env.recordRepo.DeleteByTableAndID(ctx, env.tableID, a2.ID()))
+		assert.Empty(t, other.linkCache(t, items, b2.ID()))
+	})
+}
+
+func TestLinkRepository_CandidateFilters(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		name := env.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+		status := env.addField(t, "status", "singleSelect", "VARCHAR(255)", false)
+
+		acme := env.createRecord(t, map[string]interface{}{"name": "Acme Corp", "status": "active"})
+		env.createRecord(t, map[string]interface{}{"name": "Acme Labs", "status": "archived"})
+		globex := env.createRecord(t, map[string]interface{}{"name": "Globex", "status": "active"})
+
+		constraint, err := viewDomain.LinkFilter("and", []valueobject.FilterItem{
+			{FieldID: status.ID().String(), Operator: valueobject.FilterItemOpIs, Value: "active"},
+		})
+		require.NoError(t, err)
+
+		list := func(filters ...*valueobject.Filter) []string {
+			records, _, err := env.recordRepo.List(ctx, recordRepo.RecordFilter{
+				TableID: &env.tableID, Filters: filters, OrderBy: "__auto_number",
+			})
+			require.NoError(t, err)
+			ids := make([]string, 0, len(records))
+			for _, record := range records {
+				ids = append(ids, record.ID().String())
+			}
+			return ids
+		}
+
+		// 选择器：约束 + 标题字段搜索
+		assert.Equal(t, []string{acme.ID().String()}, list(constraint, viewDomain.LinkSearchFilter(name.ID().String(), "acme")))
+		// 写入校验：按记录ID范围查询满足约束的记录
+		assert.Equal(t, []string{acme.ID().String(), globex.ID().String()},
+			list(constraint, viewDomain.LinkRecordIDFilter([]string{acme.ID().String(), globex.ID().String(), "rec_missing"})))
+	})
+}
@@ -83,6 +83,9 @@ func ToFieldEntity(dbField *models.Field) (*entity.Field, error) {
 	// 设置约束
 	field.SetRequired(dbField.IsRequired)
 	field.SetUnique(dbField.IsUnique)
+	if dbField.HasError != nil && *dbField.HasError {
+		field.MarkAsError()
+	}
 
 	return field, nil
 }
@@ -111,13 +114,13 @@ func ToFieldModel(field *entity.Field) (*models.Field, error) {
 	isRequired := field.IsRequired()
 	isUnique := field.IsUnique()
 	isPrimary := field.IsPrimary()
+	hasError := field.HasError()
 
 	// 初始化布尔指针字段为 false（参考原版所有布尔字段都需要设置）
 	falseVal := false
 	notNull := &falseVal
 	isLookup := &falseVal
 	isMultipleCellValue := &falseVal
-	hasError := &falseVal
 	isPending := &falseVal
 
 	// 设置Order字段
@@ -138,7 +141,7 @@ func ToFieldModel(field *entity.Field) (*models.Field, error) {
 		NotNull:             notNull,
 		IsLookup:            isLookup,
 		IsMultipleCellValue: isMultipleCellValue,
-		HasError:            hasError,
+		HasError:            &hasError,
 		IsPending:           isPending,
 		FieldOrder:          field.Order(),
 		Order:               &orderValue,
@@ -154,6 +157,12 @@ func ToFieldModel(field *entity.Field) (*models.Field, error) {
 		dbField.Description = field.Description()
 	}
 
+	// Link 字段的关联表单独存一列，便于按关联表查询
+	if options := field.Options(); options != nil && options.Link != nil && options.Link.LinkedTableID != "" {
+		linkedTableID := options.Link.LinkedTableID
+		dbField.LinkedTableID = &linkedTableID
+	}
+
 	return dbField, nil
 }
 
@@ -0,0 +1,706 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+// notificationSortColumns 通知列表允许的排序列
+var notificationSortColumns = map[string]bool{
+	"created_time": true,
+	"updated_time": true,
+	"priority":     true,
+	"status":       true,
+	"type":         true,
+}
+
+// recentNotificationLimit 统计中返回的最近通知数
+const recentNotificationLimit = 5
+
+// NotificationRepositoryImpl 通知、模板、订阅、偏好与投递记录的GORM实现
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository 创建通知仓储
+func NewNotificationRepository(db *gorm.DB) *NotificationRepositoryImpl {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+var (
+	_ notification.Repository             = (*NotificationRepositoryImpl)(nil)
+	_ notification.TemplateRepository     = (*NotificationRepositoryImpl)(nil)
+	_ notification.SubscriptionRepository = (*NotificationRepositoryImpl)(nil)
+	_ notification.PreferenceRepository   = (*NotificationRepositoryImpl)(nil)
+	_ notification.DeliveryRepository     = (*NotificationRepositoryImpl)(nil)
+)
+
+// ==================== 通知 ====================
+
+// CreateNotification 创建通知
+func (r *NotificationRepositoryImpl) CreateNotification(ctx context.Context, item *notification.Notification) error {
+	row, err := notificationToModel(item)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// GetNotification 获取通知，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) GetNotification(ctx context.Context, id string) (*notification.Notification, error) {
+	var row models.Notification
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	return notificationFromModel(&row)
+}
+
+// UpdateNotification 更新通知
+func (r *NotificationRepositoryImpl) UpdateNotification(ctx context.Context, item *notification.Notification) error {
+	row, err := notificationToModel(item)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+		return fmt.Errorf("failed to update notification: %w", err)
+	}
+	return nil
+}
+
+// DeleteNotification 删除通知，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) DeleteNotification(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Notification{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListNotifications 列出通知
+func (r *NotificationRepositoryImpl) ListNotifications(ctx context.Context, req *notification.ListNotificationsRequest) (*notification.ListNotificationsResponse, error) {
+	query := r.db.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ?", req.UserID)
+	if req.Type != nil {
+		query = query.Where("type = ?", string(*req.Type))
+	}
+	if req.Status != nil {
+		query = query.Where("status = ?", string(*req.Status))
+	}
+	if req.Priority != nil {
+		query = query.Where("priority = ?", string(*req.Priority))
+	}
+	if req.SourceID != "" {
+		query = query.Where("source_id = ?", req.SourceID)
+	}
+	if req.SourceType != "" {
+		query = query.Where("source_type = ?", req.SourceType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	sortBy := "created_time"
+	if notificationSortColumns[req.SortBy] {
+		sortBy = req.SortBy
+	}
+	desc := req.SortOrder != "asc"
+
+	var rows []models.Notification
+	err := query.Order(clause.OrderByColumn{Column: clause.Column{Name: sortBy}, Desc: desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc}).
+		Offset((req.Page - 1) * req.PageSize).
+		Limit(req.PageSize).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	items, err := notificationsFromModels(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &notification.ListNotificationsResponse{
+		Notifications: items,
+		Total:         total,
+		Page:          req.Page,
+		PageSize:      req.PageSize,
+		TotalPages:    int((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+	}, nil
+}
+
+// MarkNotificationsRead 标记通知为已读
+func (r *NotificationRepositoryImpl) MarkNotificationsRead(ctx context.Context, notificationIDs []string) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+	return r.markRead(ctx, r.db.WithContext(ctx).Where("id IN ?", notificationIDs))
+}
+
+// MarkAllNotificationsRead 标记用户所有未读通知为已读
+func (r *NotificationRepositoryImpl) MarkAllNotificationsRead(ctx context.Context, userID string) error {
+	return r.markRead(ctx, r.db.WithContext(ctx).Where("user_id = ?", userID))
+}
+
+func (r *NotificationRepositoryImpl) markRead(ctx context.Context, query *gorm.DB) error {
+	now := time.Now()
+	err := query.Model(&models.Notification{}).
+		Where("status = ?", string(notification.NotificationStatusUnread)).
+		Updates(map[string]interface{}{
+			"status":       string(notification.NotificationStatusRead),
+			"read_at":      now,
+			"updated_time": now,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return nil
+}
+
+// GetNotificationStats 获取用户通知统计
+func (r *NotificationRepositoryImpl) GetNotificationStats(ctx context.Context, userID string) (*notification.NotificationStats, error) {
+	var groups []struct {
+		Status   string
+		Type     string
+		Priority string
+		Count    int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Select("status, type, priority, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("status, type, priority").
+		Scan(&groups).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification stats: %w", err)
+	}
+
+	stats := &notification.NotificationStats{
+		ByType:     make(map[notification.NotificationType]int64),
+		ByPriority: make(map[notification.NotificationPriority]int64),
+	}
+	for _, group := range groups {
+		stats.TotalNotifications += group.Count
+		switch notification.NotificationStatus(group.Status) {
+		case notification.NotificationStatusUnread:
+			stats.UnreadCount += group.Count
+		case notification.NotificationStatusRead:
+			stats.ReadCount += group.Count
+		case notification.NotificationStatusArchived:
+			stats.ArchivedCount += group.Count
+		}
+		stats.ByType[notification.NotificationType(group.Type)] += group.Count
+		stats.ByPriority[notification.NotificationPriority(group.Priority)] += group.Count
+	}
+
+	var recent []models.Notification
+	err = r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("created_time DESC").Limit(recentNotificationLimit).
+		Find(&recent).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent notifications: %w", err)
+	}
+	if stats.RecentActivity, err = notificationsFromModels(recent); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// CleanupExpiredNotifications 删除已过期的通知
+func (r *NotificationRepositoryImpl) CleanupExpiredNotifications(ctx context.Context) error {
+	err := r.db.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at < ?", time.Now()).
+		Delete(&models.Notification{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to cleanup expired notifications: %w", err)
+	}
+	return nil
+}
+
+// ==================== 模板 ====================
+
+// CreateTemplate 创建模板
+func (r *NotificationRepositoryImpl) CreateTemplate(ctx context.Context, template *notification.NotificationTemplate) error {
+	row, err := templateToModel(template)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return fmt.Errorf("failed to create notification template: %w", err)
+	}
+	return nil
+}
+
+// GetTemplate 获取模板，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) GetTemplate(ctx context.Context, id string) (*notification.NotificationTemplate, error) {
+	return r.firstTemplate(ctx, "id = ?", id)
+}
+
+// GetTemplateByType 根据类型获取模板，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) GetTemplateByType(ctx context.Context, notificationType notification.NotificationType) (*notification.NotificationTemplate, error) {
+	return r.firstTemplate(ctx, "type = ?", string(notificationType))
+}
+
+// UpdateTemplate 更新模板
+func (r *NotificationRepositoryImpl) UpdateTemplate(ctx context.Context, template *notification.NotificationTemplate) error {
+	row, err := templateToModel(template)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+		return fmt.Errorf("failed to update notification template: %w", err)
+	}
+	return nil
+}
+
+// DeleteTemplate 删除模板，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) DeleteTemplate(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.NotificationTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListTemplates 列出模板
+func (r *NotificationRepositoryImpl) ListTemplates(ctx context.Context, notificationType *notification.NotificationType, isActive *bool) ([]*notification.NotificationTemplate, error) {
+	query := r.db.WithContext(ctx)
+	if notificationType != nil {
+		query = query.Where("type = ?", string(*notificationType))
+	}
+	if isActive != nil {
+		query = query.Where("is_active = ?", *isActive)
+	}
+
+	var rows []models.NotificationTemplate
+	if err := query.Order("created_time ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notification templates: %w", err)
+	}
+
+	templates := make([]*notification.NotificationTemplate, 0, len(rows))
+	for i := range rows {
+		template, err := templateFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (r *NotificationRepositoryImpl) firstTemplate(ctx context.Context, query string, args ...interface{}) (*notification.NotificationTemplate, error) {
+	var row models.NotificationTemplate
+	if err := r.db.WithContext(ctx).Where(query, args...).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification template: %w", err)
+	}
+	return templateFromModel(&row)
+}
+
+// ==================== 订阅 ====================
+
+// CreateSubscription 创建订阅
+func (r *NotificationRepositoryImpl) CreateSubscription(ctx context.Context, subscription *notification.NotificationSubscription) error {
+	row, err := subscriptionToModel(subscription)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return fmt.Errorf("failed to create notification subscription: %w", err)
+	}
+	return nil
+}
+
+// GetSubscription 获取订阅，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) GetSubscription(ctx context.Context, id string) (*notification.NotificationSubscription, error) {
+	var row models.NotificationSubscription
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification subscription: %w", err)
+	}
+	return subscriptionFromModel(&row)
+}
+
+// GetUserSubscriptions 获取用户订阅，notificationType 为 nil 时不过滤类型
+func (r *NotificationRepositoryImpl) GetUserSubscriptions(ctx context.Context, userID string, notificationType *notification.NotificationType) ([]*notification.NotificationSubscription, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if notificationType != nil {
+		query = query.Where("type = ?", string(*notificationType))
+	}
+	return r.findSubscriptions(query)
+}
+
+// GetSubscriptionsBySource 根据来源获取订阅
+func (r *NotificationRepositoryImpl) GetSubscriptionsBySource(ctx context.Context, sourceID, sourceType string, notificationType *notification.NotificationType) ([]*notification.NotificationSubscription, error) {
+	query := r.db.WithContext(ctx).Where("source_id = ? AND source_type = ?", sourceID, sourceType)
+	if notificationType != nil {
+		query = query.Where("type = ?", string(*notificationType))
+	}
+	return r.findSubscriptions(query)
+}
+
+// UpdateSubscription 更新订阅
+func (r *NotificationRepositoryImpl) UpdateSubscription(ctx context.Context, subscription *notification.NotificationSubscription) error {
+	row, err := subscriptionToModel(subscription)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+		return fmt.Errorf("failed to update notification subscription: %w", err)
+	}
+	return nil
+}
+
+// DeleteSubscription 删除订阅，不存在时返回 errors.ErrNotFound
+func (r *NotificationRepositoryImpl) DeleteSubscription(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.NotificationSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteUserSubscriptions 删除用户订阅，notificationType 为 nil 时删除全部
+func (r *NotificationRepositoryImpl) DeleteUserSubscriptions(ctx context.Context, userID string, notificationType *notification.NotificationType) error {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if notificationType != nil {
+		query = query.Where("type = ?", string(*notificationType))
+	}
+	if err := query.Delete(&models.NotificationSubscription{}).Error; err != nil {
+		return fmt.Errorf("failed to delete user notification subscriptions: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepositoryImpl) findSubscriptions(query *gorm.DB) ([]*notification.NotificationSubscription, error) {
+	var rows []models.NotificationSubscription
+	if err := query.Order("created_time ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	subscriptions := make([]*notification.NotificationSubscription, 0, len(rows))
+	for i := range rows {
+		subscription, err := subscriptionFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// ==================== 偏好 ✨ ====================
+
+// GetPreference 获取用户偏好，未设置时返回 nil
+func (r *NotificationRepositoryImpl) GetPreference(ctx context.Context, userID string) (*notification.Preference, error) {
+	var row models.NotificationPreference
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	var preference notification.Preference
+	if err := json.Unmarshal([]byte(row.Settings), &preference); err != nil {
+		return nil, fmt.Errorf("failed to decode notification preference: %w", err)
+	}
+	preference.UserID = row.UserID
+	preference.UpdatedTime = row.UpdatedTime
+	return &preference, nil
+}
+
+// SavePreference 保存用户偏好
+func (r *NotificationRepositoryImpl) SavePreference(ctx context.Context, preference *notification.Preference) error {
+	settings, err := json.Marshal(preference)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification preference: %w", err)
+	}
+	row := &models.NotificationPreference{UserID: preference.UserID, Settings: string(settings)}
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"settings", "updated_time"}),
+	}).Create(row).Error
+	if err != nil {
+		return fmt.Errorf("failed to save notification preference: %w", err)
+	}
+	return nil
+}
+
+// ==================== 投递记录 ✨ ====================
+
+// deliverySystemActor 投递记录的创建者
+const deliverySystemActor = "system"
+
+// CreateDelivery 创建投递记录
+func (r *NotificationRepositoryImpl) CreateDelivery(ctx context.Context, delivery *notification.Delivery) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(deliveryToModel(delivery)).Error; err != nil {
+		return fmt.Errorf("failed to create notification delivery: %w", err)
+	}
+	return nil
+}
+
+// UpdateDelivery 更新投递记录
+func (r *NotificationRepositoryImpl) UpdateDelivery(ctx context.Context, delivery *notification.Delivery) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(deliveryToModel(delivery)).Error; err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDueDeliveries 到期的顺延、重试和摘要投递
+func (r *NotificationRepositoryImpl) ListDueDeliveries(ctx context.Context, before time.Time, limit int) ([]*notification.Delivery, error) {
+	statuses := []string{
+		string(notification.DeliveryStatusDeferred),
+		string(notification.DeliveryStatusRetrying),
+		string(notification.DeliveryStatusBatched),
+	}
+
+	var rows []models.NotificationDelivery
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND next_retry_time <= ? AND deleted_time IS NULL", statuses, before.UTC()).
+		Order("created_time ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due notification deliveries: %w", err)
+	}
+
+	deliveries := make([]*notification.Delivery, 0, len(rows))
+	for i := range rows {
+		deliveries = append(deliveries, deliveryFromModel(&rows[i]))
+	}
+	return deliveries, nil
+}
+
+// ==================== 映射 ====================
+
+func notificationToModel(item *notification.Notification) (*models.Notification, error) {
+	data, err := json.Marshal(item.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification data: %w", err)
+	}
+	return &models.Notification{
+		ID:          item.ID,
+		UserID:      item.UserID,
+		Type:        string(item.Type),
+		Title:       item.Title,
+		Content:     item.Content,
+		Data:        string(data),
+		Status:      string(item.Status),
+		Priority:    string(item.Priority),
+		SourceID:    item.SourceID,
+		SourceType:  item.SourceType,
+		ActionURL:   item.ActionURL,
+		ExpiresAt:   item.ExpiresAt,
+		ReadAt:      item.ReadAt,
+		CreatedTime: item.CreatedTime,
+		UpdatedTime: item.UpdatedTime,
+	}, nil
+}
+
+func notificationFromModel(row *models.Notification) (*notification.Notification, error) {
+	item := &notification.Notification{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Type:        notification.NotificationType(row.Type),
+		Title:       row.Title,
+		Content:     row.Content,
+		Data:        make(map[string]interface{}),
+		Status:      notification.NotificationStatus(row.Status),
+		Priority:    notification.NotificationPriority(row.Priority),
+		SourceID:    row.SourceID,
+		SourceType:  row.SourceType,
+		ActionURL:   row.ActionURL,
+		ExpiresAt:   row.ExpiresAt,
+		ReadAt:      row.ReadAt,
+		CreatedTime: row.CreatedTime,
+		UpdatedTime: row.UpdatedTime,
+	}
+	if err := decodeJSONColumn(row.Data, &item.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode notification data: %w", err)
+	}
+	return item, nil
+}
+
+func notificationsFromModels(rows []models.Notification) ([]*notification.Notification, error) {
+	items := make([]*notification.Notification, 0, len(rows))
+	for i := range rows {
+		item, err := notificationFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func templateToModel(template *notification.NotificationTemplate) (*models.NotificationTemplate, error) {
+	variables, err := json.Marshal(template.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template variables: %w", err)
+	}
+	defaults, err := json.Marshal(template.DefaultData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template default data: %w", err)
+	}
+	return &models.NotificationTemplate{
+		ID:          template.ID,
+		Type:        string(template.Type),
+		Name:        template.Name,
+		Title:       template.Title,
+		Content:     template.Content,
+		Variables:   string(variables),
+		DefaultData: string(defaults),
+		IsActive:    template.IsActive,
+		CreatedTime: template.CreatedTime,
+		UpdatedTime: template.UpdatedTime,
+	}, nil
+}
+
+func templateFromModel(row *models.NotificationTemplate) (*notification.NotificationTemplate, error) {
+	template := &notification.NotificationTemplate{
+		ID:          row.ID,
+		Type:        notification.NotificationType(row.Type),
+		Name:        row.Name,
+		Title:       row.Title,
+		Content:     row.Content,
+		Variables:   make([]string, 0),
+		DefaultData: make(map[string]interface{}),
+		IsActive:    row.IsActive,
+		CreatedTime: row.CreatedTime,
+		UpdatedTime: row.UpdatedTime,
+	}
+	if err := decodeJSONColumn(row.Variables, &template.Variables); err != nil {
+		return nil, fmt.Errorf("failed to decode template variables: %w", err)
+	}
+	if err := decodeJSONColumn(row.DefaultData, &template.DefaultData); err != nil {
+		return nil, fmt.Errorf("failed to decode template default data: %w", err)
+	}
+	return template, nil
+}
+
+func subscriptionToModel(subscription *notification.NotificationSubscription) (*models.NotificationSubscription, error) {
+	channels, err := json.Marshal(subscription.Channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode subscription channels: %w", err)
+	}
+	settings, err := json.Marshal(subscription.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode subscription settings: %w", err)
+	}
+	return &models.NotificationSubscription{
+		ID:          subscription.ID,
+		UserID:      subscription.UserID,
+		Type:        string(subscription.Type),
+		SourceID:    subscription.SourceID,
+		SourceType:  subscription.SourceType,
+		Channels:    string(channels),
+		Settings:    string(settings),
+		IsActive:    subscription.IsActive,
+		CreatedTime: subscription.CreatedTime,
+		UpdatedTime: subscription.UpdatedTime,
+	}, nil
+}
+
+func subscriptionFromModel(row *models.NotificationSubscription) (*notification.NotificationSubscription, error) {
+	subscription := &notification.NotificationSubscription{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Type:        notification.NotificationType(row.Type),
+		SourceID:    row.SourceID,
+		SourceType:  row.SourceType,
+		Channels:    make([]string, 0),
+		Settings:    make(map[string]interface{}),
+		IsActive:    row.IsActive,
+		CreatedTime: row.CreatedTime,
+		UpdatedTime: row.UpdatedTime,
+	}
+	if err := decodeJSONColumn(row.Channels, &subscription.Channels); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription channels: %w", err)
+	}
+	if err := decodeJSONColumn(row.Settings, &subscription.Settings); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription settings: %w", err)
+	}
+	return subscription, nil
+}
+
+func deliveryToModel(delivery *notification.Delivery) *models.NotificationDelivery {
+	return &models.NotificationDelivery{
+		ID:             delivery.ID,
+		NotificationID: delivery.NotificationID,
+		UserID:         delivery.UserID,
+		Channel:        delivery.Channel,
+		Status:         string(delivery.Status),
+		SentTime:       utcTime(delivery.SentTime),
+		ErrorMessage:   optionalString(delivery.Error),
+		RetryCount:     delivery.RetryCount,
+		MaxRetries:     delivery.MaxRetries,
+		NextRetryTime:  utcTime(delivery.NextAttemptTime),
+		CreatedBy:      deliverySystemActor,
+		CreatedTime:    delivery.CreatedTime.UTC(),
+	}
+}
+
+func deliveryFromModel(row *models.NotificationDelivery) *notification.Delivery {
+	delivery := &notification.Delivery{
+		ID:              row.ID,
+		NotificationID:  row.NotificationID,
+		UserID:          row.UserID,
+		Channel:         row.Channel,
+		Status:          notification.DeliveryStatus(row.Status),
+		RetryCount:      row.RetryCount,
+		MaxRetries:      row.MaxRetries,
+		NextAttemptTime: row.NextRetryTime,
+		SentTime:        row.SentTime,
+		CreatedTime:     row.CreatedTime,
+	}
+	if row.ErrorMessage != nil {
+		delivery.Error = *row.ErrorMessage
+	}
+	return delivery
+}
+
+// utcTime 统一以 UTC 写入不带时区的时间列
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// decodeJSONColumn 解析 JSON 列，空值保持目标不变
+func decodeJSONColumn(raw string, target interface{}) error {
+	if raw == "" || raw == "null" {
+		return nil
+	}
+	return json.Unmarshal([]byte(raw), target)
+}
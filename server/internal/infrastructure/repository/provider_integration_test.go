@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
+	fieldRepo "github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
+	recordEntity "github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	recordValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
+	tableEntity "github.com/easyspace-ai/luckdb/server/internal/domain/table/entity"
+	tableValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/table/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	appLogger "github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+// providerTestEnv 同一套记录/字段场景在不同数据库上的运行环境
+type providerTestEnv struct {
+	db         *gorm.DB
+	provider   database.DBProvider
+	fieldRepo  fieldRepo.FieldRepository
+	recordRepo recordRepo.RecordRepository
+	baseID     string
+	tableID    string
+	fields     map[string]*fieldEntity.Field // 字段名 -> 字段
+}
+
+// postgresTestDSNEnv PostgreSQL 集成测试库的 DSN，未设置时跳过 PostgreSQL
+// 例如：host=localhost user=postgres password=postgres dbname=luckdb_test port=5432 sslmode=disable
+const postgresTestDSNEnv = "LUCKDB_TEST_POSTGRES_DSN"
+
+// runOnProviders 在 SQLite（内存库）和 PostgreSQL（设置了 LUCKDB_TEST_POSTGRES_DSN 时）上运行同一场景
+// 各仓储的场景放在各自的 _test.go 中，共用这里的运行环境
+func runOnProviders(t *testing.T, scenario func(t *testing.T, env *providerTestEnv)) {
+	if appLogger.Logger == nil {
+		appLogger.Logger = zap.NewNop()
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1) // 内存库每个连接独立，固定为单连接
+		scenario(t, newProviderTestEnv(t, db))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		dsn := os.Getenv(postgresTestDSNEnv)
+		if dsn == "" {
+			t.Skipf("未设置 %s，跳过 PostgreSQL", postgresTestDSNEnv)
+		}
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			t.Skipf("无法连接到测试数据库: %v", err)
+		}
+		scenario(t, newProviderTestEnv(t, db))
+	})
+}
+
+// newProviderTestEnv 创建元数据表和一张空的物理表
+func newProviderTestEnv(t *testing.T, db *gorm.DB) *providerTestEnv {
+	ctx := context.Background()
+	require.NoError(t, db.AutoMigrate(&models.Table{}, &models.Field{}))
+
+	provider, err := database.NewProviderFactory().CreateProvider(db)
+	require.NoError(t, err)
+
+	baseID := fmt.Sprintf("bse_it%d", time.Now().UnixNano())
+	name, err := tableValueobject.NewTableName("集成测试")
+	require.NoError(t, err)
+	table, err := tableEntity.NewTable(baseID, name, "usr_test")
+	require.NoError(t, err)
+
+	tableRepo := NewTableRepository(db)
+	require.NoError(t, tableRepo.Save(ctx, table))
+	require.NoError(t, provider.CreateSchema(ctx, baseID))
+	require.NoError(t, provider.CreatePhysicalTable(ctx, baseID, table.ID().String()))
+	t.Cleanup(func() {
+		_ = provider.DropSchema(ctx, baseID)
+	})
+
+	fields := NewFieldRepository(db)
+	return &providerTestEnv{
+		db:         db,
+		provider:   provider,
+		fieldRepo:  fields,
+		recordRepo: NewRecordRepositoryDynamic(db, provider, tableRepo, fields),
+		baseID:     baseID,
+		tableID:    table.ID().String(),
+		fields:     make(map[string]*fieldEntity.Field),
+	}
+}
+
+// addField 创建字段元数据和物理列
+func (env *providerTestEnv) addField(t *testing.T, name, fieldType, dbType string, unique bool) *fieldEntity.Field {
+	fieldName, err := fieldValueobject.NewFieldName(name)
+	require.NoError(t, err)
+	dbFieldName, err := fieldValueobject.NewDBFieldNameFromString("field_" + name)
+	require.NoError(t, err)
+	typ, err := fieldValueobject.NewFieldType(fieldType)
+	require.NoError(t, err)
+
+	field := fieldEntity.ReconstructField(
+		fieldValueobject.NewFieldID(""), env.tableID, fieldName, typ, dbFieldName, dbType,
+		nil, float64(len(env.fields)), 1, "usr_test", time.Now(), time.Now(),
+	)
+	ctx := context.Background()
+	require.NoError(t, env.provider.AddColumn(ctx, env.baseID, env.tableID, database.ColumnDefinition{
+		Name:   dbFieldName.String(),
+		Type:   dbType,
+		Unique: unique,
+	}))
+	require.NoError(t, env.fieldRepo.Save(ctx, field))
+	env.fields[name] = field
+	return field
+}
+
+// createRecord 按字段名写入一条记录
+func (env *providerTestEnv) createRecord(t *testing.T, values map[string]interface{}) *recordEntity.Record {
+	data := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		data[env.fields[name].ID().String()] = value
+	}
+	recordData, err := recordValueobject.NewRecordData(data)
+	require.NoError(t, err)
+	record, err := recordEntity.NewRecord(env.tableID, recordData, "usr_test")
+	require.NoError(t, err)
+	require.NoError(t, env.recordRepo.Save(context.Background(), record))
+	return record
+}
+
+// list 按过滤条件查询记录ID（按自增编号排序）
+func (env *providerTestEnv) list(t *testing.T, items ...valueobject.FilterItem) []string {
+	for i := range items {
+		items[i].FieldID = env.fields[items[i].FieldID].ID().String()
+	}
+	filter := recordRepo.RecordFilter{TableID: &env.tableID, OrderBy: "__auto_number"}
+	if len(items) > 0 {
+		filter.Filters = []*valueobject.Filter{{Operator: valueobject.FilterOperatorAnd, Filters: items}}
+	}
+
+	records, total, err := env.recordRepo.List(context.Background(), filter)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID().String())
+	}
+	assert.Equal(t, int64(len(ids)), total)
+	return ids
+}
+
+func TestProviderIntegration_RecordsAndFilters(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		env.addField(t, "name", "singleLineText", "VARCHAR(255)", false)
+		env.addField(t, "amount", "number", "NUMERIC", false)
+		env.addField(t, "done", "checkbox", "BOOLEAN", false)
+		env.addField(t, "tags", "multipleSelect", "JSONB", false)
+		env.addField(t, "owner", "user", "JSONB", false)
+
+		r1 := env.createRecord(t, map[string]interface{}{
+			"name": "alpha", "amount": 5, "done": true,
+			"tags": []interface{}{"a", "b"}, "owner": map[string]interface{}{"id": "usr_1", "title": "Ann"},
+		})
+		r2 := env.createRecord(t, map[string]interface{}{
+			"name": "beta", "amount": 20, "done": false,
+			"tags": []interface{}{"b"}, "owner": []interface{}{map[string]interface{}{"id": "usr_2"}},
+		})
+		r3 := env.createRecord(t, map[string]interface{}{"name": "gamma", "amount": 30})
+
+		// 读取时值类型与写入一致
+		records, err := env.recordRepo.FindByIDs(ctx, env.tableID, []recordValueobject.RecordID{r1.ID()})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		done, _ := records[0].Data().Get(env.fields["done"].ID().String())
+		assert.Equal(t, true, done)
+		tags, _ := records[0].Data().Get(env.fields["tags"].ID().String())
+		assert.Equal(t, []interface{}{"a", "b"}, tags)
+
+		// JSON 列的包含、完全匹配与对象 id 匹配在两种数据库上结果一致
+		assert.Equal(t, []string{r1.ID().String(), r2.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "tags", Operator: valueobject.FilterItemOpHasAnyOf, Value: []interface{}{"b"}}))
+		assert.Equal(t, []string{r2.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "tags", Operator: valueobject.FilterItemOpIsExactly, Value: []interface{}{"b"}}))
+		assert.Equal(t, []string{r1.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "owner", Operator: valueobject.FilterItemOpIs, Value: "usr_1"}))
+		assert.Equal(t, []string{r2.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "owner", Operator: valueobject.FilterItemOpIs, Value: "usr_2"}))
+		assert.Equal(t, []string{r3.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "tags", Operator: valueobject.FilterItemOpIsEmpty}))
+		assert.Equal(t, []string{r1.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "done", Operator: valueobject.FilterItemOpIs, Value: true}))
+		assert.Equal(t, []string{r2.ID().String(), r3.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "amount", Operator: valueobject.FilterItemOpGreater, Value: 10}))
+
+		// 多列排序：amount 降序，相同时 name 升序
+		r4 := env.createRecord(t, map[string]interface{}{"name": "delta", "amount": 30, "done": true})
+		sorted, _, err := env.recordRepo.List(ctx, recordRepo.RecordFilter{
+			TableID:     &env.tableID,
+			OrderBy:     env.fields["amount"].DBFieldName().String(),
+			OrderDir:    "desc",
+			ThenOrderBy: []recordRepo.RecordOrder{{Column: env.fields["name"].DBFieldName().String(), Dir: "asc"}},
+		})
+		require.NoError(t, err)
+		sortedIDs := make([]string, 0, len(sorted))
+		for _, record := range sorted {
+			sortedIDs = append(sortedIDs, record.ID().String())
+		}
+		assert.Equal(t, []string{r4.ID().String(), r3.ID().String(), r2.ID().String(), r1.ID().String()}, sortedIDs)
+		require.NoError(t, env.recordRepo.DeleteByTableAndID(ctx, env.tableID, r4.ID()))
+
+		// 更新记录（乐观锁）
+		data, err := recordValueobject.NewRecordData(map[string]interface{}{env.fields["name"].ID().String(): "gamma2"})
+		require.NoError(t, err)
+		require.NoError(t, r3.Update(data, "usr_test"))
+		require.NoError(t, env.recordRepo.Save(ctx, r3))
+		assert.Equal(t, []string{r3.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "name", Operator: valueobject.FilterItemOpIs, Value: "gamma2"}))
+	})
+}
+
+func TestProviderIntegration_SchemaChanges(t *testing.T) {
+	runOnProviders(t, func(t *testing.T, env *providerTestEnv) {
+		ctx := context.Background()
+		env.addField(t, "code", "singleLineText", "VARCHAR(255)", true)
+		env.addField(t, "note", "singleLineText", "VARCHAR(255)", false)
+		amount := env.addField(t, "amount", "number", "NUMERIC", false)
+
+		r1 := env.createRecord(t, map[string]interface{}{"code": "c1", "note": "n1", "amount": 1})
+		r2 := env.createRecord(t, map[string]interface{}{"code": "c2", "note": "n2", "amount": 2})
+		r3 := env.createRecord(t, map[string]interface{}{"code": "c3", "note": "n3", "amount": 3})
+		require.NoError(t, env.recordRepo.DeleteByTableAndID(ctx, env.tableID, r3.ID()))
+
+		// 删除字段：数据保留，唯一约束与自增编号不受影响
+		require.NoError(t, env.provider.DropColumn(ctx, env.baseID, env.tableID, amount.DBFieldName().String()))
+		require.NoError(t, env.fieldRepo.Delete(ctx, amount.ID()))
+		delete(env.fields, "amount")
+		require.NoError(t, env.provider.DropColumn(ctx, env.baseID, env.tableID, "field_missing"))
+
+		assert.Equal(t, []string{r1.ID().String(), r2.ID().String()}, env.list(t))
+		assert.Equal(t, []string{r2.ID().String()},
+			env.list(t, valueobject.FilterItem{FieldID: "note", Operator: valueobject.FilterItemOpIs, Value: "n2"}))
+
+		table := env.provider.GenerateTableName(env.baseID, env.tableID)
+		err := env.db.Table(table).Create(map[string]interface{}{
+			"__id": "rec_dup", "__created_by": "usr_test", "field_code": "c1",
+		}).Error
+		assert.Error(t, err, "字段唯一约束应在重建后保留")
+		err = env.db.Table(table).Create(map[string]interface{}{
+			"__id": r1.ID().String(), "__created_by": "usr_test", "field_code": "c9",
+		}).Error
+		assert.Error(t, err, "__id 唯一索引应在重建后保留")
+
+		r4 := env.createRecord(t, map[string]interface{}{"code": "c4", "note": "n4"})
+		var autoNumber int64
+		require.NoError(t, env.db.Table(table).Select("__auto_number").Where("__id = ?", r4.ID().String()).Scan(&autoNumber).Error)
+		assert.Greater(t, autoNumber, int64(3), "已删除记录的自增编号不应被复用")
+
+		// 修改字段类型与约束
+		defaultValue := "''"
+		require.NoError(t, env.provider.AlterColumn(ctx, env.baseID, env.tableID, "field_note", database.ColumnDefinition{
+			Type: "TEXT", NotNull: true, DefaultValue: &defaultValue,
+		}))
+		err = env.db.Table(table).Create(map[string]interface{}{
+			"__id": "rec_null", "__created_by": "usr_test", "field_code": "c5", "field_note": nil,
+		}).Error
+		assert.Error(t, err, "NOT NULL约束应生效")
+		require.NoError(t, env.provider.DropNotNull(ctx, env.baseID, env.tableID, "field_note"))
+		require.NoError(t, env.db.Table(table).Create(map[string]interface{}{
+			"__id": "rec_null", "__created_by": "usr_test", "field_code": "c5", "field_note": nil,
+		}).Error)
+
+		assert.Equal(t, []string{r1.ID().String(), r2.ID().String(), r4.ID().String(), "rec_null"}, env.list(t))
+	})
+}
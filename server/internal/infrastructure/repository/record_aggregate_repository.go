@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
+	tableRepo "github.com/easyspace-ai/luckdb/server/internal/domain/table/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database"
+	pkgDatabase "github.com/easyspace-ai/luckdb/server/pkg/database"
+)
+
+// RecordAggregateRepositoryImpl 记录聚合仓储实现（物理表上的 SQL 聚合）
+type RecordAggregateRepositoryImpl struct {
+	db         *gorm.DB
+	dbProvider database.DBProvider
+	tableRepo  tableRepo.TableRepository
+	fieldRepo  repository.FieldRepository
+}
+
+// NewRecordAggregateRepository 创建记录聚合仓储
+func NewRecordAggregateRepository(
+	db *gorm.DB,
+	dbProvider database.DBProvider,
+	tableRepo tableRepo.TableRepository,
+	fieldRepo repository.FieldRepository,
+) recordRepo.RecordAggregateRepository {
+	return &RecordAggregateRepositoryImpl{
+		db:         db,
+		dbProvider: dbProvider,
+		tableRepo:  tableRepo,
+		fieldRepo:  fieldRepo,
+	}
+}
+
+// AggregateGroups 按分组层级统计记录数和列统计
+// 每一层执行一次 GROUP BY（第0层为总计），SQLite 与 PostgreSQL 使用相同的查询结构
+func (r *RecordAggregateRepositoryImpl) AggregateGroups(ctx context.Context, query recordRepo.GroupAggregateQuery) (*recordRepo.GroupAggregateResult, error) {
+	if query.Filter.TableID == nil {
+		return nil, fmt.Errorf("TableID is required")
+	}
+	tableID := *query.Filter.TableID
+
+	table, err := r.tableRepo.GetByID(ctx, tableID)
+	if err != nil {
+		return nil, fmt.Errorf("获取Table信息失败: %w", err)
+	}
+	if table == nil {
+		return nil, fmt.Errorf("Table不存在: %s", tableID)
+	}
+
+	fields, err := r.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return nil, fmt.Errorf("获取字段列表失败: %w", err)
+	}
+
+	builder := NewFilterSQLBuilder(r.dbProvider.DriverName(), fields)
+	base := applyRecordFilter(
+		pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).Table(r.dbProvider.GenerateTableName(table.BaseID(), tableID)),
+		builder,
+		query.Filter,
+	)
+
+	statisticSelects := make([]string, 0, len(query.Statistics))
+	for i, statistic := range query.Statistics {
+		statisticSelects = append(statisticSelects, fmt.Sprintf("%s AS s%d", builder.Statistic(statistic), i))
+	}
+
+	result := &recordRepo.GroupAggregateResult{Rows: []*recordRepo.GroupAggregateRow{}}
+	for level := 0; level <= len(query.GroupBy); level++ {
+		selects := make([]string, 0, level+1+len(statisticSelects))
+		groups := make([]string, 0, level)
+		orders := make([]string, 0, level)
+		for i, item := range query.GroupBy[:level] {
+			key := builder.GroupKey(item)
+			selects = append(selects, fmt.Sprintf("%s AS g%d", key, i))
+			groups = append(groups, key)
+			orders = append(orders, builder.GroupOrder(item))
+		}
+		selects = append(selects, "COUNT(*) AS __count")
+		selects = append(selects, statisticSelects...)
+
+		levelQuery := base.Session(&gorm.Session{}).Select(strings.Join(selects, ", "))
+		if level > 0 {
+			levelQuery = levelQuery.Group(strings.Join(groups, ", ")).Order(strings.Join(orders, ", "))
+			if query.MaxGroups > 0 {
+				levelQuery = levelQuery.Limit(query.MaxGroups + 1)
+			}
+		}
+
+		var rows []map[string]interface{}
+		if err := levelQuery.Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("分组统计查询失败: %w", err)
+		}
+		if query.MaxGroups > 0 && level > 0 && len(rows) > query.MaxGroups {
+			rows = rows[:query.MaxGroups]
+			result.Truncated = true
+		}
+
+		for _, row := range rows {
+			aggregateRow := &recordRepo.GroupAggregateRow{
+				Keys:       make([]interface{}, level),
+				Count:      toInt64(row["__count"]),
+				Statistics: make(map[string]interface{}, len(query.Statistics)),
+			}
+			for i, item := range query.GroupBy[:level] {
+				aggregateRow.Keys[i] = groupKeyValue(builder.statisticKind(item.FieldID), row[fmt.Sprintf("g%d", i)])
+			}
+			for i, statistic := range query.Statistics {
+				aggregateRow.Statistics[statistic.Key()] = statisticValue(statistic.Func, builder.statisticKind(statistic.FieldID), row[fmt.Sprintf("s%d", i)])
+			}
+			result.Rows = append(result.Rows, aggregateRow)
+		}
+	}
+
+	return result, nil
+}
+
+// groupKeyValue 转换分组键
+// 日期分组统一为 2006-01-02，JSON 分组反序列化为数组
+func groupKeyValue(kind filterColumnKind, value interface{}) interface{} {
+	value = derefDBValue(value)
+	if value == nil {
+		return nil
+	}
+
+	switch kind {
+	case filterKindDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format("2006-01-02")
+		case string:
+			if len(v) >= 10 {
+				return v[:10]
+			}
+			return v
+		}
+	case filterKindJSON:
+		var raw []byte
+		switch v := value.(type) {
+		case []byte:
+			raw = v
+		case string:
+			raw = []byte(v)
+		default:
+			return value
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return string(raw)
+		}
+		return decoded
+	case filterKindNumber:
+		if f, ok := toFloat64(value); ok {
+			return f
+		}
+	}
+
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
+
+// statisticValue 转换列统计结果
+// 计数类统计为整数，sum/avg/percentFilled 与数字列的 min/max 为浮点数
+func statisticValue(fn valueobject.StatisticFunc, kind filterColumnKind, value interface{}) interface{} {
+	value = derefDBValue(value)
+	if value == nil {
+		return nil
+	}
+
+	switch fn {
+	case valueobject.StatisticEmpty, valueobject.StatisticFilled, valueobject.StatisticUnique, valueobject.StatisticChecked:
+		return toInt64(value)
+	case valueobject.StatisticSum, valueobject.StatisticAvg, valueobject.StatisticPercentFilled:
+		if f, ok := toFloat64(value); ok {
+			return f
+		}
+	case valueobject.StatisticMin, valueobject.StatisticMax:
+		if kind == filterKindNumber {
+			if f, ok := toFloat64(value); ok {
+				return f
+			}
+		}
+	}
+
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
+
+// toFloat64 数据库数值（可能以字符串返回的 NUMERIC）转为浮点数
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := derefDBValue(value).(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case []byte:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toInt64 数据库计数转为整数
+func toInt64(value interface{}) int64 {
+	f, _ := toFloat64(value)
+	return int64(f)
+}
+
+// derefDBValue 解引用扫描结果
+// SQLite 中表达式列没有声明类型，GORM 扫描到 map 时保留为 *interface{}
+func derefDBValue(value interface{}) interface{} {
+	if p, ok := value.(*interface{}); ok {
+		if p == nil {
+			return nil
+		}
+		return *p
+	}
+	return value
+}
@@ -127,7 +127,7 @@ func (r *RecordRepositoryImpl) Delete(ctx context.Context, id valueobject.Record
 	return r.db.WithContext(ctx).
 		Model(&models.Record{}).
 		Where("id = ?", id.String()).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // DeleteByTableAndID 根据表ID和记录ID删除记录（软删除）
@@ -137,7 +137,7 @@ func (r *RecordRepositoryImpl) DeleteByTableAndID(ctx context.Context, tableID s
 		Model(&models.Record{}).
 		Where("id = ?", id.String()).
 		Where("table_id = ?", tableID).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // Exists 检查记录是否存在
@@ -250,7 +250,7 @@ func (r *RecordRepositoryImpl) BatchDelete(ctx context.Context, ids []valueobjec
 	return r.db.WithContext(ctx).
 		Model(&models.Record{}).
 		Where("id IN ?", idStrs).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // CountByTableID 统计表的记录数
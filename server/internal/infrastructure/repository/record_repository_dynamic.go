@@ -8,9 +8,11 @@ import (
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	fieldEntity "github.com/easyspace-ai/luckdb/server/internal/domain/fields/entity"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/fields/repository"
+	fieldValueobject "github.com/easyspace-ai/luckdb/server/internal/domain/fields/valueobject"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/entity"
 	recordRepo "github.com/easyspace-ai/luckdb/server/internal/domain/record/repository"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/record/valueobject"
@@ -28,7 +30,8 @@ type RecordRepositoryDynamic struct {
 	dbProvider database.DBProvider
 	tableRepo  tableRepo.TableRepository
 	fieldRepo  repository.FieldRepository
-	fieldCache *FieldMappingCache // ✅ 字段映射缓存
+	fieldCache *FieldMappingCache        // ✅ 字段映射缓存
+	linkRepo   recordRepo.LinkRepository // ✨ Link 关联存储（外键列/中间表）
 }
 
 // GetDB 获取数据库连接（用于事务管理）
@@ -49,6 +52,7 @@ func NewRecordRepositoryDynamic(
 		tableRepo:  tableRepo,
 		fieldRepo:  fieldRepo,
 		fieldCache: NewFieldMappingCache(),
+		linkRepo:   NewLinkRepository(db, dbProvider, tableRepo, fieldRepo),
 	}
 }
 
@@ -168,7 +172,7 @@ func (r *RecordRepositoryDynamic) FindByIDs(ctx context.Context, tableID string,
 
 	// 查询指定 ID 的记录
 	var results []map[string]interface{}
-	err = r.db.WithContext(ctx).
+	err = pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
 		Table(fullTableName).
 		Select(selectCols).
 		Where("__id IN ?", recordIDStrs).
@@ -323,7 +327,7 @@ func (r *RecordRepositoryDynamic) Save(ctx context.Context, record *entity.Recor
 
 	// 5. ✅ 检查记录是否已存在（用于判断INSERT还是UPDATE）
 	var count int64
-	err = r.db.WithContext(ctx).
+	err = pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
 		Table(fullTableName).
 		Where("__id = ?", record.ID().String()).
 		Count(&count).Error
@@ -380,7 +384,7 @@ func (r *RecordRepositoryDynamic) Save(ctx context.Context, record *entity.Recor
 
 	if isNewRecord {
 		// ✅ 新记录：直接 INSERT
-		result = r.db.WithContext(ctx).
+		result = pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
 			Table(fullTableName).
 			Create(data)
 	} else {
@@ -390,7 +394,7 @@ func (r *RecordRepositoryDynamic) Save(ctx context.Context, record *entity.Recor
 		currentVersion := record.Version().Value() // 新版本（已递增）
 		checkVersion := currentVersion - 1         // 检查版本（旧版本）
 
-		result = r.db.WithContext(ctx).
+		result = pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
 			Table(fullTableName).
 			Where("__id = ?", record.ID().String()).
 			Where("__version = ?", checkVersion). // WHERE __version = 旧版本
@@ -418,6 +422,18 @@ func (r *RecordRepositoryDynamic) Save(ctx context.Context, record *entity.Recor
 		})
 	}
 
+	// ✨ 同步 Link 关联到外键列/中间表，并刷新对称字段缓存
+	links, err := r.newLinkSync(ctx, fields)
+	if err != nil {
+		return err
+	}
+	if err := r.syncRecordLinks(ctx, links, fields, record); err != nil {
+		return err
+	}
+	if err := r.refreshLinkCaches(ctx, links); err != nil {
+		return err
+	}
+
 	// ✅ 记录保存到物理表完成（对齐 Teable：不使用 record_meta）
 
 	logger.Info("✅ 记录保存成功（物理表+乐观锁）",
@@ -458,8 +474,13 @@ func (r *RecordRepositoryDynamic) DeleteByTableAndID(ctx context.Context, tableI
 	baseID := table.BaseID()
 	fullTableName := r.dbProvider.GenerateTableName(baseID, tableID)
 
-	// 2. 从物理表删除记录
-	err = r.db.WithContext(ctx).
+	// 2. ✨ 清理该记录的关联，以及其他表指向该记录的关联（反向外键在记录行上，需在删除前处理）
+	if err := r.deleteRecordLinks(ctx, tableID, []string{id.String()}); err != nil {
+		return err
+	}
+
+	// 3. 从物理表删除记录
+	err = pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
 		Table(fullTableName).
 		Where("__id = ?", id.String()).
 		Delete(nil).Error
@@ -479,6 +500,135 @@ func (r *RecordRepositoryDynamic) DeleteByTableAndID(ctx context.Context, tableI
 	return nil
 }
 
+// linkSync 一次写入中 Link 关联的同步状态
+// 对称字段位于关联表（可能在其他 Base），其 JSON 缓存在关联变更后统一刷新
+type linkSync struct {
+	symmetric map[string]*fieldEntity.Field // Link 字段ID -> 对称字段
+	fields    map[string]*fieldEntity.Field // 字段ID -> 字段（含对称字段）
+	refresh   map[string][]string           // 需要刷新缓存的字段ID -> 记录ID
+}
+
+// newLinkSync 加载表上 Link 字段的对称字段（需在批量写入事务开始前调用）
+func (r *RecordRepositoryDynamic) newLinkSync(ctx context.Context, fields []*fieldEntity.Field) (*linkSync, error) {
+	sync := &linkSync{
+		symmetric: make(map[string]*fieldEntity.Field),
+		fields:    make(map[string]*fieldEntity.Field),
+		refresh:   make(map[string][]string),
+	}
+	for _, field := range fields {
+		sync.fields[field.ID().String()] = field
+		options, ok := linkStorageOf(field)
+		if !ok || options.SymmetricFieldID == "" {
+			continue
+		}
+		symmetric, err := r.fieldRepo.FindByID(ctx, fieldValueobject.NewFieldID(options.SymmetricFieldID))
+		if err != nil {
+			return nil, fmt.Errorf("获取对称字段失败: %w", err)
+		}
+		if symmetric != nil {
+			sync.symmetric[field.ID().String()] = symmetric
+			sync.fields[symmetric.ID().String()] = symmetric
+		}
+	}
+	return sync, nil
+}
+
+// markRefresh 记录需要刷新缓存的记录
+func (s *linkSync) markRefresh(field *fieldEntity.Field, recordIDs []string) {
+	fieldID := field.ID().String()
+	s.refresh[fieldID] = append(s.refresh[fieldID], recordIDs...)
+}
+
+// syncRecordLinks 将记录的 Link 值写入外键列/中间表（记录列中的 JSON 值作为单元格缓存）
+func (r *RecordRepositoryDynamic) syncRecordLinks(ctx context.Context, sync *linkSync, fields []*fieldEntity.Field, record *entity.Record) error {
+	recordID := record.ID().String()
+	for _, field := range fields {
+		options, ok := linkStorageOf(field)
+		if !ok {
+			continue
+		}
+		value, _ := record.Data().Get(field.ID().String())
+		foreignIDs := fieldValueobject.ExtractLinkRecordIDs(value)
+
+		oldIDs, err := r.linkRepo.FindLinkedRecordIDs(ctx, field, recordID)
+		if err != nil {
+			return err
+		}
+		if stringSlicesEqual(oldIDs, foreignIDs) {
+			continue
+		}
+
+		symmetric := sync.symmetric[field.ID().String()]
+		if symmetric != nil && options.StorageKind() == fieldValueobject.LinkStorageReverseForeignKey {
+			// 关联记录只能属于一条记录：被抢走关联的原记录需要刷新缓存
+			for _, foreignID := range foreignIDs {
+				owners, err := r.linkRepo.FindLinkedRecordIDs(ctx, symmetric, foreignID)
+				if err != nil {
+					return err
+				}
+				for _, owner := range owners {
+					if owner != recordID {
+						sync.markRefresh(field, []string{owner})
+					}
+				}
+			}
+		}
+
+		if err := r.linkRepo.ReplaceLinks(ctx, field, recordID, foreignIDs); err != nil {
+			return fmt.Errorf("同步Link字段 %s 失败: %w", field.ID().String(), err)
+		}
+		if symmetric != nil {
+			sync.markRefresh(symmetric, oldIDs)
+			sync.markRefresh(symmetric, foreignIDs)
+		}
+	}
+	return nil
+}
+
+// refreshLinkCaches 刷新对称字段的 JSON 缓存
+func (r *RecordRepositoryDynamic) refreshLinkCaches(ctx context.Context, sync *linkSync) error {
+	for fieldID, recordIDs := range sync.refresh {
+		if err := r.linkRepo.RefreshLinkCache(ctx, sync.fields[fieldID], uniqueStrings(recordIDs)); err != nil {
+			return fmt.Errorf("刷新Link字段 %s 缓存失败: %w", fieldID, err)
+		}
+	}
+	return nil
+}
+
+// sqlOrderDir 排序方向，只有 desc 为降序
+func sqlOrderDir(dir string) string {
+	if dir == "desc" {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// deleteRecordLinks 删除记录前清理关联：指向这些记录的关联，以及本表 Link 字段的关联
+// 指向这些记录的 Link 字段（含对称字段，可能在其他 Base）的 JSON 缓存由 DeleteForeignLinks 刷新；
+// 对称字段与本表字段共享存储，需先处理指向这些记录的一侧，否则找不到受影响的记录
+func (r *RecordRepositoryDynamic) deleteRecordLinks(ctx context.Context, tableID string, recordIDs []string) error {
+	referencingFields, err := r.linkRepo.FindLinkFieldsPointingTo(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	for _, field := range referencingFields {
+		if err := r.linkRepo.DeleteForeignLinks(ctx, field, recordIDs); err != nil {
+			return err
+		}
+	}
+
+	fields, err := r.fieldRepo.FindByTableID(ctx, tableID)
+	if err != nil {
+		return fmt.Errorf("获取字段列表失败: %w", err)
+	}
+	for _, field := range fields {
+		if err := r.linkRepo.DeleteRecordLinks(ctx, field, recordIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // BatchSave 批量保存记录（包括创建和更新）
 func (r *RecordRepositoryDynamic) BatchSave(ctx context.Context, records []*entity.Record) error {
 	// 简单实现：使用 BatchUpdate
@@ -538,13 +688,7 @@ func (r *RecordRepositoryDynamic) List(ctx context.Context, filter recordRepo.Re
 	}
 	tableID := *filter.TableID
 
-	// 2. 统计总数
-	total, err := r.CountByTableID(ctx, tableID)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	// 3. 获取 Table 信息
+	// 2. 获取 Table 信息
 	table, err := r.tableRepo.GetByID(ctx, tableID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("获取Table信息失败: %w", err)
@@ -555,13 +699,13 @@ func (r *RecordRepositoryDynamic) List(ctx context.Context, filter recordRepo.Re
 
 	baseID := table.BaseID()
 
-	// 4. 获取字段列表
+	// 3. 获取字段列表
 	fields, err := r.fieldRepo.FindByTableID(ctx, tableID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("获取字段列表失败: %w", err)
 	}
 
-	// 5. ✅ 从物理表查询（带分页和过滤）
+	// 4. ✅ 从物理表查询（带分页和过滤）
 	// 使用完整表名（包含schema）："baseID"."tableID"
 	fullTableName := r.dbProvider.GenerateTableName(baseID, tableID)
 
@@ -582,29 +726,42 @@ func (r *RecordRepositoryDynamic) List(ctx context.Context, filter recordRepo.Re
 	}
 
 	// 构建查询
-	query := r.db.WithContext(ctx).
-		Table(fullTableName).
-		Select(selectCols)
+	query := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).Table(fullTableName)
 
-	// 应用过滤条件
-	if filter.CreatedBy != nil {
-		query = query.Where("__created_by = ?", *filter.CreatedBy)
-	}
-	if filter.UpdatedBy != nil {
-		query = query.Where("__last_modified_by = ?", *filter.UpdatedBy)
+	// 应用过滤条件（✅ 包括视图过滤条件与行级权限范围）
+	query = applyRecordFilter(query, NewFilterSQLBuilder(r.dbProvider.DriverName(), fields), filter)
+
+	// 5. 统计总数（与列表使用相同的过滤条件）
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计记录数量失败: %w", err)
 	}
 
+	query = query.Select(selectCols)
+
 	// 应用排序
+	if filter.ManualOrderViewID != "" {
+		// ✨ 视图手动顺序（看板拖拽），未设置顺序的记录排在后面，再按后续排序规则
+		query = query.Order(clause.Expr{
+			SQL:  "(SELECT o.sort_order FROM view_record_order o WHERE o.view_id = ? AND o.record_id = __id) ASC NULLS LAST",
+			Vars: []interface{}{filter.ManualOrderViewID},
+		})
+	}
+	orderedByAutoNumber := filter.OrderBy == "__auto_number"
 	if filter.OrderBy != "" {
-		orderDir := "ASC"
-		if filter.OrderDir == "desc" {
-			orderDir = "DESC"
-		}
-		query = query.Order(fmt.Sprintf("%s %s", filter.OrderBy, orderDir))
+		query = query.Order(fmt.Sprintf("%s %s", filter.OrderBy, sqlOrderDir(filter.OrderDir)))
 	} else {
 		// 默认按创建时间倒序
 		query = query.Order("__created_time DESC")
 	}
+	for _, order := range filter.ThenOrderBy {
+		query = query.Order(fmt.Sprintf("%s %s", order.Column, sqlOrderDir(order.Dir)))
+		orderedByAutoNumber = orderedByAutoNumber || order.Column == "__auto_number"
+	}
+	// 排序值相同时按自增编号，保证分页稳定
+	if !orderedByAutoNumber {
+		query = query.Order("__auto_number ASC")
+	}
 
 	// 应用分页
 	if filter.Limit > 0 {
@@ -644,6 +801,29 @@ func (r *RecordRepositoryDynamic) List(ctx context.Context, filter recordRepo.Re
 	return records, total, nil
 }
 
+// applyRecordFilter 应用记录过滤器中的过滤条件（不含排序和分页）
+func applyRecordFilter(query *gorm.DB, builder *FilterSQLBuilder, filter recordRepo.RecordFilter) *gorm.DB {
+	if filter.CreatedBy != nil {
+		query = query.Where("__created_by = ?", *filter.CreatedBy)
+	}
+	if filter.UpdatedBy != nil {
+		query = query.Where("__last_modified_by = ?", *filter.UpdatedBy)
+	}
+
+	for _, f := range filter.Filters {
+		if f.IsEmpty() {
+			continue
+		}
+		filterSQL, filterArgs := builder.Build(f)
+		query = query.Where(filterSQL, filterArgs...)
+	}
+	if len(filter.AccessScopes) > 0 {
+		scopeSQL, scopeArgs := builder.BuildScopes(filter.AccessScopes)
+		query = query.Where(scopeSQL, scopeArgs...)
+	}
+	return query
+}
+
 // NextID 生成下一个记录ID
 func (r *RecordRepositoryDynamic) NextID() valueobject.RecordID {
 	return valueobject.NewRecordID("")
@@ -823,7 +1003,7 @@ func (r *RecordRepositoryDynamic) convertValueFromDB(field *fieldEntity.Field, v
 	fieldType := field.Type().String()
 
 	switch fieldType {
-	case "multipleSelect", "user", "attachment", "link", "lookup":
+	case "multipleSelect", "user", "attachment", "link", "lookup", "button":
 		// ✅ JSONB 类型：需要从JSON反序列化
 		// GORM 可能返回 []byte 或 string 类型的 JSON 数据
 		var result interface{}
@@ -922,8 +1102,13 @@ func (r *RecordRepositoryDynamic) BatchCreate(ctx context.Context, records []*en
 		return fmt.Errorf("获取字段列表失败: %w", err)
 	}
 
+	links, err := r.newLinkSync(ctx, fields)
+	if err != nil {
+		return err
+	}
+
 	// 3. ✅ 开启事务（原子性保证）
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 3.1 使用完整表名（包含schema）："baseID"."tableID"
 		fullTableName := r.dbProvider.GenerateTableName(baseID, tableID)
 
@@ -955,8 +1140,22 @@ func (r *RecordRepositoryDynamic) BatchCreate(ctx context.Context, records []*en
 			return fmt.Errorf("批量插入物理表失败: %w", err)
 		}
 
+		// 3.4 ✨ 在同一事务中写入 Link 关联
+		txCtx := pkgDatabase.SetTxContext(ctx, &pkgDatabase.TxContext{Tx: tx})
+		for _, record := range records {
+			if err := r.syncRecordLinks(txCtx, links, fields, record); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// 4. ✨ 事务提交后刷新对称字段缓存
+	return r.refreshLinkCaches(ctx, links)
 }
 
 // BatchUpdate 批量更新记录（原子事务）
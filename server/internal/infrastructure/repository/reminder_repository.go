@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/reminder"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+)
+
+// ReminderRuleRepositoryImpl 记录提醒规则仓储GORM实现
+type ReminderRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReminderRuleRepository 创建记录提醒规则仓储
+func NewReminderRuleRepository(db *gorm.DB) reminder.RuleRepository {
+	return &ReminderRuleRepositoryImpl{db: db}
+}
+
+// Save 保存规则（新增或更新）
+func (r *ReminderRuleRepositoryImpl) Save(ctx context.Context, rule *reminder.Rule) error {
+	model, err := r.toRuleModel(rule)
+	if err != nil {
+		return fmt.Errorf("failed to convert reminder rule to model: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save reminder rule: %w", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找规则
+func (r *ReminderRuleRepositoryImpl) FindByID(ctx context.Context, id string) (*reminder.Rule, error) {
+	var model models.RecordReminderRule
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_time IS NULL", id).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find reminder rule: %w", err)
+	}
+	return r.toRuleEntity(&model)
+}
+
+// FindByTableID 查找表的所有规则
+func (r *ReminderRuleRepositoryImpl) FindByTableID(ctx context.Context, tableID string) ([]*reminder.Rule, error) {
+	var ruleModels []models.RecordReminderRule
+	err := r.db.WithContext(ctx).
+		Where("table_id = ? AND deleted_time IS NULL", tableID).
+		Order("created_time").
+		Find(&ruleModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reminder rules by table ID: %w", err)
+	}
+
+	rules := make([]*reminder.Rule, 0, len(ruleModels))
+	for i := range ruleModels {
+		rule, err := r.toRuleEntity(&ruleModels[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Delete 删除规则（软删除）
+func (r *ReminderRuleRepositoryImpl) Delete(ctx context.Context, id string) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&models.RecordReminderRule{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted_time":       now,
+			"last_modified_time": now,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder rule: %w", err)
+	}
+	return nil
+}
+
+// toRuleModel 领域实体转换为数据库模型
+func (r *ReminderRuleRepositoryImpl) toRuleModel(rule *reminder.Rule) (*models.RecordReminderRule, error) {
+	var filter []byte
+	if !rule.Filter.IsEmpty() {
+		data, err := json.Marshal(rule.Filter)
+		if err != nil {
+			return nil, err
+		}
+		filter = data
+	}
+	recipients, err := json.Marshal(rule.RecipientUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := rule.UpdatedAt
+	return &models.RecordReminderRule{
+		ID:               rule.ID,
+		TableID:          rule.TableID,
+		Name:             rule.Name,
+		DateFieldID:      rule.DateFieldID,
+		Offset:           rule.Offset,
+		Unit:             string(rule.Unit),
+		Direction:        string(rule.Direction),
+		Timezone:         rule.Timezone,
+		Filter:           filter,
+		RecipientFieldID: rule.RecipientFieldID,
+		RecipientUserIDs: recipients,
+		Message:          rule.Message,
+		Enabled:          rule.Enabled,
+		CreatedBy:        rule.CreatedBy,
+		CreatedTime:      rule.CreatedAt,
+		LastModifiedTime: &updatedAt,
+	}, nil
+}
+
+// toRuleEntity 数据库模型转换为领域实体
+func (r *ReminderRuleRepositoryImpl) toRuleEntity(model *models.RecordReminderRule) (*reminder.Rule, error) {
+	rule := &reminder.Rule{
+		ID:               model.ID,
+		TableID:          model.TableID,
+		Name:             model.Name,
+		DateFieldID:      model.DateFieldID,
+		Offset:           model.Offset,
+		Unit:             reminder.OffsetUnit(model.Unit),
+		Direction:        reminder.Direction(model.Direction),
+		Timezone:         model.Timezone,
+		RecipientFieldID: model.RecipientFieldID,
+		Message:          model.Message,
+		Enabled:          model.Enabled,
+		CreatedBy:        model.CreatedBy,
+		CreatedAt:        model.CreatedTime,
+		UpdatedAt:        model.CreatedTime,
+	}
+	if model.LastModifiedTime != nil {
+		rule.UpdatedAt = *model.LastModifiedTime
+	}
+
+	if len(model.RecipientUserIDs) > 0 {
+		if err := json.Unmarshal(model.RecipientUserIDs, &rule.RecipientUserIDs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reminder recipients: %w", err)
+		}
+	}
+	if len(model.Filter) > 0 && string(model.Filter) != "null" {
+		var filter valueobject.Filter
+		if err := json.Unmarshal(model.Filter, &filter); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reminder filter: %w", err)
+		}
+		rule.Filter = &filter
+	}
+	return rule, nil
+}
+
+// ReminderRepositoryImpl 记录提醒计划仓储GORM实现
+type ReminderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository 创建记录提醒计划仓储
+func NewReminderRepository(db *gorm.DB) reminder.Repository {
+	return &ReminderRepositoryImpl{db: db}
+}
+
+// Save 保存提醒计划（新增或更新）
+func (r *ReminderRepositoryImpl) Save(ctx context.Context, item *reminder.Reminder) error {
+	model := &models.RecordReminder{
+		ID:               item.ID,
+		RuleID:           item.RuleID,
+		TableID:          item.TableID,
+		RecordID:         item.RecordID,
+		FireAt:           item.FireAt,
+		Status:           string(item.Status),
+		SentAt:           item.SentAt,
+		LastError:        item.LastError,
+		CreatedTime:      item.CreatedAt,
+		LastModifiedTime: item.UpdatedAt,
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save reminder: %w", err)
+	}
+	return nil
+}
+
+// FindByRuleAndRecord 查找记录在规则下的提醒计划
+func (r *ReminderRepositoryImpl) FindByRuleAndRecord(ctx context.Context, ruleID, recordID string) (*reminder.Reminder, error) {
+	var model models.RecordReminder
+	err := r.db.WithContext(ctx).
+		Where("rule_id = ? AND record_id = ?", ruleID, recordID).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find reminder: %w", err)
+	}
+	return toReminderEntity(&model), nil
+}
+
+// FindByRecord 查找记录的所有提醒计划
+func (r *ReminderRepositoryImpl) FindByRecord(ctx context.Context, tableID, recordID string) ([]*reminder.Reminder, error) {
+	var reminderModels []models.RecordReminder
+	err := r.db.WithContext(ctx).
+		Where("table_id = ? AND record_id = ?", tableID, recordID).
+		Find(&reminderModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reminders by record: %w", err)
+	}
+	return toReminderEntities(reminderModels), nil
+}
+
+// ListDue 查找到期的待发送提醒
+func (r *ReminderRepositoryImpl) ListDue(ctx context.Context, now time.Time, limit int) ([]*reminder.Reminder, error) {
+	var reminderModels []models.RecordReminder
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND fire_at <= ?", reminder.StatusPending, now).
+		Order("fire_at").
+		Limit(limit).
+		Find(&reminderModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due reminders: %w", err)
+	}
+	return toReminderEntities(reminderModels), nil
+}
+
+// Claim 条件更新领取提醒，保证多实例下只发送一次
+func (r *ReminderRepositoryImpl) Claim(ctx context.Context, id string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.RecordReminder{}).
+		Where("id = ? AND status = ?", id, reminder.StatusPending).
+		Updates(map[string]interface{}{
+			"status":             reminder.StatusSending,
+			"last_modified_time": time.Now(),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim reminder: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseStale 恢复停留在发送中的提醒
+func (r *ReminderRepositoryImpl) ReleaseStale(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.RecordReminder{}).
+		Where("status = ? AND last_modified_time < ?", reminder.StatusSending, before).
+		Updates(map[string]interface{}{
+			"status":             reminder.StatusPending,
+			"last_modified_time": time.Now(),
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to release stale reminders: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteByRule 删除规则的所有提醒计划
+func (r *ReminderRepositoryImpl) DeleteByRule(ctx context.Context, ruleID string) error {
+	if err := r.db.WithContext(ctx).Where("rule_id = ?", ruleID).Delete(&models.RecordReminder{}).Error; err != nil {
+		return fmt.Errorf("failed to delete reminders by rule: %w", err)
+	}
+	return nil
+}
+
+func toReminderEntities(reminderModels []models.RecordReminder) []*reminder.Reminder {
+	items := make([]*reminder.Reminder, 0, len(reminderModels))
+	for i := range reminderModels {
+		items = append(items, toReminderEntity(&reminderModels[i]))
+	}
+	return items
+}
+
+func toReminderEntity(model *models.RecordReminder) *reminder.Reminder {
+	return &reminder.Reminder{
+		ID:        model.ID,
+		RuleID:    model.RuleID,
+		TableID:   model.TableID,
+		RecordID:  model.RecordID,
+		FireAt:    model.FireAt,
+		Status:    reminder.Status(model.Status),
+		SentAt:    model.SentAt,
+		LastError: model.LastError,
+		CreatedAt: model.CreatedTime,
+		UpdatedAt: model.LastModifiedTime,
+	}
+}
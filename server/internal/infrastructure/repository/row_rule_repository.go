@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	"github.com/easyspace-ai/luckdb/server/internal/domain/view/valueobject"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+)
+
+// RowRuleRepositoryImpl 行级访问规则仓储GORM实现
+type RowRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRowRuleRepository 创建行级访问规则仓储
+func NewRowRuleRepository(db *gorm.DB) permission.RowRuleRepository {
+	return &RowRuleRepositoryImpl{db: db}
+}
+
+// Save 保存规则（新增或更新）
+func (r *RowRuleRepositoryImpl) Save(ctx context.Context, rule *permission.RowRule) error {
+	model, err := r.toModel(rule)
+	if err != nil {
+		return fmt.Errorf("failed to convert row rule to model: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save row rule: %w", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找规则
+func (r *RowRuleRepositoryImpl) FindByID(ctx context.Context, id string) (*permission.RowRule, error) {
+	var model models.TableRowRule
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_time IS NULL", id).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find row rule: %w", err)
+	}
+
+	return r.toEntity(&model)
+}
+
+// FindByTableID 查找表的所有规则
+func (r *RowRuleRepositoryImpl) FindByTableID(ctx context.Context, tableID string) ([]*permission.RowRule, error) {
+	var ruleModels []models.TableRowRule
+	err := r.db.WithContext(ctx).
+		Where("table_id = ? AND deleted_time IS NULL", tableID).
+		Order("created_time").
+		Find(&ruleModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find row rules by table ID: %w", err)
+	}
+
+	rules := make([]*permission.RowRule, 0, len(ruleModels))
+	for i := range ruleModels {
+		rule, err := r.toEntity(&ruleModels[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Delete 删除规则（软删除）
+func (r *RowRuleRepositoryImpl) Delete(ctx context.Context, id string) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&models.TableRowRule{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted_time":       now,
+			"last_modified_time": now,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete row rule: %w", err)
+	}
+	return nil
+}
+
+// toModel 领域实体转换为数据库模型
+func (r *RowRuleRepositoryImpl) toModel(rule *permission.RowRule) (*models.TableRowRule, error) {
+	roles, err := json.Marshal(rule.Roles)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := json.Marshal(rule.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := rule.UpdatedAt
+	return &models.TableRowRule{
+		ID:               rule.ID,
+		TableID:          rule.TableID,
+		Name:             rule.Name,
+		Roles:            roles,
+		Actions:          actions,
+		Filter:           filter,
+		Enabled:          rule.Enabled,
+		CreatedBy:        rule.CreatedBy,
+		CreatedTime:      rule.CreatedAt,
+		LastModifiedTime: &updatedAt,
+	}, nil
+}
+
+// toEntity 数据库模型转换为领域实体
+func (r *RowRuleRepositoryImpl) toEntity(model *models.TableRowRule) (*permission.RowRule, error) {
+	rule := &permission.RowRule{
+		ID:        model.ID,
+		TableID:   model.TableID,
+		Name:      model.Name,
+		Enabled:   model.Enabled,
+		CreatedBy: model.CreatedBy,
+		CreatedAt: model.CreatedTime,
+		UpdatedAt: model.CreatedTime,
+	}
+	if model.LastModifiedTime != nil {
+		rule.UpdatedAt = *model.LastModifiedTime
+	}
+
+	if len(model.Roles) > 0 {
+		if err := json.Unmarshal(model.Roles, &rule.Roles); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal row rule roles: %w", err)
+		}
+	}
+	if err := json.Unmarshal(model.Actions, &rule.Actions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal row rule actions: %w", err)
+	}
+
+	var filter valueobject.Filter
+	if err := json.Unmarshal(model.Filter, &filter); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal row rule filter: %w", err)
+	}
+	rule.Filter = &filter
+
+	return rule, nil
+}
+
+// OrganizationGroupResolver 基于组织架构解析用户所属部门
+type OrganizationGroupResolver struct {
+	db *gorm.DB
+}
+
+// NewOrganizationGroupResolver 创建部门解析器
+func NewOrganizationGroupResolver(db *gorm.DB) permission.GroupResolver {
+	return &OrganizationGroupResolver{db: db}
+}
+
+// ResolveUserGroups 查询用户当前所在的部门ID列表
+func (r *OrganizationGroupResolver) ResolveUserGroups(ctx context.Context, userID string) ([]string, error) {
+	var departmentIDs []string
+	err := r.db.WithContext(ctx).
+		Table(models.OrganizationUserDepartment{}.TableName()+" AS oud").
+		Joins("JOIN "+models.OrganizationUser{}.TableName()+" AS ou ON ou.id = oud.organization_user_id").
+		Where("ou.user_id = ? AND ou.deleted_time IS NULL", userID).
+		Where("oud.deleted_time IS NULL AND oud.left_time IS NULL").
+		Distinct().
+		Pluck("oud.department_id", &departmentIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user groups: %w", err)
+	}
+	return departmentIDs, nil
+}
@@ -104,7 +104,7 @@ func (r *SpaceRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).
 		Model(&models.Space{}).
 		Where("id = ?", id).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // Update 更新空间
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	"github.com/easyspace-ai/luckdb/server/pkg/utils"
+)
+
+// 订阅审计日志的动作与资源类型
+const (
+	auditActionSubscribe          = "subscribe"
+	auditActionRevokeSubscribe    = "revoke_subscription"
+	auditResourceWebSocketChannel = "websocket_channel"
+)
+
+// SubscriptionAuditRepositoryImpl 将被拒绝的实时订阅写入审计日志
+type SubscriptionAuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSubscriptionAuditRepository 创建订阅审计仓储
+func NewSubscriptionAuditRepository(db *gorm.DB) websocket.SubscriptionAuditor {
+	return &SubscriptionAuditRepositoryImpl{db: db}
+}
+
+// RecordRejection 记录一次被拒绝或被撤销的订阅
+// 频道中的表 ID 可能是猜测的，只写入 metadata，不写外键列
+func (r *SubscriptionAuditRepositoryImpl) RecordRejection(ctx context.Context, rejection *websocket.SubscriptionRejection) error {
+	metadata, err := json.Marshal(map[string]interface{}{
+		"connection_id": rejection.Subscriber.ConnectionID,
+		"share_id":      rejection.Subscriber.ShareID,
+		"scope":         rejection.Scope,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription audit metadata: %w", err)
+	}
+
+	action := auditActionSubscribe
+	if rejection.Revoked {
+		action = auditActionRevokeSubscribe
+	}
+	log := &models.AuditLog{
+		ID:           utils.GenerateIDWithPrefix("aud"),
+		Action:       action,
+		ResourceType: auditResourceWebSocketChannel,
+		ResourceID:   &rejection.Channel,
+		Status:       "failure",
+		Severity:     "warning",
+		UserID:       optionalString(rejection.Subscriber.UserID),
+		IPAddress:    optionalString(rejection.RemoteAddr),
+		UserAgent:    optionalString(rejection.UserAgent),
+		ErrorMessage: &rejection.Reason,
+		Metadata:     optionalString(string(metadata)),
+		CreatedTime:  rejection.Time,
+	}
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(log).Error; err != nil {
+		return fmt.Errorf("failed to record subscription rejection: %w", err)
+	}
+	return nil
+}
+
+// optionalString 空字符串写入 NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
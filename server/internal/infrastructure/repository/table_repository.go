@@ -104,7 +104,7 @@ func (r *TableRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).
 		Model(&models.Table{}).
 		Where("id = ?", id).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // Update 更新表格
@@ -156,7 +156,7 @@ func (r *UserRepositoryImpl) Delete(ctx context.Context, id valueobject.UserID)
 	return r.db.WithContext(ctx).
 		Model(&models.User{}).
 		Where("id = ?", id.String()).
-		Update("deleted_time", gorm.Expr("NOW()")).Error
+		Update("deleted_time", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
 
 // Exists 检查用户是否存在
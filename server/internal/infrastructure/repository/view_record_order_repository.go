@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	viewRepo "github.com/easyspace-ai/luckdb/server/internal/domain/view/repository"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	pkgDatabase "github.com/easyspace-ai/luckdb/server/pkg/database"
+)
+
+// RecordOrderRepositoryImpl 视图内记录手动顺序仓储GORM实现
+// 写操作使用上下文中的事务（如果有），以便与记录更新保持原子性
+type RecordOrderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRecordOrderRepository 创建视图内记录手动顺序仓储
+func NewRecordOrderRepository(db *gorm.DB) viewRepo.RecordOrderRepository {
+	return &RecordOrderRepositoryImpl{db: db}
+}
+
+// FindOrders 查询记录在视图中的顺序值
+func (r *RecordOrderRepositoryImpl) FindOrders(ctx context.Context, viewID string, recordIDs []string) (map[string]float64, error) {
+	orders := make(map[string]float64, len(recordIDs))
+	if len(recordIDs) == 0 {
+		return orders, nil
+	}
+
+	var rows []models.ViewRecordOrder
+	err := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Where("view_id = ? AND record_id IN ?", viewID, recordIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find record orders: %w", err)
+	}
+
+	for _, row := range rows {
+		orders[row.RecordID] = row.SortOrder
+	}
+	return orders, nil
+}
+
+// FindNeighbor 查找与给定顺序值相邻的顺序值
+func (r *RecordOrderRepositoryImpl) FindNeighbor(ctx context.Context, viewID string, order float64, before bool, excludeRecordID string) (*float64, error) {
+	query := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Model(&models.ViewRecordOrder{}).
+		Where("view_id = ? AND record_id <> ?", viewID, excludeRecordID)
+	if before {
+		query = query.Where("sort_order < ?", order).Order("sort_order DESC")
+	} else {
+		query = query.Where("sort_order > ?", order).Order("sort_order ASC")
+	}
+
+	var rows []models.ViewRecordOrder
+	if err := query.Limit(1).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find neighbor order: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0].SortOrder, nil
+}
+
+// SetOrders 设置记录在视图中的顺序值（存在则更新）
+func (r *RecordOrderRepositoryImpl) SetOrders(ctx context.Context, viewID string, orders map[string]float64) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	rows := make([]models.ViewRecordOrder, 0, len(orders))
+	for recordID, order := range orders {
+		rows = append(rows, models.ViewRecordOrder{ViewID: viewID, RecordID: recordID, SortOrder: order})
+	}
+
+	err := pkgDatabase.WithTx(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "view_id"}, {Name: "record_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"sort_order", "last_modified_time"}),
+		}).
+		CreateInBatches(rows, 500).Error
+	if err != nil {
+		return fmt.Errorf("failed to set record orders: %w", err)
+	}
+	return nil
+}
@@ -12,6 +12,29 @@ import (
 	"github.com/easyspace-ai/luckdb/server/pkg/errors"
 )
 
+// fallbackMimeTypes 系统 MIME 表（/etc/mime.types）缺失时常见附件扩展名的类型
+var fallbackMimeTypes = map[string]string{
+	".txt":      "text/plain; charset=utf-8",
+	".md":       "text/markdown; charset=utf-8",
+	".markdown": "text/markdown; charset=utf-8",
+	".csv":      "text/csv; charset=utf-8",
+	".mp4":      "video/mp4",
+	".m4v":      "video/x-m4v",
+	".mov":      "video/quicktime",
+	".webm":     "video/webm",
+	".mkv":      "video/x-matroska",
+	".avi":      "video/x-msvideo",
+	".doc":      "application/msword",
+	".docx":     "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xls":      "application/vnd.ms-excel",
+	".xlsx":     "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".ppt":      "application/vnd.ms-powerpoint",
+	".pptx":     "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":      "application/vnd.oasis.opendocument.text",
+	".ods":      "application/vnd.oasis.opendocument.spreadsheet",
+	".odp":      "application/vnd.oasis.opendocument.presentation",
+}
+
 // FileValidator 文件验证器实现
 type FileValidator struct {
 	logger *zap.Logger
@@ -47,8 +70,8 @@ func (v *FileValidator) ValidateFile(ctx context.Context, filename string, size
 		return errors.ErrBadRequest.WithDetails("Filename is required")
 	}
 
-	// 检查文件名是否包含危险字符
-	if strings.ContainsAny(filename, "../\\") {
+	// 检查文件名是否包含路径分隔符或上级目录（扩展名中的点是允许的）
+	if strings.Contains(filename, "..") || strings.ContainsAny(filename, "/\\") {
 		return errors.ErrBadRequest.WithDetails("Filename contains invalid characters")
 	}
 
@@ -68,7 +91,11 @@ func (v *FileValidator) ValidateFile(ctx context.Context, filename string, size
 
 // GetMimeType 获取文件MIME类型
 func (v *FileValidator) GetMimeType(filename string) string {
-	mimeType := mime.TypeByExtension(filepath.Ext(filename))
+	ext := strings.ToLower(filepath.Ext(filename))
+	mimeType := mime.TypeByExtension(ext)
+	if mimeType == "" {
+		mimeType = fallbackMimeTypes[ext]
+	}
 	if mimeType == "" {
 		// 如果无法通过扩展名确定MIME类型，使用默认值
 		return "application/octet-stream"
@@ -117,6 +117,57 @@ func (s *LocalStorage) Delete(ctx context.Context, path string) error {
 	return nil
 }
 
+// Compose 按顺序拼接分片文件到目标路径（先写临时文件再重命名，失败时不留下半成品）
+func (s *LocalStorage) Compose(ctx context.Context, target string, parts []string, contentType string) error {
+	fullPath := filepath.Join(s.basePath, target)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".compose-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	for _, part := range parts {
+		if err := ctx.Err(); err != nil {
+			tmp.Close()
+			return err
+		}
+		if err := appendFile(tmp, filepath.Join(s.basePath, part)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file content: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return fmt.Errorf("failed to move composed file: %w", err)
+	}
+
+	s.logger.Info("File composed successfully",
+		logger.String("path", fullPath),
+		logger.Int("parts", len(parts)),
+	)
+	return nil
+}
+
+// appendFile 把源文件内容追加到 dst
+func appendFile(dst *os.File, source string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("failed to open chunk: %w", err)
+	}
+	defer src.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("failed to write file content: %w", err)
+	}
+	return nil
+}
+
 // Exists 检查文件是否存在
 func (s *LocalStorage) Exists(ctx context.Context, path string) (bool, error) {
 	fullPath := filepath.Join(s.basePath, path)
@@ -140,7 +191,7 @@ func (s *LocalStorage) Exists(ctx context.Context, path string) (bool, error) {
 func (s *LocalStorage) GetURL(ctx context.Context, path string, expires time.Duration) (string, error) {
 	// 本地存储直接返回文件路径
 	// 在实际应用中，这里应该返回一个可以通过HTTP访问的URL
-	return fmt.Sprintf("/api/attachments/read/%s", path), nil
+	return fmt.Sprintf("/api/v1/attachments/read/%s", path), nil
 }
 
 // GetSize 获取文件大小
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLocalStorage_Compose(t *testing.T) {
+	ctx := context.Background()
+	base := t.TempDir()
+	s := NewLocalStorage(base, zap.NewNop())
+
+	require.NoError(t, s.Upload(ctx, "uploads/s1/0", strings.NewReader("hello "), 6, "application/octet-stream"))
+	require.NoError(t, s.Upload(ctx, "uploads/s1/1", strings.NewReader("world"), 5, "application/octet-stream"))
+
+	require.NoError(t, s.Compose(ctx, "attachments/t/f/a.txt", []string{"uploads/s1/0", "uploads/s1/1"}, "text/plain"))
+	data, err := os.ReadFile(filepath.Join(base, "attachments/t/f/a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	// 分片缺失时不覆盖已有文件，也不留下临时文件
+	err = s.Compose(ctx, "attachments/t/f/a.txt", []string{"uploads/s1/0", "uploads/s1/missing"}, "text/plain")
+	assert.Error(t, err)
+	data, err = os.ReadFile(filepath.Join(base, "attachments/t/f/a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(base, "attachments/t/f"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/nfnt/resize"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+)
+
+// 外部工具输出截断长度（写入错误信息）
+const previewToolOutputLimit = 512
+
+// PreviewGenerator 附件预览生成器实现
+//
+// 图片、文本、MP4 元数据和 Office Open XML/ODF 文档在进程内解析；
+// PDF 渲染、视频截帧和旧版 Office 转换依赖外部工具（pdftoppm/mutool、ffmpeg/ffprobe、soffice），
+// 工具在创建时从 PATH 查找，缺失时退化为只提取元数据或返回 attachment.ErrPreviewUnavailable
+type PreviewGenerator struct {
+	logger *zap.Logger
+
+	pdftoppm string
+	pdfinfo  string
+	mutool   string
+	ffmpeg   string
+	ffprobe  string
+	soffice  string
+}
+
+// NewPreviewGenerator 创建预览生成器
+func NewPreviewGenerator(logger *zap.Logger) *PreviewGenerator {
+	g := &PreviewGenerator{
+		logger:   logger,
+		pdftoppm: lookTool("pdftoppm"),
+		pdfinfo:  lookTool("pdfinfo"),
+		mutool:   lookTool("mutool"),
+		ffmpeg:   lookTool("ffmpeg"),
+		ffprobe:  lookTool("ffprobe"),
+		soffice:  lookTool("soffice", "libreoffice"),
+	}
+
+	logger.Info("Preview generator initialized",
+		zap.Bool("pdf_render", g.pdftoppm != "" || g.mutool != ""),
+		zap.Bool("video_poster", g.ffmpeg != ""),
+		zap.Bool("office_convert", g.soffice != ""),
+	)
+	return g
+}
+
+// Supports 检查是否支持该文件类型
+func (g *PreviewGenerator) Supports(mimeType string) bool {
+	mimeType = baseMimeType(mimeType)
+	switch {
+	case isPreviewImage(mimeType), isPreviewText(mimeType), isOfficeDocument(mimeType):
+		return true
+	case mimeType == "application/pdf":
+		return true
+	case strings.HasPrefix(mimeType, "video/"):
+		return true
+	}
+	return false
+}
+
+// Generate 为本地文件生成预览
+func (g *PreviewGenerator) Generate(ctx context.Context, localPath, mimeType string, config *attachment.ThumbnailConfig) (*attachment.Preview, error) {
+	if config == nil {
+		config = attachment.DefaultThumbnailConfig()
+	}
+
+	mimeType = baseMimeType(mimeType)
+	switch {
+	case isPreviewImage(mimeType):
+		return g.imagePreview(localPath, config)
+	case isPreviewText(mimeType):
+		return textPreview(localPath, mimeType)
+	case mimeType == "application/pdf":
+		return g.pdfPreview(ctx, localPath, config)
+	case strings.HasPrefix(mimeType, "video/"):
+		return g.videoPreview(ctx, localPath, mimeType, config)
+	case isOfficeDocument(mimeType):
+		return g.officePreview(ctx, localPath, mimeType, config)
+	}
+	return nil, attachment.ErrPreviewUnavailable
+}
+
+// imagePreview 图片直接缩放为海报图
+func (g *PreviewGenerator) imagePreview(localPath string, config *attachment.ThumbnailConfig) (*attachment.Preview, error) {
+	img, err := decodeImageFile(localPath)
+	if err != nil {
+		return nil, err
+	}
+	preview := &attachment.Preview{}
+	if err := applyPoster(preview, img, config); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// applyPoster 把海报图缩放为大小两张 JPEG，并以海报图尺寸作为附件尺寸
+func applyPoster(preview *attachment.Preview, img image.Image, config *attachment.ThumbnailConfig) error {
+	small, err := encodeThumbnail(img, config.SmallWidth, config.SmallHeight, config.Quality)
+	if err != nil {
+		return err
+	}
+	large, err := encodeThumbnail(img, config.LargeWidth, config.LargeHeight, config.Quality)
+	if err != nil {
+		return err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	preview.SmallImage = small
+	preview.LargeImage = large
+	preview.Width = &width
+	preview.Height = &height
+	return nil
+}
+
+// encodeThumbnail 按最大宽高等比缩放（不放大）并编码为 JPEG
+func encodeThumbnail(img image.Image, maxWidth, maxHeight, quality int) ([]byte, error) {
+	if quality <= 0 {
+		quality = 80
+	}
+	thumbnail := img
+	if maxWidth > 0 && maxHeight > 0 {
+		thumbnail = resize.Thumbnail(uint(maxWidth), uint(maxHeight), img, resize.Lanczos3)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeImageFile 解码本地图片文件
+func decodeImageFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// runTool 运行外部工具，失败时错误中附带截断后的输出
+func runTool(ctx context.Context, tool string, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, tool, args...).CombinedOutput()
+	if err != nil {
+		message := strings.TrimSpace(string(output))
+		if len(message) > previewToolOutputLimit {
+			message = message[:previewToolOutputLimit]
+		}
+		return output, fmt.Errorf("%s failed: %w: %s", tool, err, message)
+	}
+	return output, nil
+}
+
+// lookTool 在 PATH 中查找外部工具，返回第一个找到的路径
+func lookTool(names ...string) string {
+	for _, name := range names {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// baseMimeType 去掉 MIME 类型的参数部分（如 charset）
+func baseMimeType(mimeType string) string {
+	if i := strings.Index(mimeType, ";"); i >= 0 {
+		mimeType = mimeType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// isPreviewImage 标准库可解码的图片类型
+func isPreviewImage(mimeType string) bool {
+	switch mimeType {
+	case "image/jpeg", "image/jpg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
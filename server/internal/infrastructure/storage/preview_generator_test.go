@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+)
+
+// mp4Box 拼装一个 box
+func mp4Box(boxType string, body ...[]byte) []byte {
+	payload := bytes.Join(body, nil)
+	box := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(box[:4], uint32(8+len(payload)))
+	copy(box[4:8], boxType)
+	return append(box, payload...)
+}
+
+func mvhdV0(timescale, duration uint32) []byte {
+	body := make([]byte, 100)
+	binary.BigEndian.PutUint32(body[12:16], timescale)
+	binary.BigEndian.PutUint32(body[16:20], duration)
+	return mp4Box("mvhd", body)
+}
+
+func tkhdV0(width, height uint32) []byte {
+	body := make([]byte, 84)
+	binary.BigEndian.PutUint32(body[76:80], width<<16)
+	binary.BigEndian.PutUint32(body[80:84], height<<16)
+	return mp4Box("tkhd", body)
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
+
+// newTestGenerator 不依赖外部工具的生成器
+func newTestGenerator() *PreviewGenerator {
+	return &PreviewGenerator{logger: zap.NewNop()}
+}
+
+func TestReadMP4Metadata(t *testing.T) {
+	data := bytes.Join([][]byte{
+		mp4Box("ftyp", []byte("isom0000")),
+		mp4Box("mdat", make([]byte, 64)),
+		mp4Box("moov",
+			mvhdV0(1000, 5500),
+			mp4Box("trak", tkhdV0(0, 0)), // 音频轨道
+			mp4Box("trak", tkhdV0(640, 360)),
+		),
+	}, nil)
+
+	meta, err := readMP4Metadata(writeFile(t, "clip.mp4", data))
+	require.NoError(t, err)
+	assert.InDelta(t, 5.5, meta.Duration, 0.0001)
+	assert.Equal(t, 640, meta.Width)
+	assert.Equal(t, 360, meta.Height)
+
+	_, err = readMP4Metadata(writeFile(t, "broken.mp4", mp4Box("ftyp", []byte("isom"))[:10]))
+	assert.Error(t, err)
+}
+
+func TestVideoPreview_MetadataWithoutFFmpeg(t *testing.T) {
+	data := mp4Box("moov", mvhdV0(600, 1200), mp4Box("trak", tkhdV0(1920, 1080)))
+	path := writeFile(t, "clip.mp4", data)
+
+	preview, err := newTestGenerator().Generate(context.Background(), path, "video/mp4", nil)
+	require.NoError(t, err)
+	assert.Nil(t, preview.SmallImage)
+	require.NotNil(t, preview.Duration)
+	assert.InDelta(t, 2.0, *preview.Duration, 0.0001)
+	assert.Equal(t, 1920, *preview.Width)
+
+	// 非 MP4 容器且没有 ffprobe/ffmpeg 时无法生成
+	_, err = newTestGenerator().Generate(context.Background(), writeFile(t, "clip.webm", []byte("webm")), "video/webm", nil)
+	assert.Equal(t, attachment.ErrPreviewUnavailable, err)
+}
+
+func TestScanPDFPageCount(t *testing.T) {
+	pdf := "%PDF-1.4\n" +
+		"1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n" +
+		"2 0 obj << /Type /Pages /Kids [3 0 R 4 0 R] /Count 3 >> endobj\n" +
+		"3 0 obj << /Type /Page /Parent 2 0 R >> endobj\n" +
+		"4 0 obj << /Count 2 /Type /Pages /Parent 2 0 R >> endobj\n"
+	assert.Equal(t, 3, scanPDFPageCount(writeFile(t, "a.pdf", []byte(pdf))))
+
+	// 没有页树计数时按页面对象计数
+	pdf = "%PDF-1.4\n<< /Type /Page >>\n<< /Type/Page >>\n"
+	assert.Equal(t, 2, scanPDFPageCount(writeFile(t, "b.pdf", []byte(pdf))))
+
+	preview, err := newTestGenerator().Generate(context.Background(), writeFile(t, "c.pdf", []byte(pdf)), "application/pdf", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, *preview.PageCount)
+	assert.Nil(t, preview.SmallImage)
+}
+
+func TestTextPreview(t *testing.T) {
+	g := newTestGenerator()
+
+	path := writeFile(t, "notes.md", []byte("\xef\xbb\xbf# Title\r\n\r\n\r\n\r\nbody  \n"))
+	preview, err := g.Generate(context.Background(), path, "text/markdown; charset=utf-8", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "# Title\n\nbody", preview.Text)
+
+	path = writeFile(t, "data.csv", []byte("name,note\nalice,\"hello, world\"\nbob\n"))
+	preview, err = g.Generate(context.Background(), path, "text/csv", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "name | note\nalice | hello, world\nbob", preview.Text)
+
+	long := strings.Repeat("文", previewTextLimit+10)
+	assert.Equal(t, strings.Repeat("文", previewTextLimit)+"…", truncateText(long, previewTextLimit))
+}
+
+func TestOfficePreview_Docx(t *testing.T) {
+	var thumbnail bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 40, 60))
+	img.Set(1, 1, color.White)
+	require.NoError(t, png.Encode(&thumbnail, img))
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := map[string][]byte{
+		"word/document.xml": []byte(`<w:document xmlns:w="w"><w:body>` +
+			`<w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:t xml:space="preserve"> world</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:instrText>PAGE</w:instrText><w:t>Second</w:t></w:r></w:p>` +
+			`</w:body></w:document>`),
+		"docProps/app.xml":       []byte(`<Properties><Pages>7</Pages></Properties>`),
+		"docProps/thumbnail.png": thumbnail.Bytes(),
+	}
+	for name, data := range parts {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	path := writeFile(t, "report.docx", buf.Bytes())
+	preview, err := newTestGenerator().Generate(context.Background(), path,
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world\nSecond", preview.Text)
+	assert.Equal(t, 7, *preview.PageCount)
+	assert.Equal(t, 40, *preview.Width)
+
+	small, err := jpeg.Decode(bytes.NewReader(preview.SmallImage))
+	require.NoError(t, err)
+	assert.Equal(t, 40, small.Bounds().Dx())
+
+	// 旧版二进制格式没有 soffice 时无法生成
+	_, err = newTestGenerator().Generate(context.Background(), writeFile(t, "old.doc", []byte("bin")), "application/msword", nil)
+	assert.Equal(t, attachment.ErrPreviewUnavailable, err)
+}
+
+func TestImagePreview(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2000, 1000))))
+
+	preview, err := newTestGenerator().Generate(context.Background(), writeFile(t, "a.png", buf.Bytes()), "image/png", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2000, *preview.Width)
+
+	small, err := jpeg.Decode(bytes.NewReader(preview.SmallImage))
+	require.NoError(t, err)
+	assert.Equal(t, 300, small.Bounds().Dx())
+	large, err := jpeg.Decode(bytes.NewReader(preview.LargeImage))
+	require.NoError(t, err)
+	assert.Equal(t, 1280, large.Bounds().Dx())
+}
+
+func TestPreviewGenerator_Supports(t *testing.T) {
+	g := newTestGenerator()
+	assert.True(t, g.Supports("application/pdf"))
+	assert.True(t, g.Supports("video/webm"))
+	assert.True(t, g.Supports("text/plain; charset=utf-8"))
+	assert.True(t, g.Supports("application/vnd.openxmlformats-officedocument.presentationml.presentation"))
+	assert.False(t, g.Supports("image/webp"))
+	assert.False(t, g.Supports("application/zip"))
+}
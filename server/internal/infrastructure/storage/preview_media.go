@@ -0,0 +1,370 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+)
+
+// pdfPageCountScanLimit 进程内统计 PDF 页数时最多读取的字节数
+const pdfPageCountScanLimit = 64 << 20
+
+var (
+	pdfPagesCountPattern = regexp.MustCompile(`/Type\s*/Pages\b[^>]*?/Count\s+(\d+)|/Count\s+(\d+)[^>]*?/Type\s*/Pages\b`)
+	pdfPagePattern       = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfinfoPagesPattern  = regexp.MustCompile(`(?m)^Pages:\s+(\d+)`)
+)
+
+// pdfPreview 渲染 PDF 首页为海报图并统计页数
+// 没有 pdftoppm/mutool 时只返回页数
+func (g *PreviewGenerator) pdfPreview(ctx context.Context, localPath string, config *attachment.ThumbnailConfig) (*attachment.Preview, error) {
+	preview := &attachment.Preview{PageCount: g.pdfPageCount(ctx, localPath)}
+
+	poster, err := g.renderPDFPage(ctx, localPath, config.LargeWidth)
+	if err != nil && err != attachment.ErrPreviewUnavailable {
+		return nil, err
+	}
+	if poster != "" {
+		img, err := decodeImageFile(poster)
+		os.RemoveAll(filepath.Dir(poster))
+		if err != nil {
+			return nil, err
+		}
+		if err := applyPoster(preview, img, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if preview.SmallImage == nil && preview.PageCount == nil {
+		return nil, attachment.ErrPreviewUnavailable
+	}
+	return preview, nil
+}
+
+// renderPDFPage 把 PDF 首页渲染为图片，返回临时目录中的图片路径（调用方删除所在目录）
+func (g *PreviewGenerator) renderPDFPage(ctx context.Context, localPath string, width int) (string, error) {
+	if g.pdftoppm == "" && g.mutool == "" {
+		return "", attachment.ErrPreviewUnavailable
+	}
+	if width <= 0 {
+		width = 1280
+	}
+
+	dir, err := os.MkdirTemp("", "luckdb-preview-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	if g.pdftoppm != "" {
+		prefix := filepath.Join(dir, "page")
+		_, err = runTool(ctx, g.pdftoppm, "-f", "1", "-l", "1", "-singlefile", "-jpeg", "-scale-to", strconv.Itoa(width), localPath, prefix)
+		if err == nil {
+			return prefix + ".jpg", nil
+		}
+	} else {
+		output := filepath.Join(dir, "page.png")
+		_, err = runTool(ctx, g.mutool, "draw", "-q", "-o", output, "-w", strconv.Itoa(width), localPath, "1")
+		if err == nil {
+			return output, nil
+		}
+	}
+
+	os.RemoveAll(dir)
+	return "", err
+}
+
+// pdfPageCount 统计 PDF 页数：先在进程内扫描页树，页树位于压缩对象流中时退回 pdfinfo
+func (g *PreviewGenerator) pdfPageCount(ctx context.Context, localPath string) *int {
+	if count := scanPDFPageCount(localPath); count > 0 {
+		return &count
+	}
+	if g.pdfinfo == "" {
+		return nil
+	}
+
+	output, err := runTool(ctx, g.pdfinfo, localPath)
+	if err != nil {
+		g.logger.Debug("pdfinfo failed", zap.String("path", localPath), zap.Error(err))
+		return nil
+	}
+	if match := pdfinfoPagesPattern.FindSubmatch(output); match != nil {
+		if count, err := strconv.Atoi(string(match[1])); err == nil && count > 0 {
+			return &count
+		}
+	}
+	return nil
+}
+
+// scanPDFPageCount 取根页树节点的 /Count（各 Pages 节点中最大的），没有时按 Page 对象计数
+func scanPDFPageCount(localPath string) int {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, pdfPageCountScanLimit))
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, match := range pdfPagesCountPattern.FindAllSubmatch(data, -1) {
+		value := match[1]
+		if value == nil {
+			value = match[2]
+		}
+		if n, err := strconv.Atoi(string(value)); err == nil && n > count {
+			count = n
+		}
+	}
+	if count > 0 {
+		return count
+	}
+	return len(pdfPagePattern.FindAllIndex(data, -1))
+}
+
+// videoPreview 截取视频海报帧并读取时长与分辨率
+// MP4/MOV 的元数据在进程内解析；没有 ffmpeg 时只返回元数据
+func (g *PreviewGenerator) videoPreview(ctx context.Context, localPath, mimeType string, config *attachment.ThumbnailConfig) (*attachment.Preview, error) {
+	preview := &attachment.Preview{}
+
+	if isISOBaseMedia(mimeType) {
+		if meta, err := readMP4Metadata(localPath); err == nil {
+			if meta.Duration > 0 {
+				duration := meta.Duration
+				preview.Duration = &duration
+			}
+			if meta.Width > 0 && meta.Height > 0 {
+				width, height := meta.Width, meta.Height
+				preview.Width, preview.Height = &width, &height
+			}
+		} else {
+			g.logger.Debug("Failed to parse mp4 metadata", zap.String("path", localPath), zap.Error(err))
+		}
+	}
+	if preview.Duration == nil && g.ffprobe != "" {
+		preview.Duration = g.probeDuration(ctx, localPath)
+	}
+
+	if g.ffmpeg != "" {
+		poster, err := g.extractVideoFrame(ctx, localPath, preview.Duration)
+		if err != nil {
+			return nil, err
+		}
+		img, err := decodeImageFile(poster)
+		os.RemoveAll(filepath.Dir(poster))
+		if err != nil {
+			return nil, err
+		}
+		if err := applyPoster(preview, img, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if preview.SmallImage == nil && preview.Duration == nil {
+		return nil, attachment.ErrPreviewUnavailable
+	}
+	return preview, nil
+}
+
+// extractVideoFrame 用 ffmpeg 截取一帧（默认第 1 秒，短视频取中间），返回临时目录中的图片路径
+func (g *PreviewGenerator) extractVideoFrame(ctx context.Context, localPath string, duration *float64) (string, error) {
+	dir, err := os.MkdirTemp("", "luckdb-preview-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	offset := 1.0
+	if duration != nil && *duration < 2 {
+		offset = *duration / 2
+	}
+	output := filepath.Join(dir, "poster.jpg")
+	_, err = runTool(ctx, g.ffmpeg, "-v", "error", "-y",
+		"-ss", strconv.FormatFloat(offset, 'f', 3, 64),
+		"-i", localPath, "-frames:v", "1", output)
+	if err == nil {
+		if _, statErr := os.Stat(output); statErr != nil {
+			// 偏移超出时长时 ffmpeg 不报错也不输出
+			err = fmt.Errorf("ffmpeg produced no frame at %.3fs", offset)
+		}
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return output, nil
+}
+
+// probeDuration 用 ffprobe 读取容器时长
+func (g *PreviewGenerator) probeDuration(ctx context.Context, localPath string) *float64 {
+	output, err := runTool(ctx, g.ffprobe, "-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1", localPath)
+	if err != nil {
+		g.logger.Debug("ffprobe failed", zap.String("path", localPath), zap.Error(err))
+		return nil
+	}
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil || duration <= 0 {
+		return nil
+	}
+	return &duration
+}
+
+// isISOBaseMedia 采用 ISO BMFF 容器（MP4/MOV/3GP）的视频类型
+func isISOBaseMedia(mimeType string) bool {
+	switch mimeType {
+	case "video/mp4", "video/quicktime", "video/x-m4v", "video/3gpp", "video/3gpp2":
+		return true
+	}
+	return false
+}
+
+// mp4Metadata MP4 容器元数据
+type mp4Metadata struct {
+	Duration float64 // 秒
+	Width    int
+	Height   int
+}
+
+// readMP4Metadata 解析 moov/mvhd 时长和第一条视频轨道（tkhd）的显示尺寸
+func readMP4Metadata(localPath string) (*mp4Metadata, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &mp4Metadata{}
+	found, err := walkMP4Boxes(file, 0, info.Size(), meta)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("mp4: moov box not found")
+	}
+	return meta, nil
+}
+
+// walkMP4Boxes 遍历 [start, end) 范围内的 box，进入 moov/trak 容器，返回是否找到 mvhd
+func walkMP4Boxes(r io.ReadSeeker, start, end int64, meta *mp4Metadata) (bool, error) {
+	found := false
+	offset := start
+	header := make([]byte, 16)
+
+	for offset+8 <= end {
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return found, err
+		}
+		if _, err := io.ReadFull(r, header[:8]); err != nil {
+			return found, err
+		}
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		boxType := string(header[4:8])
+		headerSize := int64(8)
+
+		switch size {
+		case 0: // 延伸到文件末尾
+			size = end - offset
+		case 1: // 64 位长度
+			if _, err := io.ReadFull(r, header[8:16]); err != nil {
+				return found, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+		if size < headerSize || offset+size > end {
+			return found, fmt.Errorf("mp4: invalid %q box size %d", boxType, size)
+		}
+
+		bodyStart, bodyEnd := offset+headerSize, offset+size
+		switch boxType {
+		case "moov", "trak":
+			ok, err := walkMP4Boxes(r, bodyStart, bodyEnd, meta)
+			if err != nil {
+				return found, err
+			}
+			found = found || ok
+		case "mvhd":
+			if err := readMVHD(r, bodyEnd-bodyStart, meta); err != nil {
+				return found, err
+			}
+			found = true
+		case "tkhd":
+			if meta.Width == 0 {
+				readTKHD(r, bodyEnd-bodyStart, meta)
+			}
+		}
+		offset += size
+	}
+	return found, nil
+}
+
+// readMVHD 读取 mvhd 中的 timescale 和 duration
+func readMVHD(r io.Reader, size int64, meta *mp4Metadata) error {
+	body := make([]byte, minInt64(size, 32))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return err
+	}
+	if len(body) < 20 {
+		return fmt.Errorf("mp4: short mvhd box")
+	}
+
+	var timescale, duration uint64
+	if body[0] == 1 {
+		if len(body) < 32 {
+			return fmt.Errorf("mp4: short mvhd box")
+		}
+		timescale = uint64(binary.BigEndian.Uint32(body[20:24]))
+		duration = binary.BigEndian.Uint64(body[24:32])
+	} else {
+		timescale = uint64(binary.BigEndian.Uint32(body[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(body[16:20]))
+	}
+	if timescale > 0 {
+		meta.Duration = float64(duration) / float64(timescale)
+	}
+	return nil
+}
+
+// readTKHD 读取轨道显示尺寸（16.16 定点数），音频轨道尺寸为 0
+func readTKHD(r io.Reader, size int64, meta *mp4Metadata) {
+	body := make([]byte, minInt64(size, 96))
+	if _, err := io.ReadFull(r, body); err != nil || len(body) == 0 {
+		return
+	}
+
+	// 版本 0/1 的时间字段长度不同，宽高位于 box 末尾
+	offset := 76
+	if body[0] == 1 {
+		offset = 88
+	}
+	if len(body) < offset+8 {
+		return
+	}
+	meta.Width = int(binary.BigEndian.Uint32(body[offset:offset+4]) >> 16)
+	meta.Height = int(binary.BigEndian.Uint32(body[offset+4:offset+8]) >> 16)
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
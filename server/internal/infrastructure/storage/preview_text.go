@@ -0,0 +1,377 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+)
+
+const (
+	previewTextLimit     = 4000    // 文本摘录最多保留的字符数
+	previewTextReadLimit = 1 << 20 // 读取文本文件/文档 XML 的最大字节数
+	previewCSVRows       = 100     // CSV 摘录最多保留的行数
+)
+
+var (
+	pptxSlidePattern  = regexp.MustCompile(`^ppt/slides/slide(\d+)\.xml$`)
+	officePagesFields = []string{"Pages", "Slides"}
+)
+
+// officeDocument Office 文档类型的解析方式
+type officeDocument struct {
+	// parts 文本所在的压缩包内文件；pptx 的幻灯片按编号单独枚举
+	parts []string
+	// textElements 文本节点的元素名（不含命名空间）
+	textElements map[string]bool
+	// breakElements 结束时换行的元素名
+	breakElements map[string]bool
+}
+
+var (
+	ooxmlText  = map[string]bool{"t": true}
+	ooxmlBreak = map[string]bool{"p": true, "si": true, "tr": true}
+	odfText    = map[string]bool{"p": true, "h": true}
+
+	officeDocuments = map[string]*officeDocument{
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document": {
+			parts: []string{"word/document.xml"}, textElements: ooxmlText, breakElements: ooxmlBreak,
+		},
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {
+			parts: []string{"xl/sharedStrings.xml"}, textElements: ooxmlText, breakElements: ooxmlBreak,
+		},
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation": {
+			textElements: ooxmlText, breakElements: ooxmlBreak,
+		},
+		"application/vnd.oasis.opendocument.text": {
+			parts: []string{"content.xml"}, textElements: odfText, breakElements: odfText,
+		},
+		"application/vnd.oasis.opendocument.spreadsheet": {
+			parts: []string{"content.xml"}, textElements: odfText, breakElements: odfText,
+		},
+		"application/vnd.oasis.opendocument.presentation": {
+			parts: []string{"content.xml"}, textElements: odfText, breakElements: odfText,
+		},
+		// 旧版二进制格式只能通过 soffice 转换
+		"application/msword":            nil,
+		"application/vnd.ms-excel":      nil,
+		"application/vnd.ms-powerpoint": nil,
+		"application/rtf":               nil,
+	}
+)
+
+// isPreviewText 可直接摘录文本的类型
+func isPreviewText(mimeType string) bool {
+	switch mimeType {
+	case "text/plain", "text/markdown", "text/x-markdown", "text/csv":
+		return true
+	}
+	return false
+}
+
+// isOfficeDocument 办公文档类型
+func isOfficeDocument(mimeType string) bool {
+	_, ok := officeDocuments[mimeType]
+	return ok
+}
+
+// textPreview 摘录纯文本/Markdown/CSV 的开头部分
+func textPreview(localPath, mimeType string) (*attachment.Preview, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open text file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, previewTextReadLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read text file: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	text := string(data)
+	if mimeType == "text/csv" {
+		if table, ok := csvExcerpt(data); ok {
+			text = table
+		}
+	}
+	return &attachment.Preview{Text: truncateText(text, previewTextLimit)}, nil
+}
+
+// csvExcerpt 把 CSV 前若干行整理为以 " | " 分隔的文本，解析失败时返回 false
+func csvExcerpt(data []byte) (string, bool) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	var lines []string
+	for len(lines) < previewCSVRows {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// 读取上限可能截断最后一行
+			if len(lines) > 0 {
+				break
+			}
+			return "", false
+		}
+		lines = append(lines, strings.Join(row, " | "))
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// officePreview 办公文档预览
+// OOXML/ODF 在进程内提取文本、页数和文档自带的缩略图；没有缩略图时用 soffice 转成 PDF 渲染首页
+func (g *PreviewGenerator) officePreview(ctx context.Context, localPath, mimeType string, config *attachment.ThumbnailConfig) (*attachment.Preview, error) {
+	preview := &attachment.Preview{}
+
+	if doc := officeDocuments[mimeType]; doc != nil {
+		if err := readOfficePackage(localPath, doc, preview, config); err != nil {
+			g.logger.Debug("Failed to read office package", zap.String("path", localPath), zap.Error(err))
+		}
+	}
+
+	if preview.SmallImage == nil && g.soffice != "" {
+		if err := g.convertOffice(ctx, localPath, preview, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if preview.SmallImage == nil && preview.Text == "" {
+		return nil, attachment.ErrPreviewUnavailable
+	}
+	return preview, nil
+}
+
+// convertOffice 用 soffice 把文档转换为 PDF 后渲染首页
+func (g *PreviewGenerator) convertOffice(ctx context.Context, localPath string, preview *attachment.Preview, config *attachment.ThumbnailConfig) error {
+	dir, err := os.MkdirTemp("", "luckdb-preview-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// 独立的用户配置目录，避免与其他 soffice 进程争用配置锁
+	profile := "-env:UserInstallation=file://" + filepath.ToSlash(filepath.Join(dir, "profile"))
+	if _, err := runTool(ctx, g.soffice, profile, "--headless", "--convert-to", "pdf", "--outdir", dir, localPath); err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(localPath), filepath.Ext(localPath))
+	converted, err := g.pdfPreview(ctx, filepath.Join(dir, base+".pdf"), config)
+	if err != nil {
+		if err == attachment.ErrPreviewUnavailable {
+			return nil
+		}
+		return err
+	}
+
+	preview.SmallImage, preview.LargeImage = converted.SmallImage, converted.LargeImage
+	preview.Width, preview.Height = converted.Width, converted.Height
+	if preview.PageCount == nil {
+		preview.PageCount = converted.PageCount
+	}
+	return nil
+}
+
+// readOfficePackage 从 OOXML/ODF 压缩包中提取文本、页数和缩略图
+func readOfficePackage(localPath string, doc *officeDocument, preview *attachment.Preview, config *attachment.ThumbnailConfig) error {
+	archive, err := zip.OpenReader(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open office package: %w", err)
+	}
+	defer archive.Close()
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	parts := doc.parts
+	if parts == nil {
+		parts = pptxSlides(archive.File)
+	}
+	var text strings.Builder
+	for _, part := range parts {
+		file := files[part]
+		if file == nil {
+			continue
+		}
+		if err := extractXMLText(file, doc, &text); err != nil {
+			return err
+		}
+		if text.Len() >= previewTextLimit*utf8.UTFMax {
+			break
+		}
+	}
+	preview.Text = truncateText(text.String(), previewTextLimit)
+
+	if file := files["docProps/app.xml"]; file != nil {
+		preview.PageCount = readAppPageCount(file)
+	}
+
+	for _, name := range []string{"docProps/thumbnail.jpeg", "docProps/thumbnail.png", "Thumbnails/thumbnail.png"} {
+		file := files[name]
+		if file == nil {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			continue
+		}
+		img, _, err := image.Decode(reader)
+		reader.Close()
+		if err != nil {
+			continue
+		}
+		return applyPoster(preview, img, config)
+	}
+	return nil
+}
+
+// pptxSlides 按编号排序的幻灯片文件
+func pptxSlides(files []*zip.File) []string {
+	type slide struct {
+		number int
+		name   string
+	}
+	var slides []slide
+	for _, file := range files {
+		if match := pptxSlidePattern.FindStringSubmatch(file.Name); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			slides = append(slides, slide{number: number, name: file.Name})
+		}
+	}
+	sort.Slice(slides, func(i, j int) bool { return slides[i].number < slides[j].number })
+
+	names := make([]string, 0, len(slides))
+	for _, s := range slides {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// extractXMLText 收集文本节点中的字符，段落结束时换行
+func extractXMLText(file *zip.File, doc *officeDocument, out *strings.Builder) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(reader, previewTextReadLimit))
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// 读取上限截断的 XML 保留已提取的部分
+			return nil
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if doc.textElements[t.Name.Local] {
+				depth++
+			}
+		case xml.EndElement:
+			if doc.textElements[t.Name.Local] && depth > 0 {
+				depth--
+			}
+			if doc.breakElements[t.Name.Local] && depth == 0 {
+				out.WriteString("\n")
+			}
+		case xml.CharData:
+			if depth > 0 {
+				out.Write(t)
+			}
+		}
+	}
+}
+
+// readAppPageCount 读取 docProps/app.xml 中的页数（Word）或幻灯片数（PowerPoint）
+func readAppPageCount(file *zip.File) *int {
+	reader, err := file.Open()
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+
+	var props map[string]string
+	decoder := xml.NewDecoder(io.LimitReader(reader, previewTextReadLimit))
+	var current string
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			current = t.Name.Local
+		case xml.CharData:
+			if current != "" {
+				if props == nil {
+					props = make(map[string]string)
+				}
+				props[current] += string(t)
+			}
+		case xml.EndElement:
+			current = ""
+		}
+	}
+
+	for _, field := range officePagesFields {
+		if count, err := strconv.Atoi(strings.TrimSpace(props[field])); err == nil && count > 0 {
+			return &count
+		}
+	}
+	return nil
+}
+
+// truncateText 规整空行并按字符数截断
+func truncateText(text string, limit int) string {
+	text = strings.ToValidUTF8(text, "�")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	blank := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		kept = append(kept, line)
+	}
+	text = strings.TrimSpace(strings.Join(kept, "\n"))
+
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:limit]) + "…"
+}
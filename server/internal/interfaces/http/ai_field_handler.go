@@ -0,0 +1,104 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// AIFieldHandler AI 字段HTTP处理器 ✨
+type AIFieldHandler struct {
+	aiFieldService *application.AIFieldService
+}
+
+// NewAIFieldHandler 创建 AI 字段处理器
+func NewAIFieldHandler(aiFieldService *application.AIFieldService) *AIFieldHandler {
+	return &AIFieldHandler{
+		aiFieldService: aiFieldService,
+	}
+}
+
+// GenerateField 重新生成 AI 字段的值 ✨
+// @Summary 重新生成 AI 字段的值
+// @Description 按提示词模板为可编辑的记录生成 AI 字段值，force 为 false 时只生成空值；
+// @Description 不超过 100 条的指定记录同步生成，整张表或更多记录提交为后台任务，按任务 ID 查询结果
+// @Tags Field
+// @Accept json
+// @Produce json
+// @Param fieldId path string true "AI 字段ID"
+// @Param request body dto.AIGenerateRequest false "生成范围"
+// @Success 200 {object} dto.AIGenerateJob
+// @Router /api/v1/fields/{fieldId}/ai/generate [post]
+func (h *AIFieldHandler) GenerateField(c *gin.Context) {
+	fieldID := c.Param("fieldId")
+	userID := c.GetString("user_id")
+
+	var req dto.AIGenerateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+			return
+		}
+	}
+
+	data, err := h.aiFieldService.GenerateField(c.Request.Context(), fieldID, req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if !data.Finished() {
+		response.Success(c, data, "AI 字段生成任务已提交")
+		return
+	}
+	response.Success(c, data, "AI 字段生成完成")
+}
+
+// GetGenerateJob 查询 AI 字段的后台生成任务 ✨
+// @Summary 查询 AI 字段的后台生成任务
+// @Tags Field
+// @Produce json
+// @Param fieldId path string true "AI 字段ID"
+// @Param jobId path string true "任务ID"
+// @Success 200 {object} dto.AIGenerateJob
+// @Router /api/v1/fields/{fieldId}/ai/jobs/{jobId} [get]
+func (h *AIFieldHandler) GetGenerateJob(c *gin.Context) {
+	data, err := h.aiFieldService.GetGenerateJob(c.Request.Context(), c.Param("fieldId"), c.Param("jobId"), c.GetString("user_id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取生成任务成功")
+}
+
+// GetBaseUsage 获取 Base 的 AI token 用量 ✨
+// @Summary 获取 Base 的 AI token 用量
+// @Description 按日期、提供者、模型汇总 AI 字段生成消耗的 token，默认最近 30 天
+// @Tags Base
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Param from query string false "开始日期（YYYY-MM-DD）"
+// @Param to query string false "结束日期（YYYY-MM-DD）"
+// @Success 200 {object} ai.UsageSummary
+// @Router /api/v1/bases/{baseId}/ai/usage [get]
+func (h *AIFieldHandler) GetBaseUsage(c *gin.Context) {
+	baseID := c.Param("baseId")
+
+	var query dto.AIUsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	data, err := h.aiFieldService.GetBaseUsage(c.Request.Context(), baseID, query, c.GetString("user_id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取 AI 用量成功")
+}
@@ -0,0 +1,271 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertHandler 告警规则处理器
+type AlertHandler struct {
+	service *application.AlertService
+}
+
+// NewAlertHandler 创建告警规则处理器
+func NewAlertHandler(service *application.AlertService) *AlertHandler {
+	return &AlertHandler{
+		service: service,
+	}
+}
+
+// ListAlertChannels 列出通知渠道
+// @Summary 列出告警通知渠道
+// @Description 返回配置文件中已启用的通知渠道名称，可用于告警规则的 channels
+// @Tags 告警
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]string}
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-channels [get]
+// @Security BearerAuth
+func (h *AlertHandler) ListAlertChannels(c *gin.Context) {
+	response.Success(c, h.service.ListChannels(c.Request.Context()), "获取通知渠道成功")
+}
+
+// ListAlertRules 列出告警规则
+// @Summary 列出告警规则
+// @Tags 告警
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dto.AlertRuleResponse}
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-rules [get]
+// @Security BearerAuth
+func (h *AlertHandler) ListAlertRules(c *gin.Context) {
+	result, err := h.service.ListRules(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取告警规则成功")
+}
+
+// CreateAlertRule 创建告警规则
+// @Summary 创建告警规则
+// @Description 指标满足条件并持续 forSeconds 秒后触发，通过指定渠道通知；恢复时再通知一次
+// @Tags 告警
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAlertRuleRequest true "创建告警规则请求"
+// @Success 200 {object} response.APIResponse{data=dto.AlertRuleResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-rules [post]
+// @Security BearerAuth
+func (h *AlertHandler) CreateAlertRule(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req dto.CreateAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.CreateRule(c.Request.Context(), req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "创建告警规则成功")
+}
+
+// GetAlertRule 获取告警规则
+// @Summary 获取告警规则
+// @Tags 告警
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} response.APIResponse{data=dto.AlertRuleResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-rules/{ruleId} [get]
+// @Security BearerAuth
+func (h *AlertHandler) GetAlertRule(c *gin.Context) {
+	result, err := h.service.GetRule(c.Request.Context(), c.Param("ruleId"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取告警规则成功")
+}
+
+// UpdateAlertRule 更新告警规则
+// @Summary 更新告警规则
+// @Tags 告警
+// @Accept json
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Param request body dto.UpdateAlertRuleRequest true "更新告警规则请求"
+// @Success 200 {object} response.APIResponse{data=dto.AlertRuleResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-rules/{ruleId} [patch]
+// @Security BearerAuth
+func (h *AlertHandler) UpdateAlertRule(c *gin.Context) {
+	var req dto.UpdateAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.UpdateRule(c.Request.Context(), c.Param("ruleId"), req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "更新告警规则成功")
+}
+
+// DeleteAlertRule 删除告警规则
+// @Summary 删除告警规则
+// @Description 告警历史保留；正在触发的告警在下一个评估周期结束（不发送恢复通知）
+// @Tags 告警
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-rules/{ruleId} [delete]
+// @Security BearerAuth
+func (h *AlertHandler) DeleteAlertRule(c *gin.Context) {
+	if err := h.service.DeleteRule(c.Request.Context(), c.Param("ruleId")); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil, "删除告警规则成功")
+}
+
+// TestAlertRule 发送测试告警
+// @Summary 发送测试告警
+// @Description 通过规则的通知渠道立即发送一条测试告警，返回每个渠道的发送结果
+// @Tags 告警
+// @Produce json
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} response.APIResponse{data=dto.AlertTestResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-rules/{ruleId}/test [post]
+// @Security BearerAuth
+func (h *AlertHandler) TestAlertRule(c *gin.Context) {
+	result, err := h.service.TestRule(c.Request.Context(), c.Param("ruleId"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "测试告警已发送")
+}
+
+// ListAlerts 查询告警历史
+// @Summary 查询告警历史
+// @Description 每次触发一条记录，包含触发、恢复时间和通知情况
+// @Tags 告警
+// @Produce json
+// @Param ruleId query string false "Rule ID"
+// @Param status query string false "状态：pending, firing, resolved"
+// @Param limit query int false "每页数量（默认 50，最大 200）"
+// @Param offset query int false "偏移量"
+// @Success 200 {object} response.APIResponse{data=dto.AlertListResponse}
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/monitoring/alerts [get]
+// @Security BearerAuth
+func (h *AlertHandler) ListAlerts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	result, err := h.service.ListAlerts(c.Request.Context(), c.Query("ruleId"), c.Query("status"), limit, offset)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取告警历史成功")
+}
+
+// ListAlertSilences 列出静默
+// @Summary 列出告警静默
+// @Tags 告警
+// @Produce json
+// @Param includeExpired query bool false "是否包含已结束的静默"
+// @Success 200 {object} response.APIResponse{data=[]dto.AlertSilenceResponse}
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-silences [get]
+// @Security BearerAuth
+func (h *AlertHandler) ListAlertSilences(c *gin.Context) {
+	includeExpired, _ := strconv.ParseBool(c.Query("includeExpired"))
+
+	result, err := h.service.ListSilences(c.Request.Context(), includeExpired)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取告警静默成功")
+}
+
+// CreateAlertSilence 创建静默
+// @Summary 创建告警静默
+// @Description 窗口内匹配的告警照常记录但不发送通知；窗口结束后仍在触发的告警会补发通知
+// @Tags 告警
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAlertSilenceRequest true "创建静默请求"
+// @Success 200 {object} response.APIResponse{data=dto.AlertSilenceResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-silences [post]
+// @Security BearerAuth
+func (h *AlertHandler) CreateAlertSilence(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req dto.CreateAlertSilenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.CreateSilence(c.Request.Context(), req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "创建告警静默成功")
+}
+
+// DeleteAlertSilence 删除静默
+// @Summary 删除告警静默
+// @Tags 告警
+// @Produce json
+// @Param silenceId path string true "Silence ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/monitoring/alert-silences/{silenceId} [delete]
+// @Security BearerAuth
+func (h *AlertHandler) DeleteAlertSilence(c *gin.Context) {
+	if err := h.service.DeleteSilence(c.Request.Context(), c.Param("silenceId")); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil, "删除告警静默成功")
+}
@@ -1,11 +1,14 @@
 package http
 
 import (
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/easyspace-ai/luckdb/server/internal/application"
 	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
 	"github.com/easyspace-ai/luckdb/server/pkg/errors"
 	resp "github.com/easyspace-ai/luckdb/server/pkg/response"
@@ -13,12 +16,12 @@ import (
 
 // AttachmentHandler 附件HTTP处理器
 type AttachmentHandler struct {
-	attachmentService attachment.Service
+	attachmentService *application.AttachmentService
 	logger            *zap.Logger
 }
 
 // NewAttachmentHandler 创建附件HTTP处理器
-func NewAttachmentHandler(attachmentService attachment.Service, logger *zap.Logger) *AttachmentHandler {
+func NewAttachmentHandler(attachmentService *application.AttachmentService, logger *zap.Logger) *AttachmentHandler {
 	return &AttachmentHandler{
 		attachmentService: attachmentService,
 		logger:            logger,
@@ -35,7 +38,7 @@ func NewAttachmentHandler(attachmentService attachment.Service, logger *zap.Logg
 // @Success 200 {object} Response{data=attachment.SignatureResponse}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/attachments/signature [post]
+// @Router /api/v1/attachments/signature [post]
 func (h *AttachmentHandler) GenerateSignature(c *gin.Context) {
 	var req attachment.SignatureRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -69,7 +72,7 @@ func (h *AttachmentHandler) GenerateSignature(c *gin.Context) {
 // @Success 200 {object} Response{success=boolean}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/attachments/upload/{token} [post]
+// @Router /api/v1/attachments/upload/{token} [post]
 func (h *AttachmentHandler) UploadFile(c *gin.Context) {
 	token := c.Param("token")
 	if token == "" {
@@ -104,16 +107,16 @@ func (h *AttachmentHandler) UploadFile(c *gin.Context) {
 
 // NotifyUpload 通知上传完成
 // @Summary 通知上传完成
-// @Description 通知服务器文件上传完成
+// @Description 通知服务器文件上传完成，附件会追加到签名时指定的单元格
 // @Tags Attachments
 // @Accept json
 // @Produce json
 // @Param token path string true "上传令牌"
-// @Param filename query string false "文件名"
+// @Param filename query string true "文件名（与上传时一致）"
 // @Success 200 {object} Response{data=attachment.NotifyResponse}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/attachments/notify/{token} [post]
+// @Router /api/v1/attachments/notify/{token} [post]
 func (h *AttachmentHandler) NotifyUpload(c *gin.Context) {
 	token := c.Param("token")
 	if token == "" {
@@ -122,8 +125,18 @@ func (h *AttachmentHandler) NotifyUpload(c *gin.Context) {
 	}
 
 	filename := c.Query("filename")
+	if filename == "" {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("filename is required"))
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		h.handleError(c, errors.ErrUnauthorized.WithDetails("User ID not found"))
+		return
+	}
 
-	respData2, err := h.attachmentService.NotifyUpload(c.Request.Context(), token, filename)
+	respData2, err := h.attachmentService.NotifyUpload(c.Request.Context(), token, filename, userID)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -134,18 +147,19 @@ func (h *AttachmentHandler) NotifyUpload(c *gin.Context) {
 
 // ReadFile 读取文件
 // @Summary 读取文件
-// @Description 通过路径读取文件内容
+// @Description 通过路径流式读取文件内容，支持 Range 分段请求
 // @Tags Attachments
 // @Produce application/octet-stream
 // @Param path path string true "文件路径"
 // @Param token query string false "访问令牌"
 // @Param response-content-disposition query string false "响应内容配置"
 // @Success 200 {file} binary
+// @Success 206 {file} binary
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Router /api/attachments/read/{path} [get]
+// @Router /api/v1/attachments/read/{path} [get]
 func (h *AttachmentHandler) ReadFile(c *gin.Context) {
-	path := c.Param("path")
+	path := strings.TrimPrefix(c.Param("path"), "/")
 	if path == "" {
 		h.handleError(c, errors.ErrBadRequest.WithDetails("Path is required"))
 		return
@@ -155,11 +169,12 @@ func (h *AttachmentHandler) ReadFile(c *gin.Context) {
 	responseContentDisposition := c.Query("response-content-disposition")
 
 	// 读取文件
-	response, err := h.attachmentService.ReadFile(c.Request.Context(), path, token)
+	response, err := h.attachmentService.ReadFile(c.Request.Context(), c.GetString("user_id"), path, token)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
+	defer response.Reader.Close()
 
 	// 设置响应头
 	for key, value := range response.Headers {
@@ -176,20 +191,26 @@ func (h *AttachmentHandler) ReadFile(c *gin.Context) {
 	c.Header("Cross-Origin-Resource-Policy", "unsafe-none")
 	c.Header("Content-Security-Policy", "")
 
-	// 返回文件内容
-	c.Data(http.StatusOK, response.MimeType, response.Data)
+	// 返回文件内容：可定位的文件交给 http.ServeContent 处理 Range / If-Modified-Since
+	c.Header("Content-Type", response.MimeType)
+	if seeker, ok := response.Reader.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, response.Name, response.ModTime, seeker)
+		return
+	}
+	c.DataFromReader(http.StatusOK, response.Size, response.MimeType, response.Reader, nil)
 }
 
 // DeleteFile 删除文件
 // @Summary 删除文件
-// @Description 删除指定的附件文件
+// @Description 删除指定的附件文件（仍被单元格引用时返回 409）
 // @Tags Attachments
 // @Produce json
 // @Param id path string true "附件ID"
 // @Success 200 {object} Response{success=boolean}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Router /api/attachments/{id} [delete]
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/attachments/{id} [delete]
 func (h *AttachmentHandler) DeleteFile(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -197,7 +218,7 @@ func (h *AttachmentHandler) DeleteFile(c *gin.Context) {
 		return
 	}
 
-	err := h.attachmentService.DeleteFile(c.Request.Context(), id)
+	err := h.attachmentService.DeleteFile(c.Request.Context(), c.GetString("user_id"), id)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -215,7 +236,7 @@ func (h *AttachmentHandler) DeleteFile(c *gin.Context) {
 // @Success 200 {object} Response{data=attachment.AttachmentItem}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Router /api/attachments/{id} [get]
+// @Router /api/v1/attachments/{id} [get]
 func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -223,7 +244,7 @@ func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 		return
 	}
 
-	attachment, err := h.attachmentService.GetAttachment(c.Request.Context(), id)
+	attachment, err := h.attachmentService.GetAttachment(c.Request.Context(), c.GetString("user_id"), id)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -243,7 +264,7 @@ func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 // @Success 200 {object} Response{data=[]attachment.AttachmentItem}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/attachments [get]
+// @Router /api/v1/attachments [get]
 func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
 	tableID := c.Query("table_id")
 	if tableID == "" {
@@ -254,7 +275,7 @@ func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
 	fieldID := c.Query("field_id")
 	recordID := c.Query("record_id")
 
-	attachments, err := h.attachmentService.ListAttachments(c.Request.Context(), tableID, fieldID, recordID)
+	attachments, err := h.attachmentService.ListAttachments(c.Request.Context(), c.GetString("user_id"), tableID, fieldID, recordID)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -268,19 +289,19 @@ func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
 // @Description 获取指定表格的附件统计信息
 // @Tags Attachments
 // @Produce json
-// @Param table_id path string true "表格ID"
+// @Param tableId path string true "表格ID"
 // @Success 200 {object} Response{data=attachment.AttachmentStats}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/tables/{table_id}/attachments/stats [get]
+// @Router /api/v1/tables/{tableId}/attachments/stats [get]
 func (h *AttachmentHandler) GetAttachmentStats(c *gin.Context) {
-	tableID := c.Param("table_id")
+	tableID := c.Param("tableId")
 	if tableID == "" {
-		h.handleError(c, errors.ErrBadRequest.WithDetails("table_id is required"))
+		h.handleError(c, errors.ErrBadRequest.WithDetails("tableId is required"))
 		return
 	}
 
-	stats, err := h.attachmentService.GetAttachmentStats(c.Request.Context(), tableID)
+	stats, err := h.attachmentService.GetAttachmentStats(c.Request.Context(), c.GetString("user_id"), tableID)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -291,12 +312,13 @@ func (h *AttachmentHandler) GetAttachmentStats(c *gin.Context) {
 
 // CleanupExpiredTokens 清理过期令牌
 // @Summary 清理过期令牌
-// @Description 清理过期的上传令牌
+// @Description 清理过期的上传令牌（需要管理员权限，后台任务每小时也会执行）
 // @Tags Attachments
 // @Produce json
 // @Success 200 {object} Response{success=boolean}
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /api/attachments/cleanup [post]
+// @Router /api/v1/attachments/cleanup [post]
 func (h *AttachmentHandler) CleanupExpiredTokens(c *gin.Context) {
 	err := h.attachmentService.CleanupExpiredTokens(c.Request.Context())
 	if err != nil {
@@ -307,6 +329,116 @@ func (h *AttachmentHandler) CleanupExpiredTokens(c *gin.Context) {
 	resp.SuccessWithMessage(c, map[string]bool{"success": true}, "")
 }
 
+// GetSpaceStorageUsage 获取空间存储用量
+// @Summary 获取空间存储用量
+// @Description 获取空间内附件占用的存储空间、文件数和配额
+// @Tags Attachments
+// @Produce json
+// @Param spaceId path string true "空间ID"
+// @Success 200 {object} Response{data=attachment.SpaceUsage}
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/spaces/{spaceId}/storage/usage [get]
+func (h *AttachmentHandler) GetSpaceStorageUsage(c *gin.Context) {
+	spaceID := c.Param("spaceId")
+	if spaceID == "" {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("spaceId is required"))
+		return
+	}
+
+	usage, err := h.attachmentService.GetSpaceUsage(c.Request.Context(), c.GetString("user_id"), spaceID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	resp.SuccessWithMessage(c, usage, "")
+}
+
+// InstantUpload 秒传
+// @Summary 秒传
+// @Description 空间内已有相同内容（SHA-256 与大小一致）的文件时直接创建附件并追加到单元格，无需上传文件；未命中返回 404，改为正常上传
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param token path string true "上传令牌"
+// @Param request body attachment.InstantUploadRequest true "文件名、SHA-256 与大小"
+// @Success 200 {object} Response{data=attachment.NotifyResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/attachments/instant/{token} [post]
+func (h *AttachmentHandler) InstantUpload(c *gin.Context) {
+	token := c.Param("token")
+	if token == "" {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("Token is required"))
+		return
+	}
+
+	var req attachment.InstantUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+	req.Hash = strings.ToLower(req.Hash)
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		h.handleError(c, errors.ErrUnauthorized.WithDetails("User ID not found"))
+		return
+	}
+
+	respData, err := h.attachmentService.InstantUpload(c.Request.Context(), token, userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	resp.SuccessWithMessage(c, respData, "")
+}
+
+// CollectOrphanBlobs 回收无引用的文件内容
+// @Summary 回收无引用的文件内容
+// @Description 删除超过宽限期且没有附件引用的去重存储对象（需要管理员权限）
+// @Tags Attachments
+// @Produce json
+// @Success 200 {object} Response{data=attachment.GCResult}
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/attachments/gc [post]
+func (h *AttachmentHandler) CollectOrphanBlobs(c *gin.Context) {
+	result, err := h.attachmentService.CollectOrphanBlobs(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	resp.SuccessWithMessage(c, result, "")
+}
+
+// GetSpaceDedupReport 获取空间附件去重报告
+// @Summary 获取空间附件去重报告
+// @Description 获取空间内附件的逻辑大小、实际占用存储、节省的空间以及节省最多的重复文件
+// @Tags Attachments
+// @Produce json
+// @Param spaceId path string true "空间ID"
+// @Success 200 {object} Response{data=attachment.DedupReport}
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/spaces/{spaceId}/storage/dedup [get]
+func (h *AttachmentHandler) GetSpaceDedupReport(c *gin.Context) {
+	spaceID := c.Param("spaceId")
+	if spaceID == "" {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("spaceId is required"))
+		return
+	}
+
+	report, err := h.attachmentService.GetDedupReport(c.Request.Context(), c.GetString("user_id"), spaceID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	resp.SuccessWithMessage(c, report, "")
+}
+
 func (h *AttachmentHandler) handleError(c *gin.Context, err error) {
 	resp.Error(c, err)
 }
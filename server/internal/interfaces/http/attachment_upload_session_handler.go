@@ -0,0 +1,210 @@
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	resp "github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// 断点续传遵循 tus 1.0 核心协议及 creation、checksum、termination 扩展
+const (
+	tusVersion          = "1.0.0"
+	tusExtensions       = "creation,checksum,termination"
+	tusChunkContentType = "application/offset+octet-stream"
+)
+
+// CreateUploadSession 创建断点续传会话
+// @Summary 创建断点续传会话
+// @Description 使用上传令牌创建 tus 断点续传会话，Upload-Length 为文件总大小，文件名取自 Upload-Metadata 的 filename 或 filename 查询参数
+// @Tags Attachments
+// @Produce json
+// @Param token path string true "上传令牌"
+// @Param Upload-Length header int true "文件总大小"
+// @Param Upload-Metadata header string false "tus 元数据（filename 为 base64 编码的文件名）"
+// @Param filename query string false "文件名"
+// @Success 201 {object} Response{data=attachment.UploadSession}
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /api/v1/attachments/upload/{token}/sessions [post]
+func (h *AttachmentHandler) CreateUploadSession(c *gin.Context) {
+	setTusHeaders(c)
+	token := c.Param("token")
+
+	size, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || size <= 0 {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("Upload-Length must be a positive integer"))
+		return
+	}
+
+	filename := parseTusMetadata(c.GetHeader("Upload-Metadata"))["filename"]
+	if filename == "" {
+		filename = c.Query("filename")
+	}
+	if filename == "" {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("filename is required"))
+		return
+	}
+
+	session, err := h.attachmentService.CreateUploadSession(c.Request.Context(), token, filename, size)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Location", uploadSessionLocation(token, session.ID))
+	c.Header("Upload-Offset", "0")
+	c.JSON(http.StatusCreated, resp.APIResponse{Code: errors.CodeOK, Data: session})
+}
+
+// HeadUploadSession 查询断点续传进度
+// @Summary 查询断点续传进度
+// @Description 通过 Upload-Offset 和 Upload-Length 响应头返回已接收的字节数和总大小
+// @Tags Attachments
+// @Param token path string true "上传令牌"
+// @Param uploadId path string true "会话ID"
+// @Success 200
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/attachments/upload/{token}/sessions/{uploadId} [head]
+func (h *AttachmentHandler) HeadUploadSession(c *gin.Context) {
+	setTusHeaders(c)
+
+	session, err := h.attachmentService.GetUploadSession(c.Request.Context(), c.Param("token"), c.Param("uploadId"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Size, 10))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+}
+
+// PatchUploadSession 上传分片
+// @Summary 上传分片
+// @Description 在 Upload-Offset 处追加一个分片，可通过 Upload-Checksum（"<算法> <base64 摘要>"）校验分片；偏移量不一致返回 409，校验失败返回 460
+// @Tags Attachments
+// @Accept application/offset+octet-stream
+// @Param token path string true "上传令牌"
+// @Param uploadId path string true "会话ID"
+// @Param Upload-Offset header int true "分片起始偏移量"
+// @Param Upload-Checksum header string false "分片校验和"
+// @Success 204
+// @Failure 409 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Router /api/v1/attachments/upload/{token}/sessions/{uploadId} [patch]
+func (h *AttachmentHandler) PatchUploadSession(c *gin.Context) {
+	setTusHeaders(c)
+
+	if c.ContentType() != tusChunkContentType {
+		h.handleError(c, errors.ErrUnsupportedMediaType.WithDetails("Content-Type must be "+tusChunkContentType))
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		h.handleError(c, errors.ErrBadRequest.WithDetails("Upload-Offset must be a non-negative integer"))
+		return
+	}
+
+	var checksum *attachment.ChunkChecksum
+	if header := c.GetHeader("Upload-Checksum"); header != "" {
+		if checksum, err = attachment.ParseChunkChecksum(header); err != nil {
+			h.handleError(c, errors.ErrBadRequest.WithDetails(err.Error()))
+			return
+		}
+	}
+
+	session, err := h.attachmentService.UploadChunk(c.Request.Context(), c.Param("token"), c.Param("uploadId"),
+		offset, c.Request.Body, c.Request.ContentLength, checksum)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Status(http.StatusNoContent)
+}
+
+// FinalizeUploadSession 完成断点续传
+// @Summary 完成断点续传
+// @Description 所有字节上传完成后合并分片为最终文件，之后调用 notify 接口把附件写入单元格
+// @Tags Attachments
+// @Produce json
+// @Param token path string true "上传令牌"
+// @Param uploadId path string true "会话ID"
+// @Success 200 {object} Response{data=attachment.UploadSession}
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/attachments/upload/{token}/sessions/{uploadId}/finalize [post]
+func (h *AttachmentHandler) FinalizeUploadSession(c *gin.Context) {
+	setTusHeaders(c)
+
+	session, err := h.attachmentService.FinalizeUploadSession(c.Request.Context(), c.Param("token"), c.Param("uploadId"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	resp.SuccessWithMessage(c, session, "")
+}
+
+// AbortUploadSession 终止断点续传
+// @Summary 终止断点续传
+// @Description 删除会话及已上传的分片
+// @Tags Attachments
+// @Param token path string true "上传令牌"
+// @Param uploadId path string true "会话ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/attachments/upload/{token}/sessions/{uploadId} [delete]
+func (h *AttachmentHandler) AbortUploadSession(c *gin.Context) {
+	setTusHeaders(c)
+
+	if err := h.attachmentService.AbortUploadSession(c.Request.Context(), c.Param("token"), c.Param("uploadId")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// setTusHeaders 设置 tus 协议版本与能力声明
+func setTusHeaders(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	c.Header("Tus-Checksum-Algorithm", strings.Join(attachment.ChecksumAlgorithms(), ","))
+}
+
+// parseTusMetadata 解析 Upload-Metadata："key base64value,key2 base64value2"
+func parseTusMetadata(header string) map[string]string {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 {
+			continue
+		}
+		value := ""
+		if len(fields) > 1 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				continue
+			}
+			value = string(decoded)
+		}
+		metadata[fields[0]] = value
+	}
+	return metadata
+}
+
+func uploadSessionLocation(token, id string) string {
+	return fmt.Sprintf("/api/v1/attachments/upload/%s/sessions/%s", token, id)
+}
@@ -120,13 +120,11 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param request body RefreshTokenRequest true "刷新令牌请求"
+// @Param request body dto.RefreshTokenRequest true "刷新令牌请求"
 // @Success 200 {object} dto.TokenResponse
 // @Router /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	var req struct {
-		RefreshToken string `json:"refresh_token" binding:"required"`
-	}
+	var req dto.RefreshTokenRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
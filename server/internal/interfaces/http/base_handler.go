@@ -28,6 +28,18 @@ func NewBaseHandler(service *application.BaseService, tableService *application.
 // POST /api/v1/spaces/:spaceId/bases
 // ✅ 严格使用 response.Success
 // ✅ 严格使用 errors.ErrXxx
+// @Summary 创建Base
+// @Tags Base
+// @Accept json
+// @Produce json
+// @Param spaceId path string true "Space ID"
+// @Param request body dto.CreateBaseRequest true "创建Base请求"
+// @Success 200 {object} response.APIResponse{data=dto.BaseResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/spaces/{spaceId}/bases [post]
+// @Security BearerAuth
 func (h *BaseHandler) CreateBase(c *gin.Context) {
 	spaceID := c.Param("spaceId")
 
@@ -62,6 +74,15 @@ func (h *BaseHandler) CreateBase(c *gin.Context) {
 // GetBase 获取Base详情
 // GET /api/v1/bases/:baseId
 // ✅ 严格使用 response.Success
+// @Summary 获取Base
+// @Tags Base
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Success 200 {object} response.APIResponse{data=dto.BaseResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId} [get]
+// @Security BearerAuth
 func (h *BaseHandler) GetBase(c *gin.Context) {
 	baseID := c.Param("baseId")
 
@@ -79,6 +100,16 @@ func (h *BaseHandler) GetBase(c *gin.Context) {
 // ListBases 获取Base列表
 // GET /api/v1/spaces/:spaceId/bases
 // ✅ Base列表不分页，返回数组
+// @Summary 列出Base
+// @Description Base 列表不分页
+// @Tags Base
+// @Produce json
+// @Param spaceId path string true "Space ID"
+// @Success 200 {object} response.APIResponse{data=[]dto.BaseResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/spaces/{spaceId}/bases [get]
+// @Security BearerAuth
 func (h *BaseHandler) ListBases(c *gin.Context) {
 	spaceID := c.Param("spaceId")
 
@@ -96,6 +127,18 @@ func (h *BaseHandler) ListBases(c *gin.Context) {
 // UpdateBase 更新Base
 // PATCH /api/v1/bases/:baseId
 // ✅ 严格使用 response.Success
+// @Summary 更新Base
+// @Tags Base
+// @Accept json
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Param request body dto.UpdateBaseRequest true "更新Base请求"
+// @Success 200 {object} response.APIResponse{data=dto.BaseResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId} [patch]
+// @Security BearerAuth
 func (h *BaseHandler) UpdateBase(c *gin.Context) {
 	baseID := c.Param("baseId")
 
@@ -120,6 +163,15 @@ func (h *BaseHandler) UpdateBase(c *gin.Context) {
 // DeleteBase 删除Base
 // DELETE /api/v1/bases/:baseId
 // ✅ 严格使用 response.Success，data为nil
+// @Summary 删除Base
+// @Tags Base
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId} [delete]
+// @Security BearerAuth
 func (h *BaseHandler) DeleteBase(c *gin.Context) {
 	baseID := c.Param("baseId")
 
@@ -160,6 +212,17 @@ func (h *BaseHandler) GetBaseTables(c *gin.Context) {
 //  3. 复制Base下的所有Table（调用TableService）
 //  4. 复制每个Table下的Fields（调用FieldService）
 //  5. 可选：复制Records数据
+//
+// @Summary 复制Base
+// @Description 尚未实现，返回 501
+// @Tags Base
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Failure 501 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId}/duplicate [post]
+// @Security BearerAuth
 func (h *BaseHandler) DuplicateBase(c *gin.Context) {
 	baseID := c.Param("baseId")
 
@@ -236,6 +299,13 @@ func (h *BaseHandler) AddBaseCollaborator(c *gin.Context) {
 // GetBasePermission 获取当前用户对Base的权限
 // GET /api/v1/bases/:baseId/permission
 // ✅ 严格使用 response.Success
+// @Summary 获取当前用户对Base的权限
+// @Tags Base
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Success 200 {object} response.APIResponse{data=object}
+// @Router /api/v1/bases/{baseId}/permission [get]
+// @Security BearerAuth
 func (h *BaseHandler) GetBasePermission(c *gin.Context) {
 	baseID := c.Param("baseId")
 
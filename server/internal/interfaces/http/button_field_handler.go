@@ -0,0 +1,57 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// ButtonFieldHandler 按钮字段HTTP处理器 ✨
+type ButtonFieldHandler struct {
+	buttonService *application.ButtonService
+}
+
+// NewButtonFieldHandler 创建按钮字段处理器
+func NewButtonFieldHandler(buttonService *application.ButtonService) *ButtonFieldHandler {
+	return &ButtonFieldHandler{
+		buttonService: buttonService,
+	}
+}
+
+// Click 点击记录上的按钮 ✨
+// @Summary 点击按钮字段
+// @Description 在服务端执行按钮配置的动作（打开链接、触发工作流、调用 Webhook），并记录点击次数；配置了确认提示时需携带 confirmed=true
+// @Tags Record
+// @Accept json
+// @Produce json
+// @Param tableId path string true "表ID"
+// @Param recordId path string true "记录ID"
+// @Param fieldId path string true "按钮字段ID"
+// @Param request body dto.ButtonClickRequest false "点击参数"
+// @Success 200 {object} dto.ButtonClickResponse
+// @Router /api/v1/tables/{tableId}/records/{recordId}/buttons/{fieldId}/click [post]
+func (h *ButtonFieldHandler) Click(c *gin.Context) {
+	tableID := c.Param("tableId")
+	recordID := c.Param("recordId")
+	fieldID := c.Param("fieldId")
+	userID := c.GetString("user_id")
+
+	var req dto.ButtonClickRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+			return
+		}
+	}
+
+	data, err := h.buttonService.Click(c.Request.Context(), tableID, recordID, fieldID, req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "按钮点击已处理")
+}
@@ -22,6 +22,18 @@ func NewFieldHandler(fieldService *application.FieldService) *FieldHandler {
 }
 
 // CreateField 创建字段
+// @Summary 创建字段
+// @Tags Field
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.CreateFieldRequest true "创建字段请求"
+// @Success 200 {object} response.APIResponse{data=dto.FieldResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/fields [post]
+// @Security BearerAuth
 func (h *FieldHandler) CreateField(c *gin.Context) {
 	var req dto.CreateFieldRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -45,6 +57,15 @@ func (h *FieldHandler) CreateField(c *gin.Context) {
 }
 
 // GetField 获取字段详情
+// @Summary 获取字段
+// @Tags Field
+// @Produce json
+// @Param fieldId path string true "Field ID"
+// @Success 200 {object} response.APIResponse{data=dto.FieldResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/fields/{fieldId} [get]
+// @Security BearerAuth
 func (h *FieldHandler) GetField(c *gin.Context) {
 	fieldID := c.Param("fieldId")
 
@@ -58,6 +79,18 @@ func (h *FieldHandler) GetField(c *gin.Context) {
 }
 
 // UpdateField 更新字段
+// @Summary 更新字段
+// @Tags Field
+// @Accept json
+// @Produce json
+// @Param fieldId path string true "Field ID"
+// @Param request body dto.UpdateFieldRequest true "更新字段请求"
+// @Success 200 {object} response.APIResponse{data=dto.FieldResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/fields/{fieldId} [patch]
+// @Security BearerAuth
 func (h *FieldHandler) UpdateField(c *gin.Context) {
 	fieldID := c.Param("fieldId")
 
@@ -77,6 +110,15 @@ func (h *FieldHandler) UpdateField(c *gin.Context) {
 }
 
 // DeleteField 删除字段
+// @Summary 删除字段
+// @Tags Field
+// @Produce json
+// @Param fieldId path string true "Field ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/fields/{fieldId} [delete]
+// @Security BearerAuth
 func (h *FieldHandler) DeleteField(c *gin.Context) {
 	fieldID := c.Param("fieldId")
 
@@ -89,6 +131,15 @@ func (h *FieldHandler) DeleteField(c *gin.Context) {
 }
 
 // ListFields 列出表格的所有字段
+// @Summary 列出字段
+// @Tags Field
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse{data=[]dto.FieldResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/fields [get]
+// @Security BearerAuth
 func (h *FieldHandler) ListFields(c *gin.Context) {
 	tableID := c.Param("tableId")
 
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// GraphQLHandler Base 的 GraphQL 接口处理器 ✨
+//
+// 查询结果按 GraphQL 规范返回 {data, errors}，不使用统一响应结构；
+// 无法执行的请求（参数错误、无权访问 Base）仍返回统一错误响应
+type GraphQLHandler struct {
+	service *application.GraphQLService
+}
+
+// NewGraphQLHandler 创建 GraphQL 处理器
+func NewGraphQLHandler(service *application.GraphQLService) *GraphQLHandler {
+	return &GraphQLHandler{service: service}
+}
+
+// Execute 执行 GraphQL 请求
+// @Summary 执行 GraphQL 请求
+// @Description Schema 按 Base 的表和字段生成：每张表提供分页查询（过滤、排序、游标分页）、按 ID 查询以及创建、更新、删除记录；关联字段为嵌套对象，选择字段为枚举。响应为 GraphQL 格式 {data, errors}
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Param request body dto.GraphQLRequest true "GraphQL 请求"
+// @Success 200 {object} object "GraphQL 响应 {data, errors, extensions}"
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId}/graphql [post]
+// @Security BearerAuth
+func (h *GraphQLHandler) Execute(c *gin.Context) {
+	var req dto.GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	h.execute(c, req)
+}
+
+// ExecuteQuery 通过 GET 执行 GraphQL 查询
+// @Summary 通过 GET 执行 GraphQL 查询
+// @Description 只允许查询操作，变更需要使用 POST；variables 为 JSON 字符串
+// @Tags GraphQL
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Param query query string true "GraphQL 查询"
+// @Param operationName query string false "操作名"
+// @Param variables query string false "变量（JSON）"
+// @Success 200 {object} object "GraphQL 响应 {data, errors, extensions}"
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId}/graphql [get]
+// @Security BearerAuth
+func (h *GraphQLHandler) ExecuteQuery(c *gin.Context) {
+	var req dto.GraphQLRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+	if variables := c.Query("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+			response.Error(c, errors.ErrBadRequest.WithDetails("variables 不是有效的 JSON 对象"))
+			return
+		}
+	}
+	req.ReadOnly = true
+
+	h.execute(c, req)
+}
+
+func (h *GraphQLHandler) execute(c *gin.Context, req dto.GraphQLRequest) {
+	result, err := h.service.Execute(c.Request.Context(), c.Param("baseId"), c.GetString("user_id"), req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSchema 获取 GraphQL Schema
+// @Summary 获取 Base 的 GraphQL Schema
+// @Description 返回 SDL 文本，表或字段变更后自动更新
+// @Tags GraphQL
+// @Produce plain
+// @Param baseId path string true "Base ID"
+// @Success 200 {string} string "GraphQL SDL"
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId}/graphql/schema [get]
+// @Security BearerAuth
+func (h *GraphQLHandler) GetSchema(c *gin.Context) {
+	sdl, err := h.service.SDL(c.Request.Context(), c.Param("baseId"), c.GetString("user_id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(sdl))
+}
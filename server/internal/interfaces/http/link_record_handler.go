@@ -0,0 +1,51 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// LinkRecordHandler Link 字段关联记录HTTP处理器 ✨
+type LinkRecordHandler struct {
+	linkRecordService *application.LinkRecordService
+}
+
+// NewLinkRecordHandler 创建 Link 字段关联记录处理器
+func NewLinkRecordHandler(linkRecordService *application.LinkRecordService) *LinkRecordHandler {
+	return &LinkRecordHandler{
+		linkRecordService: linkRecordService,
+	}
+}
+
+// ListLinkCandidates 获取 Link 字段可关联的记录 ✨
+// @Summary 获取 Link 字段可关联的记录
+// @Description 应用 Link 字段的限定视图和过滤条件，按关联表标题字段搜索，只返回可见字段
+// @Tags Field
+// @Produce json
+// @Param fieldId path string true "Link 字段ID"
+// @Param search query string false "按标题字段模糊搜索"
+// @Param offset query int false "偏移量"
+// @Param limit query int false "每页数量（默认50，最多500）"
+// @Success 200 {object} dto.LinkCandidateListResponse
+// @Router /api/v1/fields/{fieldId}/link-candidates [get]
+func (h *LinkRecordHandler) ListLinkCandidates(c *gin.Context) {
+	fieldID := c.Param("fieldId")
+
+	var query dto.LinkCandidateQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	data, err := h.linkRecordService.ListCandidates(c.Request.Context(), fieldID, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取可关联记录成功")
+}
@@ -55,6 +55,18 @@ func JWTAuthMiddleware(authService *application.AuthService) gin.HandlerFunc {
 	}
 }
 
+// AdminRequiredMiddleware 仅允许管理员访问（需在 JWTAuthMiddleware 之后使用）
+func AdminRequiredMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("is_admin") {
+			response.Error(c, errors.ErrForbidden.WithDetails("需要管理员权限"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 // ValidateBindJSON 统一的JSON绑定和验证辅助函数
 // 用于替代直接调用 ShouldBindJSON，提供更详细的错误信息
 func ValidateBindJSON(c *gin.Context, obj interface{}) error {
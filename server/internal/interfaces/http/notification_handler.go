@@ -38,7 +38,7 @@ func NewNotificationHandler(service notification.Service, logger *zap.Logger) *N
 // @Success 200 {object} response.Response{data=notification.Notification} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications [post]
+// @Router /api/v1/notifications [post]
 func (h *NotificationHandler) CreateNotification(c *gin.Context) {
 	var req notification.CreateNotificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -70,7 +70,7 @@ func (h *NotificationHandler) CreateNotification(c *gin.Context) {
 // @Success 200 {object} response.Response{data=notification.Notification} "获取成功"
 // @Failure 404 {object} response.Response "通知不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/{id} [get]
+// @Router /api/v1/notifications/{id} [get]
 func (h *NotificationHandler) GetNotification(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -78,12 +78,8 @@ func (h *NotificationHandler) GetNotification(c *gin.Context) {
 		return
 	}
 
-	notification, err := h.service.GetNotification(c.Request.Context(), id)
-	if err != nil {
-		h.logger.Error("Failed to get notification",
-			zap.String("id", id),
-			zap.Error(err))
-		response.Error(c, err)
+	notification, ok := h.ownNotification(c, id)
+	if !ok {
 		return
 	}
 
@@ -102,7 +98,7 @@ func (h *NotificationHandler) GetNotification(c *gin.Context) {
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 404 {object} response.Response "通知不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/{id} [put]
+// @Router /api/v1/notifications/{id} [put]
 func (h *NotificationHandler) UpdateNotification(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -110,6 +106,10 @@ func (h *NotificationHandler) UpdateNotification(c *gin.Context) {
 		return
 	}
 
+	if _, ok := h.ownNotification(c, id); !ok {
+		return
+	}
+
 	var req notification.UpdateNotificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Failed to bind update notification request", zap.Error(err))
@@ -139,7 +139,7 @@ func (h *NotificationHandler) UpdateNotification(c *gin.Context) {
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 404 {object} response.Response "通知不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/{id} [delete]
+// @Router /api/v1/notifications/{id} [delete]
 func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -147,6 +147,10 @@ func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
 		return
 	}
 
+	if _, ok := h.ownNotification(c, id); !ok {
+		return
+	}
+
 	err := h.service.DeleteNotification(c.Request.Context(), id)
 	if err != nil {
 		h.logger.Error("Failed to delete notification",
@@ -161,11 +165,10 @@ func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
 
 // ListNotifications 列出通知
 // @Summary 列出通知
-// @Description 获取用户的通知列表
+// @Description 获取当前用户的通知列表
 // @Tags 通知管理
 // @Accept json
 // @Produce json
-// @Param user_id query string true "用户ID"
 // @Param type query string false "通知类型"
 // @Param status query string false "通知状态"
 // @Param priority query string false "通知优先级"
@@ -178,13 +181,9 @@ func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
 // @Success 200 {object} response.Response{data=notification.ListNotificationsResponse} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications [get]
+// @Router /api/v1/notifications [get]
 func (h *NotificationHandler) ListNotifications(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		response.Error(c, errors.ErrBadRequest.WithDetails("用户ID不能为空"))
-		return
-	}
+	userID := c.GetString("user_id")
 
 	req := &notification.ListNotificationsRequest{
 		UserID: userID,
@@ -246,7 +245,7 @@ func (h *NotificationHandler) ListNotifications(c *gin.Context) {
 // @Success 200 {object} response.Response "标记成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/mark-read [post]
+// @Router /api/v1/notifications/mark-read [post]
 func (h *NotificationHandler) MarkNotificationsRead(c *gin.Context) {
 	var req notification.MarkNotificationsReadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -255,6 +254,12 @@ func (h *NotificationHandler) MarkNotificationsRead(c *gin.Context) {
 		return
 	}
 
+	for _, id := range req.NotificationIDs {
+		if _, ok := h.ownNotification(c, id); !ok {
+			return
+		}
+	}
+
 	err := h.service.MarkNotificationsRead(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to mark notifications as read",
@@ -269,21 +274,15 @@ func (h *NotificationHandler) MarkNotificationsRead(c *gin.Context) {
 
 // MarkAllNotificationsRead 标记所有通知为已读
 // @Summary 标记所有通知为已读
-// @Description 标记用户的所有通知为已读状态
+// @Description 标记当前用户的所有通知为已读状态
 // @Tags 通知管理
 // @Accept json
 // @Produce json
-// @Param user_id path string true "用户ID"
 // @Success 200 {object} response.Response "标记成功"
-// @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/user/{user_id}/mark-all-read [post]
+// @Router /api/v1/notifications/mark-all-read [post]
 func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
-	userID := c.Param("user_id")
-	if userID == "" {
-		response.Error(c, errors.ErrBadRequest.WithDetails("用户ID不能为空"))
-		return
-	}
+	userID := c.GetString("user_id")
 
 	err := h.service.MarkAllNotificationsRead(c.Request.Context(), userID)
 	if err != nil {
@@ -299,21 +298,15 @@ func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
 
 // GetNotificationStats 获取通知统计
 // @Summary 获取通知统计
-// @Description 获取用户的通知统计信息
+// @Description 获取当前用户的通知统计信息
 // @Tags 通知管理
 // @Accept json
 // @Produce json
-// @Param user_id path string true "用户ID"
 // @Success 200 {object} response.Response{data=notification.NotificationStats} "获取成功"
-// @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/user/{user_id}/stats [get]
+// @Router /api/v1/notifications/stats [get]
 func (h *NotificationHandler) GetNotificationStats(c *gin.Context) {
-	userID := c.Param("user_id")
-	if userID == "" {
-		response.Error(c, errors.ErrBadRequest.WithDetails("用户ID不能为空"))
-		return
-	}
+	userID := c.GetString("user_id")
 
 	stats, err := h.service.GetNotificationStats(c.Request.Context(), userID)
 	if err != nil {
@@ -337,7 +330,7 @@ func (h *NotificationHandler) GetNotificationStats(c *gin.Context) {
 // @Success 200 {object} response.Response "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-templates [post]
+// @Router /api/v1/notification-templates [post]
 func (h *NotificationHandler) CreateTemplate(c *gin.Context) {
 	var template notification.NotificationTemplate
 	if err := c.ShouldBindJSON(&template); err != nil {
@@ -368,7 +361,7 @@ func (h *NotificationHandler) CreateTemplate(c *gin.Context) {
 // @Success 200 {object} response.Response{data=notification.NotificationTemplate} "获取成功"
 // @Failure 404 {object} response.Response "模板不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-templates/{id} [get]
+// @Router /api/v1/notification-templates/{id} [get]
 func (h *NotificationHandler) GetTemplate(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -398,7 +391,7 @@ func (h *NotificationHandler) GetTemplate(c *gin.Context) {
 // @Success 200 {object} response.Response{data=notification.NotificationTemplate} "获取成功"
 // @Failure 404 {object} response.Response "模板不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-templates/type/{type} [get]
+// @Router /api/v1/notification-templates/type/{type} [get]
 func (h *NotificationHandler) GetTemplateByType(c *gin.Context) {
 	notificationType := notification.NotificationType(c.Param("type"))
 	if notificationType == "" {
@@ -430,7 +423,7 @@ func (h *NotificationHandler) GetTemplateByType(c *gin.Context) {
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 404 {object} response.Response "模板不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-templates/{id} [put]
+// @Router /api/v1/notification-templates/{id} [put]
 func (h *NotificationHandler) UpdateTemplate(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -469,7 +462,7 @@ func (h *NotificationHandler) UpdateTemplate(c *gin.Context) {
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 404 {object} response.Response "模板不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-templates/{id} [delete]
+// @Router /api/v1/notification-templates/{id} [delete]
 func (h *NotificationHandler) DeleteTemplate(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -499,7 +492,7 @@ func (h *NotificationHandler) DeleteTemplate(c *gin.Context) {
 // @Param is_active query bool false "是否激活"
 // @Success 200 {object} response.Response{data=[]notification.NotificationTemplate} "获取成功"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-templates [get]
+// @Router /api/v1/notification-templates [get]
 func (h *NotificationHandler) ListTemplates(c *gin.Context) {
 	var notificationType *notification.NotificationType
 	var isActive *bool
@@ -535,7 +528,7 @@ func (h *NotificationHandler) ListTemplates(c *gin.Context) {
 // @Success 200 {object} response.Response{data=notification.NotificationSubscription} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-subscriptions [post]
+// @Router /api/v1/notification-subscriptions [post]
 func (h *NotificationHandler) CreateSubscription(c *gin.Context) {
 	var req notification.CreateSubscriptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -543,6 +536,7 @@ func (h *NotificationHandler) CreateSubscription(c *gin.Context) {
 		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
 		return
 	}
+	req.UserID = c.GetString("user_id") // 只能为自己创建订阅
 
 	subscription, err := h.service.CreateSubscription(c.Request.Context(), &req)
 	if err != nil {
@@ -567,7 +561,7 @@ func (h *NotificationHandler) CreateSubscription(c *gin.Context) {
 // @Success 200 {object} response.Response{data=notification.NotificationSubscription} "获取成功"
 // @Failure 404 {object} response.Response "订阅不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-subscriptions/{id} [get]
+// @Router /api/v1/notification-subscriptions/{id} [get]
 func (h *NotificationHandler) GetSubscription(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -575,12 +569,8 @@ func (h *NotificationHandler) GetSubscription(c *gin.Context) {
 		return
 	}
 
-	subscription, err := h.service.GetSubscription(c.Request.Context(), id)
-	if err != nil {
-		h.logger.Error("Failed to get notification subscription",
-			zap.String("id", id),
-			zap.Error(err))
-		response.Error(c, err)
+	subscription, ok := h.ownSubscription(c, id)
+	if !ok {
 		return
 	}
 
@@ -589,22 +579,16 @@ func (h *NotificationHandler) GetSubscription(c *gin.Context) {
 
 // GetUserSubscriptions 获取用户订阅
 // @Summary 获取用户订阅
-// @Description 获取用户的通知订阅列表
+// @Description 获取当前用户的通知订阅列表
 // @Tags 通知订阅管理
 // @Accept json
 // @Produce json
-// @Param user_id path string true "用户ID"
 // @Param type query string false "通知类型"
 // @Success 200 {object} response.Response{data=[]notification.NotificationSubscription} "获取成功"
-// @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-subscriptions/user/{user_id} [get]
+// @Router /api/v1/notification-subscriptions [get]
 func (h *NotificationHandler) GetUserSubscriptions(c *gin.Context) {
-	userID := c.Param("user_id")
-	if userID == "" {
-		response.Error(c, errors.ErrBadRequest.WithDetails("用户ID不能为空"))
-		return
-	}
+	userID := c.GetString("user_id")
 
 	var notificationType *notification.NotificationType
 	if typeStr := c.Query("type"); typeStr != "" {
@@ -636,7 +620,7 @@ func (h *NotificationHandler) GetUserSubscriptions(c *gin.Context) {
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 404 {object} response.Response "订阅不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-subscriptions/{id} [put]
+// @Router /api/v1/notification-subscriptions/{id} [put]
 func (h *NotificationHandler) UpdateSubscription(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -644,6 +628,10 @@ func (h *NotificationHandler) UpdateSubscription(c *gin.Context) {
 		return
 	}
 
+	if _, ok := h.ownSubscription(c, id); !ok {
+		return
+	}
+
 	var req notification.UpdateSubscriptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Failed to bind update subscription request", zap.Error(err))
@@ -673,7 +661,7 @@ func (h *NotificationHandler) UpdateSubscription(c *gin.Context) {
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 404 {object} response.Response "订阅不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-subscriptions/{id} [delete]
+// @Router /api/v1/notification-subscriptions/{id} [delete]
 func (h *NotificationHandler) DeleteSubscription(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -681,6 +669,10 @@ func (h *NotificationHandler) DeleteSubscription(c *gin.Context) {
 		return
 	}
 
+	if _, ok := h.ownSubscription(c, id); !ok {
+		return
+	}
+
 	err := h.service.DeleteSubscription(c.Request.Context(), id)
 	if err != nil {
 		h.logger.Error("Failed to delete notification subscription",
@@ -695,22 +687,16 @@ func (h *NotificationHandler) DeleteSubscription(c *gin.Context) {
 
 // DeleteUserSubscriptions 删除用户订阅
 // @Summary 删除用户订阅
-// @Description 删除用户的所有或指定类型的通知订阅
+// @Description 删除当前用户的所有或指定类型的通知订阅
 // @Tags 通知订阅管理
 // @Accept json
 // @Produce json
-// @Param user_id path string true "用户ID"
 // @Param type query string false "通知类型"
 // @Success 200 {object} response.Response "删除成功"
-// @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notification-subscriptions/user/{user_id} [delete]
+// @Router /api/v1/notification-subscriptions [delete]
 func (h *NotificationHandler) DeleteUserSubscriptions(c *gin.Context) {
-	userID := c.Param("user_id")
-	if userID == "" {
-		response.Error(c, errors.ErrBadRequest.WithDetails("用户ID不能为空"))
-		return
-	}
+	userID := c.GetString("user_id")
 
 	var notificationType *notification.NotificationType
 	if typeStr := c.Query("type"); typeStr != "" {
@@ -740,7 +726,7 @@ func (h *NotificationHandler) DeleteUserSubscriptions(c *gin.Context) {
 // @Success 200 {object} response.Response "发送成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/send [post]
+// @Router /api/v1/notifications/send [post]
 func (h *NotificationHandler) SendNotification(c *gin.Context) {
 	var req notification.CreateNotificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -772,7 +758,7 @@ func (h *NotificationHandler) SendNotification(c *gin.Context) {
 // @Success 200 {object} response.Response "发送成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/send-to-subscribers [post]
+// @Router /api/v1/notifications/send-to-subscribers [post]
 func (h *NotificationHandler) SendNotificationToSubscribers(c *gin.Context) {
 	var req SendNotificationToSubscribersRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -811,7 +797,7 @@ func (h *NotificationHandler) SendNotificationToSubscribers(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} response.Response "清理成功"
 // @Failure 500 {object} response.Response "服务器内部错误"
-// @Router /api/notifications/cleanup [post]
+// @Router /api/v1/notifications/cleanup [post]
 func (h *NotificationHandler) CleanupExpiredNotifications(c *gin.Context) {
 	err := h.service.CleanupExpiredNotifications(c.Request.Context())
 	if err != nil {
@@ -823,6 +809,87 @@ func (h *NotificationHandler) CleanupExpiredNotifications(c *gin.Context) {
 	response.SuccessWithMessage(c, nil, "过期通知清理成功")
 }
 
+// GetPreference 获取通知偏好
+// @Summary 获取通知偏好
+// @Description 获取当前用户的通知渠道、免打扰时段和摘要设置，未设置时返回默认值
+// @Tags 通知偏好
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=notification.Preference} "获取成功"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/notifications/preferences [get]
+func (h *NotificationHandler) GetPreference(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	preference, err := h.service.GetPreference(c.Request.Context(), userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, preference, "获取通知偏好成功")
+}
+
+// UpdatePreference 更新通知偏好
+// @Summary 更新通知偏好
+// @Description 更新当前用户的通知偏好，未提供的字段保持不变
+// @Tags 通知偏好
+// @Accept json
+// @Produce json
+// @Param request body notification.UpdatePreferenceRequest true "更新通知偏好请求"
+// @Success 200 {object} response.Response{data=notification.Preference} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /api/v1/notifications/preferences [patch]
+func (h *NotificationHandler) UpdatePreference(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req notification.UpdatePreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	preference, err := h.service.UpdatePreference(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.logger.Error("Failed to update notification preference",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		response.Error(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, preference, "通知偏好更新成功")
+}
+
+// ownNotification 加载当前用户的通知；不存在或属于其他用户时写入 404 并返回 false
+func (h *NotificationHandler) ownNotification(c *gin.Context, id string) (*notification.Notification, bool) {
+	item, err := h.service.GetNotification(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return nil, false
+	}
+	if item.UserID != c.GetString("user_id") {
+		response.Error(c, errors.ErrNotFound.WithDetails("通知不存在"))
+		return nil, false
+	}
+	return item, true
+}
+
+// ownSubscription 加载当前用户的订阅；不存在或属于其他用户时写入 404 并返回 false
+func (h *NotificationHandler) ownSubscription(c *gin.Context, id string) (*notification.NotificationSubscription, bool) {
+	subscription, err := h.service.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return nil, false
+	}
+	if subscription.UserID != c.GetString("user_id") {
+		response.Error(c, errors.ErrNotFound.WithDetails("订阅不存在"))
+		return nil, false
+	}
+	return subscription, true
+}
+
 // SendNotificationToSubscribersRequest 向订阅者发送通知请求
 type SendNotificationToSubscribersRequest struct {
 	Type       notification.NotificationType `json:"type" binding:"required"`
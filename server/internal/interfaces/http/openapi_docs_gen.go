@@ -0,0 +1,3001 @@
+// Code generated by openapi-gen. DO NOT EDIT.
+
+package http
+
+import (
+	dto "github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	ai "github.com/easyspace-ai/luckdb/server/internal/domain/ai"
+	attachment "github.com/easyspace-ai/luckdb/server/internal/domain/attachment"
+	notification "github.com/easyspace-ai/luckdb/server/internal/domain/notification"
+	permission "github.com/easyspace-ai/luckdb/server/internal/domain/permission"
+	search "github.com/easyspace-ai/luckdb/server/internal/domain/search"
+	share "github.com/easyspace-ai/luckdb/server/internal/domain/share"
+	websocket "github.com/easyspace-ai/luckdb/server/internal/domain/websocket"
+	openapi "github.com/easyspace-ai/luckdb/server/pkg/openapi"
+	response "github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// routeDocs 处理器接口说明（Receiver.Method -> 文档）
+var routeDocs = map[string]*openapi.RouteDoc{
+	"AIFieldHandler.GenerateField": {
+		Summary:     "重新生成 AI 字段的值",
+		Description: "按提示词模板为可编辑的记录生成 AI 字段值，force 为 false 时只生成空值；\n不超过 100 条的指定记录同步生成，整张表或更多记录提交为后台任务，按任务 ID 查询结果",
+		Tags:        []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "fieldId", In: "path", Description: "AI 字段ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: false, Description: "生成范围", Type: openapi.TypeOf[dto.AIGenerateRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.AIGenerateJob]()},
+		},
+	},
+	"AIFieldHandler.GetBaseUsage": {
+		Summary:     "获取 Base 的 AI token 用量",
+		Description: "按日期、提供者、模型汇总 AI 字段生成消耗的 token，默认最近 30 天",
+		Tags:        []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "from", In: "query", Description: "开始日期（YYYY-MM-DD）", Type: openapi.TypeOf[string]()},
+			{Name: "to", In: "query", Description: "结束日期（YYYY-MM-DD）", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[ai.UsageSummary]()},
+		},
+	},
+	"AIFieldHandler.GetGenerateJob": {
+		Summary: "查询 AI 字段的后台生成任务",
+		Tags:    []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "fieldId", In: "path", Description: "AI 字段ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "jobId", In: "path", Description: "任务ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.AIGenerateJob]()},
+		},
+	},
+	"AlertHandler.CreateAlertRule": {
+		Summary:     "创建告警规则",
+		Description: "指标满足条件并持续 forSeconds 秒后触发，通过指定渠道通知；恢复时再通知一次",
+		Tags:        []string{"告警"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "创建告警规则请求", Type: openapi.TypeOf[dto.CreateAlertRuleRequest]()},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.AlertRuleResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.CreateAlertSilence": {
+		Summary:     "创建告警静默",
+		Description: "窗口内匹配的告警照常记录但不发送通知；窗口结束后仍在触发的告警会补发通知",
+		Tags:        []string{"告警"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "创建静默请求", Type: openapi.TypeOf[dto.CreateAlertSilenceRequest]()},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.AlertSilenceResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.DeleteAlertRule": {
+		Summary:     "删除告警规则",
+		Description: "告警历史保留；正在触发的告警在下一个评估周期结束（不发送恢复通知）",
+		Tags:        []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.DeleteAlertSilence": {
+		Summary: "删除告警静默",
+		Tags:    []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "silenceId", In: "path", Description: "Silence ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.GetAlertRule": {
+		Summary: "获取告警规则",
+		Tags:    []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.AlertRuleResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.ListAlertChannels": {
+		Summary:     "列出告警通知渠道",
+		Description: "返回配置文件中已启用的通知渠道名称，可用于告警规则的 channels",
+		Tags:        []string{"告警"},
+		Produces:    []string{"application/json"},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]string]()},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.ListAlertRules": {
+		Summary:  "列出告警规则",
+		Tags:     []string{"告警"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.AlertRuleResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.ListAlertSilences": {
+		Summary: "列出告警静默",
+		Tags:    []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "includeExpired", In: "query", Description: "是否包含已结束的静默", Type: openapi.TypeOf[bool]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.AlertSilenceResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.ListAlerts": {
+		Summary:     "查询告警历史",
+		Description: "每次触发一条记录，包含触发、恢复时间和通知情况",
+		Tags:        []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "ruleId", In: "query", Description: "Rule ID", Type: openapi.TypeOf[string]()},
+			{Name: "status", In: "query", Description: "状态：pending, firing, resolved", Type: openapi.TypeOf[string]()},
+			{Name: "limit", In: "query", Description: "每页数量（默认 50，最大 200）", Type: openapi.TypeOf[int]()},
+			{Name: "offset", In: "query", Description: "偏移量", Type: openapi.TypeOf[int]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.AlertListResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.TestAlertRule": {
+		Summary:     "发送测试告警",
+		Description: "通过规则的通知渠道立即发送一条测试告警，返回每个渠道的发送结果",
+		Tags:        []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.AlertTestResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"AlertHandler.UpdateAlertRule": {
+		Summary: "更新告警规则",
+		Tags:    []string{"告警"},
+		Params: []openapi.ParamDoc{
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新告警规则请求", Type: openapi.TypeOf[dto.UpdateAlertRuleRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.AlertRuleResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"AttachmentHandler.AbortUploadSession": {
+		Summary:     "终止断点续传",
+		Description: "删除会话及已上传的分片",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "uploadId", In: "path", Description: "会话ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Responses: []openapi.ResponseDoc{
+			{Status: 204},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.CleanupExpiredTokens": {
+		Summary:     "清理过期令牌",
+		Description: "清理过期的上传令牌（需要管理员权限，后台任务每小时也会执行）",
+		Tags:        []string{"Attachments"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.CollectOrphanBlobs": {
+		Summary:     "回收无引用的文件内容",
+		Description: "删除超过宽限期且没有附件引用的去重存储对象（需要管理员权限）",
+		Tags:        []string{"Attachments"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.GCResult]()},
+			{Status: 403, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.CreateUploadSession": {
+		Summary:     "创建断点续传会话",
+		Description: "使用上传令牌创建 tus 断点续传会话，Upload-Length 为文件总大小，文件名取自 Upload-Metadata 的 filename 或 filename 查询参数",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "Upload-Length", In: "header", Description: "文件总大小", Required: true, Type: openapi.TypeOf[int]()},
+			{Name: "Upload-Metadata", In: "header", Description: "tus 元数据（filename 为 base64 编码的文件名）", Type: openapi.TypeOf[string]()},
+			{Name: "filename", In: "query", Description: "文件名", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 201, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.UploadSession]()},
+			{Status: 400, Kind: "object"},
+			{Status: 413, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.DeleteFile": {
+		Summary:     "删除文件",
+		Description: "删除指定的附件文件（仍被单元格引用时返回 409）",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "附件ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+			{Status: 409, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.FinalizeUploadSession": {
+		Summary:     "完成断点续传",
+		Description: "所有字节上传完成后合并分片为最终文件，之后调用 notify 接口把附件写入单元格",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "uploadId", In: "path", Description: "会话ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.UploadSession]()},
+			{Status: 409, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.GenerateSignature": {
+		Summary:     "生成上传签名",
+		Description: "为文件上传生成签名令牌",
+		Tags:        []string{"Attachments"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "签名请求", Type: openapi.TypeOf[attachment.SignatureRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.SignatureResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.GetAttachment": {
+		Summary:     "获取附件信息",
+		Description: "获取指定附件的详细信息",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "附件ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.AttachmentItem]()},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.GetAttachmentStats": {
+		Summary:     "获取附件统计",
+		Description: "获取指定表格的附件统计信息",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.AttachmentStats]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.GetSpaceDedupReport": {
+		Summary:     "获取空间附件去重报告",
+		Description: "获取空间内附件的逻辑大小、实际占用存储、节省的空间以及节省最多的重复文件",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "空间ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.DedupReport]()},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.GetSpaceStorageUsage": {
+		Summary:     "获取空间存储用量",
+		Description: "获取空间内附件占用的存储空间、文件数和配额",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "空间ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.SpaceUsage]()},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.HeadUploadSession": {
+		Summary:     "查询断点续传进度",
+		Description: "通过 Upload-Offset 和 Upload-Length 响应头返回已接收的字节数和总大小",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "uploadId", In: "path", Description: "会话ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.InstantUpload": {
+		Summary:     "秒传",
+		Description: "空间内已有相同内容（SHA-256 与大小一致）的文件时直接创建附件并追加到单元格，无需上传文件；未命中返回 404，改为正常上传",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "文件名、SHA-256 与大小", Type: openapi.TypeOf[attachment.InstantUploadRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.NotifyResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.ListAttachments": {
+		Summary:     "列出附件",
+		Description: "列出指定条件下的附件",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "table_id", In: "query", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "field_id", In: "query", Description: "字段ID", Type: openapi.TypeOf[string]()},
+			{Name: "record_id", In: "query", Description: "记录ID", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]attachment.AttachmentItem]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.NotifyUpload": {
+		Summary:     "通知上传完成",
+		Description: "通知服务器文件上传完成，附件会追加到签名时指定的单元格",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "filename", In: "query", Description: "文件名（与上传时一致）", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[attachment.NotifyResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.PatchUploadSession": {
+		Summary:     "上传分片",
+		Description: "在 Upload-Offset 处追加一个分片，可通过 Upload-Checksum（\"<算法> <base64 摘要>\"）校验分片；偏移量不一致返回 409，校验失败返回 460",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "uploadId", In: "path", Description: "会话ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "Upload-Offset", In: "header", Description: "分片起始偏移量", Required: true, Type: openapi.TypeOf[int]()},
+			{Name: "Upload-Checksum", In: "header", Description: "分片校验和", Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/offset+octet-stream"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 204},
+			{Status: 409, Kind: "object"},
+			{Status: 415, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.ReadFile": {
+		Summary:     "读取文件",
+		Description: "通过路径流式读取文件内容，支持 Range 分段请求",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "path", In: "path", Description: "文件路径", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "token", In: "query", Description: "访问令牌", Type: openapi.TypeOf[string]()},
+			{Name: "response-content-disposition", In: "query", Description: "响应内容配置", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/octet-stream"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "file"},
+			{Status: 206, Kind: "file"},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"AttachmentHandler.UploadFile": {
+		Summary:     "上传文件",
+		Description: "使用令牌上传文件",
+		Tags:        []string{"Attachments"},
+		Params: []openapi.ParamDoc{
+			{Name: "token", In: "path", Description: "上传令牌", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "file", In: "formData", Description: "文件", Required: true},
+		},
+		Consumes: []string{"multipart/form-data"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"AuthHandler.GetCurrentUser": {
+		Summary:  "获取当前用户信息",
+		Tags:     []string{"Auth"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.TokenClaims]()},
+		},
+	},
+	"AuthHandler.Login": {
+		Summary:  "用户登录",
+		Tags:     []string{"Auth"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "登录请求", Type: openapi.TypeOf[dto.LoginRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.LoginResponse]()},
+		},
+	},
+	"AuthHandler.Logout": {
+		Summary:  "用户登出",
+		Tags:     []string{"Auth"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"AuthHandler.RefreshToken": {
+		Summary:  "刷新令牌",
+		Tags:     []string{"Auth"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "刷新令牌请求", Type: openapi.TypeOf[dto.RefreshTokenRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.TokenResponse]()},
+		},
+	},
+	"AuthHandler.Register": {
+		Summary:  "用户注册",
+		Tags:     []string{"Auth"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "注册请求", Type: openapi.TypeOf[dto.RegisterRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.LoginResponse]()},
+		},
+	},
+	"BaseHandler.CreateBase": {
+		Summary: "创建Base",
+		Tags:    []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建Base请求", Type: openapi.TypeOf[dto.CreateBaseRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.BaseResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"BaseHandler.DeleteBase": {
+		Summary: "删除Base",
+		Tags:    []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"BaseHandler.DuplicateBase": {
+		Summary:     "复制Base",
+		Description: "尚未实现，返回 501",
+		Tags:        []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 501, Kind: "object", Envelope: true},
+		},
+	},
+	"BaseHandler.GetBase": {
+		Summary: "获取Base",
+		Tags:    []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.BaseResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"BaseHandler.GetBasePermission": {
+		Summary: "获取当前用户对Base的权限",
+		Tags:    []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"BaseHandler.ListBases": {
+		Summary:     "列出Base",
+		Description: "Base 列表不分页",
+		Tags:        []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.BaseResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"BaseHandler.UpdateBase": {
+		Summary: "更新Base",
+		Tags:    []string{"Base"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新Base请求", Type: openapi.TypeOf[dto.UpdateBaseRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.BaseResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"ButtonFieldHandler.Click": {
+		Summary:     "点击按钮字段",
+		Description: "在服务端执行按钮配置的动作（打开链接、触发工作流、调用 Webhook），并记录点击次数；配置了确认提示时需携带 confirmed=true",
+		Tags:        []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "表ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "recordId", In: "path", Description: "记录ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "fieldId", In: "path", Description: "按钮字段ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: false, Description: "点击参数", Type: openapi.TypeOf[dto.ButtonClickRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ButtonClickResponse]()},
+		},
+	},
+	"CollaborationHandler.GetCollaborationStats": {
+		Summary:     "获取协作统计信息",
+		Description: "获取实时协作的统计信息",
+		Tags:        []string{"协作"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.GetCursors": {
+		Summary:     "获取光标信息",
+		Description: "获取指定集合中的所有用户光标信息",
+		Tags:        []string{"协作"},
+		Params: []openapi.ParamDoc{
+			{Name: "collection", In: "query", Description: "集合名称", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]websocket.CursorInfo]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.GetPresence": {
+		Summary:     "获取在线状态",
+		Description: "获取指定集合中的在线用户状态",
+		Tags:        []string{"协作"},
+		Params: []openapi.ParamDoc{
+			{Name: "collection", In: "query", Description: "集合名称", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]websocket.PresenceInfo]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.RemoveCursor": {
+		Summary:     "移除用户光标",
+		Description: "移除用户在指定文档中的光标",
+		Tags:        []string{"协作"},
+		Params: []openapi.ParamDoc{
+			{Name: "collection", In: "query", Description: "集合名称", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "document", In: "query", Description: "文档ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.RemovePresence": {
+		Summary:     "移除用户在线状态",
+		Description: "移除用户在指定集合中的在线状态",
+		Tags:        []string{"协作"},
+		Params: []openapi.ParamDoc{
+			{Name: "collection", In: "query", Description: "集合名称", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.SendNotification": {
+		Summary:     "发送协作通知",
+		Description: "向指定用户发送协作通知",
+		Tags:        []string{"协作"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "发送通知请求", Type: openapi.TypeOf[SendNotificationRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.UpdateCursor": {
+		Summary:     "更新用户光标位置",
+		Description: "更新用户在指定文档中的光标位置",
+		Tags:        []string{"协作"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "更新光标位置请求", Type: openapi.TypeOf[UpdateCursorRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaborationHandler.UpdatePresence": {
+		Summary:     "更新用户在线状态",
+		Description: "更新用户在指定集合中的在线状态",
+		Tags:        []string{"协作"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "更新在线状态请求", Type: openapi.TypeOf[UpdatePresenceRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"CollaboratorHandler.AddBaseCollaborator": {
+		Summary:     "添加Base协作者",
+		Description: "为Base添加新的协作者",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "添加协作者请求", Type: openapi.TypeOf[dto.AddCollaboratorRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.CollaboratorResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 409, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.AddSpaceCollaborator": {
+		Summary:     "添加Space协作者",
+		Description: "为Space添加新的协作者",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "添加协作者请求", Type: openapi.TypeOf[dto.AddCollaboratorRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.CollaboratorResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 409, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.ListBaseCollaborators": {
+		Summary:     "列出Base协作者",
+		Description: "获取指定Base的所有协作者列表",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.ListCollaboratorsResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.ListSpaceCollaborators": {
+		Summary:     "列出Space协作者",
+		Description: "获取指定Space的所有协作者列表",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.ListCollaboratorsResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.RemoveBaseCollaborator": {
+		Summary:     "移除Base协作者",
+		Description: "从Base移除协作者",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "collaboratorId", In: "path", Description: "Collaborator ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.RemoveSpaceCollaborator": {
+		Summary:     "移除Space协作者",
+		Description: "从Space移除协作者",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "collaboratorId", In: "path", Description: "Collaborator ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.UpdateBaseCollaborator": {
+		Summary:     "更新Base协作者角色",
+		Description: "更新Base协作者的角色",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "collaboratorId", In: "path", Description: "Collaborator ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新协作者请求", Type: openapi.TypeOf[dto.UpdateCollaboratorRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.CollaboratorResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"CollaboratorHandler.UpdateSpaceCollaborator": {
+		Summary:     "更新Space协作者角色",
+		Description: "更新Space协作者的角色",
+		Tags:        []string{"协作者"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "collaboratorId", In: "path", Description: "Collaborator ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新协作者请求", Type: openapi.TypeOf[dto.UpdateCollaboratorRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.CollaboratorResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"FieldHandler.CreateField": {
+		Summary: "创建字段",
+		Tags:    []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建字段请求", Type: openapi.TypeOf[dto.CreateFieldRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.FieldResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"FieldHandler.DeleteField": {
+		Summary: "删除字段",
+		Tags:    []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "fieldId", In: "path", Description: "Field ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"FieldHandler.GetField": {
+		Summary: "获取字段",
+		Tags:    []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "fieldId", In: "path", Description: "Field ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.FieldResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"FieldHandler.ListFields": {
+		Summary: "列出字段",
+		Tags:    []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.FieldResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"FieldHandler.UpdateField": {
+		Summary: "更新字段",
+		Tags:    []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "fieldId", In: "path", Description: "Field ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新字段请求", Type: openapi.TypeOf[dto.UpdateFieldRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.FieldResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"GraphQLHandler.Execute": {
+		Summary:     "执行 GraphQL 请求",
+		Description: "Schema 按 Base 的表和字段生成：每张表提供分页查询（过滤、排序、游标分页）、按 ID 查询以及创建、更新、删除记录；关联字段为嵌套对象，选择字段为枚举。响应为 GraphQL 格式 {data, errors}",
+		Tags:        []string{"GraphQL"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "GraphQL 请求", Type: openapi.TypeOf[dto.GraphQLRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "GraphQL 响应 {data, errors, extensions}", Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"GraphQLHandler.ExecuteQuery": {
+		Summary:     "通过 GET 执行 GraphQL 查询",
+		Description: "只允许查询操作，变更需要使用 POST；variables 为 JSON 字符串",
+		Tags:        []string{"GraphQL"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "query", In: "query", Description: "GraphQL 查询", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "operationName", In: "query", Description: "操作名", Type: openapi.TypeOf[string]()},
+			{Name: "variables", In: "query", Description: "变量（JSON）", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "GraphQL 响应 {data, errors, extensions}", Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"GraphQLHandler.GetSchema": {
+		Summary:     "获取 Base 的 GraphQL Schema",
+		Description: "返回 SDL 文本，表或字段变更后自动更新",
+		Tags:        []string{"GraphQL"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"text/plain"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "GraphQL SDL", Kind: "string"},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"HealthHandler.HealthCheck": {
+		Summary:     "健康检查",
+		Description: "检查系统各组件的健康状态",
+		Tags:        []string{"系统"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[HealthResponse]()},
+			{Status: 503, Kind: "object", Type: openapi.TypeOf[HealthResponse]()},
+		},
+	},
+	"HealthHandler.LivenessCheck": {
+		Summary:     "存活检查",
+		Description: "检查服务是否存活",
+		Tags:        []string{"系统"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[ServiceHealth]()},
+		},
+	},
+	"HealthHandler.Metrics": {
+		Summary:     "获取系统指标",
+		Description: "获取系统运行指标和统计信息",
+		Tags:        []string{"系统"},
+		Produces:    []string{"application/json"},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 401, Kind: "object"},
+		},
+	},
+	"HealthHandler.ReadinessCheck": {
+		Summary:     "就绪检查",
+		Description: "检查服务是否准备好接收请求",
+		Tags:        []string{"系统"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[ServiceHealth]()},
+			{Status: 503, Kind: "object", Type: openapi.TypeOf[ServiceHealth]()},
+		},
+	},
+	"LinkRecordHandler.ListLinkCandidates": {
+		Summary:     "获取 Link 字段可关联的记录",
+		Description: "应用 Link 字段的限定视图和过滤条件，按关联表标题字段搜索，只返回可见字段",
+		Tags:        []string{"Field"},
+		Params: []openapi.ParamDoc{
+			{Name: "fieldId", In: "path", Description: "Link 字段ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "search", In: "query", Description: "按标题字段模糊搜索", Type: openapi.TypeOf[string]()},
+			{Name: "offset", In: "query", Description: "偏移量", Type: openapi.TypeOf[int]()},
+			{Name: "limit", In: "query", Description: "每页数量（默认50，最多500）", Type: openapi.TypeOf[int]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.LinkCandidateListResponse]()},
+		},
+	},
+	"NotificationHandler.CleanupExpiredNotifications": {
+		Summary:     "清理过期通知",
+		Description: "清理所有过期的通知",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "清理成功", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.CreateNotification": {
+		Summary:     "创建通知",
+		Description: "创建新的通知",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "创建通知请求", Type: openapi.TypeOf[notification.CreateNotificationRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "创建成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.Notification]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.CreateSubscription": {
+		Summary:     "创建通知订阅",
+		Description: "创建新的通知订阅",
+		Tags:        []string{"通知订阅管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "创建订阅请求", Type: openapi.TypeOf[notification.CreateSubscriptionRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "创建成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.NotificationSubscription]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.CreateTemplate": {
+		Summary:     "创建通知模板",
+		Description: "创建新的通知模板",
+		Tags:        []string{"通知模板管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "创建模板请求", Type: openapi.TypeOf[notification.NotificationTemplate]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "创建成功", Kind: "object", Envelope: true},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.DeleteNotification": {
+		Summary:     "删除通知",
+		Description: "根据ID删除通知",
+		Tags:        []string{"通知管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "通知ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "删除成功", Kind: "object", Envelope: true},
+			{Status: 404, Description: "通知不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.DeleteSubscription": {
+		Summary:     "删除通知订阅",
+		Description: "根据ID删除通知订阅",
+		Tags:        []string{"通知订阅管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "订阅ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "删除成功", Kind: "object", Envelope: true},
+			{Status: 404, Description: "订阅不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.DeleteTemplate": {
+		Summary:     "删除通知模板",
+		Description: "根据ID删除通知模板",
+		Tags:        []string{"通知模板管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "模板ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "删除成功", Kind: "object", Envelope: true},
+			{Status: 404, Description: "模板不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.DeleteUserSubscriptions": {
+		Summary:     "删除用户订阅",
+		Description: "删除当前用户的所有或指定类型的通知订阅",
+		Tags:        []string{"通知订阅管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "query", Description: "通知类型", Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "删除成功", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetNotification": {
+		Summary:     "获取通知详情",
+		Description: "根据ID获取通知详情",
+		Tags:        []string{"通知管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "通知ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.Notification]()},
+			{Status: 404, Description: "通知不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetNotificationStats": {
+		Summary:     "获取通知统计",
+		Description: "获取当前用户的通知统计信息",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.NotificationStats]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetPreference": {
+		Summary:     "获取通知偏好",
+		Description: "获取当前用户的通知渠道、免打扰时段和摘要设置，未设置时返回默认值",
+		Tags:        []string{"通知偏好"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.Preference]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetSubscription": {
+		Summary:     "获取通知订阅",
+		Description: "根据ID获取通知订阅",
+		Tags:        []string{"通知订阅管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "订阅ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.NotificationSubscription]()},
+			{Status: 404, Description: "订阅不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetTemplate": {
+		Summary:     "获取通知模板",
+		Description: "根据ID获取通知模板",
+		Tags:        []string{"通知模板管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "模板ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.NotificationTemplate]()},
+			{Status: 404, Description: "模板不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetTemplateByType": {
+		Summary:     "根据类型获取通知模板",
+		Description: "根据通知类型获取模板",
+		Tags:        []string{"通知模板管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "path", Description: "通知类型", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.NotificationTemplate]()},
+			{Status: 404, Description: "模板不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.GetUserSubscriptions": {
+		Summary:     "获取用户订阅",
+		Description: "获取当前用户的通知订阅列表",
+		Tags:        []string{"通知订阅管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "query", Description: "通知类型", Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[[]notification.NotificationSubscription]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.ListNotifications": {
+		Summary:     "列出通知",
+		Description: "获取当前用户的通知列表",
+		Tags:        []string{"通知管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "query", Description: "通知类型", Type: openapi.TypeOf[string]()},
+			{Name: "status", In: "query", Description: "通知状态", Type: openapi.TypeOf[string]()},
+			{Name: "priority", In: "query", Description: "通知优先级", Type: openapi.TypeOf[string]()},
+			{Name: "source_id", In: "query", Description: "来源ID", Type: openapi.TypeOf[string]()},
+			{Name: "source_type", In: "query", Description: "来源类型", Type: openapi.TypeOf[string]()},
+			{Name: "page", In: "query", Description: "页码", Type: openapi.TypeOf[int]()},
+			{Name: "page_size", In: "query", Description: "每页数量", Type: openapi.TypeOf[int]()},
+			{Name: "sort_by", In: "query", Description: "排序字段", Type: openapi.TypeOf[string]()},
+			{Name: "sort_order", In: "query", Description: "排序顺序", Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.ListNotificationsResponse]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.ListTemplates": {
+		Summary:     "列出通知模板",
+		Description: "获取通知模板列表",
+		Tags:        []string{"通知模板管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "query", Description: "通知类型", Type: openapi.TypeOf[string]()},
+			{Name: "is_active", In: "query", Description: "是否激活", Type: openapi.TypeOf[bool]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[[]notification.NotificationTemplate]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.MarkAllNotificationsRead": {
+		Summary:     "标记所有通知为已读",
+		Description: "标记当前用户的所有通知为已读状态",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "标记成功", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.MarkNotificationsRead": {
+		Summary:     "标记通知为已读",
+		Description: "批量标记通知为已读状态",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "标记已读请求", Type: openapi.TypeOf[notification.MarkNotificationsReadRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "标记成功", Kind: "object", Envelope: true},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.SendNotification": {
+		Summary:     "发送通知",
+		Description: "发送通知到订阅者",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "发送通知请求", Type: openapi.TypeOf[notification.CreateNotificationRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "发送成功", Kind: "object", Envelope: true},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.SendNotificationToSubscribers": {
+		Summary:     "向订阅者发送通知",
+		Description: "向指定来源的订阅者发送通知",
+		Tags:        []string{"通知管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "发送通知请求", Type: openapi.TypeOf[SendNotificationToSubscribersRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "发送成功", Kind: "object", Envelope: true},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.UpdateNotification": {
+		Summary:     "更新通知",
+		Description: "更新通知信息",
+		Tags:        []string{"通知管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "通知ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新通知请求", Type: openapi.TypeOf[notification.UpdateNotificationRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "更新成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.Notification]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 404, Description: "通知不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.UpdatePreference": {
+		Summary:     "更新通知偏好",
+		Description: "更新当前用户的通知偏好，未提供的字段保持不变",
+		Tags:        []string{"通知偏好"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "更新通知偏好请求", Type: openapi.TypeOf[notification.UpdatePreferenceRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "更新成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.Preference]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.UpdateSubscription": {
+		Summary:     "更新通知订阅",
+		Description: "更新通知订阅信息",
+		Tags:        []string{"通知订阅管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "订阅ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新订阅请求", Type: openapi.TypeOf[notification.UpdateSubscriptionRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "更新成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[notification.NotificationSubscription]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 404, Description: "订阅不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"NotificationHandler.UpdateTemplate": {
+		Summary:     "更新通知模板",
+		Description: "更新通知模板信息",
+		Tags:        []string{"通知模板管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "模板ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新模板请求", Type: openapi.TypeOf[notification.NotificationTemplate]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "更新成功", Kind: "object", Envelope: true},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 404, Description: "模板不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"OpenAPIHandler.GetSpec": {
+		Summary:     "获取 OpenAPI 文档",
+		Description: "由已注册的路由、处理器注释和 DTO 生成的 OpenAPI 3.1 文档，错误响应列出各状态码可能返回的业务错误码",
+		Tags:        []string{"系统"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "OpenAPI 3.1 文档", Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"OpenAPIHandler.GetTableSpec": {
+		Summary:     "获取表格记录接口的 OpenAPI 文档",
+		Description: "记录 data 按表格字段给出每个字段的值结构（属性名为字段 ID），只包含当前用户可以执行的记录操作",
+		Tags:        []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "OpenAPI 3.1 文档", Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"PermissionHandler.CheckPermission": {
+		Summary:     "检查权限",
+		Description: "检查用户是否有指定资源的权限",
+		Tags:        []string{"权限管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "检查权限请求", Type: openapi.TypeOf[CheckPermissionRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.CheckRolePermission": {
+		Summary:     "检查角色权限",
+		Description: "检查角色是否有指定权限",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "role", In: "query", Description: "角色", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "action", In: "query", Description: "权限动作", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.CompareRoles": {
+		Summary:     "比较角色",
+		Description: "比较两个角色的权限级别",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "role1", In: "query", Description: "角色1", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "role2", In: "query", Description: "角色2", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetPermissionStats": {
+		Summary:     "获取权限统计",
+		Description: "获取权限系统的统计信息",
+		Tags:        []string{"权限管理"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[permission.PermissionStats]()},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetResourceCollaborators": {
+		Summary:     "获取资源协作者",
+		Description: "获取指定资源的所有协作者",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "resource_type", In: "query", Description: "资源类型", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "resource_id", In: "query", Description: "资源ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]permission.CollaboratorInfo]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetResourcePermissions": {
+		Summary:     "获取资源权限",
+		Description: "获取指定资源的所有权限",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "resource_type", In: "query", Description: "资源类型", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "resource_id", In: "query", Description: "资源ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]permission.Permission]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetRoleLevel": {
+		Summary:     "获取角色级别",
+		Description: "获取指定角色的权限级别",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "role", In: "query", Description: "角色", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetRolePermissions": {
+		Summary:     "获取角色权限",
+		Description: "获取指定角色的所有权限",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "role", In: "query", Description: "角色", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]permission.Action]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetUserPermissions": {
+		Summary:     "获取用户权限",
+		Description: "获取指定用户的所有权限",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "user_id", In: "query", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]permission.Permission]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetUserResources": {
+		Summary:     "获取用户资源",
+		Description: "获取用户有权限访问的资源列表",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "user_id", In: "query", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "resource_type", In: "query", Description: "资源类型", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]string]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GetUserRole": {
+		Summary:     "获取用户角色",
+		Description: "获取用户在指定资源中的角色",
+		Tags:        []string{"权限管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "user_id", In: "query", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "resource_type", In: "query", Description: "资源类型", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "resource_id", In: "query", Description: "资源ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.GrantPermission": {
+		Summary:     "授予权限",
+		Description: "为用户授予指定资源的权限",
+		Tags:        []string{"权限管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "授予权限请求", Type: openapi.TypeOf[GrantPermissionRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.RevokePermission": {
+		Summary:     "撤销权限",
+		Description: "撤销用户对指定资源的权限",
+		Tags:        []string{"权限管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "撤销权限请求", Type: openapi.TypeOf[RevokePermissionRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.TransferOwnership": {
+		Summary:     "转移所有权",
+		Description: "将资源的所有权转移给其他用户",
+		Tags:        []string{"权限管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "转移所有权请求", Type: openapi.TypeOf[TransferOwnershipRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PermissionHandler.UpdatePermission": {
+		Summary:     "更新权限",
+		Description: "更新用户对指定资源的权限",
+		Tags:        []string{"权限管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "更新权限请求", Type: openapi.TypeOf[UpdatePermissionRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"PinHandler.ListPins": {
+		Summary:     "获取 Pin 列表",
+		Description: "获取用户的 Pin 列表",
+		Tags:        []string{"Pin"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]PinItem]()},
+			{Status: 401, Kind: "object"},
+		},
+	},
+	"QuotaHandler.GetSpaceQuota": {
+		Summary:     "获取空间配额与用量",
+		Description: "返回空间所属套餐（取自空间所有者的许可证）以及记录数、附件存储、当月 API 调用次数的用量和上限；limit 为 0 表示不限制。本接口不计入 API 调用次数",
+		Tags:        []string{"Spaces"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "空间ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.SpaceQuotaResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.BatchCreateRecords": {
+		Summary: "批量创建记录",
+		Tags:    []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "批量创建请求", Type: openapi.TypeOf[dto.BatchCreateRecordRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.BatchCreateRecordResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.BatchDeleteRecords": {
+		Summary: "批量删除记录",
+		Tags:    []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "批量删除请求", Type: openapi.TypeOf[dto.BatchDeleteRecordRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.BatchDeleteRecordResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.BatchUpdateRecords": {
+		Summary: "批量更新记录",
+		Tags:    []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "批量更新请求", Type: openapi.TypeOf[dto.BatchUpdateRecordRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.BatchUpdateRecordResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.CreateRecord": {
+		Summary:     "创建记录",
+		Description: "data 的键为字段 ID（也接受字段名）；各字段的值结构见 /api/v1/tables/{tableId}/openapi.json",
+		Tags:        []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建记录请求", Type: openapi.TypeOf[dto.CreateRecordRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.RecordResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.DeleteRecord": {
+		Summary: "删除记录",
+		Tags:    []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "recordId", In: "path", Description: "Record ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.GetRecord": {
+		Summary: "获取记录",
+		Tags:    []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "recordId", In: "path", Description: "Record ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.RecordResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.ListRecords": {
+		Summary: "列出记录",
+		Tags:    []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "limit", In: "query", Description: "每页数量（默认 100）", Type: openapi.TypeOf[int]()},
+			{Name: "offset", In: "query", Description: "偏移量", Type: openapi.TypeOf[int]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[response.PaginatedData](), DataFields: openapi.Fields{"list": openapi.TypeOf[[]dto.RecordResponse]()}},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RecordHandler.UpdateRecord": {
+		Summary:     "更新记录",
+		Description: "只更新 data 中出现的字段；传入 version 时进行乐观锁检查",
+		Tags:        []string{"Record"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "recordId", In: "path", Description: "Record ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新记录请求", Type: openapi.TypeOf[dto.UpdateRecordRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.RecordResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+			{Status: 409, Kind: "object", Envelope: true},
+		},
+	},
+	"ReminderHandler.CreateReminderRule": {
+		Summary:     "创建提醒规则",
+		Description: "在日期字段之前/之后 N 分钟/小时/天提醒用户字段中的用户或固定协作者，可选过滤条件",
+		Tags:        []string{"记录提醒"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建提醒规则请求", Type: openapi.TypeOf[dto.CreateReminderRuleRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.ReminderRuleResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"ReminderHandler.DeleteReminderRule": {
+		Summary: "删除提醒规则",
+		Tags:    []string{"记录提醒"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"ReminderHandler.ListRecordReminders": {
+		Summary:     "列出记录提醒",
+		Description: "获取记录在各提醒规则下的下次提醒时间和状态",
+		Tags:        []string{"记录提醒"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "recordId", In: "path", Description: "Record ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.RecordReminderResponse]()},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"ReminderHandler.ListReminderRules": {
+		Summary:     "列出提醒规则",
+		Description: "获取指定表的所有日期字段提醒规则",
+		Tags:        []string{"记录提醒"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.ReminderRuleResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"ReminderHandler.UpdateReminderRule": {
+		Summary: "更新提醒规则",
+		Tags:    []string{"记录提醒"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新提醒规则请求", Type: openapi.TypeOf[dto.UpdateReminderRuleRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.ReminderRuleResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RowRuleHandler.CreateRowRule": {
+		Summary:     "创建行级规则",
+		Description: "为表添加行级访问规则，过滤值支持 @me 和 @myGroups 占位符",
+		Tags:        []string{"行级权限"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建行级规则请求", Type: openapi.TypeOf[dto.CreateRowRuleRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.RowRuleResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"RowRuleHandler.DeleteRowRule": {
+		Summary: "删除行级规则",
+		Tags:    []string{"行级权限"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"RowRuleHandler.ListRowRules": {
+		Summary:     "列出行级规则",
+		Description: "获取指定表的所有行级访问规则",
+		Tags:        []string{"行级权限"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.RowRuleResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+		},
+	},
+	"RowRuleHandler.UpdateRowRule": {
+		Summary: "更新行级规则",
+		Tags:    []string{"行级权限"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "ruleId", In: "path", Description: "Rule ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新行级规则请求", Type: openapi.TypeOf[dto.UpdateRowRuleRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.RowRuleResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.AdvancedSearch": {
+		Summary:     "高级搜索",
+		Description: "执行高级搜索操作",
+		Tags:        []string{"搜索管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "高级搜索请求", Type: openapi.TypeOf[search.AdvancedSearchRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "搜索成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[search.SearchResponse]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.CreateIndex": {
+		Summary:     "创建搜索索引",
+		Description: "创建新的搜索索引",
+		Tags:        []string{"搜索索引管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "创建索引请求", Type: openapi.TypeOf[search.SearchIndexRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "创建成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[search.SearchIndex]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.DeleteIndex": {
+		Summary:     "删除搜索索引",
+		Description: "根据ID删除搜索索引",
+		Tags:        []string{"搜索索引管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "索引ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "删除成功", Kind: "object", Envelope: true},
+			{Status: 404, Description: "索引不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.DeleteIndexesBySource": {
+		Summary:     "根据来源删除搜索索引",
+		Description: "根据来源ID和类型删除相关搜索索引",
+		Tags:        []string{"搜索索引管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "source_id", In: "query", Description: "来源ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "source_type", In: "query", Description: "来源类型", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "删除成功", Kind: "object", Envelope: true},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.GetIndex": {
+		Summary:     "获取搜索索引",
+		Description: "根据ID获取搜索索引",
+		Tags:        []string{"搜索索引管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "索引ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[search.SearchIndex]()},
+			{Status: 404, Description: "索引不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.GetIndexStats": {
+		Summary:     "获取索引统计",
+		Description: "获取搜索索引统计信息",
+		Tags:        []string{"搜索索引管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.GetPopularQueries": {
+		Summary:     "获取热门查询",
+		Description: "获取热门搜索查询",
+		Tags:        []string{"搜索管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "limit", In: "query", Description: "查询数量", Type: openapi.TypeOf[int]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[[]search.SearchSuggestion]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.GetSearchStats": {
+		Summary:     "获取搜索统计",
+		Description: "获取搜索系统统计信息",
+		Tags:        []string{"搜索管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[search.SearchStats]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.ListIndexes": {
+		Summary:     "列出搜索索引",
+		Description: "获取搜索索引列表",
+		Tags:        []string{"搜索索引管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "query", Description: "搜索类型", Type: openapi.TypeOf[string]()},
+			{Name: "source_id", In: "query", Description: "来源ID", Type: openapi.TypeOf[string]()},
+			{Name: "source_type", In: "query", Description: "来源类型", Type: openapi.TypeOf[string]()},
+			{Name: "page", In: "query", Description: "页码", Type: openapi.TypeOf[int]()},
+			{Name: "page_size", In: "query", Description: "每页数量", Type: openapi.TypeOf[int]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[ListIndexesResponse]()},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.OptimizeIndex": {
+		Summary:     "优化索引",
+		Description: "优化搜索索引",
+		Tags:        []string{"搜索索引管理"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "优化成功", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.RebuildIndex": {
+		Summary:     "重建索引",
+		Description: "重建搜索索引",
+		Tags:        []string{"搜索索引管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "type", In: "query", Description: "搜索类型", Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "重建成功", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.Search": {
+		Summary:     "搜索",
+		Description: "执行搜索操作",
+		Tags:        []string{"搜索管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "query", In: "query", Description: "搜索关键词", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "type", In: "query", Description: "搜索类型", Type: openapi.TypeOf[string]()},
+			{Name: "scope", In: "query", Description: "搜索范围", Type: openapi.TypeOf[string]()},
+			{Name: "source_id", In: "query", Description: "来源ID", Type: openapi.TypeOf[string]()},
+			{Name: "source_type", In: "query", Description: "来源类型", Type: openapi.TypeOf[string]()},
+			{Name: "user_id", In: "query", Description: "用户ID", Type: openapi.TypeOf[string]()},
+			{Name: "space_id", In: "query", Description: "空间ID", Type: openapi.TypeOf[string]()},
+			{Name: "table_id", In: "query", Description: "表格ID", Type: openapi.TypeOf[string]()},
+			{Name: "page", In: "query", Description: "页码", Type: openapi.TypeOf[int]()},
+			{Name: "page_size", In: "query", Description: "每页数量", Type: openapi.TypeOf[int]()},
+			{Name: "sort_by", In: "query", Description: "排序字段", Type: openapi.TypeOf[string]()},
+			{Name: "sort_order", In: "query", Description: "排序顺序", Type: openapi.TypeOf[string]()},
+			{Name: "highlight", In: "query", Description: "是否高亮", Type: openapi.TypeOf[bool]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "搜索成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[search.SearchResponse]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.SearchSuggestions": {
+		Summary:     "搜索建议",
+		Description: "获取搜索建议",
+		Tags:        []string{"搜索管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "query", In: "query", Description: "搜索关键词", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "limit", In: "query", Description: "建议数量", Type: openapi.TypeOf[int]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "获取成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[[]search.SearchSuggestion]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"SearchHandler.UpdateIndex": {
+		Summary:     "更新搜索索引",
+		Description: "更新搜索索引信息",
+		Tags:        []string{"搜索索引管理"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "索引ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新索引请求", Type: openapi.TypeOf[search.UpdateSearchIndexRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Description: "更新成功", Kind: "object", Envelope: true, Data: openapi.TypeOf[search.SearchIndex]()},
+			{Status: 400, Description: "请求参数错误", Kind: "object", Envelope: true},
+			{Status: 404, Description: "索引不存在", Kind: "object", Envelope: true},
+			{Status: 500, Description: "服务器内部错误", Kind: "object", Envelope: true},
+		},
+	},
+	"ShareHandler.CopyData": {
+		Summary:     "复制数据",
+		Description: "通过分享链接复制数据",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "复制请求", Type: openapi.TypeOf[share.ShareCopyRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareCopyResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 403, Kind: "object"},
+		},
+	},
+	"ShareHandler.CreateShareView": {
+		Summary:     "创建分享视图",
+		Description: "为指定视图创建分享链接",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "view_id", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "table_id", In: "path", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareView]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"ShareHandler.DisableShareView": {
+		Summary:     "禁用分享视图",
+		Description: "禁用指定视图的分享功能",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"ShareHandler.EnableShareView": {
+		Summary:     "启用分享视图",
+		Description: "启用指定视图的分享功能",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "分享元数据", Type: openapi.TypeOf[share.ShareViewMeta]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"ShareHandler.GetCollaborators": {
+		Summary:     "获取协作者",
+		Description: "获取分享视图的协作者列表",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "view_id", In: "query", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareCollaboratorsResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 403, Kind: "object"},
+		},
+	},
+	"ShareHandler.GetLinkRecords": {
+		Summary:     "获取链接记录",
+		Description: "获取分享视图的链接记录",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "field_id", In: "query", Description: "字段ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "type", In: "query", Description: "类型", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "search", In: "query", Description: "搜索关键词", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareLinkRecordsResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 403, Kind: "object"},
+		},
+	},
+	"ShareHandler.GetShareStats": {
+		Summary:     "获取分享统计",
+		Description: "获取指定表格的分享统计信息",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "table_id", In: "path", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareStats]()},
+			{Status: 400, Kind: "object"},
+			{Status: 500, Kind: "object"},
+		},
+	},
+	"ShareHandler.GetShareView": {
+		Summary:     "获取分享视图",
+		Description: "通过分享ID获取分享视图信息",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareViewInfo]()},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"ShareHandler.ShareAuth": {
+		Summary:     "分享认证",
+		Description: "验证分享访问权限",
+		Tags:        []string{"Share"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "认证请求", Type: openapi.TypeOf[share.ShareAuthRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareAuthResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 401, Kind: "object"},
+		},
+	},
+	"ShareHandler.SubmitForm": {
+		Summary:     "提交表单",
+		Description: "通过分享链接提交表单数据",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "表单提交请求", Type: openapi.TypeOf[share.ShareFormSubmitRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[share.ShareFormSubmitResponse]()},
+			{Status: 400, Kind: "object"},
+			{Status: 403, Kind: "object"},
+		},
+	},
+	"ShareHandler.UpdateShareMeta": {
+		Summary:     "更新分享元数据",
+		Description: "更新指定分享视图的元数据",
+		Tags:        []string{"Share"},
+		Params: []openapi.ParamDoc{
+			{Name: "share_id", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "分享元数据", Type: openapi.TypeOf[share.ShareViewMeta]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 400, Kind: "object"},
+			{Status: 404, Kind: "object"},
+		},
+	},
+	"SpaceHandler.CreateSpace": {
+		Summary:  "创建空间",
+		Tags:     []string{"Space"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建空间请求", Type: openapi.TypeOf[dto.CreateSpaceRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.SpaceResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+		},
+	},
+	"SpaceHandler.DeleteSpace": {
+		Summary: "删除空间",
+		Tags:    []string{"Space"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"SpaceHandler.GetSpace": {
+		Summary: "获取空间",
+		Tags:    []string{"Space"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.SpaceResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"SpaceHandler.ListSpaces": {
+		Summary:     "列出空间",
+		Description: "返回当前用户拥有或参与协作的空间",
+		Tags:        []string{"Space"},
+		Produces:    []string{"application/json"},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.SpaceResponse]()},
+		},
+	},
+	"SpaceHandler.UpdateSpace": {
+		Summary: "更新空间",
+		Tags:    []string{"Space"},
+		Params: []openapi.ParamDoc{
+			{Name: "spaceId", In: "path", Description: "Space ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新空间请求", Type: openapi.TypeOf[dto.UpdateSpaceRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.SpaceResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.CreateTable": {
+		Summary: "创建表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建表格请求", Type: openapi.TypeOf[dto.CreateTableRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.TableResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.DeleteTable": {
+		Summary: "删除表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.DuplicateTable": {
+		Summary: "复制表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "复制表格请求", Type: openapi.TypeOf[dto.DuplicateTableRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.TableResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.GetTable": {
+		Summary: "获取表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.TableResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.GetTableManagementMenu": {
+		Summary:     "获取表格管理菜单",
+		Description: "返回表格信息、用量和可用的管理操作",
+		Tags:        []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[map[string]interface{}]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.GetTableUsage": {
+		Summary: "获取表格用量",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.TableUsageResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.ListTables": {
+		Summary: "列出表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "baseId", In: "path", Description: "Base ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.TableResponse]()},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.RenameTable": {
+		Summary: "重命名表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "重命名请求", Type: openapi.TypeOf[dto.RenameTableRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.TableResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"TableHandler.UpdateTable": {
+		Summary: "更新表格",
+		Tags:    []string{"Table"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "Table ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新表格请求", Type: openapi.TypeOf[dto.UpdateTableRequest]()},
+		Security: true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.TableResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 403, Kind: "object", Envelope: true},
+			{Status: 404, Kind: "object", Envelope: true},
+		},
+	},
+	"UserConfigHandler.GetUserConfig": {
+		Summary:     "获取用户配置",
+		Description: "获取当前用户的个人配置（时区、语言等）",
+		Tags:        []string{"用户配置"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.UserConfigResponse]()},
+			{Status: 401, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"UserConfigHandler.UpdateUserConfig": {
+		Summary:     "更新用户配置",
+		Description: "更新当前用户的个人配置",
+		Tags:        []string{"用户配置"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Body:        &openapi.BodyDoc{Required: true, Description: "更新请求", Type: openapi.TypeOf[dto.UpdateUserConfigRequest]()},
+		Security:    true,
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[dto.UserConfigResponse]()},
+			{Status: 400, Kind: "object", Envelope: true},
+			{Status: 401, Kind: "object", Envelope: true},
+			{Status: 500, Kind: "object", Envelope: true},
+		},
+	},
+	"UserHandler.ChangePassword": {
+		Summary: "修改密码",
+		Tags:    []string{"User"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "修改密码请求", Type: openapi.TypeOf[dto.ChangePasswordRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"UserHandler.CreateUser": {
+		Summary:  "创建用户",
+		Tags:     []string{"User"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建用户请求", Type: openapi.TypeOf[dto.CreateUserRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.UserResponse]()},
+		},
+	},
+	"UserHandler.DeleteUser": {
+		Summary: "删除用户",
+		Tags:    []string{"User"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"UserHandler.GetUser": {
+		Summary: "获取用户信息",
+		Tags:    []string{"User"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.UserResponse]()},
+		},
+	},
+	"UserHandler.ListUsers": {
+		Summary: "用户列表",
+		Tags:    []string{"User"},
+		Params: []openapi.ParamDoc{
+			{Name: "email", In: "query", Description: "邮箱过滤", Type: openapi.TypeOf[string]()},
+			{Name: "name", In: "query", Description: "名称过滤", Type: openapi.TypeOf[string]()},
+			{Name: "page", In: "query", Description: "页码", Type: openapi.TypeOf[int]()},
+			{Name: "page_size", In: "query", Description: "每页数量", Type: openapi.TypeOf[int]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.UserListResponse]()},
+		},
+	},
+	"UserHandler.UpdateUser": {
+		Summary: "更新用户",
+		Tags:    []string{"User"},
+		Params: []openapi.ParamDoc{
+			{Name: "id", In: "path", Description: "用户ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新用户请求", Type: openapi.TypeOf[dto.UpdateUserRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.UserResponse]()},
+		},
+	},
+	"ViewHandler.CountViews": {
+		Summary: "统计表格视图数量",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewCountResponse]()},
+		},
+	},
+	"ViewHandler.CreateView": {
+		Summary: "创建视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "创建请求", Type: openapi.TypeOf[dto.CreateViewRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewResponse]()},
+		},
+	},
+	"ViewHandler.DeleteView": {
+		Summary: "删除视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.DisableShare": {
+		Summary: "禁用视图分享",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.DuplicateView": {
+		Summary: "复制视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "复制请求", Type: openapi.TypeOf[dto.DuplicateViewRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewResponse]()},
+		},
+	},
+	"ViewHandler.EnableShare": {
+		Summary: "启用视图分享",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.EnableShareResponse]()},
+		},
+	},
+	"ViewHandler.GetCalendarData": {
+		Summary:     "获取日历视图数据",
+		Description: "返回与查询窗口相交的事件（含跨天事件），时间按指定时区输出",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "start", In: "query", Description: "窗口开始日期", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "end", In: "query", Description: "窗口结束日期（包含当天）", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "timezone", In: "query", Description: "IANA 时区，默认 UTC", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.CalendarDataResponse]()},
+		},
+	},
+	"ViewHandler.GetGridGroupRecords": {
+		Summary:     "分页获取分组内的记录",
+		Description: "按分组ID返回分组内的记录，分页顺序稳定",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "groupId", In: "query", Description: "分组ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "offset", In: "query", Description: "偏移量", Type: openapi.TypeOf[int]()},
+			{Name: "limit", In: "query", Description: "每页记录数", Type: openapi.TypeOf[int]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.GridGroupRecordsResponse]()},
+		},
+	},
+	"ViewHandler.GetGridGroups": {
+		Summary:     "获取分组网格的分组树",
+		Description: "按视图分组配置返回分组树，包含每个分组的记录数和列统计",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "statistics", In: "query", Description: "列统计，格式为 fieldId:func，多个以逗号分隔（sum, avg, min, max, empty, filled, unique, percentFilled, earliest, latest, checked），默认使用列配置中的统计", Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.GridGroupsResponse]()},
+		},
+	},
+	"ViewHandler.GetKanbanData": {
+		Summary:     "获取看板视图数据",
+		Description: "按分组字段返回看板列，每列包含卡片总数和当前页卡片",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "stackId", In: "query", Description: "只加载指定列", Type: openapi.TypeOf[string]()},
+			{Name: "offset", In: "query", Description: "每列偏移量", Type: openapi.TypeOf[int]()},
+			{Name: "limit", In: "query", Description: "每列卡片数", Type: openapi.TypeOf[int]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.KanbanDataResponse]()},
+		},
+	},
+	"ViewHandler.GetTimelineData": {
+		Summary:     "获取时间线视图数据",
+		Description: "返回与查询窗口重叠的记录，超出窗口的起止时间会被裁剪到窗口边界",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "start", In: "query", Description: "窗口开始日期", Required: true, Type: openapi.TypeOf[string]()},
+			{Name: "end", In: "query", Description: "窗口结束日期", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.TimelineDataResponse]()},
+		},
+	},
+	"ViewHandler.GetView": {
+		Summary: "获取视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewResponse]()},
+		},
+	},
+	"ViewHandler.GetViewByShareID": {
+		Summary: "通过分享ID获取视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "shareId", In: "path", Description: "分享ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewResponse]()},
+		},
+	},
+	"ViewHandler.GetViewStatistics": {
+		Summary:     "获取视图的列统计",
+		Description: "按列配置中的统计函数计算视图内记录的汇总值，遵循视图过滤条件和行级权限",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewStatisticsResponse]()},
+		},
+	},
+	"ViewHandler.ListViews": {
+		Summary: "获取表格视图列表",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "tableId", In: "path", Description: "表格ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[[]dto.ViewResponse]()},
+		},
+	},
+	"ViewHandler.LockView": {
+		Summary: "锁定视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.MoveKanbanCard": {
+		Summary:     "移动看板卡片",
+		Description: "更新卡片记录的分组字段，并可放到参照卡片之前或之后",
+		Tags:        []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "移动请求", Type: openapi.TypeOf[dto.MoveKanbanCardRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.RecordResponse]()},
+		},
+	},
+	"ViewHandler.PatchViewOptions": {
+		Summary: "部分更新视图选项",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "选项请求", Type: openapi.TypeOf[dto.PatchViewOptionsRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.RefreshShareID": {
+		Summary: "刷新分享ID",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.RefreshShareIDResponse]()},
+		},
+	},
+	"ViewHandler.UnlockView": {
+		Summary: "解锁视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Produces: []string{"application/json"},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateShareMeta": {
+		Summary: "更新分享元数据",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "分享元数据请求", Type: openapi.TypeOf[dto.UpdateShareMetaRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateView": {
+		Summary: "更新视图",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "更新请求", Type: openapi.TypeOf[dto.UpdateViewRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[dto.ViewResponse]()},
+		},
+	},
+	"ViewHandler.UpdateViewColumnMeta": {
+		Summary: "更新视图列配置",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "列配置请求", Type: openapi.TypeOf[dto.UpdateViewColumnMetaRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateViewFilter": {
+		Summary: "更新视图过滤器",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "过滤器请求", Type: openapi.TypeOf[dto.UpdateViewFilterRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateViewGroup": {
+		Summary: "更新视图分组",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "分组请求", Type: openapi.TypeOf[dto.UpdateViewGroupRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateViewOptions": {
+		Summary: "更新视图选项",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "选项请求", Type: openapi.TypeOf[dto.UpdateViewOptionsRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateViewOrder": {
+		Summary: "更新视图排序位置",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "排序位置请求", Type: openapi.TypeOf[dto.UpdateViewOrderRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+	"ViewHandler.UpdateViewSort": {
+		Summary: "更新视图排序",
+		Tags:    []string{"View"},
+		Params: []openapi.ParamDoc{
+			{Name: "viewId", In: "path", Description: "视图ID", Required: true, Type: openapi.TypeOf[string]()},
+		},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Body:     &openapi.BodyDoc{Required: true, Description: "排序请求", Type: openapi.TypeOf[dto.UpdateViewSortRequest]()},
+		Responses: []openapi.ResponseDoc{
+			{Status: 200, Kind: "object", Type: openapi.TypeOf[map[string]interface{}]()},
+		},
+	},
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/openapi"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+//go:generate go run github.com/easyspace-ai/luckdb/server/cmd/openapi-gen -var routeDocs
+
+// openAPIPrefix 文档包含的路由前缀
+const openAPIPrefix = "/api/v1"
+
+// OpenAPIHandler OpenAPI 文档处理器 ✨
+//
+// 全局文档在首次请求时根据已注册的路由生成并缓存；表格文档按字段和当前用户角色实时生成
+type OpenAPIHandler struct {
+	routes       func() gin.RoutesInfo
+	publicRoutes map[string]bool
+	version      string
+	tableService *application.TableOpenAPIService
+
+	once sync.Once
+	spec []byte
+	err  error
+}
+
+// NewOpenAPIHandler 创建 OpenAPI 文档处理器，publicRoutes 为无需认证的路由（方法 + 空格 + 路径）
+func NewOpenAPIHandler(routes func() gin.RoutesInfo, publicRoutes map[string]bool, version string, tableService *application.TableOpenAPIService) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		routes:       routes,
+		publicRoutes: publicRoutes,
+		version:      version,
+		tableService: tableService,
+	}
+}
+
+// GetSpec 获取 API 文档
+// @Summary 获取 OpenAPI 文档
+// @Description 由已注册的路由、处理器注释和 DTO 生成的 OpenAPI 3.1 文档，错误响应列出各状态码可能返回的业务错误码
+// @Tags 系统
+// @Produce json
+// @Success 200 {object} object "OpenAPI 3.1 文档"
+// @Router /api/v1/openapi.json [get]
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	h.once.Do(func() {
+		description := fmt.Sprintf("统一响应结构为 {code, message, data}；code 为 %d 表示成功，其余为 HTTP 状态码 × 1000 + 子码。"+
+			"表格记录的字段结构见 /api/v1/tables/{tableId}/openapi.json", errors.CodeOK)
+		doc := openapi.NewDocument(openapi.Info{
+			Title:       "LuckDB API",
+			Version:     h.version,
+			Description: description,
+		})
+		doc.Build(h.routes(), routeDocs, openapi.BuildOptions{
+			Prefix: openAPIPrefix,
+			Public: func(method, path string) bool {
+				return h.publicRoutes[method+" "+path]
+			},
+		})
+		h.spec, h.err = json.Marshal(doc)
+	})
+	if h.err != nil {
+		response.Error(c, errors.ErrInternalServer.WithDetails(h.err.Error()))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// GetTableSpec 获取表格记录接口文档
+// @Summary 获取表格记录接口的 OpenAPI 文档
+// @Description 记录 data 按表格字段给出每个字段的值结构（属性名为字段 ID），只包含当前用户可以执行的记录操作
+// @Tags Table
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} object "OpenAPI 3.1 文档"
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/openapi.json [get]
+// @Security BearerAuth
+func (h *OpenAPIHandler) GetTableSpec(c *gin.Context) {
+	doc, err := h.tableService.BuildSpec(c.Request.Context(), c.Param("tableId"), c.GetString("user_id"), h.version)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyspace-ai/luckdb/server/pkg/openapi"
+)
+
+// TestRouteDocs_UpToDate 处理器注释修改后需要执行 go generate 重新生成
+func TestRouteDocs_UpToDate(t *testing.T) {
+	generator, err := openapi.NewGenerator(".")
+	require.NoError(t, err)
+	source, err := generator.Generate("routeDocs")
+	require.NoError(t, err)
+	assert.Empty(t, generator.Warnings())
+
+	current, err := os.ReadFile(openapi.GeneratedFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(source), string(current), "run go generate ./internal/interfaces/http/")
+}
+
+func TestOpenAPIHandler_GetSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	recordHandler := &RecordHandler{}
+	r.GET("/api/v1/tables/:tableId/records", recordHandler.ListRecords)
+	r.PATCH("/api/v1/tables/:tableId/records/:recordId", recordHandler.UpdateRecord)
+
+	h := NewOpenAPIHandler(r.Routes, map[string]bool{}, "test", nil)
+	r.GET("/api/v1/openapi.json", h.GetSpec)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var spec struct {
+		OpenAPI string                                       `json:"openapi"`
+		Paths   map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, openapi.Version, spec.OpenAPI)
+
+	list := spec.Paths["/api/v1/tables/{tableId}/records"]["get"]
+	require.NotNil(t, list)
+	assert.Equal(t, []interface{}{"Record"}, list["tags"])
+	update := spec.Paths["/api/v1/tables/{tableId}/records/{recordId}"]["patch"]
+	require.NotNil(t, update)
+	assert.Contains(t, update["responses"], "409")
+	assert.Contains(t, spec.Paths, "/api/v1/openapi.json")
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// QuotaHandler 空间配额处理器 ✨
+type QuotaHandler struct {
+	service *application.QuotaService
+}
+
+// NewQuotaHandler 创建配额处理器
+func NewQuotaHandler(service *application.QuotaService) *QuotaHandler {
+	return &QuotaHandler{service: service}
+}
+
+// GetSpaceQuota 获取空间配额与用量
+// @Summary 获取空间配额与用量
+// @Description 返回空间所属套餐（取自空间所有者的许可证）以及记录数、附件存储、当月 API 调用次数的用量和上限；limit 为 0 表示不限制。本接口不计入 API 调用次数
+// @Tags Spaces
+// @Produce json
+// @Param spaceId path string true "空间ID"
+// @Success 200 {object} dto.SpaceQuotaResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/spaces/{spaceId}/quota [get]
+// @Security BearerAuth
+func (h *QuotaHandler) GetSpaceQuota(c *gin.Context) {
+	usage, err := h.service.GetUsage(c.Request.Context(), c.Param("spaceId"), c.GetString("user_id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, usage, "获取空间配额成功")
+}
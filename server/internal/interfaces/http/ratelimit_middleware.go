@@ -0,0 +1,159 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/ratelimit"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// rateLimitResultKey gin context 中已写入响应头的限流结果
+const rateLimitResultKey = "rate_limit_result"
+
+// spaceQuotaPath 查看配额的接口不计入 API 调用，超出配额后仍可访问
+const spaceQuotaPath = openAPIPrefix + "/spaces/:spaceId/quota"
+
+// RateLimitMiddleware 令牌桶限流中间件 ✨
+//
+// scope 区分不同维度的桶（ip/auth/user/token），key 返回空字符串时不限流。
+// 响应头按 IETF RateLimit 草案返回 RateLimit-Limit/Remaining/Reset/Policy，
+// 多个限流中间件叠加时以剩余次数最少的为准；被拒绝时返回 429 和 Retry-After。
+// 限流存储出错时放行请求，避免限流影响可用性
+func RateLimitMiddleware(limiter ratelimit.Limiter, scope string, rule config.RateLimitRule, key func(*gin.Context) string) gin.HandlerFunc {
+	limit := ratelimit.Limit{Requests: rule.Requests, Period: rule.Period, Burst: rule.Burst}
+	policy := rateLimitPolicy(limit)
+
+	return func(c *gin.Context) {
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+		id := key(c)
+		if id == "" {
+			c.Next()
+			return
+		}
+
+		result, err := limiter.Allow(c.Request.Context(), scope+":"+id, limit)
+		if err != nil {
+			logger.Warn("限流判定失败，放行请求",
+				logger.String("scope", scope),
+				logger.ErrorField(err))
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, result, policy)
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			response.Error(c, errors.ErrTooManyRequests.WithDetails(map[string]interface{}{
+				"scope":       scope,
+				"limit":       rule.Requests,
+				"window":      rule.Period.String(),
+				"retry_after": retryAfter,
+			}))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// QuotaMiddleware 空间 API 调用配额中间件（需在 JWTAuthMiddleware 之后使用）✨
+//
+// 按路径中的 spaceId/baseId/tableId（或 fieldId/viewId/recordId 所在的表）找到所属空间并计数一次，
+// 超出当月配额时拒绝；路径中没有这些参数的请求不计数。配额存储出错时放行请求
+func QuotaMiddleware(quotas *application.QuotaService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == spaceQuotaPath {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		spaceID, err := quotas.ResolveResourceSpaceID(ctx, application.QuotaResource{
+			SpaceID:  c.Param("spaceId"),
+			BaseID:   c.Param("baseId"),
+			TableID:  c.Param("tableId"),
+			FieldID:  c.Param("fieldId"),
+			ViewID:   c.Param("viewId"),
+			RecordID: c.Param("recordId"),
+		})
+		if err == nil && spaceID != "" {
+			err = quotas.ConsumeAPICall(ctx, spaceID)
+		}
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok && appErr.Code == errors.ErrQuotaExceeded.Code {
+				response.Error(c, err)
+				c.Abort()
+				return
+			}
+			logger.Warn("API 调用配额计数失败，放行请求",
+				logger.String("path", c.FullPath()),
+				logger.ErrorField(err))
+		}
+		c.Next()
+	}
+}
+
+// rateLimitIPKey 按客户端 IP 限流
+// 只有来自 server.trusted_proxies 的请求才采用 X-Forwarded-For，否则为连接的对端地址
+func rateLimitIPKey(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// rateLimitUserKey 按当前用户限流（需在 JWTAuthMiddleware 之后使用）
+func rateLimitUserKey(c *gin.Context) string {
+	return c.GetString("user_id")
+}
+
+// rateLimitTokenKey 按访问令牌限流，同一用户的不同令牌（客户端/API Key）各自计数
+// 只保存令牌的哈希，避免令牌出现在限流存储中
+func rateLimitTokenKey(c *gin.Context) string {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:16])
+}
+
+// setRateLimitHeaders 写入限流响应头，已有更严格的结果时保留原值
+func setRateLimitHeaders(c *gin.Context, result *ratelimit.Result, policy string) {
+	if prev, ok := c.Get(rateLimitResultKey); ok && prev.(*ratelimit.Result).Remaining <= result.Remaining && result.Allowed {
+		return
+	}
+	c.Set(rateLimitResultKey, result)
+
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	c.Header("RateLimit-Policy", policy)
+}
+
+// rateLimitPolicy RateLimit-Policy 响应头，如 "600;w=60" 或 "10;w=60;burst=5"
+func rateLimitPolicy(limit ratelimit.Limit) string {
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, ceilSeconds(limit.Period))
+	if limit.Burst > 0 && limit.Burst != limit.Requests {
+		policy += fmt.Sprintf(";burst=%d", limit.Burst)
+	}
+	return policy
+}
+
+// ceilSeconds 向上取整的秒数
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/easyspace-ai/luckdb/server/internal/config"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/ratelimit"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.Logger = zap.NewNop()
+	logger.Sugar = logger.Logger.Sugar()
+
+	store := ratelimit.NewMemoryStore()
+	r := gin.New()
+	r.Use(
+		RateLimitMiddleware(store, "ip", config.RateLimitRule{Requests: 100, Period: time.Minute}, rateLimitIPKey),
+		RateLimitMiddleware(store, "auth", config.RateLimitRule{Requests: 10, Period: time.Minute, Burst: 2}, rateLimitIPKey),
+	)
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	request := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 响应头以剩余次数最少的规则为准
+	w := request()
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "6", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "10;w=60;burst=2", w.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusNoContent, request().Code)
+
+	w = request()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "6", w.Header().Get("Retry-After"))
+}
+
+func TestRateLimitTokenKey(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Empty(t, rateLimitTokenKey(c))
+
+	c.Request.Header.Set("Authorization", "Bearer secret-token")
+	key := rateLimitTokenKey(c)
+	assert.Len(t, key, 32)
+	assert.NotContains(t, key, "secret")
+}
+
+func TestRateLimitIPKey_IgnoresUntrustedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	assert.NoError(t, r.SetTrustedProxies(nil))
+
+	var keys []string
+	r.GET("/ip", func(c *gin.Context) { keys = append(keys, rateLimitIPKey(c)) })
+
+	for _, forwarded := range []string{"1.1.1.1", "2.2.2.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = "203.0.113.7:5000"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	assert.Equal(t, []string{"203.0.113.7", "203.0.113.7"}, keys)
+}
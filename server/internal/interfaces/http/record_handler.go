@@ -42,6 +42,19 @@ func NewRecordHandler(
 }
 
 // CreateRecord 创建记录
+// @Summary 创建记录
+// @Description data 的键为字段 ID（也接受字段名）；各字段的值结构见 /api/v1/tables/{tableId}/openapi.json
+// @Tags Record
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.CreateRecordRequest true "创建记录请求"
+// @Success 200 {object} response.APIResponse{data=dto.RecordResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records [post]
+// @Security BearerAuth
 func (h *RecordHandler) CreateRecord(c *gin.Context) {
 	var req dto.CreateRecordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -66,6 +79,16 @@ func (h *RecordHandler) CreateRecord(c *gin.Context) {
 }
 
 // GetRecord 获取记录详情
+// @Summary 获取记录
+// @Tags Record
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param recordId path string true "Record ID"
+// @Success 200 {object} response.APIResponse{data=dto.RecordResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/{recordId} [get]
+// @Security BearerAuth
 func (h *RecordHandler) GetRecord(c *gin.Context) {
 	tableID := c.Param("tableId")
 	recordID := c.Param("recordId")
@@ -92,6 +115,21 @@ func (h *RecordHandler) GetRecord(c *gin.Context) {
 }
 
 // UpdateRecord 更新记录
+// @Summary 更新记录
+// @Description 只更新 data 中出现的字段；传入 version 时进行乐观锁检查
+// @Tags Record
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param recordId path string true "Record ID"
+// @Param request body dto.UpdateRecordRequest true "更新记录请求"
+// @Success 200 {object} response.APIResponse{data=dto.RecordResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Failure 409 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/{recordId} [patch]
+// @Security BearerAuth
 func (h *RecordHandler) UpdateRecord(c *gin.Context) {
 	ctx := c.Request.Context()
 	tableID := c.Param("tableId") // ✅ 从路由获取 tableId（新路由）
@@ -134,6 +172,16 @@ func (h *RecordHandler) UpdateRecord(c *gin.Context) {
 }
 
 // DeleteRecord 删除记录
+// @Summary 删除记录
+// @Tags Record
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param recordId path string true "Record ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/{recordId} [delete]
+// @Security BearerAuth
 func (h *RecordHandler) DeleteRecord(c *gin.Context) {
 	tableID := c.Param("tableId") // ✅ 从路由获取 tableId（新路由）
 	recordID := c.Param("recordId")
@@ -155,6 +203,18 @@ func (h *RecordHandler) DeleteRecord(c *gin.Context) {
 // BatchCreateRecords 批量创建记录
 // POST /api/v1/tables/:tableId/records/batch
 // ✅ 严格使用 response.Success
+// @Summary 批量创建记录
+// @Tags Record
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.BatchCreateRecordRequest true "批量创建请求"
+// @Success 200 {object} response.APIResponse{data=dto.BatchCreateRecordResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/batch [post]
+// @Security BearerAuth
 func (h *RecordHandler) BatchCreateRecords(c *gin.Context) {
 	tableID := c.Param("tableId")
 
@@ -186,6 +246,18 @@ func (h *RecordHandler) BatchCreateRecords(c *gin.Context) {
 // BatchUpdateRecords 批量更新记录
 // PATCH /api/v1/records/batch
 // ✅ 严格使用 response.Success
+// @Summary 批量更新记录
+// @Tags Record
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.BatchUpdateRecordRequest true "批量更新请求"
+// @Success 200 {object} response.APIResponse{data=dto.BatchUpdateRecordResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/batch [patch]
+// @Security BearerAuth
 func (h *RecordHandler) BatchUpdateRecords(c *gin.Context) {
 	// 1. 获取 tableId
 	tableID := c.Param("tableId")
@@ -222,6 +294,18 @@ func (h *RecordHandler) BatchUpdateRecords(c *gin.Context) {
 // BatchDeleteRecords 批量删除记录
 // DELETE /api/v1/tables/:tableId/records/batch
 // ✅ 严格使用 response.Success
+// @Summary 批量删除记录
+// @Tags Record
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.BatchDeleteRecordRequest true "批量删除请求"
+// @Success 200 {object} response.APIResponse{data=dto.BatchDeleteRecordResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/batch [delete]
+// @Security BearerAuth
 func (h *RecordHandler) BatchDeleteRecords(c *gin.Context) {
 	// 1. 获取 tableId
 	tableID := c.Param("tableId")
@@ -249,6 +333,17 @@ func (h *RecordHandler) BatchDeleteRecords(c *gin.Context) {
 }
 
 // ListRecords 列出表格的所有记录
+// @Summary 列出记录
+// @Tags Record
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param limit query int false "每页数量（默认 100）"
+// @Param offset query int false "偏移量"
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{list=[]dto.RecordResponse}}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records [get]
+// @Security BearerAuth
 func (h *RecordHandler) ListRecords(c *gin.Context) {
 	tableID := c.Param("tableId")
 
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReminderHandler 记录提醒规则处理器
+type ReminderHandler struct {
+	service *application.ReminderService
+}
+
+// NewReminderHandler 创建记录提醒规则处理器
+func NewReminderHandler(service *application.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		service: service,
+	}
+}
+
+// ListReminderRules 列出表的提醒规则
+// @Summary 列出提醒规则
+// @Description 获取指定表的所有日期字段提醒规则
+// @Tags 记录提醒
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse{data=[]dto.ReminderRuleResponse}
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/reminder-rules [get]
+// @Security BearerAuth
+func (h *ReminderHandler) ListReminderRules(c *gin.Context) {
+	tableID := c.Param("tableId")
+	userID := c.GetString("user_id")
+
+	result, err := h.service.ListRules(c.Request.Context(), tableID, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取提醒规则成功")
+}
+
+// CreateReminderRule 创建提醒规则
+// @Summary 创建提醒规则
+// @Description 在日期字段之前/之后 N 分钟/小时/天提醒用户字段中的用户或固定协作者，可选过滤条件
+// @Tags 记录提醒
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.CreateReminderRuleRequest true "创建提醒规则请求"
+// @Success 200 {object} response.APIResponse{data=dto.ReminderRuleResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/reminder-rules [post]
+// @Security BearerAuth
+func (h *ReminderHandler) CreateReminderRule(c *gin.Context) {
+	tableID := c.Param("tableId")
+	userID := c.GetString("user_id")
+
+	var req dto.CreateReminderRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.CreateRule(c.Request.Context(), tableID, req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "创建提醒规则成功")
+}
+
+// UpdateReminderRule 更新提醒规则
+// @Summary 更新提醒规则
+// @Tags 记录提醒
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param ruleId path string true "Rule ID"
+// @Param request body dto.UpdateReminderRuleRequest true "更新提醒规则请求"
+// @Success 200 {object} response.APIResponse{data=dto.ReminderRuleResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/reminder-rules/{ruleId} [patch]
+// @Security BearerAuth
+func (h *ReminderHandler) UpdateReminderRule(c *gin.Context) {
+	tableID := c.Param("tableId")
+	ruleID := c.Param("ruleId")
+	userID := c.GetString("user_id")
+
+	var req dto.UpdateReminderRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.UpdateRule(c.Request.Context(), tableID, ruleID, req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "更新提醒规则成功")
+}
+
+// DeleteReminderRule 删除提醒规则
+// @Summary 删除提醒规则
+// @Tags 记录提醒
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/reminder-rules/{ruleId} [delete]
+// @Security BearerAuth
+func (h *ReminderHandler) DeleteReminderRule(c *gin.Context) {
+	tableID := c.Param("tableId")
+	ruleID := c.Param("ruleId")
+	userID := c.GetString("user_id")
+
+	if err := h.service.DeleteRule(c.Request.Context(), tableID, ruleID, userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil, "删除提醒规则成功")
+}
+
+// ListRecordReminders 列出记录的提醒计划
+// @Summary 列出记录提醒
+// @Description 获取记录在各提醒规则下的下次提醒时间和状态
+// @Tags 记录提醒
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param recordId path string true "Record ID"
+// @Success 200 {object} response.APIResponse{data=[]dto.RecordReminderResponse}
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/records/{recordId}/reminders [get]
+// @Security BearerAuth
+func (h *ReminderHandler) ListRecordReminders(c *gin.Context) {
+	tableID := c.Param("tableId")
+	recordID := c.Param("recordId")
+	userID := c.GetString("user_id")
+
+	result, err := h.service.ListRecordReminders(c.Request.Context(), tableID, recordID, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取记录提醒成功")
+}
@@ -1,28 +1,51 @@
 package http
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/easyspace-ai/luckdb/server/internal/container"
+	"github.com/easyspace-ai/luckdb/server/pkg/logger"
 )
 
 // SetupRoutes 设置所有API路由
-func SetupRoutes(router *gin.Engine, cont *container.Container) {
+func SetupRoutes(router *gin.Engine, cont *container.Container, version string) {
 	// 设置静态文件服务（前端应用）
 	setupStaticFiles(router)
 
 	// API v1路由组
 	v1 := router.Group("/api/v1")
 
+	// 按客户端 IP 限流 ✨
+	rateLimit := cont.Config().RateLimit
+	if rateLimit.Enabled {
+		v1.Use(RateLimitMiddleware(cont.RateLimitStore(), "ip", rateLimit.IP, rateLimitIPKey))
+	}
+
 	// 监控端点（无需认证）
 	setupMonitoringRoutes(v1, cont)
 
 	// 认证相关路由（无需JWT中间件）
 	setupAuthRoutes(v1, cont)
 
+	// 附件直传路由（凭上传令牌，无需JWT中间件）✨
+	setupAttachmentUploadRoutes(v1, cont)
+
+	// 以上路由无需JWT认证，记录下来用于 OpenAPI 文档的认证声明 ✨
+	publicRoutes := routeKeys(router.Routes())
+
 	// 需要JWT认证的路由组
 	authRequired := v1.Group("")
 	authRequired.Use(JWTAuthMiddleware(cont.AuthService()))
+	// 按用户、访问令牌限流，并统计空间 API 调用配额 ✨
+	if rateLimit.Enabled {
+		authRequired.Use(
+			RateLimitMiddleware(cont.RateLimitStore(), "user", rateLimit.User, rateLimitUserKey),
+			RateLimitMiddleware(cont.RateLimitStore(), "token", rateLimit.Token, rateLimitTokenKey),
+		)
+	}
+	authRequired.Use(QuotaMiddleware(cont.QuotaService()))
 	{
 		// 用户相关路由
 		setupUserRoutes(authRequired, cont)
@@ -45,13 +68,58 @@ func SetupRoutes(router *gin.Engine, cont *container.Container) {
 		// 记录相关路由
 		setupRecordRoutes(authRequired, cont)
 
+		// 行级权限规则路由 ✨
+		setupRowRuleRoutes(authRequired, cont)
+
+		// 记录提醒规则路由 ✨
+		setupReminderRoutes(authRequired, cont)
+
+		// 附件相关路由 ✨
+		setupAttachmentRoutes(authRequired, cont)
+
 		// 视图相关路由
 		setupViewRoutes(authRequired, cont)
 
+		// 通知相关路由 ✨
+		setupNotificationRoutes(authRequired, cont)
+
+		// 告警规则路由 ✨
+		setupAlertRoutes(authRequired, cont)
+
+		// GraphQL 路由 ✨
+		setupGraphQLRoutes(authRequired, cont)
+
+		// 空间配额路由 ✨
+		setupQuotaRoutes(authRequired, cont)
+
 	}
 
 	// WebSocket 路由（需要认证）✨
 	setupWebSocketRoutes(router, cont)
+
+	// OpenAPI 文档 ✨
+	if cont.Config().Server.EnableSwagger {
+		setupOpenAPIRoutes(router, v1, authRequired, cont, version, publicRoutes)
+	}
+}
+
+// setupOpenAPIRoutes 设置 OpenAPI 文档路由：全局文档无需认证，表格文档按当前用户权限生成
+func setupOpenAPIRoutes(router *gin.Engine, v1, authRequired *gin.RouterGroup, cont *container.Container, version string, publicRoutes map[string]bool) {
+	handler := NewOpenAPIHandler(router.Routes, publicRoutes, version, cont.TableOpenAPIService())
+
+	v1.GET("/openapi.json", handler.GetSpec)
+	publicRoutes[http.MethodGet+" "+openAPIPrefix+"/openapi.json"] = true
+
+	authRequired.GET("/tables/:tableId/openapi.json", handler.GetTableSpec)
+}
+
+// routeKeys 路由集合（方法 + 空格 + 路径）
+func routeKeys(routes gin.RoutesInfo) map[string]bool {
+	keys := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		keys[route.Method+" "+route.Path] = true
+	}
+	return keys
 }
 
 // setupUserConfigRoutes 设置用户配置路由
@@ -66,6 +134,51 @@ func setupUserConfigRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	}
 }
 
+// setupNotificationRoutes 设置通知路由
+// 通知、订阅和偏好只能访问当前用户自己的；模板管理和主动发送通知需要管理员权限
+func setupNotificationRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewNotificationHandler(cont.NotificationService(), logger.Logger)
+	adminOnly := AdminRequiredMiddleware()
+
+	notifications := rg.Group("/notifications")
+	{
+		notifications.GET("", handler.ListNotifications)
+		notifications.GET("/stats", handler.GetNotificationStats)
+		notifications.POST("/mark-read", handler.MarkNotificationsRead)
+		notifications.POST("/mark-all-read", handler.MarkAllNotificationsRead)
+		notifications.GET("/preferences", handler.GetPreference)
+		notifications.PATCH("/preferences", handler.UpdatePreference)
+		notifications.GET("/:id", handler.GetNotification)
+		notifications.PUT("/:id", handler.UpdateNotification)
+		notifications.DELETE("/:id", handler.DeleteNotification)
+
+		notifications.POST("", adminOnly, handler.CreateNotification)
+		notifications.POST("/send", adminOnly, handler.SendNotification)
+		notifications.POST("/send-to-subscribers", adminOnly, handler.SendNotificationToSubscribers)
+		notifications.POST("/cleanup", adminOnly, handler.CleanupExpiredNotifications)
+	}
+
+	subscriptions := rg.Group("/notification-subscriptions")
+	{
+		subscriptions.GET("", handler.GetUserSubscriptions)
+		subscriptions.POST("", handler.CreateSubscription)
+		subscriptions.DELETE("", handler.DeleteUserSubscriptions)
+		subscriptions.GET("/:id", handler.GetSubscription)
+		subscriptions.PUT("/:id", handler.UpdateSubscription)
+		subscriptions.DELETE("/:id", handler.DeleteSubscription)
+	}
+
+	templates := rg.Group("/notification-templates", adminOnly)
+	{
+		templates.GET("", handler.ListTemplates)
+		templates.POST("", handler.CreateTemplate)
+		templates.GET("/type/:type", handler.GetTemplateByType)
+		templates.GET("/:id", handler.GetTemplate)
+		templates.PUT("/:id", handler.UpdateTemplate)
+		templates.DELETE("/:id", handler.DeleteTemplate)
+	}
+}
+
 // setupSpaceRoutes 设置空间路由
 func setupSpaceRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	handler := NewSpaceHandler(cont.SpaceService())
@@ -92,6 +205,7 @@ func setupSpaceRoutes(rg *gin.RouterGroup, cont *container.Container) {
 func setupBaseRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	handler := NewBaseHandler(cont.BaseService(), cont.TableService())
 	collabHandler := NewCollaboratorHandler(cont.CollaboratorService())
+	aiHandler := NewAIFieldHandler(cont.AIFieldService())
 
 	// Space下的Base
 	spaces := rg.Group("/spaces")
@@ -117,6 +231,9 @@ func setupBaseRoutes(rg *gin.RouterGroup, cont *container.Container) {
 		bases.POST("/:baseId/collaborators", collabHandler.AddBaseCollaborator)
 		bases.PATCH("/:baseId/collaborators/:collaboratorId", collabHandler.UpdateBaseCollaborator)
 		bases.DELETE("/:baseId/collaborators/:collaboratorId", collabHandler.RemoveBaseCollaborator)
+
+		// Base AI 用量 ✨
+		bases.GET("/:baseId/ai/usage", aiHandler.GetBaseUsage)
 	}
 }
 
@@ -149,6 +266,8 @@ func setupTableRoutes(rg *gin.RouterGroup, cont *container.Container) {
 // setupFieldRoutes 设置字段路由
 func setupFieldRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	handler := NewFieldHandler(cont.FieldService())
+	linkHandler := NewLinkRecordHandler(cont.LinkRecordService())
+	aiHandler := NewAIFieldHandler(cont.AIFieldService())
 
 	// 表格下的字段
 	tables := rg.Group("/tables")
@@ -163,6 +282,9 @@ func setupFieldRoutes(rg *gin.RouterGroup, cont *container.Container) {
 		fields.GET("/:fieldId", handler.GetField)
 		fields.PATCH("/:fieldId", handler.UpdateField) // ✅ 部分更新使用PATCH
 		fields.DELETE("/:fieldId", handler.DeleteField)
+		fields.GET("/:fieldId/link-candidates", linkHandler.ListLinkCandidates) // ✨ 关联记录选择器
+		fields.POST("/:fieldId/ai/generate", aiHandler.GenerateField)           // ✨ 重新生成 AI 字段
+		fields.GET("/:fieldId/ai/jobs/:jobId", aiHandler.GetGenerateJob)        // ✨ 查询后台生成任务
 	}
 }
 
@@ -174,6 +296,7 @@ func setupRecordRoutes(rg *gin.RouterGroup, cont *container.Container) {
 		cont.CalculationService(), // ✅ 添加
 		cont.RecordRepository(),   // ✅ 添加
 	)
+	buttonHandler := NewButtonFieldHandler(cont.ButtonService())
 
 	// 表格下的记录（对齐 Teable 架构：所有记录操作都需要 tableId）
 	tables := rg.Group("/tables")
@@ -187,6 +310,7 @@ func setupRecordRoutes(rg *gin.RouterGroup, cont *container.Container) {
 		tables.GET("/:tableId/records/:recordId", handler.GetRecord)
 		tables.PATCH("/:tableId/records/:recordId", handler.UpdateRecord) // ✅ 对齐 Teable
 		tables.DELETE("/:tableId/records/:recordId", handler.DeleteRecord)
+		tables.POST("/:tableId/records/:recordId/buttons/:fieldId/click", buttonHandler.Click) // ✨ 点击按钮字段
 
 		// 批量操作
 		tables.PATCH("/:tableId/records/batch", handler.BatchUpdateRecords)
@@ -205,6 +329,93 @@ func setupRecordRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	}
 }
 
+// setupRowRuleRoutes 设置行级权限规则路由
+func setupRowRuleRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewRowRuleHandler(cont.RowPermissionService())
+
+	tables := rg.Group("/tables")
+	{
+		tables.GET("/:tableId/row-rules", handler.ListRowRules)
+		tables.POST("/:tableId/row-rules", handler.CreateRowRule)
+		tables.PATCH("/:tableId/row-rules/:ruleId", handler.UpdateRowRule)
+		tables.DELETE("/:tableId/row-rules/:ruleId", handler.DeleteRowRule)
+	}
+}
+
+// setupReminderRoutes 设置记录提醒规则路由
+func setupReminderRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewReminderHandler(cont.ReminderService())
+
+	tables := rg.Group("/tables")
+	{
+		tables.GET("/:tableId/reminder-rules", handler.ListReminderRules)
+		tables.POST("/:tableId/reminder-rules", handler.CreateReminderRule)
+		tables.PATCH("/:tableId/reminder-rules/:ruleId", handler.UpdateReminderRule)
+		tables.DELETE("/:tableId/reminder-rules/:ruleId", handler.DeleteReminderRule)
+		tables.GET("/:tableId/records/:recordId/reminders", handler.ListRecordReminders)
+	}
+}
+
+// setupGraphQLRoutes 设置 GraphQL 路由：每个 Base 一个端点，Schema 由表和字段生成
+func setupGraphQLRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewGraphQLHandler(cont.GraphQLService())
+
+	bases := rg.Group("/bases")
+	{
+		bases.POST("/:baseId/graphql", handler.Execute)
+		bases.GET("/:baseId/graphql", handler.ExecuteQuery)
+		bases.GET("/:baseId/graphql/schema", handler.GetSchema)
+	}
+}
+
+// setupQuotaRoutes 设置空间配额路由
+func setupQuotaRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewQuotaHandler(cont.QuotaService())
+
+	rg.GET("/spaces/:spaceId/quota", handler.GetSpaceQuota)
+}
+
+// setupAttachmentUploadRoutes 设置附件直传路由
+// 上传令牌由签名接口签发，本身即为上传凭证（限定表/字段/记录、大小与类型，24小时过期）
+func setupAttachmentUploadRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewAttachmentHandler(cont.AttachmentService(), logger.Logger)
+
+	rg.POST("/attachments/upload/:token", handler.UploadFile)
+
+	// ✨ 大文件断点续传（tus 协议）
+	sessions := rg.Group("/attachments/upload/:token/sessions")
+	{
+		sessions.POST("", handler.CreateUploadSession)
+		sessions.HEAD("/:uploadId", handler.HeadUploadSession)
+		sessions.PATCH("/:uploadId", handler.PatchUploadSession)
+		sessions.POST("/:uploadId/finalize", handler.FinalizeUploadSession)
+		sessions.DELETE("/:uploadId", handler.AbortUploadSession)
+	}
+}
+
+// setupAttachmentRoutes 设置附件路由
+func setupAttachmentRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewAttachmentHandler(cont.AttachmentService(), logger.Logger)
+	adminOnly := AdminRequiredMiddleware()
+
+	attachments := rg.Group("/attachments")
+	{
+		attachments.POST("/signature", handler.GenerateSignature)
+		attachments.POST("/notify/:token", handler.NotifyUpload)
+		attachments.POST("/instant/:token", handler.InstantUpload)            // 相同内容秒传
+		attachments.POST("/cleanup", adminOnly, handler.CleanupExpiredTokens) // 全局清理，后台任务也会定时执行
+		attachments.POST("/gc", adminOnly, handler.CollectOrphanBlobs)        // 回收无引用的文件内容
+		attachments.GET("", handler.ListAttachments)
+		attachments.GET("/read/*path", handler.ReadFile) // 支持 Range 分段下载
+		attachments.GET("/:id", handler.GetAttachment)
+		attachments.DELETE("/:id", handler.DeleteFile)
+	}
+
+	rg.GET("/tables/:tableId/attachments/stats", handler.GetAttachmentStats)
+	rg.GET("/spaces/:spaceId/storage/usage", handler.GetSpaceStorageUsage) // 空间存储用量与配额
+	rg.GET("/spaces/:spaceId/storage/dedup", handler.GetSpaceDedupReport)  // 空间附件去重节省
+}
+
 // setupUserRoutes 设置用户路由
 func setupUserRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	handler := NewUserHandler(cont.UserService())
@@ -225,6 +436,10 @@ func setupAuthRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	handler := NewAuthHandler(cont.AuthService())
 
 	auth := rg.Group("/auth")
+	// 认证接口按 IP 单独限流，防止暴力破解 ✨
+	if rateLimit := cont.Config().RateLimit; rateLimit.Enabled {
+		auth.Use(RateLimitMiddleware(cont.RateLimitStore(), "auth", rateLimit.Auth, rateLimitIPKey))
+	}
 	{
 		auth.POST("/register", handler.Register)    // 注册
 		auth.POST("/login", handler.Login)          // 登录
@@ -262,6 +477,15 @@ func setupViewRoutes(rg *gin.RouterGroup, cont *container.Container) {
 		views.PATCH("/:viewId/options", handler.UpdateViewOptions)        // ✅ 更新选项
 		views.PATCH("/:viewId/order", handler.UpdateViewOrder)            // ✅ 更新排序位置
 
+		// 视图数据
+		views.GET("/:viewId/timeline", handler.GetTimelineData)           // ✨ 时间线数据（按窗口查询）
+		views.GET("/:viewId/kanban", handler.GetKanbanData)               // ✨ 看板数据（按列分页）
+		views.POST("/:viewId/kanban/move", handler.MoveKanbanCard)        // ✨ 移动看板卡片
+		views.GET("/:viewId/calendar", handler.GetCalendarData)           // ✨ 日历数据（按窗口和时区查询）
+		views.GET("/:viewId/groups", handler.GetGridGroups)               // ✨ 分组树（记录数和列统计）
+		views.GET("/:viewId/groups/records", handler.GetGridGroupRecords) // ✨ 分组内记录（分页）
+		views.GET("/:viewId/statistics", handler.GetViewStatistics)       // ✨ 列统计
+
 		// 分享功能
 		views.POST("/:viewId/enable-share", handler.EnableShare)        // 启用分享
 		views.POST("/:viewId/disable-share", handler.DisableShare)      // 禁用分享
@@ -285,7 +509,7 @@ func setupViewRoutes(rg *gin.RouterGroup, cont *container.Container) {
 
 // setupWebSocketRoutes 设置WebSocket路由 ✨
 func setupWebSocketRoutes(router *gin.Engine, cont *container.Container) {
-	handler := NewWebSocketHandler(cont.WebSocketManager(), cont.AuthService())
+	handler := NewWebSocketHandler(cont.WebSocketManager(), cont.WebSocketSubscriptionGuard(), cont.WebSocketLiveQueries(), cont.AuthService())
 
 	// WebSocket 路由
 	router.GET("/ws", handler.HandleWebSocket) // WebSocket 连接入口
@@ -301,6 +525,29 @@ func setupMonitoringRoutes(rg *gin.RouterGroup, cont *container.Container) {
 	}
 }
 
+// setupAlertRoutes 设置告警规则路由（仅管理员）
+func setupAlertRoutes(rg *gin.RouterGroup, cont *container.Container) {
+	handler := NewAlertHandler(cont.AlertService())
+
+	monitoring := rg.Group("/monitoring", AdminRequiredMiddleware())
+	{
+		monitoring.GET("/alert-channels", handler.ListAlertChannels)
+
+		monitoring.GET("/alert-rules", handler.ListAlertRules)
+		monitoring.POST("/alert-rules", handler.CreateAlertRule)
+		monitoring.GET("/alert-rules/:ruleId", handler.GetAlertRule)
+		monitoring.PATCH("/alert-rules/:ruleId", handler.UpdateAlertRule)
+		monitoring.DELETE("/alert-rules/:ruleId", handler.DeleteAlertRule)
+		monitoring.POST("/alert-rules/:ruleId/test", handler.TestAlertRule)
+
+		monitoring.GET("/alerts", handler.ListAlerts)
+
+		monitoring.GET("/alert-silences", handler.ListAlertSilences)
+		monitoring.POST("/alert-silences", handler.CreateAlertSilence)
+		monitoring.DELETE("/alert-silences/:silenceId", handler.DeleteAlertSilence)
+	}
+}
+
 // setupStaticFiles 设置静态文件服务
 func setupStaticFiles(router *gin.Engine) {
 	// 创建静态文件处理器
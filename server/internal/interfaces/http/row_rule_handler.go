@@ -0,0 +1,135 @@
+package http
+
+import (
+	"github.com/easyspace-ai/luckdb/server/internal/application"
+	"github.com/easyspace-ai/luckdb/server/internal/application/dto"
+	"github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RowRuleHandler 行级权限规则处理器
+type RowRuleHandler struct {
+	service *application.RowPermissionService
+}
+
+// NewRowRuleHandler 创建行级权限规则处理器
+func NewRowRuleHandler(service *application.RowPermissionService) *RowRuleHandler {
+	return &RowRuleHandler{
+		service: service,
+	}
+}
+
+// ListRowRules 列出表的行级规则
+// @Summary 列出行级规则
+// @Description 获取指定表的所有行级访问规则
+// @Tags 行级权限
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse{data=[]dto.RowRuleResponse}
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/row-rules [get]
+// @Security BearerAuth
+func (h *RowRuleHandler) ListRowRules(c *gin.Context) {
+	tableID := c.Param("tableId")
+	userID := c.GetString("user_id")
+
+	result, err := h.service.ListRules(c.Request.Context(), tableID, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "获取行级规则成功")
+}
+
+// CreateRowRule 创建行级规则
+// @Summary 创建行级规则
+// @Description 为表添加行级访问规则，过滤值支持 @me 和 @myGroups 占位符
+// @Tags 行级权限
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.CreateRowRuleRequest true "创建行级规则请求"
+// @Success 200 {object} response.APIResponse{data=dto.RowRuleResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/row-rules [post]
+// @Security BearerAuth
+func (h *RowRuleHandler) CreateRowRule(c *gin.Context) {
+	tableID := c.Param("tableId")
+	userID := c.GetString("user_id")
+
+	var req dto.CreateRowRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.CreateRule(c.Request.Context(), tableID, req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "创建行级规则成功")
+}
+
+// UpdateRowRule 更新行级规则
+// @Summary 更新行级规则
+// @Tags 行级权限
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param ruleId path string true "Rule ID"
+// @Param request body dto.UpdateRowRuleRequest true "更新行级规则请求"
+// @Success 200 {object} response.APIResponse{data=dto.RowRuleResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/row-rules/{ruleId} [patch]
+// @Security BearerAuth
+func (h *RowRuleHandler) UpdateRowRule(c *gin.Context) {
+	tableID := c.Param("tableId")
+	ruleID := c.Param("ruleId")
+	userID := c.GetString("user_id")
+
+	var req dto.UpdateRowRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	result, err := h.service.UpdateRule(c.Request.Context(), tableID, ruleID, req, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result, "更新行级规则成功")
+}
+
+// DeleteRowRule 删除行级规则
+// @Summary 删除行级规则
+// @Tags 行级权限
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param ruleId path string true "Rule ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/row-rules/{ruleId} [delete]
+// @Security BearerAuth
+func (h *RowRuleHandler) DeleteRowRule(c *gin.Context) {
+	tableID := c.Param("tableId")
+	ruleID := c.Param("ruleId")
+	userID := c.GetString("user_id")
+
+	if err := h.service.DeleteRule(c.Request.Context(), tableID, ruleID, userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil, "删除行级规则成功")
+}
@@ -22,6 +22,15 @@ func NewSpaceHandler(spaceService *application.SpaceService) *SpaceHandler {
 }
 
 // CreateSpace 创建空间
+// @Summary 创建空间
+// @Tags Space
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateSpaceRequest true "创建空间请求"
+// @Success 200 {object} response.APIResponse{data=dto.SpaceResponse}
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/spaces [post]
+// @Security BearerAuth
 func (h *SpaceHandler) CreateSpace(c *gin.Context) {
 	var req dto.CreateSpaceRequest
 	if err := ValidateBindJSON(c, &req); err != nil {
@@ -45,6 +54,15 @@ func (h *SpaceHandler) CreateSpace(c *gin.Context) {
 }
 
 // GetSpace 获取空间详情
+// @Summary 获取空间
+// @Tags Space
+// @Produce json
+// @Param spaceId path string true "Space ID"
+// @Success 200 {object} response.APIResponse{data=dto.SpaceResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/spaces/{spaceId} [get]
+// @Security BearerAuth
 func (h *SpaceHandler) GetSpace(c *gin.Context) {
 	spaceID := c.Param("spaceId")
 
@@ -58,6 +76,18 @@ func (h *SpaceHandler) GetSpace(c *gin.Context) {
 }
 
 // UpdateSpace 更新空间
+// @Summary 更新空间
+// @Tags Space
+// @Accept json
+// @Produce json
+// @Param spaceId path string true "Space ID"
+// @Param request body dto.UpdateSpaceRequest true "更新空间请求"
+// @Success 200 {object} response.APIResponse{data=dto.SpaceResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/spaces/{spaceId} [patch]
+// @Security BearerAuth
 func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
 	spaceID := c.Param("spaceId")
 
@@ -77,6 +107,15 @@ func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
 }
 
 // DeleteSpace 删除空间
+// @Summary 删除空间
+// @Tags Space
+// @Produce json
+// @Param spaceId path string true "Space ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/spaces/{spaceId} [delete]
+// @Security BearerAuth
 func (h *SpaceHandler) DeleteSpace(c *gin.Context) {
 	spaceID := c.Param("spaceId")
 
@@ -89,6 +128,13 @@ func (h *SpaceHandler) DeleteSpace(c *gin.Context) {
 }
 
 // ListSpaces 列出用户的所有空间
+// @Summary 列出空间
+// @Description 返回当前用户拥有或参与协作的空间
+// @Tags Space
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dto.SpaceResponse}
+// @Router /api/v1/spaces [get]
+// @Security BearerAuth
 func (h *SpaceHandler) ListSpaces(c *gin.Context) {
 	userID := c.GetString("user_id")
 	if userID == "" {
@@ -23,6 +23,18 @@ func NewTableHandler(tableService *application.TableService) *TableHandler {
 }
 
 // CreateTable 创建表格
+// @Summary 创建表格
+// @Tags Table
+// @Accept json
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Param request body dto.CreateTableRequest true "创建表格请求"
+// @Success 200 {object} response.APIResponse{data=dto.TableResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId}/tables [post]
+// @Security BearerAuth
 func (h *TableHandler) CreateTable(c *gin.Context) {
 	var req dto.CreateTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -46,6 +58,15 @@ func (h *TableHandler) CreateTable(c *gin.Context) {
 }
 
 // GetTable 获取表格详情
+// @Summary 获取表格
+// @Tags Table
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse{data=dto.TableResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId} [get]
+// @Security BearerAuth
 func (h *TableHandler) GetTable(c *gin.Context) {
 	tableID := c.Param("tableId")
 
@@ -59,6 +80,18 @@ func (h *TableHandler) GetTable(c *gin.Context) {
 }
 
 // UpdateTable 更新表格
+// @Summary 更新表格
+// @Tags Table
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.UpdateTableRequest true "更新表格请求"
+// @Success 200 {object} response.APIResponse{data=dto.TableResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId} [patch]
+// @Security BearerAuth
 func (h *TableHandler) UpdateTable(c *gin.Context) {
 	tableID := c.Param("tableId")
 
@@ -78,6 +111,15 @@ func (h *TableHandler) UpdateTable(c *gin.Context) {
 }
 
 // DeleteTable 删除表格
+// @Summary 删除表格
+// @Tags Table
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId} [delete]
+// @Security BearerAuth
 func (h *TableHandler) DeleteTable(c *gin.Context) {
 	tableID := c.Param("tableId")
 
@@ -90,6 +132,15 @@ func (h *TableHandler) DeleteTable(c *gin.Context) {
 }
 
 // ListTables 列出Base下的所有表格
+// @Summary 列出表格
+// @Tags Table
+// @Produce json
+// @Param baseId path string true "Base ID"
+// @Success 200 {object} response.APIResponse{data=[]dto.TableResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/bases/{baseId}/tables [get]
+// @Security BearerAuth
 func (h *TableHandler) ListTables(c *gin.Context) {
 	baseID := c.Param("baseId")
 
@@ -104,6 +155,18 @@ func (h *TableHandler) ListTables(c *gin.Context) {
 
 // RenameTable 重命名表
 // PUT /api/v1/tables/:tableId/rename
+// @Summary 重命名表格
+// @Tags Table
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.RenameTableRequest true "重命名请求"
+// @Success 200 {object} response.APIResponse{data=dto.TableResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/rename [put]
+// @Security BearerAuth
 func (h *TableHandler) RenameTable(c *gin.Context) {
 	tableID := c.Param("tableId")
 	if tableID == "" {
@@ -137,6 +200,18 @@ func (h *TableHandler) RenameTable(c *gin.Context) {
 
 // DuplicateTable 复制表
 // POST /api/v1/tables/:tableId/duplicate
+// @Summary 复制表格
+// @Tags Table
+// @Accept json
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Param request body dto.DuplicateTableRequest true "复制表格请求"
+// @Success 200 {object} response.APIResponse{data=dto.TableResponse}
+// @Failure 400 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/duplicate [post]
+// @Security BearerAuth
 func (h *TableHandler) DuplicateTable(c *gin.Context) {
 	tableID := c.Param("tableId")
 	if tableID == "" {
@@ -181,6 +256,15 @@ func (h *TableHandler) DuplicateTable(c *gin.Context) {
 
 // GetTableUsage 获取表用量信息
 // GET /api/v1/tables/:tableId/usage
+// @Summary 获取表格用量
+// @Tags Table
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse{data=dto.TableUsageResponse}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/usage [get]
+// @Security BearerAuth
 func (h *TableHandler) GetTableUsage(c *gin.Context) {
 	tableID := c.Param("tableId")
 	if tableID == "" {
@@ -208,6 +292,16 @@ func (h *TableHandler) GetTableUsage(c *gin.Context) {
 
 // GetTableManagementMenu 获取表管理菜单信息
 // GET /api/v1/tables/:tableId/menu
+// @Summary 获取表格管理菜单
+// @Description 返回表格信息、用量和可用的管理操作
+// @Tags Table
+// @Produce json
+// @Param tableId path string true "Table ID"
+// @Success 200 {object} response.APIResponse{data=object}
+// @Failure 403 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/tables/{tableId}/menu [get]
+// @Security BearerAuth
 func (h *TableHandler) GetTableManagementMenu(c *gin.Context) {
 	tableID := c.Param("tableId")
 	if tableID == "" {
@@ -148,16 +148,13 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "用户ID"
-// @Param request body ChangePasswordRequest true "修改密码请求"
+// @Param request body dto.ChangePasswordRequest true "修改密码请求"
 // @Success 200 {object} gin.H
 // @Router /users/{id}/password [put]
 func (h *UserHandler) ChangePassword(c *gin.Context) {
 	userID := c.Param("id")
 
-	var req struct {
-		OldPassword string `json:"old_password" binding:"required"`
-		NewPassword string `json:"new_password" binding:"required,min=8"`
-	}
+	var req dto.ChangePasswordRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
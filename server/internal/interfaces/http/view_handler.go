@@ -70,6 +70,184 @@ func (h *ViewHandler) GetView(c *gin.Context) {
 	response.Success(c, view, "操作成功")
 }
 
+// GetTimelineData 获取时间线视图数据 ✨
+// @Summary 获取时间线视图数据
+// @Description 返回与查询窗口重叠的记录，超出窗口的起止时间会被裁剪到窗口边界
+// @Tags View
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Param start query string true "窗口开始日期"
+// @Param end query string true "窗口结束日期"
+// @Success 200 {object} dto.TimelineDataResponse
+// @Router /api/v1/views/{viewId}/timeline [get]
+func (h *ViewHandler) GetTimelineData(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	data, err := h.viewService.GetTimelineData(c.Request.Context(), viewID, c.Query("start"), c.Query("end"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取时间线数据成功")
+}
+
+// GetKanbanData 获取看板视图数据 ✨
+// @Summary 获取看板视图数据
+// @Description 按分组字段返回看板列，每列包含卡片总数和当前页卡片
+// @Tags View
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Param stackId query string false "只加载指定列"
+// @Param offset query int false "每列偏移量"
+// @Param limit query int false "每列卡片数"
+// @Success 200 {object} dto.KanbanDataResponse
+// @Router /api/v1/views/{viewId}/kanban [get]
+func (h *ViewHandler) GetKanbanData(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	var query dto.KanbanDataQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	data, err := h.viewService.GetKanbanData(c.Request.Context(), viewID, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取看板数据成功")
+}
+
+// GetGridGroups 获取分组网格的分组树 ✨
+// @Summary 获取分组网格的分组树
+// @Description 按视图分组配置返回分组树，包含每个分组的记录数和列统计
+// @Tags View
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Param statistics query string false "列统计，格式为 fieldId:func，多个以逗号分隔（sum, avg, min, max, empty, filled, unique, percentFilled, earliest, latest, checked），默认使用列配置中的统计"
+// @Success 200 {object} dto.GridGroupsResponse
+// @Router /api/v1/views/{viewId}/groups [get]
+func (h *ViewHandler) GetGridGroups(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	var query dto.GridGroupsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	data, err := h.viewService.GetGridGroups(c.Request.Context(), viewID, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取分组数据成功")
+}
+
+// GetGridGroupRecords 分页获取分组内的记录 ✨
+// @Summary 分页获取分组内的记录
+// @Description 按分组ID返回分组内的记录，分页顺序稳定
+// @Tags View
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Param groupId query string true "分组ID"
+// @Param offset query int false "偏移量"
+// @Param limit query int false "每页记录数"
+// @Success 200 {object} dto.GridGroupRecordsResponse
+// @Router /api/v1/views/{viewId}/groups/records [get]
+func (h *ViewHandler) GetGridGroupRecords(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	var query dto.GridGroupRecordsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	data, err := h.viewService.GetGridGroupRecords(c.Request.Context(), viewID, query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取分组记录成功")
+}
+
+// GetViewStatistics 获取视图的列统计 ✨
+// @Summary 获取视图的列统计
+// @Description 按列配置中的统计函数计算视图内记录的汇总值，遵循视图过滤条件和行级权限
+// @Tags View
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Success 200 {object} dto.ViewStatisticsResponse
+// @Router /api/v1/views/{viewId}/statistics [get]
+func (h *ViewHandler) GetViewStatistics(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	data, err := h.viewService.GetViewStatistics(c.Request.Context(), viewID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取列统计成功")
+}
+
+// MoveKanbanCard 移动看板卡片 ✨
+// @Summary 移动看板卡片
+// @Description 更新卡片记录的分组字段，并可放到参照卡片之前或之后
+// @Tags View
+// @Accept json
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Param request body dto.MoveKanbanCardRequest true "移动请求"
+// @Success 200 {object} dto.RecordResponse
+// @Router /api/v1/views/{viewId}/kanban/move [post]
+func (h *ViewHandler) MoveKanbanCard(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	var req dto.MoveKanbanCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.ErrBadRequest.WithDetails(err.Error()))
+		return
+	}
+
+	record, err := h.viewService.MoveKanbanCard(c.Request.Context(), viewID, req, c.GetString("user_id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, record, "移动卡片成功")
+}
+
+// GetCalendarData 获取日历视图数据 ✨
+// @Summary 获取日历视图数据
+// @Description 返回与查询窗口相交的事件（含跨天事件），时间按指定时区输出
+// @Tags View
+// @Produce json
+// @Param viewId path string true "视图ID"
+// @Param start query string true "窗口开始日期"
+// @Param end query string true "窗口结束日期（包含当天）"
+// @Param timezone query string false "IANA 时区，默认 UTC"
+// @Success 200 {object} dto.CalendarDataResponse
+// @Router /api/v1/views/{viewId}/calendar [get]
+func (h *ViewHandler) GetCalendarData(c *gin.Context) {
+	viewID := c.Param("viewId")
+
+	data, err := h.viewService.GetCalendarData(c.Request.Context(), viewID, c.Query("start"), c.Query("end"), c.Query("timezone"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, data, "获取日历数据成功")
+}
+
 // ListViews 获取表格的所有视图
 // @Summary 获取表格视图列表
 // @Tags View
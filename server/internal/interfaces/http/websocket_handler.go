@@ -15,9 +15,12 @@ type WebSocketHandler struct {
 }
 
 // NewWebSocketHandler 创建WebSocket处理器
-func NewWebSocketHandler(manager *wsService.Manager, authService *application.AuthService) *WebSocketHandler {
+func NewWebSocketHandler(manager *wsService.Manager, guard *wsService.SubscriptionGuard, liveQueries *wsService.LiveQueryManager, authService *application.AuthService) *WebSocketHandler {
+	handler := wsService.NewHandler(manager, logger.Logger)
+	handler.SetSubscriptionGuard(guard)      // ✨ 订阅授权
+	handler.SetLiveQueryManager(liveQueries) // ✨ 视图实时查询
 	return &WebSocketHandler{
-		handler:     wsService.NewHandler(manager, logger.Logger),
+		handler:     handler,
 		authService: authService,
 	}
 }
@@ -49,6 +52,9 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 
 		logger.Info("WebSocket token验证成功",
 			logger.String("user_id", claims.UserID))
+	} else if shareID := c.Query("share_id"); shareID != "" {
+		// ✨ 未登录时可通过分享链接匿名连接，只能订阅被分享的视图
+		c.Set("share_id", shareID)
 	}
 
 	// 3. 委托给 domain 层的 Handler 处理
@@ -12,6 +12,7 @@ import (
 	"github.com/easyspace-ai/luckdb/server/internal/config"
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/cache"
 	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/database/models"
+	"github.com/easyspace-ai/luckdb/server/internal/infrastructure/observability"
 	"github.com/easyspace-ai/luckdb/server/pkg/authctx"
 	appErrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
 	"github.com/easyspace-ai/luckdb/server/pkg/logger"
@@ -97,6 +98,7 @@ func (s *JWTAuthService) GetUserFromToken(ctx context.Context, tokenString strin
 	var user models.User
 	cacheKey := cache.BuildCacheKey(cache.UserCachePrefix, claims.UserID)
 	err = s.cacheClient.Get(ctx, cacheKey, &user)
+	observability.RecordCacheLookup("redis", err == nil)
 	if err == nil {
 		return &user, nil
 	}
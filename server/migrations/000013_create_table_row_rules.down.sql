@@ -0,0 +1,2 @@
+-- 删除行级访问规则表
+DROP TABLE IF EXISTS table_row_rule;
@@ -0,0 +1,28 @@
+-- =====================================================
+-- Migration: 000013_create_table_row_rules
+-- Description: 创建行级访问规则表
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS table_row_rule (
+    id VARCHAR(30) PRIMARY KEY,
+    table_id VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    roles JSONB,
+    actions JSONB NOT NULL,
+    filter JSONB NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(30) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_modified_time TIMESTAMP,
+    deleted_time TIMESTAMP
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS idx_table_row_rule_table_id ON table_row_rule(table_id);
+CREATE INDEX IF NOT EXISTS idx_table_row_rule_deleted_time ON table_row_rule(deleted_time);
+
+-- 注释
+COMMENT ON TABLE table_row_rule IS '行级访问规则表';
+COMMENT ON COLUMN table_row_rule.roles IS '适用角色列表，为空表示所有角色';
+COMMENT ON COLUMN table_row_rule.actions IS '适用操作：record|read, record|update, record|delete, record|create';
+COMMENT ON COLUMN table_row_rule.filter IS '行范围过滤器，支持 @me / @myGroups 占位符';
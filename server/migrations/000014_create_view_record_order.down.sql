@@ -0,0 +1,2 @@
+-- 删除视图内记录手动顺序表
+DROP TABLE IF EXISTS view_record_order;
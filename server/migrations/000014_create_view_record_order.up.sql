@@ -0,0 +1,19 @@
+-- =====================================================
+-- Migration: 000014_create_view_record_order
+-- Description: 创建视图内记录手动顺序表（看板拖拽排序）
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS view_record_order (
+    view_id VARCHAR(30) NOT NULL,
+    record_id VARCHAR(30) NOT NULL,
+    sort_order DOUBLE PRECISION NOT NULL,
+    last_modified_time TIMESTAMP,
+    PRIMARY KEY (view_id, record_id)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS idx_view_record_order_sort ON view_record_order(view_id, sort_order);
+
+-- 注释
+COMMENT ON TABLE view_record_order IS '视图内记录手动顺序表';
+COMMENT ON COLUMN view_record_order.sort_order IS '顺序值，越小越靠前；未设置的记录排在最后';
@@ -0,0 +1,2 @@
+-- 删除 AI 字段 token 用量表
+DROP TABLE IF EXISTS ai_usage;
@@ -0,0 +1,20 @@
+-- =====================================================
+-- Migration: 000015_create_ai_usage
+-- Description: 创建 AI 字段 token 用量表（按 Base 计量）
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS ai_usage (
+    base_id VARCHAR(30) NOT NULL,
+    usage_date VARCHAR(10) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    completion_tokens BIGINT NOT NULL DEFAULT 0,
+    last_modified_time TIMESTAMP,
+    PRIMARY KEY (base_id, usage_date, provider, model)
+);
+
+-- 注释
+COMMENT ON TABLE ai_usage IS 'AI 字段 token 用量表';
+COMMENT ON COLUMN ai_usage.usage_date IS '统计日期（UTC），格式 YYYY-MM-DD';
@@ -0,0 +1,5 @@
+-- 删除空间存储用量表与上传令牌表
+-- attachments / attachments_table 同时由 AutoMigrate 模型维护，回滚时保留
+DROP TABLE IF EXISTS space_storage_usage;
+DROP TABLE IF EXISTS upload_tokens;
+DROP INDEX IF EXISTS idx_attachments_table_token;
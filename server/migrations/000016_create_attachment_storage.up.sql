@@ -0,0 +1,82 @@
+-- =====================================================
+-- Migration: 000016_create_attachment_storage
+-- Description: 附件上传链路：附件表、上传令牌、附件单元格引用、空间存储用量
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    token VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    presigned_url VARCHAR(500),
+    width INT,
+    height INT,
+    small_thumbnail VARCHAR(500),
+    large_thumbnail VARCHAR(500),
+    table_id VARCHAR(30) NOT NULL,
+    field_id VARCHAR(30) NOT NULL,
+    record_id VARCHAR(30) NOT NULL,
+    created_by VARCHAR(30) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_time TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_token ON attachments(token);
+CREATE INDEX IF NOT EXISTS idx_attachments_table_id ON attachments(table_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_path ON attachments(path);
+
+-- 早期的 Prisma 兼容模型给 attachments 表加过 NOT NULL 的 hash/mimetype 列，当前写入路径不再填写
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'attachments' AND column_name = 'hash') THEN
+        ALTER TABLE attachments ALTER COLUMN hash DROP NOT NULL;
+    END IF;
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'attachments' AND column_name = 'mimetype') THEN
+        ALTER TABLE attachments ALTER COLUMN mimetype DROP NOT NULL;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS upload_tokens (
+    token VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(30) NOT NULL,
+    table_id VARCHAR(30) NOT NULL,
+    field_id VARCHAR(30) NOT NULL,
+    record_id VARCHAR(30) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    max_size BIGINT NOT NULL,
+    allowed_types JSON,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_tokens_expires_at ON upload_tokens(expires_at);
+
+CREATE TABLE IF NOT EXISTS attachments_table (
+    id VARCHAR(30) PRIMARY KEY,
+    attachment_id VARCHAR(30) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    token VARCHAR(100) NOT NULL,
+    table_id VARCHAR(30) NOT NULL,
+    record_id VARCHAR(30) NOT NULL,
+    field_id VARCHAR(30) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(30) NOT NULL,
+    last_modified_by VARCHAR(50),
+    last_modified_time TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_att_table_record ON attachments_table(table_id, record_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_table_token ON attachments_table(token);
+
+CREATE TABLE IF NOT EXISTS space_storage_usage (
+    space_id VARCHAR(30) PRIMARY KEY,
+    used_bytes BIGINT NOT NULL DEFAULT 0,
+    file_count BIGINT NOT NULL DEFAULT 0,
+    last_modified_time TIMESTAMP
+);
+
+-- 注释
+COMMENT ON TABLE attachments_table IS '附件单元格引用：附件在最后一个引用被清除后删除';
+COMMENT ON TABLE space_storage_usage IS '空间附件存储用量（配额计量）';
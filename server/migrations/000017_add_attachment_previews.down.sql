@@ -0,0 +1,6 @@
+-- 删除附件预览相关列
+DROP INDEX IF EXISTS idx_attachments_preview_status;
+ALTER TABLE attachments DROP COLUMN IF EXISTS preview_status;
+ALTER TABLE attachments DROP COLUMN IF EXISTS page_count;
+ALTER TABLE attachments DROP COLUMN IF EXISTS duration;
+ALTER TABLE attachments DROP COLUMN IF EXISTS text_preview;
@@ -0,0 +1,16 @@
+-- =====================================================
+-- Migration: 000017_add_attachment_previews
+-- Description: 附件预览：PDF/视频海报图、文本摘录与媒体元数据由后台任务生成
+-- =====================================================
+
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS text_preview VARCHAR(500);
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS duration DOUBLE PRECISION;
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS page_count INT;
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS preview_status VARCHAR(20);
+
+-- 服务启动时按状态恢复未完成的预览任务
+CREATE INDEX IF NOT EXISTS idx_attachments_preview_status ON attachments(preview_status);
+
+-- 注释
+COMMENT ON COLUMN attachments.preview_status IS '预览状态：pending/ready/failed/unsupported';
+COMMENT ON COLUMN attachments.duration IS '视频时长（秒）';
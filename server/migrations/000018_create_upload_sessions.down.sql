@@ -0,0 +1,2 @@
+-- 删除附件断点续传会话表
+DROP TABLE IF EXISTS upload_sessions;
@@ -0,0 +1,24 @@
+-- =====================================================
+-- Migration: 000018_create_upload_sessions
+-- Description: 附件断点续传会话（tus 风格的分片上传）
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id VARCHAR(30) PRIMARY KEY,
+    token VARCHAR(50) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    parts TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_token ON upload_sessions(token);
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires_at ON upload_sessions(expires_at);
+
+-- 注释
+COMMENT ON TABLE upload_sessions IS '附件断点续传会话：过期后由 CleanupExpiredTokens 删除已上传的分片';
+COMMENT ON COLUMN upload_sessions.parts IS '已接收分片的存储路径（JSON 数组，按偏移量顺序）';
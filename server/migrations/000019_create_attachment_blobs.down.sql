@@ -0,0 +1,4 @@
+-- 删除附件内容去重存储
+-- attachments.hash 可能早于本迁移存在（见 000016），回滚时只删除索引
+DROP INDEX IF EXISTS idx_attachments_hash;
+DROP TABLE IF EXISTS attachment_blobs;
@@ -0,0 +1,23 @@
+-- =====================================================
+-- Migration: 000019_create_attachment_blobs
+-- Description: 附件按内容寻址存储：相同内容只保存一份，附件通过 hash 引用
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS attachment_blobs (
+    hash VARCHAR(64) PRIMARY KEY,
+    path VARCHAR(500) NOT NULL,
+    size BIGINT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_blobs_last_used_time ON attachment_blobs(last_used_time);
+
+-- 早期模型可能已建过 hash 列（见 000016），此处只补建
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS hash VARCHAR(64);
+CREATE INDEX IF NOT EXISTS idx_attachments_hash ON attachments(hash);
+
+-- 注释
+COMMENT ON TABLE attachment_blobs IS '附件内容去重存储：没有附件引用且超过宽限期的记录由垃圾回收删除';
+COMMENT ON COLUMN attachments.hash IS '文件内容 SHA-256，为空表示去重前上传的附件';
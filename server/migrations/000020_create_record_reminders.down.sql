@@ -0,0 +1,3 @@
+-- 删除记录提醒
+DROP TABLE IF EXISTS record_reminder;
+DROP TABLE IF EXISTS record_reminder_rule;
@@ -0,0 +1,53 @@
+-- =====================================================
+-- Migration: 000020_create_record_reminders
+-- Description: 基于日期字段的记录提醒：提醒规则与每条记录的提醒计划
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS record_reminder_rule (
+    id VARCHAR(30) PRIMARY KEY,
+    table_id VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    date_field_id VARCHAR(30) NOT NULL,
+    offset_value INTEGER NOT NULL DEFAULT 0,
+    offset_unit VARCHAR(10) NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    filter JSONB,
+    recipient_field_id VARCHAR(30),
+    recipient_user_ids JSONB,
+    message TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(30) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_modified_time TIMESTAMP,
+    deleted_time TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_record_reminder_rule_table_id ON record_reminder_rule(table_id);
+CREATE INDEX IF NOT EXISTS idx_record_reminder_rule_deleted_time ON record_reminder_rule(deleted_time);
+
+CREATE TABLE IF NOT EXISTS record_reminder (
+    id VARCHAR(30) PRIMARY KEY,
+    rule_id VARCHAR(30) NOT NULL,
+    table_id VARCHAR(30) NOT NULL,
+    record_id VARCHAR(30) NOT NULL,
+    fire_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    sent_at TIMESTAMP,
+    last_error TEXT,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_modified_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_record_reminder_rule_record ON record_reminder(rule_id, record_id);
+CREATE INDEX IF NOT EXISTS idx_record_reminder_record ON record_reminder(table_id, record_id);
+CREATE INDEX IF NOT EXISTS idx_record_reminder_due ON record_reminder(fire_at, status);
+
+-- 注释
+COMMENT ON TABLE record_reminder_rule IS '记录提醒规则：在日期字段之前/之后向接收人发送提醒';
+COMMENT ON COLUMN record_reminder_rule.offset_unit IS '偏移单位：minute, hour, day';
+COMMENT ON COLUMN record_reminder_rule.direction IS '方向：before, after';
+COMMENT ON COLUMN record_reminder_rule.timezone IS '只有日期的值按该时区的当天零点计算';
+COMMENT ON COLUMN record_reminder_rule.recipient_field_id IS '接收人用户字段，可与 recipient_user_ids 同时使用';
+COMMENT ON TABLE record_reminder IS '记录提醒计划：每个（规则，记录）一行，日期变化时重新规划';
+COMMENT ON COLUMN record_reminder.status IS '状态：pending, sending, sent, canceled, missed';
@@ -0,0 +1,4 @@
+-- 删除告警
+DROP TABLE IF EXISTS alert_silence;
+DROP TABLE IF EXISTS alert_history;
+DROP TABLE IF EXISTS alert_rule;
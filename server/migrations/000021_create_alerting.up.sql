@@ -0,0 +1,78 @@
+-- =====================================================
+-- Migration: 000021_create_alerting
+-- Description: 告警规则、告警历史与静默窗口
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS alert_rule (
+    id VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    metric_name VARCHAR(200) NOT NULL,
+    metric_labels JSONB,
+    function VARCHAR(20) NOT NULL DEFAULT 'value',
+    operator VARCHAR(10) NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    for_seconds BIGINT NOT NULL DEFAULT 0,
+    severity VARCHAR(20) NOT NULL,
+    channels JSONB,
+    repeat_interval_seconds BIGINT NOT NULL DEFAULT 0,
+    labels JSONB,
+    annotations JSONB,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(30) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_modified_time TIMESTAMP,
+    deleted_time TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rule_deleted_time ON alert_rule(deleted_time);
+
+CREATE TABLE IF NOT EXISTS alert_history (
+    id VARCHAR(30) PRIMARY KEY,
+    rule_id VARCHAR(30) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    description TEXT,
+    severity VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    instance VARCHAR(255),
+    value DOUBLE PRECISION,
+    threshold DOUBLE PRECISION,
+    labels JSONB,
+    started_at TIMESTAMP NOT NULL,
+    fired_at TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP,
+    last_notified_at TIMESTAMP,
+    notify_count INTEGER NOT NULL DEFAULT 0,
+    silenced BOOLEAN NOT NULL DEFAULT FALSE,
+    last_error TEXT,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_modified_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_history_rule ON alert_history(rule_id, fired_at);
+CREATE INDEX IF NOT EXISTS idx_alert_history_open ON alert_history(status, instance);
+
+CREATE TABLE IF NOT EXISTS alert_silence (
+    id VARCHAR(30) PRIMARY KEY,
+    rule_id VARCHAR(30),
+    matchers JSONB,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    comment TEXT,
+    created_by VARCHAR(30) NOT NULL,
+    created_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_silence_ends_at ON alert_silence(ends_at);
+
+-- 注释
+COMMENT ON TABLE alert_rule IS '告警规则：对本服务 Prometheus 指标的阈值判断';
+COMMENT ON COLUMN alert_rule.function IS '取值方式：value（当前值）, rate（每秒增量）';
+COMMENT ON COLUMN alert_rule.operator IS '比较操作：gt, gte, lt, lte, eq, neq';
+COMMENT ON COLUMN alert_rule.for_seconds IS '条件持续满足多少秒后触发';
+COMMENT ON COLUMN alert_rule.channels IS '通知渠道名，为空时发送到所有渠道';
+COMMENT ON COLUMN alert_rule.repeat_interval_seconds IS '持续触发时重复通知的间隔，0 表示只通知一次';
+COMMENT ON TABLE alert_history IS '告警历史：每次触发一行，记录触发与恢复时间';
+COMMENT ON COLUMN alert_history.status IS '状态：firing, resolved';
+COMMENT ON COLUMN alert_history.instance IS '产生告警的服务实例';
+COMMENT ON TABLE alert_silence IS '告警静默窗口：窗口内匹配的告警不发送通知';
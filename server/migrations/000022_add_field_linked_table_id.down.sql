@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_field_linked_table_id;
+ALTER TABLE field DROP COLUMN IF EXISTS linked_table_id;
@@ -0,0 +1,18 @@
+-- =====================================================
+-- Migration: 000022_add_field_linked_table_id
+-- Description: Link 字段的关联表单独存一列，按关联表查询 Link 字段（删除表/Base 时清理关联）
+-- =====================================================
+
+ALTER TABLE field ADD COLUMN IF NOT EXISTS linked_table_id VARCHAR(50);
+
+COMMENT ON COLUMN field.linked_table_id IS 'Link字段的关联表ID';
+
+-- 回填已有 Link 字段（选项中的 Link.linked_table_id）
+UPDATE field
+SET linked_table_id = (options::jsonb -> 'Link' ->> 'linked_table_id')
+WHERE type = 'link'
+  AND linked_table_id IS NULL
+  AND options IS NOT NULL
+  AND options LIKE '{%';
+
+CREATE INDEX IF NOT EXISTS idx_field_linked_table_id ON field (linked_table_id);
@@ -0,0 +1,113 @@
+// Package dataloader 按请求合并同一轮中的多次按键加载
+//
+// Load 只登记键并返回一个取值函数；第一次调用任一取值函数时，
+// 所有已登记、尚未加载的键通过一次 BatchFunc 批量加载。
+// 配合 GraphQL 执行器的 Thunk 使用，可以把 N 条记录的关联字段合并成一次查询。
+// Loader 的缓存没有过期机制，应当按请求创建。
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc 批量加载；结果中缺少的键视为不存在，得到零值
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader 批量加载器
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	entries map[K]*entry[V]
+	pending []K
+}
+
+type entry[V any] struct {
+	done  bool
+	value V
+	err   error
+}
+
+// New 创建加载器
+func New[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{batch: batch, entries: make(map[K]*entry[V])}
+}
+
+// Load 登记键，返回的函数在调用时取值（必要时触发批量加载）
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	e, ok := l.entries[key]
+	if !ok {
+		e = &entry[V]{}
+		l.entries[key] = e
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !e.done {
+			l.dispatch(ctx)
+		}
+		return e.value, e.err
+	}
+}
+
+// LoadMany 登记多个键，取值时按键的顺序返回
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) func() ([]V, error) {
+	thunks := make([]func() (V, error), len(keys))
+	for i, key := range keys {
+		thunks[i] = l.Load(ctx, key)
+	}
+	return func() ([]V, error) {
+		values := make([]V, len(keys))
+		for i, thunk := range thunks {
+			value, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+}
+
+// Prime 写入已知的值，避免重复加载
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[key]; ok {
+		if !e.done {
+			e.value, e.done = value, true
+		}
+		return
+	}
+	l.entries[key] = &entry[V]{done: true, value: value}
+}
+
+// dispatch 批量加载所有待加载的键，调用方持有锁
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	keys := make([]K, 0, len(l.pending))
+	for _, key := range l.pending {
+		if !l.entries[key].done {
+			keys = append(keys, key)
+		}
+	}
+	l.pending = nil
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		e := l.entries[key]
+		e.done = true
+		if err != nil {
+			e.err = err
+			continue
+		}
+		e.value = values[key]
+	}
+}
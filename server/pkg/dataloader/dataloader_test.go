@@ -0,0 +1,70 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_BatchesPendingKeys(t *testing.T) {
+	var batches [][]string
+	loader := New(func(ctx context.Context, keys []string) (map[string]int, error) {
+		batches = append(batches, keys)
+		result := make(map[string]int, len(keys))
+		for _, key := range keys {
+			if key != "missing" {
+				result[key] = len(key)
+			}
+		}
+		return result, nil
+	})
+
+	ctx := context.Background()
+	a := loader.Load(ctx, "a")
+	bb := loader.Load(ctx, "bb")
+	again := loader.Load(ctx, "a")
+	missing := loader.Load(ctx, "missing")
+
+	value, err := bb()
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+	value, _ = a()
+	assert.Equal(t, 1, value)
+	value, _ = again()
+	assert.Equal(t, 1, value)
+	value, err = missing()
+	require.NoError(t, err)
+	assert.Equal(t, 0, value)
+	assert.Equal(t, [][]string{{"a", "bb", "missing"}}, batches)
+
+	// 已加载的键走缓存，新键进入下一批
+	many, err := loader.LoadMany(ctx, []string{"a", "ccc"})()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, many)
+	assert.Equal(t, [][]string{{"a", "bb", "missing"}, {"ccc"}}, batches)
+}
+
+func TestLoader_ErrorAndPrime(t *testing.T) {
+	calls := 0
+	loader := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		calls++
+		return nil, errors.New("boom")
+	})
+
+	ctx := context.Background()
+	loader.Prime(1, "primed")
+	primed := loader.Load(ctx, 1)
+	failed := loader.Load(ctx, 2)
+
+	value, err := primed()
+	require.NoError(t, err)
+	assert.Equal(t, "primed", value)
+	assert.Equal(t, 0, calls)
+
+	_, err = failed()
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,38 @@
+package errors
+
+import "sort"
+
+// CatalogEntry 预定义错误的快照，用于生成 API 文档中的错误码说明
+type CatalogEntry struct {
+	Code        string `json:"code"`        // 字符串业务码
+	NumericCode int    `json:"numericCode"` // 响应中的数字码
+	Message     string `json:"message"`
+	HTTPStatus  int    `json:"httpStatus"`
+}
+
+// catalog 预定义错误（在 define 时记录，之后不受 WithMessage/WithDetails 影响）
+var catalog []CatalogEntry
+
+// define 创建预定义错误并登记到错误目录
+func define(code, message string, httpStatus int) *AppError {
+	catalog = append(catalog, CatalogEntry{
+		Code:        code,
+		NumericCode: NumericCodeFromString(code, httpStatus),
+		Message:     message,
+		HTTPStatus:  httpStatus,
+	})
+	return New(code, message, httpStatus)
+}
+
+// Catalog 返回所有预定义错误，按 HTTP 状态码和数字码排序
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, len(catalog))
+	copy(entries, catalog)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].HTTPStatus != entries[j].HTTPStatus {
+			return entries[i].HTTPStatus < entries[j].HTTPStatus
+		}
+		return entries[i].NumericCode < entries[j].NumericCode
+	})
+	return entries
+}
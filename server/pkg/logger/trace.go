@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// TraceFields 从上下文提取 trace_id / span_id 日志字段，无 trace 时返回 nil
+func TraceFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	}
+}
+
+// Ctx 返回附带上下文 trace_id / span_id 的日志实例
+//
+//	logger.Ctx(ctx).Info("记录已更新", logger.String("record_id", id))
+func Ctx(ctx context.Context) *zap.Logger {
+	fields := TraceFields(ctx)
+	if len(fields) == 0 {
+		return Logger
+	}
+	return Logger.With(fields...)
+}
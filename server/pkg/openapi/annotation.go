@@ -0,0 +1,47 @@
+package openapi
+
+import "reflect"
+
+// RouteDoc 处理器的接口说明，由 openapi-gen 从 swag 风格注释生成
+type RouteDoc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Params      []ParamDoc
+	Body        *BodyDoc
+	Responses   []ResponseDoc
+	Consumes    []string
+	Produces    []string
+	Security    bool // 注释中声明了 @Security
+	Deprecated  bool
+}
+
+// ParamDoc 路径、查询、请求头或表单参数
+type ParamDoc struct {
+	Name        string
+	In          string // path, query, header, formData
+	Description string
+	Required    bool
+	Type        reflect.Type // nil 表示文件
+}
+
+// BodyDoc 请求体
+type BodyDoc struct {
+	Description string
+	Required    bool
+	Type        reflect.Type
+}
+
+// ResponseDoc 响应
+type ResponseDoc struct {
+	Status      int
+	Description string
+	Kind        string       // object, array, file, string；为空时无响应体
+	Type        reflect.Type // 响应体类型，nil 表示未约束
+	Envelope    bool         // 响应体为统一响应结构 response.APIResponse
+	Data        reflect.Type // 统一响应结构中 data 的类型，nil 表示未约束
+	DataFields  Fields       // 覆盖 data 中字段的类型，如分页响应的 list
+}
+
+// Fields 字段名 -> 类型
+type Fields map[string]reflect.Type
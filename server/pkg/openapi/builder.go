@@ -0,0 +1,253 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handlerNamePattern 运行时方法值名称：pkg.(*Receiver).Method-fm
+var handlerNamePattern = regexp.MustCompile(`\.\(\*?(\w+)\)\.(\w+)(?:-fm)?$`)
+
+// BuildOptions 从路由生成文档的选项
+type BuildOptions struct {
+	Prefix string                         // 只包含该前缀下的路由，如 /api/v1
+	Public func(method, path string) bool // 无需认证的路由（gin 路径格式）
+}
+
+// HandlerKey 由 gin 处理器名称得到 RouteDoc 键（Receiver.Method），非方法值返回空
+func HandlerKey(handler string) string {
+	m := handlerNamePattern.FindStringSubmatch(handler)
+	if m == nil {
+		return ""
+	}
+	return m[1] + "." + m[2]
+}
+
+// OpenAPIPath 将 gin 路径（:id、*path）转换为 OpenAPI 路径（{id}），并返回路径参数名
+func OpenAPIPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// Build 根据 gin 路由和接口说明填充文档
+func (d *Document) Build(routes gin.RoutesInfo, docs map[string]*RouteDoc, opts BuildOptions) {
+	sorted := make(gin.RoutesInfo, 0, len(routes))
+	for _, route := range routes {
+		if strings.HasPrefix(route.Path, opts.Prefix) {
+			sorted = append(sorted, route)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	operationIDs := make(map[string]int)
+	for _, route := range sorted {
+		key := HandlerKey(route.Handler)
+		doc := docs[key]
+		if doc == nil {
+			doc = &RouteDoc{}
+		}
+		public := opts.Public != nil && opts.Public(route.Method, route.Path)
+
+		path, pathParams := OpenAPIPath(route.Path)
+		op := d.operation(route, doc, pathParams, opts.Prefix, public)
+
+		if _, method, ok := strings.Cut(key, "."); ok {
+			id := lowerFirst(method)
+			operationIDs[id]++
+			if n := operationIDs[id]; n > 1 {
+				id += strconv.Itoa(n)
+			}
+			op.OperationID = id
+		}
+		d.AddOperation(route.Method, path, op)
+	}
+}
+
+// operation 生成单个接口操作
+func (d *Document) operation(route gin.RouteInfo, doc *RouteDoc, pathParams []string, prefix string, public bool) *Operation {
+	op := &Operation{
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		Tags:        doc.Tags,
+		Deprecated:  doc.Deprecated,
+		Responses:   make(map[string]*Response),
+	}
+	if len(op.Tags) == 0 {
+		rest := strings.TrimPrefix(strings.TrimPrefix(route.Path, prefix), "/")
+		if first, _, _ := strings.Cut(rest, "/"); first != "" && !strings.HasPrefix(first, ":") {
+			op.Tags = []string{first}
+		}
+	}
+	RequireAuth(op, public)
+
+	// 路径参数以实际路由为准，注释中的同名参数提供说明和类型
+	documented := make(map[string]ParamDoc)
+	for _, param := range doc.Params {
+		if param.In == "path" {
+			documented[param.Name] = param
+		}
+	}
+	for _, name := range pathParams {
+		param := &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: TypeNames("string")}}
+		if documentedParam, ok := documented[name]; ok {
+			param.Description = documentedParam.Description
+			if documentedParam.Type != nil {
+				param.Schema = d.Schemas().Schema(documentedParam.Type)
+			}
+		}
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	var form *Schema
+	for _, param := range doc.Params {
+		switch param.In {
+		case "query", "header", "cookie":
+			schema := &Schema{Type: TypeNames("string")}
+			if param.Type != nil {
+				schema = d.Schemas().Schema(param.Type)
+			}
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required,
+				Schema:      schema,
+			})
+		case "formData":
+			if form == nil {
+				form = &Schema{Type: TypeNames("object"), Properties: make(map[string]*Schema)}
+			}
+			schema := &Schema{Type: TypeNames("string"), ContentMediaType: "application/octet-stream"}
+			if param.Type != nil {
+				schema = d.Schemas().Schema(param.Type)
+			}
+			schema.Description = param.Description
+			form.Properties[param.Name] = schema
+			if param.Required {
+				form.Required = append(form.Required, param.Name)
+			}
+		}
+	}
+
+	switch {
+	case doc.Body != nil:
+		schema := &Schema{}
+		if doc.Body.Type != nil {
+			schema = d.Schemas().Schema(doc.Body.Type)
+		}
+		op.RequestBody = &RequestBody{
+			Description: doc.Body.Description,
+			Required:    doc.Body.Required,
+			Content:     map[string]*MediaType{firstOr(doc.Consumes, "application/json"): {Schema: schema}},
+		}
+	case form != nil:
+		op.RequestBody = &RequestBody{
+			Required: len(form.Required) > 0,
+			Content:  map[string]*MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	hasSuccess := false
+	for _, resp := range doc.Responses {
+		status := strconv.Itoa(resp.Status)
+		if resp.Status >= http.StatusBadRequest {
+			op.Responses[status] = d.ErrorResponse(resp.Status)
+			continue
+		}
+		hasSuccess = true
+		op.Responses[status] = d.successResponse(resp, doc.Produces)
+	}
+	if !hasSuccess {
+		op.Responses["200"] = d.SuccessResponse("", nil)
+	}
+	if !public {
+		if _, ok := op.Responses["401"]; !ok {
+			op.Responses["401"] = d.ErrorResponse(http.StatusUnauthorized)
+		}
+	}
+	if _, ok := op.Responses["500"]; !ok {
+		op.Responses["500"] = d.ErrorResponse(http.StatusInternalServerError)
+	}
+	return op
+}
+
+// successResponse 将注释中的成功响应转换为 OpenAPI 响应
+func (d *Document) successResponse(resp ResponseDoc, produces []string) *Response {
+	description := resp.Description
+	if description == "" {
+		description = http.StatusText(resp.Status)
+	}
+
+	switch {
+	case resp.Envelope:
+		var data *Schema
+		if resp.Data != nil {
+			data = d.Schemas().Schema(resp.Data)
+		}
+		if len(resp.DataFields) > 0 {
+			override := &Schema{Type: TypeNames("object"), Properties: make(map[string]*Schema)}
+			for name, fieldType := range resp.DataFields {
+				override.Properties[name] = d.Schemas().Schema(fieldType)
+			}
+			if data == nil {
+				data = override
+			} else {
+				data = &Schema{AllOf: []*Schema{data, override}}
+			}
+		}
+		return d.SuccessResponse(description, data)
+	case resp.Kind == "file":
+		mediaType := firstOr(produces, "application/octet-stream")
+		return &Response{Description: description, Content: map[string]*MediaType{
+			mediaType: {Schema: &Schema{Type: TypeNames("string"), ContentMediaType: mediaType}},
+		}}
+	case resp.Type != nil:
+		return &Response{Description: description, Content: map[string]*MediaType{
+			"application/json": {Schema: d.Schemas().Schema(resp.Type)},
+		}}
+	case resp.Kind == "string":
+		return &Response{Description: description, Content: map[string]*MediaType{
+			"text/plain": {Schema: &Schema{Type: TypeNames("string")}},
+		}}
+	case resp.Kind == "":
+		return &Response{Description: description}
+	default:
+		return &Response{Description: description, Content: map[string]*MediaType{
+			"application/json": {Schema: &Schema{}},
+		}}
+	}
+}
+
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 {
+		return values[0]
+	}
+	return fallback
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+)
+
+type builderHandler struct{}
+
+func (h *builderHandler) Login(c *gin.Context)        {}
+func (h *builderHandler) GetItem(c *gin.Context)      {}
+func (h *builderHandler) Download(c *gin.Context)     {}
+func (h *builderHandler) Undocumented(c *gin.Context) {}
+
+type builderItem struct {
+	ID string `json:"id"`
+}
+
+func TestHandlerKeyAndPath(t *testing.T) {
+	assert.Equal(t, "RecordHandler.GetRecord", HandlerKey("github.com/x/http.(*RecordHandler).GetRecord-fm"))
+	assert.Equal(t, "", HandlerKey("github.com/x/http.setupRoutes.func1"))
+
+	path, params := OpenAPIPath("/api/v1/tables/:tableId/files/*path")
+	assert.Equal(t, "/api/v1/tables/{tableId}/files/{path}", path)
+	assert.Equal(t, []string{"tableId", "path"}, params)
+}
+
+func TestDocument_Build(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := &builderHandler{}
+	router := gin.New()
+	router.POST("/api/v1/auth/login", handler.Login)
+	router.GET("/api/v1/items/:itemId", handler.GetItem)
+	router.GET("/api/v1/legacy/items/:itemId", handler.GetItem)
+	router.GET("/api/v1/items/:itemId/download", handler.Download)
+	router.GET("/api/v1/items", handler.Undocumented)
+	router.GET("/health", handler.Undocumented)
+
+	docs := map[string]*RouteDoc{
+		"builderHandler.Login": {Summary: "登录", Tags: []string{"Auth"}},
+		"builderHandler.GetItem": {
+			Summary: "获取条目",
+			Params: []ParamDoc{
+				{Name: "itemId", In: "path", Description: "条目 ID", Required: true},
+				{Name: "fields", In: "query", Type: TypeOf[[]string]()},
+			},
+			Responses: []ResponseDoc{
+				{Status: 200, Kind: "object", Envelope: true, Data: TypeOf[builderItem]()},
+				{Status: 404, Kind: "object", Envelope: true},
+			},
+		},
+		"builderHandler.Download": {
+			Produces:  []string{"application/octet-stream"},
+			Responses: []ResponseDoc{{Status: 200, Kind: "file"}},
+		},
+	}
+
+	doc := NewDocument(Info{Title: "test", Version: "1.0"})
+	doc.Build(router.Routes(), docs, BuildOptions{
+		Prefix: "/api/v1",
+		Public: func(method, path string) bool { return path == "/api/v1/auth/login" },
+	})
+
+	assert.NotContains(t, doc.Paths, "/health")
+
+	// 公开路由显式声明无需认证，且没有 401
+	login := (*doc.Paths["/api/v1/auth/login"])["post"]
+	require.NotNil(t, login)
+	assert.Equal(t, "login", login.OperationID)
+	assert.Empty(t, *login.Security)
+	assert.NotContains(t, login.Responses, "401")
+	assert.Contains(t, login.Responses, "500")
+
+	// 路径参数以路由为准，注释补充说明；同一处理器的操作 ID 去重
+	get := (*doc.Paths["/api/v1/items/{itemId}"])["get"]
+	require.NotNil(t, get)
+	assert.Equal(t, []SecurityRequirement{{BearerAuth: {}}}, *get.Security)
+	require.Len(t, get.Parameters, 2)
+	assert.Equal(t, "条目 ID", get.Parameters[0].Description)
+	assert.Equal(t, "query", get.Parameters[1].In)
+	assert.Equal(t, "#/components/responses/Error404", get.Responses["404"].Ref)
+	assert.Equal(t, "#/components/responses/Error401", get.Responses["401"].Ref)
+	legacy := (*doc.Paths["/api/v1/legacy/items/{itemId}"])["get"]
+	assert.NotEqual(t, get.OperationID, legacy.OperationID)
+
+	// 统一响应结构覆盖 data
+	data := get.Responses["200"].Content["application/json"].Schema.AllOf[1].Properties["data"]
+	assert.Equal(t, "#/components/schemas/openapi.builderItem", data.Ref)
+
+	download := (*doc.Paths["/api/v1/items/{itemId}/download"])["get"]
+	assert.Contains(t, download.Responses["200"].Content, "application/octet-stream")
+
+	// 未注释的路由：标签取路径首段，响应为统一结构
+	list := (*doc.Paths["/api/v1/items"])["get"]
+	assert.Equal(t, []string{"items"}, list.Tags)
+	assert.Contains(t, list.Responses, "200")
+
+	// 错误响应组件列出该状态码的预定义错误码
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var decoded struct {
+		OpenAPI    string `json:"openapi"`
+		Tags       []Tag  `json:"tags"`
+		Components struct {
+			Responses map[string]struct {
+				Codes []pkgerrors.CatalogEntry `json:"x-error-codes"`
+			} `json:"responses"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, Version, decoded.OpenAPI)
+	assert.Contains(t, decoded.Tags, Tag{Name: "Auth"})
+
+	codes := decoded.Components.Responses["Error404"].Codes
+	require.NotEmpty(t, codes)
+	found := false
+	for _, entry := range codes {
+		assert.Equal(t, 404, entry.HTTPStatus)
+		if entry.Code == pkgerrors.ErrTableNotFound.Code {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,282 @@
+// Package openapi 生成 OpenAPI 3.1 文档
+//
+// 路由来自 gin 注册表，接口说明来自处理器上的 swag 风格注释（由 openapi-gen 生成为 Go 代码），
+// 请求/响应结构通过反射 DTO 类型得到 JSON Schema，错误响应取自 pkg/errors 的预定义错误。
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	pkgerrors "github.com/easyspace-ai/luckdb/server/pkg/errors"
+	"github.com/easyspace-ai/luckdb/server/pkg/response"
+)
+
+// Version 生成的 OpenAPI 版本
+const Version = "3.1.0"
+
+// BearerAuth JWT 认证方案名
+const BearerAuth = "BearerAuth"
+
+// Document OpenAPI 文档
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+
+	schemas *SchemaRegistry
+}
+
+// Info 文档信息
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server 服务地址
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag 标签
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem 路径下的操作，键为小写 HTTP 方法
+type PathItem map[string]*Operation
+
+// Operation 接口操作
+type Operation struct {
+	OperationID string                 `json:"operationId,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*Response   `json:"responses"`
+	Security    *[]SecurityRequirement `json:"security,omitempty"` // 为空切片时表示无需认证
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+}
+
+// Parameter 参数
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query, header, cookie
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Ref         string                 `json:"$ref,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Content     map[string]*MediaType  `json:"content,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON 输出 x- 扩展字段
+func (r *Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	data, err := json.Marshal((*plain)(r))
+	if err != nil || len(r.Extensions) == 0 {
+		return data, err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range r.Extensions {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+// MediaType 媒体类型
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components 可复用组件
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme 认证方案
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// SecurityRequirement 认证要求
+type SecurityRequirement map[string][]string
+
+// NewDocument 创建文档，预置 JWT 认证方案、统一响应结构和错误响应
+func NewDocument(info Info) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: "/"}},
+		Paths:   make(map[string]*PathItem),
+		Components: &Components{
+			Schemas:   make(map[string]*Schema),
+			Responses: make(map[string]*Response),
+			SecuritySchemes: map[string]*SecurityScheme{
+				BearerAuth: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "登录或刷新令牌接口返回的 accessToken，放在 Authorization: Bearer <token> 请求头中",
+				},
+			},
+		},
+	}
+	doc.schemas = NewSchemaRegistry(doc.Components.Schemas)
+	doc.Schemas().Schema(TypeOf[response.APIResponse]())
+	return doc
+}
+
+// Schemas 文档的 Schema 注册表
+func (d *Document) Schemas() *SchemaRegistry {
+	return d.schemas
+}
+
+// AddOperation 添加操作，path 使用 OpenAPI 格式（/tables/{tableId}）
+func (d *Document) AddOperation(method, path string, op *Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	(*item)[strings.ToLower(method)] = op
+}
+
+// RequireAuth 设置操作的认证要求，public 为 true 时显式声明无需认证
+func RequireAuth(op *Operation, public bool) {
+	security := []SecurityRequirement{}
+	if !public {
+		security = append(security, SecurityRequirement{BearerAuth: {}})
+	}
+	op.Security = &security
+}
+
+// SuccessResponse 统一响应结构（response.APIResponse），data 为 nil 时不约束 data
+func (d *Document) SuccessResponse(description string, data *Schema) *Response {
+	envelope := d.Schemas().Schema(TypeOf[response.APIResponse]())
+	schema := envelope
+	if data != nil {
+		schema = &Schema{AllOf: []*Schema{envelope, {
+			Type:       TypeNames("object"),
+			Properties: map[string]*Schema{"data": data},
+		}}}
+	}
+	if description == "" {
+		description = http.StatusText(http.StatusOK)
+	}
+	return &Response{
+		Description: description,
+		Content:     map[string]*MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// ErrorResponse 错误响应引用，首次使用时生成该状态码的组件
+// 组件的 x-error-codes 列出该状态码下可能返回的预定义错误
+func (d *Document) ErrorResponse(status int) *Response {
+	name := fmt.Sprintf("Error%d", status)
+	if _, ok := d.Components.Responses[name]; !ok {
+		codes := make([]pkgerrors.CatalogEntry, 0)
+		for _, entry := range pkgerrors.Catalog() {
+			if entry.HTTPStatus == status {
+				codes = append(codes, entry)
+			}
+		}
+
+		description := http.StatusText(status)
+		if description == "" {
+			description = fmt.Sprintf("HTTP %d", status)
+		}
+		if len(codes) > 0 {
+			names := make([]string, 0, len(codes))
+			for _, entry := range codes {
+				names = append(names, fmt.Sprintf("%s(%d)", entry.Code, entry.NumericCode))
+			}
+			description += "，错误码：" + strings.Join(names, ", ")
+		}
+
+		d.Components.Responses[name] = &Response{
+			Description: description,
+			Content: map[string]*MediaType{"application/json": {
+				Schema: d.errorSchema(),
+			}},
+			Extensions: map[string]interface{}{"x-error-codes": codes},
+		}
+	}
+	return &Response{Ref: "#/components/responses/" + name}
+}
+
+// errorSchema 错误响应结构：code 为数字业务码，error.details 为错误详情
+func (d *Document) errorSchema() *Schema {
+	const name = "ErrorResponse"
+	if _, ok := d.Components.Schemas[name]; !ok {
+		d.Components.Schemas[name] = &Schema{
+			Description: "错误响应（pkg/errors.AppError）：code 为数字业务码（HTTP 状态码 × 1000 + 子码），message 为错误信息，error.details 为错误详情",
+			AllOf: []*Schema{
+				d.Schemas().Schema(TypeOf[response.APIResponse]()),
+				{
+					Type:     TypeNames("object"),
+					Required: []string{"code", "message"},
+					Properties: map[string]*Schema{
+						"code": {Type: TypeNames("integer"), Examples: []interface{}{pkgerrors.CodeNotFound}},
+						"data": {Type: TypeNames("null")},
+					},
+				},
+			},
+		}
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// finalize 整理标签列表
+func (d *Document) finalize() {
+	seen := make(map[string]bool)
+	for _, item := range d.Paths {
+		for _, op := range *item {
+			for _, tag := range op.Tags {
+				seen[tag] = true
+			}
+		}
+	}
+	d.Tags = d.Tags[:0]
+	for tag := range seen {
+		d.Tags = append(d.Tags, Tag{Name: tag})
+	}
+	sort.Slice(d.Tags, func(i, j int) bool { return d.Tags[i].Name < d.Tags[j].Name })
+}
+
+// MarshalJSON 序列化前整理标签
+func (d *Document) MarshalJSON() ([]byte, error) {
+	d.finalize()
+	type plain Document
+	return json.Marshal((*plain)(d))
+}
@@ -0,0 +1,672 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GeneratedFile openapi-gen 生成的文件名
+const GeneratedFile = "openapi_docs_gen.go"
+
+// envelopeTypes 注释中表示统一响应结构的类型名（历史注释使用过多种写法）
+var envelopeTypes = map[string]bool{
+	"response.APIResponse": true,
+	"response.Response":    true,
+	"APIResponse":          true,
+	"Response":             true,
+	"SuccessResponse":      true,
+}
+
+// primitiveTypes 注释中的基础类型对应的 Go 类型
+var primitiveTypes = map[string]string{
+	"string":                 "string",
+	"int":                    "int",
+	"integer":                "int",
+	"int64":                  "int64",
+	"uint":                   "uint",
+	"bool":                   "bool",
+	"boolean":                "bool",
+	"number":                 "float64",
+	"float64":                "float64",
+	"object":                 "map[string]interface{}",
+	"interface{}":            "interface{}",
+	"any":                    "interface{}",
+	"gin.H":                  "map[string]interface{}",
+	"map[string]interface{}": "map[string]interface{}",
+}
+
+var (
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(\S+)\s*(?:"([^"]*)")?`)
+	responsePattern = regexp.MustCompile(`^(\d+)\s*(?:\{(\w+)\}\s+(\S+))?\s*(?:"([^"]*)")?`)
+	modulePattern   = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+)
+
+// Generator 解析处理器上的 swag 风格注释，生成 RouteDoc 表（Go 源码）
+//
+// 注释中的类型解析为包内已导入的真实类型，运行时通过反射生成 Schema，
+// 因此 DTO 字段变化无需重新生成；只有注释变化时需要重新运行 go generate
+type Generator struct {
+	dir        string
+	pkgName    string
+	modulePath string
+	moduleDir  string
+
+	files      map[string]*ast.File
+	localTypes map[string]bool
+	pkgImports map[string]string          // 包内任一文件的导入：别名 -> 路径
+	typeNames  map[string]map[string]bool // 路径 -> 导出类型名
+	imports    map[string]string          // 生成文件的导入：路径 -> 别名
+	aliases    map[string]string          // 生成文件的导入：别名 -> 路径
+	warnings   []string
+}
+
+// NewGenerator 创建生成器，dir 为处理器所在包目录
+func NewGenerator(dir string) (*Generator, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	modulePath, moduleDir, err := findModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Generator{
+		dir:        dir,
+		modulePath: modulePath,
+		moduleDir:  moduleDir,
+		files:      make(map[string]*ast.File),
+		localTypes: make(map[string]bool),
+		pkgImports: make(map[string]string),
+		typeNames:  make(map[string]map[string]bool),
+		imports:    make(map[string]string),
+		aliases:    map[string]string{"openapi": modulePath + "/pkg/openapi"},
+	}
+	if err := g.parsePackage(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Warnings 无法解析的注释
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
+// Generate 生成 Go 源码，varName 为 map[string]*openapi.RouteDoc 变量名
+func (g *Generator) Generate(varName string) ([]byte, error) {
+	entries := make(map[string]string)
+	for _, name := range g.fileNames() {
+		file := g.files[name]
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+			key := handlerKey(fn)
+			if key == "" {
+				continue
+			}
+			if literal := g.routeDoc(file, fn); literal != "" {
+				entries[key] = literal
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by openapi-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkgName)
+	buf.WriteString("import (\n")
+	aliases := make([]string, 0, len(g.aliases))
+	for alias := range g.aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		if alias == "openapi" || g.imports[g.aliases[alias]] == alias {
+			fmt.Fprintf(&buf, "\t%s %q\n", alias, g.aliases[alias])
+		}
+	}
+	buf.WriteString(")\n\n")
+	fmt.Fprintf(&buf, "// %s 处理器接口说明（Receiver.Method -> 文档）\n", varName)
+	fmt.Fprintf(&buf, "var %s = map[string]*openapi.RouteDoc{\n", varName)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "%q: %s,\n", key, entries[key])
+	}
+	buf.WriteString("}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source: %w", err)
+	}
+	return source, nil
+}
+
+// parsePackage 解析处理器包（不含测试和已生成的文件）
+func (g *Generator) parsePackage() error {
+	entries, err := os.ReadDir(g.dir)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == GeneratedFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(g.dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		if g.pkgName == "" {
+			g.pkgName = file.Name.Name
+		}
+		if file.Name.Name != g.pkgName {
+			continue
+		}
+		g.files[name] = file
+
+		for alias, importPath := range fileImports(file) {
+			if _, ok := g.pkgImports[alias]; !ok {
+				g.pkgImports[alias] = importPath
+			}
+		}
+		for name := range declaredTypes(file, false) {
+			g.localTypes[name] = true
+		}
+	}
+	if len(g.files) == 0 {
+		return fmt.Errorf("no Go files in %s", g.dir)
+	}
+	return nil
+}
+
+func (g *Generator) fileNames() []string {
+	names := make([]string, 0, len(g.files))
+	for name := range g.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// routeDoc 解析注释，返回 RouteDoc 字面量；没有任何注释时返回空
+func (g *Generator) routeDoc(file *ast.File, fn *ast.FuncDecl) string {
+	where := fmt.Sprintf("%s.%s", receiverName(fn), fn.Name.Name)
+	var (
+		fields      []string
+		summary     string
+		description []string
+		tags        []string
+		params      []string
+		responses   []string
+		annotated   bool
+	)
+
+	lines := strings.Split(fn.Doc.Text(), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		annotated = true
+		keyword, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToLower(keyword) {
+		case "@summary":
+			summary = rest
+		case "@description":
+			description = append(description, rest)
+		case "@tags":
+			for _, tag := range strings.Split(rest, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+		case "@accept":
+			fields = append(fields, fmt.Sprintf("Consumes: %s", stringSlice(mimeTypes(rest))))
+		case "@produce":
+			fields = append(fields, fmt.Sprintf("Produces: %s", stringSlice(mimeTypes(rest))))
+		case "@security":
+			fields = append(fields, "Security: true")
+		case "@deprecated":
+			fields = append(fields, "Deprecated: true")
+		case "@param":
+			if param, body := g.param(file, where, rest); body != "" {
+				fields = append(fields, "Body: "+body)
+			} else if param != "" {
+				params = append(params, param)
+			}
+		case "@success", "@failure":
+			if resp := g.response(file, where, rest); resp != "" {
+				responses = append(responses, resp)
+			}
+		}
+	}
+	if !annotated {
+		return ""
+	}
+
+	// 未写 @Summary 时取注释首行（去掉方法名）
+	if summary == "" && len(lines) > 0 {
+		summary = strings.TrimSpace(strings.TrimPrefix(lines[0], fn.Name.Name))
+		if strings.HasPrefix(summary, "@") {
+			summary = ""
+		}
+	}
+
+	head := make([]string, 0, 4)
+	if summary != "" {
+		head = append(head, fmt.Sprintf("Summary: %q", summary))
+	}
+	if len(description) > 0 {
+		head = append(head, fmt.Sprintf("Description: %q", strings.Join(description, "\n")))
+	}
+	if len(tags) > 0 {
+		head = append(head, "Tags: "+stringSlice(tags))
+	}
+	if len(params) > 0 {
+		head = append(head, "Params: []openapi.ParamDoc{\n"+strings.Join(params, ",\n")+",\n}")
+	}
+	if len(responses) > 0 {
+		fields = append(fields, "Responses: []openapi.ResponseDoc{\n"+strings.Join(responses, ",\n")+",\n}")
+	}
+	return "{\n" + strings.Join(append(head, fields...), ",\n") + ",\n}"
+}
+
+// param 解析 @Param name in type required "description"，请求体参数返回 body
+func (g *Generator) param(file *ast.File, where, text string) (param, body string) {
+	m := paramPattern.FindStringSubmatch(text)
+	if m == nil {
+		g.warn(where, "invalid @Param: "+text)
+		return "", ""
+	}
+	name, in, typeName, description := m[1], m[2], m[3], m[5]
+	required, _ := strconv.ParseBool(m[4])
+
+	if in == "body" {
+		fields := []string{fmt.Sprintf("Required: %t", required)}
+		if description != "" {
+			fields = append(fields, fmt.Sprintf("Description: %q", description))
+		}
+		if expr := g.typeExpr(file, where, typeName); expr != "" {
+			fields = append(fields, fmt.Sprintf("Type: openapi.TypeOf[%s]()", expr))
+		}
+		return "", "&openapi.BodyDoc{" + strings.Join(fields, ", ") + "}"
+	}
+
+	fields := []string{fmt.Sprintf("Name: %q", name), fmt.Sprintf("In: %q", in)}
+	if description != "" {
+		fields = append(fields, fmt.Sprintf("Description: %q", description))
+	}
+	if required {
+		fields = append(fields, "Required: true")
+	}
+	if typeName != "file" {
+		if expr := g.typeExpr(file, where, typeName); expr != "" {
+			fields = append(fields, fmt.Sprintf("Type: openapi.TypeOf[%s]()", expr))
+		}
+	}
+	return "{" + strings.Join(fields, ", ") + "}", ""
+}
+
+// response 解析 @Success/@Failure code {kind} Type{data=X} "description"
+func (g *Generator) response(file *ast.File, where, text string) string {
+	m := responsePattern.FindStringSubmatch(text)
+	if m == nil {
+		g.warn(where, "invalid response annotation: "+text)
+		return ""
+	}
+	status, kind, typeName, description := m[1], m[2], m[3], m[4]
+
+	fields := []string{"Status: " + status}
+	if description != "" {
+		fields = append(fields, fmt.Sprintf("Description: %q", description))
+	}
+	if kind != "" {
+		fields = append(fields, fmt.Sprintf("Kind: %q", kind))
+	}
+
+	if kind == "object" || kind == "array" {
+		base, overrides := splitOverrides(typeName)
+		if envelopeTypes[base] {
+			fields = append(fields, "Envelope: true")
+			if data, ok := overrides["data"]; ok {
+				dataBase, dataOverrides := splitOverrides(data)
+				if expr := g.typeExpr(file, where, dataBase); expr != "" {
+					fields = append(fields, fmt.Sprintf("Data: openapi.TypeOf[%s]()", expr))
+				}
+				if dataFields := g.fieldsLiteral(file, where, dataOverrides); dataFields != "" {
+					fields = append(fields, "DataFields: "+dataFields)
+				}
+			}
+		} else if base != "ErrorResponse" {
+			if kind == "array" {
+				base = "[]" + base
+			}
+			if expr := g.typeExpr(file, where, base); expr != "" {
+				fields = append(fields, fmt.Sprintf("Type: openapi.TypeOf[%s]()", expr))
+			}
+		}
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// fieldsLiteral 字段类型覆盖，如 {list=[]dto.RecordResponse}
+func (g *Generator) fieldsLiteral(file *ast.File, where string, overrides map[string]string) string {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		if expr := g.typeExpr(file, where, overrides[name]); expr != "" {
+			items = append(items, fmt.Sprintf("%q: openapi.TypeOf[%s]()", name, expr))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return "openapi.Fields{" + strings.Join(items, ", ") + "}"
+}
+
+// typeExpr 将注释中的类型解析为生成文件中的 Go 类型表达式，无法解析时返回空
+func (g *Generator) typeExpr(file *ast.File, where, typeName string) string {
+	if goType, ok := primitiveTypes[typeName]; ok {
+		return goType
+	}
+	switch {
+	case strings.HasPrefix(typeName, "[]"):
+		if elem := g.typeExpr(file, where, typeName[2:]); elem != "" {
+			return "[]" + elem
+		}
+		return ""
+	case strings.HasPrefix(typeName, "map[string]"):
+		if elem := g.typeExpr(file, where, typeName[len("map[string]"):]); elem != "" {
+			return "map[string]" + elem
+		}
+		return ""
+	}
+
+	alias, name, qualified := strings.Cut(typeName, ".")
+	if !qualified {
+		if g.localTypes[typeName] {
+			return typeName
+		}
+		g.warn(where, "unknown type "+typeName)
+		return ""
+	}
+
+	importPath, ok := fileImports(file)[alias]
+	if !ok {
+		importPath, ok = g.pkgImports[alias]
+	}
+	if !ok {
+		importPath, ok = g.findPackage(alias, name)
+	}
+	if !ok || !g.packageHasType(importPath, name) {
+		g.warn(where, "unknown type "+typeName)
+		return ""
+	}
+	return g.importAlias(importPath) + "." + name
+}
+
+// packageHasType 检查模块内的包是否导出了该类型
+func (g *Generator) packageHasType(importPath, name string) bool {
+	names, ok := g.typeNames[importPath]
+	if !ok {
+		names = make(map[string]bool)
+		g.typeNames[importPath] = names
+
+		rel, inModule := strings.CutPrefix(importPath, g.modulePath+"/")
+		if !inModule {
+			return false
+		}
+		dir := filepath.Join(g.moduleDir, filepath.FromSlash(rel))
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return false
+		}
+		fset := token.NewFileSet()
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+				continue
+			}
+			file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+			if err != nil {
+				continue
+			}
+			for typeName := range declaredTypes(file, true) {
+				names[typeName] = true
+			}
+		}
+	}
+	return names[name]
+}
+
+// findPackage 注释中的包未在处理器包中导入时，在模块内按包目录名查找唯一导出该类型的包
+func (g *Generator) findPackage(alias, name string) (string, bool) {
+	var candidates []string
+	_ = filepath.WalkDir(g.moduleDir, func(dir string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		base := entry.Name()
+		if dir != g.moduleDir && (strings.HasPrefix(base, ".") || base == "vendor" || base == "testdata" || base == "node_modules") {
+			return filepath.SkipDir
+		}
+		if base != alias {
+			return nil
+		}
+		rel, err := filepath.Rel(g.moduleDir, dir)
+		if err != nil {
+			return nil
+		}
+		importPath := g.modulePath + "/" + filepath.ToSlash(rel)
+		if g.packageHasType(importPath, name) {
+			candidates = append(candidates, importPath)
+		}
+		return nil
+	})
+	if len(candidates) != 1 {
+		return "", false
+	}
+	return candidates[0], true
+}
+
+// importAlias 为生成文件分配导入别名
+func (g *Generator) importAlias(importPath string) string {
+	if alias, ok := g.imports[importPath]; ok {
+		return alias
+	}
+	base := strings.ReplaceAll(path.Base(importPath), "-", "")
+	alias := base
+	for i := 2; ; i++ {
+		if _, taken := g.aliases[alias]; !taken && alias != g.pkgName {
+			break
+		}
+		alias = base + strconv.Itoa(i)
+	}
+	g.imports[importPath] = alias
+	g.aliases[alias] = importPath
+	return alias
+}
+
+func (g *Generator) warn(where, message string) {
+	g.warnings = append(g.warnings, where+": "+message)
+}
+
+// handlerKey 处理器方法键 Receiver.Method，只接受 func (h *X) M(c *gin.Context)
+func handlerKey(fn *ast.FuncDecl) string {
+	receiver := receiverName(fn)
+	if receiver == "" || len(fn.Type.Params.List) != 1 || fn.Type.Results != nil {
+		return ""
+	}
+	star, ok := fn.Type.Params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Context" {
+		return ""
+	}
+	return receiver + "." + fn.Name.Name
+}
+
+// receiverName 方法接收者类型名
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// fileImports 文件导入：别名（默认为路径最后一段）-> 路径
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		alias := path.Base(importPath)
+		if spec.Name != nil {
+			alias = spec.Name.Name
+		}
+		if alias != "_" && alias != "." {
+			imports[alias] = importPath
+		}
+	}
+	return imports
+}
+
+// declaredTypes 文件中声明的非泛型类型名
+func declaredTypes(file *ast.File, exportedOnly bool) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.TypeParams != nil || (exportedOnly && !typeSpec.Name.IsExported()) {
+				continue
+			}
+			names[typeSpec.Name.Name] = true
+		}
+	}
+	return names
+}
+
+// splitOverrides 拆分 Type{data=X,success=boolean}
+func splitOverrides(typeName string) (string, map[string]string) {
+	open := strings.Index(typeName, "{")
+	if open < 0 || !strings.HasSuffix(typeName, "}") || !strings.Contains(typeName[open:], "=") {
+		return typeName, nil
+	}
+
+	overrides := make(map[string]string)
+	body := typeName[open+1 : len(typeName)-1]
+	depth, start := 0, 0
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) {
+			switch body[i] {
+			case '{', '[':
+				depth++
+				continue
+			case '}', ']':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if key, value, ok := strings.Cut(body[start:i], "="); ok {
+			overrides[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		start = i + 1
+	}
+	return typeName[:open], overrides
+}
+
+// mimeTypes 将 swag 的简写转换为 MIME 类型
+func mimeTypes(text string) []string {
+	short := map[string]string{
+		"json":                  "application/json",
+		"xml":                   "application/xml",
+		"plain":                 "text/plain",
+		"html":                  "text/html",
+		"mpfd":                  "multipart/form-data",
+		"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+		"octet-stream":          "application/octet-stream",
+	}
+	var result []string
+	for _, item := range strings.Split(text, ",") {
+		item = strings.TrimSpace(item)
+		if full, ok := short[item]; ok {
+			item = full
+		}
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func stringSlice(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// findModule 向上查找 go.mod，返回模块路径和目录
+func findModule(dir string) (string, string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		data, err := os.ReadFile(filepath.Join(current, "go.mod"))
+		if err == nil {
+			m := modulePattern.FindSubmatch(data)
+			if m == nil {
+				return "", "", fmt.Errorf("no module directive in %s", filepath.Join(current, "go.mod"))
+			}
+			return string(m[1]), current, nil
+		}
+		if parent := filepath.Dir(current); parent == current {
+			return "", "", fmt.Errorf("go.mod not found above %s", dir)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile 写入测试模块中的文件
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(root, "dto", "dto.go"), `package dto
+
+type ItemRequest struct{ Name string }
+
+type ItemResponse struct{ ID string }
+`)
+	writeFile(t, filepath.Join(root, "api", "handler.go"), `package api
+
+import (
+	"example.com/app/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type ItemHandler struct{}
+
+type localStats struct{ Count int }
+
+// CreateItem 创建条目
+// @Tags Item
+// @Accept json
+// @Param itemId path string true "Item ID"
+// @Param verbose query bool false "详细"
+// @Param request body dto.ItemRequest true "请求"
+// @Success 200 {object} response.APIResponse{data=[]dto.ItemResponse}
+// @Failure 404 {object} ErrorResponse
+// @Router /items/{itemId} [post]
+// @Security BearerAuth
+func (h *ItemHandler) CreateItem(c *gin.Context) {}
+
+// Stats 统计
+// @Summary 条目统计
+// @Success 200 {object} localStats
+// @Success 201 {object} missing.Type
+func (h *ItemHandler) Stats(c *gin.Context) {}
+
+// Page 分页
+// @Success 200 {object} Response{data=PageData{list=[]dto.ItemResponse}}
+func (h *ItemHandler) Page(c *gin.Context) {}
+
+type PageData struct{ List interface{} }
+
+// helper 不是处理器
+// @Summary ignored
+func (h *ItemHandler) helper(id string) {}
+`)
+
+	generator, err := NewGenerator(filepath.Join(root, "api"))
+	require.NoError(t, err)
+	source, err := generator.Generate("routeDocs")
+	require.NoError(t, err)
+	code := string(source)
+
+	assert.Contains(t, code, `dto "example.com/app/dto"`)
+	assert.Contains(t, code, `var routeDocs = map[string]*openapi.RouteDoc{`)
+
+	// 首行注释作为摘要，参数、请求体和统一响应结构
+	assert.Contains(t, code, `Summary: "创建条目"`)
+	assert.Contains(t, code, `{Name: "itemId", In: "path", Description: "Item ID", Required: true, Type: openapi.TypeOf[string]()}`)
+	assert.Contains(t, code, `{Name: "verbose", In: "query", Description: "详细", Type: openapi.TypeOf[bool]()}`)
+	assert.Contains(t, code, `Body:     &openapi.BodyDoc{Required: true, Description: "请求", Type: openapi.TypeOf[dto.ItemRequest]()}`)
+	assert.Contains(t, code, `{Status: 200, Kind: "object", Envelope: true, Data: openapi.TypeOf[[]dto.ItemResponse]()}`)
+	assert.Contains(t, code, `{Status: 404, Kind: "object"}`)
+	assert.Contains(t, code, `Security: true`)
+
+	// 包内类型、嵌套覆盖；无法解析的类型只产生警告
+	assert.Contains(t, code, `Summary: "条目统计"`)
+	assert.Contains(t, code, `Type: openapi.TypeOf[localStats]()`)
+	assert.Contains(t, code, `{Status: 201, Kind: "object"}`)
+	assert.Contains(t, code, `Data: openapi.TypeOf[PageData](), DataFields: openapi.Fields{"list": openapi.TypeOf[[]dto.ItemResponse]()}`)
+	assert.Equal(t, []string{"ItemHandler.Stats: unknown type missing.Type"}, generator.Warnings())
+
+	assert.NotContains(t, code, "helper")
+}
+
+func TestSplitOverrides(t *testing.T) {
+	base, overrides := splitOverrides("response.APIResponse{data=map[string]interface{},success=boolean}")
+	assert.Equal(t, "response.APIResponse", base)
+	assert.Equal(t, map[string]string{"data": "map[string]interface{}", "success": "boolean"}, overrides)
+
+	base, overrides = splitOverrides("map[string]interface{}")
+	assert.Equal(t, "map[string]interface{}", base)
+	assert.Nil(t, overrides)
+}
@@ -0,0 +1,333 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema JSON Schema（OpenAPI 3.1 使用 JSON Schema 2020-12）
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Examples             []interface{}      `json:"examples,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty"`
+	ContentMediaType     string             `json:"contentMediaType,omitempty"`
+}
+
+// SchemaType 类型，单个类型输出为字符串，多个类型（如可空）输出为数组
+type SchemaType []string
+
+// TypeNames 构造 SchemaType
+func TypeNames(names ...string) SchemaType {
+	return SchemaType(names)
+}
+
+// MarshalJSON 单个类型输出为字符串
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Ref 组件引用
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// Float 返回指针，用于 Minimum/Maximum
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Int 返回指针，用于长度和数量约束
+func Int(v int) *int {
+	return &v
+}
+
+// TypeOf 返回 T 的反射类型
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+var (
+	timeType       = TypeOf[time.Time]()
+	rawMessageType = TypeOf[json.RawMessage]()
+	marshalerType  = TypeOf[json.Marshaler]()
+)
+
+// invalidNameChars 组件名只允许字母、数字、点、横线和下划线
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// SchemaRegistry 将 Go 类型转换为 JSON Schema，具名结构体注册为组件
+type SchemaRegistry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+// NewSchemaRegistry 创建注册表，组件写入 components
+func NewSchemaRegistry(components map[string]*Schema) *SchemaRegistry {
+	return &SchemaRegistry{
+		components: components,
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// Schema 返回类型的 Schema，具名结构体返回组件引用
+func (r *SchemaRegistry) Schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: TypeNames("string"), Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: TypeNames("boolean")}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: TypeNames("integer")}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: TypeNames("integer"), Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: TypeNames("integer"), Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: TypeNames("number"), Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: TypeNames("number"), Format: "double"}
+	case reflect.String:
+		return &Schema{Type: TypeNames("string")}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: TypeNames("string"), Format: "byte"}
+		}
+		return &Schema{Type: TypeNames("array"), Items: r.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: TypeNames("object"), AdditionalProperties: r.Schema(t.Elem())}
+	case reflect.Struct:
+		// 自定义序列化的结构体无法从字段推断
+		if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+			return &Schema{}
+		}
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return Ref(r.register(t))
+	default:
+		// interface{}、func 等不约束
+		return &Schema{}
+	}
+}
+
+// register 注册具名结构体组件，返回组件名
+func (r *SchemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	base := invalidNameChars.ReplaceAllString(path.Base(t.PkgPath())+"."+t.Name(), "_")
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := r.components[name]; !taken {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+
+	// 先占位，防止递归类型无限展开
+	r.names[t] = name
+	r.components[name] = &Schema{}
+	*r.components[name] = *r.structSchema(t)
+	return name
+}
+
+// structSchema 结构体字段 Schema，嵌入结构体的字段展开到外层
+func (r *SchemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: TypeNames("object"), Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema
+}
+
+func (r *SchemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				r.addFields(schema, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.Schema(fieldType)
+		rules := parseBinding(field.Tag.Get("binding"))
+		if rules.required {
+			schema.Required = append(schema.Required, name)
+		}
+		if rules.constrains() {
+			property = rules.apply(property, fieldType)
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			property = withDescription(property, description)
+		}
+		if field.Type.Kind() == reflect.Pointer && !omitEmpty && property.Ref == "" && len(property.Type) == 1 {
+			property.Type = append(property.Type, "null")
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// jsonName 解析 json 标签
+func jsonName(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" || option == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+// withDescription 引用不能与兄弟关键字混用时包一层 allOf
+func withDescription(schema *Schema, description string) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Description: description}
+	}
+	schema.Description = description
+	return schema
+}
+
+// bindingRules gin binding 标签中与文档相关的规则
+type bindingRules struct {
+	required bool
+	min, max *float64
+	oneOf    []string
+	format   string
+}
+
+func parseBinding(tag string) bindingRules {
+	var rules bindingRules
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch key {
+		case "required":
+			rules.required = true
+		case "min", "gte":
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				rules.min = &v
+			}
+		case "max", "lte":
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				rules.max = &v
+			}
+		case "oneof":
+			rules.oneOf = strings.Fields(value)
+		case "email":
+			rules.format = "email"
+		case "url":
+			rules.format = "uri"
+		case "uuid":
+			rules.format = "uuid"
+		}
+	}
+	return rules
+}
+
+func (b bindingRules) constrains() bool {
+	return b.min != nil || b.max != nil || len(b.oneOf) > 0 || b.format != ""
+}
+
+// apply 将约束应用到 Schema：字符串为长度，数组为元素个数，数字为取值范围
+func (b bindingRules) apply(schema *Schema, t reflect.Type) *Schema {
+	if schema.Ref != "" {
+		return schema
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if b.min != nil {
+			schema.MinLength = Int(int(*b.min))
+		}
+		if b.max != nil {
+			schema.MaxLength = Int(int(*b.max))
+		}
+		for _, value := range b.oneOf {
+			schema.Enum = append(schema.Enum, value)
+		}
+		if b.format != "" {
+			schema.Format = b.format
+		}
+	case reflect.Slice, reflect.Array:
+		if b.min != nil {
+			schema.MinItems = Int(int(*b.min))
+		}
+		if b.max != nil {
+			schema.MaxItems = Int(int(*b.max))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		schema.Minimum = b.min
+		schema.Maximum = b.max
+		for _, value := range b.oneOf {
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				schema.Enum = append(schema.Enum, v)
+			}
+		}
+	}
+	return schema
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaBase struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type schemaNode struct {
+	schemaBase
+	Name     string            `json:"name" binding:"required,min=1,max=50"`
+	Kind     string            `json:"kind" binding:"omitempty,oneof=a b"`
+	Limit    int               `json:"limit" binding:"min=1,max=200"`
+	Tags     []string          `json:"tags" binding:"max=10"`
+	Parent   *schemaNode       `json:"parent,omitempty"`
+	Note     *string           `json:"note"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Payload  interface{}       `json:"payload"`
+	Raw      []byte            `json:"raw"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestSchemaRegistry_Struct(t *testing.T) {
+	components := make(map[string]*Schema)
+	registry := NewSchemaRegistry(components)
+
+	ref := registry.Schema(TypeOf[*schemaNode]())
+	assert.Equal(t, "#/components/schemas/openapi.schemaNode", ref.Ref)
+
+	node := components["openapi.schemaNode"]
+	require.NotNil(t, node)
+	assert.Equal(t, []string{"name"}, node.Required)
+
+	// 嵌入结构体展开
+	assert.Equal(t, "date-time", node.Properties["createdAt"].Format)
+	assert.Contains(t, node.Properties, "id")
+
+	// binding 约束
+	assert.Equal(t, 1, *node.Properties["name"].MinLength)
+	assert.Equal(t, 50, *node.Properties["name"].MaxLength)
+	assert.Equal(t, []interface{}{"a", "b"}, node.Properties["kind"].Enum)
+	assert.Equal(t, 1.0, *node.Properties["limit"].Minimum)
+	assert.Equal(t, 10, *node.Properties["tags"].MaxItems)
+
+	// 递归引用、可空指针、map、任意值和字节
+	assert.Equal(t, ref.Ref, node.Properties["parent"].Ref)
+	assert.Equal(t, SchemaType{"string", "null"}, node.Properties["note"].Type)
+	assert.Equal(t, SchemaType{"string"}, node.Properties["labels"].AdditionalProperties.Type)
+	assert.Empty(t, node.Properties["payload"].Type)
+	assert.Equal(t, "byte", node.Properties["raw"].Format)
+
+	assert.NotContains(t, node.Properties, "Ignored")
+	assert.NotContains(t, node.Properties, "internal")
+}
+
+func TestSchemaType_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(&Schema{Type: TypeNames("string")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"string"}`, string(data))
+
+	data, err = json.Marshal(&Schema{Type: TypeNames("string", "null")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":["string","null"]}`, string(data))
+}
@@ -17,6 +17,12 @@ type Pagination struct {
 	TotalPages int `json:"total_pages"`
 }
 
+// PaginatedData 分页响应的 data 结构
+type PaginatedData struct {
+	List       interface{} `json:"list"`
+	Pagination Pagination  `json:"pagination"`
+}
+
 // --- 新版统一响应结构（数字码） ---
 
 // APIResponse 统一响应结构（V2）
@@ -67,9 +73,9 @@ func PaginatedSuccess(c *gin.Context, list interface{}, pagination Pagination, m
 	c.JSON(http.StatusOK, APIResponse{
 		Code:    errors.CodeOK,
 		Message: message,
-		Data: gin.H{
-			"list":       list,
-			"pagination": pagination,
+		Data: PaginatedData{
+			List:       list,
+			Pagination: pagination,
 		},
 		RequestID:  reqID,
 		Timestamp:  ts,
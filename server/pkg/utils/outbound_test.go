@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1", "::ffff:127.0.0.1"} {
+		assert.False(t, IsPublicIP(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"8.8.8.8", "203.0.113.7", "2001:4860:4860::8888"} {
+		assert.True(t, IsPublicIP(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestValidateOutboundURL(t *testing.T) {
+	assert.NoError(t, ValidateOutboundURL("https://hooks.example.com/path"))
+	assert.NoError(t, ValidateOutboundURL("http://8.8.8.8:8080/"))
+
+	for _, raw := range []string{
+		"ftp://example.com",
+		"http://",
+		"http://localhost:8080/",
+		"http://api.localhost/",
+		"http://127.0.0.1/",
+		"http://[::1]/",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/internal",
+	} {
+		assert.Error(t, ValidateOutboundURL(raw), raw)
+	}
+}
+
+func TestNewOutboundHTTPClient_RejectsLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := NewOutboundHTTPClient(time.Second).Get(server.URL)
+	assert.ErrorContains(t, err, "non-public address")
+}